@@ -5,6 +5,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/models"
 )
 
 type MetricMaker interface {
@@ -17,6 +18,9 @@ type accumulator struct {
 	maker     MetricMaker
 	metrics   chan<- telegraf.Metric
 	precision time.Duration
+
+	// Runtime statistics of inputs accounting the metrics not yet passed on
+	runtimeStats *models.RuntimeStats
 }
 
 func NewAccumulator(
@@ -28,6 +32,9 @@ func NewAccumulator(
 		metrics:   metrics,
 		precision: time.Nanosecond,
 	}
+	if input, ok := maker.(*models.RunningInput); ok {
+		acc.runtimeStats = input.RuntimeStats
+	}
 	return &acc
 }
 
@@ -80,6 +87,7 @@ func (ac *accumulator) AddMetric(m telegraf.Metric) {
 	m.SetTime(m.Time().Round(ac.precision))
 	if m := ac.maker.MakeMetric(m); m != nil {
 		ac.metrics <- m
+		ac.runtimeStats.AddInFlight(-1)
 	}
 }
 
@@ -93,6 +101,7 @@ func (ac *accumulator) addMeasurement(
 	m := metric.New(measurement, tags, fields, ac.getTime(t), tp)
 	if m := ac.maker.MakeMetric(m); m != nil {
 		ac.metrics <- m
+		ac.runtimeStats.AddInFlight(-1)
 	}
 }
 
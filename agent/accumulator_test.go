@@ -11,6 +11,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/logger"
+	"github.com/influxdata/telegraf/models"
 	"github.com/influxdata/telegraf/testutil"
 )
 
@@ -140,6 +141,20 @@ func TestAddTrackingMetricGroupEmpty(t *testing.T) {
 	}
 }
 
+func TestAccumulatorRuntimeStats(t *testing.T) {
+	input := models.NewRunningInput(&reloadInput{}, &models.InputConfig{Name: "acc_runtime", RuntimeStats: true})
+
+	metrics := make(chan telegraf.Metric, 10)
+	defer close(metrics)
+	a := NewAccumulator(input, metrics)
+
+	// Metrics passed on to the agent are no longer in flight
+	a.AddFields("acctest", map[string]interface{}{"value": 42}, nil)
+	a.AddMetric(testutil.TestMetric(1))
+	require.Len(t, metrics, 2)
+	require.Zero(t, input.RuntimeStats.MetricsInFlight.Get())
+}
+
 type TestMetricMaker struct {
 }
 
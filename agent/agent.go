@@ -10,6 +10,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/benbjohnson/clock"
+
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/internal"
@@ -22,6 +24,10 @@ import (
 // Agent runs a set of plugins.
 type Agent struct {
 	Config *config.Config
+
+	// Units of the running agent required to apply configuration changes
+	units     *runningUnits
+	unitsLock sync.Mutex
 }
 
 // NewAgent returns an Agent for the given Config.
@@ -46,6 +52,12 @@ func NewAgent(cfg *config.Config) *Agent {
 type inputUnit struct {
 	dst    chan<- telegraf.Metric
 	inputs []*models.RunningInput
+
+	// Gather loops of the running inputs, used to stop single inputs
+	sync.Mutex
+	loops   map[*models.RunningInput]*pluginLoop
+	wg      sync.WaitGroup
+	stopped bool
 }
 
 //  ______     ┌───────────┐     ______
@@ -97,6 +109,32 @@ type aggregatorUnit struct {
 type outputUnit struct {
 	src     <-chan telegraf.Metric
 	outputs []*models.RunningOutput
+
+	// Flush loops of the running outputs, used to stop single outputs
+	sync.RWMutex
+	ctx     context.Context
+	loops   map[*models.RunningOutput]*pluginLoop
+	wg      sync.WaitGroup
+	stopped bool
+
+	// Number of metrics passed to the outputs and a channel closed as soon as
+	// the target number of metrics is reached, used in --once mode. Only
+	// modified by runOutputs and read after the outputs finished.
+	received int64
+	target   int64
+	reached  chan struct{}
+}
+
+// pluginLoop is the gather or flush loop of a single plugin
+type pluginLoop struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// stop terminates the loop and waits for it to finish
+func (l *pluginLoop) stop() {
+	l.cancel()
+	<-l.done
 }
 
 // Run starts and runs the Agent until the context is done.
@@ -146,9 +184,11 @@ func (a *Agent) Run(ctx context.Context) error {
 		next, au = a.startAggregators(aggC, next, a.Config.Aggregators)
 	}
 
-	var pu []*processorUnit
-	if len(a.Config.Processors) != 0 {
-		next, pu, err = a.startProcessors(next, a.Config.Processors)
+	// Without processors the inputs write to the next unit directly, so
+	// adding processors requires a restart.
+	var pc *processorChain
+	if len(a.Config.Processors) > 0 {
+		next, pc, err = a.startProcessorChain(next, a.Config.Processors)
 		if err != nil {
 			return err
 		}
@@ -159,6 +199,16 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
+	a.unitsLock.Lock()
+	a.units = &runningUnits{
+		ctx:        ctx,
+		startTime:  startTime,
+		inputs:     iu,
+		processors: pc,
+		outputs:    ou,
+	}
+	a.unitsLock.Unlock()
+
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -180,11 +230,11 @@ func (a *Agent) Run(ctx context.Context) error {
 		}()
 	}
 
-	if pu != nil {
+	if pc != nil {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			a.runProcessors(pu)
+			a.runProcessorChain(pc)
 		}()
 	}
 
@@ -196,6 +246,10 @@ func (a *Agent) Run(ctx context.Context) error {
 
 	wg.Wait()
 
+	a.unitsLock.Lock()
+	a.units = nil
+	a.unitsLock.Unlock()
+
 	if a.Config.Persister != nil {
 		log.Printf("D! [agent] Persisting plugin states")
 		if err := a.Config.Persister.Store(); err != nil {
@@ -339,22 +393,7 @@ func (a *Agent) startInputs(
 	}
 
 	for _, input := range inputs {
-		// Service input plugins are not normally subject to timestamp
-		// rounding except for when precision is set on the input plugin.
-		//
-		// This only applies to the accumulator passed to Start(), the
-		// Gather() accumulator does apply rounding according to the
-		// precision and interval agent/plugin settings.
-		var interval time.Duration
-		var precision time.Duration
-		if input.Config.Precision != 0 {
-			precision = input.Config.Precision
-		}
-
-		acc := NewAccumulator(input, dst)
-		acc.SetPrecision(getPrecision(precision, interval))
-
-		if err := input.Start(acc); err != nil {
+		if err := startInput(dst, input); err != nil {
 			// If the model tells us to remove the plugin we do so without error
 			var fatalErr *internal.FatalError
 			if errors.As(err, &fatalErr) {
@@ -372,6 +411,26 @@ func (a *Agent) startInputs(
 	return unit, nil
 }
 
+// startInput calls Start on the input writing to the given channel.
+func startInput(dst chan<- telegraf.Metric, input *models.RunningInput) error {
+	// Service input plugins are not normally subject to timestamp
+	// rounding except for when precision is set on the input plugin.
+	//
+	// This only applies to the accumulator passed to Start(), the
+	// Gather() accumulator does apply rounding according to the
+	// precision and interval agent/plugin settings.
+	var interval time.Duration
+	var precision time.Duration
+	if input.Config.Precision != 0 {
+		precision = input.Config.Precision
+	}
+
+	acc := NewAccumulator(input, dst)
+	acc.SetPrecision(getPrecision(precision, interval))
+
+	return input.Start(acc)
+}
+
 // runInputs starts and triggers the periodic gather for Inputs.
 //
 // When the context is done the timers are stopped and this function returns
@@ -381,58 +440,80 @@ func (a *Agent) runInputs(
 	startTime time.Time,
 	unit *inputUnit,
 ) {
-	var wg sync.WaitGroup
-	tickers := make([]Ticker, 0, len(unit.inputs))
+	unit.Lock()
+	unit.loops = make(map[*models.RunningInput]*pluginLoop, len(unit.inputs))
 	for _, input := range unit.inputs {
-		// Overwrite agent interval if this plugin has its own.
-		interval := time.Duration(a.Config.Agent.Interval)
-		if input.Config.Interval != 0 {
-			interval = input.Config.Interval
-		}
+		a.startGatherLoop(ctx, startTime, unit, input)
+	}
+	unit.Unlock()
 
-		// Overwrite agent precision if this plugin has its own.
-		precision := time.Duration(a.Config.Agent.Precision)
-		if input.Config.Precision != 0 {
-			precision = input.Config.Precision
-		}
+	<-ctx.Done()
 
-		// Overwrite agent collection_jitter if this plugin has its own.
-		jitter := time.Duration(a.Config.Agent.CollectionJitter)
-		if input.Config.CollectionJitter != 0 {
-			jitter = input.Config.CollectionJitter
-		}
+	unit.Lock()
+	unit.stopped = true
+	unit.Unlock()
+	unit.wg.Wait()
 
-		// Overwrite agent collection_offset if this plugin has its own.
-		offset := time.Duration(a.Config.Agent.CollectionOffset)
-		if input.Config.CollectionOffset != 0 {
-			offset = input.Config.CollectionOffset
-		}
+	log.Printf("D! [agent] Stopping service inputs")
+	stopRunningInputs(unit.inputs)
 
-		var ticker Ticker
-		if a.Config.Agent.RoundInterval {
-			ticker = NewAlignedTicker(startTime, interval, jitter, offset)
-		} else {
-			ticker = NewUnalignedTicker(interval, jitter, offset)
-		}
-		tickers = append(tickers, ticker)
+	close(unit.dst)
+	log.Printf("D! [agent] Input channel closed")
+}
 
-		acc := NewAccumulator(input, unit.dst)
-		acc.SetPrecision(getPrecision(precision, interval))
+// startGatherLoop starts the periodic gather for the given input. The unit
+// must be locked by the caller.
+func (a *Agent) startGatherLoop(
+	ctx context.Context,
+	startTime time.Time,
+	unit *inputUnit,
+	input *models.RunningInput,
+) {
+	// Overwrite agent interval if this plugin has its own.
+	interval := time.Duration(a.Config.Agent.Interval)
+	if input.Config.Interval != 0 {
+		interval = input.Config.Interval
+	}
 
-		wg.Add(1)
-		go func(input *models.RunningInput) {
-			defer wg.Done()
-			a.gatherLoop(ctx, acc, input, ticker, interval)
-		}(input)
+	// Overwrite agent precision if this plugin has its own.
+	precision := time.Duration(a.Config.Agent.Precision)
+	if input.Config.Precision != 0 {
+		precision = input.Config.Precision
 	}
-	defer stopTickers(tickers)
-	wg.Wait()
 
-	log.Printf("D! [agent] Stopping service inputs")
-	stopRunningInputs(unit.inputs)
+	// Overwrite agent collection_jitter if this plugin has its own.
+	jitter := time.Duration(a.Config.Agent.CollectionJitter)
+	if input.Config.CollectionJitter != 0 {
+		jitter = input.Config.CollectionJitter
+	}
 
-	close(unit.dst)
-	log.Printf("D! [agent] Input channel closed")
+	// Overwrite agent collection_offset if this plugin has its own.
+	offset := time.Duration(a.Config.Agent.CollectionOffset)
+	if input.Config.CollectionOffset != 0 {
+		offset = input.Config.CollectionOffset
+	}
+
+	var ticker Ticker
+	if a.Config.Agent.RoundInterval {
+		ticker = NewAlignedTicker(startTime, interval, jitter, offset)
+	} else {
+		ticker = NewUnalignedTicker(interval, jitter, offset)
+	}
+
+	acc := NewAccumulator(input, unit.dst)
+	acc.SetPrecision(getPrecision(precision, interval))
+
+	loopCtx, cancel := context.WithCancel(ctx)
+	loop := &pluginLoop{cancel: cancel, done: make(chan struct{})}
+	unit.loops[input] = loop
+
+	unit.wg.Add(1)
+	go func() {
+		defer unit.wg.Done()
+		defer close(loop.done)
+		defer ticker.Stop()
+		a.gatherLoop(loopCtx, acc, input, ticker, interval)
+	}()
 }
 
 // testStartInputs is a variation of startInputs for use in --test and --once
@@ -469,9 +550,12 @@ func (a *Agent) testStartInputs(
 
 // testRunInputs is a variation of runInputs for use in --test and --once mode.
 // Instead of using a ticker to run the inputs they are called once immediately.
+// Afterwards the service inputs run for the wait duration or until the done
+// channel is closed.
 func (a *Agent) testRunInputs(
 	ctx context.Context,
 	wait time.Duration,
+	done <-chan struct{},
 	unit *inputUnit,
 ) {
 	var wg sync.WaitGroup
@@ -523,9 +607,15 @@ func (a *Agent) testRunInputs(
 	}
 	wg.Wait()
 
-	if err := internal.SleepContext(ctx, wait); err != nil {
-		log.Printf("E! [agent] SleepContext finished with: %v", err)
+	timer := time.NewTimer(wait)
+	select {
+	case <-timer.C:
+	case <-done:
+		log.Printf("D! [agent] Received the expected number of metrics")
+	case <-ctx.Done():
+		log.Printf("E! [agent] SleepContext finished with: %v", ctx.Err())
 	}
+	timer.Stop()
 
 	log.Printf("D! [agent] Stopping service inputs")
 	stopRunningInputs(unit.inputs)
@@ -831,43 +921,25 @@ func (a *Agent) connectOutput(ctx context.Context, output *models.RunningOutput)
 
 // runOutputs begins processing metrics and returns until the source channel is
 // closed and all metrics have been written.  On shutdown metrics will be
-// written one last time and dropped if unsuccessful.
+// written one last time, retried as configured and dropped if unsuccessful.
+// As the source is only closed after all inputs are stopped, no new metrics
+// arrive during this final flush.
 func (a *Agent) runOutputs(
 	unit *outputUnit,
 ) {
-	var wg sync.WaitGroup
-
-	// Start flush loop
-	interval := time.Duration(a.Config.Agent.FlushInterval)
-	jitter := time.Duration(a.Config.Agent.FlushJitter)
-
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Start flush loop
+	unit.Lock()
+	unit.ctx = ctx
+	unit.loops = make(map[*models.RunningOutput]*pluginLoop, len(unit.outputs))
 	for _, output := range unit.outputs {
-		interval := interval
-		// Overwrite agent flush_interval if this plugin has its own.
-		if output.Config.FlushInterval != 0 {
-			interval = output.Config.FlushInterval
-		}
-
-		jitter := jitter
-		// Overwrite agent flush_jitter if this plugin has its own.
-		if output.Config.FlushJitter != 0 {
-			jitter = output.Config.FlushJitter
-		}
-
-		wg.Add(1)
-		go func(output *models.RunningOutput) {
-			defer wg.Done()
-
-			ticker := NewRollingTicker(interval, jitter)
-			defer ticker.Stop()
-
-			a.flushLoop(ctx, output, ticker)
-		}(output)
+		a.startFlushLoop(unit, output)
 	}
+	unit.Unlock()
 
 	for metric := range unit.src {
+		unit.RLock()
 		for i, output := range unit.outputs {
 			if i == len(unit.outputs)-1 {
 				output.AddMetricNoCopy(metric)
@@ -875,16 +947,79 @@ func (a *Agent) runOutputs(
 				output.AddMetric(metric)
 			}
 		}
+		unit.RUnlock()
+
+		unit.received++
+		if unit.reached != nil && unit.received == unit.target {
+			close(unit.reached)
+		}
 	}
 
 	log.Println("I! [agent] Hang on, flushing any cached metrics before shutdown")
+	unit.Lock()
+	unit.stopped = true
+	unit.Unlock()
 	cancel()
-	wg.Wait()
+	unit.wg.Wait()
 
 	log.Println("I! [agent] Stopping running outputs")
 	stopRunningOutputs(unit.outputs)
 }
 
+// startFlushLoop starts the periodic flush for the given output. The unit
+// must be locked by the caller.
+func (a *Agent) startFlushLoop(unit *outputUnit, output *models.RunningOutput) {
+	// Overwrite agent flush_interval if this plugin has its own.
+	interval := time.Duration(a.Config.Agent.FlushInterval)
+	if output.Config.FlushInterval != 0 {
+		interval = output.Config.FlushInterval
+	}
+
+	// Overwrite agent flush_jitter if this plugin has its own.
+	jitter := time.Duration(a.Config.Agent.FlushJitter)
+	if output.Config.FlushJitter != 0 {
+		jitter = output.Config.FlushJitter
+	}
+
+	loopCtx, cancel := context.WithCancel(unit.ctx)
+	loop := &pluginLoop{cancel: cancel, done: make(chan struct{})}
+	unit.loops[output] = loop
+
+	unit.wg.Add(1)
+	go func() {
+		defer unit.wg.Done()
+		defer close(loop.done)
+
+		ticker := newFlushTicker(time.Now(), interval, jitter, output.Config.FlushAlignment, clock.New())
+		defer ticker.Stop()
+
+		a.flushLoop(loopCtx, output, ticker)
+	}()
+}
+
+// newFlushTicker returns the ticker for flushing an output. With alignment,
+// flushes happen at wall-clock boundaries of the interval plus the jitter and
+// the first flush waits for the next boundary. Otherwise, the first flush
+// happens after the interval plus the jitter.
+func newFlushTicker(now time.Time, interval, jitter time.Duration, aligned bool, clk clock.Clock) Ticker {
+	if aligned {
+		ticker := &AlignedTicker{
+			interval:    interval,
+			jitter:      jitter,
+			minInterval: interval / 100,
+		}
+		ticker.start(now, clk)
+		return ticker
+	}
+
+	ticker := &RollingTicker{
+		interval: interval,
+		jitter:   jitter,
+	}
+	ticker.start(clk)
+	return ticker
+}
+
 // flushLoop runs an output's flush function periodically until the context is
 // done.
 func (a *Agent) flushLoop(
@@ -907,14 +1042,14 @@ func (a *Agent) flushLoop(
 		// Favor shutdown over other methods.
 		select {
 		case <-ctx.Done():
-			logError(a.flushOnce(output, ticker, output.Write))
+			logError(a.shutdownFlush(output, ticker))
 			return
 		default:
 		}
 
 		select {
 		case <-ctx.Done():
-			logError(a.flushOnce(output, ticker, output.Write))
+			logError(a.shutdownFlush(output, ticker))
 			return
 		case <-ticker.Elapsed():
 			logError(a.flushOnce(output, ticker, output.Write))
@@ -951,6 +1086,48 @@ func (a *Agent) flushOnce(
 	}
 }
 
+const (
+	shutdownFlushBackoff    = 100 * time.Millisecond
+	shutdownFlushMaxBackoff = 2 * time.Second
+)
+
+// shutdownFlush runs the final flush of an output. Failed writes are retried
+// with an increasing backoff until the configured number of retries is used
+// up or the shutdown flush timeout elapsed. Metrics still left in a memory
+// buffer afterwards are lost and reported.
+func (a *Agent) shutdownFlush(output *models.RunningOutput, ticker Ticker) error {
+	retries := a.Config.Agent.ShutdownFlushRetries
+	timeout := time.Duration(a.Config.Agent.ShutdownFlushTimeout)
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	err := a.flushOnce(output, ticker, output.Write)
+	backoff := shutdownFlushBackoff
+	for attempt := 0; err != nil && attempt < retries; attempt++ {
+		wait := backoff
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				break
+			}
+			wait = min(wait, remaining)
+		}
+		log.Printf("E! [agent] Error writing to %s: %v, retrying in %s", output.LogName(), err, wait.Round(time.Millisecond))
+		time.Sleep(wait)
+		backoff = min(2*backoff, shutdownFlushMaxBackoff)
+
+		err = a.flushOnce(output, ticker, output.Write)
+	}
+
+	if n := output.BufferLength(); n > 0 && output.Config.BufferStrategy != "disk" {
+		log.Printf("E! [agent] Abandoning %d metrics of %s not written before shutdown", n, output.LogName())
+	}
+	return err
+}
+
 // flushBatch runs the output's Write function once Unlike flushOnce the
 // interval elapsing is not considered during these flushes.
 func (a *Agent) flushBatch(
@@ -1059,7 +1236,7 @@ func (a *Agent) runTest(ctx context.Context, wait time.Duration, outputC chan<-
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		a.testRunInputs(ctx, wait, iu)
+		a.testRunInputs(ctx, wait, nil, iu)
 	}()
 
 	wg.Wait()
@@ -1071,11 +1248,31 @@ func (a *Agent) runTest(ctx context.Context, wait time.Duration, outputC chan<-
 
 // Once runs the full agent for a single gather.
 func (a *Agent) Once(ctx context.Context, wait time.Duration) error {
-	err := a.runOnce(ctx, wait)
+	if _, err := a.runOnce(ctx, wait, 0); err != nil {
+		return err
+	}
+	return a.checkOnce()
+}
+
+// OnceTimeout runs the full agent for a single gather like Once, but keeps the
+// service inputs running for up to the given timeout to deliver metrics. If
+// minMetrics is positive, waiting stops as soon as that many metrics reached
+// the outputs. An error is returned if fewer metrics, or no metrics at all,
+// were collected.
+func (a *Agent) OnceTimeout(ctx context.Context, timeout time.Duration, minMetrics int) error {
+	received, err := a.runOnce(ctx, timeout, int64(minMetrics))
 	if err != nil {
 		return err
 	}
 
+	if expected := int64(max(minMetrics, 1)); received < expected {
+		return fmt.Errorf("collected %d metrics but expected at least %d within %s", received, expected, timeout)
+	}
+	return a.checkOnce()
+}
+
+// checkOnce checks for errors of inputs and outputs after running once
+func (a *Agent) checkOnce() error {
 	if models.GlobalGatherErrors.Get() != 0 {
 		return fmt.Errorf("input plugins recorded %d errors", models.GlobalGatherErrors.Get())
 	}
@@ -1092,11 +1289,12 @@ func (a *Agent) Once(ctx context.Context, wait time.Duration) error {
 
 // runOnce runs the agent and performs a single gather sending output to the
 // outputC. After gathering pauses for the wait duration to allow service
-// inputs to run.
-func (a *Agent) runOnce(ctx context.Context, wait time.Duration) error {
+// inputs to run, or until the given number of metrics reached the outputs if
+// positive. Returns the number of metrics passed to the outputs.
+func (a *Agent) runOnce(ctx context.Context, wait time.Duration, minMetrics int64) (int64, error) {
 	log.Printf("D! [agent] Initializing plugins")
 	if err := a.InitPlugins(); err != nil {
-		return err
+		return 0, err
 	}
 
 	startTime := time.Now()
@@ -1104,7 +1302,11 @@ func (a *Agent) runOnce(ctx context.Context, wait time.Duration) error {
 	log.Printf("D! [agent] Connecting outputs")
 	next, ou, err := a.startOutputs(ctx, a.Config.Outputs)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if minMetrics > 0 {
+		ou.target = minMetrics
+		ou.reached = make(chan struct{})
 	}
 
 	var apu []*processorUnit
@@ -1114,7 +1316,7 @@ func (a *Agent) runOnce(ctx context.Context, wait time.Duration) error {
 		if len(a.Config.AggProcessors) != 0 && !a.Config.Agent.SkipProcessorsAfterAggregators {
 			procC, apu, err = a.startProcessors(next, a.Config.AggProcessors)
 			if err != nil {
-				return err
+				return 0, err
 			}
 		}
 
@@ -1125,7 +1327,7 @@ func (a *Agent) runOnce(ctx context.Context, wait time.Duration) error {
 	if len(a.Config.Processors) != 0 {
 		next, pu, err = a.startProcessors(next, a.Config.Processors)
 		if err != nil {
-			return err
+			return 0, err
 		}
 	}
 
@@ -1163,14 +1365,14 @@ func (a *Agent) runOnce(ctx context.Context, wait time.Duration) error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		a.testRunInputs(ctx, wait, iu)
+		a.testRunInputs(ctx, wait, ou.reached, iu)
 	}()
 
 	wg.Wait()
 
 	log.Printf("D! [agent] Stopped Successfully")
 
-	return nil
+	return ou.received, nil
 }
 
 // Returns the rounding precision for metrics.
@@ -1204,9 +1406,3 @@ func panicRecover(input *models.RunningInput) {
 			"https://github.com/influxdata/telegraf/issues/new/choose")
 	}
 }
-
-func stopTickers(tickers []Ticker) {
-	for _, ticker := range tickers {
-		ticker.Stop()
-	}
-}
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/models"
 	_ "github.com/influxdata/telegraf/plugins/aggregators/all"
+	"github.com/influxdata/telegraf/plugins/inputs"
 	_ "github.com/influxdata/telegraf/plugins/inputs/all"
 	_ "github.com/influxdata/telegraf/plugins/outputs/all"
 	"github.com/influxdata/telegraf/plugins/parsers/influx"
@@ -257,3 +260,207 @@ func collect(ctx context.Context, a *Agent, wait time.Duration) ([]telegraf.Metr
 	}
 	return received, nil
 }
+
+func TestShutdownFlush(t *testing.T) {
+	tests := []struct {
+		name      string
+		retries   int
+		timeout   time.Duration
+		failures  int
+		expectErr bool
+		remaining int
+	}{
+		{
+			name:      "single attempt by default",
+			failures:  1,
+			expectErr: true,
+			remaining: 3,
+		},
+		{
+			name:     "success after retries",
+			retries:  3,
+			failures: 2,
+		},
+		{
+			name:      "retries exhausted",
+			retries:   2,
+			failures:  5,
+			expectErr: true,
+			remaining: 3,
+		},
+		{
+			name:      "timeout elapsed",
+			retries:   100,
+			timeout:   250 * time.Millisecond,
+			failures:  100,
+			expectErr: true,
+			remaining: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := config.NewConfig()
+			c.Agent.ShutdownFlushRetries = tt.retries
+			c.Agent.ShutdownFlushTimeout = config.Duration(tt.timeout)
+			a := NewAgent(c)
+
+			plugin := &failingOutput{failures: tt.failures}
+			output := models.NewRunningOutput(plugin, &models.OutputConfig{Name: "shutdown"}, 10, 100)
+			for i := range 3 {
+				output.AddMetric(testutil.TestMetric(i))
+			}
+
+			ticker := newFlushTicker(time.Now(), time.Hour, 0, false, clock.New())
+			defer ticker.Stop()
+
+			start := time.Now()
+			err := a.shutdownFlush(output, ticker)
+			if tt.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.remaining, output.BufferLength())
+			if tt.timeout > 0 {
+				require.Less(t, time.Since(start), tt.timeout+shutdownFlushMaxBackoff)
+			}
+		})
+	}
+}
+
+// failingOutput fails the given number of writes before accepting metrics
+type failingOutput struct {
+	failures int
+	written  []telegraf.Metric
+}
+
+func (*failingOutput) SampleConfig() string {
+	return ""
+}
+
+func (*failingOutput) Connect() error {
+	return nil
+}
+
+func (*failingOutput) Close() error {
+	return nil
+}
+
+func (o *failingOutput) Write(metrics []telegraf.Metric) error {
+	if o.failures > 0 {
+		o.failures--
+		return errors.New("output unreachable")
+	}
+	o.written = append(o.written, metrics...)
+	return nil
+}
+
+func TestOnceTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		count       int
+		minMetrics  int
+		timeout     time.Duration
+		expectedErr string
+	}{
+		{
+			name:       "stop after minimum metrics",
+			count:      3,
+			minMetrics: 3,
+			timeout:    time.Minute,
+		},
+		{
+			name:    "wait for timeout",
+			count:   2,
+			timeout: 200 * time.Millisecond,
+		},
+		{
+			name:        "no metrics",
+			timeout:     100 * time.Millisecond,
+			expectedErr: "collected 0 metrics but expected at least 1",
+		},
+		{
+			name:        "too few metrics",
+			count:       1,
+			minMetrics:  5,
+			timeout:     200 * time.Millisecond,
+			expectedErr: "collected 1 metrics but expected at least 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewConfig()
+			require.NoError(t, cfg.LoadConfigData([]byte(fmt.Sprintf(`
+[agent]
+  omit_hostname = true
+
+[[inputs.oncetest]]
+  count = %d
+
+[[outputs.reloadtest]]
+`, tt.count))))
+			a := NewAgent(cfg)
+
+			start := time.Now()
+			err := a.OnceTimeout(context.Background(), tt.timeout, tt.minMetrics)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Less(t, time.Since(start), tt.timeout+5*time.Second)
+
+			// Metrics must be flushed to the output before exiting
+			output := cfg.Outputs[0].Output.(*reloadOutput)
+			output.Lock()
+			defer output.Unlock()
+			require.Len(t, output.metrics, tt.count)
+		})
+	}
+}
+
+// onceInput is a service input delivering a number of metrics after being
+// started
+type onceInput struct {
+	Count int `toml:"count"`
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+func (*onceInput) SampleConfig() string {
+	return ""
+}
+
+func (i *onceInput) Start(acc telegraf.Accumulator) error {
+	i.done = make(chan struct{})
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+		for n := range i.Count {
+			select {
+			case <-i.done:
+				return
+			case <-time.After(20 * time.Millisecond):
+				acc.AddFields("once", map[string]interface{}{"value": n}, nil)
+			}
+		}
+	}()
+	return nil
+}
+
+func (i *onceInput) Stop() {
+	close(i.done)
+	i.wg.Wait()
+}
+
+func (*onceInput) Gather(telegraf.Accumulator) error {
+	return nil
+}
+
+func init() {
+	inputs.Add("oncetest", func() telegraf.Input {
+		return &onceInput{}
+	})
+}
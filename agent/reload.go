@@ -0,0 +1,526 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/internal"
+	"github.com/influxdata/telegraf/internal/snmp"
+	"github.com/influxdata/telegraf/models"
+)
+
+// ErrRestartRequired is returned by Reload if the configuration changes cannot
+// be applied to the running agent.
+var ErrRestartRequired = errors.New("configuration changes require a restart")
+
+// runningUnits holds the units of a running agent.
+type runningUnits struct {
+	ctx        context.Context
+	startTime  time.Time
+	inputs     *inputUnit
+	processors *processorChain
+	outputs    *outputUnit
+}
+
+// Reload applies the changes of the given configuration to the running agent.
+// Inputs, processors and outputs with an unchanged configuration keep running
+// including their state and buffers, while changed or removed plugins are
+// stopped and new plugins are started.
+// ErrRestartRequired is returned if other settings changed or the agent is not
+// running. In this case, or if any other error occurs before applying the
+// changes, the plugins of the given configuration are released and the
+// configuration must be loaded again.
+func (a *Agent) Reload(cfg *config.Config) error {
+	a.unitsLock.Lock()
+	defer a.unitsLock.Unlock()
+
+	if a.units == nil || a.units.ctx.Err() != nil {
+		releaseOutputs(cfg.Outputs)
+		return ErrRestartRequired
+	}
+
+	changes := config.Diff(a.Config, cfg)
+	if changes.RestartReason == "" && changes.ProcessorsChanged && a.units.processors == nil {
+		changes.RestartReason = "processors added to agent running without processors"
+	}
+	if changes.RestartReason != "" {
+		log.Printf("I! [agent] Cannot apply configuration changes: %s", changes.RestartReason)
+		releaseOutputs(cfg.Outputs)
+		return ErrRestartRequired
+	}
+	releaseOutputs(changes.UnusedOutputs)
+
+	if changes.Empty() {
+		log.Printf("I! [agent] No plugin changes found in configuration")
+		return nil
+	}
+
+	if err := a.initAddedPlugins(changes); err != nil {
+		releaseOutputs(changes.AddedOutputs)
+		return err
+	}
+
+	// Apply the changes from the outputs to the inputs, so metrics of new
+	// inputs are passed through the updated processors and outputs.
+	log.Printf("I! [agent] Updating plugins: %d inputs, %d processors and %d outputs added, "+
+		"%d inputs, %d processors and %d outputs removed",
+		len(changes.AddedInputs), len(changes.AddedProcessors), len(changes.AddedOutputs),
+		len(changes.RemovedInputs), len(changes.RemovedProcessors), len(changes.RemovedOutputs))
+	if err := a.replaceOutputs(a.units.ctx, a.units.outputs, changes.RemovedOutputs, changes.AddedOutputs); err != nil {
+		return err
+	}
+	a.Config.Outputs = changes.Outputs
+
+	if changes.ProcessorsChanged {
+		if err := a.replaceProcessors(a.units.processors, changes.Processors); err != nil {
+			return err
+		}
+		a.Config.Processors = changes.Processors
+	}
+	// Processors after the aggregators are only used in combination with
+	// aggregators, which would require a restart on changes.
+	if len(a.Config.Aggregators) == 0 {
+		a.Config.AggProcessors = cfg.AggProcessors
+	}
+
+	if err := a.replaceInputs(a.units.ctx, a.units.startTime, a.units.inputs, changes.RemovedInputs, changes.AddedInputs); err != nil {
+		return err
+	}
+	a.Config.Inputs = changes.Inputs
+
+	return nil
+}
+
+// initAddedPlugins runs the Init function on the new plugins.
+func (a *Agent) initAddedPlugins(changes *config.Changes) error {
+	for _, input := range changes.AddedInputs {
+		// Share the snmp translator setting with plugins that need it.
+		if tp, ok := input.Input.(snmp.TranslatorPlugin); ok {
+			tp.SetTranslator(a.Config.Agent.SnmpTranslator)
+		}
+		if err := input.Init(); err != nil {
+			return fmt.Errorf("could not initialize input %s: %w", input.LogName(), err)
+		}
+	}
+	for _, processor := range changes.AddedProcessors {
+		if err := processor.Init(); err != nil {
+			return fmt.Errorf("could not initialize processor %s: %w", processor.LogName(), err)
+		}
+	}
+	for _, output := range changes.AddedOutputs {
+		if err := output.Init(); err != nil {
+			return fmt.Errorf("could not initialize output %s: %w", output.LogName(), err)
+		}
+	}
+	return nil
+}
+
+// releaseOutputs frees the resources of outputs that never got connected.
+func releaseOutputs(outputs []*models.RunningOutput) {
+	for _, output := range outputs {
+		output.CloseBuffer()
+	}
+}
+
+// replaceInputs stops the removed inputs and starts the added ones.
+func (a *Agent) replaceInputs(
+	ctx context.Context,
+	startTime time.Time,
+	unit *inputUnit,
+	removed, added []*models.RunningInput,
+) error {
+	unit.Lock()
+	defer unit.Unlock()
+
+	if unit.stopped {
+		return errors.New("inputs already stopped")
+	}
+
+	// Stop the removed inputs first to free resources such as listening
+	// ports potentially used by the new inputs.
+	for _, input := range removed {
+		if loop, found := unit.loops[input]; found {
+			loop.stop()
+			delete(unit.loops, input)
+		}
+		input.Stop()
+		log.Printf("D! [agent] Removed input %s", input.LogName())
+	}
+	unit.inputs = slices.DeleteFunc(unit.inputs, func(input *models.RunningInput) bool {
+		return slices.Contains(removed, input)
+	})
+
+	for _, input := range added {
+		if err := startInput(unit.dst, input); err != nil {
+			// If the model tells us to remove the plugin we do so without error
+			var fatalErr *internal.FatalError
+			if errors.As(err, &fatalErr) {
+				log.Printf("I! [agent] Failed to start %s, shutting down plugin: %s", input.LogName(), err)
+				continue
+			}
+			return fmt.Errorf("starting input %s: %w", input.LogName(), err)
+		}
+		unit.inputs = append(unit.inputs, input)
+
+		// Gather loops are started by runInputs if not yet running
+		if unit.loops != nil {
+			a.startGatherLoop(ctx, startTime, unit, input)
+		}
+		log.Printf("D! [agent] Added input %s", input.LogName())
+	}
+
+	return nil
+}
+
+// replaceOutputs stops the removed outputs and connects the added ones. The
+// outputs are only swapped while holding the lock, flushing the removed and
+// connecting the added outputs is done without blocking the other outputs.
+// Metrics arriving in between are not passed to the added outputs.
+func (a *Agent) replaceOutputs(
+	ctx context.Context,
+	unit *outputUnit,
+	removed, added []*models.RunningOutput,
+) error {
+	unit.Lock()
+	if unit.stopped {
+		unit.Unlock()
+		releaseOutputs(added)
+		return errors.New("outputs already stopped")
+	}
+	loops := make(map[*models.RunningOutput]*pluginLoop, len(removed))
+	for _, output := range removed {
+		if loop, found := unit.loops[output]; found {
+			loops[output] = loop
+			delete(unit.loops, output)
+		}
+	}
+	unit.outputs = slices.DeleteFunc(unit.outputs, func(output *models.RunningOutput) bool {
+		return slices.Contains(removed, output)
+	})
+	unit.Unlock()
+
+	// Stop the removed outputs first to free resources such as listening
+	// ports or disk buffers potentially used by the new outputs. Stopping the
+	// flush loop writes the buffered metrics one last time.
+	for _, output := range removed {
+		if loop, found := loops[output]; found {
+			loop.stop()
+		}
+		output.Close()
+		log.Printf("D! [agent] Removed output %s", output.LogName())
+	}
+
+	for i, output := range added {
+		if err := output.OpenBuffer(); err != nil {
+			releaseOutputs(added[i+1:])
+			return fmt.Errorf("creating buffer of output %s: %w", output.LogName(), err)
+		}
+		if err := a.connectOutput(ctx, output); err != nil {
+			var fatalErr *internal.FatalError
+			if errors.As(err, &fatalErr) {
+				// If the model tells us to remove the plugin we do so without error
+				log.Printf("I! [agent] Failed to connect to [%s], error was %q;  shutting down plugin...", output.LogName(), err)
+				output.Close()
+				continue
+			}
+			output.Close()
+			releaseOutputs(added[i+1:])
+			return fmt.Errorf("connecting output %s: %w", output.LogName(), err)
+		}
+
+		unit.Lock()
+		if unit.stopped {
+			unit.Unlock()
+			output.Close()
+			releaseOutputs(added[i+1:])
+			return errors.New("outputs already stopped")
+		}
+		unit.outputs = append(unit.outputs, output)
+
+		// Flush loops are started by runOutputs if not yet running
+		if unit.loops != nil {
+			a.startFlushLoop(unit, output)
+		}
+		unit.Unlock()
+		log.Printf("D! [agent] Added output %s", output.LogName())
+	}
+
+	return nil
+}
+
+// processorChain is the chain of processors between the inputs and the
+// aggregators or outputs. In contrast to processor units, the processors of
+// the chain can be replaced while running. Each processor owns its output
+// channel for its whole lifetime, so streaming processors can emit metrics
+// independent of the chain being rebuilt. The last stage forwards the metrics
+// to the destination channel.
+//
+//	 ______     ┌───────────┐     ______     ┌───────────┐     ______     ┌─────────┐     ______
+//	()_____)──▶ │ Processor │──▶ ()_____)──▶ │ Processor │──▶ ()_____)──▶ │ Forward │──▶ ()_____)
+//	            └───────────┘                └───────────┘                └─────────┘
+type processorChain struct {
+	src <-chan telegraf.Metric
+	dst chan<- telegraf.Metric
+
+	sync.Mutex
+	processors []*chainProcessor
+	stages     []*chainStage
+	stopped    bool
+
+	// done is closed after the destination channel was closed
+	done chan struct{}
+}
+
+type chainProcessor struct {
+	processor *models.RunningProcessor
+	out       chan telegraf.Metric
+	acc       telegraf.Accumulator
+}
+
+// chainStage reads metrics from its source and passes them to the processor,
+// or forwards them to the chain's destination if no processor is set.
+type chainStage struct {
+	src       <-chan telegraf.Metric
+	processor *chainProcessor
+	// pending metrics handled before reading from the source, e.g. metrics
+	// emitted by removed processors
+	pending []telegraf.Metric
+	pause   chan struct{}
+	// done receives true if the stage finished due to the source channel
+	// being closed and false if the stage was paused.
+	done chan bool
+}
+
+// startProcessorChain calls Start on all processors and starts handing over
+// metrics. If an error occurs any started processors are Stopped.
+func (a *Agent) startProcessorChain(
+	dst chan<- telegraf.Metric,
+	processors models.RunningProcessors,
+) (chan<- telegraf.Metric, *processorChain, error) {
+	src := make(chan telegraf.Metric, 100)
+	chain := &processorChain{
+		src:        src,
+		dst:        dst,
+		processors: make([]*chainProcessor, 0, len(processors)),
+		done:       make(chan struct{}),
+	}
+
+	for _, processor := range processors {
+		p, err := startChainProcessor(processor)
+		if err != nil {
+			for _, started := range chain.processors {
+				started.stop()
+			}
+			return nil, nil, err
+		}
+		chain.processors = append(chain.processors, p)
+	}
+
+	chain.startStages(nil)
+
+	return src, chain, nil
+}
+
+func startChainProcessor(processor *models.RunningProcessor) (*chainProcessor, error) {
+	out := make(chan telegraf.Metric, 100)
+	p := &chainProcessor{
+		processor: processor,
+		out:       out,
+		acc:       NewAccumulator(processor, out),
+	}
+	if err := processor.Start(p.acc); err != nil {
+		return nil, fmt.Errorf("starting processor %s: %w", processor.LogName(), err)
+	}
+	return p, nil
+}
+
+// stop stops the processor and closes its output channel.
+func (p *chainProcessor) stop() {
+	p.processor.Stop()
+	close(p.out)
+}
+
+// runProcessorChain waits until the source channel is closed and all metrics
+// have been passed to the destination.
+func (a *Agent) runProcessorChain(chain *processorChain) {
+	<-chain.done
+	log.Printf("D! [agent] Processor channel closed")
+}
+
+// startStages connects the processors and starts handing over metrics. The
+// pending metrics are passed to the given processors first, where the nil key
+// denotes the destination. The chain must be locked by the caller.
+func (c *processorChain) startStages(pending map[*chainProcessor][]telegraf.Metric) {
+	c.stages = make([]*chainStage, 0, len(c.processors)+1)
+	src := c.src
+	for _, p := range c.processors {
+		c.stages = append(c.stages, &chainStage{
+			src:       src,
+			processor: p,
+			pending:   pending[p],
+			pause:     make(chan struct{}),
+			done:      make(chan bool, 1),
+		})
+		src = p.out
+	}
+	c.stages = append(c.stages, &chainStage{
+		src:     src,
+		pending: pending[nil],
+		pause:   make(chan struct{}),
+		done:    make(chan bool, 1),
+	})
+
+	for _, stage := range c.stages {
+		go func(stage *chainStage) {
+			closed := c.runStage(stage)
+			if closed {
+				if stage.processor != nil {
+					stage.processor.stop()
+				} else {
+					close(c.dst)
+					close(c.done)
+				}
+			}
+			stage.done <- closed
+		}(stage)
+	}
+}
+
+// runStage handles the metrics of the stage until its source channel is
+// closed or the stage is paused. When pausing, all metrics available in the
+// source channel are handled before returning.
+func (c *processorChain) runStage(stage *chainStage) bool {
+	handle := func(m telegraf.Metric) {
+		if stage.processor == nil {
+			c.dst <- m
+			return
+		}
+		if err := stage.processor.processor.Add(m, stage.processor.acc); err != nil {
+			stage.processor.acc.AddError(err)
+			m.Drop()
+		}
+	}
+
+	for _, m := range stage.pending {
+		handle(m)
+	}
+	stage.pending = nil
+
+	for {
+		select {
+		case m, ok := <-stage.src:
+			if !ok {
+				return true
+			}
+			handle(m)
+		case <-stage.pause:
+			for {
+				select {
+				case m, ok := <-stage.src:
+					if !ok {
+						return true
+					}
+					handle(m)
+				default:
+					return false
+				}
+			}
+		}
+	}
+}
+
+// replaceProcessors rebuilds the processor chain with the given processors.
+// The running processors not contained in the list are stopped, new ones are
+// started. Metrics are held back while rebuilding the chain.
+func (a *Agent) replaceProcessors(chain *processorChain, processors models.RunningProcessors) error {
+	chain.Lock()
+	defer chain.Unlock()
+
+	if chain.stopped {
+		return errors.New("processors already stopped")
+	}
+
+	// Pause the stages from the source to the destination, so all metrics in
+	// flight are passed on before rebuilding the chain.
+	for _, stage := range chain.stages {
+		close(stage.pause)
+		if closed := <-stage.done; closed {
+			// The agent is shutting down, the remaining stages will finish
+			// as their source channels are closed.
+			chain.stopped = true
+			return errors.New("processors already stopped")
+		}
+	}
+
+	running := make(map[*models.RunningProcessor]*chainProcessor, len(chain.processors))
+	for _, p := range chain.processors {
+		running[p.processor] = p
+	}
+
+	updated := make([]*chainProcessor, 0, len(processors))
+	var started []*chainProcessor
+	for _, processor := range processors {
+		if p, found := running[processor]; found {
+			updated = append(updated, p)
+			delete(running, processor)
+			continue
+		}
+
+		p, err := startChainProcessor(processor)
+		if err != nil {
+			for _, s := range started {
+				s.stop()
+			}
+			chain.startStages(nil)
+			return err
+		}
+		started = append(started, p)
+		updated = append(updated, p)
+		log.Printf("D! [agent] Added processor %s", processor.LogName())
+	}
+
+	// Stop the removed processors and pass on the metrics emitted by those
+	// processors while stopping to the next remaining processor of the chain.
+	kept := make(map[*chainProcessor]bool, len(updated))
+	for _, p := range updated {
+		kept[p] = true
+	}
+	pending := make(map[*chainProcessor][]telegraf.Metric)
+	for i, p := range chain.processors {
+		if _, removed := running[p.processor]; !removed {
+			continue
+		}
+
+		var next *chainProcessor
+		for _, candidate := range chain.processors[i+1:] {
+			if kept[candidate] {
+				next = candidate
+				break
+			}
+		}
+
+		drained := make(chan []telegraf.Metric)
+		go func() {
+			var metrics []telegraf.Metric
+			for m := range p.out {
+				metrics = append(metrics, m)
+			}
+			drained <- metrics
+		}()
+		p.stop()
+		pending[next] = append(pending[next], <-drained...)
+		log.Printf("D! [agent] Removed processor %s", p.processor.LogName())
+	}
+
+	chain.processors = updated
+	chain.startStages(pending)
+
+	return nil
+}
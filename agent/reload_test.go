@@ -0,0 +1,389 @@
+package agent
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/influxdata/telegraf/plugins/outputs"
+	"github.com/influxdata/telegraf/plugins/processors"
+)
+
+const reloadBaseConfig = `
+[agent]
+  interval = "10ms"
+  flush_interval = "10ms"
+  round_interval = false
+  omit_hostname = true
+
+[[inputs.reloadtest]]
+  name = "a"
+
+[[inputs.reloadtest]]
+  name = "b"
+
+[[processors.reloadtest]]
+  tag = "first"
+
+[[outputs.reloadtest]]
+  id = "1"
+`
+
+func TestReload(t *testing.T) {
+	tests := []struct {
+		name           string
+		updated        string
+		expected       string
+		tags           []string
+		keptProcessors int
+	}{
+		{
+			name:           "input added",
+			updated:        reloadBaseConfig + "\n[[inputs.reloadtest]]\n  name = \"c\"\n",
+			expected:       "c",
+			tags:           []string{"first"},
+			keptProcessors: 1,
+		},
+		{
+			name:           "input removed",
+			updated:        strings.Replace(reloadBaseConfig, "[[inputs.reloadtest]]\n  name = \"b\"\n", "", 1),
+			expected:       "a",
+			tags:           []string{"first"},
+			keptProcessors: 1,
+		},
+		{
+			name:           "input modified",
+			updated:        strings.Replace(reloadBaseConfig, `name = "b"`, `name = "c"`, 1),
+			expected:       "c",
+			tags:           []string{"first"},
+			keptProcessors: 1,
+		},
+		{
+			name:           "processor added",
+			updated:        reloadBaseConfig + "\n[[processors.reloadtest]]\n  tag = \"second\"\n",
+			expected:       "a",
+			tags:           []string{"first", "second"},
+			keptProcessors: 1,
+		},
+		{
+			name:     "processor removed",
+			updated:  strings.Replace(reloadBaseConfig, "[[processors.reloadtest]]\n  tag = \"first\"\n", "", 1),
+			expected: "a",
+		},
+		{
+			name:     "processor modified",
+			updated:  strings.Replace(reloadBaseConfig, `tag = "first"`, `tag = "second"`, 1),
+			expected: "b",
+			tags:     []string{"second"},
+		},
+		{
+			name:           "output added",
+			updated:        reloadBaseConfig + "\n[[outputs.reloadtest]]\n  id = \"2\"\n",
+			expected:       "a",
+			tags:           []string{"first"},
+			keptProcessors: 1,
+		},
+		{
+			name:           "output modified",
+			updated:        strings.Replace(reloadBaseConfig, `id = "1"`, `id = "2"`, 1),
+			expected:       "b",
+			tags:           []string{"first"},
+			keptProcessors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := runReloadAgent(t, reloadBaseConfig)
+			initial := a.Config.Outputs[0].Output.(*reloadOutput)
+			waitForMetric(t, initial, "b", []string{"first"})
+
+			// Remember the running plugins
+			inputsBefore := slices.Clone(a.Config.Inputs)
+			processorsBefore := slices.Clone(a.Config.Processors)
+			outputsBefore := slices.Clone(a.Config.Outputs)
+
+			cfg := config.NewConfig()
+			require.NoError(t, cfg.LoadConfigData([]byte(tt.updated)))
+			require.NoError(t, a.Reload(cfg))
+			require.Len(t, a.Config.Inputs, len(cfg.Inputs))
+			require.Len(t, a.Config.Processors, len(cfg.Processors))
+			require.Len(t, a.Config.Outputs, len(cfg.Outputs))
+
+			// Unchanged plugins must keep running while others are stopped
+			for _, input := range inputsBefore {
+				plugin := input.Input.(*reloadInput)
+				require.Equal(t, !slices.Contains(a.Config.Inputs, input), plugin.stopped.Load(), input.LogName())
+			}
+			for _, input := range a.Config.Inputs {
+				plugin := input.Input.(*reloadInput)
+				require.True(t, plugin.started.Load(), input.LogName())
+				require.False(t, plugin.stopped.Load(), input.LogName())
+			}
+			for _, output := range outputsBefore {
+				plugin := output.Output.(*reloadOutput)
+				require.Equal(t, !slices.Contains(a.Config.Outputs, output), plugin.closed.Load(), output.LogName())
+			}
+			for _, output := range a.Config.Outputs {
+				plugin := output.Output.(*reloadOutput)
+				require.True(t, plugin.connected.Load(), output.LogName())
+				require.False(t, plugin.closed.Load(), output.LogName())
+			}
+			var kept int
+			for _, processor := range processorsBefore {
+				if slices.Contains(a.Config.Processors, processor) {
+					kept++
+				}
+			}
+			require.Equal(t, tt.keptProcessors, kept)
+
+			// Check that the metrics pass the updated plugins
+			for _, output := range a.Config.Outputs {
+				waitForMetric(t, output.Output.(*reloadOutput), tt.expected, tt.tags)
+			}
+		})
+	}
+}
+
+func TestReloadRestartRequired(t *testing.T) {
+	tests := []struct {
+		name    string
+		updated string
+	}{
+		{
+			name:    "agent settings changed",
+			updated: strings.Replace(reloadBaseConfig, `interval = "10ms"`, `interval = "20ms"`, 1),
+		},
+		{
+			name:    "global tags changed",
+			updated: reloadBaseConfig + "\n[global_tags]\n  dc = \"eu-west\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := runReloadAgent(t, reloadBaseConfig)
+			inputsBefore := slices.Clone(a.Config.Inputs)
+
+			cfg := config.NewConfig()
+			require.NoError(t, cfg.LoadConfigData([]byte(tt.updated)))
+			require.ErrorIs(t, a.Reload(cfg), ErrRestartRequired)
+
+			// Nothing must be changed
+			require.Equal(t, inputsBefore, a.Config.Inputs)
+			for _, input := range a.Config.Inputs {
+				require.False(t, input.Input.(*reloadInput).stopped.Load())
+			}
+		})
+	}
+}
+
+func TestReloadProcessorsAddedWithoutChain(t *testing.T) {
+	base := strings.Replace(reloadBaseConfig, "[[processors.reloadtest]]\n  tag = \"first\"\n", "", 1)
+	a := runReloadAgent(t, base)
+
+	cfg := config.NewConfig()
+	require.NoError(t, cfg.LoadConfigData([]byte(reloadBaseConfig)))
+	require.ErrorIs(t, a.Reload(cfg), ErrRestartRequired)
+}
+
+func TestReloadRemovedProcessorDrainsDownstream(t *testing.T) {
+	base := reloadBaseConfig + `
+[[processors.reloadstream]]
+  order = 1
+
+[[processors.reloadtest]]
+  order = 2
+  tag = "second"
+`
+	a := runReloadAgent(t, base)
+	output := a.Config.Outputs[0].Output.(*reloadOutput)
+	waitForMetric(t, output, "a", []string{"first", "second"})
+
+	// Metrics emitted by the removed processor on stop must still pass the
+	// processors following it
+	updated := strings.Replace(base, "[[processors.reloadstream]]\n  order = 1\n", "", 1)
+	cfg := config.NewConfig()
+	require.NoError(t, cfg.LoadConfigData([]byte(updated)))
+	require.NoError(t, a.Reload(cfg))
+	waitForMetric(t, output, "drained", []string{"second"})
+}
+
+func TestReloadNotRunning(t *testing.T) {
+	cfg := config.NewConfig()
+	require.NoError(t, cfg.LoadConfigData([]byte(reloadBaseConfig)))
+	a := NewAgent(cfg)
+
+	updated := config.NewConfig()
+	require.NoError(t, updated.LoadConfigData([]byte(reloadBaseConfig)))
+	require.ErrorIs(t, a.Reload(updated), ErrRestartRequired)
+}
+
+// runReloadAgent runs an agent with the given configuration until the
+// test finished.
+func runReloadAgent(t *testing.T, data string) *Agent {
+	t.Helper()
+
+	cfg := config.NewConfig()
+	require.NoError(t, cfg.LoadConfigData([]byte(data)))
+	a := NewAgent(cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- a.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	require.Eventually(t, func() bool {
+		a.unitsLock.Lock()
+		defer a.unitsLock.Unlock()
+		return a.units != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return a
+}
+
+// waitForMetric waits until the output received a metric of the given name
+// with exactly the given tags.
+func waitForMetric(t *testing.T, output *reloadOutput, name string, tags []string) {
+	t.Helper()
+
+	expected := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		expected[tag] = "true"
+	}
+
+	require.Eventuallyf(t, func() bool {
+		output.Lock()
+		defer output.Unlock()
+		return slices.ContainsFunc(output.metrics, func(m telegraf.Metric) bool {
+			return m.Name() == name && maps.Equal(m.Tags(), expected)
+		})
+	}, 5*time.Second, 10*time.Millisecond, "metric %q with tags %v not received", name, tags)
+}
+
+// reloadInput is a service input used to check that inputs are started and
+// stopped as expected
+type reloadInput struct {
+	Name    string `toml:"name"`
+	started atomic.Bool
+	stopped atomic.Bool
+}
+
+func (*reloadInput) SampleConfig() string {
+	return ""
+}
+
+func (i *reloadInput) Start(telegraf.Accumulator) error {
+	i.started.Store(true)
+	return nil
+}
+
+func (i *reloadInput) Stop() {
+	i.stopped.Store(true)
+}
+
+func (i *reloadInput) Gather(acc telegraf.Accumulator) error {
+	acc.AddFields(i.Name, map[string]interface{}{"value": 42}, nil)
+	return nil
+}
+
+// reloadProcessor tags the metrics passing the processor
+type reloadProcessor struct {
+	Tag string `toml:"tag"`
+}
+
+func (*reloadProcessor) SampleConfig() string {
+	return ""
+}
+
+func (p *reloadProcessor) Apply(in ...telegraf.Metric) []telegraf.Metric {
+	for _, m := range in {
+		m.AddTag(p.Tag, "true")
+	}
+	return in
+}
+
+// reloadStreamProcessor passes metrics through and emits an additional
+// metric when being stopped
+type reloadStreamProcessor struct {
+	acc telegraf.Accumulator
+}
+
+func (*reloadStreamProcessor) SampleConfig() string {
+	return ""
+}
+
+func (p *reloadStreamProcessor) Start(acc telegraf.Accumulator) error {
+	p.acc = acc
+	return nil
+}
+
+func (*reloadStreamProcessor) Add(m telegraf.Metric, acc telegraf.Accumulator) error {
+	acc.AddMetric(m)
+	return nil
+}
+
+func (p *reloadStreamProcessor) Stop() {
+	p.acc.AddFields("drained", map[string]interface{}{"value": 42}, nil)
+}
+
+// reloadOutput records the written metrics
+type reloadOutput struct {
+	ID        string `toml:"id"`
+	connected atomic.Bool
+	closed    atomic.Bool
+
+	sync.Mutex
+	metrics []telegraf.Metric
+}
+
+func (*reloadOutput) SampleConfig() string {
+	return ""
+}
+
+func (o *reloadOutput) Connect() error {
+	o.connected.Store(true)
+	return nil
+}
+
+func (o *reloadOutput) Close() error {
+	o.closed.Store(true)
+	return nil
+}
+
+func (o *reloadOutput) Write(metrics []telegraf.Metric) error {
+	o.Lock()
+	defer o.Unlock()
+	o.metrics = append(o.metrics, metrics...)
+	return nil
+}
+
+func init() {
+	inputs.Add("reloadtest", func() telegraf.Input {
+		return &reloadInput{}
+	})
+	processors.Add("reloadtest", func() telegraf.Processor {
+		return &reloadProcessor{}
+	})
+	processors.AddStreaming("reloadstream", func() telegraf.StreamingProcessor {
+		return &reloadStreamProcessor{}
+	})
+	outputs.Add("reloadtest", func() telegraf.Output {
+		return &reloadOutput{}
+	})
+}
@@ -226,6 +226,73 @@ func TestRollingTicker(t *testing.T) {
 	require.Equal(t, expected, actual)
 }
 
+func TestFlushTicker(t *testing.T) {
+	tests := []struct {
+		name       string
+		aligned    bool
+		jitter     time.Duration
+		boundaries []time.Time
+	}{
+		{
+			name:    "unaligned",
+			aligned: false,
+			boundaries: []time.Time{
+				time.Unix(13, 0).UTC(),
+				time.Unix(23, 0).UTC(),
+				time.Unix(33, 0).UTC(),
+			},
+		},
+		{
+			name:    "aligned",
+			aligned: true,
+			boundaries: []time.Time{
+				time.Unix(10, 0).UTC(),
+				time.Unix(20, 0).UTC(),
+				time.Unix(30, 0).UTC(),
+			},
+		},
+		{
+			name:    "aligned with jitter",
+			aligned: true,
+			jitter:  2 * time.Second,
+			boundaries: []time.Time{
+				time.Unix(10, 0).UTC(),
+				time.Unix(20, 0).UTC(),
+				time.Unix(30, 0).UTC(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Start in the middle of the interval
+			clk := clock.NewMock()
+			clk.Add(3 * time.Second)
+			until := clk.Now().Add(35 * time.Second)
+
+			ticker := newFlushTicker(clk.Now(), 10*time.Second, tt.jitter, tt.aligned, clk)
+			defer ticker.Stop()
+
+			actual := make([]time.Time, 0, len(tt.boundaries))
+			for !clk.Now().After(until) {
+				select {
+				case tm := <-ticker.Elapsed():
+					actual = append(actual, tm.UTC())
+				default:
+					clk.Add(100 * time.Millisecond)
+				}
+			}
+
+			// The jitter is applied after the alignment
+			require.Len(t, actual, len(tt.boundaries))
+			for i, tm := range actual {
+				require.False(t, tm.Before(tt.boundaries[i]), "tick %v before %v", tm, tt.boundaries[i])
+				require.LessOrEqual(t, tm.Sub(tt.boundaries[i]), tt.jitter, "tick %v", tm)
+			}
+		})
+	}
+}
+
 // Simulates running the Ticker for an hour and displays stats about the
 // operation.
 func TestAlignedTickerDistribution(t *testing.T) {
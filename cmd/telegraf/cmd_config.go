@@ -80,6 +80,7 @@ func getConfigCommands(configHandlingFlags []cli.Flag, outputBuffer io.Writer) [
 						// Load the config and try to initialize the plugins
 						c := config.NewConfig()
 						c.Agent.Quiet = cCtx.Bool("quiet")
+						c.Agent.StrictParsing = cCtx.Bool("strict")
 						if err := c.LoadAll(configFiles...); err != nil {
 							return err
 						}
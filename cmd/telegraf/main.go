@@ -133,6 +133,10 @@ func runApp(args []string, outputBuffer io.Writer, pprof Server, c TelegrafConfi
 			Name:  "secretstore-filter",
 			Usage: "filter the secret-stores to enable, separator is ':'",
 		},
+		&cli.BoolFlag{
+			Name:  "strict",
+			Usage: "reject unknown or misplaced options in the configuration",
+		},
 	}
 
 	mainFlags := append(configHandlingFlags, cliFlags()...)
@@ -238,7 +242,10 @@ func runApp(args []string, outputBuffer io.Writer, pprof Server, c TelegrafConfi
 			test:                   cCtx.Bool("test"),
 			debug:                  cCtx.Bool("debug"),
 			once:                   cCtx.Bool("once"),
+			onceTimeout:            cCtx.Duration("once-timeout"),
+			onceMinMetrics:         cCtx.Int("once-min-metrics"),
 			quiet:                  cCtx.Bool("quiet"),
+			strict:                 cCtx.Bool("strict"),
 			unprotected:            cCtx.Bool("unprotected"),
 		}
 
@@ -273,6 +280,11 @@ func runApp(args []string, outputBuffer io.Writer, pprof Server, c TelegrafConfi
 					Name:  "test-wait",
 					Usage: "wait up to this many seconds for service inputs to complete in test mode",
 				},
+				&cli.IntFlag{
+					Name: "once-min-metrics",
+					Usage: "stop waiting in --once-timeout mode as soon as this many metrics reached the outputs " +
+						"and fail if fewer metrics were collected",
+				},
 				&cli.IntFlag{
 					Name: "config-url-retry-attempts",
 					Usage: "Number of attempts to obtain a remote configuration via a URL during startup. " +
@@ -331,6 +343,12 @@ func runApp(args []string, outputBuffer io.Writer, pprof Server, c TelegrafConfi
 				},
 				//
 				// Duration flags
+				&cli.DurationFlag{
+					Name: "once-timeout",
+					Usage: "run one gather like --once but keep service inputs running for up to this duration " +
+						"and fail if no metrics were collected",
+					DefaultText: "disabled",
+				},
 				&cli.DurationFlag{
 					Name: "watch-interval",
 					Usage: "Time duration to check for updates to config files specified by --config and " +
@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -48,7 +49,10 @@ type GlobalFlags struct {
 	test                   bool
 	debug                  bool
 	once                   bool
+	onceTimeout            time.Duration
+	onceMinMetrics         int
 	quiet                  bool
+	strict                 bool
 	unprotected            bool
 }
 
@@ -80,6 +84,10 @@ type Telegraf struct {
 
 	cfg *config.Config
 
+	// Currently running agent used to apply configuration changes
+	agent     *agent.Agent
+	agentLock sync.Mutex
+
 	GlobalFlags
 	WindowFlags
 }
@@ -146,57 +154,43 @@ func (t *Telegraf) reloadLoop() error {
 		signals := make(chan os.Signal, 1)
 		signal.Notify(signals, os.Interrupt, syscall.SIGHUP,
 			syscall.SIGTERM, syscall.SIGINT)
-		if t.watchConfig != "" {
-			for _, fConfig := range t.configFiles {
-				if isURL(fConfig) {
-					continue
-				}
-
-				if _, err := os.Stat(fConfig); err != nil {
-					log.Printf("W! Cannot watch config %s: %s", fConfig, err)
-				} else {
-					go t.watchLocalConfig(ctx, signals, fConfig)
-				}
-			}
-			for _, fConfigDirectory := range t.configDir {
-				if _, err := os.Stat(fConfigDirectory); err != nil {
-					log.Printf("W! Cannot watch config directory %s: %s", fConfigDirectory, err)
-				} else {
-					go t.watchLocalConfig(ctx, signals, fConfigDirectory)
-				}
-			}
-		}
-		if t.configURLWatchInterval > 0 {
-			remoteConfigs := make([]string, 0)
-			for _, fConfig := range t.configFiles {
-				if isURL(fConfig) {
-					remoteConfigs = append(remoteConfigs, fConfig)
-				}
-			}
-			if len(remoteConfigs) > 0 {
-				go t.watchRemoteConfigs(ctx, signals, t.configURLWatchInterval, remoteConfigs)
-			}
-		}
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		t.startWatchers(watchCtx, signals)
 		go func() {
-			select {
-			case sig := <-signals:
-				if sig == syscall.SIGHUP {
-					log.Println("I! Reloading Telegraf config")
-					// May need to update the list of known config files
-					// if a delete or create occured. That way on the reload
-					// we ensure we watch the correct files.
-					if err := t.getConfigFiles(); err != nil {
-						log.Println("E! Error loading config files: ", err)
+			for {
+				select {
+				case sig := <-signals:
+					if sig == syscall.SIGHUP {
+						log.Println("I! Reloading Telegraf config")
+						// May need to update the list of known config files
+						// if a delete or create occured. That way on the reload
+						// we ensure we watch the correct files.
+						if err := t.getConfigFiles(); err != nil {
+							log.Println("E! Error loading config files: ", err)
+						}
+
+						// Apply the changes to the running plugins if
+						// watching the configuration and restart the
+						// watchers to pick up further changes.
+						if t.watchConfig != "" || t.configURLWatchInterval > 0 {
+							if t.reloadPlugins() {
+								watchCancel()
+								watchCtx, watchCancel = context.WithCancel(ctx)
+								t.startWatchers(watchCtx, signals)
+								continue
+							}
+						}
+						<-reload
+						reload <- true
 					}
-					<-reload
-					reload <- true
+					cancel()
+				case err := <-t.pprofErr:
+					log.Printf("E! pprof server failed: %v", err)
+					cancel()
+				case <-stop:
+					cancel()
 				}
-				cancel()
-			case err := <-t.pprofErr:
-				log.Printf("E! pprof server failed: %v", err)
-				cancel()
-			case <-stop:
-				cancel()
+				return
 			}
 		}()
 
@@ -210,6 +204,74 @@ func (t *Telegraf) reloadLoop() error {
 	return nil
 }
 
+// startWatchers starts watching the local and remote configuration files for
+// changes until the context is done.
+func (t *Telegraf) startWatchers(ctx context.Context, signals chan os.Signal) {
+	if t.watchConfig != "" {
+		for _, fConfig := range t.configFiles {
+			if isURL(fConfig) {
+				continue
+			}
+
+			if _, err := os.Stat(fConfig); err != nil {
+				log.Printf("W! Cannot watch config %s: %s", fConfig, err)
+			} else {
+				go t.watchLocalConfig(ctx, signals, fConfig)
+			}
+		}
+		for _, fConfigDirectory := range t.configDir {
+			if _, err := os.Stat(fConfigDirectory); err != nil {
+				log.Printf("W! Cannot watch config directory %s: %s", fConfigDirectory, err)
+			} else {
+				go t.watchLocalConfig(ctx, signals, fConfigDirectory)
+			}
+		}
+	}
+	if t.configURLWatchInterval > 0 {
+		remoteConfigs := make([]string, 0)
+		for _, fConfig := range t.configFiles {
+			if isURL(fConfig) {
+				remoteConfigs = append(remoteConfigs, fConfig)
+			}
+		}
+		if len(remoteConfigs) > 0 {
+			go t.watchRemoteConfigs(ctx, signals, t.configURLWatchInterval, remoteConfigs)
+		}
+	}
+}
+
+// reloadPlugins loads the configuration and applies the plugin changes to the
+// running agent. It returns false if the agent needs to be restarted instead.
+func (t *Telegraf) reloadPlugins() bool {
+	t.agentLock.Lock()
+	ag := t.agent
+	t.agentLock.Unlock()
+	if ag == nil {
+		return false
+	}
+
+	c, err := t.loadConfigurationFor(ag.Config)
+	if err != nil {
+		log.Printf("E! Loading config failed, restarting agent: %v", err)
+		for _, output := range c.Outputs {
+			output.CloseBuffer()
+		}
+		c.StopSecretStores()
+		return false
+	}
+
+	if err := ag.Reload(c); err != nil {
+		if !errors.Is(err, agent.ErrRestartRequired) {
+			log.Printf("E! Applying configuration changes failed: %v", err)
+		}
+		c.StopSecretStores()
+		log.Println("I! Restarting agent")
+		return false
+	}
+	log.Println("I! Configuration changes applied")
+	return true
+}
+
 func (t *Telegraf) watchLocalConfig(ctx context.Context, signals chan os.Signal, fConfig string) {
 	var mytomb tomb.Tomb
 	var watcher watch.FileWatcher
@@ -296,9 +358,19 @@ func (t *Telegraf) watchRemoteConfigs(ctx context.Context, signals chan os.Signa
 }
 
 func (t *Telegraf) loadConfiguration() (*config.Config, error) {
+	return t.loadConfigurationFor(nil)
+}
+
+// loadConfigurationFor loads the configuration for applying the changes to
+// the given running configuration if not nil.
+func (t *Telegraf) loadConfigurationFor(running *config.Config) (*config.Config, error) {
 	// If no other options are specified, load the config file and run.
 	c := config.NewConfig()
+	if running != nil {
+		c.UseRunning(running)
+	}
 	c.Agent.Quiet = t.quiet
+	c.Agent.StrictParsing = t.strict
 	c.Agent.ConfigURLRetryAttempts = t.configURLRetryAttempts
 	c.OutputFilters = t.outputFilters
 	c.InputFilters = t.inputFilters
@@ -343,10 +415,15 @@ func (t *Telegraf) runAgent(ctx context.Context, reloadConfig bool) error {
 	var err error
 	if reloadConfig {
 		if c, err = t.loadConfiguration(); err != nil {
+			c.StopSecretStores()
 			return err
 		}
 	}
+	defer c.StopSecretStores()
 
+	if t.onceMinMetrics > 0 && t.onceTimeout == 0 {
+		return errors.New("--once-min-metrics requires --once-timeout to be set")
+	}
 	if !(t.test || t.testWait != 0) && len(c.Outputs) == 0 {
 		return errors.New("no outputs found, probably invalid config file provided")
 	}
@@ -392,7 +469,7 @@ func (t *Telegraf) runAgent(ctx context.Context, reloadConfig bool) error {
 	log.Printf("I! Loaded aggregators: %s", strings.Join(c.AggregatorNames(), " "))
 	log.Printf("I! Loaded processors: %s", strings.Join(c.ProcessorNames(), " "))
 	log.Printf("I! Loaded secretstores: %s", strings.Join(c.SecretstoreNames(), " "))
-	if !t.once && (t.test || t.testWait != 0) {
+	if !t.once && t.onceTimeout == 0 && (t.test || t.testWait != 0) {
 		log.Print("W! " + color.RedString("Outputs are not used in testing mode!"))
 	} else {
 		log.Printf("I! Loaded outputs: %s", strings.Join(c.OutputNames(), " "))
@@ -437,6 +514,10 @@ func (t *Telegraf) runAgent(ctx context.Context, reloadConfig bool) error {
 	//nolint:errcheck // see above
 	daemon.SdNotify(false, daemon.SdNotifyReady)
 
+	if t.onceTimeout > 0 {
+		return ag.OnceTimeout(ctx, t.onceTimeout, t.onceMinMetrics)
+	}
+
 	if t.once {
 		wait := time.Duration(t.testWait) * time.Second
 		return ag.Once(ctx, wait)
@@ -468,6 +549,15 @@ func (t *Telegraf) runAgent(ctx context.Context, reloadConfig bool) error {
 		}
 	}
 
+	t.agentLock.Lock()
+	t.agent = ag
+	t.agentLock.Unlock()
+	defer func() {
+		t.agentLock.Lock()
+		t.agent = nil
+		t.agentLock.Unlock()
+	}()
+
 	return ag.Run(ctx)
 }
 
@@ -14,6 +14,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -69,12 +70,22 @@ type Config struct {
 	UnusedFields      map[string]bool
 	unusedFieldsMutex *sync.Mutex
 
+	// General options seen in the root table of the current plugin, only
+	// collected in strict parsing mode
+	generalFields map[string]bool
+
 	Tags               map[string]string
 	InputFilters       []string
 	OutputFilters      []string
 	SecretStoreFilters []string
 
 	SecretStores map[string]telegraf.SecretStore
+	// Configuration hashes of the secret-stores used to detect changes
+	secretStoreIDs map[string]string
+	// Running configuration to take over unchanged secret-stores from and
+	// the IDs of the secret-stores taken over
+	running            *Config
+	sharedSecretStores map[string]bool
 
 	Agent       *AgentConfig
 	Inputs      []*models.RunningInput
@@ -117,6 +128,7 @@ func NewConfig() *Config {
 	c := &Config{
 		UnusedFields:      make(map[string]bool),
 		unusedFieldsMutex: &sync.Mutex{},
+		generalFields:     make(map[string]bool),
 
 		// Agent defaults:
 		Agent: &AgentConfig{
@@ -132,6 +144,8 @@ func NewConfig() *Config {
 		Processors:         make([]*models.RunningProcessor, 0),
 		AggProcessors:      make([]*models.RunningProcessor, 0),
 		SecretStores:       make(map[string]telegraf.SecretStore),
+		secretStoreIDs:     make(map[string]string),
+		sharedSecretStores: make(map[string]bool),
 		fileProcessors:     make([]*OrderedPlugin, 0),
 		fileAggProcessors:  make([]*OrderedPlugin, 0),
 		InputFilters:       make([]string, 0),
@@ -197,6 +211,19 @@ type AgentConfig struct {
 	// ie, a jitter of 5s and interval 10s means flushes will happen every 10-15s
 	FlushJitter Duration
 
+	// FlushAlignment aligns the flushes to wall-clock boundaries of the
+	// flush interval, ie, if flush_interval="10s" then flushes happen on
+	// :00, :10, :20, etc. The flush jitter is applied after the alignment.
+	FlushAlignment bool `toml:"flush_alignment"`
+
+	// ShutdownFlushRetries is the number of times a failed write of the final
+	// flush on shutdown is retried. Zero disables retrying.
+	ShutdownFlushRetries int `toml:"shutdown_flush_retries"`
+
+	// ShutdownFlushTimeout limits the time spent retrying the final flush on
+	// shutdown per output. Zero means the time is only limited by the retries.
+	ShutdownFlushTimeout Duration `toml:"shutdown_flush_timeout"`
+
 	// MetricBatchSize is the maximum number of metrics that is written to an
 	// output plugin in one call.
 	MetricBatchSize int
@@ -288,6 +315,23 @@ type AgentConfig struct {
 	// BufferDirectory is the directory to store buffer files for serialized
 	// to disk metrics when using the "disk" buffer strategy.
 	BufferDirectory string `toml:"buffer_directory"`
+
+	// BufferDiskLimit is the maximum size of the buffer files per output
+	// plugin when using the "disk" buffer strategy. Zero means unlimited.
+	BufferDiskLimit Size `toml:"buffer_disk_limit"`
+
+	// BufferDiskSync is the policy for syncing the buffer files to disk when
+	// using the "disk" buffer strategy. Supported are "always", "flush" and
+	// "never".
+	BufferDiskSync string `toml:"buffer_disk_sync"`
+
+	// StrictParsing rejects all options not applicable to the plugin
+	// including general options in the wrong table instead of ignoring them.
+	StrictParsing bool `toml:"strict_parsing"`
+
+	// PluginRuntimeStats enables accounting the metrics in flight, goroutines
+	// and CPU time per input, processor and output plugin.
+	PluginRuntimeStats bool `toml:"plugin_runtime_stats"`
 }
 
 // InputNames returns a list of strings of the configured inputs.
@@ -540,6 +584,12 @@ func (c *Config) LoadConfigData(data []byte) error {
 		if !ok {
 			return errors.New("invalid configuration, error parsing agent table")
 		}
+
+		// Enable strict parsing before unmarshalling the agent table to also
+		// check the agent options independent of the field order
+		if c.getFieldBool(subTable, "strict_parsing") {
+			c.Agent.StrictParsing = true
+		}
 		if err = c.toml.UnmarshalTable(subTable, c.Agent); err != nil {
 			return fmt.Errorf("error parsing [agent]: %w", err)
 		}
@@ -877,6 +927,10 @@ func (c *Config) addAggregator(name string, table *ast.Table) error {
 		return err
 	}
 
+	if err := c.checkStrictOptions("aggregators", name, table, false); err != nil {
+		return err
+	}
+
 	c.Aggregators = append(c.Aggregators, models.NewRunningAggregator(aggregator, conf))
 	return nil
 }
@@ -894,6 +948,22 @@ func (c *Config) addSecretStore(name string, table *ast.Table) error {
 		return fmt.Errorf("invalid secret-store ID %q, must only contain letters, numbers or underscore", storeID)
 	}
 
+	if _, found := c.SecretStores[storeID]; found {
+		return fmt.Errorf("duplicate ID %q for secretstore %q", storeID, name)
+	}
+	hash, err := generatePluginID("secretstores."+name, table)
+	if err != nil {
+		return fmt.Errorf("generating ID for secret-store %q failed: %w", storeID, err)
+	}
+
+	// Take over the instance of the running configuration if unchanged
+	if c.running != nil && c.running.secretStoreIDs[storeID] == hash {
+		c.SecretStores[storeID] = c.running.SecretStores[storeID]
+		c.secretStoreIDs[storeID] = hash
+		c.sharedSecretStores[storeID] = true
+		return nil
+	}
+
 	creator, ok := secretstores.SecretStores[name]
 	if !ok {
 		// Handle removed, deprecated plugins
@@ -913,6 +983,10 @@ func (c *Config) addSecretStore(name string, table *ast.Table) error {
 		return err
 	}
 
+	if err := c.checkStrictOptions("secretstores", name, table, false); err != nil {
+		return err
+	}
+
 	logger := logging.New("secretstores", name, "")
 	models.SetLoggerOnPlugin(store, logger)
 
@@ -920,15 +994,39 @@ func (c *Config) addSecretStore(name string, table *ast.Table) error {
 		return fmt.Errorf("error initializing secret-store %q: %w", storeID, err)
 	}
 
-	if _, found := c.SecretStores[storeID]; found {
-		return fmt.Errorf("duplicate ID %q for secretstore %q", storeID, name)
-	}
 	c.SecretStores[storeID] = store
+	c.secretStoreIDs[storeID] = hash
 	return nil
 }
 
+// UseRunning prepares the configuration to be loaded for applying changes to
+// the given running configuration. Unchanged secret-stores are taken over from
+// the running configuration instead of creating new instances and the buffers
+// of the outputs are only opened when starting the outputs, so buffers of
+// running outputs are not touched before those are closed.
+func (c *Config) UseRunning(running *Config) {
+	c.running = running
+}
+
+// StopSecretStores stops the secret-stores created by this configuration.
+// Secret-stores taken over from a running configuration are left untouched.
+func (c *Config) StopSecretStores() {
+	for id, store := range c.SecretStores {
+		if c.sharedSecretStores[id] {
+			continue
+		}
+		if stopper, ok := store.(telegraf.SecretStoreStopper); ok {
+			stopper.Stop()
+		}
+	}
+}
+
 func (c *Config) LinkSecrets() error {
 	for _, s := range unlinkedSecrets {
+		// Skip secrets linked by a previously loaded configuration
+		if len(s.GetUnlinked()) == 0 {
+			continue
+		}
 		resolvers := make(map[string]telegraf.ResolveFunc)
 		for _, ref := range s.GetUnlinked() {
 			// Split the reference and lookup the resolver
@@ -943,10 +1041,27 @@ func (c *Config) LinkSecrets() error {
 			}
 			resolvers[ref] = resolver
 		}
+		// Allow to invalidate the parts of the secret provided by caching
+		// secret-stores. Keep the unlinked secret in this case as static parts
+		// need to be resolved again after invalidation.
+		var invalidators []func()
+		for ref := range resolvers {
+			storeID, key := splitLink(ref)
+			if invalidator, ok := c.SecretStores[storeID].(telegraf.SecretInvalidator); ok {
+				invalidators = append(invalidators, func() { invalidator.Invalidate(key) })
+			}
+		}
+		if len(invalidators) > 0 {
+			if err := s.keepTemplate(resolvers); err != nil {
+				return fmt.Errorf("keeping secret for invalidation failed: %w", err)
+			}
+		}
+
 		// Inject the resolver list into the secret
 		if err := s.Link(resolvers); err != nil {
 			return fmt.Errorf("retrieving resolver failed: %w", err)
 		}
+		s.invalidators = invalidators
 	}
 	return nil
 }
@@ -1111,7 +1226,7 @@ func (c *Config) addProcessor(name string, table *ast.Table) error {
 		}
 	}
 
-	return nil
+	return c.checkStrictOptions("processors", name, table, count > 0)
 }
 
 func (c *Config) setupProcessor(name string, creator processors.StreamingCreator, table *ast.Table) (telegraf.StreamingProcessor, int, error) {
@@ -1262,6 +1377,11 @@ func (c *Config) addOutput(name string, table *ast.Table) error {
 		}
 	}
 
+	if err := c.checkStrictOptions("outputs", name, table, missThreshold > 0); err != nil {
+		return err
+	}
+
+	outputConfig.DeferBuffer = c.running != nil
 	ro := models.NewRunningOutput(output, outputConfig, c.Agent.MetricBatchSize, c.Agent.MetricBufferLimit)
 	c.Outputs = append(c.Outputs, ro)
 
@@ -1346,6 +1466,10 @@ func (c *Config) addInput(name string, table *ast.Table) error {
 		}
 	}
 
+	if err := c.checkStrictOptions("inputs", name, table, missCountThreshold > 0); err != nil {
+		return err
+	}
+
 	rp := models.NewRunningInput(input, pluginConfig)
 	rp.SetDefaultTags(c.Tags)
 	c.Inputs = append(c.Inputs, rp)
@@ -1409,7 +1533,7 @@ func (c *Config) buildAggregator(name string, tbl *ast.Table) (*models.Aggregato
 // builds the filter and returns a
 // models.ProcessorConfig to be inserted into models.RunningProcessor
 func (c *Config) buildProcessor(category, name string, tbl *ast.Table) (*models.ProcessorConfig, error) {
-	conf := &models.ProcessorConfig{Name: name}
+	conf := &models.ProcessorConfig{Name: name, RuntimeStats: c.Agent.PluginRuntimeStats}
 
 	conf.Order = c.getFieldInt64(tbl, "order")
 	conf.Alias = c.getFieldString(tbl, "alias")
@@ -1519,6 +1643,7 @@ func (c *Config) buildInput(name string, tbl *ast.Table) (*models.InputConfig, e
 		Name:                    name,
 		AlwaysIncludeLocalTags:  c.Agent.AlwaysIncludeLocalTags,
 		AlwaysIncludeGlobalTags: c.Agent.AlwaysIncludeGlobalTags,
+		RuntimeStats:            c.Agent.PluginRuntimeStats,
 	}
 	cp.Interval, _ = c.getFieldDuration(tbl, "interval")
 	cp.Precision, _ = c.getFieldDuration(tbl, "precision")
@@ -1571,12 +1696,19 @@ func (c *Config) buildOutput(name string, tbl *ast.Table) (*models.OutputConfig,
 		Filter:          filter,
 		BufferStrategy:  c.Agent.BufferStrategy,
 		BufferDirectory: c.Agent.BufferDirectory,
+		BufferDiskLimit: int64(c.Agent.BufferDiskLimit),
+		BufferDiskSync:  c.Agent.BufferDiskSync,
+		FlushAlignment:  c.Agent.FlushAlignment,
+		RuntimeStats:    c.Agent.PluginRuntimeStats,
 	}
 
 	// TODO: support FieldPass/FieldDrop on outputs
 
 	oc.FlushInterval, _ = c.getFieldDuration(tbl, "flush_interval")
 	oc.FlushJitter, _ = c.getFieldDuration(tbl, "flush_jitter")
+	if _, found := tbl.Fields["flush_alignment"]; found {
+		oc.FlushAlignment = c.getFieldBool(tbl, "flush_alignment")
+	}
 	oc.MetricBufferLimit = c.getFieldInt(tbl, "metric_buffer_limit")
 	oc.MetricBatchSize = c.getFieldInt(tbl, "metric_batch_size")
 	oc.Alias = c.getFieldString(tbl, "alias")
@@ -1586,6 +1718,21 @@ func (c *Config) buildOutput(name string, tbl *ast.Table) (*models.OutputConfig,
 	oc.StartupErrorBehavior = c.getFieldString(tbl, "startup_error_behavior")
 	oc.LogLevel = c.getFieldString(tbl, "log_level")
 
+	// Allow to override the agent's buffer settings per output
+	if strategy := c.getFieldString(tbl, "buffer_strategy"); strategy != "" {
+		oc.BufferStrategy = strategy
+	}
+	if directory := c.getFieldString(tbl, "buffer_directory"); directory != "" {
+		oc.BufferDirectory = directory
+	}
+	if limit, found := c.getFieldSize(tbl, "buffer_disk_limit"); found {
+		oc.BufferDiskLimit = limit
+	}
+	if policy := c.getFieldString(tbl, "buffer_disk_sync"); policy != "" {
+		oc.BufferDiskSync = policy
+	}
+	oc.BufferOverflowStrategy = c.getFieldString(tbl, "buffer_overflow_strategy")
+
 	if c.hasErrs() {
 		return nil, c.firstErr()
 	}
@@ -1599,14 +1746,18 @@ func (c *Config) buildOutput(name string, tbl *ast.Table) (*models.OutputConfig,
 	return oc, err
 }
 
-func (c *Config) missingTomlField(_ reflect.Type, key string) error {
+// isGeneralOption returns true for options handled by Telegraf instead of the
+// plugin itself
+func isGeneralOption(key string) bool {
 	switch key {
 	// General options to ignore
 	case "alias", "always_include_local_tags",
-		"buffer_strategy", "buffer_directory",
+		"buffer_strategy", "buffer_directory", "buffer_disk_limit", "buffer_disk_sync",
+		"buffer_overflow_strategy",
 		"collection_jitter", "collection_offset",
 		"data_format", "delay", "drop", "drop_original",
-		"fielddrop", "fieldexclude", "fieldinclude", "fieldpass", "flush_interval", "flush_jitter",
+		"fielddrop", "fieldexclude", "fieldinclude", "fieldpass",
+		"flush_alignment", "flush_interval", "flush_jitter",
 		"grace",
 		"interval",
 		"log_level", "lvm", // What is this used for?
@@ -1614,7 +1765,8 @@ func (c *Config) missingTomlField(_ reflect.Type, key string) error {
 		"name_override", "name_prefix", "name_suffix", "namedrop", "namedrop_separator", "namepass", "namepass_separator",
 		"order",
 		"pass", "period", "precision",
-		"tagdrop", "tagexclude", "taginclude", "tagpass", "tags", "startup_error_behavior":
+		"tagdrop", "tagexclude", "taginclude", "tagpass", "tags", "startup_error_behavior",
+		"time_source":
 
 	// Secret-store options to ignore
 	case "id":
@@ -1623,13 +1775,152 @@ func (c *Config) missingTomlField(_ reflect.Type, key string) error {
 	case "data_type", "influx_parser_type":
 
 	default:
-		c.unusedFieldsMutex.Lock()
-		c.UnusedFields[key] = true
-		c.unusedFieldsMutex.Unlock()
+		return false
+	}
+	return true
+}
+
+// isPluginType returns true if the given type is the root element of a plugin
+// of any type, i.e. the type holding the plugin-specific options.
+func isPluginType(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	root := pt.Implements(reflect.TypeOf((*telegraf.Input)(nil)).Elem())
+	root = root || pt.Implements(reflect.TypeOf((*telegraf.ServiceInput)(nil)).Elem())
+	root = root || pt.Implements(reflect.TypeOf((*telegraf.Output)(nil)).Elem())
+	root = root || pt.Implements(reflect.TypeOf((*telegraf.Aggregator)(nil)).Elem())
+	root = root || pt.Implements(reflect.TypeOf((*telegraf.Processor)(nil)).Elem())
+	root = root || pt.Implements(reflect.TypeOf((*telegraf.StreamingProcessor)(nil)).Elem())
+	root = root || pt.Implements(reflect.TypeOf((*telegraf.Parser)(nil)).Elem())
+	root = root || pt.Implements(reflect.TypeOf((*telegraf.Serializer)(nil)).Elem())
+	root = root || pt.Implements(reflect.TypeOf((*telegraf.SecretStore)(nil)).Elem())
+	return root
+}
+
+func (c *Config) missingTomlField(t reflect.Type, key string) error {
+	if !isGeneralOption(key) {
+		c.markUnusedField(key)
+		return nil
+	}
+
+	// In strict mode, general options are only valid in the root table of a
+	// plugin, so reject misnested options. Options in the root table are
+	// checked against the options valid for the plugin later.
+	if c.Agent.StrictParsing {
+		if t != nil && !isPluginType(t) {
+			c.markUnusedField(key)
+		} else {
+			c.unusedFieldsMutex.Lock()
+			c.generalFields[key] = true
+			c.unusedFieldsMutex.Unlock()
+		}
 	}
 	return nil
 }
 
+// Options handled by Telegraf for the different plugin categories used to
+// reject general options not applicable to a plugin in strict parsing mode
+var (
+	filterOptions = []string{
+		"namepass", "namepass_separator", "namedrop", "namedrop_separator",
+		"pass", "fieldpass", "fieldinclude", "drop", "fielddrop", "fieldexclude",
+		"tagpass", "tagdrop", "tagexclude", "taginclude", "metricpass",
+	}
+	pluginOptions = map[string][]string{
+		"inputs": {
+			"alias", "interval", "precision", "collection_jitter", "collection_offset",
+			"startup_error_behavior", "time_source", "name_prefix", "name_suffix",
+			"name_override", "log_level", "tags",
+		},
+		"outputs": {
+			"alias", "flush_interval", "flush_jitter", "flush_alignment", "metric_buffer_limit", "metric_batch_size",
+			"name_override", "name_suffix", "name_prefix", "startup_error_behavior", "log_level",
+			"buffer_strategy", "buffer_directory", "buffer_disk_limit", "buffer_disk_sync",
+			"buffer_overflow_strategy",
+		},
+		"processors": {"alias", "order", "log_level"},
+		"aggregators": {
+			"alias", "period", "delay", "grace", "drop_original", "name_prefix",
+			"name_suffix", "name_override", "log_level", "tags",
+		},
+		"secretstores": {"id"},
+	}
+	dataFormatOptions = []string{"data_format", "data_type", "influx_parser_type"}
+)
+
+// checkStrictOptions rejects the general options of a plugin not applicable to
+// the plugin category in strict parsing mode. Plugins accepting arbitrary data
+// formats additionally allow the parser and serializer options. The returned
+// error lists all unused options of the plugin with their location.
+func (c *Config) checkStrictOptions(category, name string, table *ast.Table, dataFormat bool) error {
+	if !c.Agent.StrictParsing {
+		return nil
+	}
+
+	c.unusedFieldsMutex.Lock()
+	defer c.unusedFieldsMutex.Unlock()
+
+	for key := range c.generalFields {
+		valid := slices.Contains(filterOptions, key) || slices.Contains(pluginOptions[category], key)
+		valid = valid || dataFormat && slices.Contains(dataFormatOptions, key)
+		if !valid {
+			c.UnusedFields[key] = true
+		}
+	}
+	clear(c.generalFields)
+
+	if len(c.UnusedFields) == 0 {
+		return nil
+	}
+
+	fields := keys(c.UnusedFields)
+	sort.Strings(fields)
+	unused := make([]string, 0, len(fields))
+	for _, key := range fields {
+		unused = append(unused, fmt.Sprintf("%q (line %d)", key, findKeyLine(table, key)))
+	}
+	return fmt.Errorf("plugin %s.%s: line %d: unknown options %s", category, name, table.Line, strings.Join(unused, ", "))
+}
+
+// findKeyLine returns the line of the first occurrence of the given key in the
+// table including its sub-tables or the line of the table if not found.
+func findKeyLine(table *ast.Table, key string) int {
+	if node, found := table.Fields[key]; found {
+		switch n := node.(type) {
+		case *ast.KeyValue:
+			return n.Line
+		case *ast.Table:
+			return n.Line
+		case []*ast.Table:
+			if len(n) > 0 {
+				return n[0].Line
+			}
+		}
+	}
+
+	for _, node := range table.Fields {
+		var subtables []*ast.Table
+		switch n := node.(type) {
+		case *ast.Table:
+			subtables = []*ast.Table{n}
+		case []*ast.Table:
+			subtables = n
+		}
+		for _, subtable := range subtables {
+			if line := findKeyLine(subtable, key); line != subtable.Line {
+				return line
+			}
+		}
+	}
+
+	return table.Line
+}
+
+func (c *Config) markUnusedField(key string) {
+	c.unusedFieldsMutex.Lock()
+	c.UnusedFields[key] = true
+	c.unusedFieldsMutex.Unlock()
+}
+
 func (c *Config) setLocalMissingTomlFieldTracker(counter map[string]int) {
 	f := func(t reflect.Type, key string) error {
 		// Check if we are in a root element that might share options among
@@ -1637,23 +1928,20 @@ func (c *Config) setLocalMissingTomlFieldTracker(counter map[string]int) {
 		// All other elements are subtables of their respective plugin and
 		// should just be hit once anyway. Therefore, we mark them with a
 		// high number to handle them correctly later.
-		pt := reflect.PointerTo(t)
-		root := pt.Implements(reflect.TypeOf((*telegraf.Input)(nil)).Elem())
-		root = root || pt.Implements(reflect.TypeOf((*telegraf.ServiceInput)(nil)).Elem())
-		root = root || pt.Implements(reflect.TypeOf((*telegraf.Output)(nil)).Elem())
-		root = root || pt.Implements(reflect.TypeOf((*telegraf.Aggregator)(nil)).Elem())
-		root = root || pt.Implements(reflect.TypeOf((*telegraf.Processor)(nil)).Elem())
-		root = root || pt.Implements(reflect.TypeOf((*telegraf.StreamingProcessor)(nil)).Elem())
-		root = root || pt.Implements(reflect.TypeOf((*telegraf.Parser)(nil)).Elem())
-		root = root || pt.Implements(reflect.TypeOf((*telegraf.Serializer)(nil)).Elem())
-
-		c, ok := counter[key]
+		root := isPluginType(t)
+
+		count, ok := counter[key]
 		if !root {
 			counter[key] = 100
+
+			// General options are only valid in the root table of a plugin
+			if c.Agent.StrictParsing && isGeneralOption(key) {
+				c.markUnusedField(key)
+			}
 		} else if !ok {
 			counter[key] = 1
 		} else {
-			counter[key] = c + 1
+			counter[key] = count + 1
 		}
 		return nil
 	}
@@ -1693,6 +1981,34 @@ func (c *Config) getFieldDuration(tbl *ast.Table, fieldName string) (time.Durati
 	return 0, false
 }
 
+func (c *Config) getFieldSize(tbl *ast.Table, fieldName string) (int64, bool) {
+	if node, ok := tbl.Fields[fieldName]; ok {
+		if kv, ok := node.(*ast.KeyValue); ok {
+			var size Size
+			switch t := kv.Value.(type) {
+			case *ast.String:
+				if err := size.UnmarshalText([]byte(t.Value)); err != nil {
+					c.addError(tbl, fmt.Errorf("error parsing size: %w", err))
+					return 0, false
+				}
+			case *ast.Integer:
+				i, err := t.Int()
+				if err != nil {
+					c.addError(tbl, fmt.Errorf("unexpected int type %q, expecting int", t.Value))
+					return 0, false
+				}
+				size = Size(i)
+			default:
+				c.addError(tbl, fmt.Errorf("found unexpected format while parsing %q, expecting size", fieldName))
+				return 0, false
+			}
+			return int64(size), true
+		}
+	}
+
+	return 0, false
+}
+
 func (c *Config) getFieldBool(tbl *ast.Table, fieldName string) bool {
 	if node, ok := tbl.Fields[fieldName]; ok {
 		if kv, ok := node.(*ast.KeyValue); ok {
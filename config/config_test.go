@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -452,6 +453,83 @@ func TestConfig_FieldNotDefined(t *testing.T) {
 	}
 }
 
+func TestConfig_FlushAlignment(t *testing.T) {
+	cfg := []byte(`
+[agent]
+  flush_alignment = true
+
+[[outputs.http]]
+  url = "http://localhost:8080"
+
+[[outputs.http]]
+  url = "http://localhost:8081"
+  flush_alignment = false
+`)
+
+	c := config.NewConfig()
+	require.NoError(t, c.LoadConfigData(cfg))
+	require.Len(t, c.Outputs, 2)
+	require.True(t, c.Outputs[0].Config.FlushAlignment)
+	require.False(t, c.Outputs[1].Config.FlushAlignment)
+}
+
+func TestConfig_StrictParsing(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		expected string
+	}{
+		{
+			name:     "valid options",
+			filename: "./testdata/strict_parsing_valid.toml",
+		},
+		{
+			name:     "option of other plugin category",
+			filename: "./testdata/strict_parsing_category.toml",
+			expected: `plugin inputs.memcached: line 1: unknown options "flush_interval" (line 3)`,
+		},
+		{
+			name:     "data format without parser",
+			filename: "./testdata/strict_parsing_data_format.toml",
+			expected: `plugin processors.processor: line 1: unknown options "data_format" (line 3)`,
+		},
+		{
+			name:     "misnested option",
+			filename: "./testdata/strict_parsing_misnested.toml",
+			expected: `plugin inputs.parser: line 1: unknown options "interval" (line 6)`,
+		},
+		{
+			name:     "misnested table",
+			filename: "./testdata/strict_parsing_misnested_table.toml",
+			expected: `plugin outputs.http: line 1: unknown options "tags" (line 4)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The configuration is accepted without strict parsing
+			c := config.NewConfig()
+			require.NoError(t, c.LoadConfig(tt.filename))
+
+			c = config.NewConfig()
+			c.Agent.StrictParsing = true
+			err := c.LoadConfig(tt.filename)
+			if tt.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, "loading config file "+tt.filename+" failed")
+			require.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestConfig_StrictParsingAgentOption(t *testing.T) {
+	c := config.NewConfig()
+	err := c.LoadConfig("./testdata/strict_parsing_agent.toml")
+	require.ErrorContains(t, err, `plugin inputs.memcached: line 4: unknown options "metric_batch_size" (line 6)`)
+}
+
 func TestConfig_WrongFieldType(t *testing.T) {
 	c := config.NewConfig()
 	err := c.LoadConfig("./testdata/wrong_field_type.toml")
@@ -515,6 +593,47 @@ func TestConfig_AzureMonitorNamespacePrefix(t *testing.T) {
 	}
 }
 
+func TestConfig_OutputBufferSettings(t *testing.T) {
+	dir := t.TempDir()
+	cfg := []byte(`
+[agent]
+  buffer_disk_limit = "10MB"
+  buffer_disk_sync = "flush"
+
+[[outputs.azure_monitor]]
+
+[[outputs.azure_monitor]]
+  buffer_strategy = "disk"
+  buffer_directory = "` + filepath.ToSlash(dir) + `"
+  buffer_disk_limit = 1024
+  buffer_disk_sync = "never"
+  buffer_overflow_strategy = "drop_newest"
+
+[[outputs.azure_monitor]]
+  buffer_strategy = "disk"
+  buffer_directory = "` + filepath.ToSlash(dir) + `"
+`)
+
+	c := config.NewConfig()
+	require.NoError(t, c.LoadConfigData(cfg))
+	require.Len(t, c.Outputs, 3)
+	defer func() {
+		for _, o := range c.Outputs {
+			o.Close()
+		}
+	}()
+
+	require.Empty(t, c.Outputs[0].Config.BufferStrategy)
+	require.Equal(t, "disk", c.Outputs[1].Config.BufferStrategy)
+	require.Equal(t, filepath.ToSlash(dir), c.Outputs[1].Config.BufferDirectory)
+	require.Equal(t, int64(1024), c.Outputs[1].Config.BufferDiskLimit)
+	require.Equal(t, "never", c.Outputs[1].Config.BufferDiskSync)
+	require.Equal(t, "drop_newest", c.Outputs[1].Config.BufferOverflowStrategy)
+	require.Empty(t, c.Outputs[2].Config.BufferOverflowStrategy)
+	require.Equal(t, int64(10*1000*1000), c.Outputs[2].Config.BufferDiskLimit)
+	require.Equal(t, "flush", c.Outputs[2].Config.BufferDiskSync)
+}
+
 func TestGetDefaultConfigPathFromEnvURL(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -1293,6 +1412,162 @@ func TestPersisterProcessorRegistration(t *testing.T) {
 	}
 }
 
+func TestConfigDiff(t *testing.T) {
+	base := `
+[agent]
+  interval = "10s"
+  omit_hostname = true
+
+[[inputs.memcached]]
+  servers = ["localhost:11211"]
+
+[[inputs.procstat]]
+  pid_file = "/var/run/telegraf.pid"
+
+[[processors.processor]]
+  option = "a"
+
+[[processors.processor]]
+  option = "b"
+
+[[outputs.http]]
+  url = "http://localhost:8080"
+`
+
+	tests := []struct {
+		name              string
+		updated           string
+		restart           string
+		addedInputs       int
+		removedInputs     int
+		addedProcessors   int
+		removedProcessors int
+		processorsChanged bool
+		addedOutputs      int
+		removedOutputs    int
+	}{
+		{
+			name:    "unchanged",
+			updated: base,
+		},
+		{
+			name:        "input added",
+			updated:     base + "\n[[inputs.memcached]]\n  servers = [\"remote:11211\"]\n",
+			addedInputs: 1,
+		},
+		{
+			name:          "input removed",
+			updated:       strings.Replace(base, "[[inputs.procstat]]\n  pid_file = \"/var/run/telegraf.pid\"\n", "", 1),
+			removedInputs: 1,
+		},
+		{
+			name:          "input modified",
+			updated:       strings.Replace(base, "localhost:11211", "localhost:11212", 1),
+			addedInputs:   1,
+			removedInputs: 1,
+		},
+		{
+			name:              "processor added",
+			updated:           base + "\n[[processors.processor]]\n  option = \"c\"\n",
+			addedProcessors:   1,
+			processorsChanged: true,
+		},
+		{
+			name:              "processor modified",
+			updated:           strings.Replace(base, `option = "b"`, `option = "c"`, 1),
+			addedProcessors:   1,
+			removedProcessors: 1,
+			processorsChanged: true,
+		},
+		{
+			name: "processors reordered",
+			updated: strings.NewReplacer(
+				`option = "a"`, `option = "b"`,
+				`option = "b"`, `option = "a"`,
+			).Replace(base),
+			processorsChanged: true,
+		},
+		{
+			name:           "output modified",
+			updated:        strings.Replace(base, "http://localhost:8080", "http://localhost:8081", 1),
+			addedOutputs:   1,
+			removedOutputs: 1,
+		},
+		{
+			name:           "output removed",
+			updated:        strings.Replace(base, "[[outputs.http]]\n  url = \"http://localhost:8080\"\n", "", 1),
+			removedOutputs: 1,
+		},
+		{
+			name:    "agent settings changed",
+			updated: strings.Replace(base, `interval = "10s"`, `interval = "20s"`, 1),
+			restart: "agent settings changed",
+		},
+		{
+			name:    "global tags changed",
+			updated: base + "\n[global_tags]\n  dc = \"eu-west\"\n",
+			restart: "global tags changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := config.NewConfig()
+			require.NoError(t, current.LoadConfigData([]byte(base)))
+			updated := config.NewConfig()
+			require.NoError(t, updated.LoadConfigData([]byte(tt.updated)))
+
+			changes := config.Diff(current, updated)
+			require.Equal(t, tt.restart, changes.RestartReason)
+			if tt.restart != "" {
+				require.False(t, changes.Empty())
+				return
+			}
+			require.Len(t, changes.AddedInputs, tt.addedInputs)
+			require.Len(t, changes.RemovedInputs, tt.removedInputs)
+			require.Len(t, changes.AddedProcessors, tt.addedProcessors)
+			require.Len(t, changes.RemovedProcessors, tt.removedProcessors)
+			require.Equal(t, tt.processorsChanged, changes.ProcessorsChanged)
+			require.Len(t, changes.AddedOutputs, tt.addedOutputs)
+			require.Len(t, changes.RemovedOutputs, tt.removedOutputs)
+			require.Len(t, changes.Inputs, len(updated.Inputs))
+			require.Len(t, changes.Processors, len(updated.Processors))
+			require.Len(t, changes.Outputs, len(updated.Outputs))
+
+			// Unchanged plugins must be the running instances
+			for _, input := range changes.Inputs {
+				require.Equal(t, slices.Contains(current.Inputs, input), !slices.Contains(changes.AddedInputs, input))
+			}
+			for _, processor := range changes.Processors {
+				require.Equal(t, slices.Contains(current.Processors, processor), !slices.Contains(changes.AddedProcessors, processor))
+			}
+			for _, output := range changes.Outputs {
+				require.Equal(t, slices.Contains(current.Outputs, output), !slices.Contains(changes.AddedOutputs, output))
+			}
+			require.Len(t, changes.UnusedOutputs, len(updated.Outputs)-tt.addedOutputs)
+
+			empty := tt.addedInputs+tt.removedInputs+tt.addedOutputs+tt.removedOutputs == 0 && !tt.processorsChanged
+			require.Equal(t, empty, changes.Empty())
+		})
+	}
+}
+
+func TestConfigDiffDuplicatePlugins(t *testing.T) {
+	plugin := "[[inputs.memcached]]\n  servers = [\"localhost:11211\"]\n"
+
+	current := config.NewConfig()
+	require.NoError(t, current.LoadConfigData([]byte(plugin+plugin)))
+	require.Len(t, current.Inputs, 2)
+	updated := config.NewConfig()
+	require.NoError(t, updated.LoadConfigData([]byte(plugin)))
+
+	// Identically configured plugins are only matched once
+	changes := config.Diff(current, updated)
+	require.Empty(t, changes.AddedInputs)
+	require.Equal(t, []*models.RunningInput{current.Inputs[0]}, changes.Inputs)
+	require.Equal(t, []*models.RunningInput{current.Inputs[1]}, changes.RemovedInputs)
+}
+
 // Mockup INPUT plugin for (new) parser testing to avoid cyclic dependencies
 type MockupInputPluginParserNew struct {
 	Parser     telegraf.Parser
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/influxdata/telegraf/models"
+)
+
+// Changes describes the plugin differences between a running and an updated
+// configuration. Plugins are identified by their ID, i.e. the hash of their
+// configuration, so changing any option of a plugin will result in the plugin
+// being removed and a new instance being added.
+type Changes struct {
+	// RestartReason is set if settings other than the inputs, processors and
+	// outputs changed, so the changes cannot be applied to a running agent.
+	RestartReason string
+
+	// Inputs, Processors and Outputs contain the plugins of the updated
+	// configuration in order, where unchanged plugins are replaced by the
+	// running instances.
+	Inputs     []*models.RunningInput
+	Processors models.RunningProcessors
+	Outputs    []*models.RunningOutput
+
+	AddedInputs       []*models.RunningInput
+	RemovedInputs     []*models.RunningInput
+	AddedProcessors   []*models.RunningProcessor
+	RemovedProcessors []*models.RunningProcessor
+	AddedOutputs      []*models.RunningOutput
+	RemovedOutputs    []*models.RunningOutput
+
+	// ProcessorsChanged is set if processors were added, removed or
+	// reordered, requiring the processor chain to be rebuilt.
+	ProcessorsChanged bool
+
+	// UnusedOutputs are the outputs of the updated configuration superseded by
+	// an identical running instance. Those outputs are never connected but
+	// hold a buffer that needs to be released.
+	UnusedOutputs []*models.RunningOutput
+}
+
+// Empty returns true if there are no plugin changes
+func (c *Changes) Empty() bool {
+	return c.RestartReason == "" &&
+		len(c.AddedInputs) == 0 && len(c.RemovedInputs) == 0 &&
+		!c.ProcessorsChanged &&
+		len(c.AddedOutputs) == 0 && len(c.RemovedOutputs) == 0
+}
+
+// Diff compares the running configuration with the updated configuration
+// and determines the plugins to add and to remove.
+func Diff(current, updated *Config) *Changes {
+	changes := &Changes{RestartReason: restartReason(current, updated)}
+	if changes.RestartReason != "" {
+		return changes
+	}
+
+	changes.Inputs, changes.AddedInputs, changes.RemovedInputs, _ = diffPlugins(current.Inputs, updated.Inputs)
+	changes.Processors, changes.AddedProcessors, changes.RemovedProcessors, _ = diffPlugins(current.Processors, updated.Processors)
+	changes.ProcessorsChanged = !slices.Equal(current.Processors, changes.Processors)
+	changes.Outputs, changes.AddedOutputs, changes.RemovedOutputs, changes.UnusedOutputs = diffPlugins(current.Outputs, updated.Outputs)
+
+	return changes
+}
+
+func restartReason(current, updated *Config) string {
+	if !reflect.DeepEqual(current.Agent, updated.Agent) {
+		return "agent settings changed"
+	}
+	if !maps.Equal(current.Tags, updated.Tags) {
+		return "global tags changed"
+	}
+	if !maps.Equal(current.secretStoreIDs, updated.secretStoreIDs) {
+		return "secret-stores changed"
+	}
+
+	// Aggregators keep their aggregation window and the processors running
+	// after the aggregators are tied to them, so do not modify those.
+	if !slices.Equal(pluginIDs(current.Aggregators), pluginIDs(updated.Aggregators)) {
+		return "aggregators changed"
+	}
+	usesAggProcessors := len(current.Aggregators) > 0 && !current.Agent.SkipProcessorsAfterAggregators
+	if usesAggProcessors && !slices.Equal(pluginIDs(current.AggProcessors), pluginIDs(updated.AggProcessors)) {
+		return "processors changed while running aggregators"
+	}
+	return ""
+}
+
+type identifiablePlugin interface {
+	comparable
+	ID() string
+}
+
+func pluginIDs[T identifiablePlugin](plugins []T) []string {
+	ids := make([]string, 0, len(plugins))
+	for _, p := range plugins {
+		ids = append(ids, p.ID())
+	}
+	return ids
+}
+
+// diffPlugins matches the updated plugins against the running ones by ID. As
+// multiple plugins might share the same configuration, each running instance
+// is only used once.
+func diffPlugins[T identifiablePlugin](current, updated []T) (merged, added, removed, unused []T) {
+	running := make(map[string][]T, len(current))
+	for _, p := range current {
+		id := p.ID()
+		running[id] = append(running[id], p)
+	}
+
+	kept := make(map[T]bool, len(current))
+	merged = make([]T, 0, len(updated))
+	for _, p := range updated {
+		id := p.ID()
+		if instances := running[id]; len(instances) > 0 {
+			merged = append(merged, instances[0])
+			kept[instances[0]] = true
+			running[id] = instances[1:]
+			unused = append(unused, p)
+			continue
+		}
+		merged = append(merged, p)
+		added = append(added, p)
+	}
+
+	for _, p := range current {
+		if !kept[p] {
+			removed = append(removed, p)
+		}
+	}
+
+	return merged, added, removed, unused
+}
@@ -1,10 +1,12 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"regexp"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/influxdata/telegraf"
@@ -22,7 +24,7 @@ var unlinkedSecrets = make([]*Secret, 0)
 var secretStorePattern = regexp.MustCompile(`^\w+$`)
 
 // secretPattern is a regex to extract references to secrets store in a secret-store
-var secretPattern = regexp.MustCompile(`@\{(\w+:\w+)\}`)
+var secretPattern = regexp.MustCompile(`@\{(\w+:[\w/#.-]+)\}`)
 
 // secretCandidatePattern is a regex to find secret candidates to warn users on invalid characters in references
 var secretCandidatePattern = regexp.MustCompile(`@\{.+?:.+?}`)
@@ -84,6 +86,11 @@ type SecretBuffer interface {
 
 // Secret safely stores sensitive data such as a password or token
 type Secret struct {
+	// lock guards the container content, the resolvers and the stale flag
+	// as secrets might be invalidated while being used concurrently. It is
+	// a pointer as secrets are passed by value.
+	lock *sync.RWMutex
+
 	// container is the implementation for holding the secret. It can be
 	// protected or not depending on the concrete implementation.
 	container secretContainer
@@ -91,6 +98,17 @@ type Secret struct {
 	// resolvers are the functions for resolving a given secret-id (key)
 	resolvers map[string]telegraf.ResolveFunc
 
+	// invalidators are the functions to notify the secret-stores providing
+	// parts of the secret that the secret was rejected
+	invalidators []func()
+
+	// template contains the secret before linking and templateResolvers
+	// the resolvers of all its parts to resolve static parts again after the
+	// secret was invalidated, stale is set in this case
+	template          secretContainer
+	templateResolvers map[string]telegraf.ResolveFunc
+	stale             bool
+
 	// unlinked contains all references in the secret that are not yet
 	// linked to the corresponding secret store.
 	unlinked []string
@@ -137,18 +155,22 @@ func (s *Secret) init(secret []byte) {
 		if secretPattern.MatchString(c) {
 			s.unlinked = append(s.unlinked, c)
 		} else {
-			log.Printf("W! Secret %q contains invalid character(s), only letters, digits and underscores are allowed.", c)
+			log.Printf("W! Secret %q contains invalid character(s), only letters, digits and underscores are allowed "+
+				"with additional '/', '#', '.' and '-' in the secret key.", c)
 		}
 	}
 	s.resolvers = nil
 
 	// Setup the container implementation
+	s.lock = &sync.RWMutex{}
 	s.container = selectedImpl.Container(secret)
 }
 
 // Destroy the secret content
 func (s *Secret) Destroy() {
 	s.resolvers = nil
+	s.invalidators = nil
+	s.destroyTemplate()
 	s.unlinked = nil
 	s.notempty = false
 
@@ -176,6 +198,8 @@ func (s *Secret) EqualTo(ref []byte) (bool, error) {
 		return false, fmt.Errorf("unlinked parts in secret: %v", strings.Join(s.unlinked, ";"))
 	}
 
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	return s.container.Equals(ref)
 }
 
@@ -189,6 +213,14 @@ func (s *Secret) Get() (SecretBuffer, error) {
 		return nil, fmt.Errorf("unlinked parts in secret: %v", strings.Join(s.unlinked, ";"))
 	}
 
+	// Resolve the static parts again after the secret was invalidated
+	if err := s.relinkIfStale(); err != nil {
+		return nil, err
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
 	// Decrypt the secret so we can return it
 	buffer, err := s.container.Buffer()
 	if err != nil {
@@ -224,19 +256,110 @@ func (s *Secret) Get() (SecretBuffer, error) {
 	return s.container.AsBuffer(newsecret), nil
 }
 
+// Invalidate notifies the secret-stores providing parts of the secret that
+// the secret was rejected, e.g. due to an authentication failure. Caching
+// secret-stores will then fetch the secret again on the next call to Get, in
+// which case static parts of the secret are resolved again as well.
+func (s *Secret) Invalidate() {
+	for _, fn := range s.invalidators {
+		fn()
+	}
+	if s.template == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stale = true
+}
+
+// keepTemplate stores the unlinked secret and the given resolvers for
+// resolving the secret again after invalidation
+func (s *Secret) keepTemplate(resolvers map[string]telegraf.ResolveFunc) error {
+	s.destroyTemplate()
+	if s.container == nil {
+		return nil
+	}
+	buffer, err := s.container.Buffer()
+	if err != nil {
+		return err
+	}
+	defer buffer.Destroy()
+
+	// The buffer might be read-only, so pass a copy and wipe it afterwards
+	template := bytes.Clone(buffer.Bytes())
+	s.template = selectedImpl.Container(template)
+	selectedImpl.Wipe(template)
+	s.templateResolvers = resolvers
+	return nil
+}
+
+// relinkIfStale resolves the template again if the secret was invalidated
+func (s *Secret) relinkIfStale() error {
+	s.lock.RLock()
+	stale := s.stale
+	s.lock.RUnlock()
+	if !stale {
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Another caller might have resolved the secret in the meantime
+	if !s.stale {
+		return nil
+	}
+	return s.relink()
+}
+
+// relink resolves the template again replacing the current secret, the
+// caller must hold the write lock
+func (s *Secret) relink() error {
+	buffer, err := s.template.Buffer()
+	if err != nil {
+		return err
+	}
+	defer buffer.Destroy()
+
+	newsecret, res, replaceErrs := resolve(buffer.Bytes(), s.templateResolvers)
+	if len(replaceErrs) > 0 {
+		selectedImpl.Wipe(newsecret)
+		return fmt.Errorf("resolving invalidated secrets failed: %s", strings.Join(replaceErrs, ";"))
+	}
+	s.container.Replace(newsecret)
+	s.resolvers = res
+	s.stale = false
+
+	return nil
+}
+
+func (s *Secret) destroyTemplate() {
+	if s.template != nil {
+		s.template.Destroy()
+	}
+	s.template = nil
+	s.templateResolvers = nil
+	s.stale = false
+}
+
 // Set overwrites the secret's value with a new one. Please note, the secret
 // is not linked again, so only references to secret-stores can be used, e.g. by
 // adding more clear-text or reordering secrets.
 func (s *Secret) Set(value []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	// Link the new value can be resolved
 	secret, res, replaceErrs := resolve(value, s.resolvers)
 	if len(replaceErrs) > 0 {
 		return fmt.Errorf("linking new secrets failed: %s", strings.Join(replaceErrs, ";"))
 	}
 
-	// Set the new secret
+	// Set the new secret, the previous value cannot be resolved again
 	s.container.Replace(secret)
 	s.resolvers = res
+	s.destroyTemplate()
 	s.notempty = len(value) > 0
 
 	return nil
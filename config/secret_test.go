@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"testing"
 
 	"github.com/awnumar/memguard"
@@ -417,6 +418,47 @@ func TestSecretStoreStatic(t *testing.T) {
 	}
 }
 
+func TestSecretStoreHierarchicalKeys(t *testing.T) {
+	cfg := []byte(
+		`
+[[inputs.mockup]]
+	secret = "@{mock:telegraf/influx_token}"
+[[inputs.mockup]]
+	secret = "@{mock:telegraf/db#password}"
+[[inputs.mockup]]
+	secret = "@{mock:app.prod}"
+[[inputs.mockup]]
+	secret = "@{mock:prod-db/password}"
+`)
+
+	c := NewConfig()
+	err := c.LoadConfigData(cfg)
+	require.NoError(t, err)
+	require.Len(t, c.Inputs, 4)
+
+	// Create a mockup secretstore
+	store := &MockupSecretStore{
+		Secrets: map[string][]byte{
+			"telegraf/influx_token": []byte("Ood Bnar"),
+			"telegraf/db#password":  []byte("Thon"),
+			"app.prod":              []byte("Obi-Wan Kenobi"),
+			"prod-db/password":      []byte("Arca Jeth"),
+		},
+	}
+	require.NoError(t, store.Init())
+	c.SecretStores["mock"] = store
+	require.NoError(t, c.LinkSecrets())
+
+	expected := []string{"Ood Bnar", "Thon", "Obi-Wan Kenobi", "Arca Jeth"}
+	for i, input := range c.Inputs {
+		plugin := input.Input.(*MockupSecretPlugin)
+		secret, err := plugin.Secret.Get()
+		require.NoError(t, err)
+		require.EqualValues(t, expected[i], secret.TemporaryString())
+		secret.Destroy()
+	}
+}
+
 func TestSecretStoreInvalidKeys(t *testing.T) {
 	cfg := []byte(
 		`
@@ -425,7 +467,7 @@ func TestSecretStoreInvalidKeys(t *testing.T) {
 [[inputs.mockup]]
 	secret = "@{mock:wild?%go}"
 [[inputs.mockup]]
-	secret = "@{mock:a-strange-secret}"
+	secret = "@{mock:a+strange+secret}"
 [[inputs.mockup]]
 	secret = "@{mock:a weird secret}"
 `)
@@ -440,7 +482,7 @@ func TestSecretStoreInvalidKeys(t *testing.T) {
 		Secrets: map[string][]byte{
 			"":                 []byte("Ood Bnar"),
 			"wild?%go":         []byte("Thon"),
-			"a-strange-secret": []byte("Obi-Wan Kenobi"),
+			"a+strange+secret": []byte("Obi-Wan Kenobi"),
 			"a weird secret":   []byte("Arca Jeth"),
 		},
 	}
@@ -451,7 +493,7 @@ func TestSecretStoreInvalidKeys(t *testing.T) {
 	expected := []string{
 		"@{mock:}",
 		"@{mock:wild?%go}",
-		"@{mock:a-strange-secret}",
+		"@{mock:a+strange+secret}",
 		"@{mock:a weird secret}",
 	}
 	for i, input := range c.Inputs {
@@ -463,6 +505,45 @@ func TestSecretStoreInvalidKeys(t *testing.T) {
 	}
 }
 
+func TestSecretStoreUseRunning(t *testing.T) {
+	running := NewConfig()
+	require.NoError(t, running.LoadConfigData([]byte(`
+[[secretstores.mockup]]
+  id = "mock"
+`)))
+	store, ok := running.SecretStores["mock"].(*MockupSecretStore)
+	require.True(t, ok)
+
+	// Unchanged secret-stores are taken over from the running configuration
+	unchanged := NewConfig()
+	unchanged.UseRunning(running)
+	require.NoError(t, unchanged.LoadConfigData([]byte(`
+[[secretstores.mockup]]
+  id = "mock"
+`)))
+	require.Same(t, store, unchanged.SecretStores["mock"])
+	unchanged.StopSecretStores()
+	require.False(t, store.Stopped)
+
+	// Changed secret-stores are new instances owned by the configuration
+	changed := NewConfig()
+	changed.UseRunning(running)
+	require.NoError(t, changed.LoadConfigData([]byte(`
+[[secretstores.mockup]]
+  id = "mock"
+  dynamic = true
+`)))
+	other, ok := changed.SecretStores["mock"].(*MockupSecretStore)
+	require.True(t, ok)
+	require.NotSame(t, store, other)
+	changed.StopSecretStores()
+	require.True(t, other.Stopped)
+	require.False(t, store.Stopped)
+
+	running.StopSecretStores()
+	require.True(t, store.Stopped)
+}
+
 func TestSecretStoreDeclarationMissingID(t *testing.T) {
 	defer func() { unlinkedSecrets = make([]*Secret, 0) }()
 
@@ -653,6 +734,107 @@ func (tsuite *SecretImplTestSuite) TestSecretStoreDynamic() {
 	}
 }
 
+func (tsuite *SecretImplTestSuite) TestSecretStoreInvalidate() {
+	t := tsuite.T()
+
+	cfg := []byte(
+		`
+[[inputs.mockup]]
+	secret = "@{dynamic:user}:@{dynamic:pass}@@{static:host}"
+[[inputs.mockup]]
+	secret = "@{static:host}"
+`)
+
+	c := NewConfig()
+	require.NoError(t, c.LoadConfigData(cfg))
+	require.Len(t, c.Inputs, 2)
+
+	// Create mockup secretstores
+	dynamic := &MockupSecretStore{
+		Secrets: map[string][]byte{"user": []byte("Obi-Wan"), "pass": []byte("Kenobi")},
+		Dynamic: true,
+	}
+	static := &MockupSecretStore{
+		Secrets: map[string][]byte{"host": []byte("Tatooine")},
+	}
+	c.SecretStores["dynamic"] = dynamic
+	c.SecretStores["static"] = static
+	require.NoError(t, c.LinkSecrets())
+
+	// Rotate the static secret, the linked value is used until invalidated
+	static.Secrets["host"] = []byte("Coruscant")
+	secret := &c.Inputs[0].Input.(*MockupSecretPlugin).Secret
+	requireSecret(t, "Obi-Wan:Kenobi@Tatooine", secret)
+
+	// All parts of the secret are invalidated and static ones resolved again
+	secret.Invalidate()
+	require.ElementsMatch(t, []string{"user", "pass"}, dynamic.Invalidated)
+	require.Equal(t, []string{"host"}, static.Invalidated)
+	requireSecret(t, "Obi-Wan:Kenobi@Coruscant", secret)
+
+	other := &c.Inputs[1].Input.(*MockupSecretPlugin).Secret
+	requireSecret(t, "Tatooine", other)
+	other.Invalidate()
+	require.Len(t, dynamic.Invalidated, 2)
+	require.Equal(t, []string{"host", "host"}, static.Invalidated)
+	requireSecret(t, "Coruscant", other)
+}
+
+func (tsuite *SecretImplTestSuite) TestSecretStoreInvalidateConcurrent() {
+	t := tsuite.T()
+
+	cfg := []byte(
+		`
+[[inputs.mockup]]
+	secret = "@{dynamic:user}@@{static:host}"
+`)
+
+	c := NewConfig()
+	require.NoError(t, c.LoadConfigData(cfg))
+	require.Len(t, c.Inputs, 1)
+
+	c.SecretStores["dynamic"] = &MockupSecretStore{
+		Secrets: map[string][]byte{"user": []byte("Obi-Wan")},
+		Dynamic: true,
+	}
+	c.SecretStores["static"] = &MockupSecretStore{
+		Secrets: map[string][]byte{"host": []byte("Tatooine")},
+	}
+	require.NoError(t, c.LinkSecrets())
+
+	// Invalidating while other users get the secret must not interfere
+	secret := &c.Inputs[0].Input.(*MockupSecretPlugin).Secret
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				secret.Invalidate()
+				buf, err := secret.Get()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if v := buf.String(); v != "Obi-Wan@Tatooine" {
+					t.Errorf("unexpected secret %q", v)
+				}
+				buf.Destroy()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func requireSecret(t *testing.T, expected string, secret *Secret) {
+	t.Helper()
+
+	buf, err := secret.Get()
+	require.NoError(t, err)
+	defer buf.Destroy()
+	require.Equal(t, expected, buf.TemporaryString())
+}
+
 func (tsuite *SecretImplTestSuite) TestSecretSet() {
 	t := tsuite.T()
 
@@ -754,13 +936,13 @@ func (tsuite *SecretImplTestSuite) TestSecretInvalidWarn() {
 
 	cfg := []byte(`
       [[inputs.mockup]]
-	    secret = "server=a user=@{mock:secret-with-invalid-chars} pass=@{mock:secret_pass}"
+	    secret = "server=a user=@{mock:secret+with+invalid+chars} pass=@{mock:secret_pass}"
 	`)
 	c := NewConfig()
 	require.NoError(t, c.LoadConfigData(cfg))
 	require.Len(t, c.Inputs, 1)
 
-	require.Contains(t, buf.String(), `W! Secret "@{mock:secret-with-invalid-chars}" contains invalid character(s)`)
+	require.Contains(t, buf.String(), `W! Secret "@{mock:secret+with+invalid+chars}" contains invalid character(s)`)
 	require.NotContains(t, buf.String(), "@{mock:secret_pass}")
 }
 
@@ -797,8 +979,12 @@ func (*MockupSecretPlugin) SampleConfig() string                { return "Mockup
 func (*MockupSecretPlugin) Gather(_ telegraf.Accumulator) error { return nil }
 
 type MockupSecretStore struct {
-	Secrets map[string][]byte
-	Dynamic bool
+	Secrets     map[string][]byte
+	Dynamic     bool
+	Invalidated []string
+	Stopped     bool
+
+	sync.Mutex
 }
 
 func (s *MockupSecretStore) Init() error {
@@ -828,6 +1014,16 @@ func (s *MockupSecretStore) List() ([]string, error) {
 	}
 	return keys, nil
 }
+func (s *MockupSecretStore) Stop() {
+	s.Stopped = true
+}
+
+func (s *MockupSecretStore) Invalidate(key string) {
+	s.Lock()
+	defer s.Unlock()
+	s.Invalidated = append(s.Invalidated, key)
+}
+
 func (s *MockupSecretStore) GetResolver(key string) (telegraf.ResolveFunc, error) {
 	return func() ([]byte, bool, error) {
 		v, err := s.Get(key)
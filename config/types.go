@@ -19,6 +19,9 @@ type Duration time.Duration
 // Size is an int64
 type Size int64
 
+// Number is a float64 parsed from both, integer and float values
+type Number float64
+
 // UnmarshalText parses the duration from the Text config file
 func (d *Duration) UnmarshalText(b []byte) error {
 	// convert to string
@@ -83,3 +86,13 @@ func (s *Size) UnmarshalText(b []byte) error {
 	*s = Size(val)
 	return nil
 }
+
+// UnmarshalTOML parses the number from the TOML config file
+func (n *Number) UnmarshalTOML(b []byte) error {
+	value, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		return err
+	}
+	*n = Number(value)
+	return nil
+}
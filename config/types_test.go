@@ -261,10 +261,39 @@ func TestTOMLParsingIntegerSizes(t *testing.T) {
 	}
 }
 
+func TestTOMLParsingNumbers(t *testing.T) {
+	cfg := []byte(`
+[[inputs.typesmockup]]
+	numbers = [
+		0,
+		1,
+		-2
+	]
+	number = 3.5
+`)
+
+	expected := []float64{0, 1, -2}
+
+	// Load the data
+	c := config.NewConfig()
+	err := c.LoadConfigData(cfg)
+	require.NoError(t, err)
+	require.Len(t, c.Inputs, 1)
+	plugin := c.Inputs[0].Input.(*MockupTypesPlugin)
+
+	require.Len(t, plugin.Numbers, len(expected))
+	for i, actual := range plugin.Numbers {
+		require.InDeltaf(t, expected[i], float64(actual), 1e-9, "case %d failed", i)
+	}
+	require.InDelta(t, 3.5, float64(plugin.Number), 1e-9)
+}
+
 // Mockup (input) plugin for testing to avoid cyclic dependencies
 type MockupTypesPlugin struct {
 	Durations []config.Duration `toml:"durations"`
 	Sizes     []config.Size     `toml:"sizes"`
+	Numbers   []config.Number   `toml:"numbers"`
+	Number    config.Number     `toml:"number"`
 }
 
 func (*MockupTypesPlugin) SampleConfig() string                { return "Mockup test types plugin" }
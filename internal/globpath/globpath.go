@@ -1,6 +1,7 @@
 package globpath
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -15,9 +16,78 @@ type GlobPath struct {
 	HasSuperMeta bool
 	rootGlob     string
 	g            glob.Glob
+
+	// pattern as given by the user, alternatives of the brace expanded pattern
+	// and the negated patterns excluding paths from the matches
+	pattern      string
+	alternatives []*GlobPath
+	excludes     []*GlobPath
 }
 
+// Compile compiles the given pattern. Braces containing comma separated
+// alternatives, e.g. "/var/log/{app,web}/*.log", are expanded to match any
+// of the alternatives, nested braces are supported.
 func Compile(path string) (*GlobPath, error) {
+	expanded := expandBraces(path)
+	if len(expanded) == 1 {
+		g, err := compile(path)
+		if err != nil {
+			return nil, err
+		}
+		g.pattern = path
+		return g, nil
+	}
+
+	out := GlobPath{pattern: path}
+	for _, p := range expanded {
+		g, err := compile(p)
+		if err != nil {
+			return nil, err
+		}
+		out.hasMeta = out.hasMeta || g.hasMeta
+		out.HasSuperMeta = out.HasSuperMeta || g.HasSuperMeta
+		out.alternatives = append(out.alternatives, g)
+	}
+	return &out, nil
+}
+
+// CompileList compiles the given list of patterns. Patterns prefixed with "!"
+// are negations excluding the paths they match, including everything below
+// matched directories, from the matches of all other patterns. Only the
+// non-negated patterns are returned in the given order.
+func CompileList(patterns []string) ([]*GlobPath, error) {
+	var excludes []*GlobPath
+	includes := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		if negated, found := strings.CutPrefix(p, "!"); found {
+			g, err := Compile(negated)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+			}
+			excludes = append(excludes, g)
+			continue
+		}
+		includes = append(includes, p)
+	}
+
+	globs := make([]*GlobPath, 0, len(includes))
+	for _, p := range includes {
+		g, err := Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		g.excludes = excludes
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+// Pattern returns the pattern the glob was compiled from
+func (g *GlobPath) Pattern() string {
+	return g.pattern
+}
+
+func compile(path string) (*GlobPath, error) {
 	out := GlobPath{
 		hasMeta:      hasMeta(path),
 		HasSuperMeta: hasSuperMeta(path),
@@ -45,6 +115,14 @@ func Compile(path string) (*GlobPath, error) {
 // If it's a static path, returns path.
 // All returned path will have the host platform separator.
 func (g *GlobPath) Match() []string {
+	if len(g.alternatives) > 0 {
+		var files []string
+		for _, alt := range g.alternatives {
+			files = append(files, alt.Match()...)
+		}
+		return g.filter(unique(files))
+	}
+
 	// This string replacement is for backwards compatibility support
 	// The original implementation allowed **.txt but the double star package requires **/**.txt
 	g.path = strings.ReplaceAll(g.path, "**/**", "**")
@@ -52,12 +130,23 @@ func (g *GlobPath) Match() []string {
 
 	//nolint:errcheck // pattern is known
 	files, _ := doublestar.Glob(g.path)
-	return files
+	return g.filter(files)
 }
 
 // MatchString tests the path string against the glob.  The path should contain
 // the host platform separator.
 func (g *GlobPath) MatchString(path string) bool {
+	if g.excluded(path) {
+		return false
+	}
+	if len(g.alternatives) > 0 {
+		for _, alt := range g.alternatives {
+			if alt.MatchString(path) {
+				return true
+			}
+		}
+		return false
+	}
 	if !g.HasSuperMeta {
 		//nolint:errcheck // pattern is known
 		res, _ := filepath.Match(g.path, path)
@@ -73,17 +162,114 @@ func (g *GlobPath) MatchString(path string) bool {
 // Note that it returns both files and directories.
 // All returned path will have the host platform separator.
 func (g *GlobPath) GetRoots() []string {
+	if len(g.alternatives) > 0 {
+		var roots []string
+		for _, alt := range g.alternatives {
+			roots = append(roots, alt.GetRoots()...)
+		}
+		return g.filter(unique(roots))
+	}
 	if !g.hasMeta {
-		return []string{g.path}
+		return g.filter([]string{g.path})
 	}
 	if !g.HasSuperMeta {
 		//nolint:errcheck // pattern is known
 		matches, _ := filepath.Glob(g.path)
-		return matches
+		return g.filter(matches)
 	}
 	//nolint:errcheck // pattern is known
 	roots, _ := filepath.Glob(g.rootGlob)
-	return roots
+	return g.filter(roots)
+}
+
+// filter removes all excluded paths
+func (g *GlobPath) filter(paths []string) []string {
+	if len(g.excludes) == 0 {
+		return paths
+	}
+
+	var filtered []string
+	for _, p := range paths {
+		if !g.excluded(p) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// excluded checks if the path or any of its parent directories matches one of
+// the negated patterns
+func (g *GlobPath) excluded(path string) bool {
+	for _, e := range g.excludes {
+		for p := filepath.Clean(path); ; {
+			if e.MatchString(p) {
+				return true
+			}
+			parent := filepath.Dir(p)
+			if parent == p {
+				break
+			}
+			p = parent
+		}
+	}
+	return false
+}
+
+// unique removes duplicate paths keeping the order of the first occurrence
+func unique(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	result := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if !seen[p] {
+			seen[p] = true
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// expandBraces expands the first brace group containing alternatives and
+// recursively the resulting patterns. Braces without a comma at their top
+// level and unbalanced braces are kept literally. On non-Windows platforms
+// a backslash escapes the following character.
+func expandBraces(pattern string) []string {
+	start := -1
+	var depth, last int
+	var parts []string
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			if os.PathSeparator != '\\' {
+				i++
+			}
+		case '{':
+			if depth == 0 {
+				start, last, parts = i, i+1, nil
+			}
+			depth++
+		case ',':
+			if depth == 1 {
+				parts = append(parts, pattern[last:i])
+				last = i + 1
+			}
+		case '}':
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth > 0 || len(parts) == 0 {
+				continue
+			}
+			parts = append(parts, pattern[last:i])
+
+			var expanded []string
+			for _, part := range parts {
+				expanded = append(expanded, expandBraces(pattern[:start]+part+pattern[i+1:])...)
+			}
+			return unique(expanded)
+		}
+	}
+	return []string{pattern}
 }
 
 // hasMeta reports whether path contains any magic glob characters.
@@ -57,6 +57,111 @@ func TestCompileAndMatch(t *testing.T) {
 	}
 }
 
+func TestCompileAndMatchBraces(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{
+			name:     "alternatives",
+			path:     filepath.Join(testdataDir, "{log1,log2}.log"),
+			expected: []string{"log1.log", "log2.log"},
+		},
+		{
+			name:     "nested braces",
+			path:     filepath.Join(testdataDir, "{log{1,2}.log,test.*}"),
+			expected: []string{"log1.log", "log2.log", "test.conf"},
+		},
+		{
+			name:     "directory alternatives",
+			path:     filepath.Join(testdataDir, "{nested1,missing}", "**.txt"),
+			expected: []string{filepath.Join("nested1", "nested2", "nested.txt")},
+		},
+		{
+			name:     "duplicate matches",
+			path:     filepath.Join(testdataDir, "{log1,log*}.log"),
+			expected: []string{"log1.log", "log2.log", "log[!.log"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Compile(tt.path)
+			require.NoError(t, err)
+
+			expected := make([]string, 0, len(tt.expected))
+			for _, fn := range tt.expected {
+				expected = append(expected, filepath.Join(testdataDir, fn))
+			}
+			require.ElementsMatch(t, expected, g.Match())
+			for _, fn := range expected {
+				require.True(t, g.MatchString(fn), fn)
+			}
+		})
+	}
+}
+
+func TestExpandBraces(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"/var/log/*.log", []string{"/var/log/*.log"}},
+		{"/var/log/{app,web}/*.log", []string{"/var/log/app/*.log", "/var/log/web/*.log"}},
+		{"a{b,c{d,e}}f", []string{"abf", "acdf", "acef"}},
+		{"{a,b}{c,d}", []string{"ac", "ad", "bc", "bd"}},
+		{"a{,b}", []string{"a", "ab"}},
+		{"{a}{b,c}", []string{"{a}b", "{a}c"}},
+		{"a{b,c", []string{"a{b,c"}},
+		{"a}b,c{", []string{"a}b,c{"}},
+		{"\\{a,b}", []string{"\\{a,b}"}},
+		{"{a,a}", []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, expandBraces(tt.pattern), tt.pattern)
+	}
+}
+
+func TestCompileListNegation(t *testing.T) {
+	globs, err := CompileList([]string{
+		filepath.Join(testdataDir, "**"),
+		"!" + filepath.Join(testdataDir, "nested1"),
+		filepath.Join(testdataDir, "*.log"),
+		"!" + filepath.Join(testdataDir, "log2.log"),
+	})
+	require.NoError(t, err)
+	require.Len(t, globs, 2)
+	require.Equal(t, filepath.Join(testdataDir, "**"), globs[0].Pattern())
+
+	// The excluded directory matched by the super asterisk must be removed
+	// including its content
+	matches := globs[0].Match()
+	require.Contains(t, matches, filepath.Join(testdataDir, "log1.log"))
+	require.NotContains(t, matches, filepath.Join(testdataDir, "log2.log"))
+	for _, m := range matches {
+		require.NotContains(t, m, "nested1")
+	}
+	require.False(t, globs[0].MatchString(filepath.Join(testdataDir, "nested1", "nested2", "nested.txt")))
+	require.True(t, globs[0].MatchString(filepath.Join(testdataDir, "test.conf")))
+
+	// Negations apply to all patterns regardless of their position
+	require.ElementsMatch(t, []string{
+		filepath.Join(testdataDir, "log1.log"),
+		filepath.Join(testdataDir, "log[!.log"),
+	}, globs[1].Match())
+
+	// Roots must not contain excluded directories
+	require.NotContains(t, globs[0].GetRoots(), filepath.Join(testdataDir, "nested1"))
+}
+
+func TestCompileListOnlyNegation(t *testing.T) {
+	globs, err := CompileList([]string{"!" + filepath.Join(testdataDir, "*.log")})
+	require.NoError(t, err)
+	require.Empty(t, globs)
+}
+
 func TestRootGlob(t *testing.T) {
 	tests := []struct {
 		input  string
@@ -34,6 +34,7 @@ type FileWriter struct {
 	maxArchives              int
 	expireTime               time.Time
 	bytesWritten             int64
+	onRotate                 func()
 	sync.Mutex
 }
 
@@ -138,11 +139,24 @@ func (w *FileWriter) rotateIfNeeded() error {
 			// Ignore rotation errors and keep the log open
 			fmt.Printf("unable to rotate the file %q, %s", w.filename, err.Error())
 		}
-		return w.openCurrent()
+		if err := w.openCurrent(); err != nil {
+			return err
+		}
+		if w.onRotate != nil {
+			w.onRotate()
+		}
 	}
 	return nil
 }
 
+// OnRotate sets a function called after the current file was rotated and a
+// new file was opened.
+func (w *FileWriter) OnRotate(fn func()) {
+	w.Lock()
+	defer w.Unlock()
+	w.onRotate = fn
+}
+
 func (w *FileWriter) rotate() (err error) {
 	if err := w.current.Close(); err != nil {
 		return err
@@ -75,6 +75,26 @@ func TestFileWriter_SizeRotation(t *testing.T) {
 	require.Len(t, files, 2)
 }
 
+func TestFileWriter_OnRotate(t *testing.T) {
+	tempDir := t.TempDir()
+	maxSize := int64(9)
+	writer, err := NewFileWriter(filepath.Join(tempDir, "test.log"), 0, maxSize, -1)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, writer.Close()) })
+
+	var rotations int
+	writer.(*FileWriter).OnRotate(func() { rotations++ })
+
+	_, err = writer.Write([]byte("Hello"))
+	require.NoError(t, err)
+	require.Zero(t, rotations)
+	_, err = writer.Write([]byte("World"))
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("Hi"))
+	require.NoError(t, err)
+	require.Equal(t, 1, rotations)
+}
+
 func TestFileWriter_ReopenSizeRotation(t *testing.T) {
 	tempDir := t.TempDir()
 	maxSize := int64(12)
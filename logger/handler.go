@@ -14,6 +14,7 @@ import (
 )
 
 type entry struct {
+	source     *logger
 	timestamp  time.Time
 	level      telegraf.LogLevel
 	prefix     string
@@ -62,7 +63,9 @@ func (h *handler) switchSink(impl sink, level telegraf.LogLevel, tz *time.Locati
 		current := h.earlylogs.Front()
 		for current != nil {
 			e := current.Value.(*entry)
-			h.impl.Print(e.level, e.timestamp.In(h.timezone), e.prefix, e.attributes, e.args...)
+			if e.includedBy(level) {
+				h.impl.Print(e.level, e.timestamp.In(h.timezone), e.prefix, e.attributes, e.args...)
+			}
 			next := current.Next()
 			h.earlylogs.Remove(current)
 			current = next
@@ -71,8 +74,18 @@ func (h *handler) switchSink(impl sink, level telegraf.LogLevel, tz *time.Locati
 	h.Unlock()
 }
 
-func (h *handler) add(level telegraf.LogLevel, ts time.Time, prefix string, attr map[string]interface{}, args ...interface{}) *entry {
+// includedBy checks if the entry passes the effective log-level of its source
+// logger, i.e. the level overridden for the plugin or the given default level.
+func (e *entry) includedBy(level telegraf.LogLevel) bool {
+	if e.source != nil && e.source.level != nil {
+		level = *e.source.level
+	}
+	return level.Includes(e.level)
+}
+
+func (h *handler) add(source *logger, level telegraf.LogLevel, ts time.Time, prefix string, attr map[string]interface{}, args ...interface{}) *entry {
 	e := &entry{
+		source:     source,
 		timestamp:  ts,
 		level:      level,
 		prefix:     prefix,
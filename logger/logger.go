@@ -138,7 +138,7 @@ func (l *logger) Trace(args ...interface{}) {
 func (l *logger) Print(level telegraf.LogLevel, ts time.Time, args ...interface{}) {
 	// Check if we are in early logging state and store the message in this case
 	if instance.impl == nil {
-		instance.add(level, ts, l.prefix, l.attributes, args...)
+		instance.add(l, level, ts, l.prefix, l.attributes, args...)
 	}
 
 	// Skip all messages with insufficient log-levels
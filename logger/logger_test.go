@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"io"
 	"os"
 	"testing"
 
@@ -34,3 +35,36 @@ func TestErrorCounting(t *testing.T) {
 
 	require.Equal(t, int64(2), reg.Get())
 }
+
+func TestEarlyLogsPluginLogLevel(t *testing.T) {
+	instance = defaultHandler()
+	instance.earlysink.SetOutput(io.Discard)
+
+	tmpfile, err := os.CreateTemp("", "")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	// Log messages before the logging is set up
+	quieted := New("testing", "quiet", "")
+	require.NoError(t, quieted.SetLogLevel("error"))
+	quieted.Info("dropped")
+	verbose := New("testing", "verbose", "")
+	require.NoError(t, verbose.SetLogLevel("debug"))
+	verbose.Debug("kept")
+	general := New("testing", "general", "")
+	general.Debug("dropped")
+	general.Info("kept")
+
+	cfg := &Config{
+		Logfile:             tmpfile.Name(),
+		LogFormat:           "text",
+		RotationMaxArchives: -1,
+	}
+	require.NoError(t, SetupLogging(cfg))
+
+	buf, err := os.ReadFile(tmpfile.Name())
+	require.NoError(t, err)
+	require.NotContains(t, string(buf), "dropped")
+	require.Contains(t, string(buf), "D! [testing.verbose] kept")
+	require.Contains(t, string(buf), "I! [testing.general] kept")
+}
@@ -235,6 +235,46 @@ func TestStructuredDerivedLoggerWithAttributes(t *testing.T) {
 	require.Equal(t, expected, actual)
 }
 
+func TestStructuredPluginLogLevel(t *testing.T) {
+	instance = defaultHandler()
+
+	tmpfile, err := os.CreateTemp("", "")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	cfg := &Config{
+		Logfile:             tmpfile.Name(),
+		LogFormat:           "structured",
+		RotationMaxArchives: -1,
+		Quiet:               true,
+	}
+	require.NoError(t, SetupLogging(cfg))
+
+	l := New("testing", "test", "myalias")
+	require.NoError(t, l.SetLogLevel("debug"))
+	l.Debug("TEST")
+	l.Trace("TEST") // <- should be ignored
+
+	buf, err := os.ReadFile(tmpfile.Name())
+	require.NoError(t, err)
+
+	expected := map[string]interface{}{
+		"level":    "DEBUG",
+		"msg":      "TEST",
+		"category": "testing",
+		"plugin":   "test",
+		"alias":    "myalias",
+	}
+
+	var actual map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf, &actual))
+
+	require.Contains(t, actual, "time")
+	require.NotEmpty(t, actual["time"])
+	delete(actual, "time")
+	require.Equal(t, expected, actual)
+}
+
 func TestStructuredWriteToTruncatedFile(t *testing.T) {
 	tmpfile, err := os.CreateTemp("", "")
 	require.NoError(t, err)
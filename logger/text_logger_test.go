@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -212,6 +213,73 @@ func TestTextWriteDerivedLoggerWithAttributes(t *testing.T) {
 	require.Equal(t, "Z I! [testing.test::myalias] TEST\n", string(buf[19:]))
 }
 
+func TestTextPluginLogLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		debug    bool
+		quiet    bool
+		level    string
+		expected []string
+	}{
+		{
+			name:     "debug override",
+			level:    "debug",
+			expected: []string{"E! [testing.test::myalias] TEST", "I! [testing.test::myalias] TEST", "D! [testing.test::myalias] TEST"},
+		},
+		{
+			name:     "debug override in quiet mode",
+			quiet:    true,
+			level:    "debug",
+			expected: []string{"E! [testing.test::myalias] TEST", "I! [testing.test::myalias] TEST", "D! [testing.test::myalias] TEST"},
+		},
+		{
+			name:     "error override in debug mode",
+			debug:    true,
+			level:    "error",
+			expected: []string{"E! [testing.test::myalias] TEST"},
+		},
+		{
+			name:     "no override",
+			expected: []string{"E! [testing.test::myalias] TEST", "I! [testing.test::myalias] TEST"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance = defaultHandler()
+
+			tmpfile, err := os.CreateTemp("", "")
+			require.NoError(t, err)
+			defer os.Remove(tmpfile.Name())
+
+			cfg := &Config{
+				Logfile:             tmpfile.Name(),
+				LogFormat:           "text",
+				RotationMaxArchives: -1,
+				Debug:               tt.debug,
+				Quiet:               tt.quiet,
+			}
+			require.NoError(t, SetupLogging(cfg))
+
+			l := New("testing", "test", "myalias")
+			require.NoError(t, l.SetLogLevel(tt.level))
+			l.Error("TEST")
+			l.Info("TEST")
+			l.Debug("TEST")
+
+			buf, err := os.ReadFile(tmpfile.Name())
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(buf)), "\n")
+			actual := make([]string, 0, len(lines))
+			for _, line := range lines {
+				require.Greater(t, len(line), 21)
+				actual = append(actual, line[21:])
+			}
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
 func BenchmarkTelegrafTextLogWrite(b *testing.B) {
 	l, err := createTextLogger(&Config{})
 	require.NoError(b, err)
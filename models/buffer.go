@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
@@ -50,19 +51,47 @@ type BufferStats struct {
 	MetricsDropped selfstat.Stat
 	BufferSize     selfstat.Stat
 	BufferLimit    selfstat.Stat
+
+	// MetricsDroppedOldest and MetricsDroppedNewest count the dropped metrics
+	// by the end of the buffer they were discarded from
+	MetricsDroppedOldest selfstat.Stat
+	MetricsDroppedNewest selfstat.Stat
+
+	// MetricsCorrupted counts the metrics skipped due to corrupted data when
+	// reading from persistent buffers
+	MetricsCorrupted selfstat.Stat
+
+	// OldestMetric is the time, in unix nanoseconds, the oldest metric still
+	// in the buffer was added or zero if the buffer is empty. BufferAge
+	// reports the age of this metric in seconds at the time of collection.
+	OldestMetric selfstat.Stat
+	BufferAge    selfstat.Stat
 }
 
-// NewBuffer returns a new empty Buffer with the given capacity.
-func NewBuffer(name, id, alias string, capacity int, strategy, path string) (Buffer, error) {
+// NewBuffer returns a new empty Buffer with the given capacity. For the "disk"
+// strategy, the buffer is stored in path, limited to diskLimit bytes, and
+// synced to disk according to the given sync policy. The overflow strategy
+// determines whether the oldest or the newest metrics are dropped once the
+// buffer is full.
+func NewBuffer(name, id, alias string, capacity int, strategy, path string, diskLimit int64, diskSync, overflow string) (Buffer, error) {
 	registerGob()
 
+	switch overflow {
+	case "":
+		overflow = "drop_oldest"
+	case "drop_oldest", "drop_newest":
+		// Do nothing as those are valid settings
+	default:
+		return nil, fmt.Errorf("invalid buffer overflow strategy %q", overflow)
+	}
+
 	bs := NewBufferStats(name, alias, capacity)
 
 	switch strategy {
 	case "", "memory":
-		return NewMemoryBuffer(capacity, bs)
+		return NewMemoryBuffer(capacity, overflow, bs)
 	case "disk":
-		return NewDiskBuffer(name, id, path, bs)
+		return NewDiskBuffer(name, id, path, diskLimit, diskSync, overflow, bs)
 	}
 	return nil, fmt.Errorf("invalid buffer strategy %q", strategy)
 }
@@ -99,8 +128,42 @@ func NewBufferStats(name, alias string, capacity int) BufferStats {
 			"buffer_limit",
 			tags,
 		),
+		MetricsCorrupted: selfstat.Register(
+			"write",
+			"metrics_corrupted",
+			tags,
+		),
+		MetricsDroppedOldest: selfstat.Register(
+			"write",
+			"metrics_dropped_oldest",
+			tags,
+		),
+		MetricsDroppedNewest: selfstat.Register(
+			"write",
+			"metrics_dropped_newest",
+			tags,
+		),
+		OldestMetric: selfstat.Register(
+			"write",
+			"oldest_metric_timestamp",
+			tags,
+		),
 	}
+	oldest := bs.OldestMetric
+	bs.BufferAge = selfstat.RegisterFunc(
+		"write",
+		"buffer_age_seconds",
+		tags,
+		func() int64 {
+			added := oldest.Get()
+			if added == 0 {
+				return 0
+			}
+			return int64(time.Since(time.Unix(0, added)).Seconds())
+		},
+	)
 	bs.BufferSize.Set(int64(0))
+	bs.OldestMetric.Set(int64(0))
 	bs.BufferLimit.Set(int64(capacity))
 	return bs
 }
@@ -115,8 +178,22 @@ func (b *BufferStats) metricWritten(m telegraf.Metric) {
 	m.Accept()
 }
 
+func (b *BufferStats) metricCorrupted() {
+	b.MetricsCorrupted.Incr(1)
+}
+
 func (b *BufferStats) metricDropped(m telegraf.Metric) {
 	AgentMetricsDropped.Incr(1)
 	b.MetricsDropped.Incr(1)
 	m.Reject()
 }
+
+func (b *BufferStats) metricDroppedOldest(m telegraf.Metric) {
+	b.MetricsDroppedOldest.Incr(1)
+	b.metricDropped(m)
+}
+
+func (b *BufferStats) metricDroppedNewest(m telegraf.Metric) {
+	b.MetricsDroppedNewest.Incr(1)
+	b.metricDropped(m)
+}
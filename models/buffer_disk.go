@@ -1,11 +1,15 @@
 package models
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/tidwall/wal"
 
@@ -13,6 +17,13 @@ import (
 	"github.com/influxdata/telegraf/metric"
 )
 
+// addedEntries records the time, in unix nanoseconds, the entries starting at
+// index were added to the buffer
+type addedEntries struct {
+	index uint64
+	time  int64
+}
+
 type DiskBuffer struct {
 	BufferStats
 	sync.Mutex
@@ -20,22 +31,47 @@ type DiskBuffer struct {
 	file *wal.Log
 	path string
 
+	limit      int64  // Maximum size of the buffer files in bytes, zero means unlimited
+	size       int64  // Current size of the buffer files in bytes
+	syncPolicy string // Policy for syncing the buffer files to disk
+	dropNewest bool   // Drop new metrics instead of the oldest ones if full
+
 	batchFirst uint64 // Index of the first metric in the batch
+	batchEnd   uint64 // Index following the last entry read for the batch
 	batchSize  uint64 // Number of metrics currently in the batch
 
 	// Ending point of metrics read from disk on telegraf launch.
 	// Used to know whether to discard tracking metrics.
 	originalEnd uint64
 
+	// Time the entries starting at the given index were added, one element
+	// per call to Add. Entries left over from a previous run are attributed
+	// to the time the buffer was opened.
+	added  []addedEntries
+	opened int64
+
 	// The WAL library currently has no way to "fully empty" the walfile. In this case,
 	// we have to do our best and track that the walfile "should" be empty, so that next
 	// write, we can remove the invalid entry (also skipping this entry if it is being read).
 	isEmpty bool
 }
 
-func NewDiskBuffer(name, id, path string, stats BufferStats) (*DiskBuffer, error) {
+func NewDiskBuffer(name, id, path string, limit int64, syncPolicy, overflow string, stats BufferStats) (*DiskBuffer, error) {
+	opts := *wal.DefaultOptions
+	switch syncPolicy {
+	case "", "always":
+		syncPolicy = "always"
+	case "flush", "never":
+		opts.NoSync = true
+	default:
+		return nil, fmt.Errorf("invalid buffer disk sync policy %q", syncPolicy)
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("invalid buffer disk limit %d", limit)
+	}
+
 	filePath := filepath.Join(path, id)
-	walFile, err := wal.Open(filePath, nil)
+	walFile, err := openWAL(name, id, filePath, &opts, stats)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open wal file: %w", err)
 	}
@@ -50,13 +86,42 @@ func NewDiskBuffer(name, id, path string, stats BufferStats) (*DiskBuffer, error
 		BufferStats: stats,
 		file:        walFile,
 		path:        filePath,
+		limit:       limit,
+		syncPolicy:  syncPolicy,
+		dropNewest:  overflow == "drop_newest",
+		opened:      time.Now().UnixNano(),
 	}
 	if buf.length() > 0 {
 		buf.originalEnd = buf.writeIndex()
 	}
+	buf.updateSize()
+	buf.updateOldest()
 	return buf, nil
 }
 
+// openWAL opens the WAL file in the given path. In case the last segment,
+// which is loaded on opening, is corrupt the segment is moved aside and
+// skipped to not prevent replaying the remaining segments.
+func openWAL(name, id, path string, opts *wal.Options, stats BufferStats) (*wal.Log, error) {
+	for {
+		walFile, err := wal.Open(path, opts)
+		if !errors.Is(err, wal.ErrCorrupt) {
+			return walFile, err
+		}
+
+		segments := listSegments(path)
+		if len(segments) == 0 {
+			return nil, err
+		}
+		last := filepath.Join(path, segmentName(segments[len(segments)-1]))
+		if rerr := os.Rename(last, last+".corrupt"); rerr != nil {
+			return nil, fmt.Errorf("moving corrupt segment %q failed: %w", last, rerr)
+		}
+		stats.metricCorrupted()
+		log.Printf("E! Skipping corrupt buffer segment %q for plugin outputs.%s (%s)", last, name, id)
+	}
+}
+
 func (b *DiskBuffer) Len() int {
 	b.Lock()
 	defer b.Unlock()
@@ -96,35 +161,130 @@ func (b *DiskBuffer) Add(metrics ...telegraf.Metric) int {
 	b.Lock()
 	defer b.Unlock()
 
+	now := time.Now().UnixNano()
 	dropped := 0
 	for _, m := range metrics {
-		if !b.addSingleMetric(m) {
-			dropped++
+		index := b.writeIndex()
+		added, n := b.addSingleMetric(m)
+		dropped += n
+		if !added {
+			continue
+		}
+		if len(b.added) == 0 || b.added[len(b.added)-1].time != now {
+			b.added = append(b.added, addedEntries{index: index, time: now})
 		}
 		// as soon as a new metric is added, if this was empty, try to flush the "empty" metric out
 		b.handleEmptyFile()
 	}
 	b.BufferSize.Set(int64(b.length()))
+	b.updateOldest()
 	return dropped
 }
 
-func (b *DiskBuffer) addSingleMetric(m telegraf.Metric) bool {
+// addSingleMetric writes the metric to the buffer and returns whether the
+// metric was added and the number of dropped metrics
+func (b *DiskBuffer) addSingleMetric(m telegraf.Metric) (bool, int) {
 	data, err := metric.ToBytes(m)
 	if err != nil {
 		panic(err)
 	}
+
+	// Make room for the new metric if the buffer files reached the size limit
+	// or drop the metric if this is not possible
+	var dropped int
+	size := entrySize(data)
+	if b.limit > 0 && b.size+size > b.limit {
+		if !b.dropNewest {
+			dropped = b.dropOldest(size)
+		}
+		if b.size+size > b.limit {
+			b.metricDroppedNewest(m)
+			return false, dropped + 1
+		}
+	}
+
 	err = b.file.Write(b.writeIndex(), data)
 	if err == nil {
+		b.size += size
 		b.metricAdded()
-		return true
+		return true, dropped
 	}
-	return false
+	return false, dropped + 1
+}
+
+// dropOldest removes the oldest entries from the buffer until the given
+// number of bytes fit into the size limit. The entries of a pending batch are
+// kept as those might still be rejected, so the new metric is dropped instead.
+// The number of dropped metrics is returned.
+func (b *DiskBuffer) dropOldest(size int64) int {
+	if b.batchSize > 0 {
+		return 0
+	}
+
+	var dropped int
+	for b.length() > 0 && b.size+size > b.limit {
+		// Determine the entries to remove first to only truncate the file
+		// once as this rewrites the first segment
+		end := b.writeIndex()
+		index := b.readIndex()
+		var freed int64
+		var rescan bool
+		for index < end && b.size-freed+size > b.limit {
+			data, err := b.file.Read(index)
+			if err != nil {
+				// The size of the skipped entries is unknown, so stop here
+				// and determine the size of the remaining files instead
+				next := b.nextSegment(index, end)
+				log.Printf("E! Skipping corrupt buffer entries %d to %d: %v", index, next-1, err)
+				b.metricCorrupted()
+				index = next
+				rescan = true
+				break
+			}
+			freed += entrySize(data)
+
+			if m, err := metric.FromBytes(data); err == nil {
+				b.metricDroppedOldest(m)
+				dropped++
+			} else if !errors.Is(err, metric.ErrSkipTracking) {
+				log.Printf("E! Skipping corrupt buffer entry %d: %v", index, err)
+				b.metricCorrupted()
+			}
+			index++
+		}
+
+		// The last entry is kept in the file when emptying it, so the size
+		// must be determined in this case as well
+		if index >= end {
+			b.emptyFile()
+			rescan = true
+		} else if err := b.file.TruncateFront(index); err != nil {
+			log.Printf("E! readIndex: %d, buffer len: %d", b.readIndex(), b.length())
+			panic(err)
+		}
+		if b.originalEnd < b.readIndex() {
+			b.originalEnd = 0
+		}
+		if rescan {
+			b.updateSize()
+		} else {
+			b.size -= freed
+		}
+	}
+	return dropped
 }
 
 func (b *DiskBuffer) Batch(batchSize int) []telegraf.Metric {
 	b.Lock()
 	defer b.Unlock()
 
+	// Persist the buffered metrics once per flush if requested
+	if b.syncPolicy == "flush" {
+		if err := b.file.Sync(); err != nil {
+			log.Printf("E! Syncing buffer failed: %v", err)
+		}
+	}
+
 	if b.length() == 0 {
 		// no metrics in the wal file, so return an empty array
 		return make([]telegraf.Metric, 0)
@@ -138,7 +298,17 @@ func (b *DiskBuffer) Batch(batchSize int) []telegraf.Metric {
 	for batchSize > 0 && readIndex < endIndex {
 		data, err := b.file.Read(readIndex)
 		if err != nil {
-			panic(err)
+			// The segment containing the entry cannot be loaded so skip the
+			// whole segment. The segment is removed together with the batch
+			// as the log cannot be truncated within a corrupt segment.
+			next := b.nextSegment(readIndex, endIndex)
+			log.Printf("E! Skipping corrupt buffer entries %d to %d: %v", readIndex, next-1, err)
+			b.metricCorrupted()
+			readIndex = next
+			if len(metrics) > 0 {
+				break
+			}
+			continue
 		}
 		readIndex++
 
@@ -157,9 +327,10 @@ func (b *DiskBuffer) Batch(batchSize int) []telegraf.Metric {
 			continue
 		}
 		if err != nil {
-			// non-recoverable error in deserialization, abort
-			log.Printf("E! raw metric data: %v", data)
-			panic(err)
+			// corrupt entry, skip it to not block the remaining metrics
+			log.Printf("E! Skipping corrupt buffer entry %d: %v", readIndex-1, err)
+			b.metricCorrupted()
+			continue
 		}
 		if _, ok := m.(telegraf.TrackingMetric); ok && readIndex < b.originalEnd {
 			// tracking metric left over from previous instance, skip
@@ -170,6 +341,16 @@ func (b *DiskBuffer) Batch(batchSize int) []telegraf.Metric {
 		b.batchSize++
 		batchSize--
 	}
+	b.batchEnd = readIndex
+
+	// Remove skipped entries right away if there is nothing to accept as
+	// those would block the buffer otherwise
+	if len(metrics) == 0 {
+		if b.batchEnd > b.batchFirst {
+			b.removeBatch()
+		}
+		b.resetBatch()
+	}
 	return metrics
 }
 
@@ -184,12 +365,19 @@ func (b *DiskBuffer) Accept(batch []telegraf.Metric) {
 	for _, m := range batch {
 		b.metricWritten(m)
 	}
-	if b.length() == len(batch) {
+	b.removeBatch()
+	b.resetBatch()
+}
+
+// removeBatch removes all entries read for the current batch, including
+// skipped entries, from the front of the buffer
+func (b *DiskBuffer) removeBatch() {
+	if b.batchEnd >= b.writeIndex() {
 		b.emptyFile()
 	} else {
-		err := b.file.TruncateFront(b.batchFirst + uint64(len(batch)))
+		err := b.file.TruncateFront(b.batchEnd)
 		if err != nil {
-			log.Printf("E! batch length: %d, batchFirst: %d, batchSize: %d", len(batch), b.batchFirst, b.batchSize)
+			log.Printf("E! batchFirst: %d, batchEnd: %d, batchSize: %d", b.batchFirst, b.batchEnd, b.batchSize)
 			panic(err)
 		}
 	}
@@ -199,8 +387,9 @@ func (b *DiskBuffer) Accept(batch []telegraf.Metric) {
 		b.originalEnd = 0
 	}
 
-	b.resetBatch()
+	b.updateSize()
 	b.BufferSize.Set(int64(b.length()))
+	b.updateOldest()
 }
 
 func (b *DiskBuffer) Reject(_ []telegraf.Metric) {
@@ -219,11 +408,96 @@ func (b *DiskBuffer) Close() error {
 	return b.file.Close()
 }
 
+// updateOldest removes the timestamps of entries no longer in the buffer and
+// sets the time the oldest remaining entry was added
+func (b *DiskBuffer) updateOldest() {
+	if b.length() == 0 {
+		b.added = b.added[:0]
+		b.OldestMetric.Set(0)
+		return
+	}
+
+	readIndex := b.readIndex()
+	var drop int
+	for drop < len(b.added)-1 && b.added[drop+1].index <= readIndex {
+		drop++
+	}
+	b.added = b.added[drop:]
+
+	if len(b.added) == 0 || b.added[0].index > readIndex {
+		b.OldestMetric.Set(b.opened)
+		return
+	}
+	b.OldestMetric.Set(b.added[0].time)
+}
+
 func (b *DiskBuffer) resetBatch() {
 	b.batchFirst = 0
+	b.batchEnd = 0
 	b.batchSize = 0
 }
 
+// updateSize determines the current size of the buffer files
+func (b *DiskBuffer) updateSize() {
+	entries, err := os.ReadDir(b.path)
+	if err != nil {
+		log.Printf("E! Determining buffer size failed: %v", err)
+		return
+	}
+
+	var size int64
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+	}
+	b.size = size
+}
+
+// nextSegment returns the first index of the segment following the one
+// containing the given index or end if there is no such segment
+func (b *DiskBuffer) nextSegment(index, end uint64) uint64 {
+	for _, start := range listSegments(b.path) {
+		if start > index && start < end {
+			return start
+		}
+	}
+	return end
+}
+
+// listSegments returns the sorted start indices of all segments in the WAL
+// file, the segment files are named after their first index
+func listSegments(path string) []uint64 {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil
+	}
+
+	segments := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || len(entry.Name()) != 20 {
+			continue
+		}
+		index, err := strconv.ParseUint(entry.Name(), 10, 64)
+		if err != nil || index == 0 {
+			continue
+		}
+		segments = append(segments, index)
+	}
+	return segments
+}
+
+// entrySize returns the number of bytes occupied by the given data in the
+// WAL file including the length prefix
+func entrySize(data []byte) int64 {
+	var prefix [binary.MaxVarintLen64]byte
+	return int64(binary.PutUvarint(prefix[:], uint64(len(data))) + len(data))
+}
+
+func segmentName(index uint64) string {
+	return fmt.Sprintf("%020d", index)
+}
+
 // This is very messy and not ideal, but serves as the only way I can find currently
 // to actually treat the walfile as empty if needed, since Truncate() calls require
 // that at least one entry remains in them otherwise they return an error.
@@ -1,7 +1,9 @@
 package models
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,7 +21,7 @@ func TestDiskBufferRetainsTrackingInformation(t *testing.T) {
 	var delivered int
 	mm, _ := metric.WithTracking(m, func(telegraf.DeliveryInfo) { delivered++ })
 
-	buf, err := NewBuffer("test", "123", "", 0, "disk", t.TempDir())
+	buf, err := NewBuffer("test", "123", "", 0, "disk", t.TempDir(), 0, "", "")
 	require.NoError(t, err)
 	buf.Stats().MetricsAdded.Set(0)
 	buf.Stats().MetricsWritten.Set(0)
@@ -78,7 +80,7 @@ func TestDiskBufferTrackingDroppedFromOldWal(t *testing.T) {
 	walfile.Close()
 
 	// Create a buffer
-	buf, err := NewBuffer("123", "123", "", 0, "disk", path)
+	buf, err := NewBuffer("123", "123", "", 0, "disk", path, 0, "", "")
 	require.NoError(t, err)
 	buf.Stats().MetricsAdded.Set(0)
 	buf.Stats().MetricsWritten.Set(0)
@@ -93,3 +95,270 @@ func TestDiskBufferTrackingDroppedFromOldWal(t *testing.T) {
 	}
 	testutil.RequireMetricsEqual(t, expected, batch)
 }
+
+func TestDiskBufferAcceptRemovesSkippedEntries(t *testing.T) {
+	m := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 42.0}, time.Unix(0, 0))
+	tm, _ := metric.WithTracking(m, func(telegraf.DeliveryInfo) {})
+
+	registerGob()
+
+	// Prefill the WAL file with a tracking metric of a previous instance
+	path := t.TempDir()
+	walfile, err := wal.Open(filepath.Join(path, "123"), nil)
+	require.NoError(t, err)
+	for i, x := range []telegraf.Metric{m, tm, m, m} {
+		data, err := metric.ToBytes(x)
+		require.NoError(t, err)
+		require.NoError(t, walfile.Write(uint64(i+1), data))
+	}
+	require.NoError(t, walfile.Close())
+
+	buf, err := NewBuffer("123", "123", "", 0, "disk", path, 0, "", "")
+	require.NoError(t, err)
+	defer buf.Close()
+
+	batch := buf.Batch(3)
+	require.Len(t, batch, 3)
+	buf.Accept(batch)
+
+	// The skipped entry must not cause the last metric to be sent again
+	require.Zero(t, buf.Len())
+	require.Empty(t, buf.Batch(3))
+}
+
+func TestDiskBufferLimit(t *testing.T) {
+	m := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 42.0}, time.Unix(0, 0))
+
+	registerGob()
+	data, err := metric.ToBytes(m)
+	require.NoError(t, err)
+
+	// Allow for five metrics
+	buf, err := NewBuffer("test", "123", "", 0, "disk", t.TempDir(), 5*entrySize(data), "", "drop_newest")
+	require.NoError(t, err)
+	buf.Stats().MetricsAdded.Set(0)
+	buf.Stats().MetricsDropped.Set(0)
+	buf.Stats().MetricsDroppedNewest.Set(0)
+	defer buf.Close()
+
+	require.Equal(t, 2, buf.Add(m, m, m, m, m, m, m))
+	require.Equal(t, 5, buf.Len())
+	require.Equal(t, int64(5), buf.Stats().MetricsAdded.Get())
+	require.Equal(t, int64(2), buf.Stats().MetricsDropped.Get())
+	require.Equal(t, int64(2), buf.Stats().MetricsDroppedNewest.Get())
+
+	// Writing metrics frees up space
+	batch := buf.Batch(3)
+	require.Len(t, batch, 3)
+	buf.Accept(batch)
+	require.Zero(t, buf.Add(m, m))
+	require.Equal(t, 4, buf.Len())
+}
+
+func TestDiskBufferLimitDropOldest(t *testing.T) {
+	metrics := make([]telegraf.Metric, 0, 7)
+	for i := range 7 {
+		metrics = append(metrics, metric.New("cpu", map[string]string{}, map[string]interface{}{"value": i}, time.Unix(0, 0)))
+	}
+
+	registerGob()
+	data, err := metric.ToBytes(metrics[0])
+	require.NoError(t, err)
+
+	// Allow for five metrics
+	buf, err := NewBuffer("drop_oldest", "123", "", 0, "disk", t.TempDir(), 5*entrySize(data), "", "drop_oldest")
+	require.NoError(t, err)
+	defer buf.Close()
+
+	require.Equal(t, 2, buf.Add(metrics...))
+	require.Equal(t, 5, buf.Len())
+	require.Equal(t, int64(2), buf.Stats().MetricsDroppedOldest.Get())
+	require.Zero(t, buf.Stats().MetricsDroppedNewest.Get())
+
+	// Entries of a pending batch are kept so new metrics are dropped instead
+	batch := buf.Batch(2)
+	testutil.RequireMetricsEqual(t, metrics[2:4], batch)
+	require.Equal(t, 1, buf.Add(metrics[0]))
+	require.Equal(t, int64(1), buf.Stats().MetricsDroppedNewest.Get())
+	buf.Reject(batch)
+
+	testutil.RequireMetricsEqual(t, metrics[2:], buf.Batch(5))
+}
+
+func TestDiskBufferLimitDropOldestMultiple(t *testing.T) {
+	small := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 42}, time.Unix(0, 0))
+	large := metric.New("cpu", map[string]string{"host": strings.Repeat("x", 128)}, map[string]interface{}{"value": 42}, time.Unix(0, 0))
+
+	registerGob()
+	data, err := metric.ToBytes(small)
+	require.NoError(t, err)
+
+	// Allow for five small metrics
+	buf, err := NewBuffer("drop_oldest", "123", "", 0, "disk", t.TempDir(), 5*entrySize(data), "", "drop_oldest")
+	require.NoError(t, err)
+	defer buf.Close()
+	require.Zero(t, buf.Add(small, small, small, small, small))
+
+	// Adding a larger metric drops multiple entries at once
+	dropped := buf.Add(large)
+	require.Greater(t, dropped, 1)
+	require.Equal(t, 6-dropped, buf.Len())
+
+	// The tracked size must match the size of the buffer files
+	db := buf.(*DiskBuffer)
+	size := db.size
+	db.updateSize()
+	require.Equal(t, db.size, size)
+}
+
+func TestDiskBufferInvalidSettings(t *testing.T) {
+	_, err := NewBuffer("test", "123", "", 0, "disk", t.TempDir(), 0, "sometimes", "")
+	require.ErrorContains(t, err, `invalid buffer disk sync policy "sometimes"`)
+
+	_, err = NewBuffer("test", "123", "", 0, "disk", t.TempDir(), -1, "", "")
+	require.ErrorContains(t, err, "invalid buffer disk limit -1")
+}
+
+func TestDiskBufferSyncPolicies(t *testing.T) {
+	m := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 42.0}, time.Unix(0, 0))
+
+	for _, policy := range []string{"always", "flush", "never"} {
+		t.Run(policy, func(t *testing.T) {
+			path := t.TempDir()
+			buf, err := NewBuffer("test", "123", "", 0, "disk", path, 0, policy, "")
+			require.NoError(t, err)
+			buf.Add(m, m)
+			require.Len(t, buf.Batch(1), 1)
+			require.NoError(t, buf.Close())
+
+			// Metrics must survive a restart
+			buf, err = NewBuffer("test", "123", "", 0, "disk", path, 0, policy, "")
+			require.NoError(t, err)
+			defer buf.Close()
+			require.Equal(t, 2, buf.Len())
+		})
+	}
+}
+
+func TestDiskBufferOldestMetricRestart(t *testing.T) {
+	m := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 42.0}, time.Unix(0, 0))
+
+	path := t.TempDir()
+	buf, err := NewBuffer("oldest_restart", "123", "", 0, "disk", path, 0, "", "")
+	require.NoError(t, err)
+	buf.Add(m)
+	require.NoError(t, buf.Close())
+
+	// Metrics left over from a previous run are as old as the buffer
+	opened := time.Now().UnixNano()
+	buf, err = NewBuffer("oldest_restart", "123", "", 0, "disk", path, 0, "", "")
+	require.NoError(t, err)
+	defer buf.Close()
+	oldest := buf.Stats().OldestMetric
+	require.GreaterOrEqual(t, oldest.Get(), opened)
+
+	time.Sleep(time.Millisecond)
+	added := time.Now().UnixNano()
+	buf.Add(m)
+	require.Less(t, oldest.Get(), added)
+
+	buf.Accept(buf.Batch(1))
+	require.GreaterOrEqual(t, oldest.Get(), added)
+	buf.Accept(buf.Batch(1))
+	require.Zero(t, oldest.Get())
+}
+
+func TestDiskBufferCorruptEntry(t *testing.T) {
+	m := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 42.0}, time.Unix(0, 0))
+
+	registerGob()
+
+	// Prefill the WAL file with an undecodable entry
+	path := t.TempDir()
+	walfile, err := wal.Open(filepath.Join(path, "123"), nil)
+	require.NoError(t, err)
+	data, err := metric.ToBytes(m)
+	require.NoError(t, err)
+	require.NoError(t, walfile.Write(1, []byte("garbage")))
+	require.NoError(t, walfile.Write(2, data))
+	require.NoError(t, walfile.Close())
+
+	buf, err := NewBuffer("test", "123", "", 0, "disk", path, 0, "", "")
+	require.NoError(t, err)
+	buf.Stats().MetricsCorrupted.Set(0)
+	defer buf.Close()
+
+	batch := buf.Batch(2)
+	testutil.RequireMetricsEqual(t, []telegraf.Metric{m}, batch)
+	require.Equal(t, int64(1), buf.Stats().MetricsCorrupted.Get())
+	buf.Accept(batch)
+	require.Zero(t, buf.Len())
+}
+
+func TestDiskBufferCorruptOnlyEntries(t *testing.T) {
+	registerGob()
+
+	// Prefill the WAL file with undecodable entries only
+	path := t.TempDir()
+	walfile, err := wal.Open(filepath.Join(path, "123"), nil)
+	require.NoError(t, err)
+	require.NoError(t, walfile.Write(1, []byte("garbage")))
+	require.NoError(t, walfile.Write(2, []byte("more garbage")))
+	require.NoError(t, walfile.Close())
+
+	buf, err := NewBuffer("test", "123", "", 0, "disk", path, 0, "", "")
+	require.NoError(t, err)
+	defer buf.Close()
+
+	// The entries must be removed to not block the buffer
+	require.Empty(t, buf.Batch(5))
+	require.Zero(t, buf.Len())
+
+	m := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 42.0}, time.Unix(0, 0))
+	buf.Add(m)
+	testutil.RequireMetricsEqual(t, []telegraf.Metric{m}, buf.Batch(5))
+}
+
+func TestDiskBufferCorruptSegment(t *testing.T) {
+	m := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 42.0}, time.Unix(0, 0))
+
+	registerGob()
+	data, err := metric.ToBytes(m)
+	require.NoError(t, err)
+
+	// Prefill the WAL file using small segments
+	path := t.TempDir()
+	walfile, err := wal.Open(filepath.Join(path, "123"), &wal.Options{SegmentSize: 1})
+	require.NoError(t, err)
+	for i := uint64(1); i <= 4; i++ {
+		require.NoError(t, walfile.Write(i, data))
+	}
+	require.NoError(t, walfile.Close())
+
+	// Corrupt the second and the last segment, the latter is empty as the
+	// log creates a new segment after each write exceeding the segment size
+	for _, index := range []uint64{2, 5} {
+		fn := filepath.Join(path, "123", segmentName(index))
+		require.NoError(t, os.WriteFile(fn, []byte{0xff, 0xff, 0xff}, 0640))
+	}
+
+	// Use a dedicated name as the corruption is counted on opening already
+	buf, err := NewBuffer("corrupt_segment", "123", "", 0, "disk", path, 0, "", "")
+	require.NoError(t, err)
+	defer buf.Close()
+	require.FileExists(t, filepath.Join(path, "123", segmentName(5)+".corrupt"))
+	require.Equal(t, int64(1), buf.Stats().MetricsCorrupted.Get())
+
+	// The first batch stops at the corrupt segment
+	batch := buf.Batch(5)
+	testutil.RequireMetricsEqual(t, []telegraf.Metric{m}, batch)
+	buf.Accept(batch)
+	require.Equal(t, 2, buf.Len())
+
+	// The remaining metrics follow with the next batch
+	batch = buf.Batch(5)
+	testutil.RequireMetricsEqual(t, []telegraf.Metric{m, m}, batch)
+	buf.Accept(batch)
+	require.Zero(t, buf.Len())
+	require.Equal(t, int64(2), buf.Stats().MetricsCorrupted.Get())
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"sync"
+	"time"
 
 	"github.com/influxdata/telegraf"
 )
@@ -12,20 +13,25 @@ type MemoryBuffer struct {
 	BufferStats
 
 	buf   []telegraf.Metric
-	first int // index of the first/oldest metric
-	last  int // one after the index of the last/newest metric
-	size  int // number of metrics currently in the buffer
-	cap   int // the capacity of the buffer
+	added []int64 // time each metric was added in unix nanoseconds
+	first int     // index of the first/oldest metric
+	last  int     // one after the index of the last/newest metric
+	size  int     // number of metrics currently in the buffer
+	cap   int     // the capacity of the buffer
+
+	dropNewest bool // drop new metrics instead of the oldest ones if full
 
 	batchFirst int // index of the first metric in the batch
 	batchSize  int // number of metrics currently in the batch
 }
 
-func NewMemoryBuffer(capacity int, stats BufferStats) (*MemoryBuffer, error) {
+func NewMemoryBuffer(capacity int, overflow string, stats BufferStats) (*MemoryBuffer, error) {
 	return &MemoryBuffer{
 		BufferStats: stats,
 		buf:         make([]telegraf.Metric, capacity),
+		added:       make([]int64, capacity),
 		cap:         capacity,
+		dropNewest:  overflow == "drop_newest",
 	}, nil
 }
 
@@ -40,14 +46,16 @@ func (b *MemoryBuffer) Add(metrics ...telegraf.Metric) int {
 	b.Lock()
 	defer b.Unlock()
 
+	now := time.Now().UnixNano()
 	dropped := 0
 	for i := range metrics {
-		if n := b.addMetric(metrics[i]); n != 0 {
+		if n := b.addMetric(metrics[i], now); n != 0 {
 			dropped += n
 		}
 	}
 
 	b.BufferSize.Set(int64(b.length()))
+	b.updateOldest()
 	return dropped
 }
 
@@ -86,6 +94,7 @@ func (b *MemoryBuffer) Accept(batch []telegraf.Metric) {
 
 	b.resetBatch()
 	b.BufferSize.Set(int64(b.length()))
+	b.updateOldest()
 }
 
 func (b *MemoryBuffer) Reject(batch []telegraf.Metric) {
@@ -108,7 +117,7 @@ func (b *MemoryBuffer) Reject(batch []telegraf.Metric) {
 	// Copy metrics from the batch back into the buffer
 	for i := range batch {
 		if i < skip {
-			b.metricDropped(batch[i])
+			b.metricDroppedOldest(batch[i])
 		} else {
 			b.buf[re] = batch[i]
 			re = b.next(re)
@@ -117,6 +126,7 @@ func (b *MemoryBuffer) Reject(batch []telegraf.Metric) {
 
 	b.resetBatch()
 	b.BufferSize.Set(int64(b.length()))
+	b.updateOldest()
 }
 
 func (b *MemoryBuffer) Close() error {
@@ -131,11 +141,18 @@ func (b *MemoryBuffer) length() int {
 	return min(b.size+b.batchSize, b.cap)
 }
 
-func (b *MemoryBuffer) addMetric(m telegraf.Metric) int {
+func (b *MemoryBuffer) addMetric(m telegraf.Metric, now int64) int {
+	// Keep the space of the current batch to be able to return it to the
+	// buffer on reject and drop the new metric instead
+	if b.dropNewest && b.size+b.batchSize >= b.cap {
+		b.metricDroppedNewest(m)
+		return 1
+	}
+
 	dropped := 0
 	// Check if Buffer is full
 	if b.size == b.cap {
-		b.metricDropped(b.buf[b.last])
+		b.metricDroppedOldest(b.buf[b.last])
 		dropped++
 
 		if b.batchSize > 0 {
@@ -147,6 +164,7 @@ func (b *MemoryBuffer) addMetric(m telegraf.Metric) int {
 	b.metricAdded()
 
 	b.buf[b.last] = m
+	b.added[b.last] = now
 	b.last = b.next(b.last)
 
 	if b.size == b.cap {
@@ -188,3 +206,19 @@ func (b *MemoryBuffer) resetBatch() {
 	b.batchFirst = 0
 	b.batchSize = 0
 }
+
+// updateOldest sets the time the oldest metric was added. Metrics of a
+// pending batch are still part of the buffer unless they were overwritten, as
+// the batch might be rejected.
+func (b *MemoryBuffer) updateOldest() {
+	if b.length() == 0 {
+		b.OldestMetric.Set(0)
+		return
+	}
+
+	index := b.first
+	if overwritten := max(b.size+b.batchSize-b.cap, 0); b.batchSize > overwritten {
+		index = b.nextby(b.batchFirst, overwritten)
+	}
+	b.OldestMetric.Set(b.added[index])
+}
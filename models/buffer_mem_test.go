@@ -4,12 +4,15 @@ import (
 	"testing"
 	"time"
 
-	"github.com/influxdata/telegraf/metric"
 	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
 )
 
 func TestMemoryBufferAcceptCallsMetricAccept(t *testing.T) {
-	buf, err := NewBuffer("test", "123", "", 5, "memory", "")
+	buf, err := NewBuffer("test", "123", "", 5, "memory", "", 0, "", "")
 	require.NoError(t, err)
 	buf.Stats().MetricsAdded.Set(0)
 	buf.Stats().MetricsWritten.Set(0)
@@ -29,8 +32,51 @@ func TestMemoryBufferAcceptCallsMetricAccept(t *testing.T) {
 	require.Equal(t, 2, accept)
 }
 
+func TestMemoryBufferOverflowDropNewest(t *testing.T) {
+	buf, err := NewBuffer("drop_newest", "123", "", 3, "memory", "", 0, "", "drop_newest")
+	require.NoError(t, err)
+	defer buf.Close()
+
+	metrics := make([]telegraf.Metric, 0, 5)
+	for i := range 5 {
+		metrics = append(metrics, metric.New("cpu", map[string]string{}, map[string]interface{}{"value": i}, time.Unix(0, 0)))
+	}
+
+	require.Equal(t, 2, buf.Add(metrics...))
+	require.Equal(t, 3, buf.Len())
+	require.Equal(t, int64(2), buf.Stats().MetricsDroppedNewest.Get())
+	require.Zero(t, buf.Stats().MetricsDroppedOldest.Get())
+	testutil.RequireMetricsEqual(t, metrics[:3], buf.Batch(5))
+}
+
+func TestMemoryBufferOverflowDropNewestReject(t *testing.T) {
+	buf, err := NewBuffer("drop_newest_reject", "123", "", 3, "memory", "", 0, "", "drop_newest")
+	require.NoError(t, err)
+	defer buf.Close()
+
+	metrics := make([]telegraf.Metric, 0, 5)
+	for i := range 5 {
+		metrics = append(metrics, metric.New("cpu", map[string]string{}, map[string]interface{}{"value": i}, time.Unix(0, 0)))
+	}
+
+	// The space of the pending batch is kept for the batch to be returned
+	require.Zero(t, buf.Add(metrics[:2]...))
+	batch := buf.Batch(2)
+	require.Equal(t, 2, buf.Add(metrics[2:]...))
+	buf.Reject(batch)
+
+	require.Equal(t, 3, buf.Len())
+	require.Zero(t, buf.Stats().MetricsDroppedOldest.Get())
+	testutil.RequireMetricsEqual(t, metrics[:3], buf.Batch(5))
+}
+
+func TestBufferInvalidOverflowStrategy(t *testing.T) {
+	_, err := NewBuffer("test", "123", "", 5, "memory", "", 0, "", "drop_random")
+	require.ErrorContains(t, err, `invalid buffer overflow strategy "drop_random"`)
+}
+
 func BenchmarkMemoryBufferAddMetrics(b *testing.B) {
-	buf, err := NewBuffer("test", "123", "", 10000, "memory", "")
+	buf, err := NewBuffer("test", "123", "", 10000, "memory", "", 0, "", "")
 	require.NoError(b, err)
 	buf.Stats().MetricsAdded.Set(0)
 	buf.Stats().MetricsWritten.Set(0)
@@ -49,7 +49,7 @@ func TestDiskBufferSuite(t *testing.T) {
 
 func (s *BufferSuiteTest) newTestBuffer(capacity int) Buffer {
 	s.T().Helper()
-	buf, err := NewBuffer("test", "123", "", capacity, s.bufferType, s.bufferPath)
+	buf, err := NewBuffer("test", "123", "", capacity, s.bufferType, s.bufferPath, 0, "", "")
 	s.Require().NoError(err)
 	buf.Stats().MetricsAdded.Set(0)
 	buf.Stats().MetricsWritten.Set(0)
@@ -581,6 +581,74 @@ func (s *BufferSuiteTest) TestBufferRejectLeavesBatch() {
 	s.Equal(3, buf.Len())
 }
 
+func (s *BufferSuiteTest) TestBufferOldestMetric() {
+	buf := s.newTestBuffer(5)
+	defer buf.Close()
+	oldest := buf.Stats().OldestMetric
+	s.Zero(oldest.Get())
+
+	m := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 42.0}, time.Unix(0, 0))
+	first := time.Now().UnixNano()
+	buf.Add(m, m)
+	time.Sleep(time.Millisecond)
+	second := time.Now().UnixNano()
+	buf.Add(m)
+	s.GreaterOrEqual(oldest.Get(), first)
+	s.Less(oldest.Get(), second)
+
+	// Metrics of a pending batch are still in the buffer
+	batch := buf.Batch(1)
+	s.GreaterOrEqual(oldest.Get(), first)
+	s.Less(oldest.Get(), second)
+	buf.Reject(batch)
+	s.GreaterOrEqual(oldest.Get(), first)
+	s.Less(oldest.Get(), second)
+
+	// Partially writing the metrics added at the same time keeps the time
+	buf.Accept(buf.Batch(1))
+	s.GreaterOrEqual(oldest.Get(), first)
+	s.Less(oldest.Get(), second)
+
+	buf.Accept(buf.Batch(1))
+	s.GreaterOrEqual(oldest.Get(), second)
+	s.LessOrEqual(oldest.Get(), time.Now().UnixNano())
+
+	buf.Accept(buf.Batch(1))
+	s.Zero(oldest.Get())
+	s.Zero(buf.Stats().BufferAge.Get())
+}
+
+func (s *BufferSuiteTest) TestBufferOldestMetricDropped() {
+	if !s.hasMaxCapacity {
+		s.T().Skip("tested buffer does not have a maximum capacity")
+	}
+
+	buf := s.newTestBuffer(2)
+	defer buf.Close()
+	oldest := buf.Stats().OldestMetric
+
+	m := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 42.0}, time.Unix(0, 0))
+	buf.Add(m)
+	time.Sleep(time.Millisecond)
+	second := time.Now().UnixNano()
+	buf.Add(m)
+	time.Sleep(time.Millisecond)
+	third := time.Now().UnixNano()
+
+	// Overwriting the oldest metric of the batch makes the next one the oldest
+	batch := buf.Batch(2)
+	buf.Add(m)
+	s.GreaterOrEqual(oldest.Get(), second)
+	s.Less(oldest.Get(), third)
+	buf.Reject(batch)
+	s.GreaterOrEqual(oldest.Get(), second)
+	s.Less(oldest.Get(), third)
+
+	// Overwriting without a batch drops the oldest metric
+	buf.Add(m)
+	s.GreaterOrEqual(oldest.Get(), third)
+}
+
 func (s *BufferSuiteTest) TestBufferAcceptWritesOverwrittenBatch() {
 	buf := s.newTestBuffer(5)
 	defer buf.Close()
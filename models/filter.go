@@ -3,18 +3,32 @@ package models
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/operators"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
 	"github.com/google/cel-go/ext"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/filter"
+	"github.com/influxdata/telegraf/internal"
+	"github.com/influxdata/telegraf/selfstat"
 )
 
+var (
+	// GlobalMetricFilterErrors counts the failed evaluations of metric filters
+	GlobalMetricFilterErrors = selfstat.Register("agent", "metric_filter_errors", make(map[string]string))
+)
+
+// filterErrorInterval is the minimum interval between two reported
+// evaluation errors of a metric filter
+const filterErrorInterval = time.Minute
+
 // TagFilter is the name of a tag, and the values on which to filter
 type TagFilter struct {
 	Name   string
@@ -56,6 +70,7 @@ type Filter struct {
 	// New metric-filtering interface
 	MetricPass   string
 	metricFilter cel.Program
+	errorLimit   *errorLimiter
 
 	selectActive bool
 	modifyActive bool
@@ -149,17 +164,52 @@ func (f *Filter) Select(metric telegraf.Metric) (bool, error) {
 			"time":   metric.Time(),
 		})
 		if err != nil {
-			return true, err
+			return true, f.evalError(err)
 		}
 		if r, ok := result.Value().(bool); ok {
 			return r, nil
 		}
-		return true, fmt.Errorf("invalid result type %T", result.Value())
+		return true, f.evalError(fmt.Errorf("invalid result type %T", result.Value()))
 	}
 
 	return true, nil
 }
 
+// evalError counts the failed evaluation of the metric filter. As the filter
+// is evaluated for every metric, the error is only returned once per interval
+// including the number of errors suppressed in the meantime.
+func (f *Filter) evalError(err error) error {
+	GlobalMetricFilterErrors.Incr(1)
+	if f.errorLimit == nil {
+		return err
+	}
+	return f.errorLimit.check(err, time.Now())
+}
+
+// errorLimiter suppresses errors occurring within the filter error interval
+type errorLimiter struct {
+	last       time.Time
+	suppressed int
+	sync.Mutex
+}
+
+func (l *errorLimiter) check(err error, now time.Time) error {
+	l.Lock()
+	defer l.Unlock()
+
+	if !l.last.IsZero() && now.Sub(l.last) < filterErrorInterval {
+		l.suppressed++
+		return nil
+	}
+	l.last = now
+
+	if l.suppressed > 0 {
+		err = fmt.Errorf("%w (%d similar errors suppressed)", err, l.suppressed)
+		l.suppressed = 0
+	}
+	return err
+}
+
 // Modify removes any tags and fields from the metric according to the
 // fieldinclude/fieldexclude and taginclude/tagexclude filters.
 func (f *Filter) Modify(metric telegraf.Metric) {
@@ -258,6 +308,34 @@ func (f *Filter) compileMetricFilter() error {
 			cel.Overload("now", nil, cel.TimestampType),
 			cel.SingletonFunctionBinding(func(_ ...ref.Val) ref.Val { return types.Timestamp{Time: time.Now()} }),
 		),
+		cel.Function(
+			"to_float",
+			cel.Overload("to_float_dyn", []*cel.Type{cel.DynType}, cel.DoubleType,
+				cel.UnaryBinding(func(value ref.Val) ref.Val {
+					v, err := internal.ToFloat64(value.Value())
+					if err != nil {
+						return types.NewErr("to_float: %v", err)
+					}
+					return types.Double(v)
+				}),
+			),
+		),
+		cel.Function(
+			"to_int",
+			cel.Overload("to_int_dyn", []*cel.Type{cel.DynType}, cel.IntType,
+				cel.UnaryBinding(func(value ref.Val) ref.Val {
+					v, err := internal.ToInt64(value.Value())
+					if err != nil {
+						return types.NewErr("to_int: %v", err)
+					}
+					return types.Int(v)
+				}),
+			),
+		),
+		cel.Macros(
+			cel.GlobalMacro("has_tag", 1, existenceMacro("tags")),
+			cel.GlobalMacro("has_field", 1, existenceMacro("fields")),
+		),
 		ext.Encoders(),
 		ext.Math(),
 		ext.Strings(),
@@ -281,7 +359,19 @@ func (f *Filter) compileMetricFilter() error {
 		cel.OptOptimize,
 	)
 	f.metricFilter, err = env.Program(ast, options)
-	return err
+	if err != nil {
+		return err
+	}
+	f.errorLimit = &errorLimiter{}
+	return nil
+}
+
+// existenceMacro expands e.g. has_tag(key) to "key in tags" for the given
+// variable to check for the existence of the key.
+func existenceMacro(variable string) cel.MacroFactory {
+	return func(eh cel.MacroExprFactory, _ ast.Expr, args []ast.Expr) (ast.Expr, *cel.Error) {
+		return eh.NewCall(operators.In, args[0], eh.NewIdent(variable)), nil
+	}
 }
 
 func ShouldPassFilters(include, exclude filter.Filter, key string) bool {
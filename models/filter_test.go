@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -631,6 +632,41 @@ func TestFilterMetricPass(t *testing.T) {
 			expression: `fields.exists_one(f, type(fields[f]) in [int, uint, double] && fields[f] > 20.0)`,
 			expected:   false,
 		},
+		{
+			name:       "drop old metrics",
+			expression: `now() - time < duration("1h")`,
+			expected:   false,
+		},
+		{
+			name:       "tag exists",
+			expression: `has_tag("host") && !has_tag("missing")`,
+			expected:   true,
+		},
+		{
+			name:       "field exists",
+			expression: `has_field("count") && has_field("id")`,
+			expected:   true,
+		},
+		{
+			name:       "field does not exist",
+			expression: `has_field("missing")`,
+			expected:   false,
+		},
+		{
+			name:       "conditional access of optional field",
+			expression: `!has_field("missing") || fields.missing > 10`,
+			expected:   true,
+		},
+		{
+			name:       "float coercion",
+			expression: `to_float(fields.count) / to_float(fields.total) < 0.2 && to_float(fields.on) == 1.0`,
+			expected:   true,
+		},
+		{
+			name:       "int coercion",
+			expression: `to_int(fields.value) == 15 && to_int(fields.total) > to_int("100")`,
+			expected:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -646,6 +682,68 @@ func TestFilterMetricPass(t *testing.T) {
 	}
 }
 
+func TestFilterMetricPassErrors(t *testing.T) {
+	m := testutil.MustMetric("cpu",
+		map[string]string{},
+		map[string]interface{}{
+			"value": 42,
+			"id":    "abc",
+		},
+		time.Unix(0, 0),
+	)
+
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{
+			name:       "missing field",
+			expression: `fields.missing > 0`,
+		},
+		{
+			name:       "invalid coercion",
+			expression: `to_float(fields.id) > 0.0`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := Filter{MetricPass: tt.expression}
+			require.NoError(t, f.Compile())
+
+			// Errors are counted for every metric and the metric passes, but
+			// only the first error is reported within the interval
+			before := GlobalMetricFilterErrors.Get()
+			selected, err := f.Select(m)
+			require.Error(t, err)
+			require.True(t, selected)
+			for range 3 {
+				selected, err := f.Select(m)
+				require.NoError(t, err)
+				require.True(t, selected)
+			}
+			require.Equal(t, before+4, GlobalMetricFilterErrors.Get())
+		})
+	}
+}
+
+func TestErrorLimiter(t *testing.T) {
+	var l errorLimiter
+	start := time.Now()
+	errFailed := errors.New("failed")
+
+	require.ErrorIs(t, l.check(errFailed, start), errFailed)
+	require.NoError(t, l.check(errFailed, start.Add(time.Second)))
+	require.NoError(t, l.check(errFailed, start.Add(filterErrorInterval-time.Second)))
+
+	err := l.check(errFailed, start.Add(filterErrorInterval))
+	require.ErrorIs(t, err, errFailed)
+	require.EqualError(t, err, "failed (2 similar errors suppressed)")
+
+	err = l.check(errFailed, start.Add(3*filterErrorInterval))
+	require.EqualError(t, err, "failed")
+}
+
 func BenchmarkFilter(b *testing.B) {
 	tests := []struct {
 		name   string
@@ -715,6 +813,32 @@ func BenchmarkFilter(b *testing.B) {
 				time.Unix(0, 0),
 			),
 		},
+		{
+			name: "metric filter existence",
+			filter: Filter{
+				MetricPass: `has_tag("source") || has_field("value")`,
+			},
+			metric: testutil.MustMetric("cpu",
+				map[string]string{},
+				map[string]interface{}{
+					"value": 42,
+				},
+				time.Unix(0, 0),
+			),
+		},
+		{
+			name: "metric filter coercion",
+			filter: Filter{
+				MetricPass: `to_float(fields.value) > 20.0`,
+			},
+			metric: testutil.MustMetric("cpu",
+				map[string]string{},
+				map[string]interface{}{
+					"value": 42,
+				},
+				time.Unix(0, 0),
+			),
+		},
 		{
 			name: "metric filter complex",
 			filter: Filter{
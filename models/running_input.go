@@ -34,6 +34,9 @@ type RunningInput struct {
 	GatherTime      selfstat.Stat
 	GatherTimeouts  selfstat.Stat
 	StartupErrors   selfstat.Stat
+
+	// RuntimeStats is only set if collecting runtime statistics is enabled
+	RuntimeStats *RuntimeStats
 }
 
 func NewRunningInput(input telegraf.Input, config *InputConfig) *RunningInput {
@@ -53,6 +56,11 @@ func NewRunningInput(input telegraf.Input, config *InputConfig) *RunningInput {
 	}
 	SetLoggerOnPlugin(input, logger)
 
+	var runtimeStats *RuntimeStats
+	if config.RuntimeStats {
+		runtimeStats = NewRuntimeStats("input", config.Name, config.Alias)
+	}
+
 	return &RunningInput{
 		Input:  input,
 		Config: config,
@@ -76,7 +84,8 @@ func NewRunningInput(input telegraf.Input, config *InputConfig) *RunningInput {
 			"startup_errors",
 			tags,
 		),
-		log: logger,
+		RuntimeStats: runtimeStats,
+		log:          logger,
 	}
 }
 
@@ -100,6 +109,7 @@ type InputConfig struct {
 	Filter                  Filter
 	AlwaysIncludeLocalTags  bool
 	AlwaysIncludeGlobalTags bool
+	RuntimeStats            bool
 }
 
 func (r *RunningInput) metricFiltered(metric telegraf.Metric) {
@@ -139,7 +149,9 @@ func (r *RunningInput) Start(acc telegraf.Accumulator) error {
 
 	// Try to start the plugin and exit early on success
 	r.startAcc = acc
+	before := r.RuntimeStats.BeginStart()
 	err := plugin.Start(acc)
+	r.RuntimeStats.EndStart(before)
 	if err == nil {
 		r.started = true
 		return nil
@@ -174,6 +186,7 @@ func (r *RunningInput) Stop() {
 	if plugin, ok := r.Input.(telegraf.ServiceInput); ok {
 		plugin.Stop()
 	}
+	r.RuntimeStats.Stopped()
 }
 
 func (r *RunningInput) ID() string {
@@ -227,6 +240,7 @@ func (r *RunningInput) MakeMetric(metric telegraf.Metric) telegraf.Metric {
 
 	r.MetricsGathered.Incr(1)
 	GlobalMetricsGathered.Incr(1)
+	r.RuntimeStats.AddInFlight(1)
 	return metric
 }
 
@@ -234,7 +248,10 @@ func (r *RunningInput) Gather(acc telegraf.Accumulator) error {
 	// Try to connect if we are not yet started up
 	if plugin, ok := r.Input.(telegraf.ServiceInput); ok && !r.started {
 		r.retries++
-		if err := plugin.Start(r.startAcc); err != nil {
+		before := r.RuntimeStats.BeginStart()
+		err := plugin.Start(r.startAcc)
+		r.RuntimeStats.EndStart(before)
+		if err != nil {
 			var serr *internal.StartupError
 			if !errors.As(err, &serr) || !serr.Retry || !serr.Partial {
 				r.StartupErrors.Incr(1)
@@ -248,7 +265,9 @@ func (r *RunningInput) Gather(acc telegraf.Accumulator) error {
 	}
 
 	r.gatherStart = time.Now()
+	start := r.RuntimeStats.BeginCall()
 	err := r.Input.Gather(acc)
+	r.RuntimeStats.EndCall(start)
 	r.gatherEnd = time.Now()
 
 	r.GatherTime.Incr(r.gatherEnd.Sub(r.gatherStart).Nanoseconds())
@@ -31,6 +31,7 @@ type OutputConfig struct {
 
 	FlushInterval     time.Duration
 	FlushJitter       time.Duration
+	FlushAlignment    bool
 	MetricBufferLimit int
 	MetricBatchSize   int
 
@@ -40,8 +41,18 @@ type OutputConfig struct {
 
 	BufferStrategy  string
 	BufferDirectory string
+	BufferDiskLimit int64
+	BufferDiskSync  string
 
-	LogLevel string
+	BufferOverflowStrategy string
+
+	LogLevel     string
+	RuntimeStats bool
+
+	// DeferBuffer delays opening the buffer until OpenBuffer is called, so
+	// the buffer of a running instance with the same ID, e.g. a disk buffer
+	// using the same directory, is not touched before that instance is closed.
+	DeferBuffer bool
 }
 
 // RunningOutput contains the output configuration
@@ -57,10 +68,14 @@ type RunningOutput struct {
 
 	MetricsFiltered selfstat.Stat
 	WriteTime       selfstat.Stat
+	LastWriteTime   selfstat.Stat
 	StartupErrors   selfstat.Stat
 
 	BatchReady chan time.Time
 
+	// RuntimeStats is only set if collecting runtime statistics is enabled
+	RuntimeStats *RuntimeStats
+
 	buffer Buffer
 	log    telegraf.Logger
 
@@ -99,13 +114,7 @@ func NewRunningOutput(output telegraf.Output, config *OutputConfig, batchSize, b
 		batchSize = DefaultMetricBatchSize
 	}
 
-	b, err := NewBuffer(config.Name, config.ID, config.Alias, bufferLimit, config.BufferStrategy, config.BufferDirectory)
-	if err != nil {
-		panic(err)
-	}
-
 	ro := &RunningOutput{
-		buffer:            b,
 		BatchReady:        make(chan time.Time, 1),
 		Output:            output,
 		Config:            config,
@@ -121,6 +130,11 @@ func NewRunningOutput(output telegraf.Output, config *OutputConfig, batchSize, b
 			"write_time_ns",
 			tags,
 		),
+		LastWriteTime: selfstat.Register(
+			"write",
+			"last_write_timestamp",
+			tags,
+		),
 		StartupErrors: selfstat.Register(
 			"write",
 			"startup_errors",
@@ -128,10 +142,44 @@ func NewRunningOutput(output telegraf.Output, config *OutputConfig, batchSize, b
 		),
 		log: logger,
 	}
+	if config.RuntimeStats {
+		ro.RuntimeStats = NewRuntimeStats("output", config.Name, config.Alias)
+	}
+
+	if !config.DeferBuffer {
+		if err := ro.OpenBuffer(); err != nil {
+			panic(err)
+		}
+	}
 
 	return ro
 }
 
+// OpenBuffer creates the buffer of the output if not done yet. This is only
+// required for outputs with a deferred buffer.
+func (r *RunningOutput) OpenBuffer() error {
+	if r.buffer != nil {
+		return nil
+	}
+
+	b, err := NewBuffer(
+		r.Config.Name,
+		r.Config.ID,
+		r.Config.Alias,
+		r.MetricBufferLimit,
+		r.Config.BufferStrategy,
+		r.Config.BufferDirectory,
+		r.Config.BufferDiskLimit,
+		r.Config.BufferDiskSync,
+		r.Config.BufferOverflowStrategy,
+	)
+	if err != nil {
+		return err
+	}
+	r.buffer = b
+	return nil
+}
+
 func (r *RunningOutput) LogName() string {
 	return logName("outputs", r.Config.Name, r.Config.Alias)
 }
@@ -166,7 +214,9 @@ func (r *RunningOutput) Init() error {
 
 func (r *RunningOutput) Connect() error {
 	// Try to connect and exit early on success
+	before := r.RuntimeStats.BeginStart()
 	err := r.Output.Connect()
+	r.RuntimeStats.EndStart(before)
 	if err == nil {
 		r.started = true
 		return nil
@@ -199,7 +249,17 @@ func (r *RunningOutput) Close() {
 	if err := r.Output.Close(); err != nil {
 		r.log.Errorf("Error closing output: %v", err)
 	}
+	r.RuntimeStats.Stopped()
+
+	r.CloseBuffer()
+}
 
+// CloseBuffer closes the buffer without closing the output plugin. This is
+// used to release outputs that never got connected.
+func (r *RunningOutput) CloseBuffer() {
+	if r.buffer == nil {
+		return
+	}
 	if err := r.buffer.Close(); err != nil {
 		r.log.Errorf("Error closing output buffer: %v", err)
 	}
@@ -261,6 +321,7 @@ func (r *RunningOutput) add(metric telegraf.Metric) {
 
 	dropped := r.buffer.Add(metric)
 	atomic.AddInt64(&r.droppedMetrics, int64(dropped))
+	r.updateInFlight()
 
 	count := atomic.AddInt64(&r.newMetricsCount, 1)
 	if count == int64(r.MetricBatchSize) {
@@ -275,6 +336,8 @@ func (r *RunningOutput) add(metric telegraf.Metric) {
 // Write writes all metrics to the output, stopping when all have been sent on
 // or error.
 func (r *RunningOutput) Write() error {
+	defer r.updateInFlight()
+
 	// Try to connect if we are not yet started up
 	if !r.started {
 		r.retries++
@@ -323,6 +386,8 @@ func (r *RunningOutput) Write() error {
 
 // WriteBatch writes a single batch of metrics to the output.
 func (r *RunningOutput) WriteBatch() error {
+	defer r.updateInFlight()
+
 	// Try to connect if we are not yet started up
 	if !r.started {
 		r.retries++
@@ -357,16 +422,27 @@ func (r *RunningOutput) writeMetrics(metrics []telegraf.Metric) error {
 	}
 
 	start := time.Now()
+	cpuStart := r.RuntimeStats.BeginCall()
 	err := r.Output.Write(metrics)
+	r.RuntimeStats.EndCall(cpuStart)
 	elapsed := time.Since(start)
 	r.WriteTime.Incr(elapsed.Nanoseconds())
 
 	if err == nil {
+		r.LastWriteTime.Set(start.Add(elapsed).UnixNano())
 		r.log.Debugf("Wrote batch of %d metrics in %s", len(metrics), elapsed)
 	}
 	return err
 }
 
+// updateInFlight sets the metrics in flight to the metrics held in the buffer
+// including the batch currently written
+func (r *RunningOutput) updateInFlight() {
+	if r.RuntimeStats != nil {
+		r.RuntimeStats.SetInFlight(int64(r.buffer.Len()))
+	}
+}
+
 func (r *RunningOutput) LogBufferStatus() {
 	nBuffer := r.buffer.Len()
 	if r.Config.BufferStrategy == "disk" {
@@ -240,6 +240,27 @@ func TestRunningOutputDefault(t *testing.T) {
 	require.Len(t, m.Metrics(), 10)
 }
 
+func TestRunningOutputDeferBuffer(t *testing.T) {
+	conf := &OutputConfig{
+		Filter:      Filter{},
+		DeferBuffer: true,
+	}
+
+	m := &mockOutput{}
+	ro := NewRunningOutput(m, conf, 1000, 10000)
+	ro.CloseBuffer()
+
+	require.NoError(t, ro.OpenBuffer())
+	defer ro.CloseBuffer()
+	for _, metric := range first5 {
+		ro.AddMetric(metric)
+	}
+	require.Equal(t, 5, ro.BufferLength())
+
+	require.NoError(t, ro.Write())
+	require.Len(t, m.Metrics(), 5)
+}
+
 func TestRunningOutputWriteFail(t *testing.T) {
 	conf := &OutputConfig{
 		Filter: Filter{},
@@ -271,6 +292,24 @@ func TestRunningOutputWriteFail(t *testing.T) {
 	require.Len(t, m.Metrics(), 10)
 }
 
+func TestRunningOutputLastWriteTime(t *testing.T) {
+	m := &mockOutput{failWrite: true}
+	ro := NewRunningOutput(m, &OutputConfig{Name: "last_write"}, 4, 12)
+	for _, metric := range first5 {
+		ro.AddMetric(metric)
+	}
+
+	// failed writes must not be recorded
+	require.Error(t, ro.Write())
+	require.Zero(t, ro.LastWriteTime.Get())
+
+	m.failWrite = false
+	before := time.Now().UnixNano()
+	require.NoError(t, ro.Write())
+	require.GreaterOrEqual(t, ro.LastWriteTime.Get(), before)
+	require.LessOrEqual(t, ro.LastWriteTime.Get(), time.Now().UnixNano())
+}
+
 // Verify that the order of points is preserved during write failure.
 func TestRunningOutputWriteFailOrder(t *testing.T) {
 	conf := &OutputConfig{
@@ -429,15 +468,21 @@ func TestRunningOutputInternalMetrics(t *testing.T) {
 				"alias":  "test_alias",
 			},
 			map[string]interface{}{
-				"buffer_limit":     10,
-				"buffer_size":      0,
-				"errors":           0,
-				"metrics_added":    0,
-				"metrics_dropped":  0,
-				"metrics_filtered": 0,
-				"metrics_written":  0,
-				"write_time_ns":    0,
-				"startup_errors":   0,
+				"buffer_age_seconds":      0,
+				"buffer_limit":            10,
+				"buffer_size":             0,
+				"errors":                  0,
+				"last_write_timestamp":    0,
+				"metrics_added":           0,
+				"metrics_corrupted":       0,
+				"metrics_dropped":         0,
+				"metrics_dropped_newest":  0,
+				"metrics_dropped_oldest":  0,
+				"metrics_filtered":        0,
+				"metrics_written":         0,
+				"oldest_metric_timestamp": 0,
+				"write_time_ns":           0,
+				"startup_errors":          0,
 			},
 			time.Unix(0, 0),
 		),
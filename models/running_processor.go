@@ -2,6 +2,7 @@ package models
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/influxdata/telegraf"
 	logging "github.com/influxdata/telegraf/logger"
@@ -13,6 +14,13 @@ type RunningProcessor struct {
 	log       telegraf.Logger
 	Processor telegraf.StreamingProcessor
 	Config    *ProcessorConfig
+
+	// RuntimeStats is only set if collecting runtime statistics is enabled
+	RuntimeStats *RuntimeStats
+
+	// Metrics are only held by streaming processors, processors converted
+	// to streaming processors return all metrics immediately
+	holdsMetrics bool
 }
 
 type RunningProcessors []*RunningProcessor
@@ -29,6 +37,8 @@ type ProcessorConfig struct {
 	Order    int64
 	Filter   Filter
 	LogLevel string
+
+	RuntimeStats bool
 }
 
 func NewRunningProcessor(processor telegraf.StreamingProcessor, config *ProcessorConfig) *RunningProcessor {
@@ -47,11 +57,17 @@ func NewRunningProcessor(processor telegraf.StreamingProcessor, config *Processo
 	}
 	SetLoggerOnPlugin(processor, logger)
 
-	return &RunningProcessor{
+	rp := &RunningProcessor{
 		Processor: processor,
 		Config:    config,
 		log:       logger,
 	}
+	if config.RuntimeStats {
+		rp.RuntimeStats = NewRuntimeStats("processor", config.Name, config.Alias)
+		_, converted := processor.(interface{ Unwrap() telegraf.Processor })
+		rp.holdsMetrics = !converted
+	}
+	return rp
 }
 
 func (rp *RunningProcessor) metricFiltered(metric telegraf.Metric) {
@@ -84,11 +100,20 @@ func (rp *RunningProcessor) LogName() string {
 }
 
 func (rp *RunningProcessor) MakeMetric(metric telegraf.Metric) telegraf.Metric {
+	// Metrics created by the processor were never accounted, so only the
+	// received metrics leave the metrics in flight
+	if hm, ok := metric.(*heldMetric); ok {
+		hm.release()
+		return hm.Metric
+	}
 	return metric
 }
 
 func (rp *RunningProcessor) Start(acc telegraf.Accumulator) error {
-	return rp.Processor.Start(acc)
+	before := rp.RuntimeStats.BeginStart()
+	err := rp.Processor.Start(acc)
+	rp.RuntimeStats.EndStart(before)
+	return err
 }
 
 func (rp *RunningProcessor) Add(m telegraf.Metric, acc telegraf.Accumulator) error {
@@ -108,9 +133,62 @@ func (rp *RunningProcessor) Add(m telegraf.Metric, acc telegraf.Accumulator) err
 		return nil
 	}
 
-	return rp.Processor.Add(m, acc)
+	// Account the metric before passing it on as passing the metric
+	// downstream removes it from the metrics in flight. The metric is
+	// wrapped to notice the processor dropping the metric.
+	var held *heldMetric
+	if rp.holdsMetrics {
+		rp.RuntimeStats.AddInFlight(1)
+		held = &heldMetric{Metric: m, stats: rp.RuntimeStats}
+		m = held
+	}
+
+	start := rp.RuntimeStats.BeginCall()
+	err = rp.Processor.Add(m, acc)
+	rp.RuntimeStats.EndCall(start)
+
+	// The metric is dropped by the caller if the processor rejected it
+	if err != nil && held != nil {
+		held.release()
+	}
+	return err
 }
 
 func (rp *RunningProcessor) Stop() {
 	rp.Processor.Stop()
+	rp.RuntimeStats.Stopped()
+}
+
+// heldMetric wraps metrics passed to streaming processors to remove the
+// metric from the metrics in flight once the processor emits, accepts,
+// rejects or drops it, whichever comes first
+type heldMetric struct {
+	telegraf.Metric
+	stats    *RuntimeStats
+	released atomic.Bool
+}
+
+func (m *heldMetric) release() {
+	if m.released.CompareAndSwap(false, true) {
+		m.stats.AddInFlight(-1)
+	}
+}
+
+func (m *heldMetric) Accept() {
+	m.release()
+	m.Metric.Accept()
+}
+
+func (m *heldMetric) Reject() {
+	m.release()
+	m.Metric.Reject()
+}
+
+func (m *heldMetric) Drop() {
+	m.release()
+	m.Metric.Drop()
+}
+
+func (m *heldMetric) Unwrap() telegraf.Metric {
+	return m.Metric
 }
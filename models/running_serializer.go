@@ -102,6 +102,45 @@ func (r *RunningSerializer) SerializeBatch(metrics []telegraf.Metric) ([]byte, e
 	return buf, err
 }
 
+// SerializeBatchSplit serializes the metrics into multiple payloads if the
+// underlying serializer supports it and into a single payload otherwise
+func (r *RunningSerializer) SerializeBatchSplit(metrics []telegraf.Metric) ([][]byte, error) {
+	s, ok := r.Serializer.(telegraf.SplitSerializer)
+	if !ok {
+		buf, err := r.SerializeBatch(metrics)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{buf}, nil
+	}
+
+	start := time.Now()
+	payloads, err := s.SerializeBatchSplit(metrics)
+	elapsed := time.Since(start)
+	r.SerializationTime.Incr(elapsed.Nanoseconds())
+	r.MetricsSerialized.Incr(int64(len(metrics)))
+	for _, buf := range payloads {
+		r.BytesSerialized.Incr(int64(len(buf)))
+	}
+
+	return payloads, err
+}
+
+// HasHeader returns true if the underlying serializer emits headers and
+// calling ResetHeader has an effect
+func (r *RunningSerializer) HasHeader() bool {
+	_, ok := r.Serializer.(telegraf.HeaderSerializer)
+	return ok
+}
+
+// ResetHeader forwards the request to the underlying serializer if it emits
+// headers
+func (r *RunningSerializer) ResetHeader() {
+	if s, ok := r.Serializer.(telegraf.HeaderSerializer); ok {
+		s.ResetHeader()
+	}
+}
+
 func (r *RunningSerializer) Log() telegraf.Logger {
 	return r.log
 }
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"runtime"
+	"sync/atomic"
+
+	"github.com/influxdata/telegraf/selfstat"
+)
+
+// RuntimeStats accounts the runtime resources used by a running plugin to
+// find the plugin responsible for the resource consumption of the agent. The
+// statistics are reported as "internal_plugin_runtime" via the internal
+// input. All methods are no-ops on a nil instance so the accounting has no
+// overhead if disabled.
+type RuntimeStats struct {
+	MetricsInFlight selfstat.Stat
+	Goroutines      selfstat.Stat
+	CPUTime         selfstat.Stat
+
+	// Goroutines started by this plugin instance, multiple instances might
+	// share the same statistics
+	goroutines atomic.Int64
+}
+
+// NewRuntimeStats registers the runtime statistics for the given plugin
+func NewRuntimeStats(pluginType, name, alias string) *RuntimeStats {
+	tags := map[string]string{"type": pluginType, "plugin": name}
+	if alias != "" {
+		tags["alias"] = alias
+	}
+
+	return &RuntimeStats{
+		MetricsInFlight: selfstat.Register("plugin_runtime", "metrics_in_flight", tags),
+		Goroutines:      selfstat.Register("plugin_runtime", "goroutines", tags),
+		CPUTime:         selfstat.Register("plugin_runtime", "cpu_time_ns", tags),
+	}
+}
+
+// AddInFlight adds the given number of metrics to the metrics held by the plugin
+func (s *RuntimeStats) AddInFlight(n int64) {
+	if s == nil {
+		return
+	}
+	s.MetricsInFlight.Incr(n)
+}
+
+// SetInFlight sets the number of metrics held by the plugin
+func (s *RuntimeStats) SetInFlight(n int64) {
+	if s == nil {
+		return
+	}
+	s.MetricsInFlight.Set(n)
+}
+
+// BeginStart records the number of goroutines before a lifecycle hook starting
+// the plugin, e.g. Start or Connect, is called. The returned value must be
+// passed to EndStart after the call returned.
+func (s *RuntimeStats) BeginStart() int {
+	if s == nil {
+		return 0
+	}
+	return runtime.NumGoroutine()
+}
+
+// EndStart attributes the goroutines created since BeginStart to the plugin.
+// Goroutines started by other plugins concurrently are attributed as well, so
+// the number is an estimate.
+func (s *RuntimeStats) EndStart(before int) {
+	if s == nil {
+		return
+	}
+	n := int64(max(runtime.NumGoroutine()-before, 0))
+	s.goroutines.Add(n)
+	s.Goroutines.Incr(n)
+}
+
+// Stopped removes the goroutines attributed to the plugin after the plugin was
+// stopped or closed.
+func (s *RuntimeStats) Stopped() {
+	if s == nil {
+		return
+	}
+	s.Goroutines.Incr(-s.goroutines.Swap(0))
+}
+
+// cpuMark is the starting point of a CPU time measurement
+type cpuMark struct {
+	value int64
+}
+
+// BeginCall starts measuring the CPU time of a plugin call like Gather or
+// Write. The returned value must be passed to EndCall by the same goroutine
+// after the call returned.
+func (s *RuntimeStats) BeginCall() cpuMark {
+	if s == nil {
+		return cpuMark{}
+	}
+	return beginCPUTime()
+}
+
+// EndCall adds the CPU time used since BeginCall to the plugin's CPU time.
+// The CPU time is approximate, see endCPUTime for the limitations.
+func (s *RuntimeStats) EndCall(start cpuMark) {
+	if s == nil {
+		return
+	}
+	s.CPUTime.Incr(endCPUTime(start))
+}
@@ -0,0 +1,33 @@
+//go:build linux
+
+package models
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// beginCPUTime locks the calling goroutine to its thread and returns the CPU
+// time consumed by the thread so far. Blocking calls of the locked goroutine
+// do not block other goroutines as the runtime starts new threads if needed.
+func beginCPUTime() cpuMark {
+	runtime.LockOSThread()
+	return cpuMark{value: threadCPUTime()}
+}
+
+// endCPUTime returns the CPU time consumed by the thread since beginCPUTime
+// and unlocks the goroutine from the thread. The measurement is approximate
+// as the runtime might use the thread for other work, e.g. garbage collection.
+func endCPUTime(start cpuMark) int64 {
+	defer runtime.UnlockOSThread()
+	return max(threadCPUTime()-start.value, 0)
+}
+
+func threadCPUTime() int64 {
+	var ts unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_THREAD_CPUTIME_ID, &ts); err != nil {
+		return 0
+	}
+	return ts.Nano()
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+
+package models
+
+import "time"
+
+// beginCPUTime returns the current time as the thread's CPU time is not
+// available on this platform, so the time spent in the call is measured
+// instead.
+func beginCPUTime() cpuMark {
+	return cpuMark{value: time.Now().UnixNano()}
+}
+
+// endCPUTime returns the time elapsed since beginCPUTime
+func endCPUTime(start cpuMark) int64 {
+	return max(time.Now().UnixNano()-start.value, 0)
+}
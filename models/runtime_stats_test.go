@@ -0,0 +1,234 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestRuntimeStatsDisabled(t *testing.T) {
+	ri := NewRunningInput(&mockServiceInput{}, &InputConfig{Name: "runtime_disabled"})
+	require.Nil(t, ri.RuntimeStats)
+	rp := NewRunningProcessor(&mockStreamingProcessor{}, &ProcessorConfig{Name: "runtime_disabled"})
+	require.Nil(t, rp.RuntimeStats)
+	ro := NewRunningOutput(&mockOutput{}, &OutputConfig{Name: "runtime_disabled"}, 1, 10)
+	require.Nil(t, ro.RuntimeStats)
+
+	// The accounting must be a no-op for all plugins
+	var acc testutil.Accumulator
+	require.NoError(t, ri.Start(&acc))
+	require.NoError(t, ri.Gather(&acc))
+	ri.MakeMetric(testutil.TestMetric(1))
+	ri.Stop()
+	require.NoError(t, rp.Start(&acc))
+	require.NoError(t, rp.Add(testutil.TestMetric(1), &acc))
+	rp.Stop()
+	require.NoError(t, ro.Connect())
+	ro.AddMetric(testutil.TestMetric(1))
+	require.NoError(t, ro.Write())
+	ro.Close()
+}
+
+func TestRuntimeStatsInput(t *testing.T) {
+	input := &mockServiceInput{}
+	ri := NewRunningInput(input, &InputConfig{Name: "runtime_input", RuntimeStats: true})
+	defer input.Stop()
+	require.NotNil(t, ri.RuntimeStats)
+
+	// Goroutines started by the plugin are attributed to it until stopped
+	var acc testutil.Accumulator
+	require.NoError(t, ri.Start(&acc))
+	require.GreaterOrEqual(t, ri.RuntimeStats.Goroutines.Get(), int64(1))
+
+	// The CPU time of the gather call is accounted
+	require.NoError(t, ri.Gather(&acc))
+	require.Positive(t, ri.RuntimeStats.CPUTime.Get())
+
+	// Created metrics are in flight until passed on
+	ri.MakeMetric(testutil.TestMetric(1))
+	ri.MakeMetric(testutil.TestMetric(2))
+	require.Equal(t, int64(2), ri.RuntimeStats.MetricsInFlight.Get())
+	ri.RuntimeStats.AddInFlight(-2)
+	require.Zero(t, ri.RuntimeStats.MetricsInFlight.Get())
+
+	ri.Stop()
+	require.Zero(t, ri.RuntimeStats.Goroutines.Get())
+}
+
+func TestRuntimeStatsProcessor(t *testing.T) {
+	tests := []struct {
+		name      string
+		processor telegraf.StreamingProcessor
+		expected  int64
+	}{
+		{
+			name:      "streaming processor holding metrics",
+			processor: &mockStreamingProcessor{},
+			expected:  2,
+		},
+		{
+			name:      "converted processor",
+			processor: &mockConvertedProcessor{},
+			expected:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := NewRunningProcessor(tt.processor, &ProcessorConfig{Name: "runtime_" + tt.name, RuntimeStats: true})
+			require.NotNil(t, rp.RuntimeStats)
+
+			var acc testutil.Accumulator
+			require.NoError(t, rp.Start(&acc))
+			require.NoError(t, rp.Add(testutil.TestMetric(1), &acc))
+			require.NoError(t, rp.Add(testutil.TestMetric(2), &acc))
+			require.Equal(t, tt.expected, rp.RuntimeStats.MetricsInFlight.Get())
+
+			// Emitting the held metrics removes them from the metrics in flight
+			if p, ok := tt.processor.(*mockStreamingProcessor); ok {
+				for _, m := range p.held {
+					acc.AddMetric(rp.MakeMetric(m))
+				}
+			}
+			require.Zero(t, rp.RuntimeStats.MetricsInFlight.Get())
+			rp.Stop()
+		})
+	}
+}
+
+func TestRuntimeStatsProcessorDropped(t *testing.T) {
+	rp := NewRunningProcessor(&mockDroppingProcessor{}, &ProcessorConfig{Name: "runtime_dropping", RuntimeStats: true})
+	require.NotNil(t, rp.RuntimeStats)
+
+	// Dropped and rejected metrics are no longer in flight
+	var acc testutil.Accumulator
+	require.NoError(t, rp.Start(&acc))
+	require.NoError(t, rp.Add(testutil.TestMetric(1), &acc))
+	require.Error(t, rp.Add(testutil.TestMetric(2), &acc))
+	require.Zero(t, rp.RuntimeStats.MetricsInFlight.Get())
+	rp.Stop()
+}
+
+func TestRuntimeStatsOutput(t *testing.T) {
+	output := &mockOutput{}
+	ro := NewRunningOutput(output, &OutputConfig{Name: "runtime_output", RuntimeStats: true}, 10, 10)
+	require.NotNil(t, ro.RuntimeStats)
+	require.NoError(t, ro.Connect())
+
+	// Buffered metrics are in flight until written
+	for _, m := range first5 {
+		ro.AddMetric(m)
+	}
+	require.Equal(t, int64(5), ro.RuntimeStats.MetricsInFlight.Get())
+
+	output.failWrite = true
+	require.Error(t, ro.Write())
+	require.Equal(t, int64(5), ro.RuntimeStats.MetricsInFlight.Get())
+
+	output.failWrite = false
+	require.NoError(t, ro.Write())
+	require.Zero(t, ro.RuntimeStats.MetricsInFlight.Get())
+	require.Positive(t, ro.RuntimeStats.CPUTime.Get())
+	ro.Close()
+}
+
+// mockServiceInput starts a goroutine and burns some CPU on gather
+type mockServiceInput struct {
+	done chan struct{}
+}
+
+func (*mockServiceInput) SampleConfig() string {
+	return ""
+}
+
+func (m *mockServiceInput) Start(telegraf.Accumulator) error {
+	done := make(chan struct{})
+	m.done = done
+	go func() {
+		<-done
+	}()
+	return nil
+}
+
+func (m *mockServiceInput) Stop() {
+	if m.done != nil {
+		close(m.done)
+		m.done = nil
+	}
+}
+
+func (*mockServiceInput) Gather(telegraf.Accumulator) error {
+	for start := time.Now(); time.Since(start) < 5*time.Millisecond; {
+	}
+	return nil
+}
+
+// mockStreamingProcessor holds all metrics until they are emitted explicitly
+type mockStreamingProcessor struct {
+	held []telegraf.Metric
+}
+
+func (*mockStreamingProcessor) SampleConfig() string {
+	return ""
+}
+
+func (*mockStreamingProcessor) Start(telegraf.Accumulator) error {
+	return nil
+}
+
+func (p *mockStreamingProcessor) Add(m telegraf.Metric, _ telegraf.Accumulator) error {
+	p.held = append(p.held, m)
+	return nil
+}
+
+func (*mockStreamingProcessor) Stop() {}
+
+// mockConvertedProcessor mimics a processor converted to a streaming processor
+// passing all metrics immediately
+type mockConvertedProcessor struct{}
+
+func (*mockConvertedProcessor) SampleConfig() string {
+	return ""
+}
+
+func (*mockConvertedProcessor) Start(telegraf.Accumulator) error {
+	return nil
+}
+
+func (*mockConvertedProcessor) Add(m telegraf.Metric, acc telegraf.Accumulator) error {
+	acc.AddMetric(m)
+	return nil
+}
+
+func (*mockConvertedProcessor) Stop() {}
+
+func (*mockConvertedProcessor) Unwrap() telegraf.Processor {
+	return nil
+}
+
+// mockDroppingProcessor drops metrics with a value of one and rejects all
+// other metrics
+type mockDroppingProcessor struct{}
+
+func (*mockDroppingProcessor) SampleConfig() string {
+	return ""
+}
+
+func (*mockDroppingProcessor) Start(telegraf.Accumulator) error {
+	return nil
+}
+
+func (*mockDroppingProcessor) Add(m telegraf.Metric, _ telegraf.Accumulator) error {
+	if v, ok := m.GetField("value"); ok && v == int64(1) {
+		m.Drop()
+		return nil
+	}
+	return errors.New("rejected")
+}
+
+func (*mockDroppingProcessor) Stop() {}
@@ -0,0 +1,5 @@
+//go:build !custom || aggregators || aggregators.moving_average
+
+package all
+
+import _ "github.com/influxdata/telegraf/plugins/aggregators/moving_average" // register plugin
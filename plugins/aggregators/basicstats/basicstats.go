@@ -3,19 +3,28 @@ package basicstats
 
 import (
 	_ "embed"
+	"fmt"
 	"math"
+	"strconv"
 	"time"
 
+	"github.com/caio/go-tdigest"
+
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/plugins/aggregators"
 )
 
 //go:embed sample.conf
 var sampleConfig string
 
+// compression of the t-digest used for estimating percentiles
+const compression = 100
+
 type BasicStats struct {
-	Stats []string `toml:"stats"`
-	Log   telegraf.Logger
+	Stats       []string        `toml:"stats"`
+	Percentiles []config.Number `toml:"percentiles"`
+	Log         telegraf.Logger
 
 	cache       map[uint64]aggregate
 	statsConfig *configuredStats
@@ -37,6 +46,8 @@ type configuredStats struct {
 	interval        bool
 	last            bool
 	first           bool
+	percentiles     bool
+	mad             bool
 }
 
 func NewBasicStats() *BasicStats {
@@ -62,9 +73,10 @@ type basicstats struct {
 	interval time.Duration
 	last     float64
 	first    float64
-	M2       float64   // intermediate value for variance/stdev
-	PREVIOUS float64   // intermediate value for diff
-	TIME     time.Time // intermediate value for rate
+	M2       float64          // intermediate value for variance/stdev
+	PREVIOUS float64          // intermediate value for diff
+	TIME     time.Time        // intermediate value for rate
+	digest   *tdigest.TDigest // intermediate value for percentiles and mad
 }
 
 func (*BasicStats) SampleConfig() string {
@@ -83,6 +95,7 @@ func (b *BasicStats) Add(in telegraf.Metric) {
 		for _, field := range in.FieldList() {
 			if fv, ok := convert(field.Value); ok {
 				a.fields[field.Key] = basicstats{
+					digest:   b.newDigest(fv),
 					count:    1,
 					min:      fv,
 					max:      fv,
@@ -105,6 +118,7 @@ func (b *BasicStats) Add(in telegraf.Metric) {
 				if _, ok := b.cache[id].fields[field.Key]; !ok {
 					// hit an uncached field of a cached metric
 					b.cache[id].fields[field.Key] = basicstats{
+						digest:   b.newDigest(fv),
 						count:    1,
 						min:      fv,
 						max:      fv,
@@ -156,6 +170,12 @@ func (b *BasicStats) Add(in telegraf.Metric) {
 				}
 				// last compute
 				tmp.last = fv
+				// percentiles and mad compute
+				if tmp.digest != nil {
+					if err := tmp.digest.Add(fv); err != nil {
+						b.Log.Errorf("Adding value of field %q failed: %v", field.Key, err)
+					}
+				}
 				// store final data
 				b.cache[id].fields[field.Key] = tmp
 			}
@@ -188,6 +208,14 @@ func (b *BasicStats) Push(acc telegraf.Accumulator) {
 			if b.statsConfig.first {
 				fields[k+"_first"] = v.first
 			}
+			if b.statsConfig.percentiles {
+				for _, p := range b.Percentiles {
+					fields[k+"_p"+strconv.FormatFloat(float64(p), 'f', -1, 64)] = v.digest.Quantile(float64(p) / 100)
+				}
+			}
+			if b.statsConfig.mad {
+				fields[k+"_mad"] = medianAbsoluteDeviation(v.digest)
+			}
 
 			// v.count always >=1
 			if v.count > 1 {
@@ -263,6 +291,10 @@ func (b *BasicStats) parseStats() *configuredStats {
 			parsed.last = true
 		case "first":
 			parsed.first = true
+		case "percentiles":
+			parsed.percentiles = true
+		case "mad":
+			parsed.mad = true
 		default:
 			b.Log.Warnf("Unrecognized basic stat %q, ignoring", name)
 		}
@@ -289,6 +321,8 @@ func (b *BasicStats) initConfiguredStats() {
 			interval:        false,
 			last:            false,
 			first:           false,
+			percentiles:     len(b.Percentiles) > 0,
+			mad:             false,
 		}
 	} else {
 		b.statsConfig = b.parseStats()
@@ -299,6 +333,44 @@ func (b *BasicStats) Reset() {
 	b.cache = make(map[uint64]aggregate)
 }
 
+// newDigest creates a digest containing the given value if percentiles or
+// the median absolute deviation are configured
+func (b *BasicStats) newDigest(value float64) *tdigest.TDigest {
+	if !b.statsConfig.percentiles && !b.statsConfig.mad {
+		return nil
+	}
+
+	// Creating a digest only fails for an invalid compression setting
+	digest, err := tdigest.New(tdigest.Compression(compression))
+	if err != nil {
+		b.Log.Errorf("Creating digest failed: %v", err)
+		return nil
+	}
+	if err := digest.Add(value); err != nil {
+		b.Log.Errorf("Adding value failed: %v", err)
+	}
+	return digest
+}
+
+// medianAbsoluteDeviation estimates the median of the absolute deviations from
+// the median using the centroids of the given digest
+func medianAbsoluteDeviation(digest *tdigest.TDigest) float64 {
+	median := digest.Quantile(0.5)
+
+	deviations, err := tdigest.New(tdigest.Compression(compression))
+	if err != nil {
+		return math.NaN()
+	}
+	digest.ForEachCentroid(func(mean float64, count uint64) bool {
+		err = deviations.AddWeighted(math.Abs(mean-median), count)
+		return err == nil
+	})
+	if err != nil {
+		return math.NaN()
+	}
+	return deviations.Quantile(0.5)
+}
+
 func convert(in interface{}) (float64, bool) {
 	switch v := in.(type) {
 	case float64:
@@ -313,7 +385,16 @@ func convert(in interface{}) (float64, bool) {
 }
 
 func (b *BasicStats) Init() error {
+	for _, p := range b.Percentiles {
+		if p <= 0 || p > 100 {
+			return fmt.Errorf("invalid percentile %v, must be in (0, 100]", p)
+		}
+	}
+
 	b.initConfiguredStats()
+	if b.statsConfig.percentiles && len(b.Percentiles) == 0 {
+		b.Percentiles = []config.Number{50, 95, 99}
+	}
 
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/testutil"
 )
@@ -824,3 +825,111 @@ func TestBasicStatsWithOnlyFirst(t *testing.T) {
 	}
 	acc.AssertContainsTaggedFields(t, "m1", expectedFields, expectedTags)
 }
+
+func TestBasicStatsWithPercentiles(t *testing.T) {
+	aggregator := NewBasicStats()
+	aggregator.Stats = []string{"percentiles", "mad"}
+	aggregator.Percentiles = []config.Number{50, 95, 99.5}
+	aggregator.Log = testutil.Logger{}
+	require.NoError(t, aggregator.Init())
+
+	for i := 1; i <= 100; i++ {
+		aggregator.Add(metric.New("m1",
+			map[string]string{"foo": "bar"},
+			map[string]interface{}{"a": int64(i)},
+			time.Unix(int64(i), 0),
+		))
+	}
+
+	acc := testutil.Accumulator{}
+	aggregator.Push(&acc)
+
+	require.Len(t, acc.Metrics, 1)
+	fields := acc.Metrics[0].Fields
+	require.Len(t, fields, 4)
+	require.InDelta(t, 50.5, fields["a_p50"], 1)
+	require.InDelta(t, 95.5, fields["a_p95"], 1)
+	require.InDelta(t, 100, fields["a_p99.5"], 1)
+	require.InDelta(t, 25, fields["a_mad"], 1)
+	require.IsType(t, float64(0), fields["a_p50"])
+}
+
+func TestBasicStatsWithPercentilesDefault(t *testing.T) {
+	aggregator := NewBasicStats()
+	aggregator.Stats = []string{"percentiles"}
+	aggregator.Log = testutil.Logger{}
+	require.NoError(t, aggregator.Init())
+
+	aggregator.Add(m1)
+	aggregator.Add(m2)
+
+	acc := testutil.Accumulator{}
+	aggregator.Push(&acc)
+
+	require.True(t, acc.HasField("m1", "a_p50"))
+	require.True(t, acc.HasField("m1", "a_p95"))
+	require.True(t, acc.HasField("m1", "a_p99"))
+	require.False(t, acc.HasField("m1", "a_mad"))
+	require.False(t, acc.HasField("m1", "a_count"))
+}
+
+func TestBasicStatsWithPercentilesAndDefaultStats(t *testing.T) {
+	aggregator := NewBasicStats()
+	aggregator.Percentiles = []config.Number{90}
+	aggregator.Log = testutil.Logger{}
+	require.NoError(t, aggregator.Init())
+
+	aggregator.Add(m1)
+	aggregator.Add(m2)
+
+	acc := testutil.Accumulator{}
+	aggregator.Push(&acc)
+
+	require.True(t, acc.HasField("m1", "a_count"))
+	require.True(t, acc.HasField("m1", "a_p90"))
+}
+
+func TestBasicStatsPercentilesReset(t *testing.T) {
+	aggregator := NewBasicStats()
+	aggregator.Stats = []string{"percentiles", "mad"}
+	aggregator.Percentiles = []config.Number{50}
+	aggregator.Log = testutil.Logger{}
+	require.NoError(t, aggregator.Init())
+
+	aggregator.Add(m1)
+	aggregator.Add(m2)
+	aggregator.Reset()
+	aggregator.Add(m2)
+
+	acc := testutil.Accumulator{}
+	aggregator.Push(&acc)
+
+	expectedFields := map[string]interface{}{
+		"a_p50": float64(1),
+		"a_mad": float64(0),
+		"b_p50": float64(3),
+		"b_mad": float64(0),
+		"c_p50": float64(4),
+		"c_mad": float64(0),
+		"d_p50": float64(6),
+		"d_mad": float64(0),
+		"e_p50": float64(200),
+		"e_mad": float64(0),
+		"f_p50": float64(200),
+		"f_mad": float64(0),
+		"g_p50": float64(1),
+		"g_mad": float64(0),
+	}
+	expectedTags := map[string]string{
+		"foo": "bar",
+	}
+	acc.AssertContainsTaggedFields(t, "m1", expectedFields, expectedTags)
+}
+
+func TestBasicStatsInvalidPercentile(t *testing.T) {
+	aggregator := NewBasicStats()
+	aggregator.Stats = []string{"percentiles"}
+	aggregator.Percentiles = []config.Number{0}
+	aggregator.Log = testutil.Logger{}
+	require.ErrorContains(t, aggregator.Init(), "invalid percentile")
+}
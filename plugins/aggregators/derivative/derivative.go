@@ -3,6 +3,8 @@ package derivative
 
 import (
 	_ "embed"
+	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -17,6 +19,9 @@ type Derivative struct {
 	Variable    string          `toml:"variable"`
 	Suffix      string          `toml:"suffix"`
 	MaxRollOver uint            `toml:"max_roll_over"`
+	CounterMode bool            `toml:"counter_mode"`
+	OnReset     string          `toml:"on_reset"`
+	Detect32Bit bool            `toml:"detect_32bit_wrap"`
 	Log         telegraf.Logger `toml:"-"`
 	cache       map[uint64]*aggregate
 }
@@ -148,8 +153,16 @@ func (d *Derivative) Push(acc telegraf.Accumulator) {
 				continue
 			}
 			if end, ok := aggregate.last.fields[key]; ok {
+				delta := end - start
+				if d.CounterMode && delta < 0 {
+					var valid bool
+					if delta, valid = d.counterDelta(start, end); !valid {
+						d.Log.Debugf("Counter reset of %q for %q, skipping.", key, aggregate.name)
+						continue
+					}
+				}
 				d.Log.Debugf("Adding derivative %q to %q.", key+d.Suffix, aggregate.name)
-				derivatives[key+d.Suffix] = (end - start) / denominator
+				derivatives[key+d.Suffix] = delta / denominator
 			}
 		}
 		acc.AddFields(aggregate.name, derivatives, aggregate.tags)
@@ -170,9 +183,33 @@ func (d *Derivative) Reset() {
 	}
 }
 
+// counterDelta computes the difference of a counter decreasing from start to
+// end. A difference of more than 2^31 is considered a wrap of a 32-bit counter
+// if enabled and both values fit into 32 bits, otherwise the counter was reset.
+// Returns false if the interval should be skipped.
+func (d *Derivative) counterDelta(start, end float64) (float64, bool) {
+	delta := end - start
+	if d.Detect32Bit && delta < -math.MaxInt32 && start <= math.MaxUint32 && end >= 0 {
+		return delta + math.MaxUint32 + 1, true
+	}
+	if d.OnReset == "skip" {
+		return 0, false
+	}
+	// Assume the counter restarted from zero
+	return end, true
+}
+
 func (d *Derivative) Init() error {
 	d.Suffix = strings.TrimSpace(d.Suffix)
 	d.Variable = strings.TrimSpace(d.Variable)
+
+	switch d.OnReset {
+	case "":
+		d.OnReset = "assume_restart"
+	case "assume_restart", "skip":
+	default:
+		return fmt.Errorf("invalid 'on_reset' value %q", d.OnReset)
+	}
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package derivative
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -416,3 +417,114 @@ func TestCalculatesCorrectDerivativeOnTwoConsecutivePeriods(t *testing.T) {
 		"value_rate": 2.0,
 	})
 }
+
+func TestCounterMode(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		onReset  string
+		wrap32   bool
+		first    float64
+		last     float64
+		expected map[string]interface{}
+	}{
+		{
+			name:     "monotonic growth",
+			first:    100,
+			last:     200,
+			expected: map[string]interface{}{"counter_rate": 10.0},
+		},
+		{
+			name:     "restart",
+			first:    1000,
+			last:     50,
+			expected: map[string]interface{}{"counter_rate": 5.0},
+		},
+		{
+			name:     "restart skipped",
+			onReset:  "skip",
+			first:    1000,
+			last:     50,
+			expected: map[string]interface{}{},
+		},
+		{
+			name:     "32-bit wrap",
+			wrap32:   true,
+			first:    math.MaxUint32 - 49,
+			last:     50,
+			expected: map[string]interface{}{"counter_rate": 10.0},
+		},
+		{
+			name:     "32-bit wrap disabled",
+			first:    math.MaxUint32 - 49,
+			last:     50,
+			expected: map[string]interface{}{"counter_rate": 5.0},
+		},
+		{
+			name:     "64-bit reset with 32-bit wrap detection",
+			wrap32:   true,
+			first:    math.MaxUint32 + 1000,
+			last:     50,
+			expected: map[string]interface{}{"counter_rate": 5.0},
+		},
+		{
+			name:     "64-bit reset skipped with 32-bit wrap detection",
+			onReset:  "skip",
+			wrap32:   true,
+			first:    math.MaxUint32 + 1000,
+			last:     50,
+			expected: map[string]interface{}{},
+		},
+		{
+			name:     "restart with 32-bit wrap detection",
+			onReset:  "skip",
+			wrap32:   true,
+			first:    1000,
+			last:     50,
+			expected: map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			derivative := &Derivative{
+				Suffix:      "_rate",
+				CounterMode: true,
+				OnReset:     tt.onReset,
+				Detect32Bit: tt.wrap32,
+				Log:         testutil.Logger{},
+				cache:       make(map[uint64]*aggregate),
+			}
+			require.NoError(t, derivative.Init())
+
+			derivative.Add(metric.New("TestMetric",
+				map[string]string{"state": "full"},
+				map[string]interface{}{"counter": tt.first},
+				now,
+			))
+			derivative.Add(metric.New("TestMetric",
+				map[string]string{"state": "full"},
+				map[string]interface{}{"counter": tt.last},
+				now.Add(10*time.Second),
+			))
+
+			acc := testutil.Accumulator{}
+			derivative.Push(&acc)
+
+			if len(tt.expected) == 0 {
+				require.False(t, acc.HasField("TestMetric", "counter_rate"))
+				return
+			}
+			acc.AssertContainsTaggedFields(t, "TestMetric", tt.expected, map[string]string{"state": "full"})
+		})
+	}
+}
+
+func TestCounterModeInvalidOnReset(t *testing.T) {
+	derivative := &Derivative{
+		CounterMode: true,
+		OnReset:     "ignore",
+		Log:         testutil.Logger{},
+	}
+	require.ErrorContains(t, derivative.Init(), "invalid 'on_reset'")
+}
@@ -18,9 +18,17 @@ type Final struct {
 	OutputStrategy         string          `toml:"output_strategy"`
 	SeriesTimeout          config.Duration `toml:"series_timeout"`
 	KeepOriginalFieldNames bool            `toml:"keep_original_field_names"`
+	ReasonTag              string          `toml:"reason_tag"`
 
 	// The last metric for all series which are active
-	metricCache map[uint64]telegraf.Metric
+	metricCache map[uint64]*cacheEntry
+}
+
+// cacheEntry is the last metric of a series
+type cacheEntry struct {
+	metric telegraf.Metric
+	// updated is true if the metric was not yet emitted periodically
+	updated bool
 }
 
 func NewFinal() *Final {
@@ -38,46 +46,74 @@ func (m *Final) Init() error {
 	switch m.OutputStrategy {
 	case "":
 		m.OutputStrategy = "timeout"
-	case "timeout", "periodic":
+	case "timeout", "periodic", "both":
 		// Do nothing, those are valid
 	default:
 		return fmt.Errorf("invalid 'output_strategy': %q", m.OutputStrategy)
 	}
 
 	// Initialize the cache
-	m.metricCache = make(map[uint64]telegraf.Metric)
+	m.metricCache = make(map[uint64]*cacheEntry)
 
 	return nil
 }
 
 func (m *Final) Add(in telegraf.Metric) {
 	id := in.HashID()
-	m.metricCache[id] = in
+	m.metricCache[id] = &cacheEntry{metric: in, updated: true}
 }
 
 func (m *Final) Push(acc telegraf.Accumulator) {
 	// Preserve timestamp of original metric
 	acc.SetPrecision(time.Nanosecond)
 
-	for id, metric := range m.metricCache {
-		if m.OutputStrategy == "timeout" && time.Since(metric.Time()) <= time.Duration(m.SeriesTimeout) {
-			// We output on timeout but the last metric of the series was
-			// younger than that. So skip the output for this period.
+	for id, entry := range m.metricCache {
+		timedOut := time.Since(entry.metric.Time()) > time.Duration(m.SeriesTimeout)
+		switch m.OutputStrategy {
+		case "timeout":
+			if !timedOut {
+				// We output on timeout but the last metric of the series was
+				// younger than that. So skip the output for this period.
+				continue
+			}
+			m.emit(acc, entry.metric, "timeout")
+		case "periodic":
+			m.emit(acc, entry.metric, "period")
+		case "both":
+			// Output the metric for the period if it was updated and
+			// additionally once the series timed out. Keep the series until
+			// then.
+			if timedOut {
+				m.emit(acc, entry.metric, "timeout")
+				break
+			}
+			if entry.updated {
+				m.emit(acc, entry.metric, "period")
+				entry.updated = false
+			}
 			continue
 		}
-		var fields map[string]any
-		if m.KeepOriginalFieldNames {
-			fields = metric.Fields()
-		} else {
-			fields = make(map[string]any, len(metric.FieldList()))
-			for _, field := range metric.FieldList() {
-				fields[field.Key+"_final"] = field.Value
-			}
+		delete(m.metricCache, id)
+	}
+}
+
+func (m *Final) emit(acc telegraf.Accumulator, metric telegraf.Metric, reason string) {
+	var fields map[string]any
+	if m.KeepOriginalFieldNames {
+		fields = metric.Fields()
+	} else {
+		fields = make(map[string]any, len(metric.FieldList()))
+		for _, field := range metric.FieldList() {
+			fields[field.Key+"_final"] = field.Value
 		}
+	}
 
-		acc.AddFields(metric.Name(), fields, metric.Tags(), metric.Time())
-		delete(m.metricCache, id)
+	tags := metric.Tags()
+	if m.ReasonTag != "" {
+		tags[m.ReasonTag] = reason
 	}
+
+	acc.AddFields(metric.Name(), fields, tags, metric.Time())
 }
 
 func (m *Final) Reset() {
@@ -305,3 +305,84 @@ func TestKeepOriginalFieldNames(t *testing.T) {
 
 	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.SortMetrics())
 }
+
+func TestOutputStrategyBoth(t *testing.T) {
+	final := &Final{
+		OutputStrategy: "both",
+		SeriesTimeout:  config.Duration(30 * time.Second),
+		ReasonTag:      "final_reason",
+	}
+	require.NoError(t, final.Init())
+
+	now := time.Now()
+	tags := map[string]string{"foo": "bar"}
+	m1 := metric.New("m",
+		tags,
+		map[string]interface{}{"a": int64(1)},
+		now.Add(time.Second*-290))
+	m2 := metric.New("m",
+		tags,
+		map[string]interface{}{"a": int64(2)},
+		now.Add(time.Second*-20))
+
+	var acc testutil.Accumulator
+	final.Add(m1)
+	final.Push(&acc)
+	require.Empty(t, final.metricCache)
+
+	final.Add(m2)
+	final.Push(&acc)
+	final.Push(&acc)
+	require.Len(t, final.metricCache, 1)
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"m",
+			map[string]string{"foo": "bar", "final_reason": "timeout"},
+			map[string]interface{}{
+				"a_final": 1,
+			},
+			now.Add(time.Second*-290),
+		),
+		metric.New(
+			"m",
+			map[string]string{"foo": "bar", "final_reason": "period"},
+			map[string]interface{}{
+				"a_final": 2,
+			},
+			now.Add(time.Second*-20),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics())
+}
+
+func TestReasonTag(t *testing.T) {
+	final := &Final{
+		OutputStrategy:         "periodic",
+		SeriesTimeout:          config.Duration(30 * time.Second),
+		KeepOriginalFieldNames: true,
+		ReasonTag:              "final_reason",
+	}
+	require.NoError(t, final.Init())
+
+	now := time.Now()
+	tags := map[string]string{"foo": "bar"}
+	m1 := metric.New("m",
+		tags,
+		map[string]any{"a": 3},
+		now.Add(time.Second*-90))
+
+	var acc testutil.Accumulator
+	final.Add(m1)
+	final.Push(&acc)
+	expected := []telegraf.Metric{
+		metric.New(
+			"m",
+			map[string]string{"foo": "bar", "final_reason": "period"},
+			map[string]any{"a": 3},
+			now.Add(time.Second*-90),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics())
+	require.Equal(t, map[string]string{"foo": "bar"}, m1.Tags())
+}
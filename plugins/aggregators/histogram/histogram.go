@@ -3,6 +3,9 @@ package histogram
 
 import (
 	_ "embed"
+	"errors"
+	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"time"
@@ -41,11 +44,24 @@ type HistogramAggregator struct {
 
 // bucketConfig is the config, which contains name, field of metric and histogram buckets.
 type bucketConfig struct {
-	Metric  string   `toml:"measurement_name"`
-	Fields  []string `toml:"fields"`
-	Buckets buckets  `toml:"buckets"`
+	Metric      string       `toml:"measurement_name"`
+	Fields      []string     `toml:"fields"`
+	Buckets     buckets      `toml:"buckets"`
+	BucketsAuto *autoBuckets `toml:"buckets_auto"`
 }
 
+// autoBuckets is the config for generating the bucket borders
+type autoBuckets struct {
+	Min   config.Number `toml:"min"`
+	Max   config.Number `toml:"max"`
+	Count int           `toml:"count"`
+	Scale string        `toml:"scale"`
+}
+
+// bucketPrecision is the number of significant digits of generated bucket
+// borders to avoid floating point noise in the tags
+const bucketPrecision = 6
+
 // bucketsByMetrics contains the buckets grouped by metric and field name
 type bucketsByMetrics map[string]bucketsByFields
 
@@ -91,6 +107,82 @@ func (*HistogramAggregator) SampleConfig() string {
 	return sampleConfig
 }
 
+func (h *HistogramAggregator) Init() error {
+	for i := range h.Configs {
+		cfg := &h.Configs[i]
+		if cfg.BucketsAuto == nil {
+			continue
+		}
+		if len(cfg.Buckets) > 0 {
+			return fmt.Errorf("config %d for %q: 'buckets' and 'buckets_auto' cannot be used together", i+1, cfg.Metric)
+		}
+		generated, err := cfg.BucketsAuto.generate()
+		if err != nil {
+			return fmt.Errorf("config %d for %q: %w", i+1, cfg.Metric, err)
+		}
+		cfg.Buckets = generated
+	}
+
+	return nil
+}
+
+// generate creates the bucket borders from min to max (both inclusive)
+func (a *autoBuckets) generate() (buckets, error) {
+	if a.Count < 2 {
+		return nil, errors.New("'count' of 'buckets_auto' must be at least 2")
+	}
+	lower, upper := float64(a.Min), float64(a.Max)
+	if lower >= upper {
+		return nil, errors.New("'min' of 'buckets_auto' must be less than 'max'")
+	}
+
+	var border func(i int) float64
+	switch a.Scale {
+	case "", "linear":
+		step := (upper - lower) / float64(a.Count-1)
+		border = func(i int) float64 {
+			return lower + float64(i)*step
+		}
+	case "log":
+		if lower <= 0 {
+			return nil, errors.New("'min' of 'buckets_auto' must be positive for log scale")
+		}
+		factor := math.Log(upper/lower) / float64(a.Count-1)
+		border = func(i int) float64 {
+			return lower * math.Exp(float64(i)*factor)
+		}
+	default:
+		return nil, fmt.Errorf("invalid 'scale' %q of 'buckets_auto'", a.Scale)
+	}
+
+	generated := make(buckets, 0, a.Count)
+	for i := 0; i < a.Count; i++ {
+		v := round(border(i))
+		// Use the exact borders at the ends of the range
+		if i == 0 {
+			v = lower
+		} else if i == a.Count-1 {
+			v = upper
+		}
+		// Rounding might collapse neighboring borders for large counts
+		if len(generated) > 0 && v <= generated[len(generated)-1] {
+			continue
+		}
+		generated = append(generated, v)
+	}
+
+	return generated, nil
+}
+
+// round rounds the value to the bucket precision
+func round(v float64) float64 {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(v, 'g', bucketPrecision, 64), 64)
+	if err != nil {
+		return v
+	}
+	return rounded
+}
+
 // Add adds new hit to the buckets
 func (h *HistogramAggregator) Add(in telegraf.Metric) {
 	addTime := timeNow()
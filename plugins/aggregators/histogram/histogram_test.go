@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/influxdata/toml"
 	"github.com/stretchr/testify/require"
 
 	"github.com/influxdata/telegraf"
@@ -527,3 +528,123 @@ func assertContainsTaggedField(t *testing.T, acc *testutil.Accumulator, metricNa
 
 	require.Fail(t, fmt.Sprintf("unknown measurement %q with tags: %v, fields: %v", metricName, tags, fields))
 }
+
+func TestAutoBuckets(t *testing.T) {
+	tests := []struct {
+		name     string
+		auto     autoBuckets
+		expected buckets
+	}{
+		{
+			name:     "linear",
+			auto:     autoBuckets{Min: 0, Max: 1, Count: 11, Scale: "linear"},
+			expected: buckets{0, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1},
+		},
+		{
+			name:     "linear default",
+			auto:     autoBuckets{Min: 10, Max: 50, Count: 5},
+			expected: buckets{10, 20, 30, 40, 50},
+		},
+		{
+			name:     "log",
+			auto:     autoBuckets{Min: 0.001, Max: 1000, Count: 7, Scale: "log"},
+			expected: buckets{0.001, 0.01, 0.1, 1, 10, 100, 1000},
+		},
+		{
+			name:     "log latency",
+			auto:     autoBuckets{Min: 0.001, Max: 60, Count: 5, Scale: "log"},
+			expected: buckets{0.001, 0.0156508, 0.244949, 3.83366, 60},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHistogramAggregator()
+			h.Configs = []bucketConfig{{Metric: "first_metric_name", BucketsAuto: &tt.auto}}
+			require.NoError(t, h.Init())
+			require.Equal(t, tt.expected, h.Configs[0].Buckets)
+		})
+	}
+}
+
+func TestAutoBucketsTags(t *testing.T) {
+	h := NewHistogramAggregator()
+	h.Configs = []bucketConfig{
+		{
+			Metric:      "first_metric_name",
+			Fields:      []string{"a"},
+			BucketsAuto: &autoBuckets{Min: 0.1, Max: 0.5, Count: 5},
+		},
+	}
+	require.NoError(t, h.Init())
+
+	acc := &testutil.Accumulator{}
+	h.Add(firstMetric1)
+	h.Push(acc)
+
+	require.Len(t, acc.Metrics, 6)
+	for i, le := range []string{"0.1", "0.2", "0.3", "0.4", "0.5", bucketPosInf} {
+		require.Equal(t, le, acc.Metrics[i].Tags[bucketRightTag])
+	}
+}
+
+func TestAutoBucketsConfig(t *testing.T) {
+	cfg := `
+[[config]]
+  measurement_name = "cpu"
+  [config.buckets_auto]
+    min = 0
+    max = 60
+    count = 4
+`
+	h := NewHistogramAggregator()
+	require.NoError(t, toml.Unmarshal([]byte(cfg), h))
+	require.NoError(t, h.Init())
+	require.Equal(t, buckets{0, 20, 40, 60}, h.Configs[0].Buckets)
+}
+
+func TestAutoBucketsInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      bucketConfig
+		expected string
+	}{
+		{
+			name: "mixed with explicit buckets",
+			cfg: bucketConfig{
+				Buckets:     buckets{1, 2, 3},
+				BucketsAuto: &autoBuckets{Min: 1, Max: 10, Count: 10},
+			},
+			expected: "'buckets' and 'buckets_auto' cannot be used together",
+		},
+		{
+			name:     "count too small",
+			cfg:      bucketConfig{BucketsAuto: &autoBuckets{Min: 1, Max: 10, Count: 1}},
+			expected: "must be at least 2",
+		},
+		{
+			name:     "min not less than max",
+			cfg:      bucketConfig{BucketsAuto: &autoBuckets{Min: 10, Max: 10, Count: 2}},
+			expected: "must be less than 'max'",
+		},
+		{
+			name:     "log with zero min",
+			cfg:      bucketConfig{BucketsAuto: &autoBuckets{Min: 0, Max: 10, Count: 2, Scale: "log"}},
+			expected: "must be positive for log scale",
+		},
+		{
+			name:     "invalid scale",
+			cfg:      bucketConfig{BucketsAuto: &autoBuckets{Min: 1, Max: 10, Count: 2, Scale: "exp"}},
+			expected: "invalid 'scale'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHistogramAggregator()
+			tt.cfg.Metric = "first_metric_name"
+			h.Configs = []bucketConfig{tt.cfg}
+			require.ErrorContains(t, h.Init(), tt.expected)
+		})
+	}
+}
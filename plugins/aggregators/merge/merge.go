@@ -3,6 +3,7 @@ package merge
 
 import (
 	_ "embed"
+	"fmt"
 	"time"
 
 	"github.com/influxdata/telegraf"
@@ -16,7 +17,18 @@ var sampleConfig string
 
 type Merge struct {
 	RoundTimestamp config.Duration `toml:"round_timestamp_to"`
+	RoundMethod    string          `toml:"round_timestamp_method"`
 	grouper        *metric.SeriesGrouper
+
+	// original timestamps of the field values per series and rounded time
+	// used to keep the most recent value on conflicts
+	fieldTimes map[seriesKey]map[string]time.Time
+}
+
+// seriesKey identifies a series at a rounded timestamp
+type seriesKey struct {
+	id uint64
+	ts int64
 }
 
 func (*Merge) SampleConfig() string {
@@ -24,7 +36,15 @@ func (*Merge) SampleConfig() string {
 }
 
 func (a *Merge) Init() error {
-	a.grouper = metric.NewSeriesGrouper()
+	switch a.RoundMethod {
+	case "":
+		a.RoundMethod = "round"
+	case "round", "truncate":
+	default:
+		return fmt.Errorf("invalid round_timestamp_method %q", a.RoundMethod)
+	}
+
+	a.Reset()
 	return nil
 }
 
@@ -37,11 +57,39 @@ func (a *Merge) Add(m telegraf.Metric) {
 			gm = m.Copy()
 		}
 		ts := gm.Time()
-		gm.SetTime(ts.Round(time.Duration(a.RoundTimestamp)))
+		if a.RoundMethod == "truncate" {
+			gm.SetTime(ts.Truncate(time.Duration(a.RoundTimestamp)))
+		} else {
+			gm.SetTime(ts.Round(time.Duration(a.RoundTimestamp)))
+		}
+		a.removeOutdatedFields(gm, ts)
 	}
 	a.grouper.AddMetric(gm)
 }
 
+// removeOutdatedFields removes all fields of the rounded metric that were
+// already added with a more recent original timestamp to the same series
+func (a *Merge) removeOutdatedFields(m telegraf.Metric, ts time.Time) {
+	key := seriesKey{id: m.HashID(), ts: m.Time().UnixNano()}
+	times, found := a.fieldTimes[key]
+	if !found {
+		times = make(map[string]time.Time, len(m.FieldList()))
+		a.fieldTimes[key] = times
+	}
+
+	var outdated []string
+	for _, field := range m.FieldList() {
+		if latest, found := times[field.Key]; found && latest.After(ts) {
+			outdated = append(outdated, field.Key)
+			continue
+		}
+		times[field.Key] = ts
+	}
+	for _, key := range outdated {
+		m.RemoveField(key)
+	}
+}
+
 func (a *Merge) Push(acc telegraf.Accumulator) {
 	// Always use nanosecond precision to avoid rounding metrics that were
 	// produced at a precision higher than the agent default.
@@ -54,6 +102,7 @@ func (a *Merge) Push(acc telegraf.Accumulator) {
 
 func (a *Merge) Reset() {
 	a.grouper = metric.NewSeriesGrouper()
+	a.fieldTimes = make(map[seriesKey]map[string]time.Time)
 }
 
 func init() {
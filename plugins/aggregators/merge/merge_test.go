@@ -372,3 +372,149 @@ func BenchmarkMergeTwo(b *testing.B) {
 		merger.Push(&acc)
 	}
 }
+
+func TestWithTruncation(t *testing.T) {
+	plugin := &Merge{
+		RoundTimestamp: config.Duration(time.Second),
+		RoundMethod:    "truncate",
+	}
+	require.NoError(t, plugin.Init())
+
+	plugin.Add(
+		testutil.MustMetric(
+			"cpu",
+			map[string]string{
+				"cpu": "cpu0",
+			},
+			map[string]interface{}{
+				"time_idle": 23,
+			},
+			time.Unix(1, int64(1*time.Millisecond)),
+		),
+	)
+	plugin.Add(
+		testutil.MustMetric(
+			"cpu",
+			map[string]string{
+				"cpu": "cpu0",
+			},
+			map[string]interface{}{
+				"time_guest": 42,
+			},
+			time.Unix(1, int64(999*time.Millisecond)),
+		),
+	)
+	plugin.Add(
+		testutil.MustMetric(
+			"cpu",
+			map[string]string{
+				"cpu": "cpu1",
+			},
+			map[string]interface{}{
+				"time_guest": 5,
+			},
+			time.Unix(1, int64(500*time.Millisecond)),
+		),
+	)
+
+	var acc testutil.Accumulator
+	plugin.Push(&acc)
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(
+			"cpu",
+			map[string]string{
+				"cpu": "cpu0",
+			},
+			map[string]interface{}{
+				"time_idle":  23,
+				"time_guest": 42,
+			},
+			time.Unix(1, 0),
+		),
+		testutil.MustMetric(
+			"cpu",
+			map[string]string{
+				"cpu": "cpu1",
+			},
+			map[string]interface{}{
+				"time_guest": 5,
+			},
+			time.Unix(1, 0),
+		),
+	}
+
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics())
+}
+
+func TestConflictKeepsMostRecent(t *testing.T) {
+	plugin := &Merge{
+		RoundTimestamp: config.Duration(time.Second),
+		RoundMethod:    "truncate",
+	}
+	require.NoError(t, plugin.Init())
+
+	plugin.Add(
+		testutil.MustMetric(
+			"cpu",
+			map[string]string{
+				"cpu": "cpu0",
+			},
+			map[string]interface{}{
+				"time_idle":  23,
+				"time_guest": 1,
+			},
+			time.Unix(0, int64(300*time.Millisecond)),
+		),
+	)
+	plugin.Add(
+		testutil.MustMetric(
+			"cpu",
+			map[string]string{
+				"cpu": "cpu0",
+			},
+			map[string]interface{}{
+				"time_idle":  42,
+				"time_steal": 2,
+			},
+			time.Unix(0, int64(100*time.Millisecond)),
+		),
+	)
+	plugin.Add(
+		testutil.MustMetric(
+			"cpu",
+			map[string]string{
+				"cpu": "cpu0",
+			},
+			map[string]interface{}{
+				"time_guest": 3,
+			},
+			time.Unix(0, int64(500*time.Millisecond)),
+		),
+	)
+
+	var acc testutil.Accumulator
+	plugin.Push(&acc)
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(
+			"cpu",
+			map[string]string{
+				"cpu": "cpu0",
+			},
+			map[string]interface{}{
+				"time_idle":  23,
+				"time_guest": 3,
+				"time_steal": 2,
+			},
+			time.Unix(0, 0),
+		),
+	}
+
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics())
+}
+
+func TestInvalidRoundMethod(t *testing.T) {
+	plugin := &Merge{RoundMethod: "floor"}
+	require.ErrorContains(t, plugin.Init(), "invalid round_timestamp_method")
+}
@@ -0,0 +1,159 @@
+//go:generate ../../../tools/readme_config_includer/generator
+package moving_average
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/aggregators"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+var timeNow = time.Now
+
+type MovingAverage struct {
+	Window config.Duration `toml:"window"`
+	Stats  []string        `toml:"stats"`
+	Suffix string          `toml:"suffix"`
+
+	emitMean, emitMin, emitMax, emitCount bool
+	cache                                 map[uint64]*series
+}
+
+// series contains the samples of all fields of a series within the window
+type series struct {
+	name   string
+	tags   map[string]string
+	fields map[string]*ring
+}
+
+func (*MovingAverage) SampleConfig() string {
+	return sampleConfig
+}
+
+func (m *MovingAverage) Init() error {
+	if m.Window <= 0 {
+		return fmt.Errorf("invalid window %v, must be positive", m.Window)
+	}
+
+	if m.Stats == nil {
+		m.Stats = []string{"mean"}
+	}
+	for _, stat := range m.Stats {
+		switch stat {
+		case "mean":
+			m.emitMean = true
+		case "min":
+			m.emitMin = true
+		case "max":
+			m.emitMax = true
+		case "count":
+			m.emitCount = true
+		default:
+			return fmt.Errorf("invalid stat %q", stat)
+		}
+	}
+
+	m.cache = make(map[uint64]*series)
+
+	return nil
+}
+
+func (m *MovingAverage) Add(in telegraf.Metric) {
+	id := in.HashID()
+	s, found := m.cache[id]
+	if !found {
+		s = &series{
+			name:   in.Name(),
+			tags:   in.Tags(),
+			fields: make(map[string]*ring),
+		}
+		m.cache[id] = s
+	}
+
+	for _, field := range in.FieldList() {
+		value, ok := convert(field.Value)
+		if !ok {
+			continue
+		}
+		r, found := s.fields[field.Key]
+		if !found {
+			r = &ring{}
+			s.fields[field.Key] = r
+		}
+		r.push(sample{value: value, time: in.Time()})
+	}
+}
+
+func (m *MovingAverage) Push(acc telegraf.Accumulator) {
+	cutoff := timeNow().Add(-time.Duration(m.Window))
+
+	for id, s := range m.cache {
+		fields := make(map[string]interface{}, len(s.fields))
+		for key, r := range s.fields {
+			r.expire(cutoff)
+			if r.size == 0 {
+				delete(s.fields, key)
+				continue
+			}
+
+			sum, minimum, maximum := 0.0, math.Inf(1), math.Inf(-1)
+			for i := 0; i < r.size; i++ {
+				v := r.at(i).value
+				sum += v
+				minimum = math.Min(minimum, v)
+				maximum = math.Max(maximum, v)
+			}
+			if m.emitMean {
+				fields[key+"_mean"] = sum / float64(r.size)
+			}
+			if m.emitMin {
+				fields[key+"_min"] = minimum
+			}
+			if m.emitMax {
+				fields[key+"_max"] = maximum
+			}
+			if m.emitCount {
+				fields[key+"_count"] = int64(r.size)
+			}
+		}
+
+		// Expire series without any samples within the window
+		if len(s.fields) == 0 {
+			delete(m.cache, id)
+			continue
+		}
+
+		acc.AddFields(s.name+m.Suffix, fields, s.tags)
+	}
+}
+
+// Reset does nothing as the samples are kept until they leave the window
+func (*MovingAverage) Reset() {}
+
+func convert(in interface{}) (float64, bool) {
+	switch v := in.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func init() {
+	aggregators.Add("moving_average", func() telegraf.Aggregator {
+		return &MovingAverage{
+			Window: config.Duration(5 * time.Minute),
+			Suffix: "_moving_average",
+		}
+	})
+}
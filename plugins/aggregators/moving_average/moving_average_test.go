@@ -0,0 +1,130 @@
+package moving_average
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestSlidingWindow(t *testing.T) {
+	now := time.Unix(1000, 0)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	plugin := &MovingAverage{
+		Window: config.Duration(60 * time.Second),
+		Stats:  []string{"mean", "min", "max", "count"},
+		Suffix: "_moving_average",
+	}
+	require.NoError(t, plugin.Init())
+
+	tags := map[string]string{"host": "a"}
+	for i, v := range []int64{1, 2, 3} {
+		plugin.Add(metric.New("cpu", tags, map[string]interface{}{"value": v}, now.Add(time.Duration(i-2)*10*time.Second)))
+	}
+
+	var acc testutil.Accumulator
+	plugin.Push(&acc)
+	plugin.Reset()
+
+	// Move on in time, the first sample leaves the window
+	now = now.Add(45 * time.Second)
+	plugin.Add(metric.New("cpu", tags, map[string]interface{}{"value": int64(7)}, now))
+	plugin.Push(&acc)
+	plugin.Reset()
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"cpu_moving_average",
+			tags,
+			map[string]interface{}{
+				"value_mean":  float64(2),
+				"value_min":   float64(1),
+				"value_max":   float64(3),
+				"value_count": int64(3),
+			},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"cpu_moving_average",
+			tags,
+			map[string]interface{}{
+				"value_mean":  float64(4),
+				"value_min":   float64(2),
+				"value_max":   float64(7),
+				"value_count": int64(3),
+			},
+			time.Unix(0, 0),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestSeriesExpiry(t *testing.T) {
+	now := time.Unix(1000, 0)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	plugin := &MovingAverage{
+		Window: config.Duration(60 * time.Second),
+	}
+	require.NoError(t, plugin.Init())
+
+	plugin.Add(metric.New("cpu", map[string]string{"host": "a"}, map[string]interface{}{"value": 1.5}, now))
+	plugin.Add(metric.New("cpu", map[string]string{"host": "b"}, map[string]interface{}{"value": 2.5}, now))
+
+	var acc testutil.Accumulator
+	plugin.Push(&acc)
+	require.Len(t, acc.GetTelegrafMetrics(), 2)
+	require.Len(t, plugin.cache, 2)
+
+	// Only one series is still updated
+	now = now.Add(61 * time.Second)
+	plugin.Add(metric.New("cpu", map[string]string{"host": "b"}, map[string]interface{}{"value": 3.5}, now))
+	acc.ClearMetrics()
+	plugin.Push(&acc)
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"cpu",
+			map[string]string{"host": "b"},
+			map[string]interface{}{"value_mean": 3.5},
+			time.Unix(0, 0),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+	require.Len(t, plugin.cache, 1)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	plugin := &MovingAverage{}
+	require.ErrorContains(t, plugin.Init(), "invalid window")
+
+	plugin = &MovingAverage{
+		Window: config.Duration(time.Minute),
+		Stats:  []string{"median"},
+	}
+	require.ErrorContains(t, plugin.Init(), "invalid stat")
+}
+
+func TestRingBounded(t *testing.T) {
+	start := time.Unix(0, 0)
+	var r ring
+	for i := 0; i < 100; i++ {
+		r.push(sample{value: float64(i), time: start.Add(time.Duration(i) * time.Second)})
+	}
+	require.Equal(t, 100, r.size)
+
+	r.expire(start.Add(95 * time.Second))
+	require.Equal(t, 5, r.size)
+	require.LessOrEqual(t, len(r.buf), 64)
+	for i := 0; i < r.size; i++ {
+		require.InDelta(t, float64(95+i), r.at(i).value, 0)
+	}
+}
@@ -0,0 +1,59 @@
+package moving_average
+
+import "time"
+
+// sample is a field value at a given time
+type sample struct {
+	value float64
+	time  time.Time
+}
+
+// ring is a growable ring-buffer of samples ordered by time
+type ring struct {
+	buf   []sample
+	start int
+	size  int
+}
+
+// push appends a sample, growing the buffer if it is full
+func (r *ring) push(s sample) {
+	if r.size == len(r.buf) {
+		capacity := 2 * len(r.buf)
+		if capacity == 0 {
+			capacity = 4
+		}
+		buf := make([]sample, capacity)
+		for i := 0; i < r.size; i++ {
+			buf[i] = r.at(i)
+		}
+		r.buf = buf
+		r.start = 0
+	}
+	r.buf[(r.start+r.size)%len(r.buf)] = s
+	r.size++
+}
+
+// expire removes all samples before the given time from the front
+func (r *ring) expire(before time.Time) {
+	for r.size > 0 && r.buf[r.start].time.Before(before) {
+		r.buf[r.start] = sample{}
+		r.start = (r.start + 1) % len(r.buf)
+		r.size--
+	}
+
+	// Shrink the buffer to bound the memory if the number of samples in the
+	// window decreased significantly
+	if len(r.buf) > 4 && r.size < len(r.buf)/4 {
+		buf := make([]sample, len(r.buf)/2)
+		for i := 0; i < r.size; i++ {
+			buf[i] = r.at(i)
+		}
+		r.buf = buf
+		r.start = 0
+	}
+}
+
+// at returns the i-th sample counting from the oldest one
+func (r *ring) at(i int) sample {
+	return r.buf[(r.start+i)%len(r.buf)]
+}
@@ -106,3 +106,56 @@ func (e *exactAlgorithmR8) Quantile(q float64) float64 {
 	// Linear interpolation
 	return e.xs[j] + gamma*(e.xs[j+1]-e.xs[j])
 }
+
+// exactWithFallback computes exact quantiles (R7) up to the given number of
+// samples and falls back to a t-digest approximation once the limit is
+// exceeded to bound the memory.
+type exactWithFallback struct {
+	exact       *exactAlgorithmR7
+	digest      algorithm
+	limit       int
+	compression float64
+}
+
+func newExactWithFallback(compression float64, limit int) (algorithm, error) {
+	// Make sure the fallback can be created
+	if _, err := newTDigest(compression); err != nil {
+		return nil, err
+	}
+	return &exactWithFallback{
+		exact:       &exactAlgorithmR7{xs: make([]float64, 0, limit), sorted: false},
+		limit:       limit,
+		compression: compression,
+	}, nil
+}
+
+func (e *exactWithFallback) Add(value float64) error {
+	if e.digest != nil {
+		return e.digest.Add(value)
+	}
+	if len(e.exact.xs) < e.limit {
+		return e.exact.Add(value)
+	}
+
+	// Limit exceeded, move the samples over to the digest
+	digest, err := newTDigest(e.compression)
+	if err != nil {
+		return err
+	}
+	for _, x := range e.exact.xs {
+		if err := digest.Add(x); err != nil {
+			return err
+		}
+	}
+	e.digest = digest
+	e.exact = nil
+
+	return e.digest.Add(value)
+}
+
+func (e *exactWithFallback) Quantile(q float64) float64 {
+	if e.digest != nil {
+		return e.digest.Quantile(q)
+	}
+	return e.exact.Quantile(q)
+}
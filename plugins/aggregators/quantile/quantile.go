@@ -16,6 +16,8 @@ type Quantile struct {
 	Quantiles     []float64 `toml:"quantiles"`
 	Compression   float64   `toml:"compression"`
 	AlgorithmType string    `toml:"algorithm"`
+	ExactLimit    int       `toml:"exact_limit"`
+	EmitSamples   bool      `toml:"emit_samples"`
 
 	newAlgorithm newAlgorithmFunc
 
@@ -26,9 +28,10 @@ type Quantile struct {
 }
 
 type aggregate struct {
-	name   string
-	fields map[string]algorithm
-	tags   map[string]string
+	name    string
+	fields  map[string]algorithm
+	samples map[string]int64
+	tags    map[string]string
 }
 
 type newAlgorithmFunc func(compression float64) (algorithm, error)
@@ -48,6 +51,7 @@ func (q *Quantile) Add(in telegraf.Metric) {
 					if err != nil {
 						q.Log.Errorf("adding cached field %s: %v", k, err)
 					}
+					cached.samples[k]++
 				}
 			}
 		}
@@ -56,9 +60,10 @@ func (q *Quantile) Add(in telegraf.Metric) {
 
 	// New metric, setup cache and init algorithm
 	a := aggregate{
-		name:   in.Name(),
-		tags:   in.Tags(),
-		fields: make(map[string]algorithm),
+		name:    in.Name(),
+		tags:    in.Tags(),
+		fields:  make(map[string]algorithm),
+		samples: make(map[string]int64),
 	}
 	for k, field := range in.Fields() {
 		if v, isconvertible := convert(field); isconvertible {
@@ -71,6 +76,7 @@ func (q *Quantile) Add(in telegraf.Metric) {
 				q.Log.Errorf("adding field %s: %v", k, err)
 			}
 			a.fields[k] = algo
+			a.samples[k] = 1
 		}
 	}
 	q.cache[id] = a
@@ -83,6 +89,9 @@ func (q *Quantile) Push(acc telegraf.Accumulator) {
 			for i, qtl := range q.Quantiles {
 				fields[k+q.suffixes[i]] = algo.Quantile(qtl)
 			}
+			if q.EmitSamples {
+				fields[k+"_samples"] = aggregate.samples[k]
+			}
 		}
 		acc.AddFields(aggregate.name, fields, aggregate.tags)
 	}
@@ -113,6 +122,13 @@ func (q *Quantile) Init() error {
 		q.newAlgorithm = newExactR7
 	case "exact R8":
 		q.newAlgorithm = newExactR8
+	case "exact":
+		if q.ExactLimit <= 0 {
+			return fmt.Errorf("invalid exact_limit %d", q.ExactLimit)
+		}
+		q.newAlgorithm = func(compression float64) (algorithm, error) {
+			return newExactWithFallback(compression, q.ExactLimit)
+		}
 	default:
 		return fmt.Errorf("unknown algorithm type %q", q.AlgorithmType)
 	}
@@ -144,6 +160,6 @@ func (q *Quantile) Init() error {
 
 func init() {
 	aggregators.Add("quantile", func() telegraf.Aggregator {
-		return &Quantile{Compression: 100}
+		return &Quantile{Compression: 100, ExactLimit: 1000}
 	})
 }
@@ -669,3 +669,67 @@ func BenchmarkDefaultExactR8100Q(b *testing.B) {
 		q.Push(&acc)
 	}
 }
+
+func TestConfigInvalidExactLimit(t *testing.T) {
+	q := Quantile{Compression: 100, AlgorithmType: "exact"}
+	require.ErrorContains(t, q.Init(), "invalid exact_limit")
+}
+
+func TestSingleMetricExactWithSamples(t *testing.T) {
+	acc := testutil.Accumulator{}
+
+	q := Quantile{
+		AlgorithmType: "exact",
+		Compression:   100,
+		ExactLimit:    1000,
+		EmitSamples:   true,
+		Quantiles:     []float64{0.5, 0.99},
+		Log:           testutil.Logger{},
+	}
+	require.NoError(t, q.Init())
+
+	for _, v := range []float64{1, 2, 3, 4, 100} {
+		q.Add(testutil.MustMetric(
+			"test",
+			map[string]string{"foo": "bar"},
+			map[string]interface{}{"a": v},
+			time.Now(),
+		))
+	}
+	q.Push(&acc)
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(
+			"test",
+			map[string]string{"foo": "bar"},
+			map[string]interface{}{
+				"a_050":     3.0,
+				"a_099":     96.16,
+				"a_samples": int64(5),
+			},
+			time.Now(),
+		),
+	}
+
+	epsilon := cmpopts.EquateApprox(0, 1e-3)
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime(), epsilon)
+}
+
+func TestExactFallbackToTDigest(t *testing.T) {
+	algo, err := newExactWithFallback(100, 10)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, algo.Add(float64(i)))
+	}
+	fallback := algo.(*exactWithFallback)
+	require.Nil(t, fallback.digest)
+	require.InDelta(t, 4.5, algo.Quantile(0.5), 1e-9)
+
+	for i := 10; i < 1000; i++ {
+		require.NoError(t, algo.Add(float64(i)))
+	}
+	require.NotNil(t, fallback.digest)
+	require.Nil(t, fallback.exact)
+	require.InDelta(t, 499.5, algo.Quantile(0.5), 5)
+}
@@ -2,26 +2,92 @@
 package valuecounter
 
 import (
+	"container/heap"
 	_ "embed"
 	"fmt"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/aggregators"
+	"github.com/influxdata/telegraf/selfstat"
 )
 
 //go:embed sample.conf
 var sampleConfig string
 
+// Suffix of the field reporting the occurrences not attributed to a value if
+// the number of values is limited
+const otherSuffix = "_other"
+
 type aggregate struct {
 	name       string
 	tags       map[string]string
 	fieldCount map[string]int
+
+	// tracked values per counted field, only used if the number of values
+	// is limited
+	tracked map[string]*trackedValues
+}
+
+// counter of a tracked value with the count inherited from the evicted value
+// it replaced, see addLimited
+type counter struct {
+	name      string
+	count     int
+	inherited int
+	index     int
+}
+
+// trackedValues are the counters of a field ordered by their count in a
+// min-heap to find the value to evict without scanning all counters
+type trackedValues struct {
+	byName map[string]*counter
+	order  counterHeap
+}
+
+// counterHeap implements heap.Interface ordering the counters by count and
+// name to get a stable eviction order
+type counterHeap []*counter
+
+func (h counterHeap) Len() int {
+	return len(h)
+}
+
+func (h counterHeap) Less(i, j int) bool {
+	if h[i].count == h[j].count {
+		return h[i].name < h[j].name
+	}
+	return h[i].count < h[j].count
+}
+
+func (h counterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *counterHeap) Push(x interface{}) {
+	c := x.(*counter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *counterHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	c := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return c
 }
 
 // ValueCounter an aggregation plugin
 type ValueCounter struct {
-	cache  map[uint64]aggregate
-	Fields []string
+	cache     map[uint64]aggregate
+	Fields    []string
+	MaxValues int    `toml:"max_values"`
+	Alias     string `toml:"alias"`
+
+	valuesFolded selfstat.Stat
 }
 
 // NewValueCounter create a new aggregation plugin which counts the occurrences
@@ -36,6 +102,21 @@ func (*ValueCounter) SampleConfig() string {
 	return sampleConfig
 }
 
+func (vc *ValueCounter) Init() error {
+	if vc.MaxValues < 0 {
+		return fmt.Errorf("invalid max_values %d", vc.MaxValues)
+	}
+	if vc.MaxValues > 0 {
+		// Tag the statistics by alias to distinguish the plugin instances
+		tags := make(map[string]string)
+		if vc.Alias != "" {
+			tags["alias"] = vc.Alias
+		}
+		vc.valuesFolded = selfstat.Register("valuecounter", "values_folded", tags)
+	}
+	return nil
+}
+
 // Add is run on every metric which passes the plugin
 func (vc *ValueCounter) Add(in telegraf.Metric) {
 	id := in.HashID()
@@ -46,6 +127,7 @@ func (vc *ValueCounter) Add(in telegraf.Metric) {
 			name:       in.Name(),
 			tags:       in.Tags(),
 			fieldCount: make(map[string]int),
+			tracked:    make(map[string]*trackedValues),
 		}
 		vc.cache[id] = a
 	}
@@ -56,12 +138,54 @@ func (vc *ValueCounter) Add(in telegraf.Metric) {
 		for _, cf := range vc.Fields {
 			if fk == cf {
 				fn := fmt.Sprintf("%v_%v", fk, fv)
+				if vc.MaxValues > 0 {
+					vc.addLimited(vc.cache[id], fk, fn)
+					continue
+				}
 				vc.cache[id].fieldCount[fn]++
 			}
 		}
 	}
 }
 
+// addLimited counts the value in the tracked values of the field using the
+// space-saving algorithm. If the number of tracked values is exceeded, the
+// value with the lowest count is replaced by the new value which inherits the
+// count of the evicted value. This way frequent values are not evicted by a
+// stream of rare values. The inherited counts are reported as "other".
+func (vc *ValueCounter) addLimited(agg aggregate, field, fn string) {
+	values, ok := agg.tracked[field]
+	if !ok {
+		values = &trackedValues{
+			byName: make(map[string]*counter, vc.MaxValues),
+			order:  make(counterHeap, 0, vc.MaxValues),
+		}
+		agg.tracked[field] = values
+	}
+
+	if c, found := values.byName[fn]; found {
+		c.count++
+		heap.Fix(&values.order, c.index)
+		return
+	}
+	if len(values.byName) < vc.MaxValues {
+		c := &counter{name: fn, count: 1}
+		values.byName[fn] = c
+		heap.Push(&values.order, c)
+		return
+	}
+
+	// Replace the value with the lowest count by the new value
+	evicted := values.order[0]
+	delete(values.byName, evicted.name)
+	c := &counter{name: fn, count: evicted.count + 1, inherited: evicted.count}
+	values.byName[fn] = c
+	values.order[0] = c
+	c.index = 0
+	heap.Fix(&values.order, 0)
+	vc.valuesFolded.Incr(1)
+}
+
 // Push emits the counters
 func (vc *ValueCounter) Push(acc telegraf.Accumulator) {
 	for _, agg := range vc.cache {
@@ -69,6 +193,22 @@ func (vc *ValueCounter) Push(acc telegraf.Accumulator) {
 		for field, count := range agg.fieldCount {
 			fields[field] = count
 		}
+		for name, values := range agg.tracked {
+			var inherited int
+			for field, c := range values.byName {
+				fields[field] = c.count - c.inherited
+				inherited += c.inherited
+			}
+			if inherited == 0 {
+				continue
+			}
+			// Occurrences of a value named "other" are reported together
+			// with the ones not attributed to a value
+			if v, found := fields[name+otherSuffix]; found {
+				inherited += v.(int)
+			}
+			fields[name+otherSuffix] = inherited
+		}
 
 		acc.AddFields(agg.name, fields, agg.tags)
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/require"
 )
 
 // Create a valuecounter with config
@@ -123,3 +124,137 @@ func TestWithReset(t *testing.T) {
 	}
 	acc.AssertContainsTaggedFields(t, "m1", expectedFields, expectedTags)
 }
+
+// Test limiting the number of counted values
+func TestMaxValues(t *testing.T) {
+	vc := &ValueCounter{
+		Fields:    []string{"status"},
+		MaxValues: 2,
+	}
+	require.NoError(t, vc.Init())
+	vc.Reset()
+	folded := vc.valuesFolded.Get()
+
+	for _, status := range []string{"a", "a", "a", "b", "c", "d", "a"} {
+		vc.Add(metric.New("m1",
+			map[string]string{"foo": "bar"},
+			map[string]interface{}{"status": status},
+			time.Now(),
+		))
+	}
+
+	acc := testutil.Accumulator{}
+	vc.Push(&acc)
+
+	expected := []telegraf.Metric{
+		metric.New("m1",
+			map[string]string{"foo": "bar"},
+			map[string]interface{}{
+				"status_a":     4,
+				"status_d":     1,
+				"status_other": 2,
+			},
+			time.Unix(0, 0),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime(), testutil.SortMetrics())
+	require.Equal(t, int64(2), vc.valuesFolded.Get()-folded)
+}
+
+// Test that no other counter is emitted within the limit
+func TestMaxValuesNotExceeded(t *testing.T) {
+	vc := &ValueCounter{
+		Fields:    []string{"status"},
+		MaxValues: 2,
+	}
+	require.NoError(t, vc.Init())
+	vc.Reset()
+	acc := testutil.Accumulator{}
+
+	vc.Add(m1)
+	vc.Add(m2)
+	vc.Add(m1)
+	vc.Push(&acc)
+
+	expectedFields := map[string]interface{}{
+		"status_200": 2,
+		"status_OK":  1,
+	}
+	expectedTags := map[string]string{
+		"foo": "bar",
+	}
+	acc.AssertContainsTaggedFields(t, "m1", expectedFields, expectedTags)
+	require.Len(t, acc.GetTelegrafMetrics(), 1)
+}
+
+// Test that a frequent value is not evicted by a stream of rare values
+func TestMaxValuesFrequentValueKept(t *testing.T) {
+	vc := &ValueCounter{
+		Fields:    []string{"status"},
+		MaxValues: 2,
+	}
+	require.NoError(t, vc.Init())
+	vc.Reset()
+
+	for _, status := range []string{"a", "a", "a", "a", "a", "a", "b", "c", "d", "e", "f", "a"} {
+		vc.Add(metric.New("m1",
+			map[string]string{"foo": "bar"},
+			map[string]interface{}{"status": status},
+			time.Now(),
+		))
+	}
+
+	acc := testutil.Accumulator{}
+	vc.Push(&acc)
+
+	expected := []telegraf.Metric{
+		metric.New("m1",
+			map[string]string{"foo": "bar"},
+			map[string]interface{}{
+				"status_a":     7,
+				"status_f":     1,
+				"status_other": 4,
+			},
+			time.Unix(0, 0),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime(), testutil.SortMetrics())
+}
+
+// Test that a value named "other" is reported together with the folded values
+func TestMaxValuesOtherValue(t *testing.T) {
+	vc := &ValueCounter{
+		Fields:    []string{"status"},
+		MaxValues: 2,
+	}
+	require.NoError(t, vc.Init())
+	vc.Reset()
+
+	for _, status := range []string{"other", "other", "a", "b", "other"} {
+		vc.Add(metric.New("m1",
+			map[string]string{"foo": "bar"},
+			map[string]interface{}{"status": status},
+			time.Now(),
+		))
+	}
+
+	acc := testutil.Accumulator{}
+	vc.Push(&acc)
+
+	expected := []telegraf.Metric{
+		metric.New("m1",
+			map[string]string{"foo": "bar"},
+			map[string]interface{}{
+				"status_b":     1,
+				"status_other": 4,
+			},
+			time.Unix(0, 0),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestMaxValuesInvalid(t *testing.T) {
+	vc := &ValueCounter{MaxValues: -1}
+	require.ErrorContains(t, vc.Init(), "invalid max_values")
+}
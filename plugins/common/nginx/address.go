@@ -0,0 +1,52 @@
+// Package nginx contains the address handling shared by the nginx plugins
+package nginx
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/peterbourgon/unixtransport"
+)
+
+// ParseAddress parses the given URL and rewrites unix domain socket addresses
+// of the form "unix:///path/to/socket:/request/path" to the "http+unix" scheme
+// handled by the transport returned by NewTransport
+func ParseAddress(u string) (*url.URL, error) {
+	addr, err := url.Parse(u)
+	if err != nil {
+		return nil, err
+	}
+	if addr.Scheme == "unix" {
+		addr.Scheme = "http+unix"
+	}
+	if strings.HasSuffix(addr.Scheme, "+unix") {
+		if _, path, found := strings.Cut(addr.Path, ":"); !found || !strings.HasPrefix(path, "/") {
+			return nil, errors.New("missing request path after socket")
+		}
+	}
+	return addr, nil
+}
+
+// SocketPath returns the path of the unix domain socket of the given address
+// and false if the address does not refer to a socket
+func SocketPath(addr *url.URL) (string, bool) {
+	if !strings.HasSuffix(addr.Scheme, "+unix") {
+		return "", false
+	}
+	socket, _, _ := strings.Cut(addr.Path, ":")
+	return socket, true
+}
+
+// NewTransport returns a transport using the given TLS configuration and
+// supporting the "http+unix" and "https+unix" protocols for connecting to
+// unix domain sockets
+func NewTransport(tlsCfg *tls.Config) *http.Transport {
+	transport := &http.Transport{
+		TLSClientConfig: tlsCfg,
+	}
+	unixtransport.Register(transport)
+	return transport
+}
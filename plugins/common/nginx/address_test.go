@@ -0,0 +1,59 @@
+package nginx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		scheme   string
+		socket   string
+		isSocket bool
+		err      string
+	}{
+		{
+			name:    "http",
+			address: "http://localhost/stub_status",
+			scheme:  "http",
+		},
+		{
+			name:     "unix socket",
+			address:  "unix:///var/run/nginx/status.sock:/stub_status",
+			scheme:   "http+unix",
+			socket:   "/var/run/nginx/status.sock",
+			isSocket: true,
+		},
+		{
+			name:     "https over unix socket",
+			address:  "https+unix:///var/run/nginx/status.sock:/stub_status",
+			scheme:   "https+unix",
+			socket:   "/var/run/nginx/status.sock",
+			isSocket: true,
+		},
+		{
+			name:    "unix socket without request path",
+			address: "unix:///var/run/nginx/status.sock",
+			err:     "missing request path after socket",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := ParseAddress(tt.address)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.scheme, addr.Scheme)
+
+			socket, isSocket := SocketPath(addr)
+			require.Equal(t, tt.isSocket, isSocket)
+			require.Equal(t, tt.socket, socket)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package starlark
+
+import (
+	"fmt"
+	"time"
+
+	"go.starlark.net/lib/json"
+	startime "go.starlark.net/lib/time"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// Maximum nesting level of arrays and objects accepted by json.decode
+const maxJSONDepth = 64
+
+// predeclaredJSON returns the json module available without loading it.
+// Decoding is guarded against excessively nested documents.
+func predeclaredJSON() *starlarkstruct.Module {
+	return &starlarkstruct.Module{
+		Name: "json",
+		Members: starlark.StringDict{
+			"encode": json.Module.Members["encode"],
+			"decode": starlark.NewBuiltin("decode", jsonDecode),
+			"indent": json.Module.Members["indent"],
+		},
+	}
+}
+
+// predeclaredTime returns the time module available without loading it.
+// In contrast to the loadable module, parse_time takes the layout first to
+// ease parsing of field values.
+func predeclaredTime() *starlarkstruct.Module {
+	members := make(starlark.StringDict, len(startime.Module.Members))
+	for k, v := range startime.Module.Members {
+		members[k] = v
+	}
+	members["parse_time"] = starlark.NewBuiltin("parse_time", timeParse)
+
+	return &starlarkstruct.Module{
+		Name:    "time",
+		Members: members,
+	}
+}
+
+// json.decode(x, default=unbound) with an additional depth check
+func jsonDecode(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if len(args) > 0 {
+		if s, ok := args[0].(starlark.String); ok {
+			if err := checkJSONDepth(string(s), maxJSONDepth); err != nil {
+				return nil, fmt.Errorf("%s: %w", b.Name(), err)
+			}
+		}
+	}
+	decode := json.Module.Members["decode"].(*starlark.Builtin)
+	return decode.CallInternal(thread, args, kwargs)
+}
+
+// checkJSONDepth scans the given document and errors if arrays or objects
+// are nested deeper than the given limit.
+func checkJSONDepth(doc string, limit int) error {
+	var depth int
+	var inString, escaped bool
+	for _, c := range doc {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '[', '{':
+			depth++
+			if depth > limit {
+				return fmt.Errorf("document exceeds maximum nesting depth of %d", limit)
+			}
+		case ']', '}':
+			depth--
+		}
+	}
+	return nil
+}
+
+// time.parse_time(layout, value, location="UTC")
+func timeParse(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var layout, value string
+	location := "UTC"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "layout", &layout, "value", &value, "location?", &location); err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(location)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid location %q: %w", b.Name(), location, err)
+	}
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	return startime.Time(t), nil
+}
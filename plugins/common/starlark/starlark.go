@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"strings"
 
-	"go.starlark.net/lib/json"
 	"go.starlark.net/lib/math"
 	"go.starlark.net/lib/time"
 	"go.starlark.net/starlark"
@@ -115,6 +114,8 @@ func (s *Common) Init() error {
 	s.builtins["Metric"] = starlark.NewBuiltin("Metric", newMetric)
 	s.builtins["deepcopy"] = starlark.NewBuiltin("deepcopy", deepcopy)
 	s.builtins["catch"] = starlark.NewBuiltin("catch", catch)
+	s.builtins["json"] = predeclaredJSON()
+	s.builtins["time"] = predeclaredTime()
 
 	if err := s.addConstants(&s.builtins); err != nil {
 		return err
@@ -262,7 +263,7 @@ func LoadFunc(module string, logger telegraf.Logger) (starlark.StringDict, error
 	switch module {
 	case "json.star":
 		return starlark.StringDict{
-			"json": json.Module,
+			"json": predeclaredJSON(),
 		}, nil
 	case "logging.star":
 		return starlark.StringDict{
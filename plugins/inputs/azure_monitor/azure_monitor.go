@@ -5,12 +5,14 @@ import (
 	_ "embed"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/plugins/inputs"
 	receiver "github.com/logzio/azure-monitor-metrics-receiver"
 )
@@ -24,17 +26,22 @@ type AzureMonitor struct {
 	ResourceTargets      []*resourceTarget      `toml:"resource_target"`
 	ResourceGroupTargets []*resourceGroupTarget `toml:"resource_group_target"`
 	SubscriptionTargets  []*resource            `toml:"subscription_target"`
+	RefreshInterval      config.Duration        `toml:"refresh_interval"`
 	Log                  telegraf.Logger        `toml:"-"`
 
-	receiver     *receiver.AzureMonitorMetricsReceiver
-	azureManager azureClientsCreator
-	azureClients *receiver.AzureClients
+	receiver      *receiver.AzureMonitorMetricsReceiver
+	azureManager  azureClientsCreator
+	azureClients  *receiver.AzureClients
+	resources     *resourcesFilter
+	dimensions    map[string][]string
+	lastDiscovery time.Time
 }
 
 type resourceTarget struct {
-	ResourceID   string   `toml:"resource_id"`
-	Metrics      []string `toml:"metrics"`
-	Aggregations []string `toml:"aggregations"`
+	ResourceID        string   `toml:"resource_id"`
+	Metrics           []string `toml:"metrics"`
+	Aggregations      []string `toml:"aggregations"`
+	SplitByDimensions []string `toml:"split_by_dimensions"`
 }
 
 type resourceGroupTarget struct {
@@ -43,9 +50,11 @@ type resourceGroupTarget struct {
 }
 
 type resource struct {
-	ResourceType string   `toml:"resource_type"`
-	Metrics      []string `toml:"metrics"`
-	Aggregations []string `toml:"aggregations"`
+	ResourceType      string            `toml:"resource_type"`
+	Metrics           []string          `toml:"metrics"`
+	Aggregations      []string          `toml:"aggregations"`
+	Tags              map[string]string `toml:"tags"`
+	SplitByDimensions []string          `toml:"split_by_dimensions"`
 }
 
 type azureClientsManager struct{}
@@ -75,45 +84,49 @@ func (am *AzureMonitor) Init() error {
 		return fmt.Errorf("unknown cloud option: %s", am.CloudOption)
 	}
 
+	for _, target := range am.ResourceGroupTargets {
+		if err := checkUniqueResourceTypes(target.Resources); err != nil {
+			return fmt.Errorf("resource group target %s: %w", target.ResourceGroup, err)
+		}
+	}
+	if err := checkUniqueResourceTypes(am.SubscriptionTargets); err != nil {
+		return fmt.Errorf("subscription targets: %w", err)
+	}
+
 	var err error
 	am.azureClients, err = am.azureManager.createAzureClients(am.SubscriptionID, am.ClientID, am.ClientSecret, am.TenantID, clientOptions)
 	if err != nil {
 		return err
 	}
 
-	if err = am.setReceiver(); err != nil {
-		return fmt.Errorf("error setting Azure Monitor receiver: %w", err)
-	}
-
-	if err = am.receiver.CreateResourceTargetsFromResourceGroupTargets(); err != nil {
-		return fmt.Errorf("error creating resource targets from resource group targets: %w", err)
+	am.resources = &resourcesFilter{
+		ResourcesClient: am.azureClients.ResourcesClient,
+		groups:          am.ResourceGroupTargets,
+		subscriptions:   am.SubscriptionTargets,
+		log:             am.Log,
 	}
-
-	if err = am.receiver.CreateResourceTargetsFromSubscriptionTargets(); err != nil {
-		return fmt.Errorf("error creating resource targets from subscription targets: %w", err)
+	am.azureClients.ResourcesClient = am.resources
+	am.azureClients.MetricsClient = &throttledMetricsClient{
+		MetricsClient:  am.azureClients.MetricsClient,
+		subscriptionID: am.SubscriptionID,
+		log:            am.Log,
 	}
 
-	if err = am.receiver.CheckResourceTargetsMetricsValidation(); err != nil {
-		return fmt.Errorf("error checking resource targets metrics validation: %w", err)
-	}
+	return am.discover()
+}
 
-	if err = am.receiver.SetResourceTargetsMetrics(); err != nil {
-		return fmt.Errorf("error setting resource targets metrics: %w", err)
+func (am *AzureMonitor) Gather(acc telegraf.Accumulator) error {
+	if am.RefreshInterval > 0 && time.Since(am.lastDiscovery) >= time.Duration(am.RefreshInterval) {
+		if err := am.discover(); err != nil {
+			acc.AddError(fmt.Errorf("refreshing resource targets failed: %w", err))
+		}
 	}
 
-	if err = am.receiver.SplitResourceTargetsMetricsByMinTimeGrain(); err != nil {
-		return fmt.Errorf("error splitting resource targets metrics by min time grain: %w", err)
+	if until := throttling.throttledUntil(am.SubscriptionID); time.Now().Before(until) {
+		am.Log.Debugf("Skipping collection as requests are throttled until %s", until.Format(time.RFC3339))
+		return nil
 	}
 
-	am.receiver.SplitResourceTargetsWithMoreThanMaxMetrics()
-	am.receiver.SetResourceTargetsAggregations()
-
-	am.Log.Debug("Total resource targets: ", len(am.receiver.Targets.ResourceTargets))
-
-	return nil
-}
-
-func (am *AzureMonitor) Gather(acc telegraf.Accumulator) error {
 	var waitGroup sync.WaitGroup
 
 	for _, target := range am.receiver.Targets.ResourceTargets {
@@ -123,7 +136,14 @@ func (am *AzureMonitor) Gather(acc telegraf.Accumulator) error {
 		go func(target *receiver.ResourceTarget) {
 			defer waitGroup.Done()
 
-			collectedMetrics, notCollectedMetrics, err := am.receiver.CollectResourceTargetMetrics(target)
+			var collectedMetrics []*receiver.Metric
+			var notCollectedMetrics []string
+			var err error
+			if dimensions, found := am.dimensions[target.ResourceID]; found {
+				collectedMetrics, notCollectedMetrics, err = am.collectSplitMetrics(target, dimensions)
+			} else {
+				collectedMetrics, notCollectedMetrics, err = am.receiver.CollectResourceTargetMetrics(target)
+			}
 			if err != nil {
 				acc.AddError(err)
 			}
@@ -142,7 +162,56 @@ func (am *AzureMonitor) Gather(acc telegraf.Accumulator) error {
 	return nil
 }
 
-func (am *AzureMonitor) setReceiver() error {
+// discover creates the resource targets including the ones of resources
+// under the resource group and subscription targets. On errors the previously
+// discovered targets are kept.
+func (am *AzureMonitor) discover() error {
+	dimensions := make(map[string][]string)
+	for _, target := range am.ResourceTargets {
+		if len(target.SplitByDimensions) > 0 {
+			dimensions["/subscriptions/"+am.SubscriptionID+"/"+target.ResourceID] = target.SplitByDimensions
+		}
+	}
+	am.resources.dimensions = dimensions
+
+	r, err := am.newReceiver()
+	if err != nil {
+		return fmt.Errorf("error setting Azure Monitor receiver: %w", err)
+	}
+
+	if err := r.CreateResourceTargetsFromResourceGroupTargets(); err != nil {
+		return fmt.Errorf("error creating resource targets from resource group targets: %w", err)
+	}
+
+	if err := r.CreateResourceTargetsFromSubscriptionTargets(); err != nil {
+		return fmt.Errorf("error creating resource targets from subscription targets: %w", err)
+	}
+
+	if err := r.CheckResourceTargetsMetricsValidation(); err != nil {
+		return fmt.Errorf("error checking resource targets metrics validation: %w", err)
+	}
+
+	if err := r.SetResourceTargetsMetrics(); err != nil {
+		return fmt.Errorf("error setting resource targets metrics: %w", err)
+	}
+
+	if err := r.SplitResourceTargetsMetricsByMinTimeGrain(); err != nil {
+		return fmt.Errorf("error splitting resource targets metrics by min time grain: %w", err)
+	}
+
+	r.SplitResourceTargetsWithMoreThanMaxMetrics()
+	r.SetResourceTargetsAggregations()
+
+	am.Log.Debug("Total resource targets: ", len(r.Targets.ResourceTargets))
+
+	am.receiver = r
+	am.dimensions = dimensions
+	am.lastDiscovery = time.Now()
+
+	return nil
+}
+
+func (am *AzureMonitor) newReceiver() (*receiver.AzureMonitorMetricsReceiver, error) {
 	resourceTargets := make([]*receiver.ResourceTarget, 0, len(am.ResourceTargets))
 	resourceGroupTargets := make([]*receiver.ResourceGroupTarget, 0, len(am.ResourceGroupTargets))
 	subscriptionTargets := make([]*receiver.Resource, 0, len(am.SubscriptionTargets))
@@ -165,9 +234,23 @@ func (am *AzureMonitor) setReceiver() error {
 	}
 
 	targets := receiver.NewTargets(resourceTargets, resourceGroupTargets, subscriptionTargets)
-	var err error
-	am.receiver, err = receiver.NewAzureMonitorMetricsReceiver(am.SubscriptionID, targets, am.azureClients)
-	return err
+	return receiver.NewAzureMonitorMetricsReceiver(am.SubscriptionID, targets, am.azureClients)
+}
+
+// checkUniqueResourceTypes makes sure resources with tags or dimensions are
+// the only ones of their type as discovered resources cannot be assigned
+// otherwise
+func checkUniqueResourceTypes(resources []*resource) error {
+	count := make(map[string]int, len(resources))
+	for _, r := range resources {
+		count[r.ResourceType]++
+	}
+	for _, r := range resources {
+		if (len(r.Tags) > 0 || len(r.SplitByDimensions) > 0) && count[r.ResourceType] > 1 {
+			return fmt.Errorf("resources with tags or split_by_dimensions must be the only ones of resource type %s", r.ResourceType)
+		}
+	}
+	return nil
 }
 
 func (acm *azureClientsManager) createAzureClients(
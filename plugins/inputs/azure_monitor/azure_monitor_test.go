@@ -5,11 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/influxdata/toml"
@@ -19,7 +23,10 @@ import (
 	"github.com/influxdata/telegraf/testutil"
 )
 
-type mockAzureClientsManager struct{}
+type mockAzureClientsManager struct {
+	resourcesClient receiver.ResourcesClient
+	metricsClient   receiver.MetricsClient
+}
 
 type mockAzureResourcesClient struct{}
 
@@ -28,12 +35,19 @@ type mockAzureMetricDefinitionsClient struct{}
 type mockAzureMetricsClient struct{}
 
 func (mam *mockAzureClientsManager) createAzureClients(_, _, _, _ string, _ azcore.ClientOptions) (*receiver.AzureClients, error) {
-	return &receiver.AzureClients{
+	clients := &receiver.AzureClients{
 		Ctx:                     context.Background(),
 		ResourcesClient:         &mockAzureResourcesClient{},
 		MetricDefinitionsClient: &mockAzureMetricDefinitionsClient{},
 		MetricsClient:           &mockAzureMetricsClient{},
-	}, nil
+	}
+	if mam.resourcesClient != nil {
+		clients.ResourcesClient = mam.resourcesClient
+	}
+	if mam.metricsClient != nil {
+		clients.MetricsClient = mam.metricsClient
+	}
+	return clients, nil
 }
 
 func (marc *mockAzureResourcesClient) List(_ context.Context, _ *armresources.ClientListOptions) ([]*armresources.ClientListResponse, error) {
@@ -199,6 +213,82 @@ func (mamc *mockAzureMetricsClient) List(
 	return armmonitor.MetricsClientListResponse{}, errors.New("resource ID was not found")
 }
 
+type countingResourcesClient struct {
+	mockAzureResourcesClient
+	calls int
+}
+
+func (crc *countingResourcesClient) ListByResourceGroup(
+	ctx context.Context,
+	resourceGroup string,
+	options *armresources.ClientListByResourceGroupOptions) ([]*armresources.ClientListByResourceGroupResponse, error) {
+	crc.calls++
+	return crc.mockAzureResourcesClient.ListByResourceGroup(ctx, resourceGroup, options)
+}
+
+type mockDimensionsMetricsClient struct {
+	mockAzureMetricsClient
+	filters map[string]string
+}
+
+func (mdmc *mockDimensionsMetricsClient) List(
+	ctx context.Context,
+	resourceID string,
+	options *armmonitor.MetricsClientListOptions) (armmonitor.MetricsClientListResponse, error) {
+	if options.Filter == nil {
+		return mdmc.mockAzureMetricsClient.List(ctx, resourceID, options)
+	}
+	mdmc.filters[resourceID] = *options.Filter
+
+	timestamp := time.Date(2022, 2, 22, 22, 59, 0, 0, time.UTC)
+	series := func(lun string, total float64) *armmonitor.TimeSeriesElement {
+		return &armmonitor.TimeSeriesElement{
+			Metadatavalues: []*armmonitor.MetadataValue{
+				{Name: &armmonitor.LocalizableString{Value: to.Ptr("lun")}, Value: to.Ptr(lun)},
+			},
+			Data: []*armmonitor.MetricValue{
+				{TimeStamp: to.Ptr(timestamp.Add(-time.Minute)), Total: to.Ptr(1.0)},
+				{TimeStamp: to.Ptr(timestamp), Total: to.Ptr(total)},
+				{TimeStamp: to.Ptr(timestamp.Add(time.Minute))},
+			},
+		}
+	}
+
+	return armmonitor.MetricsClientListResponse{
+		Response: armmonitor.Response{
+			Namespace:      to.Ptr("Microsoft.Test/type1"),
+			Resourceregion: to.Ptr("eastus"),
+			Value: []*armmonitor.Metric{
+				{
+					ID:         to.Ptr(resourceID + "/providers/Microsoft.Insights/metrics/metric1"),
+					Name:       &armmonitor.LocalizableString{LocalizedValue: to.Ptr("metric1")},
+					Unit:       to.Ptr(armmonitor.UnitCount),
+					ErrorCode:  to.Ptr("Success"),
+					Timeseries: []*armmonitor.TimeSeriesElement{series("0", 5.0), series("1", 2.5)},
+				},
+			},
+		},
+	}, nil
+}
+
+type mockThrottledMetricsClient struct {
+	calls atomic.Int32
+}
+
+func (mtmc *mockThrottledMetricsClient) List(
+	_ context.Context,
+	_ string,
+	_ *armmonitor.MetricsClientListOptions) (armmonitor.MetricsClientListResponse, error) {
+	mtmc.calls.Add(1)
+	return armmonitor.MetricsClientListResponse{}, &azcore.ResponseError{
+		StatusCode: http.StatusTooManyRequests,
+		RawResponse: &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{"120"}},
+		},
+	}
+}
+
 func TestInit_ResourceTargetsOnly(t *testing.T) {
 	file, err := os.ReadFile("testdata/toml/init_resource_targets_only.toml")
 	require.NoError(t, err)
@@ -809,6 +899,63 @@ func TestInit_ResourceGroupTargetNoResourceFound(t *testing.T) {
 	require.Error(t, am.Init())
 }
 
+func TestInit_ResourceGroupTargetWithTags(t *testing.T) {
+	file, err := os.ReadFile("testdata/toml/init_resource_group_target_with_tags.toml")
+	require.NoError(t, err)
+
+	var am *AzureMonitor
+	require.NoError(t, toml.Unmarshal(file, &am))
+
+	resources := &countingResourcesClient{}
+	am.Log = testutil.Logger{}
+	am.azureManager = &mockAzureClientsManager{resourcesClient: resources}
+
+	require.NoError(t, am.Init())
+	require.Equal(t, 1, resources.calls)
+
+	resourceIDs := make([]string, 0, len(am.receiver.Targets.ResourceTargets))
+	for _, target := range am.receiver.Targets.ResourceTargets {
+		resourceIDs = append(resourceIDs, target.ResourceID)
+	}
+	require.ElementsMatch(t, []string{
+		"/subscriptions/subscriptionID/resourceGroups/resourceGroup1/providers/Microsoft.Test/type1/resource1",
+		"/subscriptions/subscriptionID/resourceGroups/resourceGroup1/providers/Microsoft.Test/type2/resource2",
+		"/subscriptions/subscriptionID/resourceGroups/resourceGroup1/providers/Microsoft.Test/type1/resource1",
+	}, resourceIDs)
+
+	// New resources are discovered after the refresh interval elapsed
+	var acc testutil.Accumulator
+	require.NoError(t, acc.GatherError(am.Gather))
+	require.Equal(t, 2, resources.calls)
+	require.Len(t, am.receiver.Targets.ResourceTargets, 3)
+}
+
+func TestInit_ResourceGroupTargetWithTagsNoResourceFound(t *testing.T) {
+	file, err := os.ReadFile("testdata/toml/init_resource_group_target_with_tags_no_resource_found.toml")
+	require.NoError(t, err)
+
+	var am *AzureMonitor
+	require.NoError(t, toml.Unmarshal(file, &am))
+
+	am.Log = testutil.Logger{}
+	am.azureManager = &mockAzureClientsManager{}
+
+	require.ErrorContains(t, am.Init(), "could not find resources with resource type Microsoft.Test/type1")
+}
+
+func TestInit_ResourceGroupTargetWithDuplicateTaggedResourceType(t *testing.T) {
+	file, err := os.ReadFile("testdata/toml/init_resource_group_target_with_duplicate_tagged_resource_type.toml")
+	require.NoError(t, err)
+
+	var am *AzureMonitor
+	require.NoError(t, toml.Unmarshal(file, &am))
+
+	am.Log = testutil.Logger{}
+	am.azureManager = &mockAzureClientsManager{}
+
+	require.ErrorContains(t, am.Init(), "must be the only ones of resource type Microsoft.Test/type1")
+}
+
 func TestInit_SubscriptionTargetWithoutResourceType(t *testing.T) {
 	file, err := os.ReadFile("testdata/toml/init_subscription_target_without_resource_type.toml")
 	require.NoError(t, err)
@@ -1082,3 +1229,96 @@ func TestGather_Public_Success(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, am.receiver)
 }
+
+func TestGather_SplitByDimensions(t *testing.T) {
+	file, err := os.ReadFile("testdata/toml/gather_split_by_dimensions.toml")
+	require.NoError(t, err)
+
+	var am *AzureMonitor
+	require.NoError(t, toml.Unmarshal(file, &am))
+
+	metrics := &mockDimensionsMetricsClient{filters: make(map[string]string)}
+	am.Log = testutil.Logger{}
+	am.azureManager = &mockAzureClientsManager{metricsClient: metrics}
+	require.NoError(t, am.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, acc.GatherError(am.Gather))
+	require.Equal(t, map[string]string{
+		"/subscriptions/subscriptionID/resourceGroups/resourceGroup1/providers/Microsoft.Test/type1/resource1": "LUN eq '*'",
+	}, metrics.filters)
+	require.Len(t, acc.Metrics, 3)
+
+	tags := func(lun string) map[string]string {
+		return map[string]string{
+			receiver.MetricTagSubscriptionID: "subscriptionID",
+			receiver.MetricTagResourceGroup:  "resourceGroup1",
+			receiver.MetricTagNamespace:      "Microsoft.Test/type1",
+			receiver.MetricTagResourceName:   "resource1",
+			receiver.MetricTagResourceRegion: "eastus",
+			receiver.MetricTagUnit:           string(armmonitor.MetricUnitCount),
+			"LUN":                            lun,
+		}
+	}
+	acc.AssertContainsTaggedFields(t, "azure_monitor_microsoft_test_type1_metric1", map[string]interface{}{
+		receiver.MetricFieldTimeStamp: "2022-02-22T22:59:00Z",
+		receiver.MetricFieldTotal:     5.0,
+	}, tags("0"))
+	acc.AssertContainsTaggedFields(t, "azure_monitor_microsoft_test_type1_metric1", map[string]interface{}{
+		receiver.MetricFieldTimeStamp: "2022-02-22T22:59:00Z",
+		receiver.MetricFieldTotal:     2.5,
+	}, tags("1"))
+	require.True(t, acc.HasMeasurement("azure_monitor_microsoft_test_type2_metric1"))
+}
+
+func TestGather_Throttled(t *testing.T) {
+	file, err := os.ReadFile("testdata/toml/gather_split_by_dimensions.toml")
+	require.NoError(t, err)
+
+	var am *AzureMonitor
+	require.NoError(t, toml.Unmarshal(file, &am))
+	t.Cleanup(func() { throttling.reset(am.SubscriptionID) })
+
+	metrics := &mockThrottledMetricsClient{}
+	am.Log = testutil.Logger{}
+	am.azureManager = &mockAzureClientsManager{metricsClient: metrics}
+	require.NoError(t, am.Init())
+
+	// The throttled requests start the backoff for the subscription
+	var acc testutil.Accumulator
+	require.NoError(t, am.Gather(&acc))
+	require.Len(t, acc.Errors, 2)
+	for _, err := range acc.Errors {
+		require.ErrorContains(t, err, errThrottled.Error())
+	}
+	calls := metrics.calls.Load()
+	require.NotZero(t, calls)
+	require.WithinDuration(t, time.Now().Add(2*time.Minute), throttling.throttledUntil(am.SubscriptionID), 5*time.Second)
+
+	// Collection is skipped during the backoff
+	acc.Errors = nil
+	require.NoError(t, am.Gather(&acc))
+	require.Empty(t, acc.Errors)
+	require.Empty(t, acc.GetTelegrafMetrics())
+	require.Equal(t, calls, metrics.calls.Load())
+}
+
+func TestSubscriptionThrottlingBackoff(t *testing.T) {
+	s := &subscriptionThrottling{backoffs: make(map[string]*backoff)}
+
+	until := s.throttle("sub", 0)
+	require.WithinDuration(t, time.Now().Add(minThrottlingBackoff), until, 5*time.Second)
+
+	// Throttling during an active backoff does not extend it
+	require.Equal(t, until, s.throttle("sub", 0))
+
+	// Subsequent throttling periods double the backoff up to the maximum
+	for _, expected := range []time.Duration{2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 16 * time.Minute, 30 * time.Minute, 30 * time.Minute} {
+		s.backoffs["sub"].until = time.Time{}
+		require.WithinDuration(t, time.Now().Add(expected), s.throttle("sub", 0), 5*time.Second)
+	}
+
+	s.reset("sub")
+	require.True(t, s.throttledUntil("sub").IsZero())
+	require.True(t, s.throttledUntil("other").IsZero())
+}
@@ -0,0 +1,164 @@
+package azure_monitor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	receiver "github.com/logzio/azure-monitor-metrics-receiver"
+)
+
+var metricNameReplacer = strings.NewReplacer(".", "_", "/", "_", " ", "_", "(", "_", ")", "_")
+
+// dimensionsFilter creates the filter requesting one timeseries per value of
+// each of the given dimensions
+func dimensionsFilter(dimensions []string) string {
+	parts := make([]string, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		parts = append(parts, dimension+" eq '*'")
+	}
+	return strings.Join(parts, " and ")
+}
+
+// collectSplitMetrics collects the metrics of the resource target split by the
+// given dimensions. In contrast to the receiver, every timeseries is returned
+// as separate metric tagged with the dimension values. Metric names, fields
+// and the remaining tags are identical to the ones of the receiver.
+func (am *AzureMonitor) collectSplitMetrics(target *receiver.ResourceTarget, dimensions []string) ([]*receiver.Metric, []string, error) {
+	metricNames := strings.Join(target.Metrics, ",")
+	aggregations := strings.Join(target.Aggregations, ",")
+	filter := dimensionsFilter(dimensions)
+	response, err := am.azureClients.MetricsClient.List(am.azureClients.Ctx, target.ResourceID, &armmonitor.MetricsClientListOptions{
+		Metricnames: &metricNames,
+		Aggregation: &aggregations,
+		Filter:      &filter,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing metrics for the resource target %s: %w", target.ResourceID, err)
+	}
+	if response.Namespace == nil || response.Resourceregion == nil {
+		return nil, nil, fmt.Errorf("error collecting resource target %s metrics: namespace or region missing", target.ResourceID)
+	}
+
+	metrics := make([]*receiver.Metric, 0, len(response.Value))
+	notCollected := make([]string, 0)
+	for _, m := range response.Value {
+		if m == nil || m.ID == nil || m.Name == nil || m.Name.LocalizedValue == nil || m.Unit == nil {
+			return nil, nil, fmt.Errorf("error collecting resource target %s metrics: metric is bad formatted", target.ResourceID)
+		}
+		if m.ErrorCode != nil && *m.ErrorCode != "Success" {
+			msg := *m.ErrorCode
+			if m.ErrorMessage != nil {
+				msg = *m.ErrorMessage
+			}
+			return nil, nil, fmt.Errorf("error collecting resource target %s metrics: response error: %s", target.ResourceID, msg)
+		}
+
+		tags, err := metricTags(m, *response.Namespace, *response.Resourceregion)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error collecting resource target %s metrics: %w", target.ResourceID, err)
+		}
+		name := fmt.Sprintf("azure_monitor_%s_%s",
+			metricNameReplacer.Replace(strings.ToLower(*response.Namespace)),
+			metricNameReplacer.Replace(strings.ToLower(*m.Name.LocalizedValue)))
+
+		var collected bool
+		for _, series := range m.Timeseries {
+			if series == nil {
+				continue
+			}
+			fields := latestMetricFields(series.Data)
+			if fields == nil {
+				continue
+			}
+
+			seriesTags := make(map[string]string, len(tags)+len(series.Metadatavalues))
+			for k, v := range tags {
+				seriesTags[k] = v
+			}
+			for _, value := range series.Metadatavalues {
+				if value == nil || value.Name == nil || value.Name.Value == nil || value.Value == nil {
+					continue
+				}
+				seriesTags[dimensionTag(dimensions, *value.Name.Value)] = *value.Value
+			}
+
+			metrics = append(metrics, &receiver.Metric{Name: name, Fields: fields, Tags: seriesTags})
+			collected = true
+		}
+		if !collected {
+			notCollected = append(notCollected, *m.ID)
+		}
+	}
+
+	return metrics, notCollected, nil
+}
+
+// dimensionTag returns the dimension name as configured as the API does not
+// preserve the case of dimension names
+func dimensionTag(dimensions []string, name string) string {
+	for _, dimension := range dimensions {
+		if strings.EqualFold(dimension, name) {
+			return dimension
+		}
+	}
+	return name
+}
+
+// metricTags returns the tags of the metric with the ID of the form
+// "/subscriptions/<id>/resourceGroups/<group>/providers/<namespace>/<name>/providers/Microsoft.Insights/metrics/<metric>"
+func metricTags(m *armmonitor.Metric, namespace, region string) (map[string]string, error) {
+	parts := strings.Split(*m.ID, "/providers/")
+	if len(parts) < 2 {
+		return nil, errors.New("metric ID is bad formatted")
+	}
+	scope := strings.Split(parts[0], "/")
+	resource := strings.Split(parts[1], "/")
+	if len(scope) < 5 || len(resource) < 3 {
+		return nil, errors.New("metric ID is bad formatted")
+	}
+
+	return map[string]string{
+		receiver.MetricTagSubscriptionID: scope[2],
+		receiver.MetricTagResourceGroup:  scope[4],
+		receiver.MetricTagResourceName:   strings.Join(resource[2:], "/"),
+		receiver.MetricTagNamespace:      namespace,
+		receiver.MetricTagResourceRegion: region,
+		receiver.MetricTagUnit:           string(*m.Unit),
+	}, nil
+}
+
+// latestMetricFields returns the fields of the latest data point with at least
+// one aggregation value
+func latestMetricFields(values []*armmonitor.MetricValue) map[string]interface{} {
+	for i := len(values) - 1; i >= 0; i-- {
+		v := values[i]
+		if v == nil || v.TimeStamp == nil {
+			continue
+		}
+
+		fields := make(map[string]interface{}, 6)
+		if v.Total != nil {
+			fields[receiver.MetricFieldTotal] = *v.Total
+		}
+		if v.Average != nil {
+			fields[receiver.MetricFieldAverage] = *v.Average
+		}
+		if v.Count != nil {
+			fields[receiver.MetricFieldCount] = *v.Count
+		}
+		if v.Minimum != nil {
+			fields[receiver.MetricFieldMinimum] = *v.Minimum
+		}
+		if v.Maximum != nil {
+			fields[receiver.MetricFieldMaximum] = *v.Maximum
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		fields[receiver.MetricFieldTimeStamp] = v.TimeStamp.Format("2006-01-02T15:04:05Z07:00")
+		return fields
+	}
+	return nil
+}
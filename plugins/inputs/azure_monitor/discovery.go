@@ -0,0 +1,112 @@
+package azure_monitor
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	receiver "github.com/logzio/azure-monitor-metrics-receiver"
+
+	"github.com/influxdata/telegraf"
+)
+
+// resourcesFilter wraps the resources client used for discovering the
+// resources of resource group and subscription targets. It drops resources
+// not matching the configured tags and records the dimensions to split the
+// metrics of the remaining resources by.
+type resourcesFilter struct {
+	receiver.ResourcesClient
+	groups        []*resourceGroupTarget
+	subscriptions []*resource
+	log           telegraf.Logger
+
+	// dimensions of the discovered resources by resource ID
+	dimensions map[string][]string
+}
+
+func (f *resourcesFilter) List(ctx context.Context, options *armresources.ClientListOptions) ([]*armresources.ClientListResponse, error) {
+	responses, err := f.ResourcesClient.List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, response := range responses {
+		response.Value = f.filter(response.Value, f.subscriptions)
+	}
+	return responses, nil
+}
+
+func (f *resourcesFilter) ListByResourceGroup(
+	ctx context.Context,
+	resourceGroup string,
+	options *armresources.ClientListByResourceGroupOptions,
+) ([]*armresources.ClientListByResourceGroupResponse, error) {
+	responses, err := f.ResourcesClient.ListByResourceGroup(ctx, resourceGroup, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []*resource
+	for _, group := range f.groups {
+		if group.ResourceGroup == resourceGroup {
+			targets = append(targets, group.Resources...)
+		}
+	}
+	for _, response := range responses {
+		response.Value = f.filter(response.Value, targets)
+	}
+	return responses, nil
+}
+
+func (f *resourcesFilter) filter(resources []*armresources.GenericResourceExpanded, targets []*resource) []*armresources.GenericResourceExpanded {
+	filtered := make([]*armresources.GenericResourceExpanded, 0, len(resources))
+	for _, r := range resources {
+		// Leave the handling of incomplete resources to the receiver
+		if r == nil || r.ID == nil || r.Type == nil {
+			filtered = append(filtered, r)
+			continue
+		}
+
+		target := findResource(targets, *r.Type)
+		if target == nil {
+			filtered = append(filtered, r)
+			continue
+		}
+		if !matchTags(target.Tags, r.Tags) {
+			f.log.Debugf("Skipping resource %q not matching the tags", *r.ID)
+			continue
+		}
+		if len(target.SplitByDimensions) > 0 {
+			f.dimensions[*r.ID] = target.SplitByDimensions
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
+// findResource returns the resource definition for the given type preferring
+// definitions with tags or dimensions. Those are unique per type.
+func findResource(targets []*resource, resourceType string) *resource {
+	var found *resource
+	for _, target := range targets {
+		if target.ResourceType != resourceType {
+			continue
+		}
+		if len(target.Tags) > 0 || len(target.SplitByDimensions) > 0 {
+			return target
+		}
+		if found == nil {
+			found = target
+		}
+	}
+	return found
+}
+
+func matchTags(expected map[string]string, actual map[string]*string) bool {
+	for k, v := range expected {
+		value, found := actual[k]
+		if !found || value == nil || *value != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,131 @@
+package azure_monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	receiver "github.com/logzio/azure-monitor-metrics-receiver"
+
+	"github.com/influxdata/telegraf"
+)
+
+const (
+	minThrottlingBackoff = time.Minute
+	maxThrottlingBackoff = 30 * time.Minute
+)
+
+var errThrottled = errors.New("requests are throttled by the Azure Monitor API")
+
+// The read limits of the Azure Monitor API apply to the whole subscription so
+// the state is shared between all plugin instances querying the same one
+var throttling = &subscriptionThrottling{backoffs: make(map[string]*backoff)}
+
+type subscriptionThrottling struct {
+	backoffs map[string]*backoff
+	sync.Mutex
+}
+
+type backoff struct {
+	until    time.Time
+	attempts int
+}
+
+// throttledUntil returns the time until which requests for the subscription
+// must not be sent
+func (s *subscriptionThrottling) throttledUntil(subscriptionID string) time.Time {
+	s.Lock()
+	defer s.Unlock()
+
+	if b, found := s.backoffs[subscriptionID]; found {
+		return b.until
+	}
+	return time.Time{}
+}
+
+// throttle registers a throttled request for the subscription. Without a
+// Retry-After duration the backoff doubles for each throttling period up to
+// the maximum. Requests throttled while a backoff is active, e.g. the remaining
+// requests of the same gather cycle, do not extend the backoff.
+func (s *subscriptionThrottling) throttle(subscriptionID string, retryAfter time.Duration) time.Time {
+	s.Lock()
+	defer s.Unlock()
+
+	now := time.Now()
+	b, found := s.backoffs[subscriptionID]
+	if !found {
+		b = &backoff{}
+		s.backoffs[subscriptionID] = b
+	}
+	if now.Before(b.until) {
+		return b.until
+	}
+
+	b.attempts++
+	wait := retryAfter
+	if wait <= 0 {
+		wait = min(minThrottlingBackoff<<(b.attempts-1), maxThrottlingBackoff)
+	}
+	b.until = now.Add(wait)
+	return b.until
+}
+
+// reset clears the backoff of the subscription after a successful request
+func (s *subscriptionThrottling) reset(subscriptionID string) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.backoffs, subscriptionID)
+}
+
+// throttledMetricsClient wraps the metrics client to back off from
+// requesting metrics for the subscription when the API responds with 429
+type throttledMetricsClient struct {
+	receiver.MetricsClient
+	subscriptionID string
+	log            telegraf.Logger
+}
+
+func (c *throttledMetricsClient) List(
+	ctx context.Context,
+	resourceID string,
+	options *armmonitor.MetricsClientListOptions,
+) (armmonitor.MetricsClientListResponse, error) {
+	if time.Now().Before(throttling.throttledUntil(c.subscriptionID)) {
+		return armmonitor.MetricsClientListResponse{}, errThrottled
+	}
+
+	response, err := c.MetricsClient.List(ctx, resourceID, options)
+	if err == nil {
+		throttling.reset(c.subscriptionID)
+		return response, nil
+	}
+
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusTooManyRequests {
+		until := throttling.throttle(c.subscriptionID, retryAfter(responseErr.RawResponse))
+		c.log.Warnf("Requests for subscription %q are throttled, backing off until %s", c.subscriptionID, until.Format(time.RFC3339))
+		return response, fmt.Errorf("%w: %w", errThrottled, err)
+	}
+
+	return response, err
+}
+
+// retryAfter returns the duration of the Retry-After header given in seconds
+func retryAfter(response *http.Response) time.Duration {
+	if response == nil {
+		return 0
+	}
+
+	seconds, err := strconv.Atoi(response.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
 
@@ -23,8 +24,11 @@ type Bind struct {
 	GatherViews          bool            `toml:"gather_views"`
 	Timeout              config.Duration `toml:"timeout"`
 	CountersAsInt        bool            `toml:"report_counters_as_int"`
+	CollectZones         bool            `toml:"collect_zones"`
+	ZoneInclude          []string        `toml:"zone_include"`
 
-	client http.Client
+	client     http.Client
+	zoneFilter filter.Filter
 }
 
 func (*Bind) SampleConfig() string {
@@ -36,6 +40,12 @@ func (b *Bind) Init() error {
 		Timeout: time.Duration(b.Timeout),
 	}
 
+	f, err := filter.Compile(b.ZoneInclude)
+	if err != nil {
+		return fmt.Errorf("creating zone filter failed: %w", err)
+	}
+	b.zoneFilter = f
+
 	return nil
 }
 
@@ -84,6 +94,27 @@ func (b *Bind) gatherURL(addr *url.URL, acc telegraf.Accumulator) error {
 	}
 }
 
+// includeZone returns true if statistics for the zone should be collected
+func (b *Bind) includeZone(name string) bool {
+	return b.zoneFilter == nil || b.zoneFilter.Match(name)
+}
+
+// zoneTags returns the tags of the zone metric omitting unknown zone types
+func zoneTags(hostPort, host, port, view, zone, class, zoneType string) map[string]string {
+	tags := map[string]string{
+		"url":    hostPort,
+		"source": host,
+		"port":   port,
+		"view":   view,
+		"zone":   zone,
+		"class":  class,
+	}
+	if zoneType != "" {
+		tags["zone_type"] = zoneType
+	}
+	return tags
+}
+
 func init() {
 	inputs.Add("bind", func() telegraf.Input { return &Bind{CountersAsInt: true} })
 }
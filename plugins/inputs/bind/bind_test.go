@@ -1884,3 +1884,167 @@ func TestBindUnparsableURL(t *testing.T) {
 	err := acc.GatherError(b.Gather)
 	require.Contains(t, err.Error(), "unable to parse address")
 }
+
+func TestBindZones(t *testing.T) {
+	// Setup a mock server to deliver the stats
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer ts.Close()
+	addr := ts.Listener.Addr().String()
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	tags := func(zone, zoneType string) map[string]string {
+		t := map[string]string{
+			"url":    addr,
+			"source": host,
+			"port":   port,
+			"view":   "_default",
+			"zone":   zone,
+			"class":  "IN",
+		}
+		if zoneType != "" {
+			t["zone_type"] = zoneType
+		}
+		return t
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected []telegraf.Metric
+	}{
+		{
+			name: "json",
+			path: "/json/v1",
+			expected: []telegraf.Metric{
+				metric.New(
+					"bind_zone",
+					tags("example.com", "master"),
+					map[string]interface{}{
+						"Requestv4":   1024,
+						"Requestv6":   12,
+						"QrySuccess":  980,
+						"QryNXDOMAIN": 56,
+						"XfrReqDone":  3,
+						"XfrRej":      1,
+						"serial":      int64(2017072801),
+					},
+					time.Unix(0, 0),
+				),
+				metric.New(
+					"bind_zone",
+					tags("example.org", "slave"),
+					map[string]interface{}{
+						"Requestv4":  42,
+						"QrySuccess": 40,
+						"XfrSuccess": 2,
+						"serial":     int64(2017072102),
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "xml v2",
+			path: "/xml/v2",
+			expected: []telegraf.Metric{
+				metric.New(
+					"bind_zone",
+					tags("example.com", ""),
+					map[string]interface{}{
+						"Requestv4":   1024,
+						"Requestv6":   12,
+						"QrySuccess":  980,
+						"QryNXDOMAIN": 56,
+						"XfrReqDone":  3,
+						"XfrRej":      1,
+						"serial":      int64(2017072801),
+					},
+					time.Unix(0, 0),
+				),
+				metric.New(
+					"bind_zone",
+					tags("example.org", ""),
+					map[string]interface{}{
+						"Requestv4":  42,
+						"QrySuccess": 40,
+						"XfrReqDone": 1,
+						"serial":     int64(2017072102),
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "xml v3",
+			path: "/xml/v3",
+			expected: []telegraf.Metric{
+				metric.New(
+					"bind_zone",
+					tags("example.com", "master"),
+					map[string]interface{}{
+						"Requestv4":   int64(1024),
+						"Requestv6":   int64(12),
+						"QrySuccess":  int64(980),
+						"QryNXDOMAIN": int64(56),
+						"XfrReqDone":  int64(3),
+						"XfrRej":      int64(1),
+						"serial":      int64(2017072801),
+					},
+					time.Unix(0, 0),
+				),
+				metric.New(
+					"bind_zone",
+					tags("example.org", "slave"),
+					map[string]interface{}{
+						"Requestv4":  int64(42),
+						"QrySuccess": int64(40),
+						"XfrSuccess": int64(2),
+						"serial":     int64(2017072102),
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &Bind{
+				Urls:          []string{ts.URL + tt.path},
+				CountersAsInt: true,
+				CollectZones:  true,
+				ZoneInclude:   []string{"example.*", "*.arpa"},
+				Timeout:       config.Duration(4 * time.Second),
+			}
+			require.NoError(t, plugin.Init())
+
+			var acc testutil.Accumulator
+			require.NoError(t, acc.GatherError(plugin.Gather))
+
+			var actual []telegraf.Metric
+			for _, m := range acc.GetTelegrafMetrics() {
+				if m.Name() == "bind_zone" {
+					actual = append(actual, m)
+				}
+			}
+			testutil.RequireMetricsEqual(t, tt.expected, actual, testutil.IgnoreTime(), testutil.SortMetrics())
+		})
+	}
+}
+
+func TestBindZonesDisabled(t *testing.T) {
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer ts.Close()
+
+	plugin := &Bind{
+		Urls:          []string{ts.URL + "/xml/v3"},
+		CountersAsInt: true,
+		Timeout:       config.Duration(4 * time.Second),
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, acc.GatherError(plugin.Gather))
+	acc.AssertDoesNotContainMeasurement(t, "bind_zone")
+}
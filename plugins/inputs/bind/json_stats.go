@@ -42,6 +42,20 @@ type jsonView struct {
 	Resolver map[string]map[string]int
 }
 
+type jsonZoneStats struct {
+	Views map[string]struct {
+		Zones []jsonZone
+	}
+}
+
+type jsonZone struct {
+	Name   string
+	Class  string
+	Type   string
+	Serial interface{}
+	RCodes map[string]int
+}
+
 // addJSONCounter adds a counter array to a Telegraf Accumulator, with the specified tags.
 func addJSONCounter(acc telegraf.Accumulator, commonTags map[string]string, stats map[string]int) {
 	grouper := metric.NewSeriesGrouper()
@@ -148,6 +162,39 @@ func (b *Bind) addStatsJSON(stats jsonStats, acc telegraf.Accumulator, urlTag st
 	}
 }
 
+// addZonesJSON adds the serial and the counters of the zones with zone
+// statistics enabled. The counters reported as "rcodes" contain the name
+// server statistics of the zone including transfers.
+func (b *Bind) addZonesJSON(stats jsonZoneStats, acc telegraf.Accumulator, urlTag string) {
+	host, port, err := net.SplitHostPort(urlTag)
+	if err != nil {
+		acc.AddError(err)
+	}
+
+	for vName, view := range stats.Views {
+		for _, zone := range view.Zones {
+			if !b.includeZone(zone.Name) {
+				continue
+			}
+
+			fields := make(map[string]interface{}, len(zone.RCodes)+1)
+			for name, value := range zone.RCodes {
+				fields[name] = value
+			}
+			// Zones without serial, e.g. not yet loaded ones, report -1
+			if serial, ok := zone.Serial.(float64); ok && serial >= 0 {
+				fields["serial"] = int64(serial)
+			}
+			if len(fields) == 0 {
+				continue
+			}
+
+			tags := zoneTags(urlTag, host, port, vName, zone.Name, zone.Class, zone.Type)
+			acc.AddFields("bind_zone", fields, tags)
+		}
+	}
+}
+
 // readStatsJSON takes a base URL to probe, and requests the individual statistics blobs that we
 // are interested in. These individual blobs have a combined size which is significantly smaller
 // than if we requested everything at once (e.g. taskmgr and socketmgr can be omitted).
@@ -156,32 +203,41 @@ func (b *Bind) readStatsJSON(addr *url.URL, acc telegraf.Accumulator) error {
 
 	// Progressively build up full jsonStats struct by parsing the individual HTTP responses
 	for _, suffix := range [...]string{"/server", "/net", "/mem"} {
-		err := func() error {
-			scrapeURL := addr.String() + suffix
+		if err := b.readJSON(addr.String()+suffix, &stats); err != nil {
+			return err
+		}
+	}
 
-			resp, err := b.client.Get(scrapeURL)
-			if err != nil {
-				return err
-			}
+	b.addStatsJSON(stats, acc, addr.Host)
 
-			defer resp.Body.Close()
+	// Zones are decoded separately as their views would replace the ones
+	// of the server statistics
+	if b.CollectZones {
+		var zones jsonZoneStats
+		if err := b.readJSON(addr.String()+"/zones", &zones); err != nil {
+			return err
+		}
+		b.addZonesJSON(zones, acc, addr.Host)
+	}
 
-			if resp.StatusCode != http.StatusOK {
-				return fmt.Errorf("%s returned HTTP status: %s", scrapeURL, resp.Status)
-			}
+	return nil
+}
 
-			if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
-				return fmt.Errorf("unable to decode JSON blob: %w", err)
-			}
+func (b *Bind) readJSON(scrapeURL string, v interface{}) error {
+	resp, err := b.client.Get(scrapeURL)
+	if err != nil {
+		return err
+	}
 
-			return nil
-		}()
+	defer resp.Body.Close()
 
-		if err != nil {
-			return err
-		}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned HTTP status: %s", scrapeURL, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("unable to decode JSON blob: %w", err)
 	}
 
-	b.addStatsJSON(stats, acc, addr.Host)
 	return nil
 }
@@ -6,6 +6,8 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/influxdata/telegraf"
@@ -22,8 +24,8 @@ type v2Root struct {
 type v2Statistics struct {
 	Version string `xml:"version,attr"`
 	Views   []struct {
-		// Omitted branches: zones
 		Name     string      `xml:"name"`
+		Zones    []v2Zone    `xml:"zones>zone"`
 		RdTypes  []v2Counter `xml:"rdtype"`
 		ResStats []v2Counter `xml:"resstat"`
 		Caches   []struct {
@@ -57,6 +59,20 @@ type v2Statistics struct {
 	} `xml:"memory"`
 }
 
+// Zones report their name in the form "<name>/<class>[/<view>]" and the
+// counters as elements named after the counter
+type v2Zone struct {
+	Name     string `xml:"name"`
+	Class    string `xml:"rdataclass"`
+	Serial   string `xml:"serial"`
+	Counters struct {
+		Values []struct {
+			XMLName xml.Name
+			Value   int `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"counters"`
+}
+
 // BIND statistics v2 counter struct used throughout
 type v2Counter struct {
 	Name  string `xml:"name"`
@@ -167,5 +183,32 @@ func (b *Bind) readStatsXMLv2(addr *url.URL, acc telegraf.Accumulator) error {
 		}
 	}
 
+	// Per-zone stats for zones with zone statistics enabled
+	if b.CollectZones {
+		for _, v := range stats.Statistics.Views {
+			for _, zone := range v.Zones {
+				name, _, _ := strings.Cut(zone.Name, "/")
+				if !b.includeZone(name) {
+					continue
+				}
+
+				fields := make(map[string]interface{}, len(zone.Counters.Values)+1)
+				for _, c := range zone.Counters.Values {
+					fields[c.XMLName.Local] = c.Value
+				}
+				// Zones without serial, e.g. not yet loaded ones, report "-"
+				if serial, err := strconv.ParseUint(zone.Serial, 10, 32); err == nil {
+					fields["serial"] = int64(serial)
+				}
+				if len(fields) == 0 {
+					continue
+				}
+
+				tags := zoneTags(addr.Host, host, port, v.Name, name, zone.Class, "")
+				acc.AddFields("bind_zone", fields, tags)
+			}
+		}
+	}
+
 	return nil
 }
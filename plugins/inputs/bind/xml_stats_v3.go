@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -47,8 +48,8 @@ type v3Server struct {
 
 // XML path: //statistics/views/view
 type v3View struct {
-	// Omitted branches: zones
 	Name          string           `xml:"name,attr"`
+	Zones         []v3Zone         `xml:"zones>zone"`
 	CounterGroups []v3CounterGroup `xml:"counters"`
 	Caches        []struct {
 		Name   string `xml:"name,attr"`
@@ -59,6 +60,16 @@ type v3View struct {
 	} `xml:"cache"`
 }
 
+// XML path: //statistics/views/view/zones/zone
+type v3Zone struct {
+	// Omitted nodes: loaded, expires, refresh
+	Name          string           `xml:"name,attr"`
+	Class         string           `xml:"rdataclass,attr"`
+	Type          string           `xml:"type"`
+	Serial        string           `xml:"serial"`
+	CounterGroups []v3CounterGroup `xml:"counters"`
+}
+
 // Generic XML v3 doc fragment used in multiple places
 type v3CounterGroup struct {
 	Type     string `xml:"type,attr"`
@@ -84,15 +95,7 @@ func (b *Bind) addStatsXMLv3(stats v3Stats, acc telegraf.Accumulator, hostPort s
 			}
 
 			tags := map[string]string{"url": hostPort, "source": host, "port": port, "type": cg.Type}
-			var v interface{} = c.Value
-			if b.CountersAsInt {
-				if c.Value < math.MaxInt64 {
-					v = int64(c.Value)
-				} else {
-					v = int64(math.MaxInt64)
-				}
-			}
-			grouper.Add("bind_counter", tags, ts, c.Name, v)
+			grouper.Add("bind_counter", tags, ts, c.Name, b.counterValue(c.Value))
 		}
 	}
 
@@ -130,15 +133,7 @@ func (b *Bind) addStatsXMLv3(stats v3Stats, acc telegraf.Accumulator, hostPort s
 						"view":   v.Name,
 						"type":   cg.Type,
 					}
-					var v interface{} = c.Value
-					if b.CountersAsInt {
-						if c.Value < math.MaxInt64 {
-							v = int64(c.Value)
-						} else {
-							v = int64(math.MaxInt64)
-						}
-					}
-					grouper.Add("bind_counter", tags, ts, c.Name, v)
+					grouper.Add("bind_counter", tags, ts, c.Name, b.counterValue(c.Value))
 				}
 			}
 		}
@@ -158,34 +153,93 @@ func (b *Bind) readStatsXMLv3(addr *url.URL, acc telegraf.Accumulator) error {
 
 	// Progressively build up full v3Stats struct by parsing the individual HTTP responses
 	for _, suffix := range [...]string{"/server", "/net", "/mem"} {
-		err := func() error {
-			scrapeURL := addr.String() + suffix
+		if err := b.readXMLv3(addr.String()+suffix, &stats); err != nil {
+			return err
+		}
+	}
 
-			resp, err := b.client.Get(scrapeURL)
-			if err != nil {
-				return err
-			}
+	b.addStatsXMLv3(stats, acc, addr.Host)
 
-			defer resp.Body.Close()
+	// Zones are decoded separately as the server statistics contain the
+	// views including the zone names but without any statistics
+	if b.CollectZones {
+		var zones v3Stats
+		if err := b.readXMLv3(addr.String()+"/zones", &zones); err != nil {
+			return err
+		}
+		b.addZonesXMLv3(zones, acc, addr.Host)
+	}
 
-			if resp.StatusCode != http.StatusOK {
-				return fmt.Errorf("%s returned HTTP status: %s", scrapeURL, resp.Status)
-			}
+	return nil
+}
+
+func (b *Bind) readXMLv3(scrapeURL string, stats *v3Stats) error {
+	resp, err := b.client.Get(scrapeURL)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
 
-			if err := xml.NewDecoder(resp.Body).Decode(&stats); err != nil {
-				return fmt.Errorf("unable to decode XML document: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned HTTP status: %s", scrapeURL, resp.Status)
+	}
+
+	if err := xml.NewDecoder(resp.Body).Decode(stats); err != nil {
+		return fmt.Errorf("unable to decode XML document: %w", err)
+	}
+
+	return nil
+}
+
+// addZonesXMLv3 adds the serial and the counters of the zones with zone
+// statistics enabled. The counters of type "rcode" contain the name server
+// statistics of the zone including transfers.
+func (b *Bind) addZonesXMLv3(stats v3Stats, acc telegraf.Accumulator, hostPort string) {
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		acc.AddError(err)
+	}
+
+	for _, v := range stats.Views {
+		for _, zone := range v.Zones {
+			if !b.includeZone(zone.Name) {
+				continue
 			}
 
-			return nil
-		}()
+			fields := make(map[string]interface{})
+			for _, cg := range zone.CounterGroups {
+				if cg.Type != "rcode" {
+					continue
+				}
+				for _, c := range cg.Counters {
+					fields[c.Name] = b.counterValue(c.Value)
+				}
+			}
+			// Zones without serial, e.g. not yet loaded ones, report "-"
+			if serial, err := strconv.ParseUint(zone.Serial, 10, 32); err == nil {
+				fields["serial"] = int64(serial)
+			}
+			if len(fields) == 0 {
+				continue
+			}
 
-		if err != nil {
-			return err
+			tags := zoneTags(hostPort, host, port, v.Name, zone.Name, zone.Class, zone.Type)
+			acc.AddFields("bind_zone", fields, tags)
 		}
 	}
+}
 
-	b.addStatsXMLv3(stats, acc, addr.Host)
-	return nil
+// counterValue converts the counter to an integer if configured clipping
+// values exceeding the integer range
+func (b *Bind) counterValue(value uint64) interface{} {
+	if !b.CountersAsInt {
+		return value
+	}
+	if value < math.MaxInt64 {
+		return int64(value)
+	}
+	return int64(math.MaxInt64)
 }
 
 func (b *Bind) postProcessFields(fields map[string]interface{}) {
@@ -47,6 +47,7 @@ type (
 		GroupsInclude   []string
 		TopicsExclude   []string
 		TopicsInclude   []string
+		Detailed        bool
 
 		client         *http.Client
 		filterClusters filter.Filter
@@ -67,6 +68,7 @@ type (
 	apiStatusResponse struct {
 		Partitions     []apiStatusResponseLag `json:"partitions"`
 		Status         string                 `json:"status"`
+		Complete       float64                `json:"complete"`
 		PartitionCount int                    `json:"partition_count"`
 		Maxlag         *apiStatusResponseLag  `json:"maxlag"`
 		TotalLag       int64                  `json:"totallag"`
@@ -80,6 +82,7 @@ type (
 		Start      apiStatusResponseLagItem `json:"start"`
 		End        apiStatusResponseLagItem `json:"end"`
 		CurrentLag int64                    `json:"current_lag"`
+		Complete   float64                  `json:"complete"`
 		Owner      string                   `json:"owner"`
 	}
 
@@ -375,22 +378,30 @@ func (b *Burrow) genGroupStatusMetrics(r *apiResponse, cluster, group string, ac
 		lag = r.Status.Maxlag.CurrentLag
 	}
 
-	acc.AddFields(
-		"burrow_group",
-		map[string]interface{}{
-			"status":          r.Status.Status,
-			"status_code":     mapStatusToCode(r.Status.Status),
-			"partition_count": partitionCount,
-			"total_lag":       r.Status.TotalLag,
-			"lag":             lag,
-			"offset":          offset,
-			"timestamp":       timestamp,
-		},
-		map[string]string{
-			"cluster": cluster,
-			"group":   group,
-		},
-	)
+	fields := map[string]interface{}{
+		"status":          r.Status.Status,
+		"status_code":     mapStatusToCode(r.Status.Status),
+		"partition_count": partitionCount,
+		"total_lag":       r.Status.TotalLag,
+		"lag":             lag,
+		"offset":          offset,
+		"timestamp":       timestamp,
+	}
+	tags := map[string]string{
+		"cluster": cluster,
+		"group":   group,
+	}
+
+	// The status evaluation is part of the lag response
+	if b.Detailed {
+		fields["complete"] = r.Status.Complete
+		if r.Status.Maxlag != nil && b.filterTopics.Match(r.Status.Maxlag.Topic) {
+			tags["worst_topic"] = r.Status.Maxlag.Topic
+			tags["worst_partition"] = strconv.FormatInt(int64(r.Status.Maxlag.Partition), 10)
+		}
+	}
+
+	acc.AddFields("burrow_group", fields, tags)
 }
 
 func (b *Burrow) genGroupLagMetrics(r *apiResponse, cluster, group string, acc telegraf.Accumulator) {
@@ -398,15 +409,19 @@ func (b *Burrow) genGroupLagMetrics(r *apiResponse, cluster, group string, acc t
 		if !b.filterTopics.Match(partition.Topic) {
 			continue
 		}
+		fields := map[string]interface{}{
+			"status":      partition.Status,
+			"status_code": mapStatusToCode(partition.Status),
+			"lag":         partition.CurrentLag,
+			"offset":      partition.End.Offset,
+			"timestamp":   partition.End.Timestamp,
+		}
+		if b.Detailed {
+			fields["complete"] = partition.Complete
+		}
 		acc.AddFields(
 			"burrow_partition",
-			map[string]interface{}{
-				"status":      partition.Status,
-				"status_code": mapStatusToCode(partition.Status),
-				"lag":         partition.CurrentLag,
-				"offset":      partition.End.Offset,
-				"timestamp":   partition.End.Timestamp,
-			},
+			fields,
 			map[string]string{
 				"cluster":   cluster,
 				"group":     group,
@@ -180,6 +180,81 @@ func TestBurrowGroup(t *testing.T) {
 	}
 }
 
+// burrow_group and burrow_partition with status evaluation
+func TestBurrowDetailed(t *testing.T) {
+	s := getHTTPServer()
+	defer s.Close()
+
+	tests := []struct {
+		name          string
+		exclude       []string
+		expectedGroup map[string]string
+	}{
+		{
+			name: "all topics",
+			expectedGroup: map[string]string{
+				"cluster":         "clustername1",
+				"group":           "group1",
+				"worst_topic":     "topicA",
+				"worst_partition": "0",
+			},
+		},
+		{
+			name:    "excluded worst topic",
+			exclude: []string{"topicA"},
+			expectedGroup: map[string]string{
+				"cluster": "clustername1",
+				"group":   "group1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &Burrow{
+				Servers:       []string{s.URL},
+				TopicsExclude: tt.exclude,
+				Detailed:      true,
+			}
+			acc := &testutil.Accumulator{}
+			require.NoError(t, plugin.Gather(acc))
+			require.Empty(t, acc.Errors)
+
+			// Only the lag endpoint is queried for the groups
+			for _, m := range acc.GetTelegrafMetrics() {
+				require.NotContains(t, m.Name(), "_status")
+			}
+
+			acc.AssertContainsTaggedFields(t, "burrow_group", map[string]interface{}{
+				"status":          "OK",
+				"status_code":     1,
+				"partition_count": 3,
+				"total_lag":       int64(0),
+				"lag":             int64(0),
+				"offset":          int64(431323195 + 431322962 + 428636563),
+				"timestamp":       int64(1515609490008),
+				"complete":        1.0,
+			}, tt.expectedGroup)
+
+			var partitions int
+			for _, m := range acc.GetTelegrafMetrics() {
+				if m.Name() != "burrow_partition" {
+					continue
+				}
+				partitions++
+				complete, found := m.GetField("complete")
+				require.True(t, found)
+				require.InDelta(t, 1.0, complete, testutil.DefaultDelta)
+			}
+			if len(tt.exclude) == 0 {
+				require.Equal(t, 3, partitions)
+			} else {
+				require.Zero(t, partitions)
+			}
+		})
+	}
+}
+
 // collect from multiple servers
 func TestMultipleServers(t *testing.T) {
 	s1 := getHTTPServer()
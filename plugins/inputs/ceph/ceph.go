@@ -6,12 +6,16 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/common/tls"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
 
@@ -44,14 +48,34 @@ type Ceph struct {
 	GatherAdminSocketStats bool   `toml:"gather_admin_socket_stats"`
 	GatherClusterStats     bool   `toml:"gather_cluster_stats"`
 
+	MgrEndpoint string          `toml:"mgr_endpoint"`
+	MgrToken    config.Secret   `toml:"mgr_token"`
+	MgrTimeout  config.Duration `toml:"mgr_timeout"`
+	tls.ClientConfig
+
 	Log        telegraf.Logger `toml:"-"`
 	schemaMaps map[socket]perfSchemaMap
+	mgrClient  *http.Client
 }
 
 func (*Ceph) SampleConfig() string {
 	return sampleConfig
 }
 
+func (c *Ceph) Init() error {
+	if c.MgrEndpoint == "" {
+		return nil
+	}
+
+	client, err := c.createMgrClient()
+	if err != nil {
+		return fmt.Errorf("creating mgr client failed: %w", err)
+	}
+	c.mgrClient = client
+
+	return nil
+}
+
 func (c *Ceph) Gather(acc telegraf.Accumulator) error {
 	if c.GatherAdminSocketStats {
 		if err := c.gatherAdminSocketStats(acc); err != nil {
@@ -65,6 +89,12 @@ func (c *Ceph) Gather(acc telegraf.Accumulator) error {
 		}
 	}
 
+	if c.MgrEndpoint != "" {
+		if err := c.gatherMgrStats(acc); err != nil {
+			return fmt.Errorf("gathering mgr stats failed: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -760,6 +790,7 @@ func init() {
 			CephConfig:             "/etc/ceph/ceph.conf",
 			GatherAdminSocketStats: true,
 			GatherClusterStats:     false,
+			MgrTimeout:             config.Duration(5 * time.Second),
 		}
 	})
 }
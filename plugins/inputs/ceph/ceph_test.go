@@ -2,15 +2,19 @@ package ceph
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/testutil"
 )
 
@@ -121,6 +125,119 @@ func TestGather(t *testing.T) {
 	require.NoError(t, c.Gather(acc))
 }
 
+func TestGatherMgr(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if _, err := w.Write([]byte(mgrMetrics)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			t.Error(err)
+		}
+	}))
+	defer ts.Close()
+
+	c := &Ceph{
+		MgrEndpoint: ts.URL + "/metrics",
+		MgrToken:    config.NewSecret([]byte("secret")),
+		MgrTimeout:  config.Duration(5 * time.Second),
+		Log:         testutil.Logger{},
+	}
+	require.NoError(t, c.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, c.Gather(&acc))
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(
+			"ceph_rgw",
+			map[string]string{"instance": "rgw.ceph1"},
+			map[string]interface{}{
+				"req":                   float64(1534),
+				"failed_req":            float64(3),
+				"get":                   float64(1020),
+				"get_b":                 float64(104857600),
+				"get_initial_lat_sum":   float64(12.5),
+				"get_initial_lat_count": float64(1020),
+				"put":                   float64(511),
+				"put_b":                 float64(52428800),
+				"qlen":                  float64(0),
+				"qactive":               float64(2),
+			},
+			time.Unix(0, 0),
+		),
+		testutil.MustMetric(
+			"ceph_rgw",
+			map[string]string{"instance": "rgw.ceph2"},
+			map[string]interface{}{
+				"req":                   float64(12),
+				"failed_req":            float64(0),
+				"get":                   float64(12),
+				"get_b":                 float64(4096),
+				"get_initial_lat_sum":   float64(0.25),
+				"get_initial_lat_count": float64(12),
+				"put":                   float64(0),
+				"put_b":                 float64(0),
+				"qlen":                  float64(1),
+				"qactive":               float64(0),
+			},
+			time.Unix(0, 0),
+		),
+		testutil.MustMetric(
+			"ceph_pool_stats",
+			map[string]string{"name": "rbd"},
+			map[string]interface{}{
+				"read_ops":                   float64(48273),
+				"read_bytes":                 float64(1977614336),
+				"write_ops":                  float64(91265),
+				"write_bytes":                float64(3738173440),
+				"num_bytes_recovered":        float64(4194304),
+				"num_objects_recovered":      float64(1),
+				"recovering_bytes_per_sec":   float64(1048576),
+				"recovering_keys_per_sec":    float64(0),
+				"recovering_objects_per_sec": float64(4),
+			},
+			time.Unix(0, 0),
+		),
+		testutil.MustMetric(
+			"ceph_pool_stats",
+			map[string]string{"name": "default.rgw.buckets.data"},
+			map[string]interface{}{
+				"read_ops":                   float64(1020),
+				"read_bytes":                 float64(104857600),
+				"write_ops":                  float64(511),
+				"write_bytes":                float64(52428800),
+				"num_bytes_recovered":        float64(0),
+				"num_objects_recovered":      float64(0),
+				"recovering_bytes_per_sec":   float64(0),
+				"recovering_keys_per_sec":    float64(0),
+				"recovering_objects_per_sec": float64(0),
+			},
+			time.Unix(0, 0),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime(), testutil.SortMetrics())
+}
+
+func TestGatherMgrUnauthorized(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	c := &Ceph{
+		MgrEndpoint: ts.URL + "/metrics",
+		MgrTimeout:  config.Duration(5 * time.Second),
+		Log:         testutil.Logger{},
+	}
+	require.NoError(t, c.Init())
+
+	var acc testutil.Accumulator
+	require.ErrorContains(t, c.Gather(&acc), "401 Unauthorized")
+}
+
 func TestParseSchema(t *testing.T) {
 	schemaMap, err := parseSchema(osdRawSchema)
 
@@ -2504,3 +2621,96 @@ var cephOSDPoolStatsResults = []expectedResult{
 		},
 	},
 }
+
+var mgrMetrics = `# HELP ceph_pool_metadata POOL Metadata
+# TYPE ceph_pool_metadata untyped
+ceph_pool_metadata{pool_id="1",name="rbd",type="replicated",description="replica:3",compression_mode="none"} 1.0
+ceph_pool_metadata{pool_id="5",name="default.rgw.buckets.data",type="replicated",description="replica:3",compression_mode="none"} 1.0
+# HELP ceph_pool_rd DF pool rd
+# TYPE ceph_pool_rd counter
+ceph_pool_rd{pool_id="1"} 48273.0
+ceph_pool_rd{pool_id="5"} 1020.0
+# HELP ceph_pool_rd_bytes DF pool rd_bytes
+# TYPE ceph_pool_rd_bytes counter
+ceph_pool_rd_bytes{pool_id="1"} 1977614336.0
+ceph_pool_rd_bytes{pool_id="5"} 104857600.0
+# HELP ceph_pool_wr DF pool wr
+# TYPE ceph_pool_wr counter
+ceph_pool_wr{pool_id="1"} 91265.0
+ceph_pool_wr{pool_id="5"} 511.0
+# HELP ceph_pool_wr_bytes DF pool wr_bytes
+# TYPE ceph_pool_wr_bytes counter
+ceph_pool_wr_bytes{pool_id="1"} 3738173440.0
+ceph_pool_wr_bytes{pool_id="5"} 52428800.0
+# HELP ceph_pool_stored DF pool stored
+# TYPE ceph_pool_stored gauge
+ceph_pool_stored{pool_id="1"} 10737418240.0
+ceph_pool_stored{pool_id="5"} 157286400.0
+# HELP ceph_pool_recovering_objects_per_sec OSD pool stats: recovering_objects_per_sec
+# TYPE ceph_pool_recovering_objects_per_sec gauge
+ceph_pool_recovering_objects_per_sec{pool_id="1"} 4.0
+ceph_pool_recovering_objects_per_sec{pool_id="5"} 0.0
+# HELP ceph_pool_recovering_bytes_per_sec OSD pool stats: recovering_bytes_per_sec
+# TYPE ceph_pool_recovering_bytes_per_sec gauge
+ceph_pool_recovering_bytes_per_sec{pool_id="1"} 1048576.0
+ceph_pool_recovering_bytes_per_sec{pool_id="5"} 0.0
+# HELP ceph_pool_recovering_keys_per_sec OSD pool stats: recovering_keys_per_sec
+# TYPE ceph_pool_recovering_keys_per_sec gauge
+ceph_pool_recovering_keys_per_sec{pool_id="1"} 0.0
+ceph_pool_recovering_keys_per_sec{pool_id="5"} 0.0
+# HELP ceph_pool_num_objects_recovered OSD pool stats: num_objects_recovered
+# TYPE ceph_pool_num_objects_recovered gauge
+ceph_pool_num_objects_recovered{pool_id="1"} 1.0
+ceph_pool_num_objects_recovered{pool_id="5"} 0.0
+# HELP ceph_pool_num_bytes_recovered OSD pool stats: num_bytes_recovered
+# TYPE ceph_pool_num_bytes_recovered gauge
+ceph_pool_num_bytes_recovered{pool_id="1"} 4194304.0
+ceph_pool_num_bytes_recovered{pool_id="5"} 0.0
+# HELP ceph_rgw_metadata RGW Metadata
+# TYPE ceph_rgw_metadata untyped
+ceph_rgw_metadata{ceph_daemon="rgw.ceph1",hostname="ceph1",ceph_version="ceph version 18.2.4 reef (stable)",instance_id="4615"} 1.0
+ceph_rgw_metadata{ceph_daemon="rgw.ceph2",hostname="ceph2",ceph_version="ceph version 18.2.4 reef (stable)",instance_id="4622"} 1.0
+# HELP ceph_rgw_req Requests
+# TYPE ceph_rgw_req counter
+ceph_rgw_req{ceph_daemon="rgw.ceph1"} 1534.0
+ceph_rgw_req{ceph_daemon="rgw.ceph2"} 12.0
+# HELP ceph_rgw_failed_req Aborted requests
+# TYPE ceph_rgw_failed_req counter
+ceph_rgw_failed_req{ceph_daemon="rgw.ceph1"} 3.0
+ceph_rgw_failed_req{ceph_daemon="rgw.ceph2"} 0.0
+# HELP ceph_rgw_get Gets
+# TYPE ceph_rgw_get counter
+ceph_rgw_get{ceph_daemon="rgw.ceph1"} 1020.0
+ceph_rgw_get{ceph_daemon="rgw.ceph2"} 12.0
+# HELP ceph_rgw_get_b Size of gets
+# TYPE ceph_rgw_get_b counter
+ceph_rgw_get_b{ceph_daemon="rgw.ceph1"} 104857600.0
+ceph_rgw_get_b{ceph_daemon="rgw.ceph2"} 4096.0
+# HELP ceph_rgw_get_initial_lat_sum Get latency Total
+# TYPE ceph_rgw_get_initial_lat_sum counter
+ceph_rgw_get_initial_lat_sum{ceph_daemon="rgw.ceph1"} 12.5
+ceph_rgw_get_initial_lat_sum{ceph_daemon="rgw.ceph2"} 0.25
+# HELP ceph_rgw_get_initial_lat_count Get latency Count
+# TYPE ceph_rgw_get_initial_lat_count counter
+ceph_rgw_get_initial_lat_count{ceph_daemon="rgw.ceph1"} 1020.0
+ceph_rgw_get_initial_lat_count{ceph_daemon="rgw.ceph2"} 12.0
+# HELP ceph_rgw_put Puts
+# TYPE ceph_rgw_put counter
+ceph_rgw_put{ceph_daemon="rgw.ceph1"} 511.0
+ceph_rgw_put{ceph_daemon="rgw.ceph2"} 0.0
+# HELP ceph_rgw_put_b Size of puts
+# TYPE ceph_rgw_put_b counter
+ceph_rgw_put_b{ceph_daemon="rgw.ceph1"} 52428800.0
+ceph_rgw_put_b{ceph_daemon="rgw.ceph2"} 0.0
+# HELP ceph_rgw_qlen Queue length
+# TYPE ceph_rgw_qlen gauge
+ceph_rgw_qlen{ceph_daemon="rgw.ceph1"} 0.0
+ceph_rgw_qlen{ceph_daemon="rgw.ceph2"} 1.0
+# HELP ceph_rgw_qactive Active requests queue
+# TYPE ceph_rgw_qactive gauge
+ceph_rgw_qactive{ceph_daemon="rgw.ceph1"} 2.0
+ceph_rgw_qactive{ceph_daemon="rgw.ceph2"} 0.0
+# HELP ceph_osd_up OSD status up
+# TYPE ceph_osd_up untyped
+ceph_osd_up{ceph_daemon="osd.0"} 1.0
+`
@@ -0,0 +1,169 @@
+package ceph
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/influxdata/telegraf"
+)
+
+// Mapping of the pool metrics exported by the mgr prometheus module to the
+// fields of the ceph_pool_stats measurement
+var mgrPoolFields = map[string]string{
+	"ceph_pool_rd":                         "read_ops",
+	"ceph_pool_rd_bytes":                   "read_bytes",
+	"ceph_pool_wr":                         "write_ops",
+	"ceph_pool_wr_bytes":                   "write_bytes",
+	"ceph_pool_num_bytes_recovered":        "num_bytes_recovered",
+	"ceph_pool_num_objects_recovered":      "num_objects_recovered",
+	"ceph_pool_recovering_bytes_per_sec":   "recovering_bytes_per_sec",
+	"ceph_pool_recovering_keys_per_sec":    "recovering_keys_per_sec",
+	"ceph_pool_recovering_objects_per_sec": "recovering_objects_per_sec",
+}
+
+func (c *Ceph) createMgrClient() (*http.Client, error) {
+	tlsCfg, err := c.ClientConfig.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsCfg,
+		},
+		Timeout: time.Duration(c.MgrTimeout),
+	}, nil
+}
+
+// gatherMgrStats queries the metrics endpoint of the ceph-mgr prometheus
+// module and adds the RGW and pool metrics
+func (c *Ceph) gatherMgrStats(acc telegraf.Accumulator) error {
+	request, err := http.NewRequest("GET", c.MgrEndpoint, nil)
+	if err != nil {
+		return fmt.Errorf("creating request failed: %w", err)
+	}
+
+	if !c.MgrToken.Empty() {
+		token, err := c.MgrToken.Get()
+		if err != nil {
+			return fmt.Errorf("getting token failed: %w", err)
+		}
+		bearer := "Bearer " + strings.TrimSpace(token.String())
+		token.Destroy()
+		request.Header.Set("Authorization", bearer)
+	}
+	request.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+
+	response, err := c.mgrClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("querying %q failed: %w", c.MgrEndpoint, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("querying %q failed: %s", c.MgrEndpoint, response.Status)
+	}
+
+	var families []*dto.MetricFamily
+	decoder := expfmt.NewDecoder(response.Body, expfmt.ResponseFormat(response.Header))
+	for {
+		var family dto.MetricFamily
+		if err := decoder.Decode(&family); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("decoding metrics failed: %w", err)
+		}
+		families = append(families, &family)
+	}
+
+	decodeMgrMetrics(acc, families)
+
+	return nil
+}
+
+// decodeMgrMetrics adds the RGW metrics per gateway instance and the client IO
+// and recovery metrics per pool
+func decodeMgrMetrics(acc telegraf.Accumulator, families []*dto.MetricFamily) {
+	// The pools are identified by ID with the names only exported as labels
+	// of the pool metadata
+	poolNames := make(map[string]string)
+	for _, family := range families {
+		if family.GetName() != "ceph_pool_metadata" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			poolNames[label(m, "pool_id")] = label(m, "name")
+		}
+	}
+
+	rgwFields := make(map[string]map[string]interface{})
+	poolFields := make(map[string]map[string]interface{})
+	for _, family := range families {
+		name := family.GetName()
+		for _, m := range family.GetMetric() {
+			value, ok := sampleValue(family, m)
+			if !ok {
+				continue
+			}
+
+			switch {
+			case name == "ceph_rgw_metadata":
+				// Only carries the version information of the instance as labels
+			case strings.HasPrefix(name, "ceph_rgw_"):
+				instance := label(m, "ceph_daemon")
+				if instance == "" {
+					continue
+				}
+				if rgwFields[instance] == nil {
+					rgwFields[instance] = make(map[string]interface{})
+				}
+				rgwFields[instance][strings.TrimPrefix(name, "ceph_rgw_")] = value
+			case mgrPoolFields[name] != "":
+				poolName := poolNames[label(m, "pool_id")]
+				if poolName == "" {
+					poolName = label(m, "pool_id")
+				}
+				if poolFields[poolName] == nil {
+					poolFields[poolName] = make(map[string]interface{})
+				}
+				poolFields[poolName][mgrPoolFields[name]] = value
+			}
+		}
+	}
+
+	for instance, fields := range rgwFields {
+		acc.AddFields("ceph_rgw", fields, map[string]string{"instance": instance})
+	}
+	for pool, fields := range poolFields {
+		acc.AddFields("ceph_pool_stats", fields, map[string]string{"name": pool})
+	}
+}
+
+func sampleValue(family *dto.MetricFamily, m *dto.Metric) (float64, bool) {
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		return m.GetCounter().GetValue(), true
+	case dto.MetricType_GAUGE:
+		return m.GetGauge().GetValue(), true
+	case dto.MetricType_UNTYPED:
+		return m.GetUntyped().GetValue(), true
+	}
+	return 0, false
+}
+
+func label(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
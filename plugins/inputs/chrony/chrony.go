@@ -27,6 +27,9 @@ import (
 //go:embed sample.conf
 var sampleConfig string
 
+// defaultSocket is the chronyd socket used if no server is configured
+var defaultSocket = "/run/chrony/chronyd.sock"
+
 type Chrony struct {
 	Server      string          `toml:"server"`
 	Timeout     config.Duration `toml:"timeout"`
@@ -122,8 +125,8 @@ func (c *Chrony) Start(_ telegraf.Accumulator) error {
 		}
 	} else {
 		// If no server is given, reproduce chronyc's behavior
-		if conn, err := c.dialUnix("/run/chrony/chronyd.sock"); err == nil {
-			c.Server = "unix:///run/chrony/chronyd.sock"
+		if conn, err := c.dialUnix(defaultSocket); err == nil {
+			c.Server = "unixgram://" + defaultSocket
 			c.conn = conn
 		} else if conn, err := net.DialTimeout("udp", "127.0.0.1:323", time.Duration(c.Timeout)); err == nil {
 			c.Server = "udp://127.0.0.1:323"
@@ -432,7 +435,8 @@ func (c *Chrony) gatherSources(acc telegraf.Accumulator) error {
 		}
 
 		tags := map[string]string{
-			"peer": peer,
+			"peer":  peer,
+			"state": sourceData.State.String(),
 		}
 		if c.source != "" {
 			tags["source"] = c.source
@@ -443,7 +447,6 @@ func (c *Chrony) gatherSources(acc telegraf.Accumulator) error {
 			"ip":                       sourceData.IPAddr.String(),
 			"poll":                     sourceData.Poll,
 			"stratum":                  sourceData.Stratum,
-			"state":                    sourceData.State.String(),
 			"mode":                     sourceData.Mode.String(),
 			"flags":                    sourceData.Flags,
 			"reachability":             sourceData.Reachability,
@@ -6,6 +6,11 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"testing"
 	"time"
 
@@ -427,13 +432,13 @@ func TestGatherSources(t *testing.T) {
 			map[string]string{
 				"source": addr,
 				"peer":   "ntp1.my.org",
+				"state":  "sync",
 			},
 			map[string]interface{}{
 				"index":                    0,
 				"ip":                       "192.168.0.1",
 				"poll":                     64,
 				"stratum":                  uint64(16),
-				"state":                    "sync",
 				"mode":                     "peer",
 				"flags":                    uint64(0),
 				"reachability":             uint64(0),
@@ -448,13 +453,13 @@ func TestGatherSources(t *testing.T) {
 			map[string]string{
 				"source": addr,
 				"peer":   "ntp2.my.org",
+				"state":  "sync",
 			},
 			map[string]interface{}{
 				"index":                    1,
 				"ip":                       "192.168.0.2",
 				"poll":                     64,
 				"stratum":                  uint64(16),
-				"state":                    "sync",
 				"mode":                     "peer",
 				"flags":                    uint64(0),
 				"reachability":             uint64(0),
@@ -469,13 +474,13 @@ func TestGatherSources(t *testing.T) {
 			map[string]string{
 				"source": addr,
 				"peer":   "ntp3.my.org",
+				"state":  "outlier",
 			},
 			map[string]interface{}{
 				"index":                    2,
 				"ip":                       "192.168.0.3",
 				"poll":                     512,
 				"stratum":                  uint64(1),
-				"state":                    "outlier",
 				"mode":                     "peer",
 				"flags":                    uint64(0),
 				"reachability":             uint64(512),
@@ -647,6 +652,49 @@ func TestGatherSourceStats(t *testing.T) {
 	testutil.RequireMetricsEqual(t, expected, actual, options...)
 }
 
+func TestStartDefaultSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on windows")
+	}
+
+	// Setup a mock server listening on the default socket
+	sock := filepath.Join(t.TempDir(), "chronyd.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: sock, Net: "unixgram"})
+	require.NoError(t, err)
+	server := Server{
+		ActivityInfo: &fbchrony.Activity{Online: 34},
+		conn:         conn,
+	}
+	go server.serve(t)
+	defer server.Shutdown()
+
+	defaultSocketBackup := defaultSocket
+	defaultSocket = sock
+	defer func() { defaultSocket = defaultSocketBackup }()
+
+	group, err := user.LookupGroupId(strconv.Itoa(os.Getgid()))
+	require.NoError(t, err)
+
+	// Setup the plugin without a server to fall back to the default socket
+	plugin := &Chrony{
+		Metrics:     []string{"activity"},
+		SocketGroup: group.Name,
+		Log:         testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Start(&acc))
+	defer plugin.Stop()
+	require.Equal(t, "unixgram://"+sock, plugin.Server)
+	require.NoError(t, plugin.Gather(&acc))
+	require.Empty(t, acc.Errors)
+	require.Len(t, acc.GetTelegrafMetrics(), 1)
+
+	// The guessed server must be a valid setting
+	require.NoError(t, plugin.Init())
+}
+
 func TestIntegration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -4,6 +4,7 @@ package cloudwatch
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -49,6 +50,8 @@ type CloudWatch struct {
 	RecentlyActive        string              `toml:"recently_active"`
 	BatchSize             int                 `toml:"batch_size"`
 	IncludeLinkedAccounts bool                `toml:"include_linked_accounts"`
+	LinkedAccountFilter   string              `toml:"linked_account_filter"`
+	MetricMath            []*metricMath       `toml:"metric_math"`
 	MetricFormat          string              `toml:"metric_format"`
 	Log                   telegraf.Logger     `toml:"-"`
 
@@ -58,6 +61,7 @@ type CloudWatch struct {
 	queryDimensions map[string]*map[string]string
 	windowStart     time.Time
 	windowEnd       time.Time
+	mathBatches     [][]types.MetricDataQuery
 
 	common_aws.CredentialConfig
 }
@@ -107,11 +111,19 @@ func (c *CloudWatch) Init() error {
 		return fmt.Errorf("invalid metric_format: %s", c.MetricFormat)
 	}
 
+	if c.LinkedAccountFilter != "" && !c.IncludeLinkedAccounts {
+		return errors.New("linked_account_filter requires include_linked_accounts to be enabled")
+	}
+
 	err := c.initializeCloudWatch()
 	if err != nil {
 		return err
 	}
 
+	if err := c.initMetricMath(); err != nil {
+		return err
+	}
+
 	// Set config level filter (won't change throughout life of plugin).
 	c.statFilter, err = filter.NewIncludeExcludeFilter(c.StatisticInclude, c.StatisticExclude)
 	if err != nil {
@@ -130,7 +142,7 @@ func (c *CloudWatch) Gather(acc telegraf.Accumulator) error {
 
 	// Get all of the possible queries so we can send groups of 100.
 	queries := c.getDataQueries(filteredMetrics)
-	if len(queries) == 0 {
+	if len(queries) == 0 && len(c.mathBatches) == 0 {
 		return nil
 	}
 
@@ -169,8 +181,29 @@ func (c *CloudWatch) Gather(acc telegraf.Accumulator) error {
 		}
 	}
 
+	// The metric math expressions are batched on initialization to keep the
+	// expressions and their input metrics within the same request.
+	var mathResults []types.MetricDataResult
+	for _, batch := range c.mathBatches {
+		wg.Add(1)
+		<-lmtr.C
+		go func(inm []types.MetricDataQuery) {
+			defer wg.Done()
+			result, err := c.gatherMetrics(c.getDataInputs(inm))
+			if err != nil {
+				acc.AddError(err)
+				return
+			}
+
+			rLock.Lock()
+			mathResults = append(mathResults, result...)
+			rLock.Unlock()
+		}(batch)
+	}
+
 	wg.Wait()
 	c.aggregateMetrics(acc, results)
+	c.aggregateMetricMath(acc, mathResults)
 	return nil
 }
 
@@ -329,6 +362,9 @@ func (c *CloudWatch) fetchNamespaceMetrics() ([]types.Metric, []string) {
 			Namespace:             aws.String(namespace),
 			IncludeLinkedAccounts: &c.IncludeLinkedAccounts,
 		}
+		if c.LinkedAccountFilter != "" {
+			params.OwningAccount = aws.String(c.LinkedAccountFilter)
+		}
 		if c.RecentlyActive == "PT3H" {
 			params.RecentlyActive = types.RecentlyActivePt3h
 		}
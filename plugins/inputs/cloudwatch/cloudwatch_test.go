@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/stretchr/testify/require"
 
+	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/filter"
 	common_aws "github.com/influxdata/telegraf/plugins/common/aws"
@@ -568,3 +570,193 @@ func TestCombineNamespaces(t *testing.T) {
 	require.NoError(t, c.Init())
 	require.Equal(t, []string{"AWS/EC2", "AWS/Billing", "AWS/ELB"}, c.Namespaces)
 }
+
+type mockMetricMathCloudWatchClient struct {
+	sync.Mutex
+	listRequests []*cloudwatch.ListMetricsInput
+	dataRequests []*cloudwatch.GetMetricDataInput
+}
+
+func (m *mockMetricMathCloudWatchClient) ListMetrics(
+	_ context.Context,
+	params *cloudwatch.ListMetricsInput,
+	_ ...func(*cloudwatch.Options),
+) (*cloudwatch.ListMetricsOutput, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.listRequests = append(m.listRequests, params)
+	return &cloudwatch.ListMetricsOutput{}, nil
+}
+
+func (m *mockMetricMathCloudWatchClient) GetMetricData(
+	_ context.Context,
+	params *cloudwatch.GetMetricDataInput,
+	_ ...func(*cloudwatch.Options),
+) (*cloudwatch.GetMetricDataOutput, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.dataRequests = append(m.dataRequests, params)
+
+	// Only the expressions are returned
+	var results []types.MetricDataResult
+	for _, q := range params.MetricDataQueries {
+		if q.Expression == nil {
+			continue
+		}
+		results = append(results, types.MetricDataResult{
+			Id:         q.Id,
+			Label:      q.Label,
+			StatusCode: types.StatusCodeComplete,
+			Timestamps: []time.Time{*params.EndTime},
+			Values:     []float64{0.25},
+		})
+	}
+	return &cloudwatch.GetMetricDataOutput{MetricDataResults: results}, nil
+}
+
+func TestMetricMath(t *testing.T) {
+	lambdaMetric := func(id, name string) *metricMathInput {
+		return &metricMathInput{
+			ID:         id,
+			Namespace:  "AWS/Lambda",
+			Name:       name,
+			Statistic:  "Sum",
+			Dimensions: map[string]string{"FunctionName": "my-function"},
+		}
+	}
+
+	c := &CloudWatch{
+		CredentialConfig: common_aws.CredentialConfig{
+			Region: "us-east-1",
+		},
+		Delay:     config.Duration(time.Minute),
+		Period:    config.Duration(time.Minute),
+		RateLimit: 200,
+		BatchSize: 4,
+		MetricMath: []*metricMath{
+			{
+				ID:         "error_rate",
+				Expression: "errors / invocations",
+				Period:     config.Duration(5 * time.Minute),
+				Metrics: []*metricMathInput{
+					lambdaMetric("errors", "Errors"),
+					lambdaMetric("invocations", "Invocations"),
+				},
+			},
+			{
+				ID:         "throttle_rate",
+				Expression: "throttles / invocations_total",
+				Label:      "Throttle Rate",
+				Metrics: []*metricMathInput{
+					lambdaMetric("throttles", "Throttles"),
+					lambdaMetric("invocations_total", "Invocations"),
+				},
+			},
+		},
+		Log: testutil.Logger{},
+	}
+	require.NoError(t, c.Init())
+	client := &mockMetricMathCloudWatchClient{}
+	c.client = client
+
+	var acc testutil.Accumulator
+	require.NoError(t, acc.GatherError(c.Gather))
+
+	// Expressions must not be split from their input metrics
+	require.Len(t, client.dataRequests, 2)
+	for _, request := range client.dataRequests {
+		require.Len(t, request.MetricDataQueries, 3)
+		require.NotNil(t, request.MetricDataQueries[0].Expression)
+		require.True(t, *request.MetricDataQueries[0].ReturnData)
+		for _, q := range request.MetricDataQueries[1:] {
+			require.Equal(t, "AWS/Lambda", *q.MetricStat.Metric.Namespace)
+			require.Equal(t, *request.MetricDataQueries[0].Period, *q.MetricStat.Period)
+			require.False(t, *q.ReturnData)
+		}
+	}
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(
+			"cloudwatch_metric_math",
+			map[string]string{"region": "us-east-1"},
+			map[string]interface{}{
+				"error_rate":    0.25,
+				"throttle_rate": 0.25,
+			},
+			time.Unix(0, 0),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestMetricMathInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		math     []*metricMath
+		expected string
+	}{
+		{
+			name:     "missing expression",
+			math:     []*metricMath{{ID: "rate"}},
+			expected: "expression required",
+		},
+		{
+			name:     "invalid id",
+			math:     []*metricMath{{ID: "Rate", Expression: "1"}},
+			expected: "must start with a lowercase letter",
+		},
+		{
+			name: "duplicate id",
+			math: []*metricMath{
+				{ID: "rate", Expression: "1"},
+				{ID: "rate", Expression: "2"},
+			},
+			expected: "duplicate metric math id",
+		},
+		{
+			name: "exceeding batch size",
+			math: []*metricMath{
+				{
+					ID:         "rate",
+					Expression: "a / b",
+					Metrics: []*metricMathInput{
+						{ID: "a", Namespace: "AWS/Lambda", Name: "Errors"},
+						{ID: "b", Namespace: "AWS/Lambda", Name: "Invocations"},
+					},
+				},
+			},
+			expected: "exceeding the batch size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &CloudWatch{
+				Period:     config.Duration(time.Minute),
+				BatchSize:  2,
+				MetricMath: tt.math,
+				Log:        testutil.Logger{},
+			}
+			require.ErrorContains(t, c.Init(), tt.expected)
+		})
+	}
+}
+
+func TestLinkedAccountFilter(t *testing.T) {
+	c := &CloudWatch{
+		Namespaces:          []string{"AWS/ELB"},
+		LinkedAccountFilter: "123456789012",
+		Log:                 testutil.Logger{},
+	}
+	require.ErrorContains(t, c.Init(), "requires include_linked_accounts")
+
+	c.IncludeLinkedAccounts = true
+	require.NoError(t, c.Init())
+	client := &mockMetricMathCloudWatchClient{}
+	c.client = client
+
+	c.fetchNamespaceMetrics()
+	require.Len(t, client.listRequests, 1)
+	require.Equal(t, "123456789012", *client.listRequests[0].OwningAccount)
+	require.True(t, *client.listRequests[0].IncludeLinkedAccounts)
+}
@@ -0,0 +1,151 @@
+package cloudwatch
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/metric"
+)
+
+const measurementMetricMath = "cloudwatch_metric_math"
+
+// Query IDs must start with a lowercase letter, see
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/APIReference/API_MetricDataQuery.html
+var queryIDPattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+
+// metricMath defines a metric math expression evaluated by CloudWatch.
+type metricMath struct {
+	ID         string             `toml:"id"`
+	Expression string             `toml:"expression"`
+	Label      string             `toml:"label"`
+	Period     config.Duration    `toml:"period"`
+	Metrics    []*metricMathInput `toml:"metrics"`
+}
+
+// metricMathInput defines a metric referenced in a metric math expression.
+type metricMathInput struct {
+	ID         string            `toml:"id"`
+	Namespace  string            `toml:"namespace"`
+	Name       string            `toml:"name"`
+	Statistic  string            `toml:"statistic"`
+	Dimensions map[string]string `toml:"dimensions"`
+}
+
+// initMetricMath validates the metric math expressions and creates the
+// batches of queries. The queries of an expression and its input metrics must
+// be part of the same request so batches are never split within an expression.
+func (c *CloudWatch) initMetricMath() error {
+	c.mathBatches = nil
+
+	seen := make(map[string]bool)
+	checkID := func(id string) error {
+		if !queryIDPattern.MatchString(id) {
+			return fmt.Errorf("invalid metric math id %q, must start with a lowercase letter followed by letters, digits or underscores", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("duplicate metric math id %q", id)
+		}
+		seen[id] = true
+		return nil
+	}
+
+	var batch []types.MetricDataQuery
+	for _, m := range c.MetricMath {
+		if m.Expression == "" {
+			return errors.New("metric math expression required")
+		}
+		if err := checkID(m.ID); err != nil {
+			return err
+		}
+		if m.Label == "" {
+			m.Label = m.ID
+		}
+		if m.Period == 0 {
+			m.Period = c.Period
+		}
+		period := aws.Int32(int32(time.Duration(m.Period).Seconds()))
+
+		queries := make([]types.MetricDataQuery, 0, len(m.Metrics)+1)
+		queries = append(queries, types.MetricDataQuery{
+			Id:         aws.String(m.ID),
+			Expression: aws.String(m.Expression),
+			Label:      aws.String(m.Label),
+			Period:     period,
+			ReturnData: aws.Bool(true),
+		})
+		for _, input := range m.Metrics {
+			if err := checkID(input.ID); err != nil {
+				return err
+			}
+			if input.Namespace == "" || input.Name == "" {
+				return fmt.Errorf("namespace and name required for metric %q of metric math expression %q", input.ID, m.ID)
+			}
+			if input.Statistic == "" {
+				input.Statistic = "Average"
+			}
+
+			dimensions := make([]types.Dimension, 0, len(input.Dimensions))
+			for _, name := range slices.Sorted(maps.Keys(input.Dimensions)) {
+				dimensions = append(dimensions, types.Dimension{
+					Name:  aws.String(name),
+					Value: aws.String(input.Dimensions[name]),
+				})
+			}
+			queries = append(queries, types.MetricDataQuery{
+				Id: aws.String(input.ID),
+				MetricStat: &types.MetricStat{
+					Metric: &types.Metric{
+						Namespace:  aws.String(input.Namespace),
+						MetricName: aws.String(input.Name),
+						Dimensions: dimensions,
+					},
+					Period: period,
+					Stat:   aws.String(input.Statistic),
+				},
+				ReturnData: aws.Bool(false),
+			})
+		}
+
+		if len(queries) > c.BatchSize {
+			return fmt.Errorf("metric math expression %q requires %d queries exceeding the batch size of %d", m.ID, len(queries), c.BatchSize)
+		}
+		if len(batch)+len(queries) > c.BatchSize {
+			c.mathBatches = append(c.mathBatches, batch)
+			batch = nil
+		}
+		batch = append(batch, queries...)
+	}
+	if len(batch) > 0 {
+		c.mathBatches = append(c.mathBatches, batch)
+	}
+
+	return nil
+}
+
+func (c *CloudWatch) aggregateMetricMath(acc telegraf.Accumulator, results []types.MetricDataResult) {
+	grouper := metric.NewSeriesGrouper()
+	for _, result := range results {
+		tags := map[string]string{"region": c.Region}
+		field := snakeCase(*result.Label)
+		if c.MetricFormat == "dense" {
+			tags["metric_name"] = field
+			field = "value"
+		}
+		for i := range result.Values {
+			grouper.Add(measurementMetricMath, tags, result.Timestamps[i], field, result.Values[i])
+		}
+	}
+
+	for _, m := range grouper.Metrics() {
+		acc.AddMetric(m)
+	}
+}
@@ -4,6 +4,7 @@
 package conntrack
 
 import (
+	"bufio"
 	_ "embed"
 	"errors"
 	"fmt"
@@ -33,6 +34,8 @@ var (
 		"nf_conntrack_count",
 		"nf_conntrack_max",
 	}
+
+	conntrackTable = "/proc/net/nf_conntrack"
 )
 
 const (
@@ -40,10 +43,12 @@ const (
 )
 
 type Conntrack struct {
-	Collect []string `toml:"collect"`
-	Dirs    []string `toml:"dirs"`
-	Files   []string `toml:"files"`
-	ps      system.PS
+	Collect        []string        `toml:"collect"`
+	Dirs           []string        `toml:"dirs"`
+	Files          []string        `toml:"files"`
+	MaxEntriesScan int             `toml:"max_entries_scan"`
+	Log            telegraf.Logger `toml:"-"`
+	ps             system.PS
 }
 
 func (*Conntrack) SampleConfig() string {
@@ -53,10 +58,14 @@ func (*Conntrack) SampleConfig() string {
 func (c *Conntrack) Init() error {
 	c.setDefaults()
 
-	if err := choice.CheckSlice(c.Collect, []string{"all", "percpu"}); err != nil {
+	if err := choice.CheckSlice(c.Collect, []string{"all", "percpu", "per_proto_state"}); err != nil {
 		return fmt.Errorf("config option 'collect': %w", err)
 	}
 
+	if c.MaxEntriesScan < 0 {
+		return fmt.Errorf("config option 'max_entries_scan' must not be negative, got %d", c.MaxEntriesScan)
+	}
+
 	return nil
 }
 
@@ -95,6 +104,13 @@ func (c *Conntrack) Gather(acc telegraf.Accumulator) error {
 	}
 
 	for _, metric := range c.Collect {
+		if metric == "per_proto_state" {
+			if err := c.gatherPerProtoState(acc); err != nil {
+				acc.AddError(fmt.Errorf("failed to collect connections per protocol and state: %w", err))
+			}
+			continue
+		}
+
 		perCPU := metric == "percpu"
 		stats, err := c.ps.NetConntrack(perCPU)
 		if err != nil {
@@ -145,6 +161,58 @@ func (c *Conntrack) Gather(acc telegraf.Accumulator) error {
 	return nil
 }
 
+// gatherPerProtoState counts the entries of the conntrack table grouped by
+// protocol and state. The table is streamed from the proc file to be able to
+// stop after scanning the configured maximum number of entries.
+func (c *Conntrack) gatherPerProtoState(acc telegraf.Accumulator) error {
+	file, err := os.Open(conntrackTable)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	type group struct {
+		protocol string
+		state    string
+	}
+	counts := make(map[group]int64)
+
+	var scanned int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if c.MaxEntriesScan > 0 && scanned >= c.MaxEntriesScan {
+			c.Log.Warnf("Stopped counting connections after scanning %d entries, counts are incomplete", scanned)
+			break
+		}
+		scanned++
+
+		// Entries have the format
+		//   <l3 name> <l3 number> <l4 name> <l4 number> <timeout> [<state>] <key=value>...
+		// with the state only present for stateful protocols like TCP.
+		parts := strings.Fields(scanner.Text())
+		if len(parts) < 5 {
+			continue
+		}
+		g := group{protocol: parts[2]}
+		if len(parts) > 5 && !strings.Contains(parts[5], "=") {
+			g.state = parts[5]
+		}
+		counts[g]++
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	for g, count := range counts {
+		tags := map[string]string{"protocol": g.protocol}
+		if g.state != "" {
+			tags["state"] = g.state
+		}
+		acc.AddGauge(inputName, map[string]interface{}{"connections": count}, tags)
+	}
+	return nil
+}
+
 func (c *Conntrack) setDefaults() {
 	if len(c.Dirs) == 0 {
 		c.Dirs = dfltDirs
@@ -158,7 +226,8 @@ func (c *Conntrack) setDefaults() {
 func init() {
 	inputs.Add(inputName, func() telegraf.Input {
 		return &Conntrack{
-			ps: system.NewSystemPS(),
+			MaxEntriesScan: 100000,
+			ps:             system.NewSystemPS(),
 		}
 	})
 }
@@ -5,13 +5,18 @@ package conntrack
 import (
 	"os"
 	"path"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/shirou/gopsutil/v4/net"
 	"github.com/stretchr/testify/require"
 
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/plugins/inputs/system"
 	"github.com/influxdata/telegraf/testutil"
 )
@@ -344,3 +349,72 @@ func TestCollectPsSystemInit(t *testing.T) {
 	// make sure Conntrack.ps gets initialized without mocking
 	require.NoError(t, err)
 }
+
+func TestCollectPerProtoState(t *testing.T) {
+	defer func(table string) { conntrackTable = table }(conntrackTable)
+	conntrackTable = filepath.Join("testdata", "nf_conntrack")
+
+	tests := []struct {
+		name       string
+		maxEntries int
+		expected   []telegraf.Metric
+	}{
+		{
+			name: "all entries",
+			expected: []telegraf.Metric{
+				metric.New("conntrack", map[string]string{"protocol": "tcp", "state": "ESTABLISHED"},
+					map[string]interface{}{"connections": int64(2)}, time.Unix(0, 0), telegraf.Gauge),
+				metric.New("conntrack", map[string]string{"protocol": "tcp", "state": "TIME_WAIT"},
+					map[string]interface{}{"connections": int64(1)}, time.Unix(0, 0), telegraf.Gauge),
+				metric.New("conntrack", map[string]string{"protocol": "tcp", "state": "SYN_SENT"},
+					map[string]interface{}{"connections": int64(1)}, time.Unix(0, 0), telegraf.Gauge),
+				metric.New("conntrack", map[string]string{"protocol": "udp"},
+					map[string]interface{}{"connections": int64(2)}, time.Unix(0, 0), telegraf.Gauge),
+				metric.New("conntrack", map[string]string{"protocol": "icmp"},
+					map[string]interface{}{"connections": int64(1)}, time.Unix(0, 0), telegraf.Gauge),
+			},
+		},
+		{
+			name:       "limited scan",
+			maxEntries: 3,
+			expected: []telegraf.Metric{
+				metric.New("conntrack", map[string]string{"protocol": "tcp", "state": "ESTABLISHED"},
+					map[string]interface{}{"connections": int64(2)}, time.Unix(0, 0), telegraf.Gauge),
+				metric.New("conntrack", map[string]string{"protocol": "tcp", "state": "TIME_WAIT"},
+					map[string]interface{}{"connections": int64(1)}, time.Unix(0, 0), telegraf.Gauge),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "nf_conntrack_count"), []byte("7"), 0640))
+
+			c := &Conntrack{
+				Collect:        []string{"per_proto_state"},
+				Dirs:           []string{dir},
+				Files:          []string{"nf_conntrack_count"},
+				MaxEntriesScan: tt.maxEntries,
+				Log:            testutil.Logger{},
+			}
+			require.NoError(t, c.Init())
+
+			var acc testutil.Accumulator
+			require.NoError(t, c.Gather(&acc))
+			require.Empty(t, acc.Errors)
+
+			expected := append(slices.Clone(tt.expected), metric.New("conntrack", map[string]string{},
+				map[string]interface{}{"ip_conntrack_count": float64(7)}, time.Unix(0, 0)))
+			testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime(), testutil.SortMetrics())
+		})
+	}
+}
+
+func TestInitNegativeMaxEntriesScan(t *testing.T) {
+	c := &Conntrack{
+		MaxEntriesScan: -1,
+		Log:            testutil.Logger{},
+	}
+	require.ErrorContains(t, c.Init(), "must not be negative")
+}
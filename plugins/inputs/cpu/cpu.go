@@ -18,17 +18,19 @@ import (
 var sampleConfig string
 
 type CPUStats struct {
-	ps         system.PS
-	lastStats  map[string]cpu.TimesStat
-	cpuInfo    map[string]cpu.InfoStat
-	coreID     bool
-	physicalID bool
+	ps          system.PS
+	lastStats   map[string]cpu.TimesStat
+	cpuInfo     map[string]cpu.InfoStat
+	coreID      bool
+	physicalID  bool
+	frequencies []coreFrequency
 
 	PerCPU         bool `toml:"percpu"`
 	TotalCPU       bool `toml:"totalcpu"`
 	CollectCPUTime bool `toml:"collect_cpu_time"`
 	ReportActive   bool `toml:"report_active"`
 	CoreTags       bool `toml:"core_tags"`
+	CoreFrequency  bool `toml:"core_frequency"`
 
 	Log telegraf.Logger `toml:"-"`
 }
@@ -53,6 +55,17 @@ func (c *CPUStats) Init() error {
 		}
 	}
 
+	if c.CoreFrequency {
+		frequencies, err := discoverFrequencies()
+		if err != nil {
+			return fmt.Errorf("discovering core frequencies failed: %w", err)
+		}
+		if len(frequencies) == 0 {
+			c.Log.Debug("No core frequency information available")
+		}
+		c.frequencies = frequencies
+	}
+
 	return nil
 }
 
@@ -138,6 +151,8 @@ func (c *CPUStats) Gather(acc telegraf.Accumulator) error {
 		acc.AddGauge("cpu", fieldsG, tags, now)
 	}
 
+	c.gatherFrequencies(acc, now)
+
 	c.lastStats = make(map[string]cpu.TimesStat)
 	for _, cts := range times {
 		c.lastStats[cts.CPU] = cts
@@ -2,11 +2,15 @@ package cpu
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/shirou/gopsutil/v4/cpu"
 	"github.com/stretchr/testify/require"
 
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/plugins/inputs/system"
 	"github.com/influxdata/telegraf/testutil"
 )
@@ -250,3 +254,47 @@ func TestCPUTimesDecrease(t *testing.T) {
 	assertContainsTaggedFloat(t, &acc, "usage_idle", 80, 0.0005)
 	assertContainsTaggedFloat(t, &acc, "usage_iowait", 2, 0.0005)
 }
+
+func TestCPUFrequency(t *testing.T) {
+	t.Setenv("HOST_SYS", filepath.Join("testdata", "sys"))
+
+	var mps system.MockPS
+	defer mps.AssertExpectations(t)
+	mps.On("CPUTimes").Return([]cpu.TimesStat{}, nil)
+
+	cs := &CPUStats{
+		ps:            &mps,
+		CoreFrequency: true,
+		Log:           testutil.Logger{},
+	}
+	require.NoError(t, cs.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, cs.Gather(&acc))
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"cpu_frequency",
+			map[string]string{"cpu": "cpu0"},
+			map[string]interface{}{
+				"current_khz":            uint64(2400000),
+				"min_khz":                uint64(800000),
+				"max_khz":                uint64(3600000),
+				"core_throttle_count":    uint64(12),
+				"package_throttle_count": uint64(3),
+			},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"cpu_frequency",
+			map[string]string{"cpu": "cpu1"},
+			map[string]interface{}{
+				"current_khz": uint64(1800000),
+				"min_khz":     uint64(800000),
+				"max_khz":     uint64(3600000),
+			},
+			time.Unix(0, 0),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime(), testutil.SortMetrics())
+}
@@ -0,0 +1,88 @@
+package cpu
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/internal"
+)
+
+// frequencyFiles maps the fields of the frequency metric to the sysfs files
+// relative to the directory of a core
+var frequencyFiles = map[string]string{
+	"current_khz":            "cpufreq/scaling_cur_freq",
+	"min_khz":                "cpufreq/scaling_min_freq",
+	"max_khz":                "cpufreq/scaling_max_freq",
+	"core_throttle_count":    "thermal_throttle/core_throttle_count",
+	"package_throttle_count": "thermal_throttle/package_throttle_count",
+}
+
+// coreFrequency holds the sysfs files available for a core, determined once
+// to not probe the files of all cores on every gather
+type coreFrequency struct {
+	cpu   string
+	files map[string]string
+}
+
+// discoverFrequencies returns the cores providing any of the frequency or
+// throttling files. Cores without those files, e.g. in VMs, are skipped.
+func discoverFrequencies() ([]coreFrequency, error) {
+	dirs, err := filepath.Glob(filepath.Join(internal.GetSysPath(), "devices", "system", "cpu", "cpu[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	cores := make([]coreFrequency, 0, len(dirs))
+	for _, dir := range dirs {
+		name := filepath.Base(dir)
+		if _, err := strconv.Atoi(strings.TrimPrefix(name, "cpu")); err != nil {
+			continue
+		}
+
+		files := make(map[string]string, len(frequencyFiles))
+		for field, fn := range frequencyFiles {
+			path := filepath.Join(dir, fn)
+			if _, err := os.Stat(path); err == nil {
+				files[field] = path
+			}
+		}
+		if len(files) > 0 {
+			cores = append(cores, coreFrequency{cpu: name, files: files})
+		}
+	}
+	return cores, nil
+}
+
+func (c *CPUStats) gatherFrequencies(acc telegraf.Accumulator, now time.Time) {
+	for _, core := range c.frequencies {
+		fields := make(map[string]interface{}, len(core.files))
+		for field, path := range core.files {
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				// The core might have been taken offline
+				continue
+			}
+			v, err := strconv.ParseUint(strings.TrimSpace(string(raw)), 10, 64)
+			if err != nil {
+				continue
+			}
+			fields[field] = v
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		tags := map[string]string{"cpu": core.cpu}
+		if c.coreID {
+			tags["core_id"] = c.cpuInfo[core.cpu].CoreID
+		}
+		if c.physicalID {
+			tags["physical_id"] = c.cpuInfo[core.cpu].PhysicalID
+		}
+		acc.AddFields("cpu_frequency", fields, tags, now)
+	}
+}
@@ -4,7 +4,9 @@ package diskio
 import (
 	_ "embed"
 	"fmt"
+	"math"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,6 +23,9 @@ var sampleConfig string
 
 var (
 	varRegex = regexp.MustCompile(`\$(?:\w+|\{\w+\})`)
+
+	// The kernel uses 32-bit counters on 32-bit platforms only
+	counterWrap = strconv.IntSize == 32
 )
 
 type DiskIO struct {
@@ -28,6 +33,7 @@ type DiskIO struct {
 	DeviceTags       []string        `toml:"device_tags"`
 	NameTemplates    []string        `toml:"name_templates"`
 	SkipSerialNumber bool            `toml:"skip_serial_number"`
+	ComputeDerived   bool            `toml:"compute_derived"`
 	Log              telegraf.Logger `toml:"-"`
 
 	ps                system.PS
@@ -138,6 +144,11 @@ func (d *DiskIO) Gather(acc telegraf.Accumulator) error {
 			if itv > 0 {
 				fields["io_util"] = 100 * deltaIOTime / itv
 			}
+			if d.ComputeDerived {
+				for name, value := range derivedFields(lastValue, io, collectTime.Sub(d.lastCollectTime)) {
+					fields[name] = value
+				}
+			}
 		}
 		acc.AddCounter("diskio", fields, tags)
 	}
@@ -146,6 +157,56 @@ func (d *DiskIO) Gather(acc telegraf.Accumulator) error {
 	return nil
 }
 
+// derivedFields computes the per-interval latencies, the average queue depth
+// and the utilization of a device from two consecutive samples. No fields are
+// returned if the counters were reset, e.g. because the device was replaced.
+func derivedFields(prev, cur disk.IOCountersStat, interval time.Duration) map[string]interface{} {
+	itv := float64(interval) / float64(time.Millisecond)
+	if itv <= 0 {
+		return nil
+	}
+
+	reads, ok1 := counterDelta(prev.ReadCount, cur.ReadCount)
+	writes, ok2 := counterDelta(prev.WriteCount, cur.WriteCount)
+	readTime, ok3 := counterDelta(prev.ReadTime, cur.ReadTime)
+	writeTime, ok4 := counterDelta(prev.WriteTime, cur.WriteTime)
+	ioTime, ok5 := counterDelta(prev.IoTime, cur.IoTime)
+	weightedIO, ok6 := counterDelta(prev.WeightedIO, cur.WeightedIO)
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 || !ok6 {
+		return nil
+	}
+
+	fields := map[string]interface{}{
+		"read_await_derived":      0.0,
+		"write_await_derived":     0.0,
+		"avg_queue_depth_derived": float64(weightedIO) / itv,
+		// The device cannot be busy for longer than the interval, exceeding
+		// values are caused by the jitter of the collection time
+		"util_percent_derived": min(100*float64(ioTime)/itv, 100),
+	}
+	if reads > 0 {
+		fields["read_await_derived"] = float64(readTime) / float64(reads)
+	}
+	if writes > 0 {
+		fields["write_await_derived"] = float64(writeTime) / float64(writes)
+	}
+	return fields
+}
+
+// counterDelta returns the increase of a counter between two samples. On
+// platforms with 32-bit counters a decreasing value fitting into 32 bits is
+// treated as a wrap-around. Other decreasing values are treated as a counter
+// reset and reported as invalid.
+func counterDelta(prev, cur uint64) (uint64, bool) {
+	if cur >= prev {
+		return cur - prev, true
+	}
+	if counterWrap && prev <= math.MaxUint32 {
+		return cur + (math.MaxUint32 - prev) + 1, true
+	}
+	return 0, false
+}
+
 // hasMeta reports whether s contains any special glob characters.
 func hasMeta(s string) bool {
 	return strings.ContainsAny(s, "*?[")
@@ -1,6 +1,7 @@
 package diskio
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -190,3 +191,173 @@ func TestDiskIOUtil(t *testing.T) {
 	require.True(t, acc.HasFloatField("diskio", "io_svctm"), "io_svctm not have value")
 	require.True(t, acc.HasFloatField("diskio", "io_await"), "io_await not have value")
 }
+
+func TestDerivedFields(t *testing.T) {
+	prev := disk.IOCountersStat{
+		ReadCount:  100,
+		WriteCount: 200,
+		ReadTime:   1000,
+		WriteTime:  4000,
+		IoTime:     5000,
+		WeightedIO: 10000,
+	}
+
+	tests := []struct {
+		name     string
+		wrap     bool
+		prev     disk.IOCountersStat
+		cur      disk.IOCountersStat
+		expected map[string]interface{}
+	}{
+		{
+			name: "regular",
+			prev: prev,
+			cur: disk.IOCountersStat{
+				ReadCount:  150,
+				WriteCount: 300,
+				ReadTime:   1500,
+				WriteTime:  4500,
+				IoTime:     5500,
+				WeightedIO: 12000,
+			},
+			expected: map[string]interface{}{
+				"read_await_derived":      10.0,
+				"write_await_derived":     5.0,
+				"avg_queue_depth_derived": 2.0,
+				"util_percent_derived":    50.0,
+			},
+		},
+		{
+			name: "idle",
+			prev: prev,
+			cur:  prev,
+			expected: map[string]interface{}{
+				"read_await_derived":      0.0,
+				"write_await_derived":     0.0,
+				"avg_queue_depth_derived": 0.0,
+				"util_percent_derived":    0.0,
+			},
+		},
+		{
+			name: "utilization limited",
+			prev: prev,
+			cur: disk.IOCountersStat{
+				ReadCount:  100,
+				WriteCount: 200,
+				ReadTime:   1000,
+				WriteTime:  4000,
+				IoTime:     6010,
+				WeightedIO: 10000,
+			},
+			expected: map[string]interface{}{
+				"read_await_derived":      0.0,
+				"write_await_derived":     0.0,
+				"avg_queue_depth_derived": 0.0,
+				"util_percent_derived":    100.0,
+			},
+		},
+		{
+			name: "32-bit counter wrap",
+			wrap: true,
+			prev: disk.IOCountersStat{
+				ReadCount:  math.MaxUint32 - 9,
+				WriteCount: 200,
+				ReadTime:   math.MaxUint32 - 99,
+				WriteTime:  4000,
+				IoTime:     5000,
+				WeightedIO: 10000,
+			},
+			cur: disk.IOCountersStat{
+				ReadCount:  10,
+				WriteCount: 200,
+				ReadTime:   100,
+				WriteTime:  4000,
+				IoTime:     5100,
+				WeightedIO: 10200,
+			},
+			expected: map[string]interface{}{
+				"read_await_derived":      10.0,
+				"write_await_derived":     0.0,
+				"avg_queue_depth_derived": 0.2,
+				"util_percent_derived":    10.0,
+			},
+		},
+		{
+			name: "counter reset",
+			wrap: true,
+			prev: disk.IOCountersStat{
+				ReadCount:  math.MaxUint32 + 100,
+				WriteCount: 200,
+			},
+			cur: disk.IOCountersStat{
+				ReadCount:  10,
+				WriteCount: 300,
+			},
+		},
+		{
+			name: "small counter reset",
+			prev: disk.IOCountersStat{
+				ReadCount:  100,
+				WriteCount: 200,
+			},
+			cur: disk.IOCountersStat{
+				ReadCount:  10,
+				WriteCount: 300,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(wrap bool) { counterWrap = wrap }(counterWrap)
+			counterWrap = tt.wrap
+
+			actual := derivedFields(tt.prev, tt.cur, time.Second)
+			require.Len(t, actual, len(tt.expected))
+			for k, v := range tt.expected {
+				require.InDelta(t, v, actual[k], 1e-9, k)
+			}
+		})
+	}
+}
+
+func TestDiskIOComputeDerived(t *testing.T) {
+	first := map[string]disk.IOCountersStat{
+		"sda": {Name: "sda", ReadCount: 100, ReadTime: 1000, IoTime: 1000},
+	}
+	second := map[string]disk.IOCountersStat{
+		"sda": {Name: "sda", ReadCount: 200, ReadTime: 2000, IoTime: 1500},
+		"sdb": {Name: "sdb", ReadCount: 100, ReadTime: 1000, IoTime: 1000},
+	}
+
+	var mps system.MockPS
+	mps.On("DiskIO").Return(first, nil)
+	diskio := &DiskIO{
+		ComputeDerived: true,
+		Log:            testutil.Logger{},
+		ps:             &mps,
+	}
+	require.NoError(t, diskio.Init())
+
+	// The first sample of a device does not contain derived fields
+	var acc testutil.Accumulator
+	require.NoError(t, diskio.Gather(&acc))
+	require.False(t, acc.HasField("diskio", "read_await_derived"))
+
+	// A newly appearing device does not contain derived fields
+	var mps2 system.MockPS
+	mps2.On("DiskIO").Return(second, nil)
+	diskio.ps = &mps2
+	acc.ClearMetrics()
+	require.NoError(t, diskio.Gather(&acc))
+	require.Len(t, acc.GetTelegrafMetrics(), 2)
+	for _, m := range acc.GetTelegrafMetrics() {
+		name, _ := m.GetTag("name")
+		_, found := m.GetField("read_await_derived")
+		require.Equal(t, name == "sda", found, name)
+		if name == "sda" {
+			v, _ := m.GetField("read_await_derived")
+			require.InDelta(t, 10.0, v, 1e-9)
+		}
+	}
+}
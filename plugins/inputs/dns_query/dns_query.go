@@ -8,6 +8,7 @@ import (
 	"net"
 	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -42,6 +43,10 @@ type DNSQuery struct {
 	Timeout       config.Duration `toml:"timeout"`
 	IncludeFields []string        `toml:"include_fields"`
 
+	DNSSEC          bool                `toml:"dnssec"`
+	EDNSBufferSize  uint16              `toml:"edns_buffer_size"`
+	ExpectedAnswers map[string][]string `toml:"expected_answers"`
+
 	fieldEnabled map[string]bool
 }
 
@@ -79,6 +84,10 @@ func (d *DNSQuery) Init() error {
 		d.Port = 53
 	}
 
+	if d.DNSSEC && d.EDNSBufferSize == 0 {
+		d.EDNSBufferSize = 1232
+	}
+
 	return nil
 }
 
@@ -133,6 +142,13 @@ func (d *DNSQuery) query(domain, server string) (map[string]interface{}, map[str
 	var msg dns.Msg
 	msg.SetQuestion(dns.Fqdn(domain), recordType)
 	msg.RecursionDesired = true
+	if d.EDNSBufferSize > 0 {
+		msg.SetEdns0(d.EDNSBufferSize, d.DNSSEC)
+	}
+	if d.DNSSEC {
+		// Signal the server to report if the answer was validated
+		msg.AuthenticatedData = true
+	}
 
 	addr := net.JoinHostPort(server, strconv.Itoa(d.Port))
 	r, rtt, err := c.Exchange(&msg, addr)
@@ -151,6 +167,24 @@ func (d *DNSQuery) query(domain, server string) (map[string]interface{}, map[str
 	fields["rcode_value"] = r.Rcode
 	fields["query_time_ms"] = float64(rtt.Nanoseconds()) / 1e6
 
+	if d.DNSSEC {
+		fields["authenticated_data"] = r.AuthenticatedData
+		fields["rrsig_present"] = slices.ContainsFunc(r.Answer, func(rr dns.RR) bool {
+			return rr.Header().Rrtype == dns.TypeRRSIG
+		})
+	}
+
+	if expected, found := d.ExpectedAnswers[domain]; found {
+		missing, unexpected := compareAnswers(expected, r.Answer, recordType)
+		fields["answer_match"] = missing == ""
+		if missing != "" {
+			tags["missing_answer"] = missing
+			if unexpected != "" {
+				tags["unexpected_answer"] = unexpected
+			}
+		}
+	}
+
 	// Handle the failure case
 	if r.Rcode != dns.RcodeSuccess {
 		return fields, tags, fmt.Errorf("invalid answer (%s) from %s after %s query for %s", dns.RcodeToString[r.Rcode], server, d.RecordType, domain)
@@ -235,6 +269,47 @@ func (d *DNSQuery) parseRecordType() (uint16, error) {
 	return recordType, err
 }
 
+// compareAnswers checks if the answers of the queried record type contain all
+// expected values. The first missing expected value and the first answer not
+// being expected are returned, both are empty if the answers match exactly.
+func compareAnswers(expected []string, answers []dns.RR, recordType uint16) (missing, unexpected string) {
+	values := make([]string, 0, len(answers))
+	for _, rr := range answers {
+		hdr := rr.Header()
+		if hdr.Rrtype == dns.TypeRRSIG || (recordType != dns.TypeANY && hdr.Rrtype != recordType) {
+			continue
+		}
+		// Use the record data in zone file format
+		value := strings.TrimPrefix(rr.String(), hdr.String())
+		values = append(values, normalizeAnswer(value))
+	}
+
+	normalized := make([]string, 0, len(expected))
+	for _, e := range expected {
+		normalized = append(normalized, normalizeAnswer(e))
+	}
+
+	for i, e := range normalized {
+		if !slices.Contains(values, e) {
+			missing = expected[i]
+			break
+		}
+	}
+	for _, v := range values {
+		if !slices.Contains(normalized, v) {
+			unexpected = v
+			break
+		}
+	}
+	return missing, unexpected
+}
+
+// normalizeAnswer makes the trailing dot of fully-qualified names optional
+// for comparing answers
+func normalizeAnswer(value string) string {
+	return strings.TrimSuffix(strings.TrimSpace(value), ".")
+}
+
 func extractIP(record dns.RR) (string, bool) {
 	if r, ok := record.(*dns.A); ok {
 		return r.A.String(), true
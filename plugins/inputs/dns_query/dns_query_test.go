@@ -1,6 +1,8 @@
 package dns_query
 
 import (
+	"net"
+	"strconv"
 	"testing"
 	"time"
 
@@ -282,3 +284,131 @@ func TestRecordTypeParserError(t *testing.T) {
 	_, err := plugin.parseRecordType()
 	require.Error(t, err)
 }
+
+func TestDNSSECAndExpectedAnswers(t *testing.T) {
+	// Start a local server answering A queries with two addresses and adding
+	// signatures if requested by the DO bit
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	handler := dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		var resp dns.Msg
+		resp.SetReply(req)
+		for _, ip := range []string{"192.0.2.1", "192.0.2.2"} {
+			rr, err := dns.NewRR(req.Question[0].Name + " 300 IN A " + ip)
+			if err != nil {
+				panic(err)
+			}
+			resp.Answer = append(resp.Answer, rr)
+		}
+		if opt := req.IsEdns0(); opt != nil {
+			resp.SetEdns0(opt.UDPSize(), opt.Do())
+			if opt.Do() {
+				resp.AuthenticatedData = true
+				resp.Answer = append(resp.Answer, &dns.RRSIG{
+					Hdr:         dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: 300},
+					TypeCovered: dns.TypeA,
+					SignerName:  "example.org.",
+				})
+			}
+		}
+		if err := w.WriteMsg(&resp); err != nil {
+			panic(err)
+		}
+	})
+	server := &dns.Server{PacketConn: pc, Handler: handler}
+	go server.ActivateAndServe() //nolint:errcheck // ignore the error on shutdown
+	defer server.Shutdown()      //nolint:errcheck // ignore the error on shutdown
+
+	host, port, err := net.SplitHostPort(pc.LocalAddr().String())
+	require.NoError(t, err)
+	portNum, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		dnssec   bool
+		expected []string
+		fields   map[string]interface{}
+		tags     map[string]string
+	}{
+		{
+			name: "no expectation",
+		},
+		{
+			name:   "dnssec",
+			dnssec: true,
+			fields: map[string]interface{}{
+				"authenticated_data": true,
+				"rrsig_present":      true,
+			},
+		},
+		{
+			name:     "superset",
+			expected: []string{"192.0.2.2"},
+			fields:   map[string]interface{}{"answer_match": true},
+		},
+		{
+			name:     "missing",
+			expected: []string{"192.0.2.1", "192.0.2.3"},
+			fields:   map[string]interface{}{"answer_match": false},
+			tags: map[string]string{
+				"missing_answer":    "192.0.2.3",
+				"unexpected_answer": "192.0.2.2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &DNSQuery{
+				Servers:    []string{host},
+				Port:       portNum,
+				Domains:    []string{"example.org"},
+				RecordType: "A",
+				Timeout:    config.Duration(2 * time.Second),
+				DNSSEC:     tt.dnssec,
+			}
+			if tt.expected != nil {
+				plugin.ExpectedAnswers = map[string][]string{"example.org": tt.expected}
+			}
+			require.NoError(t, plugin.Init())
+
+			var acc testutil.Accumulator
+			require.NoError(t, acc.GatherError(plugin.Gather))
+			require.Len(t, acc.Metrics, 1)
+			m := acc.Metrics[0]
+			require.Equal(t, "success", m.Tags["result"])
+
+			for _, field := range []string{"authenticated_data", "rrsig_present", "answer_match"} {
+				expected, found := tt.fields[field]
+				actual, exists := m.Fields[field]
+				require.Equal(t, found, exists, field)
+				require.Equal(t, expected, actual, field)
+			}
+			for _, tag := range []string{"missing_answer", "unexpected_answer"} {
+				require.Equal(t, tt.tags[tag], m.Tags[tag], tag)
+			}
+		})
+	}
+}
+
+func TestCompareAnswers(t *testing.T) {
+	var answers []dns.RR
+	for _, s := range []string{
+		"example.org. 300 IN MX 10 mail.example.org.",
+		"example.org. 300 IN MX 20 backup.example.org.",
+		"example.org. 300 IN A 192.0.2.1",
+	} {
+		rr, err := dns.NewRR(s)
+		require.NoError(t, err)
+		answers = append(answers, rr)
+	}
+
+	missing, unexpected := compareAnswers([]string{"10 mail.example.org", "20 backup.example.org."}, answers, dns.TypeMX)
+	require.Empty(t, missing)
+	require.Empty(t, unexpected)
+
+	missing, unexpected = compareAnswers([]string{"10 mail.example.org", "30 other.example.org"}, answers, dns.TypeMX)
+	require.Equal(t, "30 other.example.org", missing)
+	require.Equal(t, "20 backup.example.org", unexpected)
+}
@@ -47,6 +47,9 @@ type Ethtool struct {
 	// Normalization on the key names
 	NormalizeKeys []string `toml:"normalize_keys"`
 
+	// Collect the diagnostics of plugged-in modules
+	CollectModuleInfo bool `toml:"collect_module_info"`
+
 	Log telegraf.Logger `toml:"-"`
 
 	interfaceFilter   filter.Filter
@@ -63,6 +66,7 @@ type command interface {
 	interfaces(includeNamespaces bool) ([]namespacedInterface, error)
 	stats(intf namespacedInterface) (map[string]uint64, error)
 	get(intf namespacedInterface) (map[string]uint64, error)
+	moduleEeprom(intf namespacedInterface) ([]byte, error)
 }
 
 type commandEthtool struct {
@@ -189,6 +193,10 @@ func (e *Ethtool) gatherEthtoolStats(iface namespacedInterface, acc telegraf.Acc
 	}
 
 	acc.AddFields(pluginName, fields, tags)
+
+	if e.CollectModuleInfo {
+		e.gatherModuleInfo(iface, tags, acc)
+	}
 }
 
 // normalize key string; order matters to avoid replacing whitespace with
@@ -281,6 +289,10 @@ func (c *commandEthtool) get(intf namespacedInterface) (stats map[string]uint64,
 	return intf.namespace.get(intf)
 }
 
+func (c *commandEthtool) moduleEeprom(intf namespacedInterface) ([]byte, error) {
+	return intf.namespace.moduleEeprom(intf)
+}
+
 func (c *commandEthtool) interfaces(includeNamespaces bool) ([]namespacedInterface, error) {
 	const namespaceDirectory = "/var/run/netns"
 
@@ -26,6 +26,9 @@ type Ethtool struct {
 	// Normalization on the key names
 	NormalizeKeys []string `toml:"normalize_keys"`
 
+	// Collect the diagnostics of plugged-in modules
+	CollectModuleInfo bool `toml:"collect_module_info"`
+
 	Log telegraf.Logger `toml:"-"`
 }
 
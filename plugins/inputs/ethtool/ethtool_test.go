@@ -25,6 +25,7 @@ type interfaceMock struct {
 	loopBack      bool
 	interfaceUp   bool
 	cmdGet        map[string]uint64
+	eeprom        []byte
 }
 
 type namespaceMock struct {
@@ -51,6 +52,10 @@ func (n *namespaceMock) get(_ namespacedInterface) (map[string]uint64, error) {
 	return nil, errors.New("it is a test bug to invoke this function")
 }
 
+func (n *namespaceMock) moduleEeprom(_ namespacedInterface) ([]byte, error) {
+	return nil, errors.New("it is a test bug to invoke this function")
+}
+
 type commandEthtoolMock struct {
 	interfaceMap map[string]*interfaceMock
 }
@@ -130,6 +135,17 @@ func (c *commandEthtoolMock) get(intf namespacedInterface) (map[string]uint64, e
 	return nil, errors.New("interface not found")
 }
 
+func (c *commandEthtoolMock) moduleEeprom(intf namespacedInterface) ([]byte, error) {
+	i := c.interfaceMap[intf.Name]
+	if i == nil {
+		return nil, errors.New("interface not found")
+	}
+	if i.eeprom == nil {
+		return nil, errors.New("operation not supported")
+	}
+	return i.eeprom, nil
+}
+
 func setup() {
 	interfaceMap = make(map[string]*interfaceMock)
 
@@ -238,7 +254,7 @@ func setup() {
 		"link":    1,
 		"speed":   1000,
 	}
-	eth1 := &interfaceMock{"eth1", "driver1", "", eth1Stat, false, true, eth1Get}
+	eth1 := &interfaceMock{"eth1", "driver1", "", eth1Stat, false, true, eth1Get, nil}
 	interfaceMap[eth1.name] = eth1
 
 	eth2Stat := map[string]uint64{
@@ -346,7 +362,7 @@ func setup() {
 		"link":    0,
 		"speed":   9223372036854775807,
 	}
-	eth2 := &interfaceMock{"eth2", "driver1", "", eth2Stat, false, false, eth2Get}
+	eth2 := &interfaceMock{"eth2", "driver1", "", eth2Stat, false, false, eth2Get, nil}
 	interfaceMap[eth2.name] = eth2
 
 	eth3Stat := map[string]uint64{
@@ -454,7 +470,7 @@ func setup() {
 		"link":    1,
 		"speed":   1000,
 	}
-	eth3 := &interfaceMock{"eth3", "driver1", "namespace1", eth3Stat, false, true, eth3Get}
+	eth3 := &interfaceMock{"eth3", "driver1", "namespace1", eth3Stat, false, true, eth3Get, nil}
 	interfaceMap[eth3.name] = eth3
 
 	eth4Stat := map[string]uint64{
@@ -562,7 +578,7 @@ func setup() {
 		"link":    1,
 		"speed":   100,
 	}
-	eth4 := &interfaceMock{"eth4", "driver1", "namespace2", eth4Stat, false, true, eth4Get}
+	eth4 := &interfaceMock{"eth4", "driver1", "namespace2", eth4Stat, false, true, eth4Get, nil}
 	interfaceMap[eth4.name] = eth4
 
 	// dummy loopback including dummy stat to ensure that the ignore feature is working
@@ -575,7 +591,7 @@ func setup() {
 		"link":    1,
 		"speed":   1000,
 	}
-	lo0 := &interfaceMock{"lo0", "", "", lo0Stat, true, true, lo0Get}
+	lo0 := &interfaceMock{"lo0", "", "", lo0Stat, true, true, lo0Get, nil}
 	interfaceMap[lo0.name] = lo0
 
 	c := &commandEthtoolMock{interfaceMap}
@@ -956,7 +972,7 @@ func TestNormalizedKeys(t *testing.T) {
 	}
 
 	for _, c := range cases {
-		eth0 := &interfaceMock{"eth0", "e1000e", "", toStringMapUint(c.stats), false, true, map[string]uint64{}}
+		eth0 := &interfaceMock{"eth0", "e1000e", "", toStringMapUint(c.stats), false, true, map[string]uint64{}, nil}
 		expectedTags := map[string]string{
 			"interface": eth0.name,
 			"driver":    eth0.driverName,
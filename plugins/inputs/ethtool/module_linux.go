@@ -0,0 +1,190 @@
+//go:build linux
+
+package ethtool
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/influxdata/telegraf"
+)
+
+const measurementModule = pluginName + "_module"
+
+// Identifiers of the module types, see SFF-8024 table 4-1
+const (
+	moduleIdentifierSFP    = 0x03
+	moduleIdentifierQSFP   = 0x0c
+	moduleIdentifierQSFPP  = 0x0d
+	moduleIdentifierQSFP28 = 0x11
+)
+
+// moduleLane contains the diagnostics of a single lane of a module with the
+// power in mW and the bias current in mA
+type moduleLane struct {
+	rxPower    float64
+	txPower    float64
+	hasTxPower bool
+	biasCurr   float64
+}
+
+// moduleDiagnostics contains the digital diagnostics monitoring (DOM) values
+// of a module with the temperature in °C and the supply voltage in V
+type moduleDiagnostics struct {
+	temperature float64
+	voltage     float64
+	lanes       []moduleLane
+}
+
+// gatherModuleInfo reads the diagnostics of the module plugged into the
+// interface. Interfaces without a module, or a module not providing
+// diagnostics, are skipped quietly
+func (e *Ethtool) gatherModuleInfo(iface namespacedInterface, tags map[string]string, acc telegraf.Accumulator) {
+	eeprom, err := e.command.moduleEeprom(iface)
+	if err != nil {
+		e.Log.Debugf("Reading module EEPROM of %q failed: %v", iface.Name, err)
+		return
+	}
+
+	diag, err := parseModuleEeprom(eeprom)
+	if err != nil {
+		e.Log.Debugf("Parsing module EEPROM of %q failed: %v", iface.Name, err)
+		return
+	}
+
+	fields := map[string]interface{}{
+		"temperature_c": diag.temperature,
+		"voltage_v":     diag.voltage,
+	}
+	acc.AddFields(measurementModule, fields, tags)
+
+	for i, lane := range diag.lanes {
+		ltags := make(map[string]string, len(tags)+1)
+		for k, v := range tags {
+			ltags[k] = v
+		}
+		ltags["lane"] = strconv.Itoa(i + 1)
+
+		lfields := map[string]interface{}{
+			"rx_power_dbm":    milliwattToDBm(lane.rxPower),
+			"bias_current_ma": lane.biasCurr,
+		}
+		if lane.hasTxPower {
+			lfields["tx_power_dbm"] = milliwattToDBm(lane.txPower)
+		}
+		acc.AddFields(measurementModule, lfields, ltags)
+	}
+}
+
+// parseModuleEeprom decodes the diagnostics from the EEPROM content of SFP
+// (SFF-8472) and QSFP (SFF-8436 and SFF-8636) modules
+func parseModuleEeprom(data []byte) (*moduleDiagnostics, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty EEPROM")
+	}
+
+	switch data[0] {
+	case moduleIdentifierSFP:
+		return parseSFF8472(data)
+	case moduleIdentifierQSFP, moduleIdentifierQSFPP, moduleIdentifierQSFP28:
+		return parseSFF8636(data)
+	}
+	return nil, fmt.Errorf("unsupported module identifier 0x%02x", data[0])
+}
+
+// parseSFF8472 decodes the diagnostics of SFP modules located in the second
+// page (A2h) following the 256 bytes of the identification page (A0h)
+func parseSFF8472(data []byte) (*moduleDiagnostics, error) {
+	const (
+		diagnosticsType     = 92
+		diagnosticsPage     = 256
+		ddmImplemented      = 0x40
+		externalCalibration = 0x10
+	)
+
+	if len(data) < 2*diagnosticsPage || data[diagnosticsType]&ddmImplemented == 0 {
+		return nil, errors.New("digital diagnostics not implemented")
+	}
+	page := data[diagnosticsPage:]
+
+	temperature := float64(int16(binary.BigEndian.Uint16(page[96:98])))
+	voltage := float64(binary.BigEndian.Uint16(page[98:100]))
+	bias := float64(binary.BigEndian.Uint16(page[100:102]))
+	txPower := float64(binary.BigEndian.Uint16(page[102:104]))
+	rxPower := float64(binary.BigEndian.Uint16(page[104:106]))
+
+	// Externally calibrated modules report raw A/D values along with the
+	// calibration constants for converting them
+	if data[diagnosticsType]&externalCalibration != 0 {
+		calibrate := func(value float64, offset int) float64 {
+			slope := float64(binary.BigEndian.Uint16(page[offset:offset+2])) / 256
+			return value*slope + float64(int16(binary.BigEndian.Uint16(page[offset+2:offset+4])))
+		}
+		bias = calibrate(bias, 76)
+		txPower = calibrate(txPower, 80)
+		temperature = calibrate(temperature, 84)
+		voltage = calibrate(voltage, 88)
+
+		// The receive power is calibrated by a polynomial of fourth order
+		// with the coefficients stored as floats starting with the highest
+		// order at offset 56
+		var calibrated float64
+		for i := range 5 {
+			coefficient := math.Float32frombits(binary.BigEndian.Uint32(page[56+4*i : 60+4*i]))
+			calibrated = calibrated*rxPower + float64(coefficient)
+		}
+		rxPower = calibrated
+	}
+
+	return &moduleDiagnostics{
+		temperature: temperature / 256,
+		voltage:     voltage / 10000,
+		lanes: []moduleLane{
+			{
+				rxPower:    rxPower / 10000,
+				txPower:    txPower / 10000,
+				hasTxPower: true,
+				biasCurr:   bias * 2 / 1000,
+			},
+		},
+	}, nil
+}
+
+// parseSFF8636 decodes the diagnostics of QSFP modules located in the lower
+// page for all four lanes
+func parseSFF8636(data []byte) (*moduleDiagnostics, error) {
+	const (
+		diagnosticsType = 220
+		txPowerSupport  = 0x04
+	)
+
+	if len(data) < 256 {
+		return nil, errors.New("truncated EEPROM")
+	}
+
+	diag := &moduleDiagnostics{
+		temperature: float64(int16(binary.BigEndian.Uint16(data[22:24]))) / 256,
+		voltage:     float64(binary.BigEndian.Uint16(data[26:28])) / 10000,
+		lanes:       make([]moduleLane, 0, 4),
+	}
+	for i := range 4 {
+		offset := 2 * i
+		diag.lanes = append(diag.lanes, moduleLane{
+			rxPower:    float64(binary.BigEndian.Uint16(data[34+offset:36+offset])) / 10000,
+			txPower:    float64(binary.BigEndian.Uint16(data[50+offset:52+offset])) / 10000,
+			hasTxPower: data[diagnosticsType]&txPowerSupport != 0,
+			biasCurr:   float64(binary.BigEndian.Uint16(data[42+offset:44+offset])) * 2 / 1000,
+		})
+	}
+
+	return diag, nil
+}
+
+// milliwattToDBm converts the power to dBm limited by the resolution of the
+// modules of 0.1µW to avoid infinite values on loss of signal
+func milliwattToDBm(power float64) float64 {
+	return 10 * math.Log10(max(power, 0.0001))
+}
@@ -0,0 +1,210 @@
+//go:build linux
+
+package ethtool
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func sfpEeprom(diagnosticsType byte, values map[int]uint16) []byte {
+	data := make([]byte, 512)
+	data[0] = moduleIdentifierSFP
+	data[92] = diagnosticsType
+	for offset, v := range values {
+		binary.BigEndian.PutUint16(data[256+offset:], v)
+	}
+	return data
+}
+
+func qsfpEeprom(identifier, diagnosticsType byte, values map[int]uint16) []byte {
+	data := make([]byte, 256)
+	data[0] = identifier
+	data[220] = diagnosticsType
+	for offset, v := range values {
+		binary.BigEndian.PutUint16(data[offset:], v)
+	}
+	return data
+}
+
+func TestGatherModuleInfo(t *testing.T) {
+	// Externally calibrated SFP with a receive power polynomial of 2*x
+	external := sfpEeprom(0x50, map[int]uint16{
+		76: 256, 80: 256, 84: 256, 86: 6428, 88: 256,
+		96: 100, 98: 33000, 100: 3000, 102: 10000, 104: 500,
+	})
+	binary.BigEndian.PutUint32(external[256+68:], math.Float32bits(2))
+
+	tags := map[string]string{
+		"interface": "eth0",
+		"driver":    "driver1",
+		"namespace": "",
+	}
+	laneTags := func(lane string) map[string]string {
+		t := map[string]string{"lane": lane}
+		for k, v := range tags {
+			t[k] = v
+		}
+		return t
+	}
+
+	tests := []struct {
+		name     string
+		eeprom   []byte
+		exclude  []string
+		expected []telegraf.Metric
+	}{
+		{
+			name: "sfp",
+			eeprom: sfpEeprom(0x68, map[int]uint16{
+				96: 6528, 98: 33000, 100: 3000, 102: 10000, 104: 1000,
+			}),
+			expected: []telegraf.Metric{
+				metric.New("ethtool_module", tags, map[string]interface{}{
+					"temperature_c": 25.5,
+					"voltage_v":     3.3,
+				}, time.Unix(0, 0)),
+				metric.New("ethtool_module", laneTags("1"), map[string]interface{}{
+					"rx_power_dbm":    -10.0,
+					"tx_power_dbm":    0.0,
+					"bias_current_ma": 6.0,
+				}, time.Unix(0, 0)),
+			},
+		},
+		{
+			name:   "sfp externally calibrated",
+			eeprom: external,
+			expected: []telegraf.Metric{
+				metric.New("ethtool_module", tags, map[string]interface{}{
+					"temperature_c": 25.5,
+					"voltage_v":     3.3,
+				}, time.Unix(0, 0)),
+				metric.New("ethtool_module", laneTags("1"), map[string]interface{}{
+					"rx_power_dbm":    -10.0,
+					"tx_power_dbm":    0.0,
+					"bias_current_ma": 6.0,
+				}, time.Unix(0, 0)),
+			},
+		},
+		{
+			name: "qsfp28",
+			eeprom: qsfpEeprom(moduleIdentifierQSFP28, 0x04, map[int]uint16{
+				22: 0xfb00, 26: 32500,
+				34: 10000, 36: 1000, 38: 0, 40: 10000,
+				42: 3000, 44: 3000, 46: 3000, 48: 3500,
+				50: 10000, 52: 10000, 54: 10000, 56: 1000,
+			}),
+			expected: []telegraf.Metric{
+				metric.New("ethtool_module", tags, map[string]interface{}{
+					"temperature_c": -5.0,
+					"voltage_v":     3.25,
+				}, time.Unix(0, 0)),
+				metric.New("ethtool_module", laneTags("1"), map[string]interface{}{
+					"rx_power_dbm":    0.0,
+					"tx_power_dbm":    0.0,
+					"bias_current_ma": 6.0,
+				}, time.Unix(0, 0)),
+				metric.New("ethtool_module", laneTags("2"), map[string]interface{}{
+					"rx_power_dbm":    -10.0,
+					"tx_power_dbm":    0.0,
+					"bias_current_ma": 6.0,
+				}, time.Unix(0, 0)),
+				metric.New("ethtool_module", laneTags("3"), map[string]interface{}{
+					"rx_power_dbm":    -40.0,
+					"tx_power_dbm":    0.0,
+					"bias_current_ma": 6.0,
+				}, time.Unix(0, 0)),
+				metric.New("ethtool_module", laneTags("4"), map[string]interface{}{
+					"rx_power_dbm":    0.0,
+					"tx_power_dbm":    -10.0,
+					"bias_current_ma": 7.0,
+				}, time.Unix(0, 0)),
+			},
+		},
+		{
+			name: "qsfp without transmit power",
+			eeprom: qsfpEeprom(moduleIdentifierQSFPP, 0x00, map[int]uint16{
+				22: 7680, 26: 33000, 34: 10000, 42: 3000,
+			}),
+			expected: []telegraf.Metric{
+				metric.New("ethtool_module", tags, map[string]interface{}{
+					"temperature_c": 30.0,
+					"voltage_v":     3.3,
+				}, time.Unix(0, 0)),
+				metric.New("ethtool_module", laneTags("1"), map[string]interface{}{
+					"rx_power_dbm":    0.0,
+					"bias_current_ma": 6.0,
+				}, time.Unix(0, 0)),
+				metric.New("ethtool_module", laneTags("2"), map[string]interface{}{
+					"rx_power_dbm":    -40.0,
+					"bias_current_ma": 0.0,
+				}, time.Unix(0, 0)),
+				metric.New("ethtool_module", laneTags("3"), map[string]interface{}{
+					"rx_power_dbm":    -40.0,
+					"bias_current_ma": 0.0,
+				}, time.Unix(0, 0)),
+				metric.New("ethtool_module", laneTags("4"), map[string]interface{}{
+					"rx_power_dbm":    -40.0,
+					"bias_current_ma": 0.0,
+				}, time.Unix(0, 0)),
+			},
+		},
+		{
+			name: "excluded interface",
+			eeprom: sfpEeprom(0x68, map[int]uint16{
+				96: 6528, 98: 33000, 100: 3000, 102: 10000, 104: 1000,
+			}),
+			exclude: []string{"eth0"},
+		},
+		{
+			name: "no module",
+		},
+		{
+			name:   "sfp without diagnostics",
+			eeprom: sfpEeprom(0x00, map[int]uint16{96: 6528}),
+		},
+		{
+			name:   "unsupported module",
+			eeprom: []byte{0x18, 0x00},
+		},
+		{
+			name:   "truncated qsfp",
+			eeprom: qsfpEeprom(moduleIdentifierQSFP, 0x04, map[int]uint16{22: 7680})[:128],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eth0 := &interfaceMock{"eth0", "driver1", "", map[string]uint64{}, false, true, map[string]uint64{}, tt.eeprom}
+			plugin := &Ethtool{
+				InterfaceExclude:  tt.exclude,
+				CollectModuleInfo: true,
+				Log:               testutil.Logger{},
+				command:           &commandEthtoolMock{map[string]*interfaceMock{eth0.name: eth0}},
+			}
+			require.NoError(t, plugin.Init())
+
+			var acc testutil.Accumulator
+			require.NoError(t, plugin.Gather(&acc))
+			require.Empty(t, acc.Errors)
+
+			var actual []telegraf.Metric
+			for _, m := range acc.GetTelegrafMetrics() {
+				if m.Name() == "ethtool_module" {
+					actual = append(actual, m)
+				}
+			}
+			testutil.RequireMetricsEqual(t, tt.expected, actual,
+				testutil.IgnoreTime(), testutil.SortMetrics(), cmpopts.EquateApprox(0, 1e-9))
+		})
+	}
+}
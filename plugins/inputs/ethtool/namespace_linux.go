@@ -19,6 +19,7 @@ type namespace interface {
 	driverName(intf namespacedInterface) (string, error)
 	stats(intf namespacedInterface) (map[string]uint64, error)
 	get(intf namespacedInterface) (map[string]uint64, error)
+	moduleEeprom(intf namespacedInterface) ([]byte, error)
 }
 
 type namespacedInterface struct {
@@ -117,6 +118,17 @@ func (n *namespaceGoroutine) get(intf namespacedInterface) (map[string]uint64, e
 	return nil, err
 }
 
+func (n *namespaceGoroutine) moduleEeprom(intf namespacedInterface) ([]byte, error) {
+	result, err := n.do(func(n *namespaceGoroutine) (interface{}, error) {
+		return n.ethtoolClient.ModuleEeprom(intf.Name)
+	})
+
+	if result != nil {
+		return result.([]byte), err
+	}
+	return nil, err
+}
+
 // start locks a goroutine to an OS thread and ties it to the namespace, then
 // loops for actions to run in the namespace.
 func (n *namespaceGoroutine) start() error {
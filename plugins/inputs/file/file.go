@@ -76,15 +76,16 @@ func (f *File) Gather(acc telegraf.Accumulator) error {
 }
 
 func (f *File) refreshFilePaths() error {
+	globs, err := globpath.CompileList(f.Files)
+	if err != nil {
+		return fmt.Errorf("could not compile globs: %w", err)
+	}
+
 	var allFiles []string
-	for _, file := range f.Files {
-		g, err := globpath.Compile(file)
-		if err != nil {
-			return fmt.Errorf("could not compile glob %q: %w", file, err)
-		}
+	for _, g := range globs {
 		files := g.Match()
 		if len(files) == 0 {
-			return fmt.Errorf("could not find file(s): %v", file)
+			return fmt.Errorf("could not find file(s): %v", g.Pattern())
 		}
 		allFiles = append(allFiles, files...)
 	}
@@ -287,13 +287,13 @@ func (fc *FileCount) getDirs() []string {
 func (fc *FileCount) initGlobPaths(acc telegraf.Accumulator) {
 	dirs := fc.getDirs()
 	fc.globPaths = make([]globpath.GlobPath, 0, len(dirs))
-	for _, directory := range dirs {
-		glob, err := globpath.Compile(directory)
-		if err != nil {
-			acc.AddError(err)
-		} else {
-			fc.globPaths = append(fc.globPaths, *glob)
-		}
+	globs, err := globpath.CompileList(dirs)
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for _, glob := range globs {
+		fc.globPaths = append(fc.globPaths, *glob)
 	}
 }
 
@@ -22,8 +22,8 @@ type FileStat struct {
 
 	Log telegraf.Logger `toml:"-"`
 
-	// maps full file paths to globmatch obj
-	globs map[string]*globpath.GlobPath
+	// compiled globs of the file paths
+	globs []*globpath.GlobPath
 
 	// files that were missing - we only log the first time it's not found.
 	missingFiles map[string]bool
@@ -38,16 +38,14 @@ func (*FileStat) SampleConfig() string {
 func (f *FileStat) Gather(acc telegraf.Accumulator) error {
 	var err error
 
-	for _, filepath := range f.Files {
-		// Get the compiled glob object for this filepath
-		g, ok := f.globs[filepath]
-		if !ok {
-			if g, err = globpath.Compile(filepath); err != nil {
-				acc.AddError(err)
-				continue
-			}
-			f.globs[filepath] = g
+	if f.globs == nil {
+		if f.globs, err = globpath.CompileList(f.Files); err != nil {
+			return err
 		}
+	}
+
+	for _, g := range f.globs {
+		filepath := g.Pattern()
 
 		files := g.Match()
 		if len(files) == 0 {
@@ -128,7 +126,6 @@ func getMd5(file string) (string, error) {
 
 func newFileStat() *FileStat {
 	return &FileStat{
-		globs:           make(map[string]*globpath.GlobPath),
 		missingFiles:    make(map[string]bool),
 		filesWithErrors: make(map[string]bool),
 	}
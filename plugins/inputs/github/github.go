@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/plugins/inputs"
 	"github.com/influxdata/telegraf/selfstat"
 )
@@ -24,24 +26,51 @@ import (
 var sampleConfig string
 
 type GitHub struct {
-	Repositories      []string        `toml:"repositories"`
-	AccessToken       string          `toml:"access_token"`
-	AdditionalFields  []string        `toml:"additional_fields"`
-	EnterpriseBaseURL string          `toml:"enterprise_base_url"`
-	HTTPTimeout       config.Duration `toml:"http_timeout"`
+	Repositories              []string        `toml:"repositories"`
+	Organizations             []string        `toml:"organizations"`
+	RepositoryExclude         []string        `toml:"repository_exclude"`
+	RepositoryRefreshInterval config.Duration `toml:"repository_refresh_interval"`
+	AccessToken               string          `toml:"access_token"`
+	AdditionalFields          []string        `toml:"additional_fields"`
+	RateLimitThreshold        int             `toml:"rate_limit_threshold"`
+	EnterpriseBaseURL         string          `toml:"enterprise_base_url"`
+	HTTPTimeout               config.Duration `toml:"http_timeout"`
 
 	githubClient    *github.Client
 	obfuscatedToken string
+	excludeFilter   filter.Filter
 
-	rateLimit       selfstat.Stat
-	rateLimitErrors selfstat.Stat
-	rateRemaining   selfstat.Stat
+	discovered    []string
+	lastDiscovery time.Time
+
+	rateLimit         selfstat.Stat
+	rateLimitErrors   selfstat.Stat
+	rateRemaining     selfstat.Stat
+	rateLimitDeferred selfstat.Stat
 }
 
 func (*GitHub) SampleConfig() string {
 	return sampleConfig
 }
 
+func (g *GitHub) Init() error {
+	for _, field := range g.AdditionalFields {
+		switch field {
+		case "pull-requests", "traffic":
+		default:
+			return fmt.Errorf("unknown additional field %q", field)
+		}
+	}
+
+	f, err := filter.Compile(g.RepositoryExclude)
+	if err != nil {
+		return fmt.Errorf("compiling repository exclude filter failed: %w", err)
+	}
+	g.excludeFilter = f
+
+	return nil
+}
+
 // Gather GitHub Metrics
 func (g *GitHub) Gather(acc telegraf.Accumulator) error {
 	ctx := context.Background()
@@ -61,57 +90,134 @@ func (g *GitHub) Gather(acc telegraf.Accumulator) error {
 		g.rateLimitErrors = selfstat.Register("github", "rate_limit_blocks", tokenTags)
 		g.rateLimit = selfstat.Register("github", "rate_limit_limit", tokenTags)
 		g.rateRemaining = selfstat.Register("github", "rate_limit_remaining", tokenTags)
+		g.rateLimitDeferred = selfstat.Register("github", "rate_limit_deferred", tokenTags)
 	}
 
-	var wg sync.WaitGroup
-	wg.Add(len(g.Repositories))
+	if len(g.Organizations) > 0 && time.Since(g.lastDiscovery) >= time.Duration(g.RepositoryRefreshInterval) {
+		discovered, err := g.discoverRepositories(ctx)
+		if err != nil {
+			// Keep the previously discovered repositories and retry the
+			// discovery in the next interval
+			acc.AddError(err)
+		} else {
+			g.discovered = discovered
+			g.lastDiscovery = time.Now()
+		}
+	}
 
-	for _, repository := range g.Repositories {
-		go func(repositoryName string, acc telegraf.Accumulator) {
+	var wg sync.WaitGroup
+	for _, repository := range g.repositories() {
+		wg.Add(1)
+		go func(repositoryName string) {
 			defer wg.Done()
+			g.gatherRepository(ctx, repositoryName, acc)
+		}(repository)
+	}
 
-			owner, repository, err := splitRepositoryName(repositoryName)
-			if err != nil {
-				acc.AddError(err)
-				return
-			}
+	wg.Wait()
+	return nil
+}
 
-			repositoryInfo, response, err := g.githubClient.Repositories.Get(ctx, owner, repository)
+// repositories returns the configured repositories followed by the ones
+// discovered in the organizations without duplicates
+func (g *GitHub) repositories() []string {
+	repositories := make([]string, 0, len(g.Repositories)+len(g.discovered))
+	seen := make(map[string]bool, len(g.Repositories)+len(g.discovered))
+	for _, name := range append(slices.Clone(g.Repositories), g.discovered...) {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		repositories = append(repositories, name)
+	}
+	return repositories
+}
+
+// discoverRepositories lists the repositories of all organizations except
+// the excluded ones
+func (g *GitHub) discoverRepositories(ctx context.Context) ([]string, error) {
+	var repositories []string
+	for _, org := range g.Organizations {
+		options := &github.RepositoryListByOrgOptions{
+			ListOptions: github.ListOptions{PerPage: 100},
+		}
+		for {
+			repos, response, err := g.githubClient.Repositories.ListByOrg(ctx, org, options)
 			g.handleRateLimit(response, err)
 			if err != nil {
-				acc.AddError(err)
-				return
+				return nil, fmt.Errorf("listing repositories of organization %q failed: %w", org, err)
 			}
-
-			now := time.Now()
-			tags := getTags(repositoryInfo)
-			fields := getFields(repositoryInfo)
-
-			for _, field := range g.AdditionalFields {
-				switch field {
-				case "pull-requests":
-					// Pull request properties
-					addFields, err := g.getPullRequestFields(ctx, owner, repository)
-					if err != nil {
-						acc.AddError(err)
-						continue
-					}
-
-					for k, v := range addFields {
-						fields[k] = v
-					}
-				default:
-					acc.AddError(fmt.Errorf("unknown additional field %q", field))
+			for _, repo := range repos {
+				name := repo.GetFullName()
+				if g.excludeFilter != nil && g.excludeFilter.Match(name) {
 					continue
 				}
+				repositories = append(repositories, name)
+			}
+			if response.NextPage == 0 {
+				break
 			}
+			options.Page = response.NextPage
+		}
+	}
+	return repositories, nil
+}
 
-			acc.AddFields("github_repository", fields, tags, now)
-		}(repository, acc)
+func (g *GitHub) gatherRepository(ctx context.Context, repositoryName string, acc telegraf.Accumulator) {
+	owner, repository, err := splitRepositoryName(repositoryName)
+	if err != nil {
+		acc.AddError(err)
+		return
 	}
 
-	wg.Wait()
-	return nil
+	repositoryInfo, response, err := g.githubClient.Repositories.Get(ctx, owner, repository)
+	g.handleRateLimit(response, err)
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+
+	now := time.Now()
+	tags := getTags(repositoryInfo)
+	fields := getFields(repositoryInfo)
+
+	// Additional fields are less important than the repository information
+	// so skip them if the remaining API calls are running low. The rate
+	// information is missing, i.e. zero, if the rate-limit headers are not
+	// present e.g. on GitHub Enterprise with rate-limiting disabled.
+	deferAdditional := response.Rate.Limit > 0 && response.Rate.Remaining <= g.RateLimitThreshold
+
+	for _, field := range g.AdditionalFields {
+		if deferAdditional {
+			g.rateLimitDeferred.Incr(1)
+			continue
+		}
+
+		var addFields map[string]interface{}
+		switch field {
+		case "pull-requests":
+			// Pull request properties
+			addFields, err = g.getPullRequestFields(ctx, owner, repository)
+		case "traffic":
+			// Views and clones of the last 14 days
+			addFields, err = g.getTrafficFields(ctx, owner, repository)
+		}
+		var rlErr *github.RateLimitError
+		if errors.As(err, &rlErr) {
+			g.rateLimitDeferred.Incr(1)
+			continue
+		}
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+
+		for k, v := range addFields {
+			fields[k] = v
+		}
+	}
+
+	acc.AddFields("github_repository", fields, tags, now)
 }
 
 func (g *GitHub) createGitHubClient(ctx context.Context) (*github.Client, error) {
@@ -221,10 +327,32 @@ func (g *GitHub) getPullRequestFields(ctx context.Context, owner, repo string) (
 	return fields, nil
 }
 
+func (g *GitHub) getTrafficFields(ctx context.Context, owner, repo string) (map[string]interface{}, error) {
+	views, response, err := g.githubClient.Repositories.ListTrafficViews(ctx, owner, repo, nil)
+	g.handleRateLimit(response, err)
+	if err != nil {
+		return nil, err
+	}
+
+	clones, response, err := g.githubClient.Repositories.ListTrafficClones(ctx, owner, repo, nil)
+	g.handleRateLimit(response, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"views":         views.GetCount(),
+		"unique_views":  views.GetUniques(),
+		"clones":        clones.GetCount(),
+		"unique_clones": clones.GetUniques(),
+	}, nil
+}
+
 func init() {
 	inputs.Add("github", func() telegraf.Input {
 		return &GitHub{
-			HTTPTimeout: config.Duration(time.Second * 5),
+			HTTPTimeout:               config.Duration(time.Second * 5),
+			RepositoryRefreshInterval: config.Duration(time.Hour),
 		}
 	})
 }
@@ -1,11 +1,22 @@
 package github
 
 import (
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	gh "github.com/google/go-github/v32/github"
 	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/selfstat"
+	"github.com/influxdata/telegraf/testutil"
 )
 
 func TestNewGithubClient(t *testing.T) {
@@ -138,3 +149,211 @@ func TestGetFields(t *testing.T) {
 
 	require.Equal(t, getFieldsReturn, correctFieldReturn)
 }
+
+func TestGatherOrganizations(t *testing.T) {
+	tests := []struct {
+		name      string
+		remaining int
+		expected  []telegraf.Metric
+		deferred  int64
+	}{
+		{
+			name:      "additional fields",
+			remaining: 4000,
+			expected: []telegraf.Metric{
+				repositoryMetric("telegraf", map[string]interface{}{
+					"views":         10,
+					"unique_views":  2,
+					"clones":        5,
+					"unique_clones": 1,
+				}),
+				repositoryMetric("influxdb", map[string]interface{}{
+					"views":         10,
+					"unique_views":  2,
+					"clones":        5,
+					"unique_clones": 1,
+				}),
+			},
+		},
+		{
+			name:      "deferred additional fields",
+			remaining: 10,
+			expected: []telegraf.Metric{
+				repositoryMetric("telegraf", nil),
+				repositoryMetric("influxdb", nil),
+			},
+			deferred: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var discoveries, trafficRequests atomic.Int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v3/orgs/influxdata/repos", func(w http.ResponseWriter, r *http.Request) {
+				discoveries.Add(1)
+				w.Header().Set("X-RateLimit-Limit", "5000")
+				w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(tt.remaining))
+				if r.URL.Query().Get("page") == "2" {
+					fmt.Fprint(w, `[{"full_name": "influxdata/influxdb"}]`)
+					return
+				}
+				w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2>; rel="next"`, r.Host, r.URL.Path))
+				fmt.Fprint(w, `[{"full_name": "influxdata/telegraf"}, {"full_name": "influxdata/archived-old"}]`)
+			})
+			for _, name := range []string{"telegraf", "influxdb"} {
+				mux.HandleFunc("/api/v3/repos/influxdata/"+name, func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("X-RateLimit-Limit", "5000")
+					w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(tt.remaining))
+					fmt.Fprintf(w, `{"name": %q, "owner": {"login": "influxdata"}, "language": "Go", "stargazers_count": 42}`, name)
+				})
+				mux.HandleFunc("/api/v3/repos/influxdata/"+name+"/traffic/views", func(w http.ResponseWriter, _ *http.Request) {
+					trafficRequests.Add(1)
+					fmt.Fprint(w, `{"count": 10, "uniques": 2}`)
+				})
+				mux.HandleFunc("/api/v3/repos/influxdata/"+name+"/traffic/clones", func(w http.ResponseWriter, _ *http.Request) {
+					trafficRequests.Add(1)
+					fmt.Fprint(w, `{"count": 5, "uniques": 1}`)
+				})
+			}
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			plugin := &GitHub{
+				Organizations:             []string{"influxdata"},
+				RepositoryExclude:         []string{"influxdata/archived-*"},
+				RepositoryRefreshInterval: config.Duration(time.Hour),
+				AdditionalFields:          []string{"traffic"},
+				RateLimitThreshold:        100,
+				EnterpriseBaseURL:         server.URL + "/",
+				HTTPTimeout:               config.Duration(5 * time.Second),
+			}
+			require.NoError(t, plugin.Init())
+
+			// The statistics are shared between the plugin instances
+			deferred := selfstat.Register("github", "rate_limit_deferred", map[string]string{"access_token": "Unauthenticated"})
+			deferredBefore := deferred.Get()
+
+			// The repositories must only be discovered once per refresh interval
+			for range 2 {
+				var acc testutil.Accumulator
+				require.NoError(t, plugin.Gather(&acc))
+				require.Empty(t, acc.Errors)
+				testutil.RequireMetricsEqual(t, tt.expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime(), testutil.SortMetrics())
+			}
+			require.EqualValues(t, 2, discoveries.Load())
+			require.Equal(t, 2*tt.deferred, deferred.Get()-deferredBefore)
+			if tt.deferred > 0 {
+				require.Zero(t, trafficRequests.Load())
+			}
+		})
+	}
+}
+
+func TestGatherOrganizationsDiscoveryFailed(t *testing.T) {
+	var discoveries atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/orgs/influxdata/repos", func(w http.ResponseWriter, _ *http.Request) {
+		// Fail the first discovery and succeed afterwards
+		if discoveries.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `[{"full_name": "influxdata/telegraf"}]`)
+	})
+	mux.HandleFunc("/api/v3/repos/influxdata/telegraf", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"name": "telegraf", "owner": {"login": "influxdata"}, "language": "Go", "stargazers_count": 42}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	plugin := &GitHub{
+		Organizations:             []string{"influxdata"},
+		RepositoryRefreshInterval: config.Duration(time.Hour),
+		EnterpriseBaseURL:         server.URL + "/",
+		HTTPTimeout:               config.Duration(5 * time.Second),
+	}
+	require.NoError(t, plugin.Init())
+
+	// The failed discovery must be retried in the next gather cycle
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Len(t, acc.Errors, 1)
+	require.Empty(t, acc.GetTelegrafMetrics())
+
+	acc.ClearMetrics()
+	acc.Errors = nil
+	require.NoError(t, plugin.Gather(&acc))
+	require.Empty(t, acc.Errors)
+	expected := []telegraf.Metric{repositoryMetric("telegraf", nil)}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+	require.EqualValues(t, 2, discoveries.Load())
+}
+
+func TestGatherWithoutRateLimitHeaders(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/influxdata/telegraf", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"name": "telegraf", "owner": {"login": "influxdata"}, "language": "Go", "stargazers_count": 42}`)
+	})
+	mux.HandleFunc("/api/v3/repos/influxdata/telegraf/traffic/views", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"count": 10, "uniques": 2}`)
+	})
+	mux.HandleFunc("/api/v3/repos/influxdata/telegraf/traffic/clones", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"count": 5, "uniques": 1}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	plugin := &GitHub{
+		Repositories:      []string{"influxdata/telegraf"},
+		AdditionalFields:  []string{"traffic"},
+		EnterpriseBaseURL: server.URL + "/",
+		HTTPTimeout:       config.Duration(5 * time.Second),
+	}
+	require.NoError(t, plugin.Init())
+
+	// Missing rate-limit headers must not defer the additional fields
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Empty(t, acc.Errors)
+	expected := []telegraf.Metric{
+		repositoryMetric("telegraf", map[string]interface{}{
+			"views":         10,
+			"unique_views":  2,
+			"clones":        5,
+			"unique_clones": 1,
+		}),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestInitUnknownAdditionalField(t *testing.T) {
+	plugin := &GitHub{AdditionalFields: []string{"issues"}}
+	require.ErrorContains(t, plugin.Init(), "unknown additional field")
+}
+
+func repositoryMetric(name string, additional map[string]interface{}) telegraf.Metric {
+	fields := map[string]interface{}{
+		"stars":       42,
+		"subscribers": 0,
+		"watchers":    0,
+		"networks":    0,
+		"forks":       0,
+		"open_issues": 0,
+		"size":        0,
+	}
+	for k, v := range additional {
+		fields[k] = v
+	}
+	return metric.New(
+		"github_repository",
+		map[string]string{
+			"owner":    "influxdata",
+			"name":     name,
+			"language": "Go",
+			"license":  "None",
+		},
+		fields,
+		time.Unix(0, 0),
+	)
+}
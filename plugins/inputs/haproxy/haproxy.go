@@ -47,6 +47,7 @@ var (
 type HAProxy struct {
 	Servers        []string `toml:"servers"`
 	KeepFieldNames bool     `toml:"keep_field_names"`
+	RuntimeAPI     bool     `toml:"runtime_api"`
 	Username       string   `toml:"username"`
 	Password       string   `toml:"password"`
 	tls.ClientConfig
@@ -111,6 +112,10 @@ func (h *HAProxy) gatherServerSocket(addr string, acc telegraf.Accumulator) erro
 		address = getSocketAddr(addr)
 	}
 
+	if h.RuntimeAPI {
+		return h.gatherRuntimeAPI(network, address, acc)
+	}
+
 	c, err := net.Dial(network, address)
 	if err != nil {
 		return fmt.Errorf("could not connect to '%s://%s': %w", network, address, err)
@@ -11,9 +11,12 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/testutil"
 )
 
@@ -43,6 +46,44 @@ func (s statServer) serverSocket(l net.Listener) {
 	}
 }
 
+type runtimeServer struct {
+	version string
+}
+
+func (s runtimeServer) serverSocket(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+
+		go func(c net.Conn) {
+			defer c.Close()
+
+			buf := make([]byte, 1024)
+			n, err := c.Read(buf)
+			if err != nil {
+				return
+			}
+
+			var filename string
+			switch string(buf[:n]) {
+			case "show stat typed\n":
+				filename = "stat_typed.txt"
+			case "show servers state\n":
+				filename = "servers_state.txt"
+			default:
+				return
+			}
+			data, err := os.ReadFile(filepath.Join("testdata", "runtime_"+s.version, filename))
+			if err != nil {
+				return
+			}
+			c.Write(data) //nolint:errcheck // we return anyway
+		}(conn)
+	}
+}
+
 func TestHaproxyGeneratesMetricsWithAuthentication(t *testing.T) {
 	// We create a fake server to return test data
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -215,6 +256,176 @@ func TestHaproxyGeneratesMetricsUsingTcp(t *testing.T) {
 	require.NoError(t, r.Gather(&acc))
 }
 
+func TestHaproxyRuntimeAPI(t *testing.T) {
+	frontend := map[string]interface{}{
+		"scur":   uint64(3),
+		"stot":   uint64(1024),
+		"bin":    uint64(52428),
+		"status": "OPEN",
+		"pid":    uint64(1),
+		"iid":    uint64(2),
+		"sid":    uint64(0),
+		"mode":   "http",
+	}
+	backend := map[string]interface{}{
+		"scur":           uint64(2),
+		"status":         "UP",
+		"weight":         uint64(2),
+		"active_servers": uint64(1),
+		"backup_servers": uint64(0),
+		"lastchg":        uint64(120),
+		"pid":            uint64(1),
+		"iid":            uint64(3),
+		"sid":            uint64(0),
+		"lastsess":       int64(-1),
+		"mode":           "http",
+		"algo":           "roundrobin",
+	}
+	app1 := map[string]interface{}{
+		"scur":              uint64(2),
+		"status":            "UP",
+		"weight":            uint64(1),
+		"active_servers":    uint64(1),
+		"lastchg":           uint64(120),
+		"pid":               uint64(1),
+		"iid":               uint64(3),
+		"sid":               uint64(1),
+		"check_status":      "L7OK",
+		"check_code":        uint64(200),
+		"check_duration":    uint64(1),
+		"lastsess":          int64(4),
+		"last_chk":          "HTTP status check returned code <3C>200<3E>",
+		"agent_status":      "L7OK",
+		"addr":              "10.0.0.1:8080",
+		"mode":              "http",
+		"operational_state": "running",
+		"user_weight":       int64(1),
+		"initial_weight":    int64(1),
+		"last_state_change": int64(120),
+	}
+	app2 := map[string]interface{}{
+		"scur":              uint64(0),
+		"status":            "MAINT",
+		"weight":            uint64(1),
+		"active_servers":    uint64(1),
+		"lastchg":           uint64(30),
+		"pid":               uint64(1),
+		"iid":               uint64(3),
+		"sid":               uint64(2),
+		"check_status":      "L4CON",
+		"check_code":        uint64(0),
+		"lastsess":          int64(-1),
+		"addr":              "10.0.0.2:8080",
+		"mode":              "http",
+		"operational_state": "stopped",
+		"user_weight":       int64(1),
+		"initial_weight":    int64(1),
+		"last_state_change": int64(30),
+	}
+
+	tests := []struct {
+		version string
+		added   map[string]map[string]interface{}
+	}{
+		{
+			version: "2.2",
+		},
+		{
+			version: "2.9",
+			added: map[string]map[string]interface{}{
+				"BACKEND": {"uweight": uint64(2)},
+				"app1":    {"uweight": uint64(1), "agg_server_status": uint64(2), "h1_open_streams": uint64(7)},
+				"app2":    {"uweight": uint64(1), "agg_server_status": uint64(2), "h1_open_streams": uint64(7)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			sockname := filepath.Join(t.TempDir(), "haproxy.sock")
+			sock, err := net.Listen("unix", sockname)
+			require.NoError(t, err)
+			defer sock.Close()
+
+			s := runtimeServer{version: tt.version}
+			go s.serverSocket(sock)
+
+			fields := func(service string, base map[string]interface{}) map[string]interface{} {
+				f := make(map[string]interface{}, len(base))
+				for k, v := range base {
+					f[k] = v
+				}
+				for k, v := range tt.added[service] {
+					f[k] = v
+				}
+				return f
+			}
+			expected := []telegraf.Metric{
+				metric.New("haproxy", map[string]string{
+					"server": sockname,
+					"proxy":  "www",
+					"sv":     "FRONTEND",
+					"type":   "frontend",
+				}, fields("FRONTEND", frontend), time.Unix(0, 0)),
+				metric.New("haproxy", map[string]string{
+					"server": sockname,
+					"proxy":  "app",
+					"sv":     "BACKEND",
+					"type":   "backend",
+				}, fields("BACKEND", backend), time.Unix(0, 0)),
+				metric.New("haproxy", map[string]string{
+					"server":      sockname,
+					"proxy":       "app",
+					"sv":          "app1",
+					"type":        "server",
+					"admin_state": "ready",
+				}, fields("app1", app1), time.Unix(0, 0)),
+				metric.New("haproxy", map[string]string{
+					"server":      sockname,
+					"proxy":       "app",
+					"sv":          "app2",
+					"type":        "server",
+					"admin_state": "maint",
+				}, fields("app2", app2), time.Unix(0, 0)),
+			}
+
+			r := &HAProxy{
+				Servers:    []string{"socket:" + sockname},
+				RuntimeAPI: true,
+			}
+
+			var acc testutil.Accumulator
+			require.NoError(t, r.Gather(&acc))
+			require.Empty(t, acc.Errors)
+			testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime(), testutil.SortMetrics())
+		})
+	}
+}
+
+func TestParseServersStateAdminState(t *testing.T) {
+	tests := []struct {
+		state    string
+		expected string
+	}{
+		{state: "0", expected: "ready"},
+		{state: "1", expected: "maint"},
+		{state: "8", expected: "drain"},
+		{state: "16", expected: "drain"},
+		{state: "32", expected: "maint"},
+		{state: "9", expected: "maint"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			input := "1\n# be_id be_name srv_id srv_name srv_op_state srv_admin_state\n3 app 1 app1 2 " + tt.state + "\n"
+			states, err := parseServersState(strings.NewReader(input))
+			require.NoError(t, err)
+			require.Len(t, states, 1)
+			require.Equal(t, tt.expected, states[serverKey{"app", "app1"}].adminState)
+		})
+	}
+}
+
 // When not passing server config, we default to localhost
 // We just want to make sure we did request stat from localhost
 func TestHaproxyDefaultGetFromLocalhost(t *testing.T) {
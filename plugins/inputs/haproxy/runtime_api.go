@@ -0,0 +1,285 @@
+package haproxy
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// Typed output format: https://docs.haproxy.org/2.9/management.html#9.3-show%20stat
+
+var objectTypeNames = map[string]string{
+	"F": "frontend",
+	"B": "backend",
+	"S": "server",
+	"L": "listener",
+}
+
+// Operational states of servers as reported in the "srv_op_state" column
+var operationalStateNames = []string{"stopped", "starting", "running", "stopping"}
+
+// Numeric columns of "show servers state" added as fields
+var serverStateFields = map[string]string{
+	"srv_uweight":                "user_weight",
+	"srv_iweight":                "initial_weight",
+	"srv_time_since_last_change": "last_state_change",
+}
+
+// Administrative state flags of servers as reported in the "srv_admin_state"
+// column, see SRV_ADMF_* in HAProxy's include/haproxy/server-t.h
+const (
+	adminStateMaint = 0x01 | 0x02 | 0x04 | 0x20 | 0x40
+	adminStateDrain = 0x08 | 0x10
+)
+
+// typedObject contains the fields of a proxy, server or listener reported
+// by the typed statistics output
+type typedObject struct {
+	fields map[string]interface{}
+	tags   map[string]string
+}
+
+// serverKey identifies a server by the names of its backend and itself
+type serverKey struct {
+	backend, service string
+}
+
+// serverState contains the details reported by "show servers state"
+type serverState struct {
+	adminState string
+	fields     map[string]interface{}
+}
+
+func (h *HAProxy) gatherRuntimeAPI(network, address string, acc telegraf.Accumulator) error {
+	now := time.Now()
+
+	stats, err := runtimeCommand(network, address, "show stat typed")
+	if err != nil {
+		return err
+	}
+	objects, err := h.parseTypedStats(bytes.NewReader(stats), address)
+	if err != nil {
+		return fmt.Errorf("unable to parse typed stats from '%s://%s': %w", network, address, err)
+	}
+
+	buf, err := runtimeCommand(network, address, "show servers state")
+	if err != nil {
+		return err
+	}
+	states, err := parseServersState(bytes.NewReader(buf))
+	if err != nil {
+		return fmt.Errorf("unable to parse servers state from '%s://%s': %w", network, address, err)
+	}
+
+	proxyTag, serviceTag := "pxname", "svname"
+	if !h.KeepFieldNames {
+		proxyTag, serviceTag = fieldRenames[proxyTag], fieldRenames[serviceTag]
+	}
+	for _, obj := range objects {
+		if obj.tags["type"] == "server" {
+			if state, found := states[serverKey{obj.tags[proxyTag], obj.tags[serviceTag]}]; found {
+				obj.tags["admin_state"] = state.adminState
+				for k, v := range state.fields {
+					obj.fields[k] = v
+				}
+			}
+		}
+		acc.AddFields("haproxy", obj.fields, obj.tags, now)
+	}
+
+	return nil
+}
+
+// runtimeCommand sends the command to the runtime API and returns the
+// response read until the connection is closed by HAProxy
+func runtimeCommand(network, address, command string) ([]byte, error) {
+	c, err := net.Dial(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to '%s://%s': %w", network, address, err)
+	}
+	defer c.Close()
+
+	if _, err := c.Write([]byte(command + "\n")); err != nil {
+		return nil, fmt.Errorf("could not write to socket '%s://%s': %w", network, address, err)
+	}
+
+	buf, err := io.ReadAll(c)
+	if err != nil {
+		return nil, fmt.Errorf("could not read from socket '%s://%s': %w", network, address, err)
+	}
+	return buf, nil
+}
+
+// parseTypedStats parses the output of "show stat typed" consisting of lines
+// in the form "<type>.<proxy id>.<service id>.<position>.<name>.<process>:<tags>:<type>:<value>".
+// The fields are identified by name as the available fields and their
+// positions differ between HAProxy versions.
+func (h *HAProxy) parseTypedStats(r io.Reader, host string) ([]*typedObject, error) {
+	var objects []*typedObject
+	index := make(map[string]*typedObject)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 4)
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("invalid line %q", line)
+		}
+		id := strings.Split(parts[0], ".")
+		if len(id) < 6 {
+			return nil, fmt.Errorf("invalid field identifier %q", parts[0])
+		}
+		key := strings.Join(id[:len(id)-3], ".")
+		colName := id[len(id)-2]
+		kind, v := parts[2], parts[3]
+
+		obj, found := index[key]
+		if !found {
+			typeName, ok := objectTypeNames[id[0]]
+			if !ok {
+				return nil, fmt.Errorf("received unknown object type %q", id[0])
+			}
+			obj = &typedObject{
+				fields: make(map[string]interface{}),
+				tags: map[string]string{
+					"server": host,
+					"type":   typeName,
+				},
+			}
+			index[key] = obj
+			objects = append(objects, obj)
+		}
+
+		if v == "" {
+			continue
+		}
+
+		fieldName := colName
+		if !h.KeepFieldNames {
+			if fieldRename, ok := fieldRenames[colName]; ok {
+				fieldName = fieldRename
+			}
+		}
+
+		switch colName {
+		case "pxname", "svname":
+			obj.tags[fieldName] = v
+			continue
+		case "type", "check_desc", "agent_desc":
+			// do nothing. The type is taken from the object type and the
+			// descriptions are just a more verbose form of the status fields
+			continue
+		}
+
+		switch kind {
+		case "str":
+			obj.fields[fieldName] = v
+		case "u32", "u64":
+			vi, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse %q value %q: %w", colName, v, err)
+			}
+			obj.fields[fieldName] = vi
+		case "s32", "s64":
+			vi, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse %q value %q: %w", colName, v, err)
+			}
+			obj.fields[fieldName] = vi
+		case "flt":
+			vf, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse %q value %q: %w", colName, v, err)
+			}
+			obj.fields[fieldName] = vf
+		}
+	}
+
+	return objects, scanner.Err()
+}
+
+// parseServersState parses the output of "show servers state" using the
+// column names of the header line as HAProxy adds columns in newer versions
+func parseServersState(r io.Reader) (map[serverKey]*serverState, error) {
+	var headers []string
+	states := make(map[serverKey]*serverState)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			headers = strings.Fields(strings.TrimPrefix(line, "#"))
+			continue
+		}
+		if headers == nil {
+			// The output starts with the version of the format
+			continue
+		}
+
+		row := strings.Fields(line)
+		if len(row) != len(headers) {
+			return nil, fmt.Errorf("number of columns does not match number of headers. headers=%d columns=%d", len(headers), len(row))
+		}
+
+		var key serverKey
+		state := &serverState{fields: make(map[string]interface{})}
+		for i, v := range row {
+			switch headers[i] {
+			case "be_name":
+				key.backend = v
+			case "srv_name":
+				key.service = v
+			case "srv_op_state":
+				vi, err := strconv.ParseUint(v, 10, 64)
+				if err != nil || vi >= uint64(len(operationalStateNames)) {
+					return nil, fmt.Errorf("received unknown operational state %q", v)
+				}
+				state.fields["operational_state"] = operationalStateNames[vi]
+			case "srv_admin_state":
+				vi, err := strconv.ParseUint(v, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("unable to parse administrative state %q: %w", v, err)
+				}
+				switch {
+				case vi&adminStateMaint != 0:
+					state.adminState = "maint"
+				case vi&adminStateDrain != 0:
+					state.adminState = "drain"
+				default:
+					state.adminState = "ready"
+				}
+			default:
+				name, ok := serverStateFields[headers[i]]
+				if !ok {
+					continue
+				}
+				vi, err := strconv.ParseInt(v, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("unable to parse %q value %q: %w", headers[i], v, err)
+				}
+				state.fields[name] = vi
+			}
+		}
+		if key.backend == "" || key.service == "" {
+			return nil, errors.New("missing backend or server name")
+		}
+		states[key] = state
+	}
+
+	return states, scanner.Err()
+}
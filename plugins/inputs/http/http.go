@@ -185,6 +185,12 @@ func (h *HTTP) gatherURL(acc telegraf.Accumulator, url string) error {
 	}
 
 	if !responseHasSuccessCode {
+		// Make the secret-stores fetch rotated credentials on the next request
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			h.Token.Invalidate()
+			h.Username.Invalidate()
+			h.Password.Invalidate()
+		}
 		return fmt.Errorf("received status code %d (%s), expected any value out of %v",
 			resp.StatusCode,
 			http.StatusText(resp.StatusCode),
@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"strconv"
 	"time"
 
+	"github.com/robfig/cron/v3"
 	"github.com/showwin/speedtest-go/speedtest"
 	"github.com/showwin/speedtest-go/speedtest/transport"
 
@@ -30,19 +32,38 @@ const (
 
 // InternetSpeed is used to store configuration values.
 type InternetSpeed struct {
+	ServerIDs          []int    `toml:"server_ids"`
 	ServerIDInclude    []string `toml:"server_id_include"`
 	ServerIDExclude    []string `toml:"server_id_exclude"`
 	EnableFileDownload bool     `toml:"enable_file_download" deprecated:"1.25.0;1.35.0;use 'memory_saving_mode' instead"`
 	MemorySavingMode   bool     `toml:"memory_saving_mode"`
 	Cache              bool     `toml:"cache"`
+	CacheServers       bool     `toml:"cache_servers"`
 	Connections        int      `toml:"connections"`
 	TestMode           string   `toml:"test_mode"`
+	TestSchedule       string   `toml:"test_schedule"`
+	EmitStale          bool     `toml:"emit_stale"`
 
 	Log telegraf.Logger `toml:"-"`
 
 	server       *speedtest.Server // The main(best) server
 	servers      speedtest.Servers // Auxiliary servers
+	candidates   speedtest.Servers // Servers to test in order of preference
 	serverFilter filter.Filter
+	schedule     cron.Schedule
+	next         time.Time // Next scheduled test, zero if none
+	last         *result
+
+	fetchServers    func() (speedtest.Servers, error)
+	fetchServerByID func(id string) (*speedtest.Server, error)
+	runTest         func(server *speedtest.Server) (map[string]any, error)
+}
+
+// result holds the fields and tags of a successful test for re-emitting it
+// outside of the test schedule
+type result struct {
+	fields map[string]any
+	tags   map[string]string
 }
 
 func (*InternetSpeed) SampleConfig() string {
@@ -66,22 +87,142 @@ func (is *InternetSpeed) Init() error {
 		return fmt.Errorf("error compiling server ID filters: %w", err)
 	}
 
+	if is.TestSchedule != "" {
+		is.schedule, err = cron.ParseStandard(is.TestSchedule)
+		if err != nil {
+			return fmt.Errorf("invalid test schedule %q: %w", is.TestSchedule, err)
+		}
+		// Include the current minute if it matches the schedule
+		is.next = is.schedule.Next(time.Now().Truncate(time.Minute).Add(-time.Second))
+	}
+
+	proto := speedtest.HTTP
+	if os.Getegid() <= 0 {
+		proto = speedtest.ICMP
+	}
+
+	client := speedtest.New(speedtest.WithUserConfig(&speedtest.UserConfig{
+		UserAgent:  internal.ProductToken(),
+		PingMode:   proto,
+		SavingMode: is.MemorySavingMode,
+	}))
+	if is.Connections > 0 {
+		client.SetNThread(is.Connections)
+	}
+	is.fetchServers = client.FetchServers
+	is.fetchServerByID = client.FetchServerByID
+	is.runTest = is.speedTest
+
 	return nil
 }
 
 func (is *InternetSpeed) Gather(acc telegraf.Accumulator) error {
+	// Skip the test outside of the configured schedule and re-emit the last
+	// result if requested
+	if is.schedule != nil && !is.due(time.Now()) {
+		if is.EmitStale && is.last != nil {
+			tags := make(map[string]string, len(is.last.tags)+1)
+			for k, v := range is.last.tags {
+				tags[k] = v
+			}
+			tags["stale"] = "true"
+			acc.AddFields(measurement, is.last.fields, tags)
+		}
+		return nil
+	}
+
+	if err := is.selectServers(); err != nil {
+		return err
+	}
+
+	// Try the candidates in order and fail over to the next one on error
+	var errs []error
+	for _, server := range is.candidates {
+		fields, err := is.runTest(server)
+		if err != nil {
+			is.Log.Debugf("testing server %s failed: %v", server.ID, err)
+			errs = append(errs, fmt.Errorf("server %s: %w", server.ID, err))
+			continue
+		}
+		is.server = server
+
+		tags := map[string]string{
+			"server_id": server.ID,
+			"source":    server.Host,
+			"test_mode": is.TestMode,
+		}
+		is.last = &result{fields: fields, tags: tags}
+		acc.AddFields(measurement, fields, tags)
+		return nil
+	}
+
+	// Force a new server selection on the next run if all candidates failed
+	is.server = nil
+	return errors.Join(errs...)
+}
+
+// selectServers determines the servers to test. Configured server IDs are
+// used in the given order, otherwise the closest server is selected.
+func (is *InternetSpeed) selectServers() error {
+	cacheList := is.Cache || is.CacheServers
+
+	if len(is.ServerIDs) > 0 {
+		if cacheList && len(is.candidates) > 0 {
+			return nil
+		}
+		return is.fetchConfiguredServers()
+	}
+
 	// If not caching, go find the closest server each time.
 	// We will find the best server as the main server. And
 	// the remaining servers will be auxiliary candidates.
-	if !is.Cache || is.server == nil {
-		if err := is.findClosestServer(); err != nil {
-			return fmt.Errorf("unable to find closest server: %w", err)
+	if is.Cache && is.server != nil {
+		return nil
+	}
+	if !cacheList || len(is.servers) == 0 {
+		servers, err := is.fetchServers()
+		if err != nil {
+			return fmt.Errorf("unable to find closest server: fetching server list failed: %w", err)
 		}
+		is.servers = servers
 	}
+	if err := is.findClosestServer(); err != nil {
+		return fmt.Errorf("unable to find closest server: %w", err)
+	}
+	is.candidates = speedtest.Servers{is.server}
 
-	err := is.server.PingTest(nil)
+	return nil
+}
+
+// fetchConfiguredServers looks up the configured server IDs and keeps the
+// found servers in the configured order
+func (is *InternetSpeed) fetchConfiguredServers() error {
+	is.candidates = make(speedtest.Servers, 0, len(is.ServerIDs))
+	for _, id := range is.ServerIDs {
+		server, err := is.fetchServerByID(strconv.Itoa(id))
+		if err != nil {
+			is.Log.Warnf("looking up server %d failed: %v", id, err)
+			continue
+		}
+		is.candidates = append(is.candidates, server)
+	}
+	if len(is.candidates) == 0 {
+		return errors.New("none of the configured servers found")
+	}
+	is.servers = is.candidates
+
+	return nil
+}
+
+// speedTest runs the ping, download, upload and packet loss tests against the
+// given server and returns the resulting fields
+func (is *InternetSpeed) speedTest(server *speedtest.Server) (map[string]any, error) {
+	// Recycle the history of each test to prevent data backlog.
+	defer server.Context.Reset()
+
+	err := server.PingTest(nil)
 	if err != nil {
-		return fmt.Errorf("ping test failed: %w", err)
+		return nil, fmt.Errorf("ping test failed: %w", err)
 	}
 
 	analyzer := speedtest.NewPacketLossAnalyzer(&speedtest.PacketLossAnalyzerOptions{
@@ -92,13 +233,13 @@ func (is *InternetSpeed) Gather(acc telegraf.Accumulator) error {
 	var pLoss *transport.PLoss
 
 	if is.TestMode == testModeMulti {
-		err = is.server.MultiDownloadTestContext(context.Background(), is.servers)
+		err = server.MultiDownloadTestContext(context.Background(), is.servers)
 		if err != nil {
-			return fmt.Errorf("download test failed: %w", err)
+			return nil, fmt.Errorf("download test failed: %w", err)
 		}
-		err = is.server.MultiUploadTestContext(context.Background(), is.servers)
+		err = server.MultiUploadTestContext(context.Background(), is.servers)
 		if err != nil {
-			return fmt.Errorf("upload test failed: %w", err)
+			return nil, fmt.Errorf("upload test failed: %w", err)
 		}
 		// Not all servers are applicable for packet loss testing.
 		// If err != nil, we skip it and just report a warning.
@@ -107,17 +248,17 @@ func (is *InternetSpeed) Gather(acc telegraf.Accumulator) error {
 			is.Log.Warnf("packet loss test failed: %s", err)
 		}
 	} else {
-		err = is.server.DownloadTest()
+		err = server.DownloadTest()
 		if err != nil {
-			return fmt.Errorf("download test failed: %w", err)
+			return nil, fmt.Errorf("download test failed: %w", err)
 		}
-		err = is.server.UploadTest()
+		err = server.UploadTest()
 		if err != nil {
-			return fmt.Errorf("upload test failed: %w", err)
+			return nil, fmt.Errorf("upload test failed: %w", err)
 		}
 		// Not all servers are applicable for packet loss testing.
 		// If err != nil, we skip it and just report a warning.
-		err = analyzer.Run(is.server.Host, func(pl *transport.PLoss) {
+		err = analyzer.Run(server.Host, func(pl *transport.PLoss) {
 			pLoss = pl
 		})
 		if err != nil {
@@ -131,45 +272,17 @@ func (is *InternetSpeed) Gather(acc telegraf.Accumulator) error {
 	}
 
 	fields := map[string]any{
-		"download":    is.server.DLSpeed.Mbps(),
-		"upload":      is.server.ULSpeed.Mbps(),
-		"latency":     timeDurationMillisecondToFloat64(is.server.Latency),
-		"jitter":      timeDurationMillisecondToFloat64(is.server.Jitter),
+		"download":    server.DLSpeed.Mbps(),
+		"upload":      server.ULSpeed.Mbps(),
+		"latency":     timeDurationMillisecondToFloat64(server.Latency),
+		"jitter":      timeDurationMillisecondToFloat64(server.Jitter),
 		"packet_loss": packetLoss,
-		"location":    is.server.Name,
-	}
-	tags := map[string]string{
-		"server_id": is.server.ID,
-		"source":    is.server.Host,
-		"test_mode": is.TestMode,
+		"location":    server.Name,
 	}
-	// Recycle the history of each test to prevent data backlog.
-	is.server.Context.Reset()
-	acc.AddFields(measurement, fields, tags)
-	return nil
+	return fields, nil
 }
 
 func (is *InternetSpeed) findClosestServer() error {
-	proto := speedtest.HTTP
-	if os.Getegid() <= 0 {
-		proto = speedtest.ICMP
-	}
-
-	client := speedtest.New(speedtest.WithUserConfig(&speedtest.UserConfig{
-		UserAgent:  internal.ProductToken(),
-		PingMode:   proto,
-		SavingMode: is.MemorySavingMode,
-	}))
-	if is.Connections > 0 {
-		client.SetNThread(is.Connections)
-	}
-
-	var err error
-	is.servers, err = client.FetchServers()
-	if err != nil {
-		return fmt.Errorf("fetching server list failed: %w", err)
-	}
-
 	if len(is.servers) < 1 {
 		return errors.New("no servers found")
 	}
@@ -200,6 +313,16 @@ func (is *InternetSpeed) findClosestServer() error {
 	return errors.New("no server set: filter excluded all servers or no available server found")
 }
 
+// due checks if a test is due at the given time, i.e. if the next scheduled
+// time has been reached, and advances the next scheduled time in this case
+func (is *InternetSpeed) due(t time.Time) bool {
+	if is.next.IsZero() || t.Before(is.next) {
+		return false
+	}
+	is.next = is.schedule.Next(t)
+	return true
+}
+
 func timeDurationMillisecondToFloat64(d time.Duration) float64 {
 	return float64(d) / float64(time.Millisecond)
 }
@@ -1,10 +1,17 @@
 package internet_speed
 
 import (
+	"errors"
 	"testing"
+	"time"
 
-	"github.com/influxdata/telegraf/testutil"
+	"github.com/robfig/cron/v3"
+	"github.com/showwin/speedtest-go/speedtest"
 	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
 )
 
 func TestGathering(t *testing.T) {
@@ -38,3 +45,174 @@ func TestDataGen(t *testing.T) {
 	require.True(t, ok)
 	acc.AssertContainsTaggedFields(t, "internet_speed", metric.Fields, metric.Tags)
 }
+
+func TestServerIDsFailover(t *testing.T) {
+	plugin := &InternetSpeed{
+		ServerIDs:    []int{1234, 2345, 5678},
+		CacheServers: true,
+		Log:          testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var lookups, tested []string
+	plugin.fetchServerByID = func(id string) (*speedtest.Server, error) {
+		lookups = append(lookups, id)
+		if id == "2345" {
+			return nil, speedtest.ErrServerNotFound
+		}
+		return &speedtest.Server{ID: id, Host: "speedtest" + id + ".example.com:8080", Name: "Somewhere"}, nil
+	}
+	plugin.fetchServers = func() (speedtest.Servers, error) {
+		return nil, errors.New("server list must not be fetched")
+	}
+	plugin.runTest = func(server *speedtest.Server) (map[string]any, error) {
+		tested = append(tested, server.ID)
+		if server.ID == "1234" {
+			return nil, errors.New("download test failed")
+		}
+		return map[string]any{"download": 100.0}, nil
+	}
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"internet_speed",
+			map[string]string{
+				"server_id": "5678",
+				"source":    "speedtest5678.example.com:8080",
+				"test_mode": "single",
+			},
+			map[string]any{"download": 100.0},
+			time.Unix(0, 0),
+		),
+	}
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+	require.Equal(t, []string{"1234", "2345", "5678"}, lookups)
+	require.Equal(t, []string{"1234", "5678"}, tested)
+
+	// The server list is cached so the lookup must not happen again
+	acc.ClearMetrics()
+	require.NoError(t, plugin.Gather(&acc))
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+	require.Len(t, lookups, 3)
+	require.Equal(t, []string{"1234", "5678", "1234", "5678"}, tested)
+}
+
+func TestServerIDsAllFailing(t *testing.T) {
+	plugin := &InternetSpeed{
+		ServerIDs: []int{1234, 5678},
+		Log:       testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	plugin.fetchServerByID = func(id string) (*speedtest.Server, error) {
+		return &speedtest.Server{ID: id}, nil
+	}
+	plugin.runTest = func(*speedtest.Server) (map[string]any, error) {
+		return nil, errors.New("ping test failed")
+	}
+
+	var acc testutil.Accumulator
+	err := plugin.Gather(&acc)
+	require.ErrorContains(t, err, "server 1234: ping test failed")
+	require.ErrorContains(t, err, "server 5678: ping test failed")
+	require.Empty(t, acc.GetTelegrafMetrics())
+}
+
+func TestTestSchedule(t *testing.T) {
+	schedule, err := cron.ParseStandard("0 1-4 * * *")
+	require.NoError(t, err)
+
+	// Gather hourly at half past the hour, i.e. not aligned to the schedule
+	plugin := &InternetSpeed{
+		schedule: schedule,
+		next:     time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		time     time.Time
+		expected bool
+	}{
+		{time: time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC)},
+		{time: time.Date(2024, 3, 1, 1, 30, 0, 0, time.UTC), expected: true},
+		{time: time.Date(2024, 3, 1, 2, 30, 0, 0, time.UTC), expected: true},
+		{time: time.Date(2024, 3, 1, 3, 30, 0, 0, time.UTC), expected: true},
+		{time: time.Date(2024, 3, 1, 4, 30, 0, 0, time.UTC), expected: true},
+		{time: time.Date(2024, 3, 1, 5, 30, 0, 0, time.UTC)},
+		{time: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)},
+		// Multiple scheduled times passing between gatherings run one test
+		{time: time.Date(2024, 3, 2, 3, 0, 0, 0, time.UTC), expected: true},
+		{time: time.Date(2024, 3, 2, 3, 30, 0, 0, time.UTC)},
+		{time: time.Date(2024, 3, 2, 4, 0, 0, 0, time.UTC), expected: true},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, plugin.due(tt.time), tt.time)
+	}
+}
+
+func TestTestScheduleCurrentMinute(t *testing.T) {
+	plugin := &InternetSpeed{
+		TestSchedule: "* * * * *",
+		Log:          testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+	require.True(t, plugin.due(time.Now()))
+	require.False(t, plugin.due(time.Now()))
+}
+
+func TestTestScheduleInvalid(t *testing.T) {
+	plugin := &InternetSpeed{
+		TestSchedule: "every night",
+		Log:          testutil.Logger{},
+	}
+	require.ErrorContains(t, plugin.Init(), "invalid test schedule")
+}
+
+func TestEmitStale(t *testing.T) {
+	plugin := &InternetSpeed{
+		ServerIDs: []int{1234},
+		EmitStale: true,
+		Log:       testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var tests int
+	plugin.fetchServerByID = func(id string) (*speedtest.Server, error) {
+		return &speedtest.Server{ID: id, Host: "speedtest.example.com:8080"}, nil
+	}
+	plugin.runTest = func(*speedtest.Server) (map[string]any, error) {
+		tests++
+		return map[string]any{"download": 100.0}, nil
+	}
+
+	// Nothing to re-emit before the first successful test
+	plugin.schedule, _ = cron.ParseStandard("0 0 30 2 *")
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Empty(t, acc.GetTelegrafMetrics())
+
+	plugin.schedule = nil
+	require.NoError(t, plugin.Gather(&acc))
+
+	plugin.schedule, _ = cron.ParseStandard("0 0 30 2 *")
+	require.NoError(t, plugin.Gather(&acc))
+	require.Equal(t, 1, tests)
+
+	tags := map[string]string{
+		"server_id": "1234",
+		"source":    "speedtest.example.com:8080",
+		"test_mode": "single",
+	}
+	staleTags := map[string]string{
+		"server_id": "1234",
+		"source":    "speedtest.example.com:8080",
+		"test_mode": "single",
+		"stale":     "true",
+	}
+	expected := []telegraf.Metric{
+		metric.New("internet_speed", tags, map[string]any{"download": 100.0}, time.Unix(0, 0)),
+		metric.New("internet_speed", staleTags, map[string]any{"download": 100.0}, time.Unix(0, 0)),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
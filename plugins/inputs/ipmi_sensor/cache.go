@@ -0,0 +1,78 @@
+package ipmi_sensor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+var reCacheNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9.-]`)
+
+// cacheFile returns the path of the SDR cache file for the given connection.
+// The name contains a hash of the connection parameters, except the password,
+// to use separate caches for the same server accessed with different users,
+// interfaces or privilege levels.
+func (m *Ipmi) cacheFile(conn *connection) string {
+	if conn == nil {
+		return filepath.Join(m.CachePath, "ipmi_sdr_local.cache")
+	}
+
+	h := sha256.New()
+	for _, p := range []string{conn.hostname, strconv.Itoa(conn.port), conn.intf, conn.username, conn.privilege} {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	id := hex.EncodeToString(h.Sum(nil))[:16]
+	name := reCacheNameSanitizer.ReplaceAllString(conn.hostname, "_")
+
+	return filepath.Join(m.CachePath, "ipmi_sdr_"+name+"_"+id+".cache")
+}
+
+// updateCache dumps the SDR of the server to the given cache file if the file
+// does not exist or is older than the refresh interval. The cache is always
+// dumped if forced.
+func (m *Ipmi) updateCache(filename string, opts []string, force bool) error {
+	if !force {
+		info, err := os.Stat(filename)
+		if err == nil {
+			interval := time.Duration(m.CacheRefreshInterval)
+			if interval <= 0 || time.Since(info.ModTime()) < interval {
+				return nil
+			}
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("checking cache file failed: %w", err)
+		}
+	}
+
+	// Dump into a temporary file only accessible by the current user and
+	// replace the cache afterwards to never use an incomplete cache
+	tmpfile, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary cache file failed: %w", err)
+	}
+	tmpname := tmpfile.Name()
+	defer os.Remove(tmpname)
+	if err := tmpfile.Close(); err != nil {
+		return fmt.Errorf("closing temporary cache file failed: %w", err)
+	}
+
+	dumpOpts := append(slices.Clone(opts), "sdr", "dump", tmpname)
+	if _, err := m.run(dumpOpts); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpname, 0600); err != nil {
+		return fmt.Errorf("restricting permissions of cache file failed: %w", err)
+	}
+	if err := os.Rename(tmpname, filename); err != nil {
+		return fmt.Errorf("replacing cache file failed: %w", err)
+	}
+	m.Log.Debugf("Updated SDR cache %q", filename)
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package ipmi_sensor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestCacheFile(t *testing.T) {
+	m := &Ipmi{CachePath: "/var/cache/telegraf"}
+
+	user := m.cacheFile(newConnection("USERID:PASSW0RD@lan(192.168.1.1)", "USER", ""))
+	admin := m.cacheFile(newConnection("USERID:PASSW0RD@lan(192.168.1.1)", "ADMINISTRATOR", ""))
+	other := m.cacheFile(newConnection("USERID:PASSW0RD@lan(192.168.1.2)", "USER", ""))
+	password := m.cacheFile(newConnection("USERID:SECRET@lan(192.168.1.1)", "USER", ""))
+
+	require.Equal(t, filepath.Join("/var/cache/telegraf", "ipmi_sdr_local.cache"), m.cacheFile(nil))
+	require.True(t, strings.HasPrefix(filepath.Base(user), "ipmi_sdr_192.168.1.1_"))
+	require.NotEqual(t, user, admin, "privilege levels must use separate caches")
+	require.NotEqual(t, user, other, "servers must use separate caches")
+	require.Equal(t, user, password, "password must not be part of the cache name")
+	require.NotContains(t, user, "PASSW0RD")
+}
+
+func TestGatherCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		age     time.Duration
+		dumped  bool
+	}{
+		{
+			name:   "no cache",
+			dumped: true,
+		},
+		{
+			name:    "valid cache",
+			content: "fresh",
+		},
+		{
+			name:    "expired cache",
+			content: "fresh",
+			age:     time.Hour,
+			dumped:  true,
+		},
+		{
+			name:    "outdated cache",
+			content: "stale",
+			dumped:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Ipmi{
+				Servers:              []string{"USERID:PASSW0RD@lan(192.168.1.1)"},
+				Path:                 "ipmitool",
+				Privilege:            "USER",
+				Timeout:              config.Duration(5 * time.Second),
+				UseCache:             true,
+				CachePath:            t.TempDir(),
+				CacheRefreshInterval: config.Duration(time.Hour),
+				Log:                  testutil.Logger{},
+			}
+			require.NoError(t, m.Init())
+
+			filename := m.cacheFile(newConnection(m.Servers[0], m.Privilege, m.HexKey))
+			modified := time.Now().Add(-tt.age - 30*time.Minute)
+			if tt.content != "" {
+				require.NoError(t, os.WriteFile(filename, []byte(tt.content), 0600))
+				require.NoError(t, os.Chtimes(filename, modified, modified))
+			}
+
+			start := time.Now()
+			execCommand = fakeExecCommandCache
+			defer func() { execCommand = exec.Command }()
+			var acc testutil.Accumulator
+			require.NoError(t, acc.GatherError(m.Gather))
+			require.EqualValues(t, 2, acc.NFields())
+
+			buf, err := os.ReadFile(filename)
+			require.NoError(t, err)
+			require.Equal(t, "fresh", string(buf))
+
+			info, err := os.Stat(filename)
+			require.NoError(t, err)
+			require.Equal(t, tt.dumped, info.ModTime().After(start.Add(-time.Second)))
+			if runtime.GOOS != "windows" {
+				require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+			}
+
+			// Temporary files must be removed
+			entries, err := os.ReadDir(m.CachePath)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+		})
+	}
+}
+
+// fakeExecCommandCache is a helper function that mock
+// the exec.Command call (and call the test binary)
+func fakeExecCommandCache(command string, args ...string) *exec.Cmd {
+	cs := []string{"-test.run=TestCacheHelperProcess", "--", command}
+	cs = append(cs, args...)
+	cmd := exec.Command(os.Args[0], cs...)
+	cmd.Env = []string{"GO_WANT_HELPER_PROCESS=1"}
+	return cmd
+}
+
+// TestCacheHelperProcess isn't a real test. It's used to mock exec.Command
+// writing a valid cache on "sdr dump" and failing if a sensor read uses an
+// outdated cache.
+func TestCacheHelperProcess(_ *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+
+	// Previous arguments are tests stuff, that looks like :
+	// /tmp/go-build970079519/…/_test/integration.test -test.run=TestHelperProcess --
+	args := os.Args[4:]
+
+	if i := slices.Index(args, "dump"); i >= 0 {
+		if err := os.WriteFile(args[i+1], []byte("fresh"), 0640); err != nil {
+			fmt.Fprint(os.Stdout, err)
+			//nolint:revive // error code is important for this "test"
+			os.Exit(1)
+		}
+		//nolint:revive // error code is important for this "test"
+		os.Exit(0)
+	}
+
+	if i := slices.Index(args, "-S"); i >= 0 {
+		buf, err := os.ReadFile(args[i+1])
+		if err != nil || string(buf) != "fresh" {
+			fmt.Fprint(os.Stdout, "invalid cache")
+			//nolint:revive // error code is important for this "test"
+			os.Exit(1)
+		}
+	}
+
+	fmt.Fprint(os.Stdout, "Ambient Temp     | 20 degrees C      | ok\n")
+	//nolint:revive // error code is important for this "test"
+	os.Exit(0)
+}
@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -49,6 +48,8 @@ type Ipmi struct {
 	UseCache      bool            `toml:"use_cache"`
 	CachePath     string          `toml:"cache_path"`
 	Log           telegraf.Logger `toml:"-"`
+
+	CacheRefreshInterval config.Duration `toml:"cache_refresh_interval"`
 }
 
 func (*Ipmi) SampleConfig() string {
@@ -126,49 +127,41 @@ func (m *Ipmi) parse(acc telegraf.Accumulator, server, sensor string) error {
 
 	opts := make([]string, 0)
 	hostname := ""
+	var conn *connection
 	if server != "" {
-		conn := newConnection(server, m.Privilege, m.HexKey)
+		conn = newConnection(server, m.Privilege, m.HexKey)
 		hostname = conn.hostname
 		opts = conn.options()
 	}
 
-	opts = append(opts, command...)
-
-	if m.UseCache {
-		cacheFile := filepath.Join(m.CachePath, server+"_ipmi_cache")
-		_, err := os.Stat(cacheFile)
-		if os.IsNotExist(err) {
-			dumpOpts := opts
-			// init cache file
-			dumpOpts = append(dumpOpts, "dump", cacheFile)
-			name := m.Path
-			if m.UseSudo {
-				// -n - avoid prompting the user for input of any kind
-				dumpOpts = append([]string{"-n", name}, dumpOpts...)
-				name = "sudo"
-			}
-			cmd := execCommand(name, dumpOpts...)
-			out, err := internal.CombinedOutputTimeout(cmd, time.Duration(m.Timeout))
-			if err != nil {
-				return fmt.Errorf("failed to run command %q: %w - %s", strings.Join(sanitizeIPMICmd(cmd.Args), " "), err, string(out))
-			}
+	// The cache only contains the sensor data records
+	connOpts := opts
+	var cacheFile string
+	if m.UseCache && sensor == "sdr" {
+		cacheFile = m.cacheFile(conn)
+		if err := m.updateCache(cacheFile, connOpts, false); err != nil {
+			return err
 		}
 		opts = append(opts, "-S", cacheFile)
 	}
+
+	opts = append(opts, command...)
 	if m.MetricVersion == 2 && sensor == "sdr" {
 		opts = append(opts, "elist")
 	}
-	name := m.Path
-	if m.UseSudo {
-		// -n - avoid prompting the user for input of any kind
-		opts = append([]string{"-n", name}, opts...)
-		name = "sudo"
+	out, err := m.run(opts)
+	if err != nil && cacheFile != "" {
+		// The cache might be outdated, e.g. after replacing hardware, so
+		// refresh it and try again
+		m.Log.Debugf("Reading sensors using cache %q failed, refreshing cache: %v", cacheFile, err)
+		if err := m.updateCache(cacheFile, connOpts, true); err != nil {
+			return err
+		}
+		out, err = m.run(opts)
 	}
-	cmd := execCommand(name, opts...)
-	out, err := internal.CombinedOutputTimeout(cmd, time.Duration(m.Timeout))
 	timestamp := time.Now()
 	if err != nil {
-		return fmt.Errorf("failed to run command %q: %w - %s", strings.Join(sanitizeIPMICmd(cmd.Args), " "), err, string(out))
+		return err
 	}
 
 	switch sensor {
@@ -187,6 +180,22 @@ func (m *Ipmi) parse(acc telegraf.Accumulator, server, sensor string) error {
 	return fmt.Errorf("unknown sensor type %q", sensor)
 }
 
+// run executes ipmitool with the given options
+func (m *Ipmi) run(opts []string) ([]byte, error) {
+	name := m.Path
+	if m.UseSudo {
+		// -n - avoid prompting the user for input of any kind
+		opts = append([]string{"-n", name}, opts...)
+		name = "sudo"
+	}
+	cmd := execCommand(name, opts...)
+	out, err := internal.CombinedOutputTimeout(cmd, time.Duration(m.Timeout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to run command %q: %w - %s", strings.Join(sanitizeIPMICmd(cmd.Args), " "), err, string(out))
+	}
+	return out, nil
+}
+
 func (m *Ipmi) parseChassisPowerStatus(acc telegraf.Accumulator, hostname string, cmdOut []byte, measuredAt time.Time) error {
 	// each line will look something like
 	// Chassis Power is on
@@ -145,6 +145,13 @@ func (c *client) getBuild(ctx context.Context, jr jobRequest, number int64) (b *
 	return b, err
 }
 
+func (c *client) getPipelineRun(ctx context.Context, jr jobRequest, number int64) (r *pipelineRunResponse, err error) {
+	r = new(pipelineRunResponse)
+	url := jr.pipelineRunURL(number)
+	err = c.doGet(ctx, url, r)
+	return r, err
+}
+
 func (c *client) getAllNodes(ctx context.Context) (nodeResp *nodeResponse, err error) {
 	nodeResp = new(nodeResponse)
 	err = c.doGet(ctx, nodePath, nodeResp)
@@ -28,8 +28,12 @@ const (
 	measurementJenkins = "jenkins"
 	measurementNode    = "jenkins_node"
 	measurementJob     = "jenkins_job"
+	measurementStage   = "jenkins_pipeline_stage"
 )
 
+// Class of pipeline jobs providing the stages via the workflow API
+const pipelineJobClass = "org.jenkinsci.plugins.workflow.job.WorkflowJob"
+
 type Jenkins struct {
 	URL      string `toml:"url"`
 	Username string `toml:"username"`
@@ -48,6 +52,8 @@ type Jenkins struct {
 	JobInclude        []string        `toml:"job_include"`
 	jobFilter         filter.Filter
 
+	CollectPipelineStages bool `toml:"collect_pipeline_stages"`
+
 	NodeExclude []string `toml:"node_exclude"`
 	NodeInclude []string `toml:"node_include"`
 	nodeFilter  filter.Filter
@@ -315,6 +321,16 @@ func (j *Jenkins) getJobDetail(jr jobRequest, acc telegraf.Accumulator) error {
 	}
 
 	j.gatherJobBuild(jr, build, acc)
+
+	// only pipeline jobs provide stages, skip the request for all others
+	if !j.CollectPipelineStages || js.Class != pipelineJobClass {
+		return nil
+	}
+	run, err := j.client.getPipelineRun(context.Background(), jr, number)
+	if err != nil {
+		return err
+	}
+	j.gatherPipelineStages(jr, build, run, acc)
 	return nil
 }
 
@@ -361,6 +377,7 @@ type swapSpaceMonitor struct {
 }
 
 type jobResponse struct {
+	Class     string     `json:"_class"`
 	LastBuild jobBuild   `json:"lastBuild"`
 	Jobs      []innerJob `json:"jobs"`
 	Name      string     `json:"name"`
@@ -389,6 +406,22 @@ func (b *buildResponse) getTimestamp() time.Time {
 	return time.Unix(0, b.Timestamp*int64(time.Millisecond))
 }
 
+type pipelineRunResponse struct {
+	Stages []pipelineStage `json:"stages"`
+}
+
+type pipelineStage struct {
+	Name                string `json:"name"`
+	Status              string `json:"status"`
+	StartTimeMillis     int64  `json:"startTimeMillis"`
+	DurationMillis      int64  `json:"durationMillis"`
+	PauseDurationMillis int64  `json:"pauseDurationMillis"`
+}
+
+func (s *pipelineStage) getTimestamp() time.Time {
+	return time.Unix(0, s.StartTimeMillis*int64(time.Millisecond))
+}
+
 const (
 	nodePath = "/computer/api/json"
 	jobPath  = "/api/json"
@@ -422,6 +455,10 @@ func (jr jobRequest) buildURL(number int64) string {
 	return "/job/" + strings.Join(jr.combinedEscaped(), "/job/") + "/" + strconv.Itoa(int(number)) + jobPath
 }
 
+func (jr jobRequest) pipelineRunURL(number int64) string {
+	return "/job/" + strings.Join(jr.combinedEscaped(), "/job/") + "/" + strconv.Itoa(int(number)) + "/wfapi/describe"
+}
+
 func (jr jobRequest) hierarchyName() string {
 	return strings.Join(jr.combined(), "/")
 }
@@ -440,6 +477,25 @@ func (j *Jenkins) gatherJobBuild(jr jobRequest, b *buildResponse, acc telegraf.A
 	acc.AddFields(measurementJob, fields, tags, b.getTimestamp())
 }
 
+func (j *Jenkins) gatherPipelineStages(jr jobRequest, b *buildResponse, run *pipelineRunResponse, acc telegraf.Accumulator) {
+	for _, stage := range run.Stages {
+		tags := map[string]string{
+			"name":    jr.name,
+			"parents": jr.parentsString(),
+			"stage":   stage.Name,
+			"status":  stage.Status,
+			"source":  j.source,
+			"port":    j.port,
+		}
+		fields := map[string]interface{}{
+			"duration":       stage.DurationMillis,
+			"pause_duration": stage.PauseDurationMillis,
+			"number":         b.Number,
+		}
+		acc.AddFields(measurementStage, fields, tags, stage.getTimestamp())
+	}
+}
+
 // perform status mapping
 func mapResultCode(s string) int {
 	switch strings.ToLower(s) {
@@ -995,3 +995,128 @@ func TestGatherJobs(t *testing.T) {
 		})
 	}
 }
+
+func TestGatherPipelineStages(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	start := now.Add(-time.Minute)
+	mh := mockHandler{
+		responseMap: map[string]interface{}{
+			"/api/json": &jobResponse{
+				Jobs: []innerJob{
+					{Name: "freestyle"},
+					{Name: "pipeline"},
+					{Name: "old"},
+				},
+			},
+			// requests to the workflow API of other jobs must not happen as
+			// they would fail with an unknown path
+			"/job/freestyle/api/json": &jobResponse{
+				Class:     "hudson.model.FreeStyleProject",
+				LastBuild: jobBuild{Number: 1},
+			},
+			"/job/freestyle/1/api/json": &buildResponse{
+				Result:    "SUCCESS",
+				Duration:  1000,
+				Number:    1,
+				Timestamp: start.UnixMilli(),
+			},
+			"/job/pipeline/api/json": &jobResponse{
+				Class:     pipelineJobClass,
+				LastBuild: jobBuild{Number: 7},
+			},
+			"/job/pipeline/7/api/json": &buildResponse{
+				Result:    "FAILURE",
+				Duration:  5000,
+				Number:    7,
+				Timestamp: start.UnixMilli(),
+			},
+			"/job/pipeline/7/wfapi/describe": &pipelineRunResponse{
+				Stages: []pipelineStage{
+					{
+						Name:            "Build",
+						Status:          "SUCCESS",
+						StartTimeMillis: start.UnixMilli(),
+						DurationMillis:  3000,
+					},
+					{
+						Name:                "Test",
+						Status:              "FAILED",
+						StartTimeMillis:     start.Add(3 * time.Second).UnixMilli(),
+						DurationMillis:      2000,
+						PauseDurationMillis: 500,
+					},
+				},
+			},
+			"/job/old/api/json": &jobResponse{
+				Class:     pipelineJobClass,
+				LastBuild: jobBuild{Number: 1},
+			},
+			"/job/old/1/api/json": &buildResponse{
+				Result:    "SUCCESS",
+				Number:    1,
+				Timestamp: now.Add(-2 * time.Hour).UnixMilli(),
+			},
+		},
+	}
+	ts := httptest.NewServer(mh)
+	defer ts.Close()
+
+	j := &Jenkins{
+		Log:                   testutil.Logger{},
+		URL:                   ts.URL,
+		MaxBuildAge:           config.Duration(time.Hour),
+		ResponseTimeout:       config.Duration(time.Microsecond),
+		CollectPipelineStages: true,
+	}
+	require.NoError(t, j.initialize(&http.Client{Transport: &http.Transport{}}))
+
+	var acc testutil.Accumulator
+	j.gatherJobs(&acc)
+	require.Empty(t, acc.Errors)
+
+	host, port := j.source, j.port
+	expected := []telegraf.Metric{
+		testutil.MustMetric(
+			measurementStage,
+			map[string]string{
+				"name":    "pipeline",
+				"parents": "",
+				"stage":   "Build",
+				"status":  "SUCCESS",
+				"source":  host,
+				"port":    port,
+			},
+			map[string]interface{}{
+				"duration":       int64(3000),
+				"pause_duration": int64(0),
+				"number":         int64(7),
+			},
+			start,
+		),
+		testutil.MustMetric(
+			measurementStage,
+			map[string]string{
+				"name":    "pipeline",
+				"parents": "",
+				"stage":   "Test",
+				"status":  "FAILED",
+				"source":  host,
+				"port":    port,
+			},
+			map[string]interface{}{
+				"duration":       int64(2000),
+				"pause_duration": int64(500),
+				"number":         int64(7),
+			},
+			start.Add(3*time.Second),
+		),
+	}
+
+	var actual []telegraf.Metric
+	for _, m := range acc.GetTelegrafMetrics() {
+		if m.Name() == measurementStage {
+			actual = append(actual, m)
+		}
+	}
+	testutil.RequireMetricsEqual(t, expected, actual, testutil.SortMetrics())
+}
@@ -172,13 +172,14 @@ func (l *LogParser) tailNewFiles(fromBeginning bool) {
 		poll = true
 	}
 
+	globs, err := globpath.CompileList(l.Files)
+	if err != nil {
+		l.Log.Errorf("Compiling globs failed: %s", err)
+		return
+	}
+
 	// Create a "tailer" for each file
-	for _, filepath := range l.Files {
-		g, err := globpath.Compile(filepath)
-		if err != nil {
-			l.Log.Errorf("Glob %q failed to compile: %s", filepath, err)
-			continue
-		}
+	for _, g := range globs {
 		files := g.Match()
 
 		for _, file := range files {
@@ -22,8 +22,9 @@ import (
 var sampleConfig string
 
 type Net struct {
-	Interfaces          []string `toml:"interfaces"`
-	IgnoreProtocolStats bool     `toml:"ignore_protocol_stats"`
+	Interfaces             []string `toml:"interfaces"`
+	IgnoreProtocolStats    bool     `toml:"ignore_protocol_stats"`
+	CollectInterfaceStatus bool     `toml:"collect_interface_status"`
 
 	filter     filter.Filter
 	ps         system.PS
@@ -110,6 +111,9 @@ func (n *Net) Gather(acc telegraf.Accumulator) error {
 			"drop_out":     io.Dropout,
 			"speed":        getInterfaceSpeed(io.Name),
 		}
+		if n.CollectInterfaceStatus {
+			addInterfaceStatus(io.Name, fields, tags)
+		}
 		acc.AddCounter("net", fields, tags)
 	}
 
@@ -151,6 +155,29 @@ func getInterfaceSpeed(ioName string) int64 {
 	return speed
 }
 
+// Add the link state, speed and duplex mode of the interface from the
+// /sys/class/net/<iface>/ files. Values not available, e.g. the speed of
+// virtual interfaces or of interfaces without link, are omitted.
+func addInterfaceStatus(ioName string, fields map[string]interface{}, tags map[string]string) {
+	if speed := getInterfaceSpeed(ioName); speed >= 0 {
+		fields["speed_mbps"] = speed
+	}
+	if state, err := readInterfaceFile(ioName, "operstate"); err == nil {
+		fields["link_up"] = state == "up"
+	}
+	if duplex, err := readInterfaceFile(ioName, "duplex"); err == nil && duplex != "" && duplex != "unknown" {
+		tags["duplex"] = duplex
+	}
+}
+
+func readInterfaceFile(ioName, name string) (string, error) {
+	raw, err := os.ReadFile(filepath.Join(internal.GetSysPath(), "class", "net", ioName, name))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
 func init() {
 	inputs.Add("net", func() telegraf.Input {
 		return &Net{ps: system.NewSystemPS()}
@@ -213,3 +213,86 @@ func TestNetIOStatsNoSpeedFile(t *testing.T) {
 	}
 	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
 }
+
+func TestNetIOStatsInterfaceStatus(t *testing.T) {
+	var mps system.MockPS
+	defer mps.AssertExpectations(t)
+
+	mps.On("NetIO").Return([]net.IOCountersStat{
+		{Name: "eth0", BytesSent: 1123},
+		{Name: "eth1", BytesSent: 2345},
+		{Name: "eth2", BytesSent: 3456},
+		{Name: "wlan0", BytesSent: 4567},
+	}, nil)
+
+	t.Setenv("HOST_SYS", filepath.Join("testdata", "general", "sys"))
+
+	plugin := &Net{
+		Interfaces:             []string{"eth*"},
+		IgnoreProtocolStats:    true,
+		CollectInterfaceStatus: true,
+		ps:                     &mps,
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"net",
+			map[string]string{"interface": "eth0", "duplex": "full"},
+			map[string]interface{}{
+				"bytes_sent":   uint64(1123),
+				"bytes_recv":   uint64(0),
+				"packets_sent": uint64(0),
+				"packets_recv": uint64(0),
+				"err_in":       uint64(0),
+				"err_out":      uint64(0),
+				"drop_in":      uint64(0),
+				"drop_out":     uint64(0),
+				"speed":        int64(100),
+				"speed_mbps":   int64(100),
+				"link_up":      true,
+			},
+			time.Unix(0, 0),
+			telegraf.Counter,
+		),
+		metric.New(
+			"net",
+			map[string]string{"interface": "eth1"},
+			map[string]interface{}{
+				"bytes_sent":   uint64(2345),
+				"bytes_recv":   uint64(0),
+				"packets_sent": uint64(0),
+				"packets_recv": uint64(0),
+				"err_in":       uint64(0),
+				"err_out":      uint64(0),
+				"drop_in":      uint64(0),
+				"drop_out":     uint64(0),
+				"speed":        int64(-1),
+				"link_up":      false,
+			},
+			time.Unix(0, 0),
+			telegraf.Counter,
+		),
+		metric.New(
+			"net",
+			map[string]string{"interface": "eth2"},
+			map[string]interface{}{
+				"bytes_sent":   uint64(3456),
+				"bytes_recv":   uint64(0),
+				"packets_sent": uint64(0),
+				"packets_recv": uint64(0),
+				"err_in":       uint64(0),
+				"err_out":      uint64(0),
+				"drop_in":      uint64(0),
+				"drop_out":     uint64(0),
+				"speed":        int64(-1),
+			},
+			time.Unix(0, 0),
+			telegraf.Counter,
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
@@ -3,6 +3,9 @@ package net_response
 
 import (
 	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	_ "embed"
 	"errors"
 	"fmt"
@@ -14,6 +17,7 @@ import (
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/internal/choice"
+	common_tls "github.com/influxdata/telegraf/plugins/common/tls"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
 
@@ -23,11 +27,15 @@ var sampleConfig string
 type resultType uint64
 
 const (
-	success          resultType = 0
-	timeout          resultType = 1
-	connectionFailed resultType = 2
-	readFailed       resultType = 3
-	stringMismatch   resultType = 4
+	success            resultType = 0
+	timeout            resultType = 1
+	connectionFailed   resultType = 2
+	readFailed         resultType = 3
+	stringMismatch     resultType = 4
+	certExpired        resultType = 5
+	handshakeTimeout   resultType = 6
+	verificationFailed resultType = 7
+	handshakeFailed    resultType = 8
 )
 
 type NetResponse struct {
@@ -37,6 +45,9 @@ type NetResponse struct {
 	Send        string          `toml:"send"`
 	Expect      string          `toml:"expect"`
 	Protocol    string          `toml:"protocol"`
+	common_tls.ClientConfig
+
+	tlsCfg *tls.Config
 }
 
 func (*NetResponse) SampleConfig() string {
@@ -70,10 +81,25 @@ func (n *NetResponse) Init() error {
 		return errors.New("bad port in config option address")
 	}
 
-	if err := choice.Check(n.Protocol, []string{"tcp", "udp"}); err != nil {
+	if err := choice.Check(n.Protocol, []string{"tcp", "udp", "tls"}); err != nil {
 		return fmt.Errorf("config option protocol: %w", err)
 	}
 
+	if n.Protocol == "tls" {
+		tlsCfg, err := n.ClientConfig.TLSConfig()
+		if err != nil {
+			return err
+		}
+		if tlsCfg == nil {
+			tlsCfg = &tls.Config{}
+		}
+		// Use the host of the address for SNI and verification by default
+		if tlsCfg.ServerName == "" {
+			tlsCfg.ServerName, _, _ = net.SplitHostPort(n.Address)
+		}
+		n.tlsCfg = tlsCfg
+	}
+
 	return nil
 }
 
@@ -103,6 +129,12 @@ func (n *NetResponse) Gather(acc telegraf.Accumulator) error {
 			return err
 		}
 		tags["protocol"] = "udp"
+	case "tls":
+		returnTags, fields, err = n.tlsGather()
+		if err != nil {
+			return err
+		}
+		tags["protocol"] = "tls"
 	}
 
 	// Merge the tags
@@ -135,11 +167,99 @@ func (n *NetResponse) tcpGather() (map[string]string, map[string]interface{}, er
 		return tags, fields, nil
 	}
 	defer conn.Close()
+
+	responseTime, err = n.exchange(conn, start, responseTime, fields, tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields["response_time"] = responseTime
+	return tags, fields, nil
+}
+
+func (n *NetResponse) tlsGather() (map[string]string, map[string]interface{}, error) {
+	// Prepare returns
+	tags := make(map[string]string)
+	fields := make(map[string]interface{})
+	// Start Timer
+	start := time.Now()
+	// Connecting
+	conn, err := net.DialTimeout("tcp", n.Address, time.Duration(n.Timeout))
+	connectTime := time.Since(start).Seconds()
+	// Handle error
+	if err != nil {
+		var e net.Error
+		if errors.As(err, &e) && e.Timeout() {
+			setResult(timeout, fields, tags, n.Expect)
+		} else {
+			setResult(connectionFailed, fields, tags, n.Expect)
+		}
+		return tags, fields, nil
+	}
+	defer conn.Close()
+	fields["connect_time"] = connectTime
+
+	// Handshake within the same timeout as connecting
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(n.Timeout))
+	defer cancel()
+	tlsConn := tls.Client(conn, n.tlsCfg)
+	handshakeStart := time.Now()
+	err = tlsConn.HandshakeContext(ctx)
+	fields["handshake_time"] = time.Since(handshakeStart).Seconds()
+	if err != nil {
+		setResult(handshakeResult(err), fields, tags, n.Expect)
+		return tags, fields, nil
+	}
+
+	state := tlsConn.ConnectionState()
+	fields["tls_version"] = tls.VersionName(state.Version)
+	if len(state.PeerCertificates) > 0 {
+		remaining := time.Until(state.PeerCertificates[0].NotAfter)
+		fields["cert_days_remaining"] = remaining.Hours() / 24
+	}
+
+	responseTime, err := n.exchange(tlsConn, start, time.Since(start).Seconds(), fields, tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields["response_time"] = responseTime
+	return tags, fields, nil
+}
+
+// handshakeResult classifies the error of a failed TLS handshake
+func handshakeResult(err error) resultType {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return handshakeTimeout
+	}
+
+	var invalidErr x509.CertificateInvalidError
+	if errors.As(err, &invalidErr) && invalidErr.Reason == x509.Expired {
+		return certExpired
+	}
+
+	var verificationErr *tls.CertificateVerificationError
+	if errors.As(err, &verificationErr) {
+		return verificationFailed
+	}
+
+	return handshakeFailed
+}
+
+// exchange sends the configured string to the server and checks the answer
+// against the expected string if configured. The given response time is
+// updated after each step.
+func (n *NetResponse) exchange(
+	conn net.Conn,
+	start time.Time,
+	responseTime float64,
+	fields map[string]interface{},
+	tags map[string]string,
+) (float64, error) {
 	// Send string if needed
 	if n.Send != "" {
 		msg := []byte(n.Send)
 		if _, gerr := conn.Write(msg); gerr != nil {
-			return nil, nil, gerr
+			return 0, gerr
 		}
 		// Stop timer
 		responseTime = time.Since(start).Seconds()
@@ -148,7 +268,7 @@ func (n *NetResponse) tcpGather() (map[string]string, map[string]interface{}, er
 	if n.Expect != "" {
 		// Set read timeout
 		if gerr := conn.SetReadDeadline(time.Now().Add(time.Duration(n.ReadTimeout))); gerr != nil {
-			return nil, nil, gerr
+			return 0, gerr
 		}
 		// Prepare reader
 		reader := bufio.NewReader(conn)
@@ -173,8 +293,7 @@ func (n *NetResponse) tcpGather() (map[string]string, map[string]interface{}, er
 	} else {
 		setResult(success, fields, tags, n.Expect)
 	}
-	fields["response_time"] = responseTime
-	return tags, fields, nil
+	return responseTime, nil
 }
 
 func (n *NetResponse) udpGather() (map[string]string, map[string]interface{}, error) {
@@ -246,6 +365,14 @@ func setResult(result resultType, fields map[string]interface{}, tags map[string
 		tag = "read_failed"
 	case stringMismatch:
 		tag = "string_mismatch"
+	case certExpired:
+		tag = "cert_expired"
+	case handshakeTimeout:
+		tag = "handshake_timeout"
+	case verificationFailed:
+		tag = "verification_failed"
+	case handshakeFailed:
+		tag = "handshake_failed"
 	}
 
 	tags["result"] = tag
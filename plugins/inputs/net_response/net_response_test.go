@@ -1,7 +1,18 @@
 package net_response
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
 	"net"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -12,6 +23,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var pki = testutil.NewPKI("../../../testutil/pki")
+
 func TestBadProtocol(t *testing.T) {
 	// Init plugin
 	c := NetResponse{
@@ -339,3 +352,122 @@ func tcpServer(t *testing.T, wg *sync.WaitGroup) {
 		return
 	}
 }
+
+func TestTLS(t *testing.T) {
+	pair, err := tls.X509KeyPair([]byte(pki.ReadServerCert()), []byte(pki.ReadServerKey()))
+	require.NoError(t, err)
+
+	// Create an expired self-signed certificate
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-48 * time.Hour),
+		NotAfter:              time.Now().Add(-24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	expiredCA := filepath.Join(t.TempDir(), "expired.pem")
+	require.NoError(t, os.WriteFile(expiredCA, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0640))
+	expired := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+
+	tests := []struct {
+		name      string
+		cert      *tls.Certificate
+		ca        string
+		expect    string
+		result    string
+		handshake bool
+	}{
+		{
+			name:      "success",
+			cert:      &pair,
+			ca:        pki.CACertPath(),
+			expect:    "test",
+			result:    "success",
+			handshake: true,
+		},
+		{
+			name:   "unknown authority",
+			cert:   &pair,
+			result: "verification_failed",
+		},
+		{
+			name:   "expired certificate",
+			cert:   &expired,
+			ca:     expiredCA,
+			result: "cert_expired",
+		},
+		{
+			name:   "handshake timeout",
+			ca:     pki.CACertPath(),
+			result: "handshake_timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer listener.Close()
+
+			done := make(chan struct{})
+			defer close(done)
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				// Never answer the handshake without a certificate
+				if tt.cert == nil {
+					<-done
+					return
+				}
+
+				tlsConn := tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{*tt.cert}})
+				buf := make([]byte, 4)
+				if _, err := io.ReadFull(tlsConn, buf); err != nil {
+					return
+				}
+				if _, err := tlsConn.Write(append(buf, '\n')); err != nil {
+					t.Error(err)
+				}
+			}()
+
+			plugin := &NetResponse{
+				Address:     listener.Addr().String(),
+				Protocol:    "tls",
+				Send:        "test",
+				Expect:      tt.expect,
+				Timeout:     config.Duration(500 * time.Millisecond),
+				ReadTimeout: config.Duration(time.Second),
+			}
+			plugin.TLSCA = tt.ca
+			require.NoError(t, plugin.Init())
+
+			var acc testutil.Accumulator
+			require.NoError(t, plugin.Gather(&acc))
+			require.Len(t, acc.Metrics, 1)
+
+			m := acc.Metrics[0]
+			require.Equal(t, "tls", m.Tags["protocol"])
+			require.Equal(t, tt.result, m.Tags["result"])
+			require.Contains(t, m.Fields, "connect_time")
+			require.Contains(t, m.Fields, "handshake_time")
+			if tt.handshake {
+				require.Contains(t, m.Fields, "tls_version")
+				require.Contains(t, m.Fields, "response_time")
+				require.Positive(t, m.Fields["cert_days_remaining"])
+			} else {
+				require.NotContains(t, m.Fields, "tls_version")
+			}
+		})
+	}
+}
@@ -28,6 +28,8 @@ type NFSClient struct {
 	Log               telegraf.Logger `toml:"-"`
 	nfs3Ops           map[string]bool
 	nfs4Ops           map[string]bool
+	includeMounts     []*regexp.Regexp
+	excludeMounts     []*regexp.Regexp
 	mountstatsPath    string
 }
 
@@ -167,6 +169,21 @@ func (n *NFSClient) Init() error {
 	n.nfs3Ops = nfs3Ops
 	n.nfs4Ops = nfs4Ops
 
+	for _, pattern := range n.IncludeMounts {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("compiling include_mounts pattern %q failed: %w", pattern, err)
+		}
+		n.includeMounts = append(n.includeMounts, re)
+	}
+	for _, pattern := range n.ExcludeMounts {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("compiling exclude_mounts pattern %q failed: %w", pattern, err)
+		}
+		n.excludeMounts = append(n.excludeMounts, re)
+	}
+
 	if len(n.IncludeMounts) > 0 {
 		n.Log.Debugf("Including these mount patterns: %v", n.IncludeMounts)
 	} else {
@@ -364,13 +381,21 @@ func (n *NFSClient) parseStat(mountpoint, export, version string, line []string,
 			}
 		}
 
+	}
+
+	// Without fullstat, the detailed operation statistics are only reported
+	// for explicitly included operations
+	if n.Fullstat || len(n.IncludeOperations) > 0 {
 		if (version == "3" && n.nfs3Ops[first]) || (version == "4" && n.nfs4Ops[first]) {
 			tags["operation"] = first
 			if len(nline) <= len(nfsopFields) {
+				// Use separate fields as the READ and WRITE summary above
+				// already filled the fields of the nfsstat metric
+				opsFields := make(map[string]interface{}, len(nline))
 				for i, t := range nline {
-					fields[nfsopFields[i]] = t
+					opsFields[nfsopFields[i]] = t
 				}
-				acc.AddFields("nfs_ops", fields, tags)
+				acc.AddFields("nfs_ops", opsFields, tags)
 			}
 		}
 	}
@@ -392,42 +417,27 @@ func (n *NFSClient) processText(scanner *bufio.Scanner, acc telegraf.Accumulator
 			continue
 		}
 
-		skip = false
-
-		// This denotes a new mount has been found, so set
-		// mount and export, and stop skipping (for now)
-		if lineLength > 4 && choice.Contains("fstype", line) && (choice.Contains("nfs", line) || choice.Contains("nfs4", line)) {
-			mount = line[4]
-			export = line[1]
+		// This denotes a new mount has been found, so set mount and export
+		// and decide if the mount is skipped. Mounts of other filesystems
+		// reset the mount to not attribute their statistics to the previous
+		// NFS mount.
+		if line[0] == "device" {
+			mount, export, version = "", "", ""
+			if lineLength > 4 && choice.Contains("fstype", line) && (choice.Contains("nfs", line) || choice.Contains("nfs4", line)) {
+				mount = line[4]
+				export = line[1]
+				skip = !n.includeMount(mount)
+			}
 		} else if lineLength > 5 && (choice.Contains("(nfs)", line) || choice.Contains("(nfs4)", line)) {
-			version = strings.Split(line[5], "/")[1]
+			if _, v, found := strings.Cut(line[5], "/"); found {
+				version = v
+			}
 		}
 
 		if mount == "" {
 			continue
 		}
 
-		if len(n.IncludeMounts) > 0 {
-			skip = true
-			for _, RE := range n.IncludeMounts {
-				matched, err := regexp.MatchString(RE, mount)
-				if matched && err != nil {
-					skip = false
-					break
-				}
-			}
-		}
-
-		if !skip && len(n.ExcludeMounts) > 0 {
-			for _, RE := range n.ExcludeMounts {
-				matched, err := regexp.MatchString(RE, mount)
-				if matched && err != nil {
-					skip = true
-					break
-				}
-			}
-		}
-
 		if !skip {
 			err := n.parseStat(mount, export, version, line, acc)
 			if err != nil {
@@ -439,6 +449,31 @@ func (n *NFSClient) processText(scanner *bufio.Scanner, acc telegraf.Accumulator
 	return nil
 }
 
+// includeMount returns true if the mount point matches any of the include
+// patterns, if given, and none of the exclude patterns
+func (n *NFSClient) includeMount(mount string) bool {
+	if len(n.includeMounts) > 0 {
+		var included bool
+		for _, re := range n.includeMounts {
+			if re.MatchString(mount) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+
+	for _, re := range n.excludeMounts {
+		if re.MatchString(mount) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (n *NFSClient) getMountStatsPath() string {
 	path := "/proc/self/mountstats"
 	if os.Getenv("MOUNT_PROC") != "" {
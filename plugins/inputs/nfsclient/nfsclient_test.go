@@ -214,3 +214,152 @@ func TestNFSClientFileDoesNotExist(t *testing.T) {
 	nfsclient.mountstatsPath = "/does_not_exist"
 	require.Error(t, nfsclient.Gather(&acc))
 }
+
+func TestNFSClientMountFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{
+			name:     "all mounts",
+			expected: []string{"/A", "/B", "/C", "/D"},
+		},
+		{
+			name:     "include",
+			include:  []string{"^/[AB]$"},
+			expected: []string{"/A", "/B"},
+		},
+		{
+			name:     "exclude",
+			exclude:  []string{"^/C$"},
+			expected: []string{"/A", "/B", "/D"},
+		},
+		{
+			name:     "include and exclude",
+			include:  []string{"^/[ABC]$"},
+			exclude:  []string{"^/B$"},
+			expected: []string{"/A", "/C"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nfsclient := NFSClient{
+				IncludeMounts: tt.include,
+				ExcludeMounts: tt.exclude,
+				Log:           testutil.Logger{},
+			}
+			require.NoError(t, nfsclient.Init())
+			nfsclient.mountstatsPath = getMountStatsPath()
+
+			var acc testutil.Accumulator
+			require.NoError(t, nfsclient.Gather(&acc))
+
+			mounts := make(map[string]bool)
+			for _, m := range acc.GetTelegrafMetrics() {
+				require.Equal(t, "nfsstat", m.Name())
+				mountpoint, found := m.GetTag("mountpoint")
+				require.True(t, found)
+				mounts[mountpoint] = true
+			}
+			actual := make([]string, 0, len(mounts))
+			for mountpoint := range mounts {
+				actual = append(actual, mountpoint)
+			}
+			require.ElementsMatch(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestNFSClientInvalidMountFilter(t *testing.T) {
+	nfsclient := NFSClient{
+		IncludeMounts: []string{"^/[A"},
+		Log:           testutil.Logger{},
+	}
+	require.ErrorContains(t, nfsclient.Init(), "compiling include_mounts pattern")
+}
+
+func TestNFSClientIncludeOperations(t *testing.T) {
+	nfsclient := NFSClient{
+		IncludeOperations: []string{"GETATTR"},
+		Log:               testutil.Logger{},
+	}
+	require.NoError(t, nfsclient.Init())
+	nfsclient.mountstatsPath = getMountStatsPath()
+
+	var acc testutil.Accumulator
+	require.NoError(t, nfsclient.Gather(&acc))
+
+	// Detailed statistics of the included operation for NFSv3 and NFSv4
+	// mounts in addition to the summary but no other full statistics
+	require.False(t, acc.HasMeasurement("nfs_events"))
+	require.False(t, acc.HasMeasurement("nfs_bytes"))
+	require.True(t, acc.HasMeasurement("nfsstat"))
+
+	var ops int
+	for _, m := range acc.GetTelegrafMetrics() {
+		if m.Name() != "nfs_ops" {
+			continue
+		}
+		ops++
+		operation, found := m.GetTag("operation")
+		require.True(t, found)
+		require.Equal(t, "GETATTR", operation)
+	}
+	require.Equal(t, 4, ops)
+
+	acc.AssertContainsTaggedFields(t, "nfs_ops",
+		map[string]interface{}{
+			"ops":           uint64(2),
+			"trans":         uint64(2),
+			"timeouts":      uint64(0),
+			"bytes_sent":    uint64(364),
+			"bytes_recv":    uint64(480),
+			"queue_time":    uint64(0),
+			"response_time": uint64(1),
+			"total_time":    uint64(1),
+			"errors":        uint64(0),
+		},
+		map[string]string{
+			"serverexport": "nfsserver2:/tank/os2warp",
+			"mountpoint":   "/D",
+			"operation":    "GETATTR",
+		},
+	)
+}
+
+func TestNFSClientIncludeOperationsRead(t *testing.T) {
+	nfsclient := NFSClient{
+		IncludeOperations: []string{"READ"},
+		Log:               testutil.Logger{},
+	}
+	require.NoError(t, nfsclient.Init())
+
+	var acc testutil.Accumulator
+	data := strings.Fields("         READ: 500 501 502 503 504 505 506 507 508")
+	require.NoError(t, nfsclient.parseStat("1.2.3.4:/storage/NFS", "/A", "3", data, &acc))
+
+	// The operation details must not contain the fields of the summary
+	var found bool
+	for _, m := range acc.GetTelegrafMetrics() {
+		if m.Name() != "nfs_ops" {
+			continue
+		}
+		found = true
+		require.Equal(t, map[string]interface{}{
+			"ops":           uint64(500),
+			"trans":         uint64(501),
+			"timeouts":      uint64(502),
+			"bytes_sent":    uint64(503),
+			"bytes_recv":    uint64(504),
+			"queue_time":    uint64(505),
+			"response_time": uint64(506),
+			"total_time":    uint64(507),
+			"errors":        uint64(508),
+		}, m.Fields())
+	}
+	require.True(t, found)
+	require.True(t, acc.HasMeasurement("nfsstat"))
+}
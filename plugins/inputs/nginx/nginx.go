@@ -15,6 +15,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	common_nginx "github.com/influxdata/telegraf/plugins/common/nginx"
 	"github.com/influxdata/telegraf/plugins/common/tls"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
@@ -49,7 +50,7 @@ func (n *Nginx) Gather(acc telegraf.Accumulator) error {
 	}
 
 	for _, u := range n.Urls {
-		addr, err := url.Parse(u)
+		addr, err := common_nginx.ParseAddress(u)
 		if err != nil {
 			acc.AddError(fmt.Errorf("unable to parse address %q: %w", u, err))
 			continue
@@ -77,10 +78,8 @@ func (n *Nginx) createHTTPClient() (*http.Client, error) {
 	}
 
 	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: tlsCfg,
-		},
-		Timeout: time.Duration(n.ResponseTimeout),
+		Transport: common_nginx.NewTransport(tlsCfg),
+		Timeout:   time.Duration(n.ResponseTimeout),
 	}
 
 	return client, nil
@@ -171,6 +170,11 @@ func (n *Nginx) gatherURL(addr *url.URL, acc telegraf.Accumulator) error {
 
 // Get tag(s) for the nginx plugin
 func getTags(addr *url.URL) map[string]string {
+	// Use the socket path as server for unix domain sockets
+	if socket, ok := common_nginx.SocketPath(addr); ok {
+		return map[string]string{"server": socket}
+	}
+
 	h := addr.Host
 	host, port, err := net.SplitHostPort(h)
 	if err != nil {
@@ -6,10 +6,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	common_nginx "github.com/influxdata/telegraf/plugins/common/nginx"
 	"github.com/influxdata/telegraf/testutil"
 )
 
@@ -37,6 +40,96 @@ func TestNginxTags(t *testing.T) {
 	}
 }
 
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		expected map[string]string
+		err      string
+	}{
+		{
+			name:     "http",
+			address:  "http://localhost/stub_status",
+			expected: map[string]string{"server": "localhost", "port": "80"},
+		},
+		{
+			name:     "https with port",
+			address:  "https://localhost:8443/stub_status",
+			expected: map[string]string{"server": "localhost", "port": "8443"},
+		},
+		{
+			name:     "unix socket",
+			address:  "unix:///var/run/nginx/status.sock:/stub_status",
+			expected: map[string]string{"server": "/var/run/nginx/status.sock"},
+		},
+		{
+			name:     "https over unix socket",
+			address:  "https+unix:///var/run/nginx/status.sock:/stub_status",
+			expected: map[string]string{"server": "/var/run/nginx/status.sock"},
+		},
+		{
+			name:    "unix socket without request path",
+			address: "unix:///var/run/nginx/status.sock",
+			err:     "missing request path after socket",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := common_nginx.ParseAddress(tt.address)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, getTags(addr))
+		})
+	}
+}
+
+func TestNginxUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on windows")
+	}
+
+	sock := filepath.Join(t.TempDir(), "status.sock")
+	listener, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stub_status" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := fmt.Fprint(w, nginxSampleResponse); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			t.Error(err)
+			return
+		}
+	}))
+	ts.Listener = listener
+	ts.Start()
+	defer ts.Close()
+
+	n := &Nginx{
+		Urls: []string{"unix://" + sock + ":/stub_status"},
+	}
+
+	var acc testutil.Accumulator
+	require.NoError(t, acc.GatherError(n.Gather))
+
+	fields := map[string]interface{}{
+		"active":   uint64(585),
+		"accepts":  uint64(85340),
+		"handled":  uint64(85340),
+		"requests": uint64(35085),
+		"reading":  uint64(4),
+		"writing":  uint64(135),
+		"waiting":  uint64(446),
+	}
+	acc.AssertContainsTaggedFields(t, "nginx", fields, map[string]string{"server": sock})
+}
+
 func TestNginxGeneratesMetrics(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var rsp string
@@ -17,6 +17,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	common_nginx "github.com/influxdata/telegraf/plugins/common/nginx"
 	"github.com/influxdata/telegraf/plugins/common/tls"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
@@ -51,7 +52,7 @@ func (n *NginxPlus) Gather(acc telegraf.Accumulator) error {
 	}
 
 	for _, u := range n.Urls {
-		addr, err := url.Parse(u)
+		addr, err := common_nginx.ParseAddress(u)
 		if err != nil {
 			acc.AddError(fmt.Errorf("unable to parse address %q: %w", u, err))
 			continue
@@ -79,10 +80,8 @@ func (n *NginxPlus) createHTTPClient() (*http.Client, error) {
 	}
 
 	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: tlsConfig,
-		},
-		Timeout: time.Duration(n.ResponseTimeout),
+		Transport: common_nginx.NewTransport(tlsConfig),
+		Timeout:   time.Duration(n.ResponseTimeout),
 	}
 
 	return client, nil
@@ -108,6 +107,11 @@ func (n *NginxPlus) gatherURL(addr *url.URL, acc telegraf.Accumulator) error {
 }
 
 func getTags(addr *url.URL) map[string]string {
+	// Use the socket path as server for unix domain sockets
+	if socket, ok := common_nginx.SocketPath(addr); ok {
+		return map[string]string{"server": socket}
+	}
+
 	h := addr.Host
 	host, port, err := net.SplitHostPort(h)
 	if err != nil {
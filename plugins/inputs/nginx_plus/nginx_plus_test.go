@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	common_nginx "github.com/influxdata/telegraf/plugins/common/nginx"
 	"github.com/influxdata/telegraf/testutil"
 )
 
@@ -408,3 +409,9 @@ func TestNginxPlusGeneratesMetrics(t *testing.T) {
 			"id":               "0",
 		})
 }
+
+func TestGetTagsUnixSocket(t *testing.T) {
+	addr, err := common_nginx.ParseAddress("unix:///var/run/nginx/status.sock:/status")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"server": "/var/run/nginx/status.sock"}, getTags(addr))
+}
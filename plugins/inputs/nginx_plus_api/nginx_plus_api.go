@@ -11,6 +11,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	common_nginx "github.com/influxdata/telegraf/plugins/common/nginx"
 	"github.com/influxdata/telegraf/plugins/common/tls"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
@@ -72,7 +73,7 @@ func (n *NginxPlusAPI) Gather(acc telegraf.Accumulator) error {
 	}
 
 	for _, u := range n.Urls {
-		addr, err := url.Parse(u)
+		addr, err := common_nginx.ParseAddress(u)
 		if err != nil {
 			acc.AddError(fmt.Errorf("unable to parse address %q: %w", u, err))
 			continue
@@ -100,10 +101,8 @@ func (n *NginxPlusAPI) createHTTPClient() (*http.Client, error) {
 	}
 
 	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: tlsConfig,
-		},
-		Timeout: time.Duration(n.ResponseTimeout),
+		Transport: common_nginx.NewTransport(tlsConfig),
+		Timeout:   time.Duration(n.ResponseTimeout),
 	}
 
 	return client, nil
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/influxdata/telegraf"
+	common_nginx "github.com/influxdata/telegraf/plugins/common/nginx"
 )
 
 var (
@@ -653,6 +654,11 @@ func (n *NginxPlusAPI) gatherHTTPLimitReqsMetrics(addr *url.URL, acc telegraf.Ac
 }
 
 func getTags(addr *url.URL) map[string]string {
+	// Use the socket path as source for unix domain sockets
+	if socket, ok := common_nginx.SocketPath(addr); ok {
+		return map[string]string{"source": socket}
+	}
+
 	h := addr.Host
 	host, port, err := net.SplitHostPort(h)
 	if err != nil {
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -1584,3 +1586,46 @@ func prepareEndpoint(t *testing.T, path, payload string) (*httptest.Server, *Ngi
 
 	return ts, n
 }
+
+func TestGatherUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on windows")
+	}
+
+	sock := filepath.Join(t.TempDir(), "api.sock")
+	listener, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/9/"+processesPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := fmt.Fprint(w, processesPayload); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			t.Error(err)
+			return
+		}
+	}))
+	ts.Listener = listener
+	ts.Start()
+	defer ts.Close()
+
+	n := &NginxPlusAPI{
+		Urls:       []string{"unix://" + sock + ":/api"},
+		APIVersion: 9,
+	}
+
+	var acc testutil.Accumulator
+	require.NoError(t, acc.GatherError(n.Gather))
+	acc.AssertContainsTaggedFields(
+		t,
+		"nginx_plus_api_processes",
+		map[string]interface{}{
+			"respawned": int(0),
+		},
+		map[string]string{
+			"source": sock,
+		})
+}
@@ -4,6 +4,7 @@ package openldap
 import (
 	_ "embed"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -28,11 +29,27 @@ type Openldap struct {
 	BindDn             string
 	BindPassword       string
 	ReverseMetricNames bool
+	CollectReplication bool     `toml:"collect_replication"`
+	ReplicationPeers   []string `toml:"replication_peers"`
 }
 
 var searchBase = "cn=Monitor"
 var searchFilter = "(|(objectClass=monitorCounterObject)(objectClass=monitorOperation)(objectClass=monitoredObject))"
 var searchAttrs = []string{"monitorCounter", "monitorOpInitiated", "monitorOpCompleted", "monitoredInfo"}
+var databaseSearchBase = "cn=Databases,cn=Monitor"
+var databaseSearchFilter = "(namingContexts=*)"
+var databaseSearchAttrs = []string{
+	"namingContexts", "monitoredInfo",
+	"olmMDBEntries", "olmMDBPagesMax", "olmMDBPagesUsed", "olmMDBPagesFree", "olmMDBReadersMax", "olmMDBReadersUsed",
+}
+var databaseAttrFields = map[string]string{
+	"olmMDBEntries":     "entries",
+	"olmMDBPagesMax":    "pages_max",
+	"olmMDBPagesUsed":   "pages_used",
+	"olmMDBPagesFree":   "pages_free",
+	"olmMDBReadersMax":  "readers_max",
+	"olmMDBReadersUsed": "readers_used",
+}
 var attrTranslate = map[string]string{
 	"monitorCounter":     "",
 	"monitoredInfo":      "",
@@ -66,6 +83,30 @@ func (*Openldap) SampleConfig() string {
 	return sampleConfig
 }
 
+func (o *Openldap) Init() error {
+	// The peers must use the same transport security as the local server to
+	// not send the bind credentials in plaintext
+	mode := o.TLS
+	if mode == "" {
+		mode = o.SSL
+	}
+	scheme := "ldap"
+	if mode == "ldaps" {
+		scheme = "ldaps"
+	}
+	for _, peer := range o.ReplicationPeers {
+		u, err := url.Parse(peer)
+		if err != nil {
+			return fmt.Errorf("parsing replication peer %q failed: %w", peer, err)
+		}
+		if u.Scheme != scheme {
+			return fmt.Errorf("scheme of replication peer %q does not match the TLS setting %q, use %q", peer, mode, scheme)
+		}
+	}
+
+	return nil
+}
+
 // gather metrics
 func (o *Openldap) Gather(acc telegraf.Accumulator) error {
 	if o.TLS == "" {
@@ -75,61 +116,17 @@ func (o *Openldap) Gather(acc telegraf.Accumulator) error {
 		o.TLSCA = o.SSLCA
 	}
 
-	var err error
-	var l *ldap.Conn
-	if o.TLS != "" {
-		// build tls config
-		clientTLSConfig := tls.ClientConfig{
-			TLSCA:              o.TLSCA,
-			InsecureSkipVerify: o.InsecureSkipVerify,
-		}
-		tlsConfig, err := clientTLSConfig.TLSConfig()
-		if err != nil {
-			acc.AddError(err)
-			return nil
-		}
-
-		switch o.TLS {
-		case "ldaps":
-			l, err = ldap.DialURL(fmt.Sprintf("ldaps://%s:%d", o.Host, o.Port), ldap.DialWithTLSConfig(tlsConfig))
-			if err != nil {
-				acc.AddError(err)
-				return nil
-			}
-		case "starttls":
-			l, err = ldap.DialURL(fmt.Sprintf("ldap://%s:%d", o.Host, o.Port))
-			if err != nil {
-				acc.AddError(err)
-				return nil
-			}
-			err = l.StartTLS(tlsConfig)
-			if err != nil {
-				acc.AddError(err)
-				return nil
-			}
-		default:
-			acc.AddError(fmt.Errorf("invalid setting for ssl: %s", o.TLS))
-			return nil
-		}
-	} else {
-		l, err = ldap.DialURL(fmt.Sprintf("ldap://%s:%d", o.Host, o.Port))
+	scheme := "ldap"
+	if o.TLS == "ldaps" {
+		scheme = "ldaps"
 	}
-
+	l, err := o.connect(fmt.Sprintf("%s://%s:%d", scheme, o.Host, o.Port))
 	if err != nil {
 		acc.AddError(err)
 		return nil
 	}
 	defer l.Close()
 
-	// username/password bind
-	if o.BindDn != "" && o.BindPassword != "" {
-		err = l.Bind(o.BindDn, o.BindPassword)
-		if err != nil {
-			acc.AddError(err)
-			return nil
-		}
-	}
-
 	searchRequest := ldap.NewSearchRequest(
 		searchBase,
 		ldap.ScopeWholeSubtree,
@@ -150,9 +147,94 @@ func (o *Openldap) Gather(acc telegraf.Accumulator) error {
 
 	gatherSearchResult(sr, o, acc)
 
+	if !o.CollectReplication {
+		return nil
+	}
+
+	// Per-database statistics of the backends
+	databaseRequest := ldap.NewSearchRequest(
+		databaseSearchBase,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		databaseSearchFilter,
+		databaseSearchAttrs,
+		nil,
+	)
+	dr, err := l.Search(databaseRequest)
+	if err != nil {
+		acc.AddError(fmt.Errorf("searching databases failed: %w", err))
+		return nil
+	}
+	suffixes := gatherDatabaseResult(dr, o, acc)
+	o.gatherReplication(l, suffixes, acc)
+
 	return nil
 }
 
+// connect to the server with the given URL using the configured TLS settings
+// and bind if credentials are given
+func (o *Openldap) connect(serverURL string) (*ldap.Conn, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing URL %q failed: %w", serverURL, err)
+	}
+
+	var l *ldap.Conn
+	switch o.TLS {
+	case "":
+		l, err = ldap.DialURL(serverURL)
+		if err != nil {
+			return nil, err
+		}
+	case "ldaps", "starttls":
+		// build tls config
+		clientTLSConfig := tls.ClientConfig{
+			TLSCA:              o.TLSCA,
+			InsecureSkipVerify: o.InsecureSkipVerify,
+		}
+		tlsConfig, err := clientTLSConfig.TLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		if tlsConfig != nil && tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = u.Hostname()
+		}
+
+		if u.Scheme == "ldaps" {
+			l, err = ldap.DialURL(serverURL, ldap.DialWithTLSConfig(tlsConfig))
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+		l, err = ldap.DialURL(serverURL)
+		if err != nil {
+			return nil, err
+		}
+		if o.TLS == "starttls" {
+			if err := l.StartTLS(tlsConfig); err != nil {
+				l.Close()
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid setting for ssl: %s", o.TLS)
+	}
+
+	// username/password bind
+	if o.BindDn != "" && o.BindPassword != "" {
+		if err := l.Bind(o.BindDn, o.BindPassword); err != nil {
+			l.Close()
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
 func gatherSearchResult(sr *ldap.SearchResult, o *Openldap, acc telegraf.Accumulator) {
 	fields := make(map[string]interface{})
 	tags := map[string]string{
@@ -172,6 +254,38 @@ func gatherSearchResult(sr *ldap.SearchResult, o *Openldap, acc telegraf.Accumul
 	acc.AddFields("openldap", fields, tags)
 }
 
+// gatherDatabaseResult adds the statistics of the databases tagged by their
+// suffix and returns the suffixes of all databases
+func gatherDatabaseResult(sr *ldap.SearchResult, o *Openldap, acc telegraf.Accumulator) []string {
+	suffixes := make([]string, 0, len(sr.Entries))
+	for _, entry := range sr.Entries {
+		suffix := entry.GetAttributeValue("namingContexts")
+		if suffix == "" {
+			continue
+		}
+		suffixes = append(suffixes, suffix)
+
+		fields := make(map[string]interface{})
+		for attr, field := range databaseAttrFields {
+			if v, err := strconv.ParseInt(entry.GetAttributeValue(attr), 10, 64); err == nil {
+				fields[field] = v
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		tags := map[string]string{
+			"server":  o.Host,
+			"port":    strconv.Itoa(o.Port),
+			"suffix":  suffix,
+			"backend": entry.GetAttributeValue("monitoredInfo"),
+		}
+		acc.AddFields("openldap_database", fields, tags)
+	}
+	return suffixes
+}
+
 // Convert a DN to metric name, eg cn=Read,cn=Waiters,cn=Monitor becomes waiters_read
 // Assumes the last part of the DN is cn=Monitor and we want to drop it
 func dnToMetric(dn string, o *Openldap) string {
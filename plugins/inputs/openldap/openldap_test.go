@@ -4,12 +4,15 @@ import (
 	"path/filepath"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/docker/go-connections/nat"
 	"github.com/go-ldap/ldap/v3"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go/wait"
 
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/testutil"
 )
 
@@ -39,6 +42,202 @@ func TestOpenldapMockResult(t *testing.T) {
 	commonTests(t, o, &acc)
 }
 
+func TestOpenldapDatabaseMockResult(t *testing.T) {
+	mockSearchResult := ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			{
+				DN: "cn=Database 1,cn=Databases,cn=Monitor",
+				Attributes: []*ldap.EntryAttribute{
+					{Name: "namingContexts", Values: []string{"dc=example,dc=org"}},
+					{Name: "monitoredInfo", Values: []string{"mdb"}},
+					{Name: "olmMDBEntries", Values: []string{"42"}},
+					{Name: "olmMDBPagesMax", Values: []string{"262144"}},
+					{Name: "olmMDBPagesUsed", Values: []string{"83"}},
+					{Name: "olmMDBPagesFree", Values: []string{"12"}},
+					{Name: "olmMDBReadersMax", Values: []string{"126"}},
+					{Name: "olmMDBReadersUsed", Values: []string{"2"}},
+				},
+			},
+			{
+				DN: "cn=Database 2,cn=Databases,cn=Monitor",
+				Attributes: []*ldap.EntryAttribute{
+					{Name: "namingContexts", Values: []string{"cn=Monitor"}},
+					{Name: "monitoredInfo", Values: []string{"monitor"}},
+				},
+			},
+		},
+	}
+
+	o := &Openldap{
+		Host: "localhost",
+		Port: 389,
+	}
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"openldap_database",
+			map[string]string{
+				"server":  "localhost",
+				"port":    "389",
+				"suffix":  "dc=example,dc=org",
+				"backend": "mdb",
+			},
+			map[string]interface{}{
+				"entries":      int64(42),
+				"pages_max":    int64(262144),
+				"pages_used":   int64(83),
+				"pages_free":   int64(12),
+				"readers_max":  int64(126),
+				"readers_used": int64(2),
+			},
+			time.Unix(0, 0),
+		),
+	}
+
+	var acc testutil.Accumulator
+	suffixes := gatherDatabaseResult(&mockSearchResult, o, &acc)
+	require.Equal(t, []string{"dc=example,dc=org", "cn=Monitor"}, suffixes)
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestInitReplicationPeers(t *testing.T) {
+	tests := []struct {
+		name  string
+		tls   string
+		peers []string
+		err   string
+	}{
+		{
+			name:  "plaintext",
+			peers: []string{"ldap://peer.example.org:389"},
+		},
+		{
+			name:  "starttls",
+			tls:   "starttls",
+			peers: []string{"ldap://peer.example.org:389"},
+		},
+		{
+			name:  "ldaps",
+			tls:   "ldaps",
+			peers: []string{"ldaps://peer.example.org:636"},
+		},
+		{
+			name:  "plaintext peer with ldaps",
+			tls:   "ldaps",
+			peers: []string{"ldaps://peer1.example.org:636", "ldap://peer2.example.org:389"},
+			err:   `scheme of replication peer "ldap://peer2.example.org:389" does not match`,
+		},
+		{
+			name:  "ldaps peer with starttls",
+			tls:   "starttls",
+			peers: []string{"ldaps://peer.example.org:636"},
+			err:   `scheme of replication peer "ldaps://peer.example.org:636" does not match`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Openldap{
+				TLS:              tt.tls,
+				ReplicationPeers: tt.peers,
+			}
+			if tt.err != "" {
+				require.ErrorContains(t, o.Init(), tt.err)
+				return
+			}
+			require.NoError(t, o.Init())
+		})
+	}
+}
+
+func TestParseContextCSN(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected map[string]time.Time
+		err      string
+	}{
+		{
+			name:   "multiple servers",
+			values: []string{"20240102030405.123456Z#000000#001#000000", "20240102030410.000000Z#000000#002#000000"},
+			expected: map[string]time.Time{
+				"001": time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC),
+				"002": time.Date(2024, 1, 2, 3, 4, 10, 0, time.UTC),
+			},
+		},
+		{
+			name:   "invalid format",
+			values: []string{"20240102030405.123456Z#000000"},
+			err:    `invalid CSN "20240102030405.123456Z#000000"`,
+		},
+		{
+			name:   "invalid timestamp",
+			values: []string{"2024-01-02#000000#001#000000"},
+			err:    `invalid timestamp in CSN "2024-01-02#000000#001#000000"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseContextCSN(tt.values)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestReplicationLag(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		local    map[string]time.Time
+		remote   map[string]time.Time
+		expected time.Duration
+		err      string
+	}{
+		{
+			name:   "in sync",
+			local:  map[string]time.Time{"001": ts, "002": ts.Add(time.Second)},
+			remote: map[string]time.Time{"001": ts, "002": ts.Add(time.Second)},
+		},
+		{
+			name:     "remote behind",
+			local:    map[string]time.Time{"001": ts.Add(90 * time.Second), "002": ts.Add(time.Second)},
+			remote:   map[string]time.Time{"001": ts, "002": ts},
+			expected: 90 * time.Second,
+		},
+		{
+			name:     "local behind",
+			local:    map[string]time.Time{"001": ts},
+			remote:   map[string]time.Time{"001": ts.Add(1500 * time.Millisecond), "003": ts.Add(time.Hour)},
+			expected: 1500 * time.Millisecond,
+		},
+		{
+			name:   "no common server",
+			local:  map[string]time.Time{"001": ts},
+			remote: map[string]time.Time{"002": ts},
+			err:    "no common server IDs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lag, err := replicationLag(tt.local, tt.remote)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, lag)
+		})
+	}
+}
+
 func TestOpenldapNoConnectionIntegration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
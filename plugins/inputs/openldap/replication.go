@@ -0,0 +1,170 @@
+package openldap
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/influxdata/telegraf"
+)
+
+// Layout of the timestamp part of a change sequence number (CSN) of the form
+// "20240102030405.123456Z#000000#001#000000"
+const csnTimeLayout = "20060102150405.000000Z"
+
+// gatherReplication adds the replication status of each suffix by reading
+// the contextCSN values of the local server and comparing them with the ones
+// of the configured peers
+func (o *Openldap) gatherReplication(l *ldap.Conn, suffixes []string, acc telegraf.Accumulator) {
+	peers := make(map[string]*ldap.Conn, len(o.ReplicationPeers))
+	for _, peer := range o.ReplicationPeers {
+		conn, err := o.connect(peer)
+		if err != nil {
+			acc.AddError(fmt.Errorf("connecting to peer %q failed: %w", peer, err))
+			continue
+		}
+		peers[peer] = conn
+	}
+	defer func() {
+		for _, conn := range peers {
+			conn.Close()
+		}
+	}()
+
+	for _, suffix := range suffixes {
+		if strings.EqualFold(suffix, searchBase) {
+			continue
+		}
+
+		local, err := searchContextCSN(l, suffix)
+		if err != nil {
+			acc.AddError(fmt.Errorf("reading contextCSN of %q failed: %w", suffix, err))
+			continue
+		}
+		// Databases without replication do not carry a contextCSN
+		if len(local) == 0 {
+			continue
+		}
+
+		tags := map[string]string{
+			"server": o.Host,
+			"port":   strconv.Itoa(o.Port),
+			"suffix": suffix,
+		}
+		fields := map[string]interface{}{
+			"context_csn_time": latestCSN(local).Unix(),
+		}
+		acc.AddFields("openldap_replication", fields, tags)
+
+		for _, peer := range o.ReplicationPeers {
+			conn, found := peers[peer]
+			if !found {
+				continue
+			}
+
+			remote, err := searchContextCSN(conn, suffix)
+			if err != nil {
+				acc.AddError(fmt.Errorf("reading contextCSN of %q from peer %q failed: %w", suffix, peer, err))
+				continue
+			}
+			lag, err := replicationLag(local, remote)
+			if err != nil {
+				acc.AddError(fmt.Errorf("comparing contextCSN of %q with peer %q failed: %w", suffix, peer, err))
+				continue
+			}
+
+			peerTags := map[string]string{
+				"server": o.Host,
+				"port":   strconv.Itoa(o.Port),
+				"suffix": suffix,
+				"peer":   peer,
+			}
+			peerFields := map[string]interface{}{
+				"lag":     lag.Seconds(),
+				"in_sync": lag == 0,
+			}
+			acc.AddFields("openldap_replication", peerFields, peerTags)
+		}
+	}
+}
+
+// searchContextCSN returns the timestamps of the contextCSN values of the
+// given suffix by server ID
+func searchContextCSN(l *ldap.Conn, suffix string) (map[string]time.Time, error) {
+	request := ldap.NewSearchRequest(
+		suffix,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=*)",
+		[]string{"contextCSN"},
+		nil,
+	)
+	sr, err := l.Search(request)
+	if err != nil {
+		return nil, err
+	}
+	if len(sr.Entries) == 0 {
+		return nil, nil
+	}
+	return parseContextCSN(sr.Entries[0].GetAttributeValues("contextCSN"))
+}
+
+// parseContextCSN returns the timestamps of the given CSN values by server ID
+func parseContextCSN(values []string) (map[string]time.Time, error) {
+	csns := make(map[string]time.Time, len(values))
+	for _, value := range values {
+		parts := strings.Split(value, "#")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("invalid CSN %q", value)
+		}
+		ts, err := time.Parse(csnTimeLayout, parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp in CSN %q: %w", value, err)
+		}
+		csns[parts[2]] = ts
+	}
+	return csns, nil
+}
+
+// latestCSN returns the most recent timestamp of the CSNs
+func latestCSN(csns map[string]time.Time) time.Time {
+	var latest time.Time
+	for _, ts := range csns {
+		if ts.After(latest) {
+			latest = ts
+		}
+	}
+	return latest
+}
+
+// replicationLag returns the largest difference between the local and the
+// remote CSNs of the server IDs known to both sides
+func replicationLag(local, remote map[string]time.Time) (time.Duration, error) {
+	var lag time.Duration
+	var common bool
+	for sid, localTS := range local {
+		remoteTS, found := remote[sid]
+		if !found {
+			continue
+		}
+		common = true
+		diff := localTS.Sub(remoteTS)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > lag {
+			lag = diff
+		}
+	}
+	if !common {
+		return 0, errors.New("no common server IDs")
+	}
+	return lag, nil
+}
@@ -2,6 +2,7 @@
 package ping
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
@@ -41,7 +42,8 @@ type Ping struct {
 	calcInterval time.Duration
 	calcTimeout  time.Duration
 
-	sourceAddress string
+	// IP versions to ping each URL with when an address family is given
+	ipVersions []string
 
 	Log telegraf.Logger `toml:"-"`
 
@@ -79,9 +81,15 @@ type Ping struct {
 	// Whether to resolve addresses using ipv6 or not.
 	IPv6 bool
 
+	// Address family to resolve and ping the URLs with (ipv4, ipv6 or both)
+	AddressFamily string `toml:"address_family"`
+
 	// host ping function
 	pingHost HostPinger
 
+	// address lookup function
+	lookupIP func(ctx context.Context, network, host string) ([]net.IP, error)
+
 	nativePingFunc NativePingFunc
 
 	// Calculate the given percentiles when using native method
@@ -97,17 +105,22 @@ func (*Ping) SampleConfig() string {
 
 func (p *Ping) Gather(acc telegraf.Accumulator) error {
 	for _, host := range p.Urls {
-		p.wg.Add(1)
-		go func(host string) {
-			defer p.wg.Done()
-
-			switch p.Method {
-			case "native":
-				p.pingToURLNative(host, acc)
-			default:
-				p.pingToURL(host, acc)
-			}
-		}(host)
+		if len(p.ipVersions) == 0 {
+			p.wg.Add(1)
+			go func(host string) {
+				defer p.wg.Done()
+				p.ping(host, map[string]string{"url": host}, acc)
+			}(host)
+			continue
+		}
+
+		for _, version := range p.ipVersions {
+			p.wg.Add(1)
+			go func(host, version string) {
+				defer p.wg.Done()
+				p.pingAddressFamily(host, version, acc)
+			}(host, version)
+		}
 	}
 
 	p.wg.Wait()
@@ -115,6 +128,31 @@ func (p *Ping) Gather(acc telegraf.Accumulator) error {
 	return nil
 }
 
+func (p *Ping) ping(destination string, tags map[string]string, acc telegraf.Accumulator) {
+	switch p.Method {
+	case "native":
+		p.pingToURLNative(destination, tags, acc)
+	default:
+		p.pingToURL(destination, tags, acc)
+	}
+}
+
+// pingAddressFamily resolves the host using the given IP version and pings the
+// resulting address. A failing resolution is reported as unknown host for
+// this IP version only.
+func (p *Ping) pingAddressFamily(host, version string, acc telegraf.Accumulator) {
+	tags := map[string]string{"url": host, "ip_version": version}
+
+	ips, err := p.lookupIP(context.Background(), "ip"+version, host)
+	if err != nil || len(ips) == 0 {
+		p.Log.Debugf("Resolving %q using IPv%s failed: %v", host, version, err)
+		acc.AddFields("ping", map[string]interface{}{"result_code": 1}, tags)
+		return
+	}
+
+	p.ping(ips[0].String(), tags, acc)
+}
+
 type pingStats struct {
 	ping.Statistics
 	ttl int
@@ -148,26 +186,14 @@ func (p *Ping) nativePing(destination string) (*pingStats, error) {
 	}
 
 	// Support either an IP address or interface name
-	if p.Interface != "" && p.sourceAddress == "" {
-		if addr := net.ParseIP(p.Interface); addr != nil {
-			p.sourceAddress = p.Interface
-		} else {
-			i, err := net.InterfaceByName(p.Interface)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get interface: %w", err)
-			}
-			addrs, err := i.Addrs()
-			if err != nil {
-				return nil, fmt.Errorf("failed to get the address of interface: %w", err)
-			}
-			if len(addrs) == 0 {
-				return nil, fmt.Errorf("no address found for interface %s", p.Interface)
-			}
-			p.sourceAddress = addrs[0].(*net.IPNet).IP.String()
+	if p.Interface != "" {
+		source, err := p.sourceAddress(pinger.IPAddr().IP)
+		if err != nil {
+			return nil, err
 		}
+		pinger.Source = source
 	}
 
-	pinger.Source = p.sourceAddress
 	pinger.Interval = p.calcInterval
 
 	if p.Deadline > 0 {
@@ -200,9 +226,36 @@ func (p *Ping) nativePing(destination string) (*pingStats, error) {
 	return ps, nil
 }
 
-func (p *Ping) pingToURLNative(destination string, acc telegraf.Accumulator) {
-	tags := map[string]string{"url": destination}
+// sourceAddress returns the configured source address or the address of the
+// configured interface, preferring an address of the same family as the
+// destination
+func (p *Ping) sourceAddress(destination net.IP) (string, error) {
+	if addr := net.ParseIP(p.Interface); addr != nil {
+		return p.Interface, nil
+	}
+
+	i, err := net.InterfaceByName(p.Interface)
+	if err != nil {
+		return "", fmt.Errorf("failed to get interface: %w", err)
+	}
+	addrs, err := i.Addrs()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the address of interface: %w", err)
+	}
+	if len(addrs) == 0 {
+		return "", fmt.Errorf("no address found for interface %s", p.Interface)
+	}
 
+	isIPv4 := destination.To4() != nil
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok && (ipnet.IP.To4() != nil) == isIPv4 {
+			return ipnet.IP.String(), nil
+		}
+	}
+	return addrs[0].(*net.IPNet).IP.String(), nil
+}
+
+func (p *Ping) pingToURLNative(destination string, tags map[string]string, acc telegraf.Accumulator) {
 	stats, err := p.nativePingFunc(destination)
 	if err != nil {
 		p.Log.Errorf("ping failed: %s", err.Error())
@@ -312,6 +365,24 @@ func (p *Ping) Init() error {
 		p.calcTimeout = time.Duration(p.Timeout) * time.Second
 	}
 
+	switch p.AddressFamily {
+	case "":
+	case "ipv4":
+		p.ipVersions = []string{"4"}
+	case "ipv6":
+		p.ipVersions = []string{"6"}
+	case "both":
+		p.ipVersions = []string{"4", "6"}
+	default:
+		return fmt.Errorf("invalid address_family %q", p.AddressFamily)
+	}
+	if p.AddressFamily != "" && (p.IPv4 || p.IPv6) {
+		return errors.New("address_family cannot be used together with ipv4 or ipv6")
+	}
+	if p.lookupIP == nil {
+		p.lookupIP = net.DefaultResolver.LookupIP
+	}
+
 	return nil
 }
 
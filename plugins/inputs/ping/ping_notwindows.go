@@ -29,8 +29,7 @@ type statistics struct {
 	roundTripTimeStats
 }
 
-func (p *Ping) pingToURL(u string, acc telegraf.Accumulator) {
-	tags := map[string]string{"url": u}
+func (p *Ping) pingToURL(u string, tags map[string]string, acc telegraf.Accumulator) {
 	fields := map[string]interface{}{"result_code": 0}
 
 	out, err := p.pingHost(p.Binary, 60.0, p.args(u, runtime.GOOS)...)
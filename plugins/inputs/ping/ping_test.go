@@ -3,8 +3,11 @@
 package ping
 
 import (
+	"context"
 	"errors"
+	"net"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -502,7 +505,7 @@ func TestNoPacketsSent(t *testing.T) {
 	var testAcc testutil.Accumulator
 	require.NoError(t, p.Init())
 
-	p.pingToURLNative("localhost", &testAcc)
+	p.pingToURLNative("localhost", map[string]string{"url": "localhost"}, &testAcc)
 	require.Zero(t, testAcc.Errors)
 	require.True(t, testAcc.HasField("ping", "result_code"))
 	require.Equal(t, 2, testAcc.Metrics[0].Fields["result_code"])
@@ -524,8 +527,95 @@ func TestDNSLookupError(t *testing.T) {
 	var testAcc testutil.Accumulator
 	require.NoError(t, p.Init())
 
-	p.pingToURLNative("localhost", &testAcc)
+	p.pingToURLNative("localhost", map[string]string{"url": "localhost"}, &testAcc)
 	require.Zero(t, testAcc.Errors)
 	require.True(t, testAcc.HasField("ping", "result_code"))
 	require.Equal(t, 1, testAcc.Metrics[0].Fields["result_code"])
 }
+
+func TestPingGatherAddressFamily(t *testing.T) {
+	lookup := func(_ context.Context, network, host string) ([]net.IP, error) {
+		switch {
+		case host == "dual.example.org" && network == "ip4":
+			return []net.IP{net.ParseIP("192.0.2.1")}, nil
+		case host == "dual.example.org" && network == "ip6":
+			return []net.IP{net.ParseIP("2001:db8::1")}, nil
+		case host == "v4.example.org" && network == "ip4":
+			return []net.IP{net.ParseIP("192.0.2.2")}, nil
+		}
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	var mu sync.Mutex
+	var destinations []string
+	p := &Ping{
+		Log:           testutil.Logger{},
+		Urls:          []string{"dual.example.org", "v4.example.org"},
+		Method:        "native",
+		Count:         1,
+		AddressFamily: "both",
+		lookupIP:      lookup,
+		nativePingFunc: func(destination string) (*pingStats, error) {
+			mu.Lock()
+			destinations = append(destinations, destination)
+			mu.Unlock()
+			return &pingStats{
+				Statistics: ping.Statistics{
+					PacketsSent: 1,
+					PacketsRecv: 1,
+					Rtts:        []time.Duration{time.Millisecond},
+				},
+			}, nil
+		},
+	}
+	require.NoError(t, p.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, acc.GatherError(p.Gather))
+	require.ElementsMatch(t, []string{"192.0.2.1", "2001:db8::1", "192.0.2.2"}, destinations)
+	require.Len(t, acc.Metrics, 4)
+
+	for _, tags := range []map[string]string{
+		{"url": "dual.example.org", "ip_version": "4"},
+		{"url": "dual.example.org", "ip_version": "6"},
+		{"url": "v4.example.org", "ip_version": "4"},
+	} {
+		require.True(t, acc.HasPoint("ping", tags, "result_code", 0), tags)
+		require.True(t, acc.HasPoint("ping", tags, "packets_received", 1), tags)
+	}
+	require.True(t, acc.HasPoint("ping", map[string]string{"url": "v4.example.org", "ip_version": "6"}, "result_code", 1))
+}
+
+func TestPingGatherAddressFamilyExec(t *testing.T) {
+	var mu sync.Mutex
+	var destinations []string
+	p := &Ping{
+		Log:           testutil.Logger{},
+		Urls:          []string{"localhost"},
+		Count:         1,
+		AddressFamily: "ipv6",
+		lookupIP: func(context.Context, string, string) ([]net.IP, error) {
+			return []net.IP{net.ParseIP("::1")}, nil
+		},
+		pingHost: func(_ string, _ float64, args ...string) (string, error) {
+			mu.Lock()
+			destinations = append(destinations, args[len(args)-1])
+			mu.Unlock()
+			return linuxPingOutput, nil
+		},
+	}
+	require.NoError(t, p.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, acc.GatherError(p.Gather))
+	require.Equal(t, []string{"::1"}, destinations)
+	require.True(t, acc.HasPoint("ping", map[string]string{"url": "localhost", "ip_version": "6"}, "packets_received", 5))
+}
+
+func TestAddressFamilyInvalid(t *testing.T) {
+	p := &Ping{Count: 1, AddressFamily: "ipx"}
+	require.ErrorContains(t, p.Init(), "invalid address_family")
+
+	p = &Ping{Count: 1, AddressFamily: "both", IPv6: true}
+	require.ErrorContains(t, p.Init(), "cannot be used together")
+}
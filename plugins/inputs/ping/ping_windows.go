@@ -25,8 +25,7 @@ type statistics struct {
 	roundTripTimeStats
 }
 
-func (p *Ping) pingToURL(host string, acc telegraf.Accumulator) {
-	tags := map[string]string{"url": host}
+func (p *Ping) pingToURL(host string, tags map[string]string, acc telegraf.Accumulator) {
 	fields := map[string]interface{}{"result_code": 0}
 
 	args := p.args(host)
@@ -0,0 +1,233 @@
+package procstat
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// procInfo holds the parent and the start time of a process. The start time
+// allows to detect PIDs reused by a new process between gathers.
+type procInfo struct {
+	ppid    PID
+	started uint64
+}
+
+// processTree is a snapshot of the parent-child relations of all processes
+type processTree struct {
+	info     map[PID]procInfo
+	children map[PID][]PID
+}
+
+// aggregatedProc is a process cached for aggregating the metrics of
+// process trees across gathers
+type aggregatedProc struct {
+	Process
+	started uint64
+	seen    time.Time
+}
+
+func newProcessTree(info map[PID]procInfo) *processTree {
+	children := make(map[PID][]PID, len(info))
+	for pid, i := range info {
+		if i.ppid != pid {
+			children[i.ppid] = append(children[i.ppid], pid)
+		}
+	}
+	return &processTree{info: info, children: children}
+}
+
+// descendants returns all children of the given process including their
+// children recursively
+func (t *processTree) descendants(pid PID) []PID {
+	var result []PID
+	seen := map[PID]bool{pid: true}
+	queue := slices.Clone(t.children[pid])
+	for len(queue) > 0 {
+		child := queue[0]
+		queue = queue[1:]
+		if seen[child] {
+			continue
+		}
+		seen[child] = true
+		result = append(result, child)
+		queue = append(queue, t.children[child]...)
+	}
+	return result
+}
+
+// roots returns the given processes not being a descendant of any other of
+// the given processes, as those are accounted for by their ancestor
+func (t *processTree) roots(pids []PID) []PID {
+	matched := make(map[PID]bool, len(pids))
+	for _, pid := range pids {
+		matched[pid] = true
+	}
+
+	roots := make([]PID, 0, len(pids))
+	for _, pid := range pids {
+		if !matched[pid] || t.hasAncestor(pid, matched) {
+			continue
+		}
+		roots = append(roots, pid)
+		// Skip duplicates
+		matched[pid] = false
+	}
+	return roots
+}
+
+// hasAncestor checks if any of the ancestors of the given process is contained
+// in the given set
+func (t *processTree) hasAncestor(pid PID, set map[PID]bool) bool {
+	// Limit the depth to not loop forever on inconsistent snapshots
+	for range len(t.info) {
+		info, found := t.info[pid]
+		if !found || info.ppid == pid || info.ppid == 0 {
+			return false
+		}
+		if _, found := set[info.ppid]; found {
+			return true
+		}
+		pid = info.ppid
+	}
+	return false
+}
+
+// gatherAggregated adds one metric for each of the given processes, not being
+// a descendant of another given process, with the metrics of all descendants
+// summed up. The number of collected process trees is returned.
+func (p *Procstat) gatherAggregated(
+	acc telegraf.Accumulator,
+	pids []PID,
+	tags map[string]string,
+	tree *processTree,
+	now time.Time,
+) int {
+	var count int
+	for _, root := range tree.roots(pids) {
+		proc, ok := p.aggregatedProcess(root, tree, now)
+		if !ok {
+			continue
+		}
+		for k, v := range tags {
+			proc.SetTag(k, v)
+		}
+		if p.ProcessName != "" {
+			proc.SetTag("process_name", p.ProcessName)
+		}
+
+		metrics, err := proc.Metrics(p.Prefix, &p.cfg, now)
+		if err != nil {
+			// Continue after logging an error as there might still be
+			// metrics available
+			acc.AddError(err)
+		}
+		if len(metrics) == 0 {
+			continue
+		}
+		count++
+
+		prefix := p.Prefix
+		if prefix != "" {
+			prefix += "_"
+		}
+		fields := metrics[0].Fields()
+		var children int
+		for _, pid := range tree.descendants(root) {
+			child, ok := p.aggregatedProcess(pid, tree, now)
+			if !ok {
+				continue
+			}
+			// Errors are ignored as children might exit at any time
+			//nolint:errcheck // only collecting the available metrics
+			cm, _ := child.Metrics(p.Prefix, &p.cfg, now)
+			if len(cm) == 0 {
+				continue
+			}
+			sumFields(fields, cm[0].Fields(), prefix)
+			children++
+		}
+		fields[prefix+"num_children"] = children
+		delete(fields, "pid")
+
+		mtags := metrics[0].Tags()
+		mtags["pid"] = strconv.Itoa(int(root))
+		acc.AddFields(metrics[0].Name(), fields, mtags, now)
+
+		// Keep the socket metrics of the root process
+		for _, m := range metrics[1:] {
+			acc.AddMetric(m)
+		}
+	}
+	return count
+}
+
+// aggregatedProcess returns the cached process for the given PID or creates
+// a new instance if not cached or if the PID got reused by another process
+func (p *Procstat) aggregatedProcess(pid PID, tree *processTree, now time.Time) (Process, bool) {
+	started := tree.info[pid].started
+	if cached, found := p.aggregated[pid]; found && cached.started == started {
+		cached.seen = now
+		return cached.Process, true
+	}
+
+	proc, err := p.createProcess(pid)
+	if err != nil {
+		// The process may have ended after listing it
+		delete(p.aggregated, pid)
+		return nil, false
+	}
+	//nolint:errcheck // Assumption: if a process has no name, it probably does not exist
+	if name, _ := proc.Name(); name == "" {
+		delete(p.aggregated, pid)
+		return nil, false
+	}
+	p.aggregated[pid] = &aggregatedProc{Process: proc, started: started, seen: now}
+	return proc, true
+}
+
+// loadProcessTree takes a snapshot of all processes for aggregating children
+func (p *Procstat) loadProcessTree() (*processTree, error) {
+	info, err := p.listProcesses()
+	if err != nil {
+		return nil, err
+	}
+	return newProcessTree(info), nil
+}
+
+// cleanupAggregated removes the processes not seen in the gather cycle
+func (p *Procstat) cleanupAggregated(now time.Time) {
+	for pid, cached := range p.aggregated {
+		if !cached.seen.Equal(now) {
+			delete(p.aggregated, pid)
+		}
+	}
+}
+
+// sumFields adds the numeric fields of a child process to the given fields.
+// Fields describing the process itself, like its PID, creation time or
+// resource limits, are kept from the root process.
+func sumFields(total, fields map[string]interface{}, prefix string) {
+	for k, v := range fields {
+		name := strings.TrimPrefix(k, prefix)
+		if k == "pid" || name == "ppid" || name == "created_at" || strings.HasPrefix(name, "rlimit_") {
+			continue
+		}
+
+		// Metric fields are normalized to 64-bit types
+		switch v := v.(type) {
+		case int64:
+			c, _ := total[k].(int64)
+			total[k] = c + v
+		case uint64:
+			c, _ := total[k].(uint64)
+			total[k] = c + v
+		case float64:
+			c, _ := total[k].(float64)
+			total[k] = c + v
+		}
+	}
+}
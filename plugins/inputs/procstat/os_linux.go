@@ -25,6 +25,31 @@ func processName(p *process.Process) (string, error) {
 	return p.Exe()
 }
 
+// listProcesses returns the parent and start time of all processes by reading
+// the process stats once, which is much cheaper than querying the processes
+// individually on hosts with many processes
+func listProcesses() (map[PID]procInfo, error) {
+	fs, err := procfs.NewFS(internal.GetProcPath())
+	if err != nil {
+		return nil, err
+	}
+	procs, err := fs.AllProcs()
+	if err != nil {
+		return nil, err
+	}
+
+	info := make(map[PID]procInfo, len(procs))
+	for _, proc := range procs {
+		stat, err := proc.Stat()
+		if err != nil {
+			// The process may have ended in the meantime
+			continue
+		}
+		info[PID(stat.PID)] = procInfo{ppid: PID(stat.PPID), started: stat.Starttime}
+	}
+	return info, nil
+}
+
 func queryPidWithWinServiceName(_ string) (uint32, error) {
 	return 0, errors.New("os not supporting win_service option")
 }
@@ -45,7 +70,9 @@ func collectMemmap(proc Process, prefix string, fields map[string]any) {
 	}
 }
 
-func findBySystemdUnits(units []string) ([]processGroup, error) {
+// listSystemdUnits returns the active systemd services matching the given
+// patterns together with their main PID and control group
+func listSystemdUnits(patterns []string) ([]systemdUnit, error) {
 	ctx := context.Background()
 	conn, err := dbus.NewSystemConnectionContext(ctx)
 	if err != nil {
@@ -53,13 +80,18 @@ func findBySystemdUnits(units []string) ([]processGroup, error) {
 	}
 	defer conn.Close()
 
-	sdunits, err := conn.ListUnitsByPatternsContext(ctx, []string{"enabled", "disabled", "static"}, units)
+	sdunits, err := conn.ListUnitsByPatternsContext(ctx, nil, patterns)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list units: %w", err)
 	}
 
-	groups := make([]processGroup, 0, len(sdunits))
+	units := make([]systemdUnit, 0, len(sdunits))
 	for _, u := range sdunits {
+		// Inactive units do not have any processes
+		if u.ActiveState != "active" && u.ActiveState != "reloading" {
+			continue
+		}
+
 		prop, err := conn.GetUnitTypePropertyContext(ctx, u.Name, "Service", "MainPID")
 		if err != nil {
 			// This unit might not be a service or similar
@@ -68,15 +100,39 @@ func findBySystemdUnits(units []string) ([]processGroup, error) {
 		raw := prop.Value.Value()
 		pid, ok := raw.(uint32)
 		if !ok {
-			return nil, fmt.Errorf("failed to parse PID %v of unit %q: invalid type %T", raw, u, raw)
+			return nil, fmt.Errorf("failed to parse PID %v of unit %q: invalid type %T", raw, u.Name, raw)
+		}
+
+		unit := systemdUnit{name: u.Name, mainPID: PID(pid)}
+		if prop, err := conn.GetUnitTypePropertyContext(ctx, u.Name, "Service", "ControlGroup"); err == nil {
+			if cgroup, ok := prop.Value.Value().(string); ok {
+				unit.cgroup = cgroup
+			}
+		}
+		units = append(units, unit)
+	}
+
+	return units, nil
+}
+
+func findBySystemdUnits(units []string) ([]processGroup, error) {
+	sdunits, err := listSystemdUnits(units)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]processGroup, 0, len(sdunits))
+	for _, u := range sdunits {
+		if u.mainPID == 0 {
+			continue
 		}
-		p, err := process.NewProcess(int32(pid))
+		p, err := process.NewProcess(int32(u.mainPID))
 		if err != nil {
-			return nil, fmt.Errorf("failed to find process for PID %d of unit %q: %w", pid, u, err)
+			return nil, fmt.Errorf("failed to find process for PID %d of unit %q: %w", u.mainPID, u.name, err)
 		}
 		groups = append(groups, processGroup{
 			processes: []*process.Process{p},
-			tags:      map[string]string{"systemd_unit": u.Name},
+			tags:      map[string]string{"systemd_unit": u.name},
 		})
 	}
 
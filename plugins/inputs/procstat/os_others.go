@@ -14,12 +14,37 @@ func processName(p *process.Process) (string, error) {
 	return p.Exe()
 }
 
+// listProcesses returns the parent and start time of all processes
+func listProcesses() (map[PID]procInfo, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := make(map[PID]procInfo, len(procs))
+	for _, proc := range procs {
+		ppid, err := proc.Ppid()
+		if err != nil {
+			// The process may have ended in the meantime
+			continue
+		}
+		//nolint:errcheck // the start time is only used to detect reused PIDs
+		created, _ := proc.CreateTime()
+		info[PID(proc.Pid)] = procInfo{ppid: PID(ppid), started: uint64(created)}
+	}
+	return info, nil
+}
+
 func queryPidWithWinServiceName(string) (uint32, error) {
 	return 0, errors.New("os not supporting win_service option")
 }
 
 func collectMemmap(Process, string, map[string]any) {}
 
+func listSystemdUnits([]string) ([]systemdUnit, error) {
+	return nil, errors.New("os not supporting systemd units")
+}
+
 func findBySystemdUnits([]string) ([]processGroup, error) {
 	return nil, nil
 }
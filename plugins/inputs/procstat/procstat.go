@@ -28,6 +28,9 @@ var sampleConfig string
 // execCommand is so tests can mock out exec.Command usage.
 var execCommand = exec.Command
 
+// cgroupRoot is the mount point of the cgroup hierarchies, tests may replace it
+var cgroupRoot = "/sys/fs/cgroup"
+
 type PID int32
 
 type collectionConfig struct {
@@ -58,14 +61,18 @@ type Procstat struct {
 	SocketProtocols        []string        `toml:"socket_protocols"`
 	TagWith                []string        `toml:"tag_with"`
 	Filter                 []Filter        `toml:"filter"`
+	AggregateChildren      bool            `toml:"aggregate_children"`
 	Log                    telegraf.Logger `toml:"-"`
 
-	finder    PIDFinder
-	processes map[PID]Process
-	cfg       collectionConfig
-	oldMode   bool
+	finder     PIDFinder
+	processes  map[PID]Process
+	aggregated map[PID]*aggregatedProc
+	cfg        collectionConfig
+	oldMode    bool
 
 	createProcess func(PID) (Process, error)
+	listProcesses func() (map[PID]procInfo, error)
+	listUnits     func([]string) ([]systemdUnit, error)
 }
 
 type PidsTags struct {
@@ -73,6 +80,14 @@ type PidsTags struct {
 	Tags map[string]string
 }
 
+// systemdUnit is an active systemd service with its main PID and the path of
+// its control group relative to the cgroup root
+type systemdUnit struct {
+	name    string
+	mainPID PID
+	cgroup  string
+}
+
 type processGroup struct {
 	processes []*process.Process
 	tags      map[string]string
@@ -204,6 +219,7 @@ func (p *Procstat) Init() error {
 
 	// Initialize the running process cache
 	p.processes = make(map[PID]Process)
+	p.aggregated = make(map[PID]*aggregatedProc)
 
 	return nil
 }
@@ -239,13 +255,25 @@ func (p *Procstat) gatherOld(acc telegraf.Accumulator) error {
 		return err
 	}
 
-	var count int
+	var tree *processTree
+	if p.AggregateChildren {
+		if tree, err = p.loadProcessTree(); err != nil {
+			return fmt.Errorf("listing processes failed: %w", err)
+		}
+		defer p.cleanupAggregated(now)
+	}
+
+	var count, aggregated int
 	running := make(map[PID]bool)
 	for _, r := range results {
 		if len(r.PIDs) < 1 && len(p.SupervisorUnits) > 0 {
 			continue
 		}
 		count += len(r.PIDs)
+		if tree != nil {
+			aggregated += p.gatherAggregated(acc, r.PIDs, r.Tags, tree, now)
+			continue
+		}
 		for _, pid := range r.PIDs {
 			// Check if the process is still running
 			proc, err := p.createProcess(pid)
@@ -302,7 +330,7 @@ func (p *Procstat) gatherOld(acc telegraf.Accumulator) error {
 	// Add lookup statistics-metric
 	fields := map[string]interface{}{
 		"pid_count":   count,
-		"running":     len(running),
+		"running":     len(running) + aggregated,
 		"result_code": 0,
 	}
 	tags := map[string]string{
@@ -324,6 +352,16 @@ func (p *Procstat) gatherOld(acc telegraf.Accumulator) error {
 
 func (p *Procstat) gatherNew(acc telegraf.Accumulator) error {
 	now := time.Now()
+
+	var tree *processTree
+	if p.AggregateChildren {
+		var err error
+		if tree, err = p.loadProcessTree(); err != nil {
+			return fmt.Errorf("listing processes failed: %w", err)
+		}
+		defer p.cleanupAggregated(now)
+	}
+
 	running := make(map[PID]bool)
 	for _, f := range p.Filter {
 		groups, err := f.ApplyFilter()
@@ -346,9 +384,22 @@ func (p *Procstat) gatherNew(acc telegraf.Accumulator) error {
 			continue
 		}
 
-		var count int
+		var count, aggregated int
 		for _, g := range groups {
 			count += len(g.processes)
+			if tree != nil {
+				pids := make([]PID, 0, len(g.processes))
+				for _, gp := range g.processes {
+					pids = append(pids, PID(gp.Pid))
+				}
+				tags := make(map[string]string, len(g.tags)+1)
+				for k, v := range g.tags {
+					tags[k] = v
+				}
+				tags["filter"] = f.Name
+				aggregated += p.gatherAggregated(acc, pids, tags, tree, now)
+				continue
+			}
 			for _, gp := range g.processes {
 				// Skip over non-running processes
 				if running, err := gp.IsRunning(); err != nil || !running {
@@ -401,7 +452,7 @@ func (p *Procstat) gatherNew(acc telegraf.Accumulator) error {
 			"procstat_lookup",
 			map[string]interface{}{
 				"pid_count":   count,
-				"running":     len(running),
+				"running":     len(running) + aggregated,
 				"result_code": 0,
 			},
 			map[string]string{
@@ -560,6 +611,9 @@ func (p *Procstat) supervisorPIDs() ([]string, map[string]map[string]string, err
 }
 
 func (p *Procstat) systemdUnitPIDs() ([]PidsTags, error) {
+	if strings.ContainsAny(p.SystemdUnit, "*?[") {
+		return p.systemdUnitGlobPIDs()
+	}
 	if p.IncludeSystemdChildren {
 		p.CGroup = "systemd/system.slice/" + p.SystemdUnit
 		return p.cgroupPIDs()
@@ -575,6 +629,65 @@ func (p *Procstat) systemdUnitPIDs() ([]PidsTags, error) {
 	return pidTags, nil
 }
 
+// systemdUnitGlobPIDs resolves the units matching the glob pattern via
+// systemd's D-Bus API and returns one group per unit
+func (p *Procstat) systemdUnitGlobPIDs() ([]PidsTags, error) {
+	units, err := p.listUnits([]string{p.SystemdUnit})
+	if err != nil {
+		return nil, err
+	}
+
+	pidTags := make([]PidsTags, 0, len(units))
+	for _, u := range units {
+		pids := p.systemdUnitCgroupPIDs(u)
+		if len(pids) == 0 {
+			if u.mainPID == 0 {
+				// The unit has no processes, e.g. as it is exiting
+				continue
+			}
+			pids = []PID{u.mainPID}
+		}
+		tags := map[string]string{"systemd_unit": u.name}
+		pidTags = append(pidTags, PidsTags{pids, tags})
+	}
+	return pidTags, nil
+}
+
+// systemdUnitCgroupPIDs returns the PIDs of all processes in the control group
+// of the unit if children should be included. The unified hierarchy is
+// preferred over the legacy systemd hierarchy if available.
+func (p *Procstat) systemdUnitCgroupPIDs(u systemdUnit) []PID {
+	if !p.IncludeSystemdChildren || u.cgroup == "" {
+		return nil
+	}
+
+	root := cgroupRoot
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		root = filepath.Join(cgroupRoot, "systemd")
+	}
+
+	var pids []PID
+	err := filepath.WalkDir(filepath.Join(root, u.cgroup), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		found, err := p.singleCgroupPIDs(path)
+		if err != nil {
+			return err
+		}
+		pids = append(pids, found...)
+		return nil
+	})
+	if err != nil {
+		p.Log.Debugf("Reading control group of unit %q failed, only using main PID: %v", u.name, err)
+		return nil
+	}
+	return pids
+}
+
 func (p *Procstat) simpleSystemdUnitPIDs() ([]PID, error) {
 	out, err := execCommand("systemctl", "show", p.SystemdUnit).Output()
 	if err != nil {
@@ -684,6 +797,8 @@ func init() {
 		return &Procstat{
 			Properties:    []string{"cpu", "memory", "mmap"},
 			createProcess: newProc,
+			listProcesses: listProcesses,
+			listUnits:     listSystemdUnits,
 		}
 	})
 }
@@ -571,6 +571,96 @@ func TestGather_systemdUnitPIDs(t *testing.T) {
 	}
 }
 
+func TestGather_systemdUnitGlobPIDs(t *testing.T) {
+	// no cgroups in windows
+	if runtime.GOOS == "windows" {
+		t.Skip("no cgroups in windows")
+	}
+
+	units := []systemdUnit{
+		{name: "worker@1.service", mainPID: 1234, cgroup: "/system.slice/worker@1.service"},
+		{name: "worker@2.service", mainPID: 5678, cgroup: "/system.slice/worker@2.service"},
+		{name: "worker@3.service", cgroup: "/system.slice/worker@3.service"},
+	}
+
+	tests := []struct {
+		name     string
+		children bool
+		legacy   bool
+		expected map[string][]PID
+	}{
+		{
+			name: "main PID",
+			expected: map[string][]PID{
+				"worker@1.service": {1234},
+				"worker@2.service": {5678},
+			},
+		},
+		{
+			name:     "unified hierarchy",
+			children: true,
+			expected: map[string][]PID{
+				"worker@1.service": {1234, 1235, 1236},
+				"worker@2.service": {5678},
+			},
+		},
+		{
+			name:     "legacy hierarchy",
+			children: true,
+			legacy:   true,
+			expected: map[string][]PID{
+				"worker@1.service": {1234, 1235, 1236},
+				"worker@2.service": {5678},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup the control group of the first unit only
+			td := t.TempDir()
+			root := td
+			if tt.legacy {
+				root = filepath.Join(td, "systemd")
+			} else {
+				require.NoError(t, os.WriteFile(filepath.Join(td, "cgroup.controllers"), []byte("cpu memory pids\n"), 0640))
+			}
+			cgroup := filepath.Join(root, units[0].cgroup)
+			require.NoError(t, os.MkdirAll(filepath.Join(cgroup, "worker"), 0750))
+			require.NoError(t, os.WriteFile(filepath.Join(cgroup, "cgroup.procs"), []byte("1234\n1235\n"), 0640))
+			require.NoError(t, os.WriteFile(filepath.Join(cgroup, "worker", "cgroup.procs"), []byte("1236\n"), 0640))
+
+			defer func(root string) { cgroupRoot = root }(cgroupRoot)
+			cgroupRoot = td
+
+			var patterns []string
+			p := Procstat{
+				SystemdUnit:            "worker@*.service",
+				IncludeSystemdChildren: tt.children,
+				PidFinder:              "test",
+				Properties:             []string{"cpu", "memory", "mmap"},
+				Log:                    testutil.Logger{},
+				finder:                 newTestFinder([]PID{pid}),
+				listUnits: func(p []string) ([]systemdUnit, error) {
+					patterns = p
+					return units, nil
+				},
+			}
+			require.NoError(t, p.Init())
+
+			pidsTags, err := p.findPids()
+			require.NoError(t, err)
+			require.Equal(t, []string{"worker@*.service"}, patterns)
+
+			actual := make(map[string][]PID, len(pidsTags))
+			for _, pidsTag := range pidsTags {
+				actual[pidsTag.Tags["systemd_unit"]] = pidsTag.PIDs
+			}
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
 func TestGather_cgroupPIDs(t *testing.T) {
 	// no cgroups in windows
 	if runtime.GOOS == "windows" {
@@ -678,3 +768,98 @@ func TestGather_MoresupervisorUnitPIDs(t *testing.T) {
 		}
 	}
 }
+
+func TestGather_AggregateChildren(t *testing.T) {
+	tree := map[PID]procInfo{
+		1:   {ppid: 0, started: 1},
+		10:  {ppid: 1, started: 2},
+		11:  {ppid: 1, started: 3},
+		100: {ppid: 10, started: 4},
+		2:   {ppid: 0, started: 5},
+		20:  {ppid: 2, started: 6},
+		3:   {ppid: 0, started: 7},
+	}
+
+	created := make(map[PID]int)
+	p := Procstat{
+		Exe:               exe,
+		PidFinder:         "test",
+		AggregateChildren: true,
+		Log:               testutil.Logger{},
+		finder:            newTestFinder([]PID{1, 10, 2}),
+		createProcess: func(pid PID) (Process, error) {
+			created[pid]++
+			proc, err := newTestProc(pid)
+			return &threadedProc{proc.(*testProc)}, err
+		},
+		listProcesses: func() (map[PID]procInfo, error) {
+			return tree, nil
+		},
+	}
+	require.NoError(t, p.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, p.Gather(&acc))
+
+	// The matched child 10 is accounted for by its parent
+	expected := map[string][2]int64{
+		"1": {8, 3},
+		"2": {4, 1},
+	}
+	actual := make(map[string][2]int64)
+	for _, m := range acc.GetTelegrafMetrics() {
+		if m.Name() != "procstat" {
+			continue
+		}
+		require.False(t, m.HasField("pid"))
+		pid, found := m.GetTag("pid")
+		require.True(t, found)
+		threads, found := m.GetField("num_threads")
+		require.True(t, found)
+		children, found := m.GetField("num_children")
+		require.True(t, found)
+		actual[pid] = [2]int64{threads.(int64), children.(int64)}
+	}
+	require.Equal(t, expected, actual)
+	lookup, found := acc.Get("procstat_lookup")
+	require.True(t, found)
+	require.Equal(t, 3, lookup.Fields["pid_count"])
+	require.Equal(t, 2, lookup.Fields["running"])
+
+	// Processes are cached across gathers unless their PID was reused
+	tree[20] = procInfo{ppid: 2, started: 8}
+	delete(tree, 11)
+	acc.ClearMetrics()
+	require.NoError(t, p.Gather(&acc))
+	require.Equal(t, map[PID]int{1: 1, 10: 1, 11: 1, 100: 1, 2: 1, 20: 2}, created)
+	require.NotContains(t, p.aggregated, PID(11))
+	require.NotContains(t, p.aggregated, PID(3))
+}
+
+func TestProcessTreeRoots(t *testing.T) {
+	tree := newProcessTree(map[PID]procInfo{
+		1:  {ppid: 0},
+		10: {ppid: 1},
+		11: {ppid: 10},
+		2:  {ppid: 0},
+	})
+
+	require.ElementsMatch(t, []PID{10, 11}, tree.descendants(1))
+	require.Equal(t, []PID{11}, tree.descendants(10))
+	require.Empty(t, tree.descendants(2))
+	require.Equal(t, []PID{1, 2}, tree.roots([]PID{11, 1, 2, 1}))
+	require.Equal(t, []PID{10, 42}, tree.roots([]PID{10, 11, 42}))
+}
+
+// threadedProc reports two threads per process
+type threadedProc struct {
+	*testProc
+}
+
+func (p *threadedProc) Metrics(prefix string, cfg *collectionConfig, t time.Time) ([]telegraf.Metric, error) {
+	metrics, err := p.testProc.Metrics(prefix, cfg, t)
+	for _, m := range metrics {
+		m.AddField("num_threads", int32(2))
+	}
+	return metrics, err
+}
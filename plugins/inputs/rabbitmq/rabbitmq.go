@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"sync"
 	"time"
@@ -50,6 +51,7 @@ type RabbitMQ struct {
 	MetricExclude             []string `toml:"metric_exclude"`
 	QueueInclude              []string `toml:"queue_name_include"`
 	QueueExclude              []string `toml:"queue_name_exclude"`
+	QueueDetails              bool     `toml:"queue_details"`
 	FederationUpstreamInclude []string `toml:"federation_upstream_include"`
 	FederationUpstreamExclude []string `toml:"federation_upstream_exclude"`
 
@@ -130,6 +132,15 @@ type queue struct {
 	SlaveNodes             []string `json:"slave_nodes"`
 	SynchronisedSlaveNodes []string `json:"synchronised_slave_nodes"`
 	HeadMessageTimestamp   *int64   `json:"head_message_timestamp"`
+
+	// Only available in the details of a single queue
+	ConsumerCapacity          *float64 `json:"consumer_capacity"`
+	MessagesRAM               int64    `json:"messages_ram"`
+	MessagesReadyRAM          int64    `json:"messages_ready_ram"`
+	MessagesUnacknowledgedRAM int64    `json:"messages_unacknowledged_ram"`
+	MessagesPersistent        int64    `json:"messages_persistent"`
+	MessagesPagedOut          int64    `json:"messages_paged_out"`
+	MessageBytesPagedOut      int64    `json:"message_bytes_paged_out"`
 }
 
 type node struct {
@@ -603,6 +614,17 @@ func gatherQueues(r *RabbitMQ, acc telegraf.Accumulator) {
 			fields["head_message_timestamp"] = *queue.HeadMessageTimestamp
 		}
 
+		if r.QueueDetails {
+			// The details are only available for a single queue and more
+			// expensive to compute, so only query the filtered queues
+			details, err := r.requestQueueDetails(queue.Vhost, queue.Name)
+			if err != nil {
+				acc.AddError(err)
+			} else {
+				addQueueDetails(fields, details)
+			}
+		}
+
 		acc.AddFields(
 			"rabbitmq_queue",
 			fields,
@@ -611,6 +633,37 @@ func gatherQueues(r *RabbitMQ, acc telegraf.Accumulator) {
 	}
 }
 
+func (r *RabbitMQ) requestQueueDetails(vhost, name string) (*queue, error) {
+	details := &queue{}
+	u := "/api/queues/" + url.PathEscape(vhost) + "/" + url.PathEscape(name)
+	if err := r.requestJSON(u, details); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+func addQueueDetails(fields map[string]interface{}, details *queue) {
+	fields["memory"] = details.Memory
+	fields["consumer_utilisation"] = details.ConsumerUtilisation
+	fields["messages_ram"] = details.MessagesRAM
+	fields["messages_ready_ram"] = details.MessagesReadyRAM
+	fields["messages_unack_ram"] = details.MessagesUnacknowledgedRAM
+	fields["messages_persist"] = details.MessagesPersistent
+	fields["messages_paged_out"] = details.MessagesPagedOut
+	fields["message_bytes_paged_out"] = details.MessageBytesPagedOut
+
+	if details.ConsumerCapacity != nil {
+		fields["consumer_capacity"] = *details.ConsumerCapacity
+	}
+
+	// Queues without messages or without the timestamp plugin enabled do not
+	// report a timestamp, so the age is omitted instead of being zero
+	if details.HeadMessageTimestamp != nil {
+		fields["head_message_timestamp"] = *details.HeadMessageTimestamp
+		fields["head_message_age"] = max(time.Now().Unix()-*details.HeadMessageTimestamp, 0)
+	}
+}
+
 func gatherExchanges(r *RabbitMQ, acc telegraf.Accumulator) {
 	// Gather information about exchanges
 	exchanges := make([]exchange, 0)
@@ -686,3 +686,85 @@ func TestRabbitMQMetricFilerts(t *testing.T) {
 		require.ElementsMatch(t, expected, acc.Errors)
 	}
 }
+
+func TestRabbitMQQueueDetails(t *testing.T) {
+	timestamp := time.Now().Add(-90 * time.Second).Unix()
+
+	queues := `[
+		{"name": "jobs", "vhost": "/", "node": "rabbit@node1", "consumers": 2, "messages": 12},
+		{"name": "empty", "vhost": "/", "node": "rabbit@node1", "consumers": 1},
+		{"name": "ignored", "vhost": "/", "node": "rabbit@node1"}
+	]`
+	details := map[string]string{
+		"/api/queues/%2F/jobs": fmt.Sprintf(`{
+			"name": "jobs", "vhost": "/", "node": "rabbit@node1", "consumers": 2, "messages": 12,
+			"memory": 55000, "consumer_utilisation": 0.5, "consumer_capacity": 0.25,
+			"head_message_timestamp": %d,
+			"messages_ram": 10, "messages_ready_ram": 8, "messages_unacknowledged_ram": 2,
+			"messages_persistent": 12, "messages_paged_out": 2, "message_bytes_paged_out": 2048
+		}`, timestamp),
+		"/api/queues/%2F/empty": `{
+			"name": "empty", "vhost": "/", "node": "rabbit@node1", "consumers": 1,
+			"memory": 10000, "consumer_utilisation": 1.0, "consumer_capacity": 1.0,
+			"head_message_timestamp": null
+		}`,
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		if r.URL.Path == "/api/queues" {
+			response = queues
+		} else if d, found := details[r.URL.EscapedPath()]; found {
+			response = d
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+			t.Errorf("unknown path %q", r.URL.EscapedPath())
+			return
+		}
+		if _, err := w.Write([]byte(response)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			t.Error(err)
+			return
+		}
+	}))
+	defer ts.Close()
+
+	plugin := &RabbitMQ{
+		URL:           ts.URL,
+		MetricInclude: []string{"queue"},
+		QueueExclude:  []string{"ignored"},
+		QueueDetails:  true,
+		Log:           testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Empty(t, acc.Errors)
+	require.Len(t, acc.GetTelegrafMetrics(), 2)
+
+	metrics := make(map[string]*testutil.Metric)
+	for _, m := range acc.Metrics {
+		metrics[m.Tags["queue"]] = m
+	}
+	jobs := metrics["jobs"]
+	require.NotNil(t, jobs)
+	require.Equal(t, int64(55000), jobs.Fields["memory"])
+	require.InDelta(t, 0.5, jobs.Fields["consumer_utilisation"], 1e-9)
+	require.InDelta(t, 0.25, jobs.Fields["consumer_capacity"], 1e-9)
+	require.Equal(t, timestamp, jobs.Fields["head_message_timestamp"])
+	require.InDelta(t, 90, jobs.Fields["head_message_age"], 5)
+	require.Equal(t, int64(10), jobs.Fields["messages_ram"])
+	require.Equal(t, int64(8), jobs.Fields["messages_ready_ram"])
+	require.Equal(t, int64(2), jobs.Fields["messages_unack_ram"])
+	require.Equal(t, int64(12), jobs.Fields["messages_persist"])
+	require.Equal(t, int64(2), jobs.Fields["messages_paged_out"])
+	require.Equal(t, int64(2048), jobs.Fields["message_bytes_paged_out"])
+
+	empty := metrics["empty"]
+	require.NotNil(t, empty)
+	require.Equal(t, int64(10000), empty.Fields["memory"])
+	require.InDelta(t, 1.0, empty.Fields["consumer_capacity"], 1e-9)
+	require.NotContains(t, empty.Fields, "head_message_age")
+	require.NotContains(t, empty.Fields, "head_message_timestamp")
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
@@ -29,9 +30,15 @@ var (
 )
 
 type Sensors struct {
-	RemoveNumbers bool            `toml:"remove_numbers"`
-	Timeout       config.Duration `toml:"timeout"`
-	path          string
+	RemoveNumbers   bool            `toml:"remove_numbers"`
+	Timeout         config.Duration `toml:"timeout"`
+	ChipInclude     []string        `toml:"chip_include"`
+	FeatureInclude  []string        `toml:"feature_include"`
+	AlarmsAsInteger bool            `toml:"alarms_as_integer"`
+	path            string
+
+	chipFilter    filter.Filter
+	featureFilter filter.Filter
 }
 
 const cmd = "sensors"
@@ -55,6 +62,14 @@ func (s *Sensors) Init() error {
 		return fmt.Errorf("no path specified for %q", cmd)
 	}
 
+	var err error
+	if s.chipFilter, err = filter.Compile(s.ChipInclude); err != nil {
+		return fmt.Errorf("creating chip filter failed: %w", err)
+	}
+	if s.featureFilter, err = filter.Compile(s.FeatureInclude); err != nil {
+		return fmt.Errorf("creating feature filter failed: %w", err)
+	}
+
 	return nil
 }
 
@@ -75,6 +90,7 @@ func (s *Sensors) parse(acc telegraf.Accumulator) error {
 	tags := make(map[string]string)
 	fields := make(map[string]interface{})
 	chip := ""
+	var skipChip, skipFeature bool
 	cmd := execCommand(s.path, "-A", "-u")
 	out, err := internal.StdOutputTimeout(cmd, time.Duration(s.Timeout))
 	if err != nil {
@@ -83,8 +99,11 @@ func (s *Sensors) parse(acc telegraf.Accumulator) error {
 	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
 	for _, line := range lines {
 		if len(line) == 0 {
-			acc.AddFields("sensors", fields, tags)
+			if !skipChip && !skipFeature {
+				acc.AddFields("sensors", fields, tags)
+			}
 			chip = ""
+			skipChip, skipFeature = false, false
 			tags = make(map[string]string)
 			fields = make(map[string]interface{})
 			continue
@@ -92,18 +111,28 @@ func (s *Sensors) parse(acc telegraf.Accumulator) error {
 		if len(chip) == 0 {
 			chip = line
 			tags["chip"] = chip
+			skipChip = s.chipFilter != nil && !s.chipFilter.Match(chip)
+			continue
+		}
+		// Skip filtered chips and features before parsing their values
+		if skipChip {
 			continue
 		}
 		if !strings.HasPrefix(line, "  ") {
-			if len(tags) > 1 {
+			if len(tags) > 1 && !skipFeature {
 				acc.AddFields("sensors", fields, tags)
 			}
+			feature := strings.TrimRight(snake(line), ":")
+			skipFeature = s.featureFilter != nil && !s.featureFilter.Match(feature)
 			fields = make(map[string]interface{})
 			tags = map[string]string{
 				"chip":    chip,
-				"feature": strings.TrimRight(snake(line), ":"),
+				"feature": feature,
 			}
 		} else {
+			if skipFeature {
+				continue
+			}
 			splitted := strings.Split(line, ":")
 			fieldName := strings.TrimSpace(splitted[0])
 			if s.RemoveNumbers {
@@ -113,10 +142,17 @@ func (s *Sensors) parse(acc telegraf.Accumulator) error {
 			if err != nil {
 				return err
 			}
-			fields[fieldName] = fieldValue
+			isFlag := strings.HasSuffix(fieldName, "_alarm") || strings.HasSuffix(fieldName, "_fault")
+			if s.AlarmsAsInteger && isFlag {
+				fields[fieldName] = int64(fieldValue)
+			} else {
+				fields[fieldName] = fieldValue
+			}
 		}
 	}
-	acc.AddFields("sensors", fields, tags)
+	if !skipChip && !skipFeature {
+		acc.AddFields("sensors", fields, tags)
+	}
 	return nil
 }
 
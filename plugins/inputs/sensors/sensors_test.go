@@ -7,9 +7,12 @@ import (
 	"os"
 	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/testutil"
 )
 
@@ -290,6 +293,127 @@ func TestGatherNotRemoveNumbers(t *testing.T) {
 	}
 }
 
+func TestGatherAlarms(t *testing.T) {
+	tests := []struct {
+		name            string
+		removeNumbers   bool
+		alarmsAsInteger bool
+		chipInclude     []string
+		featureInclude  []string
+		expected        []telegraf.Metric
+	}{
+		{
+			name:            "remove numbers",
+			removeNumbers:   true,
+			alarmsAsInteger: true,
+			expected: []telegraf.Metric{
+				metric.New("sensors",
+					map[string]string{"chip": "nct6775-isa-0290", "feature": "vcore"},
+					map[string]interface{}{"in_input": 0.352, "in_min": 0.0, "in_max": 1.744, "in_alarm": int64(0)},
+					time.Unix(0, 0),
+				),
+				metric.New("sensors",
+					map[string]string{"chip": "nct6775-isa-0290", "feature": "fan1"},
+					map[string]interface{}{"fan_input": 0.0, "fan_min": 300.0, "fan_alarm": int64(1)},
+					time.Unix(0, 0),
+				),
+				metric.New("sensors",
+					map[string]string{"chip": "nct6775-isa-0290", "feature": "cputin"},
+					map[string]interface{}{
+						"temp_input":    127.5,
+						"temp_max":      80.0,
+						"temp_max_hyst": 75.0,
+						"temp_alarm":    int64(1),
+						"temp_fault":    int64(1),
+					},
+					time.Unix(0, 0),
+				),
+				metric.New("sensors",
+					map[string]string{"chip": "nct6775-isa-0290", "feature": "intrusion0"},
+					map[string]interface{}{"intrusion_alarm": int64(1)},
+					time.Unix(0, 0),
+				),
+				metric.New("sensors",
+					map[string]string{"chip": "nvme-pci-0100", "feature": "composite"},
+					map[string]interface{}{
+						"temp_input":      38.85,
+						"temp_max":        81.85,
+						"temp_crit":       84.85,
+						"temp_alarm":      int64(0),
+						"temp_crit_alarm": int64(0),
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name:        "chip filter",
+			chipInclude: []string{"nvme-*"},
+			expected: []telegraf.Metric{
+				metric.New("sensors",
+					map[string]string{"chip": "nvme-pci-0100", "feature": "composite"},
+					map[string]interface{}{
+						"temp1_input":      38.85,
+						"temp1_max":        81.85,
+						"temp1_crit":       84.85,
+						"temp1_alarm":      0.0,
+						"temp1_crit_alarm": 0.0,
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name:            "feature filter",
+			chipInclude:     []string{"nct6775-*"},
+			featureInclude:  []string{"fan*", "intrusion*"},
+			alarmsAsInteger: true,
+			expected: []telegraf.Metric{
+				metric.New("sensors",
+					map[string]string{"chip": "nct6775-isa-0290", "feature": "fan1"},
+					map[string]interface{}{"fan1_input": 0.0, "fan1_min": 300.0, "fan1_alarm": int64(1)},
+					time.Unix(0, 0),
+				),
+				metric.New("sensors",
+					map[string]string{"chip": "nct6775-isa-0290", "feature": "intrusion0"},
+					map[string]interface{}{"intrusion0_alarm": int64(1)},
+					time.Unix(0, 0),
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Sensors{
+				RemoveNumbers:   tt.removeNumbers,
+				ChipInclude:     tt.chipInclude,
+				FeatureInclude:  tt.featureInclude,
+				AlarmsAsInteger: tt.alarmsAsInteger,
+				Timeout:         defaultTimeout,
+				path:            "sensors",
+			}
+			execCommand = fakeExecCommandFixture("testdata/alarms.txt")
+			defer func() { execCommand = exec.Command }()
+
+			require.NoError(t, s.Init())
+			var acc testutil.Accumulator
+			require.NoError(t, s.Gather(&acc))
+			testutil.RequireMetricsEqual(t, tt.expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+		})
+	}
+}
+
+// fakeExecCommandFixture returns a mock of the exec.Command call printing the
+// given file
+func fakeExecCommandFixture(filename string) func(string, ...string) *exec.Cmd {
+	return func(command string, args ...string) *exec.Cmd {
+		cmd := fakeExecCommand(command, args...)
+		cmd.Env = append(cmd.Env, "SENSORS_FIXTURE="+filename)
+		return cmd
+	}
+}
+
 // fakeExecCommand is a helper function that mock
 // the exec.Command call (and call the test binary)
 func fakeExecCommand(command string, args ...string) *exec.Cmd {
@@ -376,6 +500,16 @@ Vcore Voltage:
 		os.Exit(1)
 	}
 
+	if filename := os.Getenv("SENSORS_FIXTURE"); filename != "" {
+		buf, err := os.ReadFile(filename)
+		if err != nil {
+			fmt.Fprint(os.Stdout, err)
+			//nolint:revive // error code is important for this "test"
+			os.Exit(1)
+		}
+		mockData = string(buf)
+	}
+
 	fmt.Fprint(os.Stdout, mockData)
 	//nolint:revive // error code is important for this "test"
 	os.Exit(0)
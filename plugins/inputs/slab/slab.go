@@ -12,11 +12,13 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
@@ -25,10 +27,14 @@ import (
 var sampleConfig string
 
 type SlabStats struct {
-	Log telegraf.Logger `toml:"-"`
+	SlabInclude []string        `toml:"slab_include"`
+	SlabExclude []string        `toml:"slab_exclude"`
+	Log         telegraf.Logger `toml:"-"`
 
 	statFile string
+	sysDir   string
 	useSudo  bool
+	filter   filter.Filter
 }
 
 func (*SlabStats) SampleConfig() string {
@@ -36,6 +42,12 @@ func (*SlabStats) SampleConfig() string {
 }
 
 func (ss *SlabStats) Init() error {
+	f, err := filter.NewIncludeExcludeFilter(ss.SlabInclude, ss.SlabExclude)
+	if err != nil {
+		return fmt.Errorf("creating slab filter failed: %w", err)
+	}
+	ss.filter = f
+
 	return nil
 }
 
@@ -62,6 +74,15 @@ func (ss *SlabStats) getSlabStats() (map[string]interface{}, error) {
 	scanner.Scan() // for "slabinfo - version: 2.1"
 	scanner.Scan() // for "# name <active_objs> <num_objs> <objsize> ..."
 
+	// The reclaimable flag of the caches is only available in sysfs for the
+	// SLUB allocator
+	_, err = os.Stat(ss.sysDir)
+	withReclaim := err == nil
+	if !withReclaim {
+		ss.Log.Debugf("Cannot determine reclaimable caches: %v", err)
+	}
+
+	var reclaimable, unreclaimable int
 	fields := make(map[string]interface{})
 	// Read data rows
 	for scanner.Scan() {
@@ -84,7 +105,23 @@ func (ss *SlabStats) getSlabStats() (map[string]interface{}, error) {
 			return nil, err
 		}
 
-		fields[normalizeName(cols[0])] = numObj * sizObj
+		size := numObj * sizObj
+		if withReclaim {
+			if ss.isReclaimable(cols[0]) {
+				reclaimable += size
+			} else {
+				unreclaimable += size
+			}
+		}
+
+		if ss.filter.Match(cols[0]) {
+			fields[normalizeName(cols[0])] = size
+		}
+	}
+
+	if withReclaim {
+		fields["total_reclaimable_size"] = reclaimable
+		fields["total_unreclaimable_size"] = unreclaimable
 	}
 	return fields, nil
 }
@@ -103,6 +140,16 @@ func (ss *SlabStats) runCmd(cmd string, args []string) ([]byte, error) {
 	return out, nil
 }
 
+// isReclaimable checks if the cache is accounted as reclaimable memory by the
+// kernel, i.e. is part of "SReclaimable" in /proc/meminfo
+func (ss *SlabStats) isReclaimable(name string) bool {
+	buf, err := os.ReadFile(filepath.Join(ss.sysDir, name, "reclaim_account"))
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(buf)) == "1"
+}
+
 func normalizeName(name string) string {
 	return strings.ReplaceAll(strings.ToLower(name), "-", "_") + "_size"
 }
@@ -111,6 +158,7 @@ func init() {
 	inputs.Add("slab", func() telegraf.Input {
 		return &SlabStats{
 			statFile: path.Join(internal.GetProcPath(), "slabinfo"),
+			sysDir:   path.Join(internal.GetSysPath(), "kernel", "slab"),
 			useSudo:  true,
 		}
 	})
@@ -14,8 +14,11 @@ import (
 func TestSlab(t *testing.T) {
 	slabStats := SlabStats{
 		statFile: path.Join("testdata", "slabinfo"),
+		sysDir:   path.Join("testdata", "sys"),
 		useSudo:  false,
+		Log:      testutil.Logger{},
 	}
+	require.NoError(t, slabStats.Init())
 
 	var acc testutil.Accumulator
 	require.NoError(t, slabStats.Gather(&acc))
@@ -42,7 +45,75 @@ func TestSlab(t *testing.T) {
 		"kmalloc_96_size":              int(12378240),
 		"kmem_cache_size":              int(81920),
 		"kmem_cache_node_size":         int(36864),
+		"total_reclaimable_size":       int(499680),
+		"total_unreclaimable_size":     int(345048896),
 	}
 
 	acc.AssertContainsFields(t, "slab", fields)
 }
+
+func TestSlabFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		sysDir   string
+		expected map[string]interface{}
+	}{
+		{
+			name:    "include",
+			include: []string{"ext4_inode_cache", "kmalloc-1*"},
+			sysDir:  path.Join("testdata", "sys"),
+			expected: map[string]interface{}{
+				"ext4_inode_cache_size":    int(491520),
+				"kmalloc_1024_size":        int(239927296),
+				"kmalloc_128_size":         int(5586944),
+				"kmalloc_16_size":          int(17002496),
+				"kmalloc_192_size":         int(4015872),
+				"total_reclaimable_size":   int(499680),
+				"total_unreclaimable_size": int(345048896),
+			},
+		},
+		{
+			name:    "include and exclude",
+			include: []string{"ext4_*"},
+			exclude: []string{"ext4_inode_cache", "ext4_xattr"},
+			sysDir:  path.Join("testdata", "sys"),
+			expected: map[string]interface{}{
+				"ext4_allocation_context_size": int(16384),
+				"ext4_extent_status_size":      int(8160),
+				"ext4_free_data_size":          int(0),
+				"ext4_io_end_size":             int(4032),
+				"total_reclaimable_size":       int(499680),
+				"total_unreclaimable_size":     int(345048896),
+			},
+		},
+		{
+			name:    "without reclaimable information",
+			include: []string{"kmem_cache*"},
+			sysDir:  path.Join("testdata", "missing"),
+			expected: map[string]interface{}{
+				"kmem_cache_size":      int(81920),
+				"kmem_cache_node_size": int(36864),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slabStats := SlabStats{
+				SlabInclude: tt.include,
+				SlabExclude: tt.exclude,
+				Log:         testutil.Logger{},
+				statFile:    path.Join("testdata", "slabinfo"),
+				sysDir:      tt.sysDir,
+			}
+			require.NoError(t, slabStats.Init())
+
+			var acc testutil.Accumulator
+			require.NoError(t, slabStats.Gather(&acc))
+			require.Len(t, acc.Metrics, 1)
+			require.Equal(t, tt.expected, acc.Metrics[0].Fields)
+		})
+	}
+}
@@ -372,7 +372,11 @@ type Smart struct {
 	TagWithDeviceType bool            `toml:"tag_with_device_type"`
 	Timeout           config.Duration `toml:"timeout"`
 	ReadMethod        string          `toml:"read_method"`
+	UseJSON           bool            `toml:"use_json"`
 	Log               telegraf.Logger `toml:"-"`
+
+	checkJSON     sync.Once
+	jsonSupported bool
 }
 
 type nvmeDevice struct {
@@ -555,14 +559,19 @@ func excludedDev(excludes []string, deviceLine string) bool {
 
 // Add info and attributes for each S.M.A.R.T. device
 func (m *Smart) addAttributes(acc telegraf.Accumulator, devices []string) {
+	gather := m.gatherDisk
+	if m.UseJSON && m.supportsJSON() {
+		gather = m.gatherDiskJSON
+	}
+
 	var wg sync.WaitGroup
 	wg.Add(len(devices))
 	for _, device := range devices {
 		switch m.ReadMethod {
 		case "concurrent":
-			go m.gatherDisk(acc, device, &wg)
+			go gather(acc, device, &wg)
 		case "sequential":
-			m.gatherDisk(acc, device, &wg)
+			gather(acc, device, &wg)
 		default:
 			wg.Done()
 		}
@@ -750,17 +759,7 @@ func (m *Smart) gatherDisk(acc telegraf.Accumulator, device string, wg *sync.Wai
 		return
 	}
 
-	deviceTags := make(map[string]string)
-	if m.TagWithDeviceType {
-		deviceNode := strings.SplitN(device, " ", 2)
-		deviceTags["device"] = path.Base(deviceNode[0])
-		if len(deviceNode) == 2 && deviceNode[1] != "" {
-			deviceTags["device_type"] = strings.TrimPrefix(deviceNode[1], "-d ")
-		}
-	} else {
-		deviceNode := strings.Split(device, " ")[0]
-		deviceTags["device"] = path.Base(deviceNode)
-	}
+	deviceTags := m.deviceTags(device)
 	deviceFields := make(map[string]interface{})
 	deviceFields["exit_status"] = exitStatus
 
@@ -815,13 +814,7 @@ func (m *Smart) gatherDisk(acc telegraf.Accumulator, device string, wg *sync.Wai
 		fields := make(map[string]interface{})
 
 		if m.Attributes {
-			// add power mode
-			keys := [...]string{"device", "device_type", "model", "serial_no", "wwn", "capacity", "enabled", "power"}
-			for _, key := range keys {
-				if value, ok := deviceTags[key]; ok {
-					tags[key] = value
-				}
-			}
+			tags = attributeTags(deviceTags)
 		}
 
 		attr := attribute.FindStringSubmatch(line)
@@ -897,6 +890,35 @@ func (m *Smart) gatherDisk(acc telegraf.Accumulator, device string, wg *sync.Wai
 	acc.AddFields("smart_device", deviceFields, deviceTags)
 }
 
+// deviceTags returns the tags identifying the given device
+func (m *Smart) deviceTags(device string) map[string]string {
+	tags := make(map[string]string)
+	if m.TagWithDeviceType {
+		deviceNode := strings.SplitN(device, " ", 2)
+		tags["device"] = path.Base(deviceNode[0])
+		if len(deviceNode) == 2 && deviceNode[1] != "" {
+			tags["device_type"] = strings.TrimPrefix(deviceNode[1], "-d ")
+		}
+	} else {
+		deviceNode := strings.Split(device, " ")[0]
+		tags["device"] = path.Base(deviceNode)
+	}
+	return tags
+}
+
+// attributeTags returns the device tags added to each attribute
+func attributeTags(deviceTags map[string]string) map[string]string {
+	tags := make(map[string]string)
+	// add power mode
+	keys := [...]string{"device", "device_type", "model", "serial_no", "wwn", "capacity", "enabled", "power"}
+	for _, key := range keys {
+		if value, ok := deviceTags[key]; ok {
+			tags[key] = value
+		}
+	}
+	return tags
+}
+
 // Command line parse errors are denoted by the exit code having the 0 bit set.
 // All other errors are drive/communication errors and should be ignored.
 func exitStatus(err error) (int, error) {
@@ -0,0 +1,326 @@
+package smart
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/telegraf"
+)
+
+// smartctl 7.0 and newer support JSON output
+const minJSONMajorVersion = 7
+
+// smartctl 7.2 2020-12-30 r5155 [x86_64-linux-5.10.0-8-amd64] (local build)
+var smartctlVersion = regexp.MustCompile(`(?m)^smartctl\s+(\d+)\.(\d+)`)
+
+// smartctlJSON is the subset of the output of 'smartctl --json' used by the plugin
+type smartctlJSON struct {
+	Smartctl struct {
+		Messages []struct {
+			String string `json:"string"`
+		} `json:"messages"`
+	} `json:"smartctl"`
+	ModelName       string `json:"model_name"`
+	Product         string `json:"product"`
+	SCSIProduct     string `json:"scsi_product"`
+	SerialNumber    string `json:"serial_number"`
+	FirmwareVersion string `json:"firmware_version"`
+	WWN             *struct {
+		NAA uint64 `json:"naa"`
+		OUI uint64 `json:"oui"`
+		ID  uint64 `json:"id"`
+	} `json:"wwn"`
+	UserCapacity *struct {
+		Bytes uint64 `json:"bytes"`
+	} `json:"user_capacity"`
+	SmartSupport *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"smart_support"`
+	SmartStatus *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature *struct {
+		Current int64 `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime *struct {
+		Hours int64 `json:"hours"`
+	} `json:"power_on_time"`
+	PowerCycleCount *int64 `json:"power_cycle_count"`
+
+	ATASmartAttributes *struct {
+		Table []struct {
+			ID         int64  `json:"id"`
+			Name       string `json:"name"`
+			Value      int64  `json:"value"`
+			Worst      int64  `json:"worst"`
+			Thresh     int64  `json:"thresh"`
+			WhenFailed string `json:"when_failed"`
+			Flags      struct {
+				String string `json:"string"`
+			} `json:"flags"`
+			Raw struct {
+				Value  int64  `json:"value"`
+				String string `json:"string"`
+			} `json:"raw"`
+		} `json:"table"`
+	} `json:"ata_smart_attributes"`
+
+	NVMeSmartHealthInformationLog *struct {
+		CriticalWarning         *int64  `json:"critical_warning"`
+		Temperature             *int64  `json:"temperature"`
+		AvailableSpare          *int64  `json:"available_spare"`
+		AvailableSpareThreshold *int64  `json:"available_spare_threshold"`
+		PercentageUsed          *int64  `json:"percentage_used"`
+		DataUnitsRead           *int64  `json:"data_units_read"`
+		DataUnitsWritten        *int64  `json:"data_units_written"`
+		HostReads               *int64  `json:"host_reads"`
+		HostWrites              *int64  `json:"host_writes"`
+		ControllerBusyTime      *int64  `json:"controller_busy_time"`
+		PowerCycles             *int64  `json:"power_cycles"`
+		PowerOnHours            *int64  `json:"power_on_hours"`
+		UnsafeShutdowns         *int64  `json:"unsafe_shutdowns"`
+		MediaErrors             *int64  `json:"media_errors"`
+		NumErrLogEntries        *int64  `json:"num_err_log_entries"`
+		WarningTempTime         *int64  `json:"warning_temp_time"`
+		CriticalCompTime        *int64  `json:"critical_comp_time"`
+		TemperatureSensors      []int64 `json:"temperature_sensors"`
+	} `json:"nvme_smart_health_information_log"`
+
+	SCSIGrownDefectList       *int64 `json:"scsi_grown_defect_list"`
+	SCSIStartStopCycleCounter *struct {
+		AccumulatedStartStopCycles  *int64 `json:"accumulated_start_stop_cycles"`
+		AccumulatedLoadUnloadCycles *int64 `json:"accumulated_load_unload_cycles"`
+	} `json:"scsi_start_stop_cycle_counter"`
+}
+
+// jsonAttribute is an attribute reported in the 'smart_attribute' measurement
+// using the same names as the text output parser
+type jsonAttribute struct {
+	id    string
+	name  string
+	value *int64
+}
+
+// supportsJSON checks once whether the installed smartctl supports JSON output
+func (m *Smart) supportsJSON() bool {
+	m.checkJSON.Do(func() {
+		out, err := runCmd(m.Timeout, m.UseSudo, m.PathSmartctl, "--version")
+		if err != nil {
+			m.Log.Warnf("Determining smartctl version failed, falling back to text output: %v", err)
+			return
+		}
+		match := smartctlVersion.FindSubmatch(out)
+		if match == nil {
+			m.Log.Warn("Unknown smartctl version, falling back to text output")
+			return
+		}
+		major, err := strconv.Atoi(string(match[1]))
+		if err != nil || major < minJSONMajorVersion {
+			m.Log.Warnf("smartctl %s.%s does not support JSON output, falling back to text output", match[1], match[2])
+			return
+		}
+		m.jsonSupported = true
+	})
+	return m.jsonSupported
+}
+
+func (m *Smart) gatherDiskJSON(acc telegraf.Accumulator, device string, wg *sync.WaitGroup) {
+	defer wg.Done()
+	args := []string{"--json", "--info", "--health", "--attributes", "--tolerance=verypermissive", "-n", m.Nocheck}
+	args = append(args, strings.Split(device, " ")...)
+	out, e := runCmd(m.Timeout, m.UseSudo, m.PathSmartctl, args...)
+
+	// Ignore all exit statuses except if it is a command line parse error
+	exitStatus, er := exitStatus(e)
+	if er != nil {
+		acc.AddError(fmt.Errorf("failed to run command '%s %s': %w - %s", m.PathSmartctl, strings.Join(args, " "), e, string(out)))
+		return
+	}
+
+	var data smartctlJSON
+	if err := json.Unmarshal(out, &data); err != nil {
+		acc.AddError(fmt.Errorf("parsing output of '%s %s' failed: %w", m.PathSmartctl, strings.Join(args, " "), err))
+		return
+	}
+
+	deviceTags := m.deviceTags(device)
+	deviceFields := map[string]interface{}{"exit_status": exitStatus}
+
+	// Device identity
+	switch {
+	case data.SCSIProduct != "":
+		deviceTags["model"] = data.SCSIProduct
+	case data.Product != "":
+		deviceTags["model"] = data.Product
+	case data.ModelName != "":
+		deviceTags["model"] = data.ModelName
+	}
+	if data.SerialNumber != "" {
+		deviceTags["serial_no"] = data.SerialNumber
+	}
+	if data.FirmwareVersion != "" {
+		deviceTags["firmware"] = data.FirmwareVersion
+	}
+	if data.WWN != nil {
+		deviceTags["wwn"] = fmt.Sprintf("%x%06x%09x", data.WWN.NAA, data.WWN.OUI, data.WWN.ID)
+	}
+	if data.UserCapacity != nil && data.UserCapacity.Bytes > 0 {
+		deviceTags["capacity"] = strconv.FormatUint(data.UserCapacity.Bytes, 10)
+	}
+	if data.SmartSupport != nil {
+		deviceTags["enabled"] = "Disabled"
+		if data.SmartSupport.Enabled {
+			deviceTags["enabled"] = "Enabled"
+		}
+	}
+	// Devices not queried due to the 'nocheck' setting only report their
+	// power mode in the messages
+	for _, msg := range data.Smartctl.Messages {
+		if power := standbyInfo.FindStringSubmatch(msg.String); len(power) > 1 {
+			deviceTags["power"] = power[1]
+		}
+	}
+
+	if data.SmartStatus != nil {
+		deviceFields["health_ok"] = data.SmartStatus.Passed
+	}
+
+	var tags map[string]string
+	if m.Attributes {
+		tags = attributeTags(deviceTags)
+	}
+
+	// ATA attribute table
+	if data.ATASmartAttributes != nil {
+		for _, attr := range data.ATASmartAttributes.Table {
+			id := strconv.FormatInt(attr.ID, 10)
+			// Use the first part of the raw string for compatibility with the
+			// text output as raw values might contain packed data, e.g. the
+			// minimum and maximum temperature
+			rawValue := attr.Raw.Value
+			if parts := strings.Fields(attr.Raw.String); len(parts) > 0 {
+				if v, err := parseRawValue(parts[0]); err == nil {
+					rawValue = v
+				}
+			}
+
+			if m.Attributes {
+				atags := make(map[string]string, len(tags)+4)
+				for k, v := range tags {
+					atags[k] = v
+				}
+				atags["id"] = id
+				atags["name"] = attr.Name
+				atags["flags"] = strings.TrimRight(attr.Flags.String, " +")
+				switch attr.WhenFailed {
+				case "now":
+					atags["fail"] = "FAILING_NOW"
+				case "past":
+					atags["fail"] = "In_the_past"
+				default:
+					atags["fail"] = "-"
+				}
+				fields := map[string]interface{}{
+					"exit_status": exitStatus,
+					"value":       attr.Value,
+					"worst":       attr.Worst,
+					"threshold":   attr.Thresh,
+					"raw_value":   rawValue,
+				}
+				acc.AddFields("smart_attribute", fields, atags)
+			}
+
+			if field, ok := deviceFieldIDs[id]; ok {
+				deviceFields[field] = rawValue
+			}
+			if field, ok := deviceFieldNames[attr.Name]; ok {
+				deviceFields[field] = attr.Value
+			}
+		}
+	}
+
+	// NVMe health log and SCSI counters
+	var attributes []jsonAttribute
+	if log := data.NVMeSmartHealthInformationLog; log != nil {
+		attributes = append(attributes,
+			jsonAttribute{name: "Critical_Warning", value: log.CriticalWarning},
+			jsonAttribute{id: "194", name: "Temperature_Celsius", value: log.Temperature},
+			jsonAttribute{name: "Available_Spare", value: log.AvailableSpare},
+			jsonAttribute{name: "Available_Spare_Threshold", value: log.AvailableSpareThreshold},
+			jsonAttribute{name: "Percentage_Used", value: log.PercentageUsed},
+			jsonAttribute{name: "Data_Units_Read", value: log.DataUnitsRead},
+			jsonAttribute{name: "Data_Units_Written", value: log.DataUnitsWritten},
+			jsonAttribute{name: "Host_Read_Commands", value: log.HostReads},
+			jsonAttribute{name: "Host_Write_Commands", value: log.HostWrites},
+			jsonAttribute{name: "Controller_Busy_Time", value: log.ControllerBusyTime},
+			jsonAttribute{id: "12", name: "Power_Cycle_Count", value: log.PowerCycles},
+			jsonAttribute{id: "9", name: "Power_On_Hours", value: log.PowerOnHours},
+			jsonAttribute{name: "Unsafe_Shutdowns", value: log.UnsafeShutdowns},
+			jsonAttribute{name: "Media_and_Data_Integrity_Errors", value: log.MediaErrors},
+			jsonAttribute{name: "Error_Information_Log_Entries", value: log.NumErrLogEntries},
+			jsonAttribute{name: "Warning_Temperature_Time", value: log.WarningTempTime},
+			jsonAttribute{name: "Critical_Temperature_Time", value: log.CriticalCompTime},
+		)
+		for i := range log.TemperatureSensors {
+			attributes = append(attributes, jsonAttribute{
+				name:  fmt.Sprintf("Temperature_Sensor_%d", i+1),
+				value: &log.TemperatureSensors[i],
+			})
+		}
+
+		// Fields of the health log not available in the text output
+		for field, value := range map[string]*int64{
+			"critical_warning": log.CriticalWarning,
+			"available_spare":  log.AvailableSpare,
+			"percentage_used":  log.PercentageUsed,
+			"media_errors":     log.MediaErrors,
+			"unsafe_shutdowns": log.UnsafeShutdowns,
+		} {
+			if value != nil {
+				deviceFields[field] = *value
+			}
+		}
+	}
+	if counter := data.SCSIStartStopCycleCounter; counter != nil {
+		attributes = append(attributes,
+			jsonAttribute{id: "4", name: "Start_Stop_Count", value: counter.AccumulatedStartStopCycles},
+			jsonAttribute{id: "193", name: "Load_Cycle_Count", value: counter.AccumulatedLoadUnloadCycles},
+		)
+	}
+	if data.SCSIGrownDefectList != nil {
+		deviceFields["grown_defect_list"] = *data.SCSIGrownDefectList
+	}
+	if m.Attributes {
+		for _, attr := range attributes {
+			if attr.value == nil {
+				continue
+			}
+			atags := make(map[string]string, len(tags)+2)
+			for k, v := range tags {
+				atags[k] = v
+			}
+			atags["name"] = attr.name
+			if attr.id != "" {
+				atags["id"] = attr.id
+			}
+			acc.AddFields("smart_attribute", map[string]interface{}{"raw_value": *attr.value}, atags)
+		}
+	}
+
+	// Common values reported for all protocols, attribute values take precedence
+	if _, found := deviceFields["temp_c"]; !found && data.Temperature != nil {
+		deviceFields["temp_c"] = data.Temperature.Current
+	}
+	if _, found := deviceFields["power_on_hours"]; !found && data.PowerOnTime != nil {
+		deviceFields["power_on_hours"] = data.PowerOnTime.Hours
+	}
+	if _, found := deviceFields["power_cycle_count"]; !found && data.PowerCycleCount != nil {
+		deviceFields["power_cycle_count"] = *data.PowerCycleCount
+	}
+
+	acc.AddFields("smart_device", deviceFields, deviceTags)
+}
@@ -0,0 +1,405 @@
+package smart
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestGatherJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		device   string
+		output   string
+		expected []telegraf.Metric
+	}{
+		{
+			name:     "SATA",
+			device:   "/dev/sda",
+			output:   smartctlJSONSATAData,
+			expected: testSmartctlJSONSATAMetrics,
+		},
+		{
+			name:     "NVMe",
+			device:   "/dev/nvme0",
+			output:   smartctlJSONNVMeData,
+			expected: testSmartctlJSONNVMeMetrics,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCmd = func(_ config.Duration, _ bool, _ string, args ...string) ([]byte, error) {
+				switch {
+				case len(args) == 1 && args[0] == "--version":
+					return []byte(smartctlVersion72), nil
+				case len(args) > 7 && args[0] == "--json" && args[7] == tt.device:
+					return []byte(tt.output), nil
+				}
+				return nil, errors.New("command not found")
+			}
+
+			s := newSmart()
+			s.Attributes = true
+			s.UseJSON = true
+			s.PathSmartctl = "smartctl"
+			s.Devices = []string{tt.device}
+			s.Log = testutil.Logger{}
+
+			var acc testutil.Accumulator
+			require.NoError(t, s.Gather(&acc))
+			require.Empty(t, acc.Errors)
+			testutil.RequireMetricsEqual(t, tt.expected, acc.GetTelegrafMetrics(),
+				testutil.SortMetrics(), testutil.IgnoreTime())
+		})
+	}
+}
+
+func TestGatherJSONFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+	}{
+		{
+			name:    "old version",
+			version: smartctlVersion65,
+		},
+		{
+			name:    "unknown version",
+			version: "command not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			runCmd = func(_ config.Duration, _ bool, _ string, args ...string) ([]byte, error) {
+				switch {
+				case len(args) == 1 && args[0] == "--version":
+					calls++
+					return []byte(tt.version), nil
+				case len(args) > 7 && args[0] == "--info" && args[7] == "/dev/nvme0":
+					return []byte(smartctlNVMeInfoData), nil
+				}
+				return nil, errors.New("command not found")
+			}
+
+			s := newSmart()
+			s.Attributes = true
+			s.UseJSON = true
+			s.PathSmartctl = "smartctl"
+			s.Devices = []string{"/dev/nvme0"}
+			s.Log = testutil.Logger{}
+
+			// The text output must be used and the version must only be
+			// checked once
+			for range 2 {
+				var acc testutil.Accumulator
+				require.NoError(t, s.Gather(&acc))
+				require.Empty(t, acc.Errors)
+				testutil.RequireMetricsEqual(t, testSmartctlNVMeAttributes, acc.GetTelegrafMetrics(),
+					testutil.SortMetrics(), testutil.IgnoreTime())
+			}
+			require.Equal(t, 1, calls)
+		})
+	}
+}
+
+func TestGatherJSONInvalid(t *testing.T) {
+	runCmd = func(_ config.Duration, _ bool, _ string, args ...string) ([]byte, error) {
+		if len(args) == 1 && args[0] == "--version" {
+			return []byte(smartctlVersion72), nil
+		}
+		return []byte("not json"), nil
+	}
+
+	s := newSmart()
+	s.UseJSON = true
+	s.PathSmartctl = "smartctl"
+	s.Devices = []string{"/dev/sda"}
+	s.Log = testutil.Logger{}
+
+	var acc testutil.Accumulator
+	require.NoError(t, s.Gather(&acc))
+	require.Len(t, acc.Errors, 1)
+	require.ErrorContains(t, acc.Errors[0], "parsing output")
+	require.Empty(t, acc.GetTelegrafMetrics())
+}
+
+var (
+	smartctlVersion72 = `smartctl 7.2 2020-12-30 r5155 [x86_64-linux-5.10.0-8-amd64] (local build)
+Copyright (C) 2002-20, Bruce Allen, Christian Franke, www.smartmontools.org
+`
+	smartctlVersion65 = `smartctl 6.5 2016-05-07 r4318 [x86_64-linux-4.1.27-gvt-yocto-standard] (local build)
+Copyright (C) 2002-16, Bruce Allen, Christian Franke, www.smartmontools.org
+`
+
+	smartctlJSONSATAData = `{
+  "json_format_version": [1, 0],
+  "smartctl": {
+    "version": [7, 2],
+    "argv": ["smartctl", "--json", "--info", "--health", "--attributes", "/dev/sda"],
+    "exit_status": 0
+  },
+  "device": {"name": "/dev/sda", "info_name": "/dev/sda [SAT]", "type": "sat", "protocol": "ATA"},
+  "model_name": "Samsung SSD 860 EVO 500GB",
+  "serial_number": "S3Z1NB0K123456A",
+  "wwn": {"naa": 5, "oui": 9528, "id": 61213911632},
+  "firmware_version": "RVT02B6Q",
+  "user_capacity": {"blocks": 976773168, "bytes": 500107862016},
+  "smart_support": {"available": true, "enabled": true},
+  "smart_status": {"passed": true},
+  "ata_smart_attributes": {
+    "revision": 1,
+    "table": [
+      {
+        "id": 5, "name": "Reallocated_Sector_Ct", "value": 100, "worst": 100, "thresh": 10, "when_failed": "",
+        "flags": {"value": 51, "string": "PO--CK ", "prefailure": true},
+        "raw": {"value": 0, "string": "0"}
+      },
+      {
+        "id": 9, "name": "Power_On_Hours", "value": 95, "worst": 95, "thresh": 0, "when_failed": "",
+        "flags": {"value": 50, "string": "-O--CK ", "prefailure": false},
+        "raw": {"value": 21893, "string": "21893"}
+      },
+      {
+        "id": 177, "name": "Wear_Leveling_Count", "value": 98, "worst": 98, "thresh": 0, "when_failed": "past",
+        "flags": {"value": 19, "string": "PO--C- ", "prefailure": true},
+        "raw": {"value": 27, "string": "27"}
+      },
+      {
+        "id": 190, "name": "Airflow_Temperature_Cel", "value": 71, "worst": 51, "thresh": 0, "when_failed": "",
+        "flags": {"value": 50, "string": "-O--CK ", "prefailure": false},
+        "raw": {"value": 1717960733, "string": "29 (Min/Max 20/49)"}
+      }
+    ]
+  },
+  "power_on_time": {"hours": 21893},
+  "power_cycle_count": 63,
+  "temperature": {"current": 29}
+}`
+
+	testSmartctlJSONSATAMetrics = []telegraf.Metric{
+		testutil.MustMetric("smart_device",
+			map[string]string{
+				"device":    "sda",
+				"model":     "Samsung SSD 860 EVO 500GB",
+				"serial_no": "S3Z1NB0K123456A",
+				"wwn":       "5002538e40a22a50",
+				"firmware":  "RVT02B6Q",
+				"capacity":  "500107862016",
+				"enabled":   "Enabled",
+			},
+			map[string]interface{}{
+				"exit_status":               0,
+				"health_ok":                 true,
+				"reallocated_sectors_count": int64(0),
+				"power_on_hours":            int64(21893),
+				"wear_leveling_count":       int64(98),
+				"temp_c":                    int64(29),
+				"power_cycle_count":         int64(63),
+			},
+			time.Now(),
+		),
+		testutil.MustMetric("smart_attribute",
+			map[string]string{
+				"device":    "sda",
+				"model":     "Samsung SSD 860 EVO 500GB",
+				"serial_no": "S3Z1NB0K123456A",
+				"wwn":       "5002538e40a22a50",
+				"capacity":  "500107862016",
+				"enabled":   "Enabled",
+				"id":        "5",
+				"name":      "Reallocated_Sector_Ct",
+				"flags":     "PO--CK",
+				"fail":      "-",
+			},
+			map[string]interface{}{
+				"exit_status": 0,
+				"value":       int64(100),
+				"worst":       int64(100),
+				"threshold":   int64(10),
+				"raw_value":   int64(0),
+			},
+			time.Now(),
+		),
+		testutil.MustMetric("smart_attribute",
+			map[string]string{
+				"device":    "sda",
+				"model":     "Samsung SSD 860 EVO 500GB",
+				"serial_no": "S3Z1NB0K123456A",
+				"wwn":       "5002538e40a22a50",
+				"capacity":  "500107862016",
+				"enabled":   "Enabled",
+				"id":        "9",
+				"name":      "Power_On_Hours",
+				"flags":     "-O--CK",
+				"fail":      "-",
+			},
+			map[string]interface{}{
+				"exit_status": 0,
+				"value":       int64(95),
+				"worst":       int64(95),
+				"threshold":   int64(0),
+				"raw_value":   int64(21893),
+			},
+			time.Now(),
+		),
+		testutil.MustMetric("smart_attribute",
+			map[string]string{
+				"device":    "sda",
+				"model":     "Samsung SSD 860 EVO 500GB",
+				"serial_no": "S3Z1NB0K123456A",
+				"wwn":       "5002538e40a22a50",
+				"capacity":  "500107862016",
+				"enabled":   "Enabled",
+				"id":        "177",
+				"name":      "Wear_Leveling_Count",
+				"flags":     "PO--C-",
+				"fail":      "In_the_past",
+			},
+			map[string]interface{}{
+				"exit_status": 0,
+				"value":       int64(98),
+				"worst":       int64(98),
+				"threshold":   int64(0),
+				"raw_value":   int64(27),
+			},
+			time.Now(),
+		),
+		testutil.MustMetric("smart_attribute",
+			map[string]string{
+				"device":    "sda",
+				"model":     "Samsung SSD 860 EVO 500GB",
+				"serial_no": "S3Z1NB0K123456A",
+				"wwn":       "5002538e40a22a50",
+				"capacity":  "500107862016",
+				"enabled":   "Enabled",
+				"id":        "190",
+				"name":      "Airflow_Temperature_Cel",
+				"flags":     "-O--CK",
+				"fail":      "-",
+			},
+			map[string]interface{}{
+				"exit_status": 0,
+				"value":       int64(71),
+				"worst":       int64(51),
+				"threshold":   int64(0),
+				"raw_value":   int64(29),
+			},
+			time.Now(),
+		),
+	}
+
+	smartctlJSONNVMeData = `{
+  "json_format_version": [1, 0],
+  "smartctl": {
+    "version": [7, 2],
+    "argv": ["smartctl", "--json", "--info", "--health", "--attributes", "/dev/nvme0"],
+    "exit_status": 0
+  },
+  "device": {"name": "/dev/nvme0", "info_name": "/dev/nvme0", "type": "nvme", "protocol": "NVMe"},
+  "model_name": "TS128GMTE850",
+  "serial_number": "D704940282?",
+  "firmware_version": "C2.3.13",
+  "nvme_pci_vendor": {"id": 4719, "subsystem_id": 4719},
+  "nvme_ieee_oui_identifier": 0,
+  "nvme_number_of_namespaces": 1,
+  "user_capacity": {"blocks": 250069680, "bytes": 128035676160},
+  "smart_support": {"available": true, "enabled": true},
+  "smart_status": {"passed": true, "nvme": {"value": 0}},
+  "nvme_smart_health_information_log": {
+    "critical_warning": 9,
+    "temperature": 38,
+    "available_spare": 100,
+    "available_spare_threshold": 10,
+    "percentage_used": 16,
+    "data_units_read": 11836935,
+    "data_units_written": 62288091,
+    "host_reads": 135924188,
+    "host_writes": 7715573429,
+    "controller_busy_time": 4042,
+    "power_cycles": 472,
+    "power_on_hours": 6038,
+    "unsafe_shutdowns": 355,
+    "media_errors": 0,
+    "num_err_log_entries": 119699,
+    "warning_temp_time": 11,
+    "critical_comp_time": 7,
+    "temperature_sensors": [57, 50]
+  },
+  "temperature": {"current": 38},
+  "power_cycle_count": 472,
+  "power_on_time": {"hours": 6038}
+}`
+
+	testSmartctlJSONNVMeTags = map[string]string{
+		"device":    "nvme0",
+		"model":     "TS128GMTE850",
+		"serial_no": "D704940282?",
+		"capacity":  "128035676160",
+		"enabled":   "Enabled",
+	}
+
+	testSmartctlJSONNVMeMetrics = []telegraf.Metric{
+		testutil.MustMetric("smart_device",
+			map[string]string{
+				"device":    "nvme0",
+				"model":     "TS128GMTE850",
+				"serial_no": "D704940282?",
+				"firmware":  "C2.3.13",
+				"capacity":  "128035676160",
+				"enabled":   "Enabled",
+			},
+			map[string]interface{}{
+				"exit_status":       0,
+				"health_ok":         true,
+				"temp_c":            int64(38),
+				"power_on_hours":    int64(6038),
+				"power_cycle_count": int64(472),
+				"critical_warning":  int64(9),
+				"available_spare":   int64(100),
+				"percentage_used":   int64(16),
+				"media_errors":      int64(0),
+				"unsafe_shutdowns":  int64(355),
+			},
+			time.Now(),
+		),
+		nvmeJSONAttribute("", "Critical_Warning", 9),
+		nvmeJSONAttribute("194", "Temperature_Celsius", 38),
+		nvmeJSONAttribute("", "Available_Spare", 100),
+		nvmeJSONAttribute("", "Available_Spare_Threshold", 10),
+		nvmeJSONAttribute("", "Percentage_Used", 16),
+		nvmeJSONAttribute("", "Data_Units_Read", 11836935),
+		nvmeJSONAttribute("", "Data_Units_Written", 62288091),
+		nvmeJSONAttribute("", "Host_Read_Commands", 135924188),
+		nvmeJSONAttribute("", "Host_Write_Commands", 7715573429),
+		nvmeJSONAttribute("", "Controller_Busy_Time", 4042),
+		nvmeJSONAttribute("12", "Power_Cycle_Count", 472),
+		nvmeJSONAttribute("9", "Power_On_Hours", 6038),
+		nvmeJSONAttribute("", "Unsafe_Shutdowns", 355),
+		nvmeJSONAttribute("", "Media_and_Data_Integrity_Errors", 0),
+		nvmeJSONAttribute("", "Error_Information_Log_Entries", 119699),
+		nvmeJSONAttribute("", "Warning_Temperature_Time", 11),
+		nvmeJSONAttribute("", "Critical_Temperature_Time", 7),
+		nvmeJSONAttribute("", "Temperature_Sensor_1", 57),
+		nvmeJSONAttribute("", "Temperature_Sensor_2", 50),
+	}
+)
+
+func nvmeJSONAttribute(id, name string, value int64) telegraf.Metric {
+	tags := map[string]string{"name": name}
+	if id != "" {
+		tags["id"] = id
+	}
+	for k, v := range testSmartctlJSONNVMeTags {
+		tags[k] = v
+	}
+	return testutil.MustMetric("smart_attribute", tags, map[string]interface{}{"raw_value": value}, time.Now())
+}
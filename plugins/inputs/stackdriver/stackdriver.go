@@ -6,6 +6,7 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"strconv"
 	"strings"
@@ -53,6 +54,8 @@ type (
 		MetricTypePrefixExclude         []string              `toml:"metric_type_prefix_exclude"`
 		GatherRawDistributionBuckets    bool                  `toml:"gather_raw_distribution_buckets"`
 		DistributionAggregationAligners []string              `toml:"distribution_aggregation_aligners"`
+		DistributionAggregation         string                `toml:"distribution_aggregation"`
+		DistributionMaxBuckets          int                   `toml:"distribution_max_buckets"`
 		Filter                          *listTimeSeriesFilter `toml:"filter"`
 
 		Log telegraf.Logger
@@ -114,6 +117,11 @@ type (
 	lockedSeriesGrouper struct {
 		sync.Mutex
 		*metric.SeriesGrouper
+
+		// histograms holds the metrics of distributions expanded into
+		// buckets. They are kept separate as they must not be merged with
+		// the fields of other metric types in the same measurement.
+		histograms []telegraf.Metric
 	}
 )
 
@@ -129,6 +137,12 @@ func (g *lockedSeriesGrouper) Add(
 	g.SeriesGrouper.Add(measurement, tags, tm, field, fieldValue)
 }
 
+func (g *lockedSeriesGrouper) addHistogram(m telegraf.Metric) {
+	g.Lock()
+	defer g.Unlock()
+	g.histograms = append(g.histograms, m)
+}
+
 // ListMetricDescriptors implements metricClient interface
 func (smc *stackdriverMetricClient) ListMetricDescriptors(
 	ctx context.Context,
@@ -196,6 +210,21 @@ func (*stackdriver) SampleConfig() string {
 	return sampleConfig
 }
 
+// Init validates the distribution settings
+func (s *stackdriver) Init() error {
+	switch s.DistributionAggregation {
+	case "", "buckets":
+	default:
+		return fmt.Errorf("invalid distribution_aggregation %q", s.DistributionAggregation)
+	}
+
+	if s.DistributionMaxBuckets < 0 {
+		return fmt.Errorf("invalid distribution_max_buckets %d", s.DistributionMaxBuckets)
+	}
+
+	return nil
+}
+
 // Gather implements telegraf.Input interface
 func (s *stackdriver) Gather(acc telegraf.Accumulator) error {
 	ctx := context.Background()
@@ -238,6 +267,9 @@ func (s *stackdriver) Gather(acc telegraf.Accumulator) error {
 	for _, groupedMetric := range grouper.Metrics() {
 		acc.AddMetric(groupedMetric)
 	}
+	for _, m := range grouper.histograms {
+		acc.AddMetric(m)
+	}
 
 	return nil
 }
@@ -670,6 +702,10 @@ func NewBucket(dist *distributionpb.Distribution) (buckets, error) {
 func (s *stackdriver) addDistribution(dist *distributionpb.Distribution, tags map[string]string, ts time.Time,
 	grouper *lockedSeriesGrouper, tsConf *timeSeriesConf,
 ) error {
+	if s.DistributionAggregation == "buckets" {
+		return s.addDistributionBuckets(dist, tags, ts, grouper, tsConf)
+	}
+
 	field := tsConf.fieldKey
 	name := tsConf.measurement
 
@@ -682,30 +718,79 @@ func (s *stackdriver) addDistribution(dist *distributionpb.Distribution, tags ma
 		grouper.Add(name, tags, ts, field+"_range_max", dist.Range.Max)
 	}
 
+	return s.forEachBucket(dist, func(bound string, count int64) {
+		btags := maps.Clone(tags)
+		btags["lt"] = bound
+		grouper.Add(name, btags, ts, field+"_bucket", count)
+	})
+}
+
+// addDistributionBuckets adds the distribution as histogram metrics with the
+// cumulative bucket counts tagged by their upper bound in the "le" tag as
+// expected by the prometheus serializer.
+func (s *stackdriver) addDistributionBuckets(dist *distributionpb.Distribution, tags map[string]string, ts time.Time,
+	grouper *lockedSeriesGrouper, tsConf *timeSeriesConf,
+) error {
+	field := tsConf.fieldKey
+	name := tsConf.measurement
+
+	fields := map[string]interface{}{
+		field + "_count":                    dist.Count,
+		field + "_sum":                      dist.Mean * float64(dist.Count),
+		field + "_mean":                     dist.Mean,
+		field + "_sum_of_squared_deviation": dist.SumOfSquaredDeviation,
+	}
+	if dist.Range != nil {
+		fields[field+"_range_min"] = dist.Range.Min
+		fields[field+"_range_max"] = dist.Range.Max
+	}
+	grouper.addHistogram(metric.New(name, maps.Clone(tags), fields, ts, telegraf.Histogram))
+
+	return s.forEachBucket(dist, func(bound string, count int64) {
+		btags := maps.Clone(tags)
+		btags["le"] = bound
+		grouper.addHistogram(metric.New(name, btags, map[string]interface{}{field + "_bucket": count}, ts, telegraf.Histogram))
+	})
+}
+
+// forEachBucket calls the given function with the upper bound and cumulative
+// count of the buckets. If the distribution has more buckets than allowed by
+// the distribution_max_buckets setting, adjacent buckets are merged.
+func (s *stackdriver) forEachBucket(dist *distributionpb.Distribution, fn func(bound string, count int64)) error {
 	bucket, err := NewBucket(dist)
 	if err != nil {
 		return err
 	}
 	numBuckets := bucket.Amount()
 
+	// As the counts are cumulative, merging buckets is done by skipping the
+	// bounds in between while keeping the overflow bucket.
+	step := int32(1)
+	if s.DistributionMaxBuckets > 0 && numBuckets > int32(s.DistributionMaxBuckets) {
+		step = numBuckets
+		if s.DistributionMaxBuckets > 1 {
+			finite := numBuckets - 1
+			limit := int32(s.DistributionMaxBuckets) - 1
+			step = (finite + limit - 1) / limit
+		}
+	}
+
 	var i int32
 	var count int64
 	for i = 0; i < numBuckets; i++ {
-		// The last bucket is the overflow bucket, and includes all values
-		// greater than the previous bound.
-		if i == numBuckets-1 {
-			tags["lt"] = "+Inf"
-		} else {
-			upperBound := bucket.UpperBound(i)
-			tags["lt"] = strconv.FormatFloat(upperBound, 'f', -1, 64)
-		}
-
 		// Add to the cumulative count; trailing buckets with value 0 are
 		// omitted from the response.
 		if i < int32(len(dist.BucketCounts)) {
 			count += dist.BucketCounts[i]
 		}
-		grouper.Add(name, tags, ts, field+"_bucket", count)
+
+		// The last bucket is the overflow bucket, and includes all values
+		// greater than the previous bound.
+		if i == numBuckets-1 {
+			fn("+Inf", count)
+		} else if (i+1)%step == 0 {
+			fn(strconv.FormatFloat(bucket.UpperBound(i), 'f', -1, 64), count)
+		}
 	}
 
 	return nil
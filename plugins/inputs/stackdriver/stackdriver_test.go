@@ -14,6 +14,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/plugins/inputs"
 	"github.com/influxdata/telegraf/testutil"
 )
@@ -1231,3 +1232,201 @@ func TestNewListTimeSeriesFilter(_ *testing.T) {
 
 func TestTimeSeriesConfCacheIsValid(_ *testing.T) {
 }
+
+func createDistributionTimeSeries(ts time.Time, dist *distribution.Distribution) *monitoringpb.TimeSeries {
+	return createTimeSeries(
+		&monitoringpb.Point{
+			Interval: &monitoringpb.TimeInterval{
+				EndTime: &timestamppb.Timestamp{
+					Seconds: ts.Unix(),
+				},
+			},
+			Value: &monitoringpb.TypedValue{
+				Value: &monitoringpb.TypedValue_DistributionValue{
+					DistributionValue: dist,
+				},
+			},
+		},
+		metricpb.MetricDescriptor_DISTRIBUTION,
+	)
+}
+
+func TestGatherDistributionBuckets(t *testing.T) {
+	now := time.Now().Round(time.Second)
+	tags := func(bound string) map[string]string {
+		t := map[string]string{
+			"resource_type": "global",
+			"project_id":    "test",
+		}
+		if bound != "" {
+			t["le"] = bound
+		}
+		return t
+	}
+
+	linear := &distribution.Distribution{
+		Count:                 4,
+		Mean:                  2.0,
+		SumOfSquaredDeviation: 1.0,
+		Range: &distribution.Distribution_Range{
+			Min: 0.0,
+			Max: 3.0,
+		},
+		BucketCounts: []int64{0, 1, 3, 0},
+		BucketOptions: &distribution.Distribution_BucketOptions{
+			Options: &distribution.Distribution_BucketOptions_LinearBuckets{
+				LinearBuckets: &distribution.Distribution_BucketOptions_Linear{
+					NumFiniteBuckets: 2,
+					Width:            1,
+					Offset:           1,
+				},
+			},
+		},
+	}
+	exponential := &distribution.Distribution{
+		Count:        3,
+		Mean:         2.0,
+		BucketCounts: []int64{1, 2},
+		BucketOptions: &distribution.Distribution_BucketOptions{
+			Options: &distribution.Distribution_BucketOptions_ExponentialBuckets{
+				ExponentialBuckets: &distribution.Distribution_BucketOptions_Exponential{
+					NumFiniteBuckets: 2,
+					GrowthFactor:     2,
+					Scale:            1,
+				},
+			},
+		},
+	}
+	explicit := &distribution.Distribution{
+		Count:        6,
+		Mean:         5.0,
+		BucketCounts: []int64{1, 1, 1, 1, 1, 1},
+		BucketOptions: &distribution.Distribution_BucketOptions{
+			Options: &distribution.Distribution_BucketOptions_ExplicitBuckets{
+				ExplicitBuckets: &distribution.Distribution_BucketOptions_Explicit{
+					Bounds: []float64{1, 2, 4, 8, 16},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		aligners   []string
+		maxBuckets int
+		timeseries *monitoringpb.TimeSeries
+		expected   []telegraf.Metric
+	}{
+		{
+			name:       "raw linear buckets",
+			timeseries: createDistributionTimeSeries(now, linear),
+			expected: []telegraf.Metric{
+				metric.New("telegraf/cpu", tags(""), map[string]interface{}{
+					"usage_count":                    int64(4),
+					"usage_sum":                      8.0,
+					"usage_mean":                     2.0,
+					"usage_sum_of_squared_deviation": 1.0,
+					"usage_range_min":                0.0,
+					"usage_range_max":                3.0,
+				}, now, telegraf.Histogram),
+				metric.New("telegraf/cpu", tags("1"), map[string]interface{}{"usage_bucket": int64(0)}, now, telegraf.Histogram),
+				metric.New("telegraf/cpu", tags("2"), map[string]interface{}{"usage_bucket": int64(1)}, now, telegraf.Histogram),
+				metric.New("telegraf/cpu", tags("3"), map[string]interface{}{"usage_bucket": int64(4)}, now, telegraf.Histogram),
+				metric.New("telegraf/cpu", tags("+Inf"), map[string]interface{}{"usage_bucket": int64(4)}, now, telegraf.Histogram),
+			},
+		},
+		{
+			name:       "raw explicit buckets capped",
+			maxBuckets: 3,
+			timeseries: createDistributionTimeSeries(now, explicit),
+			expected: []telegraf.Metric{
+				metric.New("telegraf/cpu", tags(""), map[string]interface{}{
+					"usage_count":                    int64(6),
+					"usage_sum":                      30.0,
+					"usage_mean":                     5.0,
+					"usage_sum_of_squared_deviation": 0.0,
+				}, now, telegraf.Histogram),
+				metric.New("telegraf/cpu", tags("4"), map[string]interface{}{"usage_bucket": int64(3)}, now, telegraf.Histogram),
+				metric.New("telegraf/cpu", tags("+Inf"), map[string]interface{}{"usage_bucket": int64(6)}, now, telegraf.Histogram),
+			},
+		},
+		{
+			name:       "aligned exponential buckets",
+			aligners:   []string{"ALIGN_DELTA"},
+			timeseries: createDistributionTimeSeries(now, exponential),
+			expected: []telegraf.Metric{
+				metric.New("telegraf/cpu", tags(""), map[string]interface{}{
+					"usage_align_delta_count":                    int64(3),
+					"usage_align_delta_sum":                      6.0,
+					"usage_align_delta_mean":                     2.0,
+					"usage_align_delta_sum_of_squared_deviation": 0.0,
+				}, now, telegraf.Histogram),
+				metric.New("telegraf/cpu", tags("1"), map[string]interface{}{"usage_align_delta_bucket": int64(1)}, now, telegraf.Histogram),
+				metric.New("telegraf/cpu", tags("2"), map[string]interface{}{"usage_align_delta_bucket": int64(3)}, now, telegraf.Histogram),
+				metric.New("telegraf/cpu", tags("4"), map[string]interface{}{"usage_align_delta_bucket": int64(3)}, now, telegraf.Histogram),
+				metric.New("telegraf/cpu", tags("+Inf"), map[string]interface{}{"usage_align_delta_bucket": int64(3)}, now, telegraf.Histogram),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockStackdriverClient{
+				ListMetricDescriptorsF: func() (<-chan *metricpb.MetricDescriptor, error) {
+					ch := make(chan *metricpb.MetricDescriptor, 1)
+					ch <- &metricpb.MetricDescriptor{
+						Type:      "telegraf/cpu/usage",
+						ValueType: metricpb.MetricDescriptor_DISTRIBUTION,
+					}
+					close(ch)
+					return ch, nil
+				},
+				ListTimeSeriesF: func() (<-chan *monitoringpb.TimeSeries, error) {
+					ch := make(chan *monitoringpb.TimeSeries, 1)
+					ch <- tt.timeseries
+					close(ch)
+					return ch, nil
+				},
+				CloseF: func() error {
+					return nil
+				},
+			}
+
+			s := &stackdriver{
+				Log:                             testutil.Logger{},
+				Project:                         "test",
+				RateLimit:                       10,
+				GatherRawDistributionBuckets:    len(tt.aligners) == 0,
+				DistributionAggregationAligners: tt.aligners,
+				DistributionAggregation:         "buckets",
+				DistributionMaxBuckets:          tt.maxBuckets,
+				client:                          client,
+			}
+			require.NoError(t, s.Init())
+
+			var acc testutil.Accumulator
+			require.NoError(t, s.Gather(&acc))
+			require.Empty(t, acc.Errors)
+
+			testutil.RequireMetricsEqual(t, tt.expected, acc.GetTelegrafMetrics(), testutil.SortMetrics())
+
+			// Aligned series must request the aggregation
+			for _, call := range client.calls {
+				if call.name != "ListTimeSeries" {
+					continue
+				}
+				req, ok := call.args[1].(*monitoringpb.ListTimeSeriesRequest)
+				require.True(t, ok)
+				require.Equal(t, len(tt.aligners) > 0, req.Aggregation != nil)
+			}
+		})
+	}
+}
+
+func TestInitInvalidDistributionAggregation(t *testing.T) {
+	s := &stackdriver{DistributionAggregation: "quantiles"}
+	require.ErrorContains(t, s.Init(), "invalid distribution_aggregation")
+
+	s = &stackdriver{DistributionMaxBuckets: -1}
+	require.ErrorContains(t, s.Init(), "invalid distribution_max_buckets")
+}
@@ -385,6 +385,15 @@ func (s *SystemdUnits) Gather(acc telegraf.Accumulator) error {
 
 			tags["state"] = unitFileState
 			tags["preset"] = unitFilePreset
+			if drift, ok := presetDrift(unitFileState, unitFilePreset); ok {
+				fields["preset_drift"] = drift
+			}
+
+			if v, err := s.client.GetUnitPropertyContext(ctx, state.Name, "ActiveEnterTimestamp"); err == nil {
+				if ts, ok := v.Value.Value().(uint64); ok {
+					fields["active_enter_timestamp_us"] = ts
+				}
+			}
 
 			fields["status_errno"] = properties["StatusErrno"]
 			fields["restarts"] = properties["NRestarts"]
@@ -394,6 +403,12 @@ func (s *SystemdUnits) Gather(acc telegraf.Accumulator) error {
 			fields["swap_current"] = properties["MemorySwapCurrent"]
 			fields["swap_peak"] = properties["MemorySwapPeak"]
 			fields["mem_avail"] = properties["MemoryAvailable"]
+			fields["tasks_current"] = properties["TasksCurrent"]
+			fields["exec_main_start_timestamp_us"] = properties["ExecMainStartTimestamp"]
+			if s.UnitType == "timer" {
+				fields["next_elapse_timestamp_us"] = properties["NextElapseUSecRealtime"]
+				fields["last_trigger_timestamp_us"] = properties["LastTriggerUSec"]
+			}
 
 			// Sanitize unset memory fields
 			for k, value := range fields {
@@ -403,6 +418,17 @@ func (s *SystemdUnits) Gather(acc telegraf.Accumulator) error {
 					if ok && v == math.MaxUint64 || value == nil {
 						fields[k] = uint64(0)
 					}
+				case k == "next_elapse_timestamp_us", k == "last_trigger_timestamp_us":
+					// Timers report zero if they never triggered or have no
+					// next elapse scheduled
+					if v, ok := value.(uint64); value == nil || ok && (v == 0 || v == math.MaxUint64) {
+						delete(fields, k)
+					}
+				case k == "tasks_current", strings.HasSuffix(k, "_timestamp_us"):
+					// Remove fields not available for the unit or unset
+					if v, ok := value.(uint64); value == nil || ok && v == math.MaxUint64 {
+						delete(fields, k)
+					}
 				}
 			}
 		}
@@ -411,3 +437,20 @@ func (s *SystemdUnits) Gather(acc telegraf.Accumulator) error {
 
 	return nil
 }
+
+// presetDrift checks if the enabled state of a unit file differs from its
+// preset. Units without an enable state, e.g. static ones, or without a preset
+// cannot drift and are reported as not applicable.
+func presetDrift(state, preset string) (drift, ok bool) {
+	if preset != "enabled" && preset != "disabled" {
+		return false, false
+	}
+
+	switch state {
+	case "enabled", "enabled-runtime":
+		return preset != "enabled", true
+	case "disabled", "masked", "masked-runtime":
+		return preset != "disabled", true
+	}
+	return false, false
+}
@@ -25,12 +25,13 @@ import (
 )
 
 type properties struct {
-	uf         *sdbus.UnitFile
-	utype      string
-	state      *sdbus.UnitStatus
-	ufPreset   string
-	ufState    string
-	properties map[string]interface{}
+	uf          *sdbus.UnitFile
+	utype       string
+	state       *sdbus.UnitStatus
+	ufPreset    string
+	ufState     string
+	activeEnter uint64
+	properties  map[string]interface{}
 }
 
 func TestDefaultPattern(t *testing.T) {
@@ -331,6 +332,7 @@ func TestShow(t *testing.T) {
 						"load_code":    0,
 						"active_code":  0,
 						"sub_code":     0,
+						"preset_drift": true,
 						"status_errno": 0,
 						"restarts":     1,
 						"mem_current":  uint64(1000),
@@ -379,6 +381,7 @@ func TestShow(t *testing.T) {
 						"load_code":    0,
 						"active_code":  0,
 						"sub_code":     4,
+						"preset_drift": true,
 						"status_errno": 0,
 						"restarts":     0,
 						"mem_current":  uint64(0),
@@ -431,6 +434,7 @@ func TestShow(t *testing.T) {
 						"load_code":    0,
 						"active_code":  3,
 						"sub_code":     12,
+						"preset_drift": true,
 						"status_errno": 10,
 						"restarts":     1,
 						"mem_current":  uint64(1000),
@@ -476,6 +480,7 @@ func TestShow(t *testing.T) {
 						"load_code":    2,
 						"active_code":  2,
 						"sub_code":     1,
+						"preset_drift": true,
 						"mem_current":  uint64(0),
 						"mem_peak":     uint64(0),
 						"swap_current": uint64(0),
@@ -546,6 +551,7 @@ func TestShow(t *testing.T) {
 						"load_code":    0,
 						"active_code":  int64(2),
 						"sub_code":     1,
+						"preset_drift": false,
 						"status_errno": 0,
 						"restarts":     0,
 						"mem_current":  uint64(0),
@@ -597,6 +603,224 @@ func TestShow(t *testing.T) {
 	}
 }
 
+func TestShowTimestampsAndAccounting(t *testing.T) {
+	tests := []struct {
+		name       string
+		unittype   string
+		properties map[string]properties
+		expected   []telegraf.Metric
+	}{
+		{
+			name:     "service",
+			unittype: "service",
+			properties: map[string]properties{
+				"example.service": {
+					utype: "Service",
+					state: &sdbus.UnitStatus{
+						Name:        "example.service",
+						LoadState:   "loaded",
+						ActiveState: "active",
+						SubState:    "running",
+					},
+					ufPreset:    "enabled",
+					ufState:     "enabled",
+					activeEnter: 1700000000000000,
+					properties: map[string]interface{}{
+						"Id":                     "example.service",
+						"StatusErrno":            0,
+						"NRestarts":              3,
+						"MemoryCurrent":          1000,
+						"MainPID":                9999,
+						"TasksCurrent":           12,
+						"ExecMainStartTimestamp": 1700000001000000,
+					},
+				},
+			},
+			expected: []telegraf.Metric{
+				metric.New(
+					"systemd_units",
+					map[string]string{
+						"name":   "example.service",
+						"load":   "loaded",
+						"active": "active",
+						"sub":    "running",
+						"state":  "enabled",
+						"preset": "enabled",
+					},
+					map[string]interface{}{
+						"load_code":                    0,
+						"active_code":                  0,
+						"sub_code":                     0,
+						"preset_drift":                 false,
+						"status_errno":                 0,
+						"restarts":                     3,
+						"pid":                          9999,
+						"mem_current":                  uint64(1000),
+						"mem_peak":                     uint64(0),
+						"swap_current":                 uint64(0),
+						"swap_peak":                    uint64(0),
+						"mem_avail":                    uint64(0),
+						"tasks_current":                uint64(12),
+						"active_enter_timestamp_us":    uint64(1700000000000000),
+						"exec_main_start_timestamp_us": uint64(1700000001000000),
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name:     "timer",
+			unittype: "timer",
+			properties: map[string]properties{
+				"example.timer": {
+					utype: "Timer",
+					state: &sdbus.UnitStatus{
+						Name:        "example.timer",
+						LoadState:   "loaded",
+						ActiveState: "active",
+						SubState:    "waiting",
+					},
+					ufPreset:    "enabled",
+					ufState:     "static",
+					activeEnter: 1700000000000000,
+					properties: map[string]interface{}{
+						"Id":                     "example.timer",
+						"NextElapseUSecRealtime": 1700003600000000,
+						"LastTriggerUSec":        uint64(math.MaxUint64),
+					},
+				},
+			},
+			expected: []telegraf.Metric{
+				metric.New(
+					"systemd_units",
+					map[string]string{
+						"name":   "example.timer",
+						"load":   "loaded",
+						"active": "active",
+						"sub":    "waiting",
+						"state":  "static",
+						"preset": "enabled",
+					},
+					map[string]interface{}{
+						"load_code":                 0,
+						"active_code":               0,
+						"sub_code":                  0x0010,
+						"mem_current":               uint64(0),
+						"mem_peak":                  uint64(0),
+						"swap_current":              uint64(0),
+						"swap_peak":                 uint64(0),
+						"mem_avail":                 uint64(0),
+						"active_enter_timestamp_us": uint64(1700000000000000),
+						"next_elapse_timestamp_us":  uint64(1700003600000000),
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name:     "timer never triggered",
+			unittype: "timer",
+			properties: map[string]properties{
+				"example.timer": {
+					utype: "Timer",
+					state: &sdbus.UnitStatus{
+						Name:        "example.timer",
+						LoadState:   "loaded",
+						ActiveState: "active",
+						SubState:    "waiting",
+					},
+					ufPreset:    "enabled",
+					ufState:     "static",
+					activeEnter: 1700000000000000,
+					properties: map[string]interface{}{
+						"Id":                     "example.timer",
+						"NextElapseUSecRealtime": uint64(0),
+						"LastTriggerUSec":        uint64(0),
+					},
+				},
+			},
+			expected: []telegraf.Metric{
+				metric.New(
+					"systemd_units",
+					map[string]string{
+						"name":   "example.timer",
+						"load":   "loaded",
+						"active": "active",
+						"sub":    "waiting",
+						"state":  "static",
+						"preset": "enabled",
+					},
+					map[string]interface{}{
+						"load_code":                 0,
+						"active_code":               0,
+						"sub_code":                  0x0010,
+						"mem_current":               uint64(0),
+						"mem_peak":                  uint64(0),
+						"swap_current":              uint64(0),
+						"swap_peak":                 uint64(0),
+						"mem_avail":                 uint64(0),
+						"active_enter_timestamp_us": uint64(1700000000000000),
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &SystemdUnits{
+				Pattern:  "examp*",
+				UnitType: tt.unittype,
+				Details:  true,
+				Timeout:  config.Duration(time.Second),
+				Log:      testutil.Logger{},
+			}
+			require.NoError(t, plugin.Init())
+
+			client := &fakeClient{
+				units:     tt.properties,
+				connected: true,
+			}
+			client.fixPropertyTypes()
+			plugin.client = client
+			defer plugin.Stop()
+
+			var acc testutil.Accumulator
+			require.NoError(t, acc.GatherError(plugin.Gather))
+			testutil.RequireMetricsEqual(t, tt.expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+		})
+	}
+}
+
+func TestPresetDrift(t *testing.T) {
+	tests := []struct {
+		state    string
+		preset   string
+		drift    bool
+		provided bool
+	}{
+		{state: "enabled", preset: "enabled", provided: true},
+		{state: "enabled", preset: "disabled", drift: true, provided: true},
+		{state: "enabled-runtime", preset: "disabled", drift: true, provided: true},
+		{state: "disabled", preset: "disabled", provided: true},
+		{state: "disabled", preset: "enabled", drift: true, provided: true},
+		{state: "masked", preset: "enabled", drift: true, provided: true},
+		{state: "static", preset: "enabled"},
+		{state: "indirect", preset: "disabled"},
+		{state: "enabled", preset: "ignore"},
+		{state: "enabled", preset: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state+"/"+tt.preset, func(t *testing.T) {
+			drift, ok := presetDrift(tt.state, tt.preset)
+			require.Equal(t, tt.provided, ok)
+			require.Equal(t, tt.drift, drift)
+		})
+	}
+}
+
 func TestMultiInstance(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -902,7 +1126,8 @@ type fakeClient struct {
 func (c *fakeClient) fixPropertyTypes() {
 	for unit, u := range c.units {
 		for k, value := range u.properties {
-			if strings.HasPrefix(k, "Memory") {
+			if strings.HasPrefix(k, "Memory") || strings.HasPrefix(k, "Tasks") ||
+				strings.HasSuffix(k, "Timestamp") || strings.HasSuffix(k, "USec") || strings.HasSuffix(k, "USecRealtime") {
 				//nolint:errcheck // will cause issues later in tests
 				u.properties[k], _ = internal.ToUint64(value)
 			}
@@ -985,6 +1210,10 @@ func (c *fakeClient) GetUnitPropertyContext(_ context.Context, unit, propertyNam
 		return &sdbus.Property{Name: propertyName, Value: dbus.MakeVariant(u.ufState)}, nil
 	case "UnitFilePreset":
 		return &sdbus.Property{Name: propertyName, Value: dbus.MakeVariant(u.ufPreset)}, nil
+	case "ActiveEnterTimestamp":
+		if u.activeEnter > 0 {
+			return &sdbus.Property{Name: propertyName, Value: dbus.MakeVariant(u.activeEnter)}, nil
+		}
 	}
 	return nil, errors.New("unknown property")
 }
@@ -8,6 +8,7 @@ import (
 	"context"
 	_ "embed"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 	"sync"
@@ -168,12 +169,13 @@ func (t *Tail) tailNewFiles(fromBeginning bool) error {
 		poll = true
 	}
 
+	globs, err := globpath.CompileList(t.Files)
+	if err != nil {
+		return fmt.Errorf("compiling globs failed: %w", err)
+	}
+
 	// Create a "tailer" for each file
-	for _, filepath := range t.Files {
-		g, err := globpath.Compile(filepath)
-		if err != nil {
-			t.Log.Errorf("Glob %q failed to compile: %s", filepath, err.Error())
-		}
+	for _, g := range globs {
 		for _, file := range g.Match() {
 			if _, ok := t.tailers[file]; ok {
 				// we're already tailing this file
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -27,12 +28,14 @@ type runner func(unbound Unbound) (*bytes.Buffer, error)
 
 // Unbound is used to store configuration values
 type Unbound struct {
-	Binary      string          `toml:"binary"`
-	Timeout     config.Duration `toml:"timeout"`
-	UseSudo     bool            `toml:"use_sudo"`
-	Server      string          `toml:"server"`
-	ThreadAsTag bool            `toml:"thread_as_tag"`
-	ConfigFile  string          `toml:"config_file"`
+	Binary       string          `toml:"binary"`
+	Timeout      config.Duration `toml:"timeout"`
+	UseSudo      bool            `toml:"use_sudo"`
+	Server       string          `toml:"server"`
+	ThreadAsTag  bool            `toml:"thread_as_tag"`
+	ConfigFile   string          `toml:"config_file"`
+	Histogram    bool            `toml:"histogram"`
+	StatsNoReset bool            `toml:"stats_noreset"`
 
 	run runner
 }
@@ -42,7 +45,10 @@ var defaultTimeout = config.Duration(time.Second)
 
 // Shell out to unbound_stat and return the output
 func unboundRunner(unbound Unbound) (*bytes.Buffer, error) {
-	cmdArgs := []string{"stats_noreset"}
+	cmdArgs := []string{"stats"}
+	if unbound.StatsNoReset {
+		cmdArgs = []string{"stats_noreset"}
+	}
 
 	if unbound.Server != "" {
 		host, port, err := net.SplitHostPort(unbound.Server)
@@ -96,9 +102,10 @@ func (*Unbound) SampleConfig() string {
 	return sampleConfig
 }
 
-// All the dots in stat name will replaced by underscores. Histogram statistics will not be collected.
+// All the dots in stat name will replaced by underscores. Histogram statistics are only collected
+// if enabled and reported as separate metrics.
 func (s *Unbound) Gather(acc telegraf.Accumulator) error {
-	// Always exclude histogram statistics
+	// Exclude histogram statistics from the regular fields
 	statExcluded := []string{"histogram.*"}
 	filterExcluded, err := filter.Compile(statExcluded)
 	if err != nil {
@@ -113,6 +120,7 @@ func (s *Unbound) Gather(acc telegraf.Accumulator) error {
 	// Process values
 	fields := make(map[string]interface{})
 	fieldsThreads := make(map[string]map[string]interface{})
+	var buckets []histogramBucket
 
 	scanner := bufio.NewScanner(out)
 	for scanner.Scan() {
@@ -128,6 +136,14 @@ func (s *Unbound) Gather(acc telegraf.Accumulator) error {
 
 		// Filter value
 		if filterExcluded.Match(stat) {
+			if s.Histogram {
+				bucket, err := parseHistogramBucket(stat, value)
+				if err != nil {
+					acc.AddError(err)
+					continue
+				}
+				buckets = append(buckets, bucket)
+			}
 			continue
 		}
 
@@ -172,19 +188,68 @@ func (s *Unbound) Gather(acc telegraf.Accumulator) error {
 		}
 	}
 
+	if len(buckets) > 0 {
+		addHistogram(acc, buckets)
+	}
+
 	return nil
 }
 
+type histogramBucket struct {
+	upperBound float64
+	count      uint64
+}
+
+// parseHistogramBucket parses a histogram statistic of the form
+// "histogram.<sec>.<usec>.to.<sec>.<usec>=<count>"
+func parseHistogramBucket(stat, value string) (histogramBucket, error) {
+	tokens := strings.Split(stat, ".")
+	if len(tokens) != 6 || tokens[3] != "to" {
+		return histogramBucket{}, fmt.Errorf("unexpected histogram statistic %q", stat)
+	}
+
+	sec, err := strconv.ParseUint(tokens[4], 10, 64)
+	if err != nil {
+		return histogramBucket{}, fmt.Errorf("invalid upper bound of histogram statistic %q: %w", stat, err)
+	}
+	usec, err := strconv.ParseUint(tokens[5], 10, 64)
+	if err != nil {
+		return histogramBucket{}, fmt.Errorf("invalid upper bound of histogram statistic %q: %w", stat, err)
+	}
+	count, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return histogramBucket{}, fmt.Errorf("expected a numerical value for %s = %v", stat, value)
+	}
+
+	return histogramBucket{upperBound: float64(sec) + float64(usec)/1e6, count: count}, nil
+}
+
+// addHistogram adds the recursion time histogram with cumulative bucket
+// counts tagged by the upper bound of the bucket in seconds similar to the
+// histogram aggregator
+func addHistogram(acc telegraf.Accumulator, buckets []histogramBucket) {
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].upperBound < buckets[j].upperBound })
+
+	var cumulative uint64
+	for _, bucket := range buckets {
+		cumulative += bucket.count
+		tags := map[string]string{"le": strconv.FormatFloat(bucket.upperBound, 'f', -1, 64)}
+		acc.AddFields("unbound_histogram", map[string]interface{}{"recursion_time_bucket": cumulative}, tags)
+	}
+	acc.AddFields("unbound_histogram", map[string]interface{}{"recursion_time_bucket": cumulative}, map[string]string{"le": "+Inf"})
+}
+
 func init() {
 	inputs.Add("unbound", func() telegraf.Input {
 		return &Unbound{
-			run:         unboundRunner,
-			Binary:      defaultBinary,
-			Timeout:     defaultTimeout,
-			UseSudo:     false,
-			Server:      "",
-			ThreadAsTag: false,
-			ConfigFile:  "",
+			run:          unboundRunner,
+			Binary:       defaultBinary,
+			Timeout:      defaultTimeout,
+			UseSudo:      false,
+			Server:       "",
+			ThreadAsTag:  false,
+			ConfigFile:   "",
+			StatsNoReset: true,
 		}
 	})
 }
@@ -52,6 +52,56 @@ func TestParseFullOutputThreadAsTag(t *testing.T) {
 	acc.AssertContainsFields(t, "unbound_threads", parsedFullOutputThreadAsTagMeasurementUnboundThreads)
 }
 
+func TestParseFullOutputHistogram(t *testing.T) {
+	acc := &testutil.Accumulator{}
+	v := &Unbound{
+		run:       UnboundControl(fullOutput),
+		Histogram: true,
+	}
+	require.NoError(t, v.Gather(acc))
+
+	require.True(t, acc.HasMeasurement("unbound"))
+	require.True(t, acc.HasMeasurement("unbound_histogram"))
+	acc.AssertContainsFields(t, "unbound", parsedFullOutput)
+
+	// 40 buckets of the output plus the +Inf bucket
+	require.Len(t, acc.Metrics, 42)
+
+	expected := []struct {
+		le    string
+		count uint64
+	}{
+		{"0.000001", 20},
+		{"0.000002", 25},
+		{"0.000004", 38},
+		{"1", 417935},
+		{"2", 418071},
+		{"524288", 418308},
+		{"+Inf", 418308},
+	}
+	for _, tt := range expected {
+		acc.AssertContainsTaggedFields(t, "unbound_histogram",
+			map[string]interface{}{"recursion_time_bucket": tt.count},
+			map[string]string{"le": tt.le},
+		)
+	}
+}
+
+func TestParseHistogramInvalid(t *testing.T) {
+	acc := &testutil.Accumulator{}
+	v := &Unbound{
+		run:       UnboundControl("histogram.000000.000000.to.000000=20\nhistogram.000000.000000.to.000000.000001=20\n"),
+		Histogram: true,
+	}
+	require.NoError(t, v.Gather(acc))
+	require.Len(t, acc.Errors, 1)
+	require.ErrorContains(t, acc.Errors[0], "unexpected histogram statistic")
+	acc.AssertContainsTaggedFields(t, "unbound_histogram",
+		map[string]interface{}{"recursion_time_bucket": uint64(20)},
+		map[string]string{"le": "0.000001"},
+	)
+}
+
 var parsedFullOutput = map[string]interface{}{
 	"thread0_num_queries":              float64(11907596),
 	"thread0_num_cachehits":            float64(11489288),
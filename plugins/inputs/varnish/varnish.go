@@ -70,8 +70,11 @@ type Varnish struct {
 	Timeout       config.Duration
 	Regexps       []string
 	MetricVersion int
+	UseVSM        bool     `toml:"use_vsm"`
+	StatsInclude  []string `toml:"stats_include"`
 
 	filter          filter.Filter
+	vsmFilter       filter.Filter
 	run             runner
 	admRun          runner
 	regexpsCompiled []*regexp.Regexp
@@ -112,6 +115,26 @@ func (s *Varnish) Init() error {
 		customRegexps = append(customRegexps, compiled)
 	}
 	s.regexpsCompiled = append(customRegexps, s.regexpsCompiled...)
+
+	// legacy support, change "all" -> "*":
+	if len(s.Stats) > 0 && s.Stats[0] == "all" {
+		s.Stats[0] = "*"
+	}
+
+	if s.UseVSM {
+		include := s.StatsInclude
+		if len(include) == 0 {
+			include = s.Stats
+		}
+		if len(include) == 0 {
+			include = defaultStats
+		}
+		f, err := filter.Compile(include)
+		if err != nil {
+			return fmt.Errorf("error compiling stats_include: %w", err)
+		}
+		s.vsmFilter = f
+	}
 	return nil
 }
 
@@ -127,10 +150,6 @@ func (s *Varnish) Gather(acc telegraf.Accumulator) error {
 		if len(s.Stats) == 0 {
 			s.filter, err = filter.Compile(defaultStats)
 		} else {
-			// legacy support, change "all" -> "*":
-			if s.Stats[0] == "all" {
-				s.Stats[0] = "*"
-			}
 			s.filter, err = filter.Compile(s.Stats)
 		}
 		if err != nil {
@@ -138,6 +157,10 @@ func (s *Varnish) Gather(acc telegraf.Accumulator) error {
 		}
 	}
 
+	if s.UseVSM {
+		return s.gatherVSM(acc)
+	}
+
 	admArgs, statsArgs := s.prepareCmdArgs()
 
 	statOut, err := s.run(s.Binary, s.UseSudo, statsArgs, s.Timeout)
@@ -262,28 +285,8 @@ func (s *Varnish) processMetricsV2(activeVcl string, acc telegraf.Accumulator, o
 			continue
 		}
 
-		var metricValue interface{}
-		var parseError error
 		flag := data["flag"]
-
-		if value, ok := data["value"]; ok {
-			if number, ok := value.(json.Number); ok {
-				// parse bitmap value
-				if flag == "b" {
-					if metricValue, parseError = strconv.ParseUint(number.String(), 10, 64); parseError != nil {
-						parseError = fmt.Errorf("%q value uint64 error: %w", fieldName, parseError)
-					}
-				} else if metricValue, parseError = number.Int64(); parseError != nil {
-					// try parse float
-					if metricValue, parseError = number.Float64(); parseError != nil {
-						parseError = fmt.Errorf("stat %q value %q is not valid number: %w", fieldName, value, parseError)
-					}
-				}
-			} else {
-				metricValue = value
-			}
-		}
-
+		metricValue, parseError := parseValueJSON(fieldName, data)
 		if parseError != nil {
 			acc.AddError(parseError)
 			continue
@@ -309,6 +312,37 @@ func (s *Varnish) processMetricsV2(activeVcl string, acc telegraf.Accumulator, o
 	return nil
 }
 
+// parseValueJSON converts the value of a counter of the varnishstat json
+// output to a bitmap, integer or float value
+func parseValueJSON(fieldName string, data map[string]interface{}) (interface{}, error) {
+	value, ok := data["value"]
+	if !ok {
+		return nil, nil
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return value, nil
+	}
+
+	// parse bitmap value
+	if data["flag"] == "b" {
+		v, err := strconv.ParseUint(number.String(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q value uint64 error: %w", fieldName, err)
+		}
+		return v, nil
+	}
+	if v, err := number.Int64(); err == nil {
+		return v, nil
+	}
+	// try parse float
+	v, err := number.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("stat %q value %q is not valid number: %w", fieldName, value, err)
+	}
+	return v, nil
+}
+
 // Parse the output of "varnishadm vcl.list -j" and find active vcls
 func getActiveVCLJson(out io.Reader) (string, error) {
 	var output = ""
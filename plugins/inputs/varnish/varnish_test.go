@@ -8,10 +8,13 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/testutil"
 )
 
@@ -632,3 +635,118 @@ func TestVarnishAdmJson(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "reload_20210723_091821_2056185", activeVcl)
 }
+
+func TestParseBackendCounter(t *testing.T) {
+	tests := []struct {
+		name    string
+		vcl     string
+		backend string
+		counter string
+		ok      bool
+	}{
+		{name: "boot.default.happy", vcl: "boot", backend: "default", counter: "happy", ok: true},
+		{name: "reload_20210622_153544_23757.server1.req", vcl: "reload_20210622_153544_23757", backend: "server1", counter: "req", ok: true},
+		{name: "default(127.0.0.1,,8080).pipe_out", backend: "default", counter: "pipe_out", ok: true},
+		{
+			name:    "vcl1.goto.000007c8.(10.1.2.3).(http://example.com:80).(ttl:5.000000).fail",
+			vcl:     "vcl1",
+			backend: "goto.000007c8.(10.1.2.3).(http://example.com:80).(ttl:5.000000)",
+			counter: "fail",
+			ok:      true,
+		},
+		{name: "happy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vcl, backend, counter, ok := parseBackendCounter(tt.name)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.vcl, vcl)
+			require.Equal(t, tt.backend, backend)
+			require.Equal(t, tt.counter, counter)
+		})
+	}
+}
+
+func TestGatherVSM(t *testing.T) {
+	output, err := os.ReadFile("test_data/varnish6.6.json")
+	require.NoError(t, err)
+
+	var args []string
+	v := &Varnish{
+		run: func(_ string, _ bool, a []string, _ config.Duration) (*bytes.Buffer, error) {
+			args = a
+			return bytes.NewBuffer(output), nil
+		},
+		UseVSM:        true,
+		StatsInclude:  []string{"MAIN.cache_hit", "MAIN.uptime", "VBE.*.conn", "VBE.*.happy"},
+		InstanceName:  "foo",
+		BinaryArgs:    []string{"-1"},
+		MetricVersion: 1,
+	}
+	require.NoError(t, v.Init())
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, v.Gather(acc))
+	require.Equal(t, []string{"-j", "-n", "foo"}, args)
+	require.Empty(t, acc.Errors)
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"varnish",
+			map[string]string{"section": "MAIN"},
+			map[string]interface{}{
+				"cache_hit": int64(0),
+				"uptime":    int64(238360),
+			},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"varnish",
+			map[string]string{"section": "VBE", "vcl": "boot", "backend": "default"},
+			map[string]interface{}{
+				"conn":  int64(0),
+				"happy": uint64(0),
+			},
+			time.Unix(0, 0),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.SortMetrics(), testutil.IgnoreTime())
+}
+
+func TestGatherVSMDefaultFilter(t *testing.T) {
+	output, err := os.ReadFile("test_data/varnish4_4.json")
+	require.NoError(t, err)
+
+	v := &Varnish{
+		run:    fakeVarnishRunner(string(output)),
+		UseVSM: true,
+		Stats:  defaultStats,
+	}
+	require.NoError(t, v.Init())
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, v.Gather(acc))
+	require.Empty(t, acc.Errors)
+	require.Len(t, acc.Metrics, 1)
+	require.Equal(t, map[string]string{"section": "MAIN"}, acc.Metrics[0].Tags)
+	require.Len(t, acc.Metrics[0].Fields, 3)
+}
+
+func TestGatherVSMLegacyAll(t *testing.T) {
+	output, err := os.ReadFile("test_data/varnish4_4.json")
+	require.NoError(t, err)
+
+	v := &Varnish{
+		run:    fakeVarnishRunner(string(output)),
+		UseVSM: true,
+		Stats:  []string{"all"},
+	}
+	require.NoError(t, v.Init())
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, v.Gather(acc))
+	require.Empty(t, acc.Errors)
+	require.True(t, acc.HasMeasurement("varnish"))
+	require.Greater(t, len(acc.Metrics), 1)
+}
@@ -0,0 +1,123 @@
+//go:build !windows
+
+package varnish
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/telegraf"
+)
+
+// gatherVSM reads all counters of the shared memory (VSM) at once using the
+// JSON output of varnishstat and adds the counters matching the include filter
+func (s *Varnish) gatherVSM(acc telegraf.Accumulator) error {
+	args := []string{"-j"}
+	if s.InstanceName != "" {
+		args = append(args, "-n", s.InstanceName)
+	}
+
+	out, err := s.run(s.Binary, s.UseSudo, args, s.Timeout)
+	if err != nil {
+		return fmt.Errorf("error gathering metrics: %w", err)
+	}
+	return s.processMetricsVSM(acc, out)
+}
+
+// processMetricsVSM adds the counters grouped by section. Backend counters
+// (VBE) are additionally tagged with the VCL and the backend name.
+func (s *Varnish) processMetricsVSM(acc telegraf.Accumulator, out *bytes.Buffer) error {
+	rootJSON := make(map[string]interface{})
+	dec := json.NewDecoder(out)
+	dec.UseNumber()
+	if err := dec.Decode(&rootJSON); err != nil {
+		return err
+	}
+
+	type series struct {
+		tags   map[string]string
+		fields map[string]interface{}
+	}
+	grouped := make(map[string]*series)
+	for name, raw := range getCountersJSON(rootJSON) {
+		if name == "timestamp" || !strings.Contains(name, ".") {
+			continue
+		}
+		if s.vsmFilter != nil && !s.vsmFilter.Match(name) {
+			continue
+		}
+		data, ok := raw.(map[string]interface{})
+		if !ok {
+			acc.AddError(fmt.Errorf("unexpected data from json: %s: %#v", name, raw))
+			continue
+		}
+		value, err := parseValueJSON(name, data)
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+		if value == nil {
+			continue
+		}
+
+		section, field, _ := strings.Cut(name, ".")
+		tags := map[string]string{"section": section}
+		if section == "VBE" {
+			if vcl, backend, counter, ok := parseBackendCounter(field); ok {
+				if vcl != "" {
+					tags["vcl"] = vcl
+				}
+				tags["backend"] = backend
+				field = counter
+			}
+		}
+
+		key := tags["section"] + "\x00" + tags["vcl"] + "\x00" + tags["backend"]
+		if _, found := grouped[key]; !found {
+			grouped[key] = &series{tags: tags, fields: make(map[string]interface{})}
+		}
+		grouped[key].fields[field] = value
+	}
+
+	for _, m := range grouped {
+		acc.AddFields(measurementNamespace, m.fields, m.tags)
+	}
+	return nil
+}
+
+// parseBackendCounter splits the name of a backend counter with the "VBE."
+// prefix removed into the VCL, the backend name and the counter. Supported
+// forms are "<vcl>.<backend>.<counter>" of Varnish 4.1 and newer, including
+// dynamic backends with dots in their name, and "<backend>(<address>).<counter>"
+// of Varnish 4.0.
+func parseBackendCounter(name string) (vcl, backend, counter string, ok bool) {
+	idx := strings.LastIndex(name, ".")
+	if idx <= 0 {
+		return "", "", "", false
+	}
+	backend, counter = name[:idx], name[idx+1:]
+
+	// Split off the VCL at the first dot not being part of an address
+	var depth int
+	for i, c := range backend {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '.':
+			if depth == 0 {
+				vcl, backend = backend[:i], backend[i+1:]
+				return vcl, backend, counter, backend != ""
+			}
+		}
+	}
+
+	// Strip the address of backends in the form "default(127.0.0.1,,8080)"
+	if p := strings.IndexByte(backend, '('); p > 0 {
+		backend = backend[:p]
+	}
+	return "", backend, counter, true
+}
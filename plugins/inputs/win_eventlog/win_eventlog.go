@@ -98,6 +98,15 @@ func (w *WinEventLog) Init() error {
 
 func (w *WinEventLog) Start(_ telegraf.Accumulator) error {
 	subscription, err := w.evtSubscribe()
+	if err != nil && w.subscriptionFlag == EvtSubscribeStartAfterBookmark {
+		// The bookmark might reference events or channels not available
+		// anymore, so start with new events instead of failing
+		w.Log.Warnf("Subscribing after the restored bookmark failed, collecting new events only: %v", err)
+		if err := w.resetBookmark(); err != nil {
+			return err
+		}
+		subscription, err = w.evtSubscribe()
+	}
 	if err != nil {
 		return fmt.Errorf("subscription of Windows Event Log failed: %w", err)
 	}
@@ -127,14 +136,27 @@ func (w *WinEventLog) SetState(state interface{}) error {
 		return fmt.Errorf("invalid type %T for state", state)
 	}
 
+	// Do not fail on corrupted states but continue with new events to avoid
+	// reading events twice
+	if bookmarkXML == "" {
+		w.Log.Warn("Restored state contains no bookmark, collecting new events only")
+		return w.resetBookmark()
+	}
+
 	ptr, err := syscall.UTF16PtrFromString(bookmarkXML)
 	if err != nil {
-		return fmt.Errorf("conversion to pointer failed: %w", err)
+		w.Log.Warnf("Restored bookmark is invalid, collecting new events only: %v", err)
+		return w.resetBookmark()
 	}
 
 	bookmark, err := _EvtCreateBookmark(ptr)
 	if err != nil {
-		return fmt.Errorf("creating bookmark failed: %w", err)
+		w.Log.Warnf("Creating bookmark from state failed, collecting new events only: %v", err)
+		return w.resetBookmark()
+	}
+	if w.bookmark != 0 {
+		//nolint:errcheck // replacing the bookmark, error can be ignored
+		_ = _EvtClose(w.bookmark)
 	}
 	w.bookmark = bookmark
 	w.subscriptionFlag = EvtSubscribeStartAfterBookmark
@@ -142,6 +164,23 @@ func (w *WinEventLog) SetState(state interface{}) error {
 	return nil
 }
 
+// resetBookmark replaces the current bookmark by an empty one and subscribes
+// to future events only
+func (w *WinEventLog) resetBookmark() error {
+	bookmark, err := _EvtCreateBookmark(nil)
+	if err != nil {
+		return fmt.Errorf("creating bookmark failed: %w", err)
+	}
+	if w.bookmark != 0 {
+		//nolint:errcheck // replacing the bookmark, error can be ignored
+		_ = _EvtClose(w.bookmark)
+	}
+	w.bookmark = bookmark
+	w.subscriptionFlag = EvtSubscribeToFutureEvents
+
+	return nil
+}
+
 // Gather Windows Event Log entries
 func (w *WinEventLog) Gather(acc telegraf.Accumulator) error {
 	for {
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf/testutil"
 )
 
 func TestWinEventLog_shouldExcludeEmptyField(t *testing.T) {
@@ -134,3 +136,35 @@ func TestWinEventLog_shouldProcessField(t *testing.T) {
 		})
 	}
 }
+
+func TestWinEventLog_SetState(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    string
+		expected EvtSubscribeFlag
+	}{
+		{
+			name:     "valid bookmark",
+			state:    "<BookmarkList>\r\n  <Bookmark Channel='Application' RecordId='1' IsCurrent='true'/>\r\n</BookmarkList>",
+			expected: EvtSubscribeStartAfterBookmark,
+		},
+		{
+			name:     "empty state",
+			expected: EvtSubscribeToFutureEvents,
+		},
+		{
+			name:     "corrupted bookmark",
+			state:    "<BookmarkList><Bookmark Channel=",
+			expected: EvtSubscribeToFutureEvents,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &WinEventLog{FromBeginning: true, Log: testutil.Logger{}}
+			require.NoError(t, w.Init())
+			require.NoError(t, w.SetState(tt.state))
+			require.Equal(t, tt.expected, w.subscriptionFlag)
+			require.NotZero(t, w.bookmark)
+		})
+	}
+}
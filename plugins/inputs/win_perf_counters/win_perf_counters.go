@@ -15,6 +15,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
 
@@ -52,18 +53,34 @@ type hostCountersInfo struct {
 	counters  []*counter
 	query     PerformanceQuery
 	timestamp time.Time
+	// expanded wildcard counter paths of objects refreshing their instances
+	wildcards map[*perfObject][]wildcardCounter
 }
 
 type perfObject struct {
-	Sources       []string
-	ObjectName    string
-	Counters      []string
-	Instances     []string
-	Measurement   string
-	WarnOnMissing bool
-	FailOnMissing bool
-	IncludeTotal  bool
-	UseRawValues  bool
+	Sources                 []string
+	ObjectName              string
+	Counters                []string
+	Instances               []string
+	ExcludeInstances        []string
+	Measurement             string
+	WarnOnMissing           bool
+	FailOnMissing           bool
+	IncludeTotal            bool
+	UseRawValues            bool
+	InstanceRefreshInterval config.Duration
+
+	exclude       filter.Filter
+	lastRefreshed time.Time
+}
+
+// wildcardCounter is a counter path containing wildcards along with the
+// configured names used for the expanded counters
+type wildcardCounter struct {
+	path        string
+	objectName  string
+	counterName string
+	instance    string
 }
 
 type counter struct {
@@ -76,6 +93,9 @@ type counter struct {
 	includeTotal  bool
 	useRawValue   bool
 	counterHandle pdhCounterHandle
+
+	object       *perfObject
+	failingSince time.Time
 }
 
 type instanceGrouping struct {
@@ -190,13 +210,31 @@ func newCounter(
 	if useRawValue {
 		newCounterName += "_Raw"
 	}
-	return &counter{counterPath, computer, objectName, newCounterName, instance, measurementName,
-		includeTotal, useRawValue, counterHandle}
+	return &counter{
+		counterPath:   counterPath,
+		computer:      computer,
+		objectName:    objectName,
+		counter:       newCounterName,
+		instance:      instance,
+		measurement:   measurementName,
+		includeTotal:  includeTotal,
+		useRawValue:   useRawValue,
+		counterHandle: counterHandle,
+	}
 }
 
 //nolint:revive //argument-limit conditionally more arguments allowed
 func (m *WinPerfCounters) AddItem(counterPath, computer, objectName, instance, counterName, measurement string, includeTotal bool, useRawValue bool) error {
-	origCounterPath := counterPath
+	obj := &perfObject{
+		ObjectName:   objectName,
+		Measurement:  measurement,
+		IncludeTotal: includeTotal,
+		UseRawValues: useRawValue,
+	}
+	return m.addItem(obj, counterPath, computer, instance, counterName)
+}
+
+func (m *WinPerfCounters) addItem(obj *perfObject, counterPath, computer, instance, counterName string) error {
 	var err error
 	var counterHandle pdhCounterHandle
 
@@ -231,7 +269,7 @@ func (m *WinPerfCounters) AddItem(counterPath, computer, objectName, instance, c
 	}
 
 	if m.UseWildcardsExpansion {
-		origInstance := instance
+		origCounterPath := counterPath
 		counterPath, err = hostCounter.query.GetCounterPath(counterHandle)
 		if err != nil {
 			return err
@@ -245,87 +283,37 @@ func (m *WinPerfCounters) AddItem(counterPath, computer, objectName, instance, c
 		if err != nil {
 			return err
 		}
-
-		for _, counterPath := range counters {
-			_, err := hostCounter.query.AddCounterToQuery(counterPath)
-			if err != nil {
-				return err
+		wildcard := wildcardCounter{
+			path:        counterPath,
+			objectName:  origObjectName,
+			counterName: origCounterName,
+			instance:    instance,
+		}
+		if obj.InstanceRefreshInterval > 0 {
+			if hostCounter.wildcards == nil {
+				hostCounter.wildcards = make(map[*perfObject][]wildcardCounter)
 			}
+			hostCounter.wildcards[obj] = append(hostCounter.wildcards[obj], wildcard)
+		}
 
-			computer, objectName, instance, counterName, err = extractCounterInfoFromCounterPath(counterPath)
-			if err != nil {
+		for _, counterPath := range counters {
+			if err := m.addExpandedCounter(hostCounter, obj, wildcard, counterPath, nil); err != nil {
 				return err
 			}
-
-			var newItem *counter
-			if !m.LocalizeWildcardsExpansion {
-				// On localized installations of Windows, Telegraf
-				// should return English metrics, but
-				// ExpandWildCardPath returns localized counters. Undo
-				// that by using the original object and counter
-				// names, along with the expanded instance.
-
-				var newInstance string
-				if instance == "" {
-					newInstance = emptyInstance
-				} else {
-					newInstance = instance
-				}
-				counterPath = formatPath(computer, origObjectName, newInstance, origCounterName)
-				counterHandle, err = hostCounter.query.AddEnglishCounterToQuery(counterPath)
-				if err != nil {
-					return err
-				}
-				newItem = newCounter(
-					counterHandle,
-					counterPath,
-					computer,
-					origObjectName, instance,
-					origCounterName,
-					measurement,
-					includeTotal,
-					useRawValue,
-				)
-			} else {
-				counterHandle, err = hostCounter.query.AddCounterToQuery(counterPath)
-				if err != nil {
-					return err
-				}
-				newItem = newCounter(
-					counterHandle,
-					counterPath,
-					computer,
-					objectName,
-					instance,
-					counterName,
-					measurement,
-					includeTotal,
-					useRawValue,
-				)
-			}
-
-			if instance == "_Total" && origInstance == "*" && !includeTotal {
-				continue
-			}
-
-			hostCounter.counters = append(hostCounter.counters, newItem)
-
-			if m.PrintValid {
-				m.Log.Infof("Valid: %s", counterPath)
-			}
 		}
 	} else {
 		newItem := newCounter(
 			counterHandle,
 			counterPath,
 			computer,
-			objectName,
+			obj.ObjectName,
 			instance,
 			counterName,
-			measurement,
-			includeTotal,
-			useRawValue,
+			obj.Measurement,
+			obj.IncludeTotal,
+			obj.UseRawValues,
 		)
+		newItem.object = obj
 		hostCounter.counters = append(hostCounter.counters, newItem)
 		if m.PrintValid {
 			m.Log.Infof("Valid: %s", counterPath)
@@ -335,6 +323,74 @@ func (m *WinPerfCounters) AddItem(counterPath, computer, objectName, instance, c
 	return nil
 }
 
+// addExpandedCounter adds the counter found by expanding the given wildcard
+// counter unless the instance is excluded or the counter path is contained in
+// the given known paths
+func (m *WinPerfCounters) addExpandedCounter(
+	hostCounter *hostCountersInfo,
+	obj *perfObject,
+	wildcard wildcardCounter,
+	counterPath string,
+	known map[string]bool,
+) error {
+	computer, objectName, instance, counterName, err := extractCounterInfoFromCounterPath(counterPath)
+	if err != nil {
+		return err
+	}
+	if instance == "_Total" && wildcard.instance == "*" && !obj.IncludeTotal {
+		return nil
+	}
+	if obj.exclude != nil && obj.exclude.Match(instance) {
+		return nil
+	}
+
+	if !m.LocalizeWildcardsExpansion {
+		// On localized installations of Windows, Telegraf
+		// should return English metrics, but
+		// ExpandWildCardPath returns localized counters. Undo
+		// that by using the original object and counter
+		// names, along with the expanded instance.
+		newInstance := instance
+		if instance == "" {
+			newInstance = emptyInstance
+		}
+		counterPath = formatPath(computer, wildcard.objectName, newInstance, wildcard.counterName)
+		objectName = wildcard.objectName
+		counterName = wildcard.counterName
+	}
+	if known[counterPath] {
+		return nil
+	}
+
+	var counterHandle pdhCounterHandle
+	if !m.LocalizeWildcardsExpansion {
+		counterHandle, err = hostCounter.query.AddEnglishCounterToQuery(counterPath)
+	} else {
+		counterHandle, err = hostCounter.query.AddCounterToQuery(counterPath)
+	}
+	if err != nil {
+		return err
+	}
+	newItem := newCounter(
+		counterHandle,
+		counterPath,
+		computer,
+		objectName,
+		instance,
+		counterName,
+		obj.Measurement,
+		obj.IncludeTotal,
+		obj.UseRawValues,
+	)
+	newItem.object = obj
+	hostCounter.counters = append(hostCounter.counters, newItem)
+
+	if m.PrintValid {
+		m.Log.Infof("Valid: %s", counterPath)
+	}
+	return nil
+}
+
 const emptyInstance = "------"
 
 func formatPath(computer, objectName, instance, counter string) string {
@@ -362,7 +418,8 @@ func (m *WinPerfCounters) ParseConfig() error {
 		return err
 	}
 
-	for _, PerfObject := range m.Object {
+	for i := range m.Object {
+		PerfObject := &m.Object[i]
 		computers := PerfObject.Sources
 		if len(computers) == 0 {
 			computers = m.Sources
@@ -380,8 +437,7 @@ func (m *WinPerfCounters) ParseConfig() error {
 					objectName := PerfObject.ObjectName
 					counterPath = formatPath(computer, objectName, instance, counter)
 
-					err := m.AddItem(counterPath, computer, objectName, instance, counter,
-						PerfObject.Measurement, PerfObject.IncludeTotal, PerfObject.UseRawValues)
+					err := m.addItem(PerfObject, counterPath, computer, instance, counter)
 					if err != nil {
 						if PerfObject.FailOnMissing || PerfObject.WarnOnMissing {
 							m.Log.Errorf("Invalid counterPath %q: %s", counterPath, err.Error())
@@ -431,8 +487,13 @@ func (m *WinPerfCounters) Gather(acc telegraf.Accumulator) error {
 			}
 		}
 		m.lastRefreshed = time.Now()
+		for i := range m.Object {
+			m.Object[i].lastRefreshed = m.lastRefreshed
+		}
 		// minimum time between collecting two samples
 		time.Sleep(time.Second)
+	} else if m.UseWildcardsExpansion {
+		m.refreshInstances(time.Now())
 	}
 
 	for _, hostCounterSet := range m.hostCounters {
@@ -468,9 +529,87 @@ func (m *WinPerfCounters) Gather(acc telegraf.Accumulator) error {
 	return nil
 }
 
+// refreshInstances adds the counters of new instances and removes the counters
+// of vanished instances for all objects with an instance refresh interval
+func (m *WinPerfCounters) refreshInstances(now time.Time) {
+	for i := range m.Object {
+		obj := &m.Object[i]
+		interval := time.Duration(obj.InstanceRefreshInterval)
+		if interval <= 0 || now.Sub(obj.lastRefreshed) < interval {
+			continue
+		}
+		obj.lastRefreshed = now
+
+		for _, hostCounter := range m.hostCounters {
+			wildcards, found := hostCounter.wildcards[obj]
+			if !found {
+				continue
+			}
+			m.removeVanishedCounters(hostCounter, obj, now)
+
+			known := make(map[string]bool, len(hostCounter.counters))
+			for _, c := range hostCounter.counters {
+				known[c.counterPath] = true
+			}
+			for _, wildcard := range wildcards {
+				counterPaths, err := hostCounter.query.ExpandWildCardPath(wildcard.path)
+				if err != nil {
+					m.Log.Warnf("Refreshing instances of %q on %q failed: %v", wildcard.path, hostCounter.computer, err)
+					continue
+				}
+				for _, counterPath := range counterPaths {
+					if err := m.addExpandedCounter(hostCounter, obj, wildcard, counterPath, known); err != nil {
+						m.Log.Warnf("Adding counter %q failed: %v", counterPath, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// removeVanishedCounters removes the counters of the given object failing for
+// longer than the instance refresh interval from the query
+func (m *WinPerfCounters) removeVanishedCounters(hostCounter *hostCountersInfo, obj *perfObject, now time.Time) {
+	counters := hostCounter.counters[:0]
+	for _, c := range hostCounter.counters {
+		if c.object != obj || !c.vanished(now) {
+			counters = append(counters, c)
+			continue
+		}
+		if err := hostCounter.query.RemoveCounter(c.counterHandle); err != nil {
+			m.Log.Warnf("Removing counter %q failed: %v", c.counterPath, err)
+		}
+		m.Log.Debugf("Removed vanished counter %q", c.counterPath)
+	}
+	hostCounter.counters = counters
+}
+
+// vanished checks if the counter is failing for longer than the instance
+// refresh interval of its object
+func (c *counter) vanished(now time.Time) bool {
+	if c.failingSince.IsZero() || c.object == nil || c.object.InstanceRefreshInterval <= 0 {
+		return false
+	}
+	return now.Sub(c.failingSince) >= time.Duration(c.object.InstanceRefreshInterval)
+}
+
+// counterFailed logs the error of a counter unless the counter vanished
+func (m *WinPerfCounters) counterFailed(metric *counter, now time.Time, err error) {
+	if metric.failingSince.IsZero() {
+		metric.failingSince = now
+	}
+	if metric.vanished(now) {
+		m.Log.Debugf("Counter %q, instance: %s, is failing since %v, will skip metric: %v",
+			metric.counterPath, metric.instance, metric.failingSince, err)
+		return
+	}
+	m.Log.Warnf("Error while getting value for counter %q, instance: %s, will skip metric: %v", metric.counterPath, metric.instance, err)
+}
+
 func (m *WinPerfCounters) gatherComputerCounters(hostCounterInfo *hostCountersInfo, acc telegraf.Accumulator) error {
 	var value interface{}
 	var err error
+	now := time.Now()
 	collectedFields := make(fieldGrouping)
 	// For iterate over the known metrics and get the samples.
 	for _, metric := range hostCounterInfo.counters {
@@ -486,9 +625,10 @@ func (m *WinPerfCounters) gatherComputerCounters(hostCounterInfo *hostCountersIn
 				if !isKnownCounterDataError(err) {
 					return fmt.Errorf("error while getting value for counter %q: %w", metric.counterPath, err)
 				}
-				m.Log.Warnf("Error while getting value for counter %q, instance: %s, will skip metric: %v", metric.counterPath, metric.instance, err)
+				m.counterFailed(metric, now, err)
 				continue
 			}
+			metric.failingSince = time.Time{}
 			addCounterMeasurement(metric, metric.instance, value, collectedFields)
 		} else {
 			var counterValues []counterValue
@@ -502,9 +642,10 @@ func (m *WinPerfCounters) gatherComputerCounters(hostCounterInfo *hostCountersIn
 				if !isKnownCounterDataError(err) {
 					return fmt.Errorf("error while getting value for counter %q: %w", metric.counterPath, err)
 				}
-				m.Log.Warnf("Error while getting value for counter %q, instance: %s, will skip metric: %v", metric.counterPath, metric.instance, err)
+				m.counterFailed(metric, now, err)
 				continue
 			}
+			metric.failingSince = time.Time{}
 			for _, cValue := range counterValues {
 				if strings.Contains(metric.instance, "#") && strings.HasPrefix(metric.instance, cValue.InstanceName) {
 					// If you are using a multiple instance identifier such as "w3wp#1"
@@ -544,6 +685,9 @@ func (m *WinPerfCounters) cleanQueries() error {
 }
 
 func shouldIncludeMetric(metric *counter, cValue counterValue) bool {
+	if metric.object != nil && metric.object.exclude != nil && metric.object.exclude.Match(cValue.InstanceName) {
+		return false
+	}
 	if metric.includeTotal {
 		// If IncludeTotal is set, include all.
 		return true
@@ -592,6 +736,19 @@ func (m *WinPerfCounters) Init() error {
 		return fmt.Errorf("maximum buffer size should be smaller than %d", uint32(math.MaxUint32))
 	}
 
+	for i := range m.Object {
+		object := &m.Object[i]
+		exclude, err := filter.Compile(object.ExcludeInstances)
+		if err != nil {
+			return fmt.Errorf("invalid ExcludeInstances for object %q: %w", object.ObjectName, err)
+		}
+		object.exclude = exclude
+
+		if object.InstanceRefreshInterval > 0 && !m.UseWildcardsExpansion {
+			m.Log.Warnf("InstanceRefreshInterval of object %q has no effect without UseWildcardsExpansion", object.ObjectName)
+		}
+	}
+
 	if m.UseWildcardsExpansion && !m.LocalizeWildcardsExpansion {
 		// Counters must not have wildcards with this option
 		found := false
@@ -27,6 +27,7 @@ type FakePerformanceQuery struct {
 	vistaAndNewer bool
 	expandPaths   map[string][]string
 	openCalled    bool
+	removed       []string
 }
 
 var MetricTime = time.Date(2018, 5, 28, 12, 0, 0, 0, time.UTC)
@@ -86,6 +87,19 @@ func (m *FakePerformanceQuery) AddEnglishCounterToQuery(counterPath string) (pdh
 	return 0, fmt.Errorf("in AddEnglishCounterToQuery: invalid counter path: %q", counterPath)
 }
 
+func (m *FakePerformanceQuery) RemoveCounter(counterHandle pdhCounterHandle) error {
+	if !m.openCalled {
+		return errors.New("in RemoveCounter: uninitialized query")
+	}
+	for path, counter := range m.counters {
+		if counter.handle == counterHandle {
+			m.removed = append(m.removed, path)
+			return nil
+		}
+	}
+	return fmt.Errorf("in RemoveCounter: invalid handle: %q", counterHandle)
+}
+
 func (m *FakePerformanceQuery) GetCounterPath(counterHandle pdhCounterHandle) (string, error) {
 	for _, counter := range m.counters {
 		if counter.handle == counterHandle {
@@ -1775,6 +1789,121 @@ func TestGatherTotalNoExpansion(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGatherExcludeInstances(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping long taking test in short mode")
+	}
+	for _, expansion := range []bool{false, true} {
+		t.Run(fmt.Sprintf("expansion %v", expansion), func(t *testing.T) {
+			measurement := "m"
+			perfObjects := createPerfObject("", measurement, "O", []string{"*"}, []string{"C1"}, true, true, false)
+			perfObjects[0].ExcludeInstances = []string{"_Total", "Idle*"}
+			cps := []string{"\\O(I1)\\C1", "\\O(Idle0)\\C1", "\\O(_Total)\\C1"}
+			m := WinPerfCounters{
+				Log:                        testutil.Logger{},
+				UseWildcardsExpansion:      expansion,
+				LocalizeWildcardsExpansion: true,
+				MaxBufferSize:              defaultMaxBufferSize,
+				Object:                     perfObjects,
+				queryCreator: &FakePerformanceQueryCreator{
+					fakeQueries: map[string]*FakePerformanceQuery{"localhost": {
+						counters: createCounterMap(
+							append([]string{"\\O(*)\\C1"}, cps...),
+							[]float64{0, 1.1, 1.2, 1.3},
+							[]uint32{0, 0, 0, 0}),
+						expandPaths: map[string][]string{
+							"\\O(*)\\C1": cps,
+						},
+						vistaAndNewer: true,
+					}},
+				},
+			}
+			require.NoError(t, m.Init())
+
+			var acc testutil.Accumulator
+			require.NoError(t, m.Gather(&acc))
+			require.Len(t, acc.Metrics, 1)
+			acc.AssertContainsTaggedFields(t, measurement,
+				map[string]interface{}{"C1": 1.1},
+				map[string]string{"instance": "I1", "objectname": "O", "source": hostname()},
+			)
+			require.NoError(t, m.cleanQueries())
+		})
+	}
+}
+
+func TestGatherInstanceRefresh(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping long taking test in short mode")
+	}
+	measurement := "m"
+	perfObjects := createPerfObject("", measurement, "O", []string{"*"}, []string{"C1"}, true, false, false)
+	perfObjects[0].InstanceRefreshInterval = config.Duration(time.Minute)
+	cps := []string{"\\O(I1)\\C1", "\\O(I2)\\C1"}
+	fpm := &FakePerformanceQuery{
+		counters: createCounterMap(
+			append([]string{"\\O(*)\\C1"}, cps...),
+			[]float64{0, 1.1, 1.2},
+			[]uint32{0, 0, 0}),
+		expandPaths: map[string][]string{
+			"\\O(*)\\C1": cps,
+		},
+		vistaAndNewer: true,
+	}
+	m := WinPerfCounters{
+		Log:                        testutil.Logger{},
+		UseWildcardsExpansion:      true,
+		LocalizeWildcardsExpansion: true,
+		MaxBufferSize:              defaultMaxBufferSize,
+		Object:                     perfObjects,
+		queryCreator: &FakePerformanceQueryCreator{
+			fakeQueries: map[string]*FakePerformanceQuery{"localhost": fpm},
+		},
+	}
+	require.NoError(t, m.Init())
+
+	var acc1 testutil.Accumulator
+	require.NoError(t, m.Gather(&acc1))
+	require.Len(t, acc1.Metrics, 2)
+
+	// Instance I2 vanishes and I3 appears
+	vanished := fpm.counters["\\O(I2)\\C1"]
+	vanished.status = PdhCstatusNoInstance
+	fpm.counters["\\O(I2)\\C1"] = vanished
+	fpm.counters["\\O(I3)\\C1"] = testCounter{10, "\\O(I3)\\C1", 1.3, 0}
+	fpm.expandPaths["\\O(*)\\C1"] = []string{"\\O(I1)\\C1", "\\O(I3)\\C1"}
+
+	// The new instance is not discovered before the refresh interval elapsed
+	var acc2 testutil.Accumulator
+	require.NoError(t, m.Gather(&acc2))
+	require.Len(t, acc2.Metrics, 1)
+	require.Len(t, m.hostCounters["localhost"].counters, 2)
+	require.Empty(t, fpm.removed)
+
+	// Simulate the elapsed refresh interval
+	m.Object[0].lastRefreshed = m.Object[0].lastRefreshed.Add(-time.Minute)
+	for _, c := range m.hostCounters["localhost"].counters {
+		if !c.failingSince.IsZero() {
+			c.failingSince = c.failingSince.Add(-time.Minute)
+		}
+	}
+
+	var acc3 testutil.Accumulator
+	require.NoError(t, m.Gather(&acc3))
+	require.Len(t, acc3.Metrics, 2)
+	acc3.AssertContainsTaggedFields(t, measurement,
+		map[string]interface{}{"C1": 1.1},
+		map[string]string{"instance": "I1", "objectname": "O", "source": hostname()},
+	)
+	acc3.AssertContainsTaggedFields(t, measurement,
+		map[string]interface{}{"C1": 1.3},
+		map[string]string{"instance": "I3", "objectname": "O", "source": hostname()},
+	)
+	require.Equal(t, []string{"\\O(I2)\\C1"}, fpm.removed)
+	require.Len(t, m.hostCounters["localhost"].counters, 2)
+	require.NoError(t, m.cleanQueries())
+}
+
 func TestGatherMultiComps(t *testing.T) {
 	var err error
 	perfObjects := []perfObject{
@@ -104,16 +104,29 @@ type WinServices struct {
 
 	ServiceNames         []string `toml:"service_names"`
 	ServiceNamesExcluded []string `toml:"excluded_service_names"`
+	StartupTypeInclude   []string `toml:"startup_type_include"`
 	mgrProvider          ManagerProvider
 
 	servicesFilter filter.Filter
+	startupTypes   map[string]bool
 }
 
 type serviceInfo struct {
-	ServiceName string
-	DisplayName string
-	State       int
-	StartUpMode int
+	ServiceName    string
+	DisplayName    string
+	State          int
+	StartUpMode    int
+	StartupType    string
+	ServiceAccount string
+}
+
+// startupTypes maps the service start types to their names
+var startupTypes = map[uint32]string{
+	windows.SERVICE_BOOT_START:   "boot",
+	windows.SERVICE_SYSTEM_START: "system",
+	windows.SERVICE_AUTO_START:   "automatic",
+	windows.SERVICE_DEMAND_START: "manual",
+	windows.SERVICE_DISABLED:     "disabled",
 }
 
 func (*WinServices) SampleConfig() string {
@@ -138,6 +151,18 @@ func (m *WinServices) Init() error {
 	}
 	m.servicesFilter = f
 
+	if len(m.StartupTypeInclude) > 0 {
+		m.startupTypes = make(map[string]bool, len(m.StartupTypeInclude))
+		for _, t := range m.StartupTypeInclude {
+			switch t = strings.ToLower(t); t {
+			case "boot", "system", "automatic", "automatic-delayed", "manual", "disabled":
+				m.startupTypes[t] = true
+			default:
+				return fmt.Errorf("invalid startup type %q", t)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -154,7 +179,7 @@ func (m *WinServices) Gather(acc telegraf.Accumulator) error {
 	}
 
 	for _, srvName := range serviceNames {
-		service, err := collectServiceInfo(scmgr, srvName)
+		service, err := m.collectServiceInfo(scmgr, srvName)
 		if err != nil {
 			if IsPermission(err) {
 				m.Log.Debug(err.Error())
@@ -163,6 +188,9 @@ func (m *WinServices) Gather(acc telegraf.Accumulator) error {
 			}
 			continue
 		}
+		if service == nil {
+			continue
+		}
 
 		tags := map[string]string{
 			"service_name": service.ServiceName,
@@ -175,6 +203,10 @@ func (m *WinServices) Gather(acc telegraf.Accumulator) error {
 		fields := map[string]interface{}{
 			"state":        service.State,
 			"startup_mode": service.StartUpMode,
+			"startup_type": service.StartupType,
+		}
+		if service.ServiceAccount != "" {
+			fields["service_account"] = service.ServiceAccount
 		}
 		acc.AddFields("win_services", fields, tags)
 	}
@@ -201,8 +233,9 @@ func (m *WinServices) listServices(scmgr WinServiceManager) ([]string, error) {
 	return services, nil
 }
 
-// collectServiceInfo gathers info about a service.
-func collectServiceInfo(scmgr WinServiceManager, serviceName string) (*serviceInfo, error) {
+// collectServiceInfo gathers info about a service. Services not matching the
+// startup type filter are skipped by returning no info.
+func (m *WinServices) collectServiceInfo(scmgr WinServiceManager, serviceName string) (*serviceInfo, error) {
 	srv, err := scmgr.OpenService(serviceName)
 	if err != nil {
 		return nil, &serviceError{
@@ -213,33 +246,52 @@ func collectServiceInfo(scmgr WinServiceManager, serviceName string) (*serviceIn
 	}
 	defer srv.Close()
 
-	srvStatus, err := srv.Query()
+	// Query the config first to skip querying the status of filtered services
+	srvCfg, err := srv.Config()
 	if err != nil {
 		return nil, &serviceError{
-			Message: "could not query service",
+			Message: "could not get config of service",
 			Service: serviceName,
 			Err:     err,
 		}
 	}
 
-	srvCfg, err := srv.Config()
+	startupType := startupTypeName(srvCfg)
+	if m.startupTypes != nil && !m.startupTypes[startupType] {
+		return nil, nil
+	}
+
+	srvStatus, err := srv.Query()
 	if err != nil {
 		return nil, &serviceError{
-			Message: "could not get config of service",
+			Message: "could not query service",
 			Service: serviceName,
 			Err:     err,
 		}
 	}
 
 	serviceInfo := &serviceInfo{
-		ServiceName: serviceName,
-		DisplayName: srvCfg.DisplayName,
-		StartUpMode: int(srvCfg.StartType),
-		State:       int(srvStatus.State),
+		ServiceName:    serviceName,
+		DisplayName:    srvCfg.DisplayName,
+		StartUpMode:    int(srvCfg.StartType),
+		State:          int(srvStatus.State),
+		StartupType:    startupType,
+		ServiceAccount: srvCfg.ServiceStartName,
 	}
 	return serviceInfo, nil
 }
 
+// startupTypeName returns the name of the startup type of a service
+func startupTypeName(cfg mgr.Config) string {
+	if cfg.StartType == windows.SERVICE_AUTO_START && cfg.DelayedAutoStart {
+		return "automatic-delayed"
+	}
+	if name, found := startupTypes[cfg.StartType]; found {
+		return name
+	}
+	return "unknown"
+}
+
 func init() {
 	inputs.Add("win_services", func() telegraf.Input {
 		return &WinServices{
@@ -8,11 +8,15 @@ import (
 	"fmt"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/svc"
 	"golang.org/x/sys/windows/svc/mgr"
 
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/testutil"
 )
 
@@ -208,6 +212,8 @@ func TestGatherContainsTag(t *testing.T) {
 		tags := make(map[string]string)
 		fields["state"] = s.state
 		fields["startup_mode"] = s.startUpMode
+		fields["startup_type"] = "automatic"
+		fields["service_account"] = s.serviceName
 		tags["service_name"] = s.serviceName
 		tags["display_name"] = s.displayName
 		acc1.AssertContainsTaggedFields(t, "win_services", fields, tags)
@@ -231,8 +237,102 @@ func TestExcludingNamesTag(t *testing.T) {
 		tags := make(map[string]string)
 		fields["state"] = s.state
 		fields["startup_mode"] = s.startUpMode
+		fields["startup_type"] = "automatic"
+		fields["service_account"] = s.serviceName
 		tags["service_name"] = s.serviceName
 		tags["display_name"] = s.displayName
 		acc1.AssertDoesNotContainsTaggedFields(t, "win_services", fields, tags)
 	}
 }
+
+func TestGatherStartupTypeInclude(t *testing.T) {
+	data := testData{[]string{"Service 1", "Service 2", "Service 3", "Other"}, nil, nil, []serviceTestInfo{
+		{nil, nil, nil, "Service 1", "Fake service 1", 1, 2},
+		{nil, nil, nil, "Service 2", "Fake service 2", 4, 3},
+		{nil, nil, nil, "Service 3", "Fake service 3", 1, 4},
+		{nil, nil, nil, "Other", "Fake service 4", 1, 2},
+	}}
+	winServices := &WinServices{
+		Log:                  testutil.Logger{},
+		ServiceNames:         []string{"Service*"},
+		ServiceNamesExcluded: []string{"Service 3"},
+		StartupTypeInclude:   []string{"automatic", "Disabled"},
+		mgrProvider:          &FakeMgProvider{data},
+	}
+	require.NoError(t, winServices.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, winServices.Gather(&acc))
+	require.Empty(t, acc.Errors)
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"win_services",
+			map[string]string{
+				"service_name": "Service 1",
+				"display_name": "Fake service 1",
+			},
+			map[string]interface{}{
+				"state":           1,
+				"startup_mode":    2,
+				"startup_type":    "automatic",
+				"service_account": "Service 1",
+			},
+			time.Unix(0, 0),
+		),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestInvalidStartupType(t *testing.T) {
+	winServices := &WinServices{
+		Log:                testutil.Logger{},
+		StartupTypeInclude: []string{"automatic-delayed", "sometimes"},
+		mgrProvider:        &FakeMgProvider{},
+	}
+	require.ErrorContains(t, winServices.Init(), "invalid startup type")
+}
+
+func TestStartupTypeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      mgr.Config
+		expected string
+	}{
+		{
+			name:     "boot",
+			cfg:      mgr.Config{StartType: windows.SERVICE_BOOT_START},
+			expected: "boot",
+		},
+		{
+			name:     "automatic",
+			cfg:      mgr.Config{StartType: mgr.StartAutomatic},
+			expected: "automatic",
+		},
+		{
+			name:     "automatic delayed",
+			cfg:      mgr.Config{StartType: mgr.StartAutomatic, DelayedAutoStart: true},
+			expected: "automatic-delayed",
+		},
+		{
+			name:     "manual",
+			cfg:      mgr.Config{StartType: mgr.StartManual},
+			expected: "manual",
+		},
+		{
+			name:     "disabled",
+			cfg:      mgr.Config{StartType: mgr.StartDisabled},
+			expected: "disabled",
+		},
+		{
+			name:     "unknown",
+			cfg:      mgr.Config{StartType: 42},
+			expected: "unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, startupTypeName(tt.cfg))
+		})
+	}
+}
@@ -5,11 +5,13 @@ import (
 	_ "embed"
 	"fmt"
 	"strings"
+	"time"
 
 	"golang.zx2c4.com/wireguard/wgctrl"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
 
@@ -32,8 +34,10 @@ var (
 // Wireguard is an input that enumerates all Wireguard interfaces/devices on
 // the host, and reports gauge metrics for the device itself and its peers.
 type Wireguard struct {
-	Devices []string        `toml:"devices"`
-	Log     telegraf.Logger `toml:"-"`
+	Devices          []string        `toml:"devices"`
+	HandshakeTimeout config.Duration `toml:"handshake_timeout"`
+	IncludeEndpoint  bool            `toml:"include_endpoint"`
+	Log              telegraf.Logger `toml:"-"`
 
 	client *wgctrl.Client
 }
@@ -129,10 +133,22 @@ func (wg *Wireguard) gatherDevicePeerMetrics(acc telegraf.Accumulator, device *w
 		"tx_bytes":               peer.TransmitBytes,
 	}
 
+	// Peers without any handshake have a zero handshake time so the age is
+	// omitted for those
+	fields["connected"] = false
+	if !peer.LastHandshakeTime.IsZero() {
+		age := time.Since(peer.LastHandshakeTime)
+		gauges["handshake_age_seconds"] = int64(age.Seconds())
+		fields["connected"] = age <= time.Duration(wg.HandshakeTimeout)
+	}
+
 	tags := map[string]string{
 		"device":     device.Name,
 		"public_key": peer.PublicKey.String(),
 	}
+	if wg.IncludeEndpoint && peer.Endpoint != nil {
+		tags["endpoint"] = peer.Endpoint.IP.String()
+	}
 
 	acc.AddFields(measurementPeer, fields, tags)
 	acc.AddGauge(measurementPeer, gauges, tags)
@@ -140,6 +156,8 @@ func (wg *Wireguard) gatherDevicePeerMetrics(acc telegraf.Accumulator, device *w
 
 func init() {
 	inputs.Add("wireguard", func() telegraf.Input {
-		return &Wireguard{}
+		return &Wireguard{
+			HandshakeTimeout: config.Duration(5 * time.Minute),
+		}
 	})
 }
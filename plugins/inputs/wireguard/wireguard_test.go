@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/testutil"
 )
 
@@ -48,7 +49,7 @@ func TestWireguard_gatherDevicePeerMetrics(t *testing.T) {
 	pubkey, err := wgtypes.ParseKey("NZTRIrv/ClTcQoNAnChEot+WL7OH7uEGQmx8oAN9rWE=")
 	require.NoError(t, err)
 
-	wg := &Wireguard{}
+	wg := &Wireguard{HandshakeTimeout: config.Duration(5 * time.Minute)}
 	device := &wgtypes.Device{
 		Name: "wg0",
 	}
@@ -67,6 +68,7 @@ func TestWireguard_gatherDevicePeerMetrics(t *testing.T) {
 		"protocol_version":                 0,
 		"allowed_ips":                      2,
 		"allowed_peer_cidr":                "<nil>,<nil>",
+		"connected":                        false,
 	}
 	expectGauges := map[string]interface{}{
 		"last_handshake_time_ns": int64(100000000000),
@@ -80,7 +82,14 @@ func TestWireguard_gatherDevicePeerMetrics(t *testing.T) {
 
 	wg.gatherDevicePeerMetrics(&acc, device, peer)
 
-	require.Equal(t, 7, acc.NFields())
+	require.Equal(t, 9, acc.NFields())
+	age, found := acc.Int64Field(measurementPeer, "handshake_age_seconds")
+	require.True(t, found)
+	require.InDelta(t, time.Since(peer.LastHandshakeTime).Seconds(), float64(age), 2)
+	for _, m := range acc.Metrics {
+		delete(m.Fields, "handshake_age_seconds")
+	}
+
 	acc.AssertDoesNotContainMeasurement(t, measurementDevice)
 	acc.AssertContainsTaggedFields(t, measurementPeer, expectFields, expectTags)
 	acc.AssertContainsTaggedFields(t, measurementPeer, expectGauges, expectTags)
@@ -140,6 +149,7 @@ func TestWireguard_allowedPeerCIDR(t *testing.T) {
 				"protocol_version":                 0,
 				"allowed_ips":                      len(tc.allowedIPs),
 				"allowed_peer_cidr":                tc.allowedPeerCidr,
+				"connected":                        false,
 			}
 			_ = map[string]string{
 				"device":     "wg0",
@@ -152,3 +162,94 @@ func TestWireguard_allowedPeerCIDR(t *testing.T) {
 		})
 	}
 }
+
+func TestWireguard_peerConnection(t *testing.T) {
+	endpoint := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 51820}
+
+	var testcases = []struct {
+		name            string
+		handshake       time.Time
+		endpoint        *net.UDPAddr
+		includeEndpoint bool
+		connected       bool
+		expectAge       bool
+		expectTags      map[string]string
+	}{
+		{
+			name:       "recent handshake",
+			handshake:  time.Now().Add(-30 * time.Second),
+			connected:  true,
+			expectAge:  true,
+			expectTags: map[string]string{"device": "wg0"},
+		},
+		{
+			name:       "stale handshake",
+			handshake:  time.Now().Add(-10 * time.Minute),
+			expectAge:  true,
+			expectTags: map[string]string{"device": "wg0"},
+		},
+		{
+			name:       "no handshake",
+			expectTags: map[string]string{"device": "wg0"},
+		},
+		{
+			name:       "endpoint not included",
+			handshake:  time.Now().Add(-30 * time.Second),
+			endpoint:   endpoint,
+			connected:  true,
+			expectAge:  true,
+			expectTags: map[string]string{"device": "wg0"},
+		},
+		{
+			name:            "endpoint included",
+			handshake:       time.Now().Add(-30 * time.Second),
+			endpoint:        endpoint,
+			includeEndpoint: true,
+			connected:       true,
+			expectAge:       true,
+			expectTags:      map[string]string{"device": "wg0", "endpoint": "192.0.2.1"},
+		},
+		{
+			name:            "endpoint included but unknown",
+			includeEndpoint: true,
+			expectTags:      map[string]string{"device": "wg0"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var acc testutil.Accumulator
+
+			wg := &Wireguard{
+				HandshakeTimeout: config.Duration(5 * time.Minute),
+				IncludeEndpoint:  tc.includeEndpoint,
+			}
+			device := &wgtypes.Device{
+				Name: "wg0",
+			}
+			peer := wgtypes.Peer{
+				LastHandshakeTime: tc.handshake,
+				Endpoint:          tc.endpoint,
+			}
+
+			wg.gatherDevicePeerMetrics(&acc, device, peer)
+
+			metrics := acc.GetTelegrafMetrics()
+			require.Len(t, metrics, 2)
+			for _, m := range metrics {
+				tags := m.Tags()
+				delete(tags, "public_key")
+				require.Equal(t, tc.expectTags, tags)
+			}
+
+			connected, found := acc.BoolField(measurementPeer, "connected")
+			require.True(t, found)
+			require.Equal(t, tc.connected, connected)
+
+			age, found := acc.Int64Field(measurementPeer, "handshake_age_seconds")
+			require.Equal(t, tc.expectAge, found)
+			if tc.expectAge {
+				require.InDelta(t, time.Since(tc.handshake).Seconds(), float64(age), 2)
+			}
+		})
+	}
+}
@@ -12,7 +12,9 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"net/smtp"
 	"net/url"
 	"os"
@@ -44,13 +46,15 @@ type X509Cert struct {
 	Timeout          config.Duration `toml:"timeout"`
 	ServerName       string          `toml:"server_name"`
 	ExcludeRootCerts bool            `toml:"exclude_root_certs"`
+	CheckOCSP        bool            `toml:"check_ocsp"`
 	Log              telegraf.Logger `toml:"-"`
 	common_tls.ClientConfig
 	proxy.TCPProxy
 
-	tlsCfg    *tls.Config
-	locations []*url.URL
-	globpaths []*globpath.GlobPath
+	tlsCfg     *tls.Config
+	locations  []*url.URL
+	globpaths  []*globpath.GlobPath
+	ocspClient *http.Client
 
 	classification map[string]string
 }
@@ -88,6 +92,13 @@ func (c *X509Cert) Init() error {
 	}
 	c.tlsCfg = tlsCfg
 
+	// Always bound the time taken for querying the OCSP responder
+	ocspTimeout := time.Duration(c.Timeout)
+	if ocspTimeout <= 0 {
+		ocspTimeout = 5 * time.Second
+	}
+	c.ocspClient = &http.Client{Timeout: ocspTimeout}
+
 	return nil
 }
 
@@ -174,26 +185,26 @@ func (c *X509Cert) Gather(acc telegraf.Accumulator) error {
 					} else {
 						tags["ocsp_verified"] = "no"
 					}
-					// resp.Status: 0=Good 1=Revoked 2=Unknown
-					fields["ocsp_status_code"] = resp.Status
-					switch resp.Status {
-					case 0:
-						tags["ocsp_status"] = "good"
-					case 1:
-						tags["ocsp_status"] = "revoked"
-						// Status=Good: revoked_at always = -62135596800
-						fields["ocsp_revoked_at"] = resp.RevokedAt.Unix()
-					default:
-						tags["ocsp_status"] = "unknown"
-					}
-					fields["ocsp_produced_at"] = resp.ProducedAt.Unix()
-					fields["ocsp_this_update"] = resp.ThisUpdate.Unix()
-					fields["ocsp_next_update"] = resp.NextUpdate.Unix()
+					addOCSPStatus(resp, tags, fields)
 				}
 			} else {
 				tags["ocsp_stapled"] = "no"
 			}
 
+			// Ask the responder if there is no valid stapled response
+			if i == 0 && c.CheckOCSP && tags["ocsp_stapled"] == "no" {
+				resp, err := c.queryOCSP(cert, findIssuer(cert, certs[1:]))
+				if err != nil {
+					c.Log.Debugf("Querying OCSP responder for %q failed: %v", location, err)
+					tags["ocsp_status"] = "error"
+					fields["ocsp_error"] = err.Error()
+				} else {
+					tags["ocsp_verified"] = "yes"
+					delete(fields, "ocsp_error")
+					addOCSPStatus(resp, tags, fields)
+				}
+			}
+
 			// Determine the classification
 			sig := hex.EncodeToString(cert.Signature)
 			if class, found := c.classification[sig]; found {
@@ -212,6 +223,77 @@ func (c *X509Cert) Gather(acc telegraf.Accumulator) error {
 	return nil
 }
 
+// addOCSPStatus adds the status of the OCSP response to the metric
+func addOCSPStatus(resp *ocsp.Response, tags map[string]string, fields map[string]interface{}) {
+	// resp.Status: 0=Good 1=Revoked 2=Unknown
+	fields["ocsp_status_code"] = resp.Status
+	switch resp.Status {
+	case 0:
+		tags["ocsp_status"] = "good"
+	case 1:
+		tags["ocsp_status"] = "revoked"
+		// Status=Good: revoked_at always = -62135596800
+		fields["ocsp_revoked_at"] = resp.RevokedAt.Unix()
+	default:
+		tags["ocsp_status"] = "unknown"
+	}
+	fields["ocsp_produced_at"] = resp.ProducedAt.Unix()
+	fields["ocsp_this_update"] = resp.ThisUpdate.Unix()
+	fields["ocsp_next_update"] = resp.NextUpdate.Unix()
+}
+
+// findIssuer returns the certificate of the chain which signed the given
+// certificate or nil if the issuer is not part of the chain
+func findIssuer(cert *x509.Certificate, chain []*x509.Certificate) *x509.Certificate {
+	for _, candidate := range chain {
+		if cert.CheckSignatureFrom(candidate) == nil {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// queryOCSP requests the revocation status of the certificate from the OCSP
+// responder given in the authority information access extension
+func (c *X509Cert) queryOCSP(cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	if len(cert.OCSPServer) == 0 {
+		return nil, errors.New("no OCSP responder in certificate")
+	}
+	if issuer == nil {
+		return nil, errors.New("issuer certificate not found in chain")
+	}
+
+	request, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating OCSP request failed: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, cert.OCSPServer[0], bytes.NewReader(request))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+	req.Header.Set("Accept", "application/ocsp-response")
+
+	resp, err := c.ocspClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCSP responder returned status %q", resp.Status)
+	}
+
+	// Limit the size of the response, responses are usually about 1-2 kB
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("reading OCSP response failed: %w", err)
+	}
+
+	return ocsp.ParseResponseForCert(body, cert, issuer)
+}
+
 func (c *X509Cert) processCertificate(certificate *x509.Certificate, opts x509.VerifyOptions) error {
 	chains, err := certificate.Verify(opts)
 	if err != nil {
@@ -273,18 +355,24 @@ func (c *X509Cert) processCertificate(certificate *x509.Certificate, opts x509.V
 }
 
 func (c *X509Cert) sourcesToURLs() error {
+	var patterns []string
 	for _, source := range c.Sources {
+		// Negated file patterns exclude files from the other patterns
+		negation, negated := "", false
+		if s, found := strings.CutPrefix(source, "!"); found {
+			source, negation, negated = s, "!", true
+		}
+
 		if strings.HasPrefix(source, "file://") || strings.HasPrefix(source, "/") {
 			source = filepath.ToSlash(strings.TrimPrefix(source, "file://"))
 			// Removing leading slash in Windows path containing a drive-letter
 			// like "file:///C:/Windows/..."
 			source = reDriveLetter.ReplaceAllString(source, "$1")
-			g, err := globpath.Compile(source)
-			if err != nil {
-				return fmt.Errorf("could not compile glob %q: %w", source, err)
-			}
-			c.globpaths = append(c.globpaths, g)
+			patterns = append(patterns, negation+source)
 		} else {
+			if negated {
+				return fmt.Errorf("negation is only supported for file sources but found %q", "!"+source)
+			}
 			if strings.Index(source, ":\\") == 1 {
 				source = "file://" + filepath.ToSlash(source)
 			}
@@ -296,6 +384,12 @@ func (c *X509Cert) sourcesToURLs() error {
 		}
 	}
 
+	globs, err := globpath.CompileList(patterns)
+	if err != nil {
+		return fmt.Errorf("could not compile globs: %w", err)
+	}
+	c.globpaths = globs
+
 	return nil
 }
 
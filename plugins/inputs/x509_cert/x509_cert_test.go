@@ -9,6 +9,7 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
+	"io"
 	"math/big"
 	"net"
 	"net/http"
@@ -23,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pion/dtls/v2"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
@@ -678,3 +680,153 @@ func TestClassification(t *testing.T) {
 	actual := acc.GetTelegrafMetrics()
 	testutil.RequireMetricsEqual(t, expected, actual, opts...)
 }
+
+func TestGatherOCSP(t *testing.T) {
+	// Create a CA and a leaf certificate referring to a local OCSP responder
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	var status int
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		req, err := ocsp.ParseRequest(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp, err := ocsp.CreateResponse(ca, ca, ocsp.Response{
+			Status:       status,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Unix(1700000000, 0),
+			NextUpdate:   time.Unix(1700086400, 0),
+			RevokedAt:    time.Unix(1690000000, 0),
+		}, caKey)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if _, err := w.Write(resp); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer responder.Close()
+
+	createLeaf := func(ocspServer []string) string {
+		leafKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		leafTemplate := &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: "leaf.example.org"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			OCSPServer:   ocspServer,
+		}
+		leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, &leafKey.PublicKey, caKey)
+		require.NoError(t, err)
+
+		content := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})
+		content = append(content, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})...)
+		fn := filepath.Join(t.TempDir(), "chain.pem")
+		require.NoError(t, os.WriteFile(fn, content, 0640))
+		return fn
+	}
+
+	// Reserve an address without a listening server
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := "http://" + listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	tests := []struct {
+		name       string
+		ocspServer []string
+		status     int
+		expected   string
+		fields     map[string]interface{}
+	}{
+		{
+			name:       "good",
+			ocspServer: []string{responder.URL},
+			status:     ocsp.Good,
+			expected:   "good",
+			fields: map[string]interface{}{
+				"ocsp_status_code": 0,
+				"ocsp_next_update": int64(1700086400),
+				"ocsp_this_update": int64(1700000000),
+			},
+		},
+		{
+			name:       "revoked",
+			ocspServer: []string{responder.URL},
+			status:     ocsp.Revoked,
+			expected:   "revoked",
+			fields: map[string]interface{}{
+				"ocsp_status_code": 1,
+				"ocsp_revoked_at":  int64(1690000000),
+				"ocsp_next_update": int64(1700086400),
+			},
+		},
+		{
+			name:       "unreachable responder",
+			ocspServer: []string{unreachable},
+			expected:   "error",
+		},
+		{
+			name:     "no responder",
+			expected: "error",
+			fields: map[string]interface{}{
+				"ocsp_error": "no OCSP responder in certificate",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status = tt.status
+			plugin := &X509Cert{
+				Sources:   []string{createLeaf(tt.ocspServer)},
+				Timeout:   config.Duration(5 * time.Second),
+				CheckOCSP: true,
+				Log:       testutil.Logger{},
+			}
+			require.NoError(t, plugin.Init())
+
+			var acc testutil.Accumulator
+			require.NoError(t, plugin.Gather(&acc))
+			require.Empty(t, acc.Errors)
+			require.Len(t, acc.Metrics, 2)
+
+			leaf := acc.Metrics[0]
+			require.Equal(t, "no", leaf.Tags["ocsp_stapled"])
+			require.Equal(t, tt.expected, leaf.Tags["ocsp_status"])
+			for k, v := range tt.fields {
+				require.Equal(t, v, leaf.Fields[k], k)
+			}
+			if tt.expected == "error" {
+				require.Contains(t, leaf.Fields, "ocsp_error")
+			} else {
+				require.Equal(t, "yes", leaf.Tags["ocsp_verified"])
+			}
+
+			// Only the leaf certificate is checked
+			require.NotContains(t, acc.Metrics[1].Tags, "ocsp_status")
+		})
+	}
+}
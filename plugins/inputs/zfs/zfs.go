@@ -5,6 +5,8 @@ import (
 	_ "embed"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/filter"
 )
 
 //go:embed sample.conf
@@ -16,17 +18,22 @@ type Zdataset func(properties []string) ([]string, error)
 type Uname func() (string, error)
 
 type Zfs struct {
-	KstatPath      string
-	KstatMetrics   []string
-	PoolMetrics    bool
-	DatasetMetrics bool
-	Log            telegraf.Logger `toml:"-"`
+	KstatPath       string
+	KstatMetrics    []string
+	PoolMetrics     bool
+	DatasetMetrics  bool
+	DatasetsInclude []string        `toml:"datasets_include"`
+	ZpoolStatus     bool            `toml:"zpool_status"`
+	Timeout         config.Duration `toml:"timeout"`
+	Log             telegraf.Logger `toml:"-"`
 
-	sysctl   Sysctl   //nolint:unused // False positive - this var is used for non-default build tag: freebsd
-	zpool    Zpool    //nolint:unused // False positive - this var is used for non-default build tag: freebsd
-	zdataset Zdataset //nolint:unused // False positive - this var is used for non-default build tag: freebsd
-	uname    Uname    //nolint:unused // False positive - this var is used for non-default build tag: freebsd
-	version  int64    //nolint:unused // False positive - this var is used for non-default build tag: freebsd
+	sysctl        Sysctl //nolint:unused // False positive - this var is used for non-default build tag: freebsd
+	zpool         Zpool  //nolint:unused // False positive - this var is used for non-default build tag: freebsd
+	zpoolStatus   Zpool
+	zdataset      Zdataset
+	uname         Uname //nolint:unused // False positive - this var is used for non-default build tag: freebsd
+	version       int64 //nolint:unused // False positive - this var is used for non-default build tag: freebsd
+	datasetFilter filter.Filter
 }
 
 func (*Zfs) SampleConfig() string {
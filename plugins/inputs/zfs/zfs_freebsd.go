@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/plugins/inputs"
 	"golang.org/x/sys/unix"
 )
@@ -37,6 +38,12 @@ func (z *Zfs) Init() error {
 		}
 	}
 
+	f, err := filter.Compile(z.DatasetsInclude)
+	if err != nil {
+		return fmt.Errorf("creating dataset filter failed: %w", err)
+	}
+	z.datasetFilter = f
+
 	return nil
 }
 
@@ -184,6 +191,9 @@ func (z *Zfs) gatherDatasetStats(acc telegraf.Accumulator) (string, error) {
 			z.Log.Warnf("Invalid number of columns for line: %s", line)
 			continue
 		}
+		if z.datasetFilter != nil && !z.datasetFilter.Match(col[0]) {
+			continue
+		}
 
 		tags := map[string]string{"dataset": col[0]}
 		fields := map[string]interface{}{}
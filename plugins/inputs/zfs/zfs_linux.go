@@ -3,13 +3,18 @@
 package zfs
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
@@ -179,6 +184,27 @@ func gatherPoolStats(pool poolInfo, acc telegraf.Accumulator) error {
 	return nil
 }
 
+func (z *Zfs) Init() error {
+	f, err := filter.Compile(z.DatasetsInclude)
+	if err != nil {
+		return fmt.Errorf("creating dataset filter failed: %w", err)
+	}
+	z.datasetFilter = f
+
+	if z.zdataset == nil {
+		z.zdataset = func(properties []string) ([]string, error) {
+			return run(time.Duration(z.Timeout), "zfs", "list", "-Hp", "-t", "filesystem,volume", "-o", strings.Join(properties, ","))
+		}
+	}
+	if z.zpoolStatus == nil {
+		z.zpoolStatus = func() ([]string, error) {
+			return run(time.Duration(z.Timeout), "zpool", "status", "-p", "-P")
+		}
+	}
+
+	return nil
+}
+
 func (z *Zfs) Gather(acc telegraf.Accumulator) error {
 	kstatMetrics := z.KstatMetrics
 	if len(kstatMetrics) == 0 {
@@ -233,11 +259,150 @@ func (z *Zfs) Gather(acc telegraf.Accumulator) error {
 		}
 	}
 	acc.AddFields("zfs", fields, tags)
+
+	if z.DatasetMetrics {
+		if err := z.gatherDatasetStats(acc); err != nil {
+			acc.AddError(fmt.Errorf("gathering dataset stats failed: %w", err))
+		}
+	}
+
+	if z.ZpoolStatus {
+		if err := z.gatherVdevStats(acc); err != nil {
+			acc.AddError(fmt.Errorf("gathering vdev stats failed: %w", err))
+		}
+	}
+
 	return nil
 }
 
+func (z *Zfs) gatherDatasetStats(acc telegraf.Accumulator) error {
+	properties := []string{"name", "avail", "used", "usedsnap", "usedds", "refer"}
+
+	lines, err := z.zdataset(properties)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		col := strings.Split(line, "\t")
+		if len(col) != len(properties) {
+			z.Log.Warnf("Invalid number of columns for line: %s", line)
+			continue
+		}
+		if z.datasetFilter != nil && !z.datasetFilter.Match(col[0]) {
+			continue
+		}
+
+		fields := make(map[string]interface{}, len(properties)-1)
+		for i, key := range properties[1:] {
+			// Treat '-' entries as zero
+			if col[i+1] == "-" {
+				fields[key] = int64(0)
+				continue
+			}
+			value, err := strconv.ParseInt(col[i+1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("parsing %s %q failed: %w", key, col[i+1], err)
+			}
+			fields[key] = value
+		}
+
+		acc.AddFields("zfs_dataset", fields, map[string]string{"dataset": col[0]})
+	}
+
+	return nil
+}
+
+// gatherVdevStats parses the configuration section of the pools reported by
+// "zpool status" looking like this:
+//
+//	  pool: rpool
+//	 state: ONLINE
+//	config:
+//
+//		NAME                   STATE     READ WRITE CKSUM
+//		rpool                  ONLINE       0     0     0
+//		  mirror-0             ONLINE       0     0     0
+//		    /dev/sda3          ONLINE       0     0     0
+//		    /dev/sdb3          ONLINE       0     0     2
+//
+//	errors: No known data errors
+//
+// Lines without error counters, e.g. the headings of log or spare devices,
+// are skipped.
+func (z *Zfs) gatherVdevStats(acc telegraf.Accumulator) error {
+	lines, err := z.zpoolStatus()
+	if err != nil {
+		return err
+	}
+
+	var pool string
+	var inConfig bool
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "pool:"):
+			pool = strings.TrimSpace(strings.TrimPrefix(line, "pool:"))
+			inConfig = false
+			continue
+		case strings.HasPrefix(line, "NAME") && strings.Contains(line, "CKSUM"):
+			inConfig = true
+			continue
+		case strings.HasPrefix(line, "errors:"):
+			inConfig = false
+			continue
+		}
+		if !inConfig {
+			continue
+		}
+
+		col := strings.Fields(line)
+		if len(col) < 5 {
+			continue
+		}
+		fields := make(map[string]interface{}, 3)
+		for i, key := range []string{"read_errors", "write_errors", "checksum_errors"} {
+			value, err := strconv.ParseInt(col[i+2], 10, 64)
+			if err != nil {
+				break
+			}
+			fields[key] = value
+		}
+		if len(fields) != 3 {
+			continue
+		}
+
+		tags := map[string]string{
+			"pool":  pool,
+			"vdev":  col[0],
+			"state": col[1],
+		}
+		acc.AddFields("zfs_vdev", fields, tags)
+	}
+
+	return nil
+}
+
+func run(timeout time.Duration, command string, args ...string) ([]string, error) {
+	cmd := exec.Command(command, args...)
+	var outbuf, errbuf bytes.Buffer
+	cmd.Stdout = &outbuf
+	cmd.Stderr = &errbuf
+	if err := internal.RunTimeout(cmd, timeout); err != nil {
+		if stderr := strings.TrimSpace(errbuf.String()); stderr != "" {
+			return nil, fmt.Errorf("%s error: %s", command, stderr)
+		}
+		return nil, fmt.Errorf("%s error: %w", command, err)
+	}
+
+	return strings.Split(strings.TrimSpace(outbuf.String()), "\n"), nil
+}
+
 func init() {
 	inputs.Add("zfs", func() telegraf.Input {
-		return &Zfs{}
+		return &Zfs{Timeout: config.Duration(5 * time.Second)}
 	})
 }
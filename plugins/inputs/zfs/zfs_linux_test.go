@@ -3,14 +3,54 @@
 package zfs
 
 import (
+	"errors"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/testutil"
 )
 
+const zpoolStatusContents = `  pool: backup
+ state: DEGRADED
+status: One or more devices are faulted in response to persistent errors.
+	Sufficient replicas exist for the pool to continue functioning in a
+	degraded state.
+action: Replace the faulted device, or use 'zpool clear' to mark the device
+	repaired.
+  scan: scrub repaired 0B in 02:11:34 with 0 errors on Sun Oct 11 02:35:35 2026
+config:
+
+	NAME                                 STATE     READ WRITE CKSUM
+	backup                               DEGRADED     0     0     0
+	  raidz1-0                           DEGRADED     0     0     0
+	    /dev/disk/by-id/ata-disk1-part1  ONLINE       0     0     0
+	    /dev/disk/by-id/ata-disk2-part1  FAULTED      3   120     0  too many errors
+	    /dev/disk/by-id/ata-disk3-part1  ONLINE       0     0    17
+
+errors: No known data errors
+
+  pool: rpool
+ state: ONLINE
+  scan: scrub repaired 0B in 00:01:12 with 0 errors on Sun Oct 11 00:25:13 2026
+config:
+
+	NAME                STATE     READ WRITE CKSUM
+	rpool               ONLINE       0     0     0
+	  mirror-0          ONLINE       0     0     0
+	    /dev/sda3       ONLINE       0     0     0
+	    /dev/sdb3       ONLINE       0     0     0
+	logs
+	  /dev/nvme0n1p1    ONLINE       0     0     0
+	spares
+	  /dev/sdc3         AVAIL
+
+errors: No known data errors`
+
 const arcstatsContents = `5 1 0x01 86 4128 23617128247 12081618582809582
 name                            type data
 hits                            4    5968846374
@@ -396,6 +436,163 @@ func TestGetTags(t *testing.T) {
 	}
 }
 
+func TestZfsDatasetMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		include  []string
+		expected []telegraf.Metric
+	}{
+		{
+			name: "all datasets",
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"zfs_dataset",
+					map[string]string{"dataset": "rpool"},
+					map[string]interface{}{
+						"avail":    int64(10741741326336),
+						"used":     int64(8564135526400),
+						"usedsnap": int64(0),
+						"usedds":   int64(98304),
+						"refer":    int64(98304),
+					},
+					time.Unix(0, 0),
+				),
+				testutil.MustMetric(
+					"zfs_dataset",
+					map[string]string{"dataset": "rpool/data"},
+					map[string]interface{}{
+						"avail":    int64(10741741326336),
+						"used":     int64(8564135428096),
+						"usedsnap": int64(1048576),
+						"usedds":   int64(8564134379520),
+						"refer":    int64(8564134379520),
+					},
+					time.Unix(0, 0),
+				),
+				testutil.MustMetric(
+					"zfs_dataset",
+					map[string]string{"dataset": "rpool/vm-100-disk-0"},
+					map[string]interface{}{
+						"avail":    int64(10741741326336),
+						"used":     int64(34359738368),
+						"usedsnap": int64(0),
+						"usedds":   int64(0),
+						"refer":    int64(5368709120),
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name:    "filtered datasets",
+			include: []string{"rpool/data*"},
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"zfs_dataset",
+					map[string]string{"dataset": "rpool/data"},
+					map[string]interface{}{
+						"avail":    int64(10741741326336),
+						"used":     int64(8564135428096),
+						"usedsnap": int64(1048576),
+						"usedds":   int64(8564134379520),
+						"refer":    int64(8564134379520),
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z := &Zfs{
+				KstatPath:       t.TempDir(),
+				DatasetMetrics:  true,
+				DatasetsInclude: tt.include,
+				Log:             testutil.Logger{},
+				zdataset: func([]string) ([]string, error) {
+					return []string{
+						"rpool\t10741741326336\t8564135526400\t0\t98304\t98304",
+						"rpool/data\t10741741326336\t8564135428096\t1048576\t8564134379520\t8564134379520",
+						"rpool/vm-100-disk-0\t10741741326336\t34359738368\t0\t-\t5368709120",
+					}, nil
+				},
+			}
+			require.NoError(t, z.Init())
+
+			var acc testutil.Accumulator
+			require.NoError(t, z.Gather(&acc))
+			require.Empty(t, acc.Errors)
+
+			testutil.RequireMetricsEqual(t, tt.expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+		})
+	}
+}
+
+func TestZfsVdevMetrics(t *testing.T) {
+	z := &Zfs{
+		KstatPath:   t.TempDir(),
+		ZpoolStatus: true,
+		zpoolStatus: func() ([]string, error) {
+			return strings.Split(zpoolStatusContents, "\n"), nil
+		},
+	}
+	require.NoError(t, z.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, z.Gather(&acc))
+	require.Empty(t, acc.Errors)
+
+	vdev := func(pool, name, state string, read, write, cksum int64) telegraf.Metric {
+		return testutil.MustMetric(
+			"zfs_vdev",
+			map[string]string{"pool": pool, "vdev": name, "state": state},
+			map[string]interface{}{
+				"read_errors":     read,
+				"write_errors":    write,
+				"checksum_errors": cksum,
+			},
+			time.Unix(0, 0),
+		)
+	}
+	expected := []telegraf.Metric{
+		vdev("backup", "backup", "DEGRADED", 0, 0, 0),
+		vdev("backup", "raidz1-0", "DEGRADED", 0, 0, 0),
+		vdev("backup", "/dev/disk/by-id/ata-disk1-part1", "ONLINE", 0, 0, 0),
+		vdev("backup", "/dev/disk/by-id/ata-disk2-part1", "FAULTED", 3, 120, 0),
+		vdev("backup", "/dev/disk/by-id/ata-disk3-part1", "ONLINE", 0, 0, 17),
+		vdev("rpool", "rpool", "ONLINE", 0, 0, 0),
+		vdev("rpool", "mirror-0", "ONLINE", 0, 0, 0),
+		vdev("rpool", "/dev/sda3", "ONLINE", 0, 0, 0),
+		vdev("rpool", "/dev/sdb3", "ONLINE", 0, 0, 0),
+		vdev("rpool", "/dev/nvme0n1p1", "ONLINE", 0, 0, 0),
+	}
+
+	var actual []telegraf.Metric
+	for _, m := range acc.GetTelegrafMetrics() {
+		if m.Name() == "zfs_vdev" {
+			actual = append(actual, m)
+		}
+	}
+	testutil.RequireMetricsEqual(t, expected, actual, testutil.IgnoreTime())
+}
+
+func TestZfsVdevMetricsError(t *testing.T) {
+	z := &Zfs{
+		KstatPath:   t.TempDir(),
+		ZpoolStatus: true,
+		zpoolStatus: func() ([]string, error) {
+			return nil, errors.New("zpool error: permission denied")
+		},
+	}
+	require.NoError(t, z.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, z.Gather(&acc))
+	require.Len(t, acc.Errors, 1)
+	require.ErrorContains(t, acc.Errors[0], "permission denied")
+}
+
 func getKstatMetricsArcOnly() map[string]interface{} {
 	return map[string]interface{}{
 		"arcstats_hits":                     int64(5968846374),
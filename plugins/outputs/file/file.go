@@ -2,7 +2,9 @@
 package file
 
 import (
+	"bytes"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -30,9 +32,20 @@ type File struct {
 	Log                  telegraf.Logger `toml:"-"`
 
 	encoder    internal.ContentEncoder
-	writer     io.Writer
+	writers    []io.Writer
 	closers    []io.Closer
 	serializer serializers.Serializer
+
+	// header is set if the serializer emits a header and rotated contains
+	// the files rotated since the last write requiring a new header
+	header  telegraf.HeaderSerializer
+	rotated map[io.Writer]bool
+}
+
+// headerChecker is implemented by serializer wrappers always forwarding
+// header resets to report if the wrapped serializer emits a header
+type headerChecker interface {
+	HasHeader() bool
 }
 
 func (*File) SampleConfig() string {
@@ -65,6 +78,14 @@ func (f *File) Init() error {
 func (f *File) Connect() error {
 	var writers []io.Writer
 
+	f.header = nil
+	if hs, ok := f.serializer.(telegraf.HeaderSerializer); ok {
+		if hc, ok := f.serializer.(headerChecker); !ok || hc.HasHeader() {
+			f.header = hs
+		}
+	}
+
+	f.rotated = make(map[io.Writer]bool)
 	for _, file := range f.Files {
 		if file == "stdout" {
 			writers = append(writers, os.Stdout)
@@ -75,11 +96,16 @@ func (f *File) Connect() error {
 				return err
 			}
 
+			// Start a new header, if any, when writing to a new file
+			if rw, ok := of.(*rotate.FileWriter); ok && f.header != nil {
+				rw.OnRotate(func() { f.rotated[rw] = true })
+			}
+
 			writers = append(writers, of)
 			f.closers = append(f.closers, of)
 		}
 	}
-	f.writer = io.MultiWriter(writers...)
+	f.writers = writers
 	return nil
 }
 
@@ -98,7 +124,7 @@ func (f *File) Write(metrics []telegraf.Metric) error {
 	var writeErr error
 
 	if f.UseBatchFormat {
-		octets, err := f.serializer.SerializeBatch(metrics)
+		octets, rotated, err := f.serialize(func() ([]byte, error) { return f.serializer.SerializeBatch(metrics) })
 		if err != nil {
 			f.Log.Errorf("Could not serialize metric: %v", err)
 		}
@@ -107,14 +133,18 @@ func (f *File) Write(metrics []telegraf.Metric) error {
 		if err != nil {
 			f.Log.Errorf("Could not compress metrics: %v", err)
 		}
+		if rotated != nil {
+			if rotated, err = f.encoder.Encode(rotated); err != nil {
+				f.Log.Errorf("Could not compress metrics: %v", err)
+			}
+		}
 
-		_, err = f.writer.Write(octets)
-		if err != nil {
+		if err := f.write(octets, rotated); err != nil {
 			f.Log.Errorf("Error writing to file: %v", err)
 		}
 	} else {
 		for _, metric := range metrics {
-			b, err := f.serializer.Serialize(metric)
+			b, rotated, err := f.serialize(func() ([]byte, error) { return f.serializer.Serialize(metric) })
 			if err != nil {
 				f.Log.Debugf("Could not serialize metric: %v", err)
 			}
@@ -123,9 +153,13 @@ func (f *File) Write(metrics []telegraf.Metric) error {
 			if err != nil {
 				f.Log.Errorf("Could not compress metrics: %v", err)
 			}
+			if rotated != nil {
+				if rotated, err = f.encoder.Encode(rotated); err != nil {
+					f.Log.Errorf("Could not compress metrics: %v", err)
+				}
+			}
 
-			_, err = f.writer.Write(b)
-			if err != nil {
+			if err := f.write(b, rotated); err != nil {
 				writeErr = fmt.Errorf("failed to write message: %w", err)
 			}
 		}
@@ -134,6 +168,53 @@ func (f *File) Write(metrics []telegraf.Metric) error {
 	return writeErr
 }
 
+// serialize returns the output of the given serialization function for all
+// files. If only some of the files were rotated since the last write, the
+// serialization is repeated after resetting the header and the output for
+// the rotated files is returned separately.
+func (f *File) serialize(fn func() ([]byte, error)) (octets, rotated []byte, err error) {
+	if len(f.rotated) == 0 {
+		octets, err := fn()
+		return octets, nil, err
+	}
+	// All files start with the header
+	if len(f.rotated) == len(f.writers) {
+		f.header.ResetHeader()
+		octets, err := fn()
+		return octets, nil, err
+	}
+
+	// Serialize the rotated files first, so the header is not emitted for the
+	// other files. Copy the output as serializers might reuse their buffer.
+	f.header.ResetHeader()
+	rotated, err = fn()
+	if err != nil {
+		return nil, nil, err
+	}
+	rotated = bytes.Clone(rotated)
+	octets, err = fn()
+	return octets, rotated, err
+}
+
+// write sends the data to all files, files rotated since the last write
+// receive the given rotated data instead if any
+func (f *File) write(octets, rotated []byte) error {
+	current := f.rotated
+	f.rotated = make(map[io.Writer]bool)
+
+	var errs []error
+	for _, w := range f.writers {
+		data := octets
+		if rotated != nil && current[w] {
+			data = rotated
+		}
+		if _, err := w.Write(data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func init() {
 	outputs.Add("file", func() telegraf.Output {
 		return &File{
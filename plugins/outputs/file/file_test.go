@@ -8,7 +8,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/internal"
+	"github.com/influxdata/telegraf/models"
+	"github.com/influxdata/telegraf/plugins/serializers/csv"
 	"github.com/influxdata/telegraf/plugins/serializers/influx"
 	"github.com/influxdata/telegraf/testutil"
 )
@@ -234,6 +237,69 @@ func TestFileBoth(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestFileRotationHeader(t *testing.T) {
+	header := "timestamp,measurement,tag1,value\n"
+	row := "1257894000,test1,value1,1\n"
+
+	// The existing file is rotated after the first write while the new file
+	// is not
+	fh1 := createFile(t)
+	fh2 := tmpFile(t)
+
+	s := &csv.Serializer{Header: true}
+	require.NoError(t, s.Init())
+
+	f := File{
+		Files:            []string{fh1.Name(), fh2},
+		RotationMaxSize:  config.Size(100),
+		serializer:       s,
+		CompressionLevel: -1,
+	}
+	require.NoError(t, f.Init())
+	require.NoError(t, f.Connect())
+
+	require.NoError(t, f.Write(testutil.MockMetrics()))
+	require.NoError(t, f.Write(testutil.MockMetrics()))
+
+	// Only the rotated file starts with a new header
+	validateFile(t, fh1.Name(), header+row)
+	validateFile(t, fh2, header+row+row)
+
+	require.NoError(t, f.Close())
+}
+
+func TestFileRotationWithoutHeader(t *testing.T) {
+	// The existing file is rotated after the first write while the new file
+	// is not
+	fh1 := createFile(t)
+	_, err := fh1.WriteString("mem value=1\n")
+	require.NoError(t, err)
+	fh2 := tmpFile(t)
+
+	s := &influx.Serializer{}
+	require.NoError(t, s.Init())
+	rs := models.NewRunningSerializer(s, &models.SerializerConfig{DataFormat: "influx"})
+
+	f := File{
+		Files:            []string{fh1.Name(), fh2},
+		RotationMaxSize:  config.Size(100),
+		serializer:       rs,
+		CompressionLevel: -1,
+	}
+	require.NoError(t, f.Init())
+	require.NoError(t, f.Connect())
+
+	// Metrics must be serialized once even if only some files were rotated
+	require.NoError(t, f.Write(testutil.MockMetrics()))
+	require.NoError(t, f.Write(testutil.MockMetrics()))
+	require.Equal(t, int64(2), rs.MetricsSerialized.Get())
+
+	validateFile(t, fh1.Name(), expNewFile)
+	validateFile(t, fh2, expNewFile+expNewFile)
+
+	require.NoError(t, f.Close())
+}
+
 type erroredString struct {
 	str string
 	err error
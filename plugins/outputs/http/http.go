@@ -58,6 +58,10 @@ type HTTP struct {
 	client     *http.Client
 	serializer serializers.Serializer
 
+	// payloads of a split batch already accepted by the endpoint, used to
+	// only resend the remaining payloads when the batch is retried
+	accepted map[[sha256.Size]byte]bool
+
 	awsCfg *aws.Config
 	common_aws.CredentialConfig
 
@@ -111,6 +115,28 @@ func (h *HTTP) Close() error {
 
 func (h *HTTP) Write(metrics []telegraf.Metric) error {
 	if h.UseBatchFormat {
+		// Send the batch in multiple requests if the serializer splits it
+		if s, ok := h.serializer.(telegraf.SplitSerializer); ok {
+			payloads, err := s.SerializeBatchSplit(metrics)
+			if err != nil {
+				return err
+			}
+
+			accepted := make(map[[sha256.Size]byte]bool, len(payloads))
+			for _, reqBody := range payloads {
+				sum := sha256.Sum256(reqBody)
+				if !h.accepted[sum] {
+					if err := h.writeMetric(reqBody); err != nil {
+						h.accepted = accepted
+						return err
+					}
+				}
+				accepted[sum] = true
+			}
+			h.accepted = nil
+			return nil
+		}
+
 		reqBody, err := h.serializer.SerializeBatch(metrics)
 		if err != nil {
 			return err
@@ -24,6 +24,7 @@ import (
 	"github.com/influxdata/telegraf/plugins/serializers"
 	"github.com/influxdata/telegraf/plugins/serializers/influx"
 	"github.com/influxdata/telegraf/plugins/serializers/json"
+	"github.com/influxdata/telegraf/plugins/serializers/prometheusremotewrite"
 	"github.com/influxdata/telegraf/testutil"
 )
 
@@ -768,6 +769,95 @@ func TestBatchedUnbatched(t *testing.T) {
 	}
 }
 
+func TestBatchSplit(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse("http://" + ts.Listener.Addr().String())
+	require.NoError(t, err)
+
+	serializer := &prometheusremotewrite.Serializer{
+		MaxTimeseriesPerWrite: 2,
+		Log:                   testutil.Logger{},
+	}
+	require.NoError(t, serializer.Init())
+
+	plugin := &HTTP{
+		URL:            u.String(),
+		Method:         defaultMethod,
+		UseBatchFormat: true,
+	}
+	plugin.SetSerializer(serializer)
+	require.NoError(t, plugin.Connect())
+
+	metrics := make([]telegraf.Metric, 0, 5)
+	for i := 0; i < 5; i++ {
+		m := getMetric()
+		m.AddTag("cpu", fmt.Sprintf("cpu%d", i))
+		metrics = append(metrics, m)
+	}
+	require.NoError(t, plugin.Write(metrics))
+	require.Equal(t, 3, requests)
+}
+
+func TestBatchSplitRetry(t *testing.T) {
+	var requests int
+	received := make(map[string]int)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// Fail the second request once
+		if requests == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		received[string(body)]++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse("http://" + ts.Listener.Addr().String())
+	require.NoError(t, err)
+
+	serializer := &prometheusremotewrite.Serializer{
+		MaxTimeseriesPerWrite: 2,
+		Log:                   testutil.Logger{},
+	}
+	require.NoError(t, serializer.Init())
+
+	plugin := &HTTP{
+		URL:            u.String(),
+		Method:         defaultMethod,
+		UseBatchFormat: true,
+	}
+	plugin.SetSerializer(serializer)
+	require.NoError(t, plugin.Connect())
+
+	metrics := make([]telegraf.Metric, 0, 5)
+	for i := 0; i < 5; i++ {
+		m := getMetric()
+		m.AddTag("cpu", fmt.Sprintf("cpu%d", i))
+		metrics = append(metrics, m)
+	}
+	require.Error(t, plugin.Write(metrics))
+	require.NoError(t, plugin.Write(metrics))
+
+	// The accepted payload must not be sent again
+	require.Equal(t, 4, requests)
+	require.Len(t, received, 3)
+	for _, count := range received {
+		require.Equal(t, 1, count)
+	}
+}
+
 func TestAwsCredentials(t *testing.T) {
 	ts := httptest.NewServer(http.NotFoundHandler())
 	defer ts.Close()
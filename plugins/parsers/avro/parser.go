@@ -11,8 +11,10 @@ import (
 	"github.com/linkedin/goavro/v2"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/metric"
+	common_tls "github.com/influxdata/telegraf/plugins/common/tls"
 	"github.com/influxdata/telegraf/plugins/parsers"
 )
 
@@ -24,22 +26,27 @@ import (
 // an attached schema or schema fingerprint
 
 type Parser struct {
-	MetricName       string            `toml:"metric_name"`
-	SchemaRegistry   string            `toml:"avro_schema_registry"`
-	CaCertPath       string            `toml:"avro_schema_registry_cert"`
-	Schema           string            `toml:"avro_schema"`
-	Format           string            `toml:"avro_format"`
-	Measurement      string            `toml:"avro_measurement"`
-	MeasurementField string            `toml:"avro_measurement_field"`
-	Tags             []string          `toml:"avro_tags"`
-	Fields           []string          `toml:"avro_fields"`
-	Timestamp        string            `toml:"avro_timestamp"`
-	TimestampFormat  string            `toml:"avro_timestamp_format"`
-	FieldSeparator   string            `toml:"avro_field_separator"`
-	UnionMode        string            `toml:"avro_union_mode"`
-	DefaultTags      map[string]string `toml:"tags"`
-	Log              telegraf.Logger   `toml:"-"`
-	registryObj      *schemaRegistry
+	MetricName             string                  `toml:"metric_name"`
+	SchemaRegistry         string                  `toml:"avro_schema_registry"`
+	CaCertPath             string                  `toml:"avro_schema_registry_cert"`
+	SchemaRegistryUsername config.Secret           `toml:"avro_schema_registry_username"`
+	SchemaRegistryPassword config.Secret           `toml:"avro_schema_registry_password"`
+	SchemaRegistryTLS      common_tls.ClientConfig `toml:"avro_schema_registry_tls"`
+	SchemaCacheSize        int                     `toml:"avro_schema_cache_size"`
+	SchemaNegativeCacheTTL config.Duration         `toml:"avro_schema_negative_cache_ttl"`
+	Schema                 string                  `toml:"avro_schema"`
+	Format                 string                  `toml:"avro_format"`
+	Measurement            string                  `toml:"avro_measurement"`
+	MeasurementField       string                  `toml:"avro_measurement_field"`
+	Tags                   []string                `toml:"avro_tags"`
+	Fields                 []string                `toml:"avro_fields"`
+	Timestamp              string                  `toml:"avro_timestamp"`
+	TimestampFormat        string                  `toml:"avro_timestamp_format"`
+	FieldSeparator         string                  `toml:"avro_field_separator"`
+	UnionMode              string                  `toml:"avro_union_mode"`
+	DefaultTags            map[string]string       `toml:"tags"`
+	Log                    telegraf.Logger         `toml:"-"`
+	registryObj            *schemaRegistry
 }
 
 func (p *Parser) Init() error {
@@ -72,7 +79,30 @@ func (p *Parser) Init() error {
 		return fmt.Errorf("invalid timestamp format '%v'", p.TimestampFormat)
 	}
 	if p.SchemaRegistry != "" {
-		registry, err := newSchemaRegistry(p.SchemaRegistry, p.CaCertPath)
+		if p.SchemaCacheSize == 0 {
+			p.SchemaCacheSize = 1000
+		}
+		if p.SchemaCacheSize < 0 {
+			return fmt.Errorf("invalid schema cache size %d", p.SchemaCacheSize)
+		}
+
+		// Keep the legacy certificate setting working
+		if p.SchemaRegistryTLS.TLSCA == "" {
+			p.SchemaRegistryTLS.TLSCA = p.CaCertPath
+		}
+		tlsCfg, err := p.SchemaRegistryTLS.TLSConfig()
+		if err != nil {
+			return fmt.Errorf("creating TLS configuration for the schema registry failed: %w", err)
+		}
+
+		registry, err := newSchemaRegistry(
+			p.SchemaRegistry,
+			p.SchemaRegistryUsername,
+			p.SchemaRegistryPassword,
+			tlsCfg,
+			p.SchemaCacheSize,
+			time.Duration(p.SchemaNegativeCacheTTL),
+		)
 		if err != nil {
 			return fmt.Errorf("error connecting to the schema registry %q: %w", p.SchemaRegistry, err)
 		}
@@ -1,15 +1,25 @@
 package avro
 
 import (
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/linkedin/goavro/v2"
 	"github.com/stretchr/testify/require"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/metric"
+	common_tls "github.com/influxdata/telegraf/plugins/common/tls"
 	"github.com/influxdata/telegraf/plugins/inputs"
 	"github.com/influxdata/telegraf/plugins/inputs/file"
 	"github.com/influxdata/telegraf/plugins/parsers/influx"
@@ -181,3 +191,186 @@ func BenchmarkParsingBinary(b *testing.B) {
 		plugin.Parse(benchmarkData)
 	}
 }
+
+const registrySchema = `
+{
+	"namespace": "com.example",
+	"name": "sensor",
+	"type": "record",
+	"fields": [
+			{"name": "value", "type": "long"}
+	]
+}
+`
+
+func registryMessage(t *testing.T, id uint32) []byte {
+	t.Helper()
+
+	codec, err := goavro.NewCodec(registrySchema)
+	require.NoError(t, err)
+	data, err := codec.BinaryFromNative(nil, map[string]interface{}{"value": int64(42)})
+	require.NoError(t, err)
+
+	msg := []byte{0, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(msg[1:], id)
+	return append(msg, data...)
+}
+
+func newRegistryServer(t *testing.T, requests *atomic.Int64) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/schemas/ids/1", "/schemas/ids/2":
+			response, err := json.Marshal(map[string]string{"schema": registrySchema})
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				t.Error(err)
+				return
+			}
+			if _, err := w.Write(response); err != nil {
+				t.Error(err)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "ca.pem")
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(filename, ca, 0600))
+	return filename
+}
+
+func TestSchemaRegistryAuthAndTLS(t *testing.T) {
+	var requests atomic.Int64
+	server := newRegistryServer(t, &requests)
+
+	plugin := &Parser{
+		SchemaRegistry:         server.URL,
+		SchemaRegistryUsername: config.NewSecret([]byte("user")),
+		SchemaRegistryPassword: config.NewSecret([]byte("secret")),
+		SchemaRegistryTLS:      common_tls.ClientConfig{TLSCA: writeServerCA(t, server)},
+		Log:                    testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	expected := []telegraf.Metric{
+		metric.New("com.example.sensor", map[string]string{}, map[string]interface{}{"value": int64(42)}, time.Unix(0, 0)),
+	}
+	actual, err := plugin.Parse(registryMessage(t, 1))
+	require.NoError(t, err)
+	testutil.RequireMetricsEqual(t, expected, actual, testutil.IgnoreTime())
+	require.Equal(t, int64(1), requests.Load())
+}
+
+func TestSchemaRegistryCredentialsInURL(t *testing.T) {
+	var requests atomic.Int64
+	server := newRegistryServer(t, &requests)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	u.User = url.UserPassword("user", "secret")
+
+	plugin := &Parser{
+		SchemaRegistry: u.String(),
+		CaCertPath:     writeServerCA(t, server),
+		Log:            testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	_, err = plugin.Parse(registryMessage(t, 1))
+	require.NoError(t, err)
+}
+
+func TestSchemaRegistryFetchError(t *testing.T) {
+	var requests atomic.Int64
+	server := newRegistryServer(t, &requests)
+
+	plugin := &Parser{
+		SchemaRegistry:         server.URL,
+		SchemaRegistryUsername: config.NewSecret([]byte("user")),
+		SchemaRegistryPassword: config.NewSecret([]byte("wrong")),
+		SchemaRegistryTLS:      common_tls.ClientConfig{TLSCA: writeServerCA(t, server)},
+		Log:                    testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	_, err := plugin.Parse(registryMessage(t, 1))
+	require.ErrorContains(t, err, `fetching schema 1 from registry failed: received status "401 Unauthorized"`)
+}
+
+func TestSchemaRegistryCacheSize(t *testing.T) {
+	var requests atomic.Int64
+	server := newRegistryServer(t, &requests)
+
+	plugin := &Parser{
+		SchemaRegistry:         server.URL,
+		SchemaRegistryUsername: config.NewSecret([]byte("user")),
+		SchemaRegistryPassword: config.NewSecret([]byte("secret")),
+		SchemaRegistryTLS:      common_tls.ClientConfig{TLSCA: writeServerCA(t, server)},
+		SchemaCacheSize:        1,
+		Log:                    testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	for i, id := range []uint32{1, 1, 2, 2, 1} {
+		_, err := plugin.Parse(registryMessage(t, id))
+		require.NoErrorf(t, err, "message %d", i)
+	}
+	// Schema 1 must be fetched again after being evicted by schema 2
+	require.Equal(t, int64(3), requests.Load())
+}
+
+func TestSchemaRegistryNegativeCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		ttl      config.Duration
+		expected int64
+	}{
+		{
+			name:     "disabled",
+			expected: 3,
+		},
+		{
+			name:     "enabled",
+			ttl:      config.Duration(time.Minute),
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := newRegistryServer(t, &requests)
+
+			plugin := &Parser{
+				SchemaRegistry:         server.URL,
+				SchemaRegistryUsername: config.NewSecret([]byte("user")),
+				SchemaRegistryPassword: config.NewSecret([]byte("secret")),
+				SchemaRegistryTLS:      common_tls.ClientConfig{TLSCA: writeServerCA(t, server)},
+				SchemaNegativeCacheTTL: tt.ttl,
+				Log:                    testutil.Logger{},
+			}
+			require.NoError(t, plugin.Init())
+
+			for i := 0; i < 3; i++ {
+				_, err := plugin.Parse(registryMessage(t, 5))
+				require.ErrorContains(t, err, `fetching schema 5 from registry failed: received status "404 Not Found"`)
+			}
+			require.Equal(t, tt.expected, requests.Load())
+		})
+	}
+}
@@ -2,17 +2,18 @@ package avro
 
 import (
 	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
-	"os"
-	"sync"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/linkedin/goavro/v2"
+
+	"github.com/influxdata/telegraf/config"
 )
 
 type schemaAndCodec struct {
@@ -22,30 +23,23 @@ type schemaAndCodec struct {
 
 type schemaRegistry struct {
 	url      string
-	username string
-	password string
-	cache    map[int]*schemaAndCodec
+	username config.Secret
+	password config.Secret
+	cache    *lru.Cache[int, *schemaAndCodec]
+	failures *expirable.LRU[int, error]
 	client   *http.Client
-	mu       sync.RWMutex
 }
 
 const schemaByID = "%s/schemas/ids/%d"
 
-func newSchemaRegistry(addr, caCertPath string) (*schemaRegistry, error) {
-	var client *http.Client
-	var tlsCfg *tls.Config
-	if caCertPath != "" {
-		caCert, err := os.ReadFile(caCertPath)
-		if err != nil {
-			return nil, err
-		}
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM(caCert)
-		tlsCfg = &tls.Config{
-			RootCAs: caCertPool,
-		}
-	}
-	client = &http.Client{
+func newSchemaRegistry(
+	addr string,
+	username, password config.Secret,
+	tlsCfg *tls.Config,
+	cacheSize int,
+	negativeTTL time.Duration,
+) (*schemaRegistry, error) {
+	client := &http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: tlsCfg,
 			MaxIdleConns:    10,
@@ -58,47 +52,82 @@ func newSchemaRegistry(addr, caCertPath string) (*schemaRegistry, error) {
 		return nil, fmt.Errorf("parsing registry URL failed: %w", err)
 	}
 
-	var username, password string
+	// Fallback to the credentials given in the URL if not set explicitly
 	if u.User != nil {
-		username = u.User.Username()
-		password, _ = u.User.Password()
+		if username.Empty() {
+			username = config.NewSecret([]byte(u.User.Username()))
+		}
+		if p, ok := u.User.Password(); ok && password.Empty() {
+			password = config.NewSecret([]byte(p))
+		}
+		u.User = nil
+	}
+
+	cache, err := lru.New[int, *schemaAndCodec](cacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("creating schema cache failed: %w", err)
 	}
 
 	registry := &schemaRegistry{
 		url:      u.String(),
 		username: username,
 		password: password,
-		cache:    make(map[int]*schemaAndCodec),
+		cache:    cache,
 		client:   client,
 	}
 
+	// Remember failed lookups to avoid querying the registry for unknown
+	// schema IDs with every message
+	if negativeTTL > 0 {
+		registry.failures = expirable.NewLRU[int, error](cacheSize, nil, negativeTTL)
+	}
+
 	return registry, nil
 }
 
-// Helper function to make managing lock easier
-func (sr *schemaRegistry) getSchemaAndCodecFromCache(id int) (*schemaAndCodec, error) {
-	// Read-lock the cache map before access.
-	sr.mu.RLock()
-	defer sr.mu.RUnlock()
-	if v, ok := sr.cache[id]; ok {
+func (sr *schemaRegistry) getSchemaAndCodec(id int) (*schemaAndCodec, error) {
+	if v, ok := sr.cache.Get(id); ok {
 		return v, nil
 	}
-	return nil, fmt.Errorf("schema %d not in cache", id)
-}
+	if sr.failures != nil {
+		if err, ok := sr.failures.Get(id); ok {
+			return nil, err
+		}
+	}
 
-func (sr *schemaRegistry) getSchemaAndCodec(id int) (*schemaAndCodec, error) {
-	v, err := sr.getSchemaAndCodecFromCache(id)
-	if err == nil {
-		return v, nil
+	v, err := sr.fetchSchemaAndCodec(id)
+	if err != nil {
+		err = fmt.Errorf("fetching schema %d from registry failed: %w", id, err)
+		if sr.failures != nil {
+			sr.failures.Add(id, err)
+		}
+		return nil, err
 	}
+	sr.cache.Add(id, v)
+
+	return v, nil
+}
 
+func (sr *schemaRegistry) fetchSchemaAndCodec(id int) (*schemaAndCodec, error) {
 	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf(schemaByID, sr.url, id), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if sr.username != "" {
-		req.SetBasicAuth(sr.username, sr.password)
+	if !sr.username.Empty() || !sr.password.Empty() {
+		username, err := sr.username.Get()
+		if err != nil {
+			return nil, fmt.Errorf("getting username failed: %w", err)
+		}
+		defer username.Destroy()
+
+		password, err := sr.password.Get()
+		if err != nil {
+			return nil, fmt.Errorf("getting password failed: %w", err)
+		}
+		defer password.Destroy()
+
+		req.SetBasicAuth(username.String(), password.String())
 	}
 
 	resp, err := sr.client.Do(req)
@@ -107,6 +136,10 @@ func (sr *schemaRegistry) getSchemaAndCodec(id int) (*schemaAndCodec, error) {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received status %q", resp.Status)
+	}
+
 	var jsonResponse map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&jsonResponse); err != nil {
 		return nil, err
@@ -125,10 +158,5 @@ func (sr *schemaRegistry) getSchemaAndCodec(id int) (*schemaAndCodec, error) {
 	if err != nil {
 		return nil, err
 	}
-	retval := &schemaAndCodec{Schema: schemaValue, Codec: codec}
-	// Lock the cache map before update.
-	sr.mu.Lock()
-	defer sr.mu.Unlock()
-	sr.cache[id] = retval
-	return retval, nil
+	return &schemaAndCodec{Schema: schemaValue, Codec: codec}, nil
 }
@@ -27,10 +27,19 @@ type Filter struct {
 	Length    uint64       `toml:"length"`
 }
 
+type Repeat struct {
+	Count      uint64  `toml:"count"`
+	CountFrom  string  `toml:"count_from"`
+	Terminator string  `toml:"terminator"`
+	Mode       string  `toml:"mode"`
+	Entries    []Entry `toml:"entries"`
+}
+
 type Config struct {
 	MetricName string  `toml:"metric_name"`
 	Filter     *Filter `toml:"filter"`
 	Entries    []Entry `toml:"entries"`
+	Repeat     *Repeat `toml:"repeat"`
 }
 
 func (c *Config) preprocess(defaultName string) error {
@@ -90,6 +99,17 @@ func (c *Config) preprocess(defaultName string) error {
 		hasField = hasField || e.Assignment == "field"
 	}
 
+	// Preprocess the repeated entries part
+	if c.Repeat != nil {
+		if err := c.Repeat.preprocess(c.Entries, defined); err != nil {
+			return fmt.Errorf("repeat: %w", err)
+		}
+		for _, e := range c.Repeat.Entries {
+			hasMeasurement = hasMeasurement || e.Assignment == "measurement"
+			hasField = hasField || (!e.Omit && e.Assignment == "field")
+		}
+	}
+
 	if !hasMeasurement && c.MetricName == "" {
 		if defaultName == "" {
 			return errors.New("no metric name given")
@@ -103,6 +123,85 @@ func (c *Config) preprocess(defaultName string) error {
 	return nil
 }
 
+func (r *Repeat) preprocess(header []Entry, defined map[string]bool) error {
+	// Check the repetition settings
+	var settings int
+	if r.Count > 0 {
+		settings++
+	}
+	if r.CountFrom != "" {
+		settings++
+	}
+	switch r.Terminator {
+	case "":
+	case "end-of-buffer":
+		settings++
+	default:
+		return fmt.Errorf("unknown terminator %q", r.Terminator)
+	}
+	if settings != 1 {
+		return errors.New("exactly one of 'count', 'count_from' or 'terminator' required")
+	}
+
+	switch r.Mode {
+	case "":
+		r.Mode = "metric"
+	case "metric", "index":
+	default:
+		return fmt.Errorf("unknown mode %q", r.Mode)
+	}
+
+	// The count has to reference an integer entry of the header
+	if r.CountFrom != "" {
+		var found bool
+		for _, e := range header {
+			if e.Omit || e.Name != r.CountFrom || (e.Assignment != "field" && e.Assignment != "tag") {
+				continue
+			}
+			if !strings.HasPrefix(e.Type, "int") && !strings.HasPrefix(e.Type, "uint") {
+				return fmt.Errorf("count entry %q has non-integer type %q", e.Name, e.Type)
+			}
+			found = true
+			break
+		}
+		if !found {
+			return fmt.Errorf("count entry %q not found", r.CountFrom)
+		}
+	}
+
+	if len(r.Entries) == 0 {
+		return errors.New("no entries defined")
+	}
+	groupDefined := make(map[string]bool)
+	for i, e := range r.Entries {
+		if err := e.check(); err != nil {
+			return fmt.Errorf("entry %q (%d): %w", e.Name, i, err)
+		}
+		// Store the normalized entry
+		r.Entries[i] = e
+
+		if e.Omit {
+			continue
+		}
+
+		// Indexed entries are added to a single metric so there can only be
+		// one name and timestamp
+		if r.Mode == "index" && (e.Assignment == "measurement" || e.Assignment == "time") {
+			return fmt.Errorf("%q assignment not supported in %q mode", e.Assignment, r.Mode)
+		}
+
+		// Check for duplicate entries, in "metric" mode the entries of the
+		// group must not collide with the header entries
+		key := e.Assignment + "_" + e.Name
+		if groupDefined[key] || (r.Mode == "metric" && defined[key]) {
+			return fmt.Errorf("multiple definitions of %q", e.Name)
+		}
+		groupDefined[key] = true
+	}
+
+	return nil
+}
+
 func (c *Config) matches(in []byte) bool {
 	// If no filter is given, just match everything
 	if c.Filter == nil {
@@ -137,47 +236,135 @@ func (c *Config) matches(in []byte) bool {
 	return true
 }
 
-func (c *Config) collect(in []byte, order binary.ByteOrder, defaultTime time.Time) (telegraf.Metric, error) {
-	t := defaultTime
-	name := c.MetricName
-	tags := make(map[string]string)
-	fields := make(map[string]interface{})
+func (c *Config) collect(in []byte, order binary.ByteOrder, defaultTime time.Time) ([]telegraf.Metric, error) {
+	header := &record{
+		name:   c.MetricName,
+		t:      defaultTime,
+		tags:   make(map[string]string),
+		fields: make(map[string]interface{}),
+	}
+
+	offset, err := header.collect(c.Entries, in, 0, order, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Repeat == nil {
+		return []telegraf.Metric{header.metric()}, nil
+	}
+
+	// Determine the number of repetitions
+	var count uint64
+	switch {
+	case c.Repeat.Count > 0:
+		count = c.Repeat.Count
+	case c.Repeat.CountFrom != "":
+		raw, found := header.fields[c.Repeat.CountFrom]
+		if !found {
+			raw = header.tags[c.Repeat.CountFrom]
+		}
+		count, err = internal.ToUint64(raw)
+		if err != nil {
+			return nil, fmt.Errorf("count from %q failed: %w", c.Repeat.CountFrom, err)
+		}
+	}
+
+	inbits := uint64(len(in)) * 8
+	metrics := make([]telegraf.Metric, 0)
+	for i := uint64(0); ; i++ {
+		if c.Repeat.Terminator == "end-of-buffer" {
+			if offset >= inbits {
+				break
+			}
+		} else if i >= count {
+			break
+		}
+
+		switch c.Repeat.Mode {
+		case "metric":
+			r := header.clone()
+			offset, err = r.collect(c.Repeat.Entries, in, offset, order, "")
+			if err != nil {
+				return nil, fmt.Errorf("repetition %d: %w", i, err)
+			}
+			metrics = append(metrics, r.metric())
+		case "index":
+			offset, err = header.collect(c.Repeat.Entries, in, offset, order, fmt.Sprintf("_%d", i))
+			if err != nil {
+				return nil, fmt.Errorf("repetition %d: %w", i, err)
+			}
+		}
+	}
+
+	if c.Repeat.Mode == "index" {
+		metrics = append(metrics, header.metric())
+	}
+
+	return metrics, nil
+}
+
+type record struct {
+	name   string
+	t      time.Time
+	tags   map[string]string
+	fields map[string]interface{}
+}
 
-	var offset uint64
-	for _, e := range c.Entries {
+func (r *record) collect(entries []Entry, in []byte, offset uint64, order binary.ByteOrder, suffix string) (uint64, error) {
+	for _, e := range entries {
 		data, n, err := e.extract(in, offset)
 		if err != nil {
-			return nil, err
+			return offset, err
 		}
 		offset += n
 
 		switch e.Assignment {
 		case "measurement":
-			name = convertStringType(data)
+			r.name = convertStringType(data)
 		case "field":
 			v, err := e.convertType(data, order)
 			if err != nil {
-				return nil, fmt.Errorf("field %q failed: %w", e.Name, err)
+				return offset, fmt.Errorf("field %q failed: %w", e.Name, err)
 			}
-			fields[e.Name] = v
+			r.fields[e.Name+suffix] = v
 		case "tag":
 			raw, err := e.convertType(data, order)
 			if err != nil {
-				return nil, fmt.Errorf("tag %q failed: %w", e.Name, err)
+				return offset, fmt.Errorf("tag %q failed: %w", e.Name, err)
 			}
 			v, err := internal.ToString(raw)
 			if err != nil {
-				return nil, fmt.Errorf("tag %q failed: %w", e.Name, err)
+				return offset, fmt.Errorf("tag %q failed: %w", e.Name, err)
 			}
-			tags[e.Name] = v
+			r.tags[e.Name+suffix] = v
 		case "time":
 			var err error
-			t, err = e.convertTimeType(data, order)
+			r.t, err = e.convertTimeType(data, order)
 			if err != nil {
-				return nil, fmt.Errorf("time failed: %w", err)
+				return offset, fmt.Errorf("time failed: %w", err)
 			}
 		}
 	}
 
-	return metric.New(name, tags, fields, t), nil
+	return offset, nil
+}
+
+func (r *record) clone() *record {
+	c := &record{
+		name:   r.name,
+		t:      r.t,
+		tags:   make(map[string]string, len(r.tags)),
+		fields: make(map[string]interface{}, len(r.fields)),
+	}
+	for k, v := range r.tags {
+		c.tags[k] = v
+	}
+	for k, v := range r.fields {
+		c.fields[k] = v
+	}
+	return c
+}
+
+func (r *record) metric() telegraf.Metric {
+	return metric.New(r.name, r.tags, r.fields, r.t)
 }
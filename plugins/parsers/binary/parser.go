@@ -107,12 +107,12 @@ func (p *Parser) Parse(data []byte) ([]telegraf.Metric, error) {
 		}
 		matches++
 
-		// Collect the metric
-		m, err := cfg.collect(buf, p.converter, t)
+		// Collect the metrics
+		ms, err := cfg.collect(buf, p.converter, t)
 		if err != nil {
 			return nil, err
 		}
-		metrics = append(metrics, m)
+		metrics = append(metrics, ms...)
 	}
 	if matches == 0 && !p.AllowNoMatch {
 		return nil, errors.New("no matching configuration")
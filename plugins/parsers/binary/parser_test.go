@@ -1403,6 +1403,104 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestRepeatInvalid(t *testing.T) {
+	header := []Entry{
+		{Name: "count", Type: "uint8"},
+		{Name: "label", Type: "string", Terminator: "null"},
+	}
+
+	var tests = []struct {
+		name     string
+		repeat   *Repeat
+		expected string
+	}{
+		{
+			name:     "no repetition setting",
+			repeat:   &Repeat{Entries: []Entry{dummyEntry}},
+			expected: `config 0 invalid: repeat: exactly one of 'count', 'count_from' or 'terminator' required`,
+		},
+		{
+			name:     "multiple repetition settings",
+			repeat:   &Repeat{Count: 2, CountFrom: "count", Entries: []Entry{dummyEntry}},
+			expected: `config 0 invalid: repeat: exactly one of 'count', 'count_from' or 'terminator' required`,
+		},
+		{
+			name:     "unknown terminator",
+			repeat:   &Repeat{Terminator: "null", Entries: []Entry{dummyEntry}},
+			expected: `config 0 invalid: repeat: unknown terminator "null"`,
+		},
+		{
+			name:     "unknown mode",
+			repeat:   &Repeat{Count: 2, Mode: "foo", Entries: []Entry{dummyEntry}},
+			expected: `config 0 invalid: repeat: unknown mode "foo"`,
+		},
+		{
+			name:     "unknown count entry",
+			repeat:   &Repeat{CountFrom: "foo", Entries: []Entry{dummyEntry}},
+			expected: `config 0 invalid: repeat: count entry "foo" not found`,
+		},
+		{
+			name:     "non-integer count entry",
+			repeat:   &Repeat{CountFrom: "label", Entries: []Entry{dummyEntry}},
+			expected: `config 0 invalid: repeat: count entry "label" has non-integer type "string"`,
+		},
+		{
+			name:     "no entries",
+			repeat:   &Repeat{Count: 2},
+			expected: `config 0 invalid: repeat: no entries defined`,
+		},
+		{
+			name:     "collision with header",
+			repeat:   &Repeat{Count: 2, Entries: []Entry{{Name: "count", Type: "uint8"}}},
+			expected: `config 0 invalid: repeat: multiple definitions of "count"`,
+		},
+		{
+			name: "time in index mode",
+			repeat: &Repeat{
+				Count:   2,
+				Mode:    "index",
+				Entries: []Entry{dummyEntry, {Type: "unix", Assignment: "time"}},
+			},
+			expected: `config 0 invalid: repeat: "time" assignment not supported in "index" mode`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := &Parser{
+				Endianness: "le",
+				Configs:    []Config{{Entries: append([]Entry{}, header...), Repeat: tt.repeat}},
+				Log:        testutil.Logger{Name: "parsers.binary"},
+				metricName: "binary",
+			}
+			require.EqualError(t, parser.Init(), tt.expected)
+		})
+	}
+}
+
+func TestRepeatParseInvalid(t *testing.T) {
+	parser := &Parser{
+		Endianness: "le",
+		Configs: []Config{{
+			Entries: []Entry{{Name: "count", Type: "uint8"}},
+			Repeat: &Repeat{
+				CountFrom: "count",
+				Entries:   []Entry{{Name: "value", Type: "uint16"}},
+			},
+		}},
+		Log:        testutil.Logger{Name: "parsers.binary"},
+		metricName: "binary",
+	}
+	require.NoError(t, parser.Init())
+
+	// Claim three records but only provide two
+	data, err := generateBinary([]interface{}{uint8(3), uint16(1), uint16(2)}, binary.LittleEndian)
+	require.NoError(t, err)
+
+	_, err = parser.Parse(data)
+	require.EqualError(t, err, `repetition 2: out-of-bounds @40 with 16 bits`)
+}
+
 func TestCases(t *testing.T) {
 	// Get all directories in testdata
 	folders, err := os.ReadDir("testcases")
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -29,7 +30,8 @@ const commaByte = "\u002C"
 
 type Parser struct {
 	ColumnNames        []string        `toml:"csv_column_names"`
-	ColumnTypes        []string        `toml:"csv_column_types"`
+	ColumnTypes        []string        `toml:"-"`
+	ColumnTypesConfig  columnTypes     `toml:"csv_column_types"`
 	Comment            string          `toml:"csv_comment"`
 	Delimiter          string          `toml:"csv_delimiter"`
 	HeaderRowCount     int             `toml:"csv_header_row_count"`
@@ -49,9 +51,14 @@ type Parser struct {
 	MetadataSeparators []string        `toml:"csv_metadata_separators"`
 	MetadataTrimSet    string          `toml:"csv_metadata_trim_set"`
 	ResetMode          string          `toml:"csv_reset_mode"`
+	DecimalSeparator   string          `toml:"csv_decimal_separator"`
+	ThousandSeparator  string          `toml:"csv_thousand_separator"`
 	Log                telegraf.Logger `toml:"-"`
 
 	metadataSeparatorList metadataPattern
+	columnTypesByName     map[string]string
+	numberReplacer        *strings.Replacer
+	numberPattern         *regexp.Regexp
 	location              *time.Location
 
 	gotColumnNames bool
@@ -66,6 +73,26 @@ type Parser struct {
 	remainingSkipRows     int
 	remainingHeaderRows   int
 	remainingMetadataRows int
+
+	skippedRows int64
+}
+
+// columnTypes are the column types either given as list in the order of the
+// columns or as map keyed by the column name
+type columnTypes struct {
+	List   []string
+	ByName map[string]string
+}
+
+func (c *columnTypes) UnmarshalTOML(unmarshal func(interface{}) error) error {
+	if err := unmarshal(&c.List); err == nil {
+		return nil
+	}
+	c.List = nil
+	if err := unmarshal(&c.ByName); err != nil {
+		return errors.New("csv_column_types must be a list of types or a map of column names to types")
+	}
+	return nil
 }
 
 type metadataPattern []string
@@ -157,11 +184,54 @@ func (p *Parser) Init() error {
 		}
 	}
 
+	if len(p.ColumnTypes) == 0 {
+		p.ColumnTypes = p.ColumnTypesConfig.List
+	}
+	p.columnTypesByName = p.ColumnTypesConfig.ByName
+	for name, typ := range p.columnTypesByName {
+		if !choice.Contains(typ, []string{"int", "float", "bool", "string"}) {
+			return fmt.Errorf("invalid type %q for column %q in csv_column_types", typ, name)
+		}
+	}
+
 	p.gotInitialColumnNames = len(p.ColumnNames) > 0
 	if len(p.ColumnNames) > 0 && len(p.ColumnTypes) > 0 && len(p.ColumnNames) != len(p.ColumnTypes) {
 		return errors.New("csv_column_names field count doesn't match with csv_column_types")
 	}
 
+	if len([]rune(p.DecimalSeparator)) > 1 {
+		return fmt.Errorf("csv_decimal_separator must be a single character, got: %s", p.DecimalSeparator)
+	}
+	if len([]rune(p.ThousandSeparator)) > 1 {
+		return fmt.Errorf("csv_thousand_separator must be a single character, got: %s", p.ThousandSeparator)
+	}
+	if p.DecimalSeparator != "" && p.DecimalSeparator == p.ThousandSeparator {
+		return errors.New("csv_decimal_separator and csv_thousand_separator must differ")
+	}
+	if p.DecimalSeparator != "" || p.ThousandSeparator != "" {
+		var replacements []string
+		if p.ThousandSeparator != "" {
+			replacements = append(replacements, p.ThousandSeparator, "")
+		}
+		if p.DecimalSeparator != "" && p.DecimalSeparator != "." {
+			replacements = append(replacements, p.DecimalSeparator, ".")
+		}
+		p.numberReplacer = strings.NewReplacer(replacements...)
+	}
+	if p.ThousandSeparator != "" {
+		// Only accept groups of three digits between the thousand separators
+		// to not mistake e.g. IP addresses or versions for numbers
+		decimal := p.DecimalSeparator
+		if decimal == "" {
+			decimal = "."
+		}
+		pattern := `^[+-]?(\d{1,3}(` + regexp.QuoteMeta(p.ThousandSeparator) + `\d{3})+|\d+)`
+		if decimal != p.ThousandSeparator {
+			pattern += `(` + regexp.QuoteMeta(decimal) + `\d*)?([eE][+-]?\d+)?`
+		}
+		p.numberPattern = regexp.MustCompile(pattern + `$`)
+	}
+
 	if err := p.initializeMetadataSeparators(); err != nil {
 		return fmt.Errorf("initializing separators failed: %w", err)
 	}
@@ -321,26 +391,70 @@ func parseCSV(p *Parser, r io.Reader) ([]telegraf.Metric, error) {
 		p.gotColumnNames = true
 	}
 
-	table, err := csvReader.ReadAll()
-	if err != nil {
-		return nil, err
-	}
-
 	metrics := make([]telegraf.Metric, 0)
-	for _, record := range table {
-		m, err := p.parseRecord(record)
+	var skipped int
+	var firstErr error
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err == nil {
+			if p.SkipErrors && len(record)-p.SkipColumns != len(p.ColumnNames) {
+				err = fmt.Errorf("column count mismatch: expected %d but got %d columns", len(p.ColumnNames), len(record)-p.SkipColumns)
+			}
+		} else {
+			// Only malformed rows can be skipped, all other errors are fatal
+			var perr *csv.ParseError
+			if !p.SkipErrors || !errors.As(err, &perr) {
+				return nil, err
+			}
+		}
+
+		var m telegraf.Metric
+		if err == nil {
+			m, err = p.parseRecord(record)
+		}
 		if err != nil {
-			if p.SkipErrors {
-				p.Log.Debugf("Parsing error: %v", err)
-				continue
+			if !p.SkipErrors {
+				return metrics, err
+			}
+			if firstErr == nil {
+				firstErr = err
 			}
-			return metrics, err
+			skipped++
+			continue
 		}
 		metrics = append(metrics, m)
 	}
+
+	if skipped > 0 {
+		p.skippedRows += int64(skipped)
+		p.Log.Warnf("Skipped %d malformed rows, first error: %v", skipped, firstErr)
+	}
+
 	return metrics, nil
 }
 
+// SkippedRows returns the total number of rows skipped due to errors if
+// skipping errors is enabled
+func (p *Parser) SkippedRows() int64 {
+	return p.skippedRows
+}
+
+// normalizeNumber removes the thousand separators and replaces the decimal
+// separator by a dot if configured. Values not matching the configured number
+// format are returned unchanged.
+func (p *Parser) normalizeNumber(value string) string {
+	if p.numberReplacer == nil {
+		return value
+	}
+	if p.numberPattern != nil && !p.numberPattern.MatchString(value) {
+		return value
+	}
+	return p.numberReplacer.Replace(value)
+}
+
 func (p *Parser) parseRecord(record []string) (telegraf.Metric, error) {
 	recordFields := make(map[string]interface{})
 	tags := make(map[string]string)
@@ -388,23 +502,29 @@ outer:
 			}
 
 			// Try explicit conversion only when column types is defined.
+			var columnType string
 			if len(p.ColumnTypes) > 0 {
 				// Throw error if current column count exceeds defined types.
 				if i >= len(p.ColumnTypes) {
 					return nil, errors.New("column type: column count exceeded")
 				}
+				columnType = p.ColumnTypes[i]
+			} else if typ, found := p.columnTypesByName[fieldName]; found {
+				columnType = typ
+			}
 
+			if columnType != "" {
 				var val interface{}
 				var err error
 
-				switch p.ColumnTypes[i] {
+				switch columnType {
 				case "int":
-					val, err = strconv.ParseInt(value, 10, 64)
+					val, err = strconv.ParseInt(p.normalizeNumber(value), 10, 64)
 					if err != nil {
 						return nil, fmt.Errorf("column type: parse int error %w", err)
 					}
 				case "float":
-					val, err = strconv.ParseFloat(value, 64)
+					val, err = strconv.ParseFloat(p.normalizeNumber(value), 64)
 					if err != nil {
 						return nil, fmt.Errorf("column type: parse float error %w", err)
 					}
@@ -422,9 +542,10 @@ outer:
 			}
 
 			// attempt type conversions
-			if iValue, err := strconv.ParseInt(value, 10, 64); err == nil {
+			number := p.normalizeNumber(value)
+			if iValue, err := strconv.ParseInt(number, 10, 64); err == nil {
 				recordFields[fieldName] = iValue
-			} else if fValue, err := strconv.ParseFloat(value, 64); err == nil {
+			} else if fValue, err := strconv.ParseFloat(number, 64); err == nil {
 				recordFields[fieldName] = fValue
 			} else if bValue, err := strconv.ParseBool(value); err == nil {
 				recordFields[fieldName] = bValue
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/influxdata/toml"
 	"github.com/stretchr/testify/require"
 
 	"github.com/influxdata/telegraf"
@@ -845,6 +846,236 @@ corrupted_line
 	require.Equal(t, expectedFields1, metrics[1].Fields())
 }
 
+func TestSkipErrorsMalformedRows(t *testing.T) {
+	testCSV := `a,b,c
+1,2,true
+3,"x"y,false
+4,5
+6,seven,true
+8,9,false
+10,11,12,13
+`
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric("csv",
+			map[string]string{},
+			map[string]interface{}{
+				"a": int64(1),
+				"b": int64(2),
+				"c": true,
+			},
+			time.Unix(0, 0),
+		),
+		testutil.MustMetric("csv",
+			map[string]string{},
+			map[string]interface{}{
+				"a": int64(8),
+				"b": int64(9),
+				"c": false,
+			},
+			time.Unix(0, 0),
+		),
+	}
+
+	t.Run("parse", func(t *testing.T) {
+		logger := &testutil.CaptureLogger{}
+		p := &Parser{
+			MetricName:     "csv",
+			HeaderRowCount: 1,
+			ColumnTypes:    []string{"int", "int", "bool"},
+			SkipErrors:     true,
+			Log:            logger,
+		}
+		require.NoError(t, p.Init())
+
+		metrics, err := p.Parse([]byte(testCSV))
+		require.NoError(t, err)
+		testutil.RequireMetricsEqual(t, expected, metrics, testutil.IgnoreTime())
+		require.Equal(t, int64(4), p.SkippedRows())
+
+		warnings := logger.Warnings()
+		require.Len(t, warnings, 1)
+		require.Contains(t, warnings[0], "Skipped 4 malformed rows")
+		require.Contains(t, warnings[0], `extraneous or missing "`)
+	})
+
+	t.Run("parse line", func(t *testing.T) {
+		p := &Parser{
+			MetricName:     "csv",
+			HeaderRowCount: 1,
+			ColumnTypes:    []string{"int", "int", "bool"},
+			SkipErrors:     true,
+			Log:            testutil.Logger{},
+		}
+		require.NoError(t, p.Init())
+
+		var metrics []telegraf.Metric
+		for _, line := range strings.Split(testCSV, "\n") {
+			if line == "" {
+				continue
+			}
+			m, err := p.ParseLine(line)
+			if errors.Is(err, parsers.ErrEOF) {
+				continue
+			}
+			require.NoError(t, err)
+			if m != nil {
+				metrics = append(metrics, m)
+			}
+		}
+		testutil.RequireMetricsEqual(t, expected, metrics, testutil.IgnoreTime())
+		require.Equal(t, int64(4), p.SkippedRows())
+	})
+}
+
+func TestMalformedRowsWithoutSkipErrors(t *testing.T) {
+	p := &Parser{
+		MetricName:     "csv",
+		HeaderRowCount: 1,
+		Log:            testutil.Logger{},
+	}
+	require.NoError(t, p.Init())
+
+	_, err := p.Parse([]byte("a,b\n1,\"x\"y\n"))
+	require.ErrorContains(t, err, `extraneous or missing "`)
+}
+
+func TestColumnTypesConfig(t *testing.T) {
+	var p Parser
+	require.NoError(t, toml.Unmarshal([]byte(`csv_column_types = ["int", "string"]`), &p))
+	require.Equal(t, []string{"int", "string"}, p.ColumnTypesConfig.List)
+	require.Nil(t, p.ColumnTypesConfig.ByName)
+
+	p = Parser{}
+	require.NoError(t, toml.Unmarshal([]byte(`csv_column_types = {a = "int", b = "string"}`), &p))
+	require.Nil(t, p.ColumnTypesConfig.List)
+	require.Equal(t, map[string]string{"a": "int", "b": "string"}, p.ColumnTypesConfig.ByName)
+}
+
+func TestColumnTypesByName(t *testing.T) {
+	p := &Parser{
+		MetricName:        "csv",
+		HeaderRowCount:    1,
+		ColumnTypesConfig: columnTypes{ByName: map[string]string{"id": "string", "value": "float"}},
+		ResetMode:         "always",
+	}
+	require.NoError(t, p.Init())
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric("csv",
+			map[string]string{},
+			map[string]interface{}{
+				"id":    "42",
+				"value": float64(1),
+				"count": int64(3),
+			},
+			time.Unix(0, 0),
+		),
+	}
+
+	// Different column orders must result in the same types
+	for _, input := range []string{"id,value,count\n42,1,3\n", "count,value,id\n3,1,42\n"} {
+		metrics, err := p.Parse([]byte(input))
+		require.NoError(t, err)
+		testutil.RequireMetricsEqual(t, expected, metrics, testutil.IgnoreTime())
+	}
+
+	_, err := p.Parse([]byte("id,value\n42,abc\n"))
+	require.ErrorContains(t, err, "parse float error")
+}
+
+func TestColumnTypesByNameInvalid(t *testing.T) {
+	p := &Parser{
+		HeaderRowCount:    1,
+		ColumnTypesConfig: columnTypes{ByName: map[string]string{"id": "uuid"}},
+	}
+	require.ErrorContains(t, p.Init(), `invalid type "uuid" for column "id"`)
+}
+
+func TestDecimalAndThousandSeparator(t *testing.T) {
+	p := &Parser{
+		MetricName:        "csv",
+		HeaderRowCount:    1,
+		Delimiter:         ";",
+		DecimalSeparator:  ",",
+		ThousandSeparator: ".",
+		ColumnTypesConfig: columnTypes{ByName: map[string]string{"amount": "float", "items": "int"}},
+		TimestampColumn:   "time",
+		TimestampFormat:   "02.01.2006",
+		Log:               testutil.Logger{},
+	}
+	require.NoError(t, p.Init())
+
+	testCSV := `time;amount;items;ratio;count;name
+14.10.2024;1.234,5;1.000;0,25;12;foo`
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric("csv",
+			map[string]string{},
+			map[string]interface{}{
+				"amount": float64(1234.5),
+				"items":  int64(1000),
+				"ratio":  float64(0.25),
+				"count":  int64(12),
+				"name":   "foo",
+			},
+			time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC),
+		),
+	}
+
+	metrics, err := p.Parse([]byte(testCSV))
+	require.NoError(t, err)
+	testutil.RequireMetricsEqual(t, expected, metrics)
+}
+
+func TestThousandSeparatorNonNumbers(t *testing.T) {
+	p := &Parser{
+		MetricName:        "csv",
+		HeaderRowCount:    1,
+		Delimiter:         ";",
+		ThousandSeparator: ".",
+		Log:               testutil.Logger{},
+	}
+	require.NoError(t, p.Init())
+
+	// Only values grouped in three digits are numbers
+	testCSV := `count;address;version;large;small
+1.000;10.0.0.1;1.2.3;1.234.567;12`
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric("csv",
+			map[string]string{},
+			map[string]interface{}{
+				"count":   int64(1000),
+				"address": "10.0.0.1",
+				"version": "1.2.3",
+				"large":   int64(1234567),
+				"small":   int64(12),
+			},
+			time.Unix(0, 0),
+		),
+	}
+
+	metrics, err := p.Parse([]byte(testCSV))
+	require.NoError(t, err)
+	testutil.RequireMetricsEqual(t, expected, metrics, testutil.IgnoreTime())
+}
+
+func TestInvalidSeparators(t *testing.T) {
+	p := &Parser{
+		HeaderRowCount:    1,
+		DecimalSeparator:  ",",
+		ThousandSeparator: ",",
+	}
+	require.ErrorContains(t, p.Init(), "must differ")
+
+	p = &Parser{
+		HeaderRowCount:   1,
+		DecimalSeparator: ",,",
+	}
+	require.ErrorContains(t, p.Init(), "must be a single character")
+}
+
 func TestParseMetadataSeparators(t *testing.T) {
 	p := &Parser{
 		ColumnNames:  []string{"a", "b"},
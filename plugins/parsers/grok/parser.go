@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"regexp"
 	"strconv"
@@ -14,6 +15,7 @@ import (
 	"github.com/vjeantet/grok"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/plugins/parsers"
@@ -77,6 +79,8 @@ type Parser struct {
 	NamedPatterns      []string          `toml:"grok_named_patterns"`
 	CustomPatterns     string            `toml:"grok_custom_patterns"`
 	CustomPatternFiles []string          `toml:"grok_custom_pattern_files"`
+	WatchPatternFiles  bool              `toml:"grok_custom_pattern_files_watch"`
+	WatchInterval      config.Duration   `toml:"grok_custom_pattern_files_watch_interval"`
 	Multiline          bool              `toml:"grok_multiline"`
 	Measurement        string            `toml:"-"`
 	DefaultTags        map[string]string `toml:"-"`
@@ -129,10 +133,24 @@ type Parser struct {
 	timeFunc func() time.Time
 	g        *grok.Grok
 	tsModder *tsModder
+
+	// userCustomPatterns are the custom patterns as provided by the user
+	// required to recompile the patterns when reloading the pattern files.
+	userCustomPatterns string
+	// patternFiles holds the state of the custom pattern files at the time
+	// of the last check to detect changes.
+	patternFiles     map[string]patternFileState
+	nextPatternCheck time.Time
+}
+
+type patternFileState struct {
+	modTime time.Time
+	size    int64
 }
 
 // Compile is a bound method to Parser which will process the options for our parser
 func (p *Parser) Compile() error {
+	p.userCustomPatterns = p.CustomPatterns
 	p.typeMap = make(map[string]map[string]string)
 	p.tsMap = make(map[string]map[string]string)
 	p.patternsMap = make(map[string]string)
@@ -173,13 +191,16 @@ func (p *Parser) Compile() error {
 	}
 
 	// Parse any custom pattern files supplied.
+	if p.WatchPatternFiles {
+		p.patternFiles = statPatternFiles(p.CustomPatternFiles)
+	}
 	for _, filename := range p.CustomPatternFiles {
-		file, fileErr := os.Open(filename)
+		buf, fileErr := os.ReadFile(filename)
 		if fileErr != nil {
 			return fileErr
 		}
 
-		scanner := bufio.NewScanner(bufio.NewReader(file))
+		scanner := bufio.NewScanner(bytes.NewReader(buf))
 		p.addCustomPatterns(scanner)
 	}
 
@@ -198,6 +219,8 @@ func (p *Parser) Compile() error {
 
 // ParseLine is the primary function to process individual lines, returning the metrics
 func (p *Parser) ParseLine(line string) (telegraf.Metric, error) {
+	p.reloadPatternFiles()
+
 	var err error
 	// values are the parsed fields from the log line
 	var values map[string]string
@@ -416,6 +439,75 @@ func (p *Parser) SetDefaultTags(tags map[string]string) {
 	p.DefaultTags = tags
 }
 
+// reloadPatternFiles recompiles the patterns if any of the custom pattern
+// files changed since the last check. The previously compiled patterns are
+// kept if compiling the new patterns fails.
+func (p *Parser) reloadPatternFiles() {
+	if !p.WatchPatternFiles {
+		return
+	}
+
+	now := time.Now()
+	if now.Before(p.nextPatternCheck) {
+		return
+	}
+	p.nextPatternCheck = now.Add(time.Duration(p.WatchInterval))
+
+	current := statPatternFiles(p.CustomPatternFiles)
+	if maps.Equal(current, p.patternFiles) {
+		return
+	}
+	// Remember the state even if compilation fails to only report the error
+	// once per change
+	p.patternFiles = current
+
+	// Compile the patterns into a separate parser so the current patterns
+	// stay intact on errors
+	np := &Parser{
+		Patterns:           p.Patterns,
+		CustomPatterns:     p.userCustomPatterns,
+		CustomPatternFiles: p.CustomPatternFiles,
+		Timezone:           p.Timezone,
+		UniqueTimestamp:    p.UniqueTimestamp,
+		Log:                p.Log,
+		timeFunc:           p.timeFunc,
+	}
+	err := np.Compile()
+	if err == nil {
+		// The regular expressions are compiled lazily so force compilation
+		// to detect invalid patterns before replacing the current ones
+		for _, pattern := range np.NamedPatterns {
+			if _, err = np.g.Match(pattern, ""); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		p.Log.Errorf("Reloading custom pattern files failed, keeping previous patterns: %v", err)
+		return
+	}
+
+	p.NamedPatterns = np.NamedPatterns
+	p.typeMap = np.typeMap
+	p.tsMap = np.tsMap
+	p.patternsMap = np.patternsMap
+	p.g = np.g
+	p.Log.Info("Reloaded custom pattern files")
+}
+
+func statPatternFiles(filenames []string) map[string]patternFileState {
+	states := make(map[string]patternFileState, len(filenames))
+	for _, filename := range filenames {
+		// Missing files are detected when compiling the patterns
+		info, err := os.Stat(filename)
+		if err != nil {
+			continue
+		}
+		states[filename] = patternFileState{modTime: info.ModTime(), size: info.Size()}
+	}
+	return states
+}
+
 func (p *Parser) addCustomPatterns(scanner *bufio.Scanner) {
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -589,6 +681,10 @@ func (p *Parser) Init() error {
 		p.Timezone = "UTC"
 	}
 
+	if p.WatchPatternFiles && p.WatchInterval <= 0 {
+		p.WatchInterval = config.Duration(10 * time.Second)
+	}
+
 	return p.Compile()
 }
 
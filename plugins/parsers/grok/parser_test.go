@@ -3,6 +3,7 @@ package grok
 import (
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -1229,6 +1230,86 @@ func TestBenchmarkData(t *testing.T) {
 	testutil.RequireMetricsEqual(t, expected, actual, testutil.SortMetrics())
 }
 
+func TestWatchPatternFiles(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "patterns")
+	require.NoError(t, os.WriteFile(filename, []byte("MYLOG %{NUMBER:value:int} %{WORD:status:tag}\n"), 0600))
+
+	logger := &testutil.CaptureLogger{}
+	p := &Parser{
+		Measurement:        "test",
+		Patterns:           []string{"%{MYLOG}"},
+		CustomPatternFiles: []string{filename},
+		WatchPatternFiles:  true,
+		Log:                logger,
+	}
+	require.NoError(t, p.Init())
+
+	m, err := p.ParseLine("42 ok")
+	require.NoError(t, err)
+	require.NotNil(t, m)
+	testutil.RequireMetricEqual(t,
+		metric.New("test", map[string]string{"status": "ok"}, map[string]interface{}{"value": int64(42)}, time.Unix(0, 0)),
+		m,
+		testutil.IgnoreTime(),
+	)
+
+	// Change the pattern and force a check
+	mtime := time.Now().Add(time.Minute)
+	require.NoError(t, os.WriteFile(filename, []byte("MYLOG %{WORD:status:tag} %{NUMBER:value:float}\n"), 0600))
+	require.NoError(t, os.Chtimes(filename, mtime, mtime))
+	p.nextPatternCheck = time.Time{}
+
+	m, err = p.ParseLine("ok 42.5")
+	require.NoError(t, err)
+	require.NotNil(t, m)
+	testutil.RequireMetricEqual(t,
+		metric.New("test", map[string]string{"status": "ok"}, map[string]interface{}{"value": 42.5}, time.Unix(0, 0)),
+		m,
+		testutil.IgnoreTime(),
+	)
+
+	// Invalid patterns must keep the previous ones and only log once
+	mtime = mtime.Add(time.Minute)
+	require.NoError(t, os.WriteFile(filename, []byte("MYLOG %{WORD:status:tag} (%{NUMBER:value:float}\n"), 0600))
+	require.NoError(t, os.Chtimes(filename, mtime, mtime))
+	for i := 0; i < 2; i++ {
+		p.nextPatternCheck = time.Time{}
+		m, err = p.ParseLine("ok 23.5")
+		require.NoError(t, err)
+		require.NotNil(t, m)
+		testutil.RequireMetricEqual(t,
+			metric.New("test", map[string]string{"status": "ok"}, map[string]interface{}{"value": 23.5}, time.Unix(0, 0)),
+			m,
+			testutil.IgnoreTime(),
+		)
+	}
+	require.Len(t, logger.Errors(), 1)
+	require.Contains(t, logger.Errors()[0], "Reloading custom pattern files failed")
+}
+
+func TestWatchPatternFilesDisabled(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "patterns")
+	require.NoError(t, os.WriteFile(filename, []byte("MYLOG %{NUMBER:value:int}\n"), 0600))
+
+	p := &Parser{
+		Measurement:        "test",
+		Patterns:           []string{"%{MYLOG}"},
+		CustomPatternFiles: []string{filename},
+		Log:                testutil.Logger{},
+	}
+	require.NoError(t, p.Init())
+
+	// Changes must not be picked up without watching the files
+	mtime := time.Now().Add(time.Minute)
+	require.NoError(t, os.WriteFile(filename, []byte("MYLOG %{WORD:status}\n"), 0600))
+	require.NoError(t, os.Chtimes(filename, mtime, mtime))
+
+	m, err := p.ParseLine("42")
+	require.NoError(t, err)
+	require.NotNil(t, m)
+	require.Equal(t, map[string]interface{}{"value": int64(42)}, m.Fields())
+}
+
 func BenchmarkParsing(b *testing.B) {
 	plugin := &Parser{
 		//nolint:lll // conditionally long lines allowed
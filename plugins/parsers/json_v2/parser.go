@@ -1,6 +1,7 @@
 package json_v2
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -21,6 +22,7 @@ import (
 // Parser adheres to the parser interface, contains the parser configuration, and data required to parse JSON
 type Parser struct {
 	Configs           []Config          `toml:"json_v2"`
+	JSONLines         bool              `toml:"json_lines"`
 	DefaultMetricName string            `toml:"-"`
 	DefaultTags       map[string]string `toml:"-"`
 	Log               telegraf.Logger   `toml:"-"`
@@ -135,9 +137,6 @@ func (p *Parser) parseCriticalPath(input []byte) ([]telegraf.Metric, error) {
 	p.parseMutex.Lock()
 	defer p.parseMutex.Unlock()
 
-	// Clear intermediate results if left by previous call
-	p.subPathResults = nil
-
 	reader := strings.NewReader(string(input))
 	body, _ := utfbom.Skip(reader)
 	input, err := io.ReadAll(body)
@@ -145,6 +144,44 @@ func (p *Parser) parseCriticalPath(input []byte) ([]telegraf.Metric, error) {
 		return nil, fmt.Errorf("unable to read body after BOM removal: %w", err)
 	}
 
+	if !p.JSONLines {
+		return p.parseDocument(input)
+	}
+
+	// Parse each line as a separate document and continue with the next
+	// line on errors
+	var metrics []telegraf.Metric
+	var errs []error
+	for i, line := range bytes.Split(input, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		m, err := p.parseDocument(line)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", i+1, err))
+			continue
+		}
+		metrics = append(metrics, m...)
+	}
+
+	// Fail if no line could be parsed at all, otherwise only log the errors
+	// to not lose the metrics of the valid lines
+	if len(metrics) == 0 {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		p.Log.Errorf("Parsing JSON lines failed: %v", err)
+	}
+
+	return metrics, nil
+}
+
+// parseDocument creates the metrics for a single JSON document
+func (p *Parser) parseDocument(input []byte) ([]telegraf.Metric, error) {
+	// Clear intermediate results if left by previous call
+	p.subPathResults = nil
+
 	// Only valid JSON is supported
 	if !gjson.Valid(string(input)) {
 		return nil, fmt.Errorf("invalid JSON provided, unable to parse: %s", string(input))
@@ -593,11 +630,16 @@ func (p *Parser) combineObject(result metricNode, timestamp time.Time) ([]telegr
 			arrayNode.Tag = tag
 
 			if val.IsObject() {
-				results, err = p.combineObject(arrayNode, timestamp)
+				// Merge the nested object into the results collected so far
+				// instead of replacing them, so a nested object without any
+				// matching field or tag path does not drop the whole element.
+				arrayNode.ParentIndex -= result.Index
+				r, err := p.combineObject(arrayNode, timestamp)
 				if err != nil {
 					p.Log.Error(err)
 					return false
 				}
+				results = cartesianProduct(r, results)
 			} else {
 				arrayNode.Index -= result.Index
 				arrayNode.ParentIndex -= result.Index
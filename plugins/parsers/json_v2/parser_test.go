@@ -12,6 +12,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/plugins/inputs"
 	"github.com/influxdata/telegraf/plugins/inputs/file"
 	"github.com/influxdata/telegraf/plugins/parsers/influx"
@@ -166,3 +167,64 @@ func BenchmarkParsingParallel(b *testing.B) {
 		}
 	})
 }
+
+func TestJSONLinesWithInvalidLine(t *testing.T) {
+	input := `{"device": "sensor-1", "temperature": 20.5}
+{"device": "sensor-2", "temperature": 
+{"device": "sensor-3", "temperature": 19.0}
+`
+	logger := &testutil.CaptureLogger{}
+	plugin := &json_v2.Parser{
+		JSONLines: true,
+		Configs: []json_v2.Config{
+			{
+				MeasurementName: "sensors",
+				Tags:            []json_v2.DataSet{{Path: "device"}},
+				Fields:          []json_v2.DataSet{{Path: "temperature"}},
+			},
+		},
+		Log: logger,
+	}
+	require.NoError(t, plugin.Init())
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"sensors",
+			map[string]string{"device": "sensor-1"},
+			map[string]interface{}{"temperature": 20.5},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"sensors",
+			map[string]string{"device": "sensor-3"},
+			map[string]interface{}{"temperature": 19.0},
+			time.Unix(0, 0),
+		),
+	}
+
+	actual, err := plugin.Parse([]byte(input))
+	require.NoError(t, err)
+	testutil.RequireMetricsEqual(t, expected, actual, testutil.IgnoreTime())
+
+	errs := logger.Errors()
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0], "line 2: invalid JSON provided")
+}
+
+func TestJSONLinesAllInvalid(t *testing.T) {
+	plugin := &json_v2.Parser{
+		JSONLines: true,
+		Configs: []json_v2.Config{
+			{
+				MeasurementName: "sensors",
+				Fields:          []json_v2.DataSet{{Path: "temperature"}},
+			},
+		},
+		Log: testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	_, err := plugin.Parse([]byte("{\"temperature\": 20.5\n\n{\"temperature\":}\n"))
+	require.ErrorContains(t, err, "line 1: invalid JSON provided")
+	require.ErrorContains(t, err, "line 3: invalid JSON provided")
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"syscall"
 	"time"
+	"unicode/utf8"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
@@ -87,8 +88,9 @@ func AddState(runErr error, errMessage []byte, metrics []telegraf.Metric) []tele
 }
 
 type Parser struct {
-	DefaultTags map[string]string `toml:"-"`
-	Log         telegraf.Logger   `toml:"-"`
+	LongOutputMaxLength int               `toml:"nagios_long_output_max_length"`
+	DefaultTags         map[string]string `toml:"-"`
+	Log                 telegraf.Logger   `toml:"-"`
 
 	metricName string
 }
@@ -180,7 +182,7 @@ func (p *Parser) Parse(buf []byte) ([]telegraf.Metric, error) {
 		"service_output": msg.String(),
 	}
 	if longmsg.Len() != 0 {
-		fields["long_service_output"] = longmsg.String()
+		fields["long_service_output"] = truncate(longmsg.String(), p.LongOutputMaxLength)
 	}
 
 	m := metric.New("nagios_state", nil, fields, ts)
@@ -189,6 +191,18 @@ func (p *Parser) Parse(buf []byte) ([]telegraf.Metric, error) {
 	return metrics, nil
 }
 
+// truncate limits the given string to at most maxLength bytes without
+// splitting multi-byte characters. A maxLength of zero disables truncation.
+func truncate(s string, maxLength int) string {
+	if maxLength <= 0 || len(s) <= maxLength {
+		return s
+	}
+	for maxLength > 0 && !utf8.RuneStart(s[maxLength]) {
+		maxLength--
+	}
+	return s[:maxLength]
+}
+
 func parsePerfData(perfdatas string, timestamp time.Time) ([]telegraf.Metric, error) {
 	metrics := make([]telegraf.Metric, 0)
 
@@ -278,8 +292,10 @@ const (
 var ErrBadThresholdFormat = errors.New("bad threshold format")
 
 // Handles all cases from https://nagios-plugins.org/doc/guidelines.html#THRESHOLDFORMAT
+// The leading "@" of inverted ranges is ignored, the caller is responsible for
+// handling the inversion.
 func parseThreshold(threshold string) (vmin, vmax float64, err error) {
-	thresh := strings.Split(threshold, ":")
+	thresh := strings.Split(strings.TrimPrefix(threshold, "@"), ":")
 	switch len(thresh) {
 	case 1:
 		vmax, err = strconv.ParseFloat(thresh[0], 64)
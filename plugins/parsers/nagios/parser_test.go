@@ -509,6 +509,24 @@ func TestParseThreshold(t *testing.T) {
 			eMax:  20,
 			eErr:  nil,
 		},
+		{
+			input: "@10:20",
+			eMin:  10,
+			eMax:  20,
+			eErr:  nil,
+		},
+		{
+			input: "@10",
+			eMin:  0,
+			eMax:  10,
+			eErr:  nil,
+		},
+		{
+			input: "@~:10",
+			eMin:  MinFloat64,
+			eMax:  10,
+			eErr:  nil,
+		},
 		{
 			input: "10:20:30",
 			eMin:  0,
@@ -525,6 +543,72 @@ func TestParseThreshold(t *testing.T) {
 	}
 }
 
+func TestParseInvertedRanges(t *testing.T) {
+	parser := Parser{metricName: "nagios_test"}
+
+	metrics, err := parser.Parse([]byte("TEMP OK - 15C | temp=15C;@10:20;@30;0;100\n"))
+	require.NoError(t, err)
+	require.Len(t, metrics, 2)
+	require.Equal(t, map[string]interface{}{
+		"value":       float64(15),
+		"warning_le":  float64(10),
+		"warning_ge":  float64(20),
+		"critical_le": float64(0),
+		"critical_ge": float64(30),
+		"min":         float64(0),
+		"max":         float64(100),
+	}, metrics[0].Fields())
+}
+
+func TestParseLongOutputTruncation(t *testing.T) {
+	input := `DISK OK - free space: / 3326 MB (56%); | /=2643MB;5948;5958;0;5968
+/ 15272 MB (77%);
+/boot 68 MB (69%);
+`
+
+	tests := []struct {
+		name      string
+		maxLength int
+		expected  string
+	}{
+		{
+			name:     "unlimited",
+			expected: "/ 15272 MB (77%);\n/boot 68 MB (69%);",
+		},
+		{
+			name:      "truncated",
+			maxLength: 20,
+			expected:  "/ 15272 MB (77%);\n/b",
+		},
+		{
+			name:      "longer than output",
+			maxLength: 100,
+			expected:  "/ 15272 MB (77%);\n/boot 68 MB (69%);",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := Parser{
+				LongOutputMaxLength: tt.maxLength,
+				metricName:          "nagios_test",
+			}
+			metrics, err := parser.Parse([]byte(input))
+			require.NoError(t, err)
+			require.Len(t, metrics, 2)
+			assertNagiosState(t, metrics[1], map[string]interface{}{
+				"service_output":      "DISK OK - free space: / 3326 MB (56%);",
+				"long_service_output": tt.expected,
+			})
+		})
+	}
+}
+
+func TestTruncateMultiByte(t *testing.T) {
+	require.Equal(t, "temp 15", truncate("temp 15°C", 8))
+	require.Equal(t, "temp 15°", truncate("temp 15°C", 9))
+}
+
 const benchmarkData = `DISK OK - free space: / 3326 MB (56%); | /=2643MB;5948;5958;0;5968
 / 15272 MB (77%);
 /boot 68 MB (69%);
@@ -0,0 +1,320 @@
+package protobuf
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/internal"
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/plugins/parsers"
+)
+
+const timestampMessage = "google.protobuf.Timestamp"
+
+type Parser struct {
+	DescriptorFile  string          `toml:"protobuf_descriptor_file"`
+	MessageType     string          `toml:"protobuf_message_type"`
+	Tags            []string        `toml:"protobuf_tags"`
+	TimestampField  string          `toml:"protobuf_timestamp_field"`
+	TimestampFormat string          `toml:"protobuf_timestamp_format"`
+	Separator       string          `toml:"protobuf_separator"`
+	RepeatedMode    string          `toml:"protobuf_repeated_mode"`
+	Log             telegraf.Logger `toml:"-"`
+
+	metricName   string
+	defaultTags  map[string]string
+	tags         map[string]bool
+	msgType      protoreflect.MessageType
+	unmarshaller proto.UnmarshalOptions
+}
+
+func (p *Parser) Init() error {
+	if p.DescriptorFile == "" {
+		return errors.New("'protobuf_descriptor_file' required")
+	}
+	if p.MessageType == "" {
+		return errors.New("'protobuf_message_type' required")
+	}
+
+	if p.Separator == "" {
+		p.Separator = "_"
+	}
+	if p.TimestampFormat == "" {
+		p.TimestampFormat = "unix"
+	}
+
+	switch p.RepeatedMode {
+	case "":
+		p.RepeatedMode = "index"
+	case "index", "explode":
+		// Do nothing as those are valid settings
+	default:
+		return fmt.Errorf("unknown 'protobuf_repeated_mode' %q", p.RepeatedMode)
+	}
+
+	p.tags = make(map[string]bool, len(p.Tags))
+	for _, tag := range p.Tags {
+		p.tags[tag] = true
+	}
+
+	// Load the compiled file descriptor set and lookup the message type
+	buf, err := os.ReadFile(p.DescriptorFile)
+	if err != nil {
+		return fmt.Errorf("reading descriptor file failed: %w", err)
+	}
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(buf, &fds); err != nil {
+		return fmt.Errorf("decoding descriptor file %q failed: %w", p.DescriptorFile, err)
+	}
+	registry, err := protodesc.NewFiles(&fds)
+	if err != nil {
+		return fmt.Errorf("constructing registry failed: %w", err)
+	}
+
+	descriptor, err := registry.FindDescriptorByName(protoreflect.FullName(p.MessageType))
+	if err != nil {
+		return fmt.Errorf("looking up message type %q failed: %w", p.MessageType, err)
+	}
+	msgDesc, ok := descriptor.(protoreflect.MessageDescriptor)
+	if !ok {
+		return fmt.Errorf("%q is not a message descriptor (%T)", p.MessageType, descriptor)
+	}
+	p.msgType = dynamicpb.NewMessageType(msgDesc)
+
+	// Ignore fields not contained in the descriptor
+	p.unmarshaller = proto.UnmarshalOptions{
+		DiscardUnknown: true,
+		Resolver:       dynamicpb.NewTypes(registry),
+	}
+
+	return nil
+}
+
+func (p *Parser) Parse(buf []byte) ([]telegraf.Metric, error) {
+	msg := p.msgType.New()
+	if err := p.unmarshaller.Unmarshal(buf, msg.Interface()); err != nil {
+		p.Log.Debugf("raw data (hex): %q", hex.EncodeToString(buf))
+		return nil, fmt.Errorf("decoding %q message failed: %w", p.MessageType, err)
+	}
+
+	now := time.Now()
+	sets := p.flatten("", msg)
+	metrics := make([]telegraf.Metric, 0, len(sets))
+	for _, values := range sets {
+		timestamp := now
+		if p.TimestampField != "" {
+			v, found := values[p.TimestampField]
+			if !found {
+				return nil, fmt.Errorf("timestamp field %q not found", p.TimestampField)
+			}
+			delete(values, p.TimestampField)
+
+			if ts, ok := v.(time.Time); ok {
+				timestamp = ts
+			} else {
+				ts, err := internal.ParseTimestamp(p.TimestampFormat, v, nil)
+				if err != nil {
+					return nil, fmt.Errorf("parsing timestamp field %q failed: %w", p.TimestampField, err)
+				}
+				timestamp = ts
+			}
+		}
+
+		tags := make(map[string]string, len(p.defaultTags)+len(p.tags))
+		for k, v := range p.defaultTags {
+			tags[k] = v
+		}
+		fields := make(map[string]interface{}, len(values))
+		for k, v := range values {
+			if ts, ok := v.(time.Time); ok {
+				v = ts.UnixNano()
+			}
+			if p.tags[k] {
+				s, err := internal.ToString(v)
+				if err != nil {
+					return nil, fmt.Errorf("converting tag %q failed: %w", k, err)
+				}
+				tags[k] = s
+				continue
+			}
+			fields[k] = v
+		}
+
+		// Skip metrics without any field
+		if len(fields) == 0 {
+			continue
+		}
+		metrics = append(metrics, metric.New(p.metricName, tags, fields, timestamp))
+	}
+
+	return metrics, nil
+}
+
+func (p *Parser) ParseLine(line string) (telegraf.Metric, error) {
+	metrics, err := p.Parse([]byte(line))
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(metrics) {
+	case 0:
+		return nil, nil
+	case 1:
+		return metrics[0], nil
+	default:
+		return metrics[0], fmt.Errorf("cannot parse line with multiple (%d) metrics", len(metrics))
+	}
+}
+
+func (p *Parser) SetDefaultTags(tags map[string]string) {
+	p.defaultTags = tags
+}
+
+// flatten converts the given message into sets of values keyed by the field
+// path. Exploding repeated fields results in multiple sets, one per element.
+func (p *Parser) flatten(prefix string, msg protoreflect.Message) []map[string]interface{} {
+	results := []map[string]interface{}{{}}
+
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		// Skip unset message and optional fields but keep scalars with
+		// implicit presence as those are not serialized for zero values.
+		if fd.HasPresence() && !msg.Has(fd) {
+			continue
+		}
+
+		name := p.join(prefix, string(fd.Name()))
+		value := msg.Get(fd)
+		switch {
+		case fd.IsMap():
+			m := value.Map()
+			keys := make([]protoreflect.MapKey, 0, m.Len())
+			m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				keys = append(keys, k)
+				return true
+			})
+			sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+			for _, k := range keys {
+				results = product(results, p.flattenValue(p.join(name, k.String()), fd.MapValue(), m.Get(k)))
+			}
+		case fd.IsList():
+			list := value.List()
+			if list.Len() == 0 {
+				continue
+			}
+			if p.RepeatedMode == "explode" {
+				var elements []map[string]interface{}
+				for j := 0; j < list.Len(); j++ {
+					elements = append(elements, p.flattenValue(name, fd, list.Get(j))...)
+				}
+				results = product(results, elements)
+				continue
+			}
+			for j := 0; j < list.Len(); j++ {
+				results = product(results, p.flattenValue(p.join(name, strconv.Itoa(j)), fd, list.Get(j)))
+			}
+		default:
+			results = product(results, p.flattenValue(name, fd, value))
+		}
+	}
+
+	return results
+}
+
+func (p *Parser) flattenValue(name string, fd protoreflect.FieldDescriptor, value protoreflect.Value) []map[string]interface{} {
+	var v interface{}
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := value.Message()
+		if msg.Descriptor().FullName() == timestampMessage {
+			fields := msg.Descriptor().Fields()
+			seconds := msg.Get(fields.ByName("seconds")).Int()
+			nanos := msg.Get(fields.ByName("nanos")).Int()
+			return []map[string]interface{}{{name: time.Unix(seconds, nanos).UTC()}}
+		}
+		return p.flatten(name, msg)
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(value.Enum()); ev != nil {
+			v = string(ev.Name())
+		} else {
+			v = int64(value.Enum())
+		}
+	case protoreflect.BoolKind:
+		v = value.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v = value.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v = value.Uint()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		v = value.Float()
+	case protoreflect.StringKind:
+		// Empty strings and bytes are indistinguishable from unset fields
+		if value.String() == "" {
+			return nil
+		}
+		v = value.String()
+	case protoreflect.BytesKind:
+		if len(value.Bytes()) == 0 {
+			return nil
+		}
+		v = hex.EncodeToString(value.Bytes())
+	default:
+		p.Log.Debugf("Ignoring field %q of unsupported kind %v", name, fd.Kind())
+		return nil
+	}
+
+	return []map[string]interface{}{{name: v}}
+}
+
+func (p *Parser) join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + p.Separator + name
+}
+
+// product combines each of the value sets in a with each of the sets in b
+func product(a, b []map[string]interface{}) []map[string]interface{} {
+	if len(b) == 0 {
+		return a
+	}
+
+	results := make([]map[string]interface{}, 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			combined := make(map[string]interface{}, len(x)+len(y))
+			for k, v := range x {
+				combined[k] = v
+			}
+			for k, v := range y {
+				combined[k] = v
+			}
+			results = append(results, combined)
+		}
+	}
+	return results
+}
+
+func init() {
+	parsers.Add("protobuf",
+		func(defaultMetricName string) telegraf.Parser {
+			return &Parser{metricName: defaultMetricName}
+		},
+	)
+}
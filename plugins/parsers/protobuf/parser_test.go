@@ -0,0 +1,456 @@
+package protobuf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		parser   *Parser
+		input    string
+		expected []telegraf.Metric
+	}{
+		{
+			name: "scalar fields",
+			parser: &Parser{
+				MessageType: "telegraf.test.Sensor",
+			},
+			input: `{
+				"device": "sensor01",
+				"temperature": 23,
+				"active": true,
+				"status": "OK",
+				"serial": "3q2+7w=="
+			}`,
+			expected: []telegraf.Metric{
+				metric.New(
+					"protobuf",
+					map[string]string{},
+					map[string]interface{}{
+						"device":      "sensor01",
+						"timestamp":   uint64(0),
+						"temperature": int64(23),
+						"active":      true,
+						"status":      "OK",
+						"serial":      "deadbeef",
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "tags and timestamp",
+			parser: &Parser{
+				MessageType:     "telegraf.test.Sensor",
+				Tags:            []string{"device", "location_site"},
+				TimestampField:  "timestamp",
+				TimestampFormat: "unix_ms",
+			},
+			input: `{
+				"device": "sensor01",
+				"timestamp": 1704067200123,
+				"temperature": -5,
+				"location": {"site": "berlin", "latitude": 52.52, "longitude": 13.405},
+				"humidity": 42.5
+			}`,
+			expected: []telegraf.Metric{
+				metric.New(
+					"protobuf",
+					map[string]string{
+						"device":        "sensor01",
+						"location_site": "berlin",
+					},
+					map[string]interface{}{
+						"temperature":        int64(-5),
+						"active":             false,
+						"status":             "UNKNOWN",
+						"location_latitude":  float64(52.52),
+						"location_longitude": float64(13.405),
+						"humidity":           float64(42.5),
+					},
+					time.UnixMilli(1704067200123),
+				),
+			},
+		},
+		{
+			name: "well-known timestamp",
+			parser: &Parser{
+				MessageType:    "telegraf.test.Sensor",
+				Tags:           []string{"device"},
+				TimestampField: "time",
+			},
+			input: `{
+				"device": "sensor01",
+				"temperature": 23,
+				"time": "2024-01-01T00:00:00.5Z"
+			}`,
+			expected: []telegraf.Metric{
+				metric.New(
+					"protobuf",
+					map[string]string{"device": "sensor01"},
+					map[string]interface{}{
+						"timestamp":   uint64(0),
+						"temperature": int64(23),
+						"active":      false,
+						"status":      "UNKNOWN",
+					},
+					time.Date(2024, 1, 1, 0, 0, 0, 500000000, time.UTC),
+				),
+			},
+		},
+		{
+			name: "custom separator",
+			parser: &Parser{
+				MessageType:    "telegraf.test.Sensor",
+				Tags:           []string{"device", "labels.room"},
+				TimestampField: "timestamp",
+				Separator:      ".",
+			},
+			input: `{
+				"device": "sensor01",
+				"timestamp": 1704067200,
+				"temperature": 23,
+				"location": {"site": "berlin"},
+				"labels": {"room": "kitchen", "floor": "1"}
+			}`,
+			expected: []telegraf.Metric{
+				metric.New(
+					"protobuf",
+					map[string]string{
+						"device":      "sensor01",
+						"labels.room": "kitchen",
+					},
+					map[string]interface{}{
+						"temperature":        int64(23),
+						"active":             false,
+						"status":             "UNKNOWN",
+						"location.site":      "berlin",
+						"location.latitude":  float64(0),
+						"location.longitude": float64(0),
+						"labels.floor":       "1",
+					},
+					time.Unix(1704067200, 0),
+				),
+			},
+		},
+		{
+			name: "repeated fields indexed",
+			parser: &Parser{
+				MessageType:    "telegraf.test.Sensor",
+				Tags:           []string{"device"},
+				TimestampField: "timestamp",
+			},
+			input: `{
+				"device": "sensor01",
+				"timestamp": 1704067200,
+				"readings": [
+					{"name": "voltage", "value": 3.3},
+					{"name": "current", "value": 0.5}
+				],
+				"samples": [1, 2, 3]
+			}`,
+			expected: []telegraf.Metric{
+				metric.New(
+					"protobuf",
+					map[string]string{"device": "sensor01"},
+					map[string]interface{}{
+						"temperature":      int64(0),
+						"active":           false,
+						"status":           "UNKNOWN",
+						"readings_0_name":  "voltage",
+						"readings_0_value": float64(3.3),
+						"readings_1_name":  "current",
+						"readings_1_value": float64(0.5),
+						"samples_0":        int64(1),
+						"samples_1":        int64(2),
+						"samples_2":        int64(3),
+					},
+					time.Unix(1704067200, 0),
+				),
+			},
+		},
+		{
+			name: "repeated fields exploded",
+			parser: &Parser{
+				MessageType:    "telegraf.test.Sensor",
+				Tags:           []string{"device", "readings_name"},
+				TimestampField: "timestamp",
+				RepeatedMode:   "explode",
+			},
+			input: `{
+				"device": "sensor01",
+				"timestamp": 1704067200,
+				"temperature": 23,
+				"readings": [
+					{"name": "voltage", "value": 3.3},
+					{"name": "current", "value": 0.5}
+				]
+			}`,
+			expected: []telegraf.Metric{
+				metric.New(
+					"protobuf",
+					map[string]string{
+						"device":        "sensor01",
+						"readings_name": "voltage",
+					},
+					map[string]interface{}{
+						"temperature":    int64(23),
+						"active":         false,
+						"status":         "UNKNOWN",
+						"readings_value": float64(3.3),
+					},
+					time.Unix(1704067200, 0),
+				),
+				metric.New(
+					"protobuf",
+					map[string]string{
+						"device":        "sensor01",
+						"readings_name": "current",
+					},
+					map[string]interface{}{
+						"temperature":    int64(23),
+						"active":         false,
+						"status":         "UNKNOWN",
+						"readings_value": float64(0.5),
+					},
+					time.Unix(1704067200, 0),
+				),
+			},
+		},
+	}
+
+	descriptorFile := compileDescriptorSet(t, "sensor.proto")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.parser.DescriptorFile = descriptorFile
+			tt.parser.metricName = "protobuf"
+			tt.parser.Log = testutil.Logger{}
+			require.NoError(t, tt.parser.Init())
+
+			buf := encodeMessage(t, descriptorFile, tt.parser.MessageType, tt.input)
+			actual, err := tt.parser.Parse(buf)
+			require.NoError(t, err)
+
+			options := []cmp.Option{testutil.SortMetrics()}
+			if tt.parser.TimestampField == "" {
+				options = append(options, testutil.IgnoreTime())
+			}
+			testutil.RequireMetricsEqual(t, tt.expected, actual, options...)
+		})
+	}
+}
+
+func TestParseUnknownFields(t *testing.T) {
+	descriptorFile := compileDescriptorSet(t, "sensor.proto")
+	descriptorFileV2 := compileDescriptorSet(t, "sensor_v2.proto")
+
+	plugin := &Parser{
+		DescriptorFile: descriptorFile,
+		MessageType:    "telegraf.test.Sensor",
+		Tags:           []string{"device"},
+		TimestampField: "timestamp",
+		metricName:     "protobuf",
+		Log:            testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	// Encode a message of a newer schema revision containing fields unknown
+	// to the configured descriptor
+	input := `{
+		"device": "sensor01",
+		"timestamp": 1704067200,
+		"temperature": 23,
+		"firmware": "1.2.3",
+		"voltage": 3.3
+	}`
+	buf := encodeMessage(t, descriptorFileV2, "telegraf.test.SensorV2", input)
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"protobuf",
+			map[string]string{"device": "sensor01"},
+			map[string]interface{}{
+				"temperature": int64(23),
+				"active":      false,
+				"status":      "UNKNOWN",
+			},
+			time.Unix(1704067200, 0),
+		),
+	}
+
+	actual, err := plugin.Parse(buf)
+	require.NoError(t, err)
+	testutil.RequireMetricsEqual(t, expected, actual)
+}
+
+func TestParseLine(t *testing.T) {
+	descriptorFile := compileDescriptorSet(t, "sensor.proto")
+
+	plugin := &Parser{
+		DescriptorFile:  descriptorFile,
+		MessageType:     "telegraf.test.Sensor",
+		TimestampField:  "timestamp",
+		TimestampFormat: "unix",
+		metricName:      "protobuf",
+		Log:             testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+	plugin.SetDefaultTags(map[string]string{"host": "localhost"})
+
+	buf := encodeMessage(t, descriptorFile, plugin.MessageType, `{"device": "sensor01", "timestamp": 1704067200}`)
+
+	expected := metric.New(
+		"protobuf",
+		map[string]string{"host": "localhost"},
+		map[string]interface{}{
+			"device":      "sensor01",
+			"temperature": int64(0),
+			"active":      false,
+			"status":      "UNKNOWN",
+		},
+		time.Unix(1704067200, 0),
+	)
+
+	actual, err := plugin.ParseLine(string(buf))
+	require.NoError(t, err)
+	testutil.RequireMetricEqual(t, expected, actual)
+}
+
+func TestParseErrors(t *testing.T) {
+	descriptorFile := compileDescriptorSet(t, "sensor.proto")
+
+	plugin := &Parser{
+		DescriptorFile: descriptorFile,
+		MessageType:    "telegraf.test.Sensor",
+		TimestampField: "time",
+		metricName:     "protobuf",
+		Log:            testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	_, err := plugin.Parse([]byte{0xff, 0xff, 0xff})
+	require.ErrorContains(t, err, `decoding "telegraf.test.Sensor" message failed`)
+
+	buf := encodeMessage(t, descriptorFile, plugin.MessageType, `{"device": "sensor01"}`)
+	_, err = plugin.Parse(buf)
+	require.EqualError(t, err, `timestamp field "time" not found`)
+}
+
+func TestInitErrors(t *testing.T) {
+	descriptorFile := compileDescriptorSet(t, "sensor.proto")
+
+	tests := []struct {
+		name     string
+		parser   *Parser
+		expected string
+	}{
+		{
+			name:     "missing descriptor file",
+			parser:   &Parser{MessageType: "telegraf.test.Sensor"},
+			expected: "'protobuf_descriptor_file' required",
+		},
+		{
+			name:     "missing message type",
+			parser:   &Parser{DescriptorFile: descriptorFile},
+			expected: "'protobuf_message_type' required",
+		},
+		{
+			name: "invalid repeated mode",
+			parser: &Parser{
+				DescriptorFile: descriptorFile,
+				MessageType:    "telegraf.test.Sensor",
+				RepeatedMode:   "foo",
+			},
+			expected: `unknown 'protobuf_repeated_mode' "foo"`,
+		},
+		{
+			name: "non-existing descriptor file",
+			parser: &Parser{
+				DescriptorFile: filepath.Join("testdata", "non-existing.pb"),
+				MessageType:    "telegraf.test.Sensor",
+			},
+			expected: "reading descriptor file failed",
+		},
+		{
+			name: "invalid descriptor file",
+			parser: &Parser{
+				DescriptorFile: filepath.Join("testdata", "sensor.proto"),
+				MessageType:    "telegraf.test.Sensor",
+			},
+			expected: "decoding descriptor file",
+		},
+		{
+			name: "unknown message type",
+			parser: &Parser{
+				DescriptorFile: descriptorFile,
+				MessageType:    "telegraf.test.Unknown",
+			},
+			expected: `looking up message type "telegraf.test.Unknown" failed`,
+		},
+		{
+			name: "not a message type",
+			parser: &Parser{
+				DescriptorFile: descriptorFile,
+				MessageType:    "telegraf.test.Status",
+			},
+			expected: `"telegraf.test.Status" is not a message descriptor`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorContains(t, tt.parser.Init(), tt.expected)
+		})
+	}
+}
+
+// compileDescriptorSet compiles the given protocol-buffer definition from the
+// testdata directory into a file descriptor set as generated by
+// "protoc --include_imports --descriptor_set_out"
+func compileDescriptorSet(t *testing.T, filename string) string {
+	t.Helper()
+
+	parser := protoparse.Parser{ImportPaths: []string{"testdata"}}
+	fds, err := parser.ParseFiles(filename)
+	require.NoError(t, err)
+
+	buf, err := proto.Marshal(desc.ToFileDescriptorSet(fds...))
+	require.NoError(t, err)
+
+	fn := filepath.Join(t.TempDir(), "descriptor.pb")
+	require.NoError(t, os.WriteFile(fn, buf, 0600))
+
+	return fn
+}
+
+// encodeMessage serializes the given JSON representation of the message
+func encodeMessage(t *testing.T, descriptorFile, messageType, input string) []byte {
+	t.Helper()
+
+	plugin := &Parser{DescriptorFile: descriptorFile, MessageType: messageType}
+	require.NoError(t, plugin.Init())
+
+	msg := dynamicpb.NewMessage(plugin.msgType.Descriptor())
+	require.NoError(t, protojson.Unmarshal([]byte(input), msg))
+
+	buf, err := proto.Marshal(msg)
+	require.NoError(t, err)
+
+	return buf
+}
@@ -3,6 +3,7 @@ package value
 import (
 	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -15,39 +16,61 @@ import (
 
 type Parser struct {
 	DataType    string            `toml:"data_type"`
+	DataTypes   []string          `toml:"value_data_types"`
 	FieldName   string            `toml:"value_field_name"`
+	FieldNames  []string          `toml:"value_field_names"`
+	Separator   string            `toml:"value_separator"`
 	MetricName  string            `toml:"-"`
 	DefaultTags map[string]string `toml:"-"`
+
+	types []string
 }
 
 func (v *Parser) Init() error {
-	switch v.DataType {
-	case "", "int", "integer":
-		v.DataType = "int"
-	case "float", "long":
-		v.DataType = "float"
-	case "str", "string":
-		v.DataType = "string"
-	case "base64":
-		v.DataType = "base64"
-	case "bool", "boolean":
-		v.DataType = "bool"
-	case "auto_integer", "auto_float":
-		// Do nothing both are valid
-	default:
-		return fmt.Errorf("unknown datatype %q", v.DataType)
+	dtype, err := normalizeDataType(v.DataType)
+	if err != nil {
+		return err
 	}
+	v.DataType = dtype
 
 	if v.FieldName == "" {
 		v.FieldName = "value"
 	}
 
+	if len(v.DataTypes) > 0 {
+		if len(v.FieldNames) == 0 {
+			return errors.New("'value_data_types' requires 'value_field_names' to be set")
+		}
+		if len(v.DataTypes) != len(v.FieldNames) {
+			return fmt.Errorf("number of data types (%d) does not match number of field names (%d)", len(v.DataTypes), len(v.FieldNames))
+		}
+	}
+	v.types = make([]string, 0, len(v.FieldNames))
+	for i, name := range v.FieldNames {
+		if name == "" {
+			return fmt.Errorf("empty field name at index %d", i)
+		}
+		if len(v.DataTypes) == 0 {
+			v.types = append(v.types, v.DataType)
+			continue
+		}
+		dtype, err := normalizeDataType(v.DataTypes[i])
+		if err != nil {
+			return fmt.Errorf("field %q: %w", name, err)
+		}
+		v.types = append(v.types, dtype)
+	}
+
 	return nil
 }
 
 func (v *Parser) Parse(buf []byte) ([]telegraf.Metric, error) {
 	vStr := string(bytes.TrimSpace(bytes.Trim(buf, "\x00")))
 
+	if len(v.FieldNames) > 0 {
+		return v.parseMultiple(vStr)
+	}
+
 	// unless it's a string, separate out any fields in the buffer,
 	// ignore anything but the last.
 	if v.DataType != "string" {
@@ -60,29 +83,10 @@ func (v *Parser) Parse(buf []byte) ([]telegraf.Metric, error) {
 
 	var value interface{}
 	var err error
-	switch v.DataType {
-	case "int":
-		value, err = strconv.Atoi(vStr)
-	case "float":
-		value, err = strconv.ParseFloat(vStr, 64)
-	case "string":
-		value = vStr
-	case "base64":
+	if v.DataType == "base64" {
 		value = base64.StdEncoding.EncodeToString(buf)
-	case "bool":
-		value, err = strconv.ParseBool(vStr)
-	case "auto_integer":
-		value, err = strconv.Atoi(vStr)
-		if err != nil {
-			value = vStr
-			err = nil
-		}
-	case "auto_float":
-		value, err = strconv.ParseFloat(vStr, 64)
-		if err != nil {
-			value = vStr
-			err = nil
-		}
+	} else {
+		value, err = convert(v.DataType, vStr)
 	}
 	if err != nil {
 		return nil, err
@@ -95,6 +99,46 @@ func (v *Parser) Parse(buf []byte) ([]telegraf.Metric, error) {
 	return []telegraf.Metric{m}, nil
 }
 
+// parseMultiple splits the data into values and assigns them to the
+// configured field names in order
+func (v *Parser) parseMultiple(vStr string) ([]telegraf.Metric, error) {
+	if vStr == "" {
+		return nil, nil
+	}
+
+	var values []string
+	if v.Separator == "" {
+		values = strings.Fields(vStr)
+	} else {
+		values = strings.Split(vStr, v.Separator)
+		for i, value := range values {
+			values[i] = strings.TrimSpace(value)
+		}
+	}
+	if len(values) != len(v.FieldNames) {
+		return nil, fmt.Errorf("expected %d values but got %d in line %q", len(v.FieldNames), len(values), vStr)
+	}
+
+	fields := make(map[string]interface{}, len(values))
+	for i, vs := range values {
+		var value interface{}
+		var err error
+		if v.types[i] == "base64" {
+			value = base64.StdEncoding.EncodeToString([]byte(vs))
+		} else {
+			value, err = convert(v.types[i], vs)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("converting field %q in line %q failed: %w", v.FieldNames[i], vStr, err)
+		}
+		fields[v.FieldNames[i]] = value
+	}
+
+	m := metric.New(v.MetricName, v.DefaultTags, fields, time.Now().UTC())
+
+	return []telegraf.Metric{m}, nil
+}
+
 func (v *Parser) ParseLine(line string) (telegraf.Metric, error) {
 	metrics, err := v.Parse([]byte(line))
 
@@ -113,6 +157,44 @@ func (v *Parser) SetDefaultTags(tags map[string]string) {
 	v.DefaultTags = tags
 }
 
+func normalizeDataType(dtype string) (string, error) {
+	switch dtype {
+	case "", "int", "integer":
+		return "int", nil
+	case "float", "long":
+		return "float", nil
+	case "str", "string":
+		return "string", nil
+	case "base64":
+		return "base64", nil
+	case "bool", "boolean":
+		return "bool", nil
+	case "auto_integer", "auto_float":
+		return dtype, nil
+	}
+	return "", fmt.Errorf("unknown datatype %q", dtype)
+}
+
+func convert(dtype, vStr string) (interface{}, error) {
+	switch dtype {
+	case "int":
+		return strconv.Atoi(vStr)
+	case "float":
+		return strconv.ParseFloat(vStr, 64)
+	case "bool":
+		return strconv.ParseBool(vStr)
+	case "auto_integer":
+		if value, err := strconv.Atoi(vStr); err == nil {
+			return value, nil
+		}
+	case "auto_float":
+		if value, err := strconv.ParseFloat(vStr, 64); err == nil {
+			return value, nil
+		}
+	}
+	return vStr, nil
+}
+
 func init() {
 	parsers.Add("value",
 		func(defaultMetricName string) telegraf.Parser {
@@ -312,6 +312,116 @@ func TestInvalidDatatype(t *testing.T) {
 	require.ErrorContains(t, parser.Init(), "unknown datatype")
 }
 
+func TestParseMultipleValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		dtype     string
+		dtypes    []string
+		separator string
+		input     string
+		expected  map[string]interface{}
+	}{
+		{
+			name:     "whitespace separated",
+			dtype:    "float",
+			input:    "23.5 48\t1013",
+			expected: map[string]interface{}{"temp": 23.5, "humidity": float64(48), "pressure": float64(1013)},
+		},
+		{
+			name:      "custom separator",
+			dtype:     "float",
+			separator: ",",
+			input:     "23.5, 48,1013\n",
+			expected:  map[string]interface{}{"temp": 23.5, "humidity": float64(48), "pressure": float64(1013)},
+		},
+		{
+			name:     "per-field types",
+			dtypes:   []string{"float", "integer", "auto_integer"},
+			input:    "23.5 48 n/a",
+			expected: map[string]interface{}{"temp": 23.5, "humidity": int64(48), "pressure": "n/a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := Parser{
+				MetricName: "value_test",
+				DataType:   tt.dtype,
+				DataTypes:  tt.dtypes,
+				FieldNames: []string{"temp", "humidity", "pressure"},
+				Separator:  tt.separator,
+			}
+			require.NoError(t, plugin.Init())
+
+			actual, err := plugin.Parse([]byte(tt.input))
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+			require.Equal(t, tt.expected, actual[0].Fields())
+		})
+	}
+}
+
+func TestParseMultipleValuesInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "too few values",
+			input:    "23.5 48",
+			expected: `expected 3 values but got 2 in line "23.5 48"`,
+		},
+		{
+			name:     "too many values",
+			input:    "23.5 48 1013 1",
+			expected: `expected 3 values but got 4 in line "23.5 48 1013 1"`,
+		},
+		{
+			name:     "invalid value",
+			input:    "23.5 foo 1013",
+			expected: `converting field "humidity" in line "23.5 foo 1013" failed`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := Parser{
+				MetricName: "value_test",
+				DataType:   "float",
+				FieldNames: []string{"temp", "humidity", "pressure"},
+			}
+			require.NoError(t, plugin.Init())
+
+			actual, err := plugin.Parse([]byte(tt.input))
+			require.ErrorContains(t, err, tt.expected)
+			require.Empty(t, actual)
+		})
+	}
+}
+
+func TestMultipleValuesInvalidDatatypes(t *testing.T) {
+	parser := Parser{
+		MetricName: "value_test",
+		DataTypes:  []string{"float", "integer"},
+		FieldNames: []string{"temp", "humidity", "pressure"},
+	}
+	require.ErrorContains(t, parser.Init(), "number of data types (2) does not match number of field names (3)")
+
+	parser = Parser{
+		MetricName: "value_test",
+		DataTypes:  []string{"float", "foo"},
+		FieldNames: []string{"temp", "humidity"},
+	}
+	require.ErrorContains(t, parser.Init(), `field "humidity": unknown datatype "foo"`)
+
+	parser = Parser{
+		MetricName: "value_test",
+		DataTypes:  []string{"float"},
+	}
+	require.ErrorContains(t, parser.Init(), "'value_data_types' requires 'value_field_names' to be set")
+}
+
 const benchmarkData = `5`
 
 func TestBenchmarkData(t *testing.T) {
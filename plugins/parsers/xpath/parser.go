@@ -46,6 +46,8 @@ type Parser struct {
 	AllowEmptySelection  bool              `toml:"xpath_allow_empty_selection"`
 	NativeTypes          bool              `toml:"xpath_native_types"`
 	Trace                bool              `toml:"xpath_trace" deprecated:"1.35.0;use 'log_level' 'trace' instead"`
+	Namespaces           map[string]string `toml:"xpath_xmlns"`
+	StripNamespaces      bool              `toml:"xpath_strip_namespaces"`
 	Configs              []Config          `toml:"xpath"`
 	DefaultMetricName    string            `toml:"-"`
 	DefaultTags          map[string]string `toml:"-"`
@@ -88,9 +90,21 @@ type Config struct {
 }
 
 func (p *Parser) Init() error {
+	for prefix, uri := range p.Namespaces {
+		if prefix == "" || uri == "" {
+			return fmt.Errorf("invalid namespace binding %q = %q, prefix and URI must not be empty", prefix, uri)
+		}
+	}
+	if p.StripNamespaces && len(p.Namespaces) > 0 {
+		return errors.New("'xpath_xmlns' cannot be used together with 'xpath_strip_namespaces'")
+	}
+
 	switch p.Format {
 	case "", "xml":
-		p.document = &xmlDocument{}
+		p.document = &xmlDocument{
+			Namespaces:      p.Namespaces,
+			StripNamespaces: p.StripNamespaces,
+		}
 
 		// Required for backward compatibility
 		if len(p.ConfigsXML) > 0 {
@@ -156,6 +170,13 @@ func (p *Parser) Init() error {
 		return fmt.Errorf("unknown data-format %q for xpath parser", p.Format)
 	}
 
+	// Namespaces only exist in XML documents
+	if p.StripNamespaces {
+		if _, ok := p.document.(*xmlDocument); !ok {
+			return fmt.Errorf("'xpath_strip_namespaces' is not supported for data-format %q", p.Format)
+		}
+	}
+
 	// Make sure we do have a metric name
 	if p.DefaultMetricName == "" {
 		return errors.New("missing default metric name")
@@ -517,7 +538,7 @@ func (p *Parser) executeQuery(doc, selected dataNode, query string) (r interface
 	}
 
 	// Compile the query
-	expr, err := path.Compile(query)
+	expr, err := path.CompileWithNS(query, p.Namespaces)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile query %q: %w", query, err)
 	}
@@ -1117,6 +1117,169 @@ func TestParseErrors(t *testing.T) {
 	}
 }
 
+func TestParseNamespaces(t *testing.T) {
+	input := `
+<?xml version="1.0"?>
+<d:measurements xmlns:d="urn:device" xmlns:x="urn:extra">
+	<d:device d:id="sensor-1">
+		<d:value>42.5</d:value>
+		<x:value>23</x:value>
+	</d:device>
+	<d:device d:id="sensor-2">
+		<d:value>41.0</d:value>
+	</d:device>
+</d:measurements>
+`
+	defaultNamespaceInput := `
+<?xml version="1.0"?>
+<measurements xmlns="urn:device">
+	<device id="sensor-1">
+		<value>42.5</value>
+	</device>
+	<device id="sensor-2">
+		<value>41.0</value>
+	</device>
+</measurements>
+`
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"test",
+			map[string]string{"device": "sensor-1"},
+			map[string]interface{}{"value": 42.5},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"test",
+			map[string]string{"device": "sensor-2"},
+			map[string]interface{}{"value": 41.0},
+			time.Unix(0, 0),
+		),
+	}
+
+	var tests = []struct {
+		name       string
+		input      string
+		namespaces map[string]string
+		strip      bool
+		config     Config
+	}{
+		{
+			name:       "document prefix",
+			input:      input,
+			namespaces: map[string]string{"d": "urn:device"},
+			config: Config{
+				Selection: "//d:device",
+				Tags:      map[string]string{"device": "@d:id"},
+				Fields:    map[string]string{"value": "number(d:value)"},
+			},
+		},
+		{
+			name:       "different prefix",
+			input:      input,
+			namespaces: map[string]string{"dev": "urn:device"},
+			config: Config{
+				Selection: "//dev:device",
+				Tags:      map[string]string{"device": "@dev:id"},
+				Fields:    map[string]string{"value": "number(dev:value)"},
+			},
+		},
+		{
+			name:       "default namespace",
+			input:      defaultNamespaceInput,
+			namespaces: map[string]string{"d": "urn:device"},
+			config: Config{
+				Selection: "/d:measurements/d:device",
+				Tags:      map[string]string{"device": "@id"},
+				Fields:    map[string]string{"value": "number(d:value)"},
+			},
+		},
+		{
+			name:  "strip namespaces",
+			input: input,
+			strip: true,
+			config: Config{
+				Selection: "//device",
+				Tags:      map[string]string{"device": "@id"},
+				Fields:    map[string]string{"value": "number(value[1])"},
+			},
+		},
+		{
+			name:  "strip default namespace",
+			input: defaultNamespaceInput,
+			strip: true,
+			config: Config{
+				Selection: "/measurements/device",
+				Tags:      map[string]string{"device": "@id"},
+				Fields:    map[string]string{"value": "number(value)"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := &Parser{
+				DefaultMetricName: "test",
+				Namespaces:        tt.namespaces,
+				StripNamespaces:   tt.strip,
+				Configs:           []Config{tt.config},
+				Log:               testutil.Logger{Name: "parsers.xml"},
+			}
+			require.NoError(t, parser.Init())
+
+			actual, err := parser.Parse([]byte(tt.input))
+			require.NoError(t, err)
+			testutil.RequireMetricsEqual(t, expected, actual, testutil.IgnoreTime())
+		})
+	}
+}
+
+func TestNamespacesInvalid(t *testing.T) {
+	var tests = []struct {
+		name       string
+		format     string
+		namespaces map[string]string
+		strip      bool
+		expected   string
+	}{
+		{
+			name:       "empty prefix",
+			namespaces: map[string]string{"": "urn:device"},
+			expected:   `invalid namespace binding "" = "urn:device"`,
+		},
+		{
+			name:       "empty uri",
+			namespaces: map[string]string{"d": ""},
+			expected:   `invalid namespace binding "d" = ""`,
+		},
+		{
+			name:       "strip with bindings",
+			namespaces: map[string]string{"d": "urn:device"},
+			strip:      true,
+			expected:   "cannot be used together with",
+		},
+		{
+			name:     "strip for json",
+			format:   "xpath_json",
+			strip:    true,
+			expected: `'xpath_strip_namespaces' is not supported for data-format "xpath_json"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := &Parser{
+				Format:            tt.format,
+				DefaultMetricName: "test",
+				Namespaces:        tt.namespaces,
+				StripNamespaces:   tt.strip,
+				Log:               testutil.Logger{Name: "parsers.xml"},
+			}
+			require.ErrorContains(t, parser.Init(), tt.expected)
+		})
+	}
+}
+
 func TestEmptySelection(t *testing.T) {
 	var tests = []struct {
 		name    string
@@ -7,15 +7,25 @@ import (
 	path "github.com/antchfx/xpath"
 )
 
-type xmlDocument struct{}
+type xmlDocument struct {
+	Namespaces      map[string]string
+	StripNamespaces bool
+}
 
 func (d *xmlDocument) Parse(buf []byte) (dataNode, error) {
-	return xmlquery.Parse(strings.NewReader(string(buf)))
+	doc, err := xmlquery.Parse(strings.NewReader(string(buf)))
+	if err != nil {
+		return nil, err
+	}
+	if d.StripNamespaces {
+		stripNamespaces(doc)
+	}
+	return doc, nil
 }
 
 func (d *xmlDocument) QueryAll(node dataNode, expr string) ([]dataNode, error) {
 	// If this panics it's a programming error as we changed the document type while processing
-	native, err := xmlquery.QueryAll(node.(*xmlquery.Node), expr)
+	native, err := d.queryAll(node.(*xmlquery.Node), expr)
 	if err != nil {
 		return nil, err
 	}
@@ -27,6 +37,19 @@ func (d *xmlDocument) QueryAll(node dataNode, expr string) ([]dataNode, error) {
 	return nodes, nil
 }
 
+func (d *xmlDocument) queryAll(node *xmlquery.Node, expr string) ([]*xmlquery.Node, error) {
+	if len(d.Namespaces) == 0 {
+		return xmlquery.QueryAll(node, expr)
+	}
+
+	// Bind the configured namespace prefixes to the query
+	compiled, err := path.CompileWithNS(expr, d.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+	return xmlquery.QuerySelectorAll(node, compiled), nil
+}
+
 func (d *xmlDocument) CreateXPathNavigator(node dataNode) path.NodeNavigator {
 	// If this panics it's a programming error as we changed the document type while processing
 	return xmlquery.CreateXPathNavigator(node.(*xmlquery.Node))
@@ -71,3 +94,25 @@ func (d *xmlDocument) OutputXML(node dataNode) string {
 	native := node.(*xmlquery.Node)
 	return native.OutputXML(false)
 }
+
+// stripNamespaces removes all namespace prefixes, namespace URIs and namespace
+// declarations from the given node and its children
+func stripNamespaces(node *xmlquery.Node) {
+	for n := node; n != nil; n = n.NextSibling {
+		n.Prefix = ""
+		n.NamespaceURI = ""
+
+		attrs := n.Attr[:0]
+		for _, attr := range n.Attr {
+			if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+				continue
+			}
+			attr.Name.Space = ""
+			attr.NamespaceURI = ""
+			attrs = append(attrs, attr)
+		}
+		n.Attr = attrs
+
+		stripNamespaces(n.FirstChild)
+	}
+}
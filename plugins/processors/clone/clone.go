@@ -3,8 +3,10 @@ package clone
 
 import (
 	_ "embed"
+	"fmt"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/plugins/processors"
 )
 
@@ -12,39 +14,112 @@ import (
 var sampleConfig string
 
 type Clone struct {
-	NameOverride string
-	NamePrefix   string
-	NameSuffix   string
-	Tags         map[string]string
+	NameOverride string            `toml:"name_override"`
+	NamePrefix   string            `toml:"name_prefix"`
+	NameSuffix   string            `toml:"name_suffix"`
+	Tags         map[string]string `toml:"tags"`
+	Copies       []copySpec        `toml:"copy"`
+	DropOriginal bool              `toml:"drop_original"`
+}
+
+// copySpec describes one of multiple copies created for each metric
+type copySpec struct {
+	NameOverride string              `toml:"name_override"`
+	NamePrefix   string              `toml:"name_prefix"`
+	NameSuffix   string              `toml:"name_suffix"`
+	Tags         map[string]string   `toml:"tags"`
+	TagPass      map[string][]string `toml:"tagpass"`
+
+	tagFilters map[string]filter.Filter
 }
 
 func (*Clone) SampleConfig() string {
 	return sampleConfig
 }
 
+func (c *Clone) Init() error {
+	for i := range c.Copies {
+		cs := &c.Copies[i]
+		cs.tagFilters = make(map[string]filter.Filter, len(cs.TagPass))
+		for key, patterns := range cs.TagPass {
+			f, err := filter.Compile(patterns)
+			if err != nil {
+				return fmt.Errorf("creating tagpass filter for %q in copy %d failed: %w", key, i+1, err)
+			}
+			cs.tagFilters[key] = f
+		}
+	}
+	return nil
+}
+
 func (c *Clone) Apply(in ...telegraf.Metric) []telegraf.Metric {
-	out := make([]telegraf.Metric, 0, 2*len(in))
+	out := make([]telegraf.Metric, 0, (len(c.Copies)+2)*len(in))
 
 	for _, original := range in {
-		m := original.Copy()
-		if len(c.NameOverride) > 0 {
-			m.SetName(c.NameOverride)
-		}
-		if len(c.NamePrefix) > 0 {
-			m.AddPrefix(c.NamePrefix)
+		// Without any copy section, create a single copy using the global
+		// modifications only
+		if len(c.Copies) == 0 {
+			m := original.Copy()
+			c.modify(m)
+			out = append(out, m)
+			continue
 		}
-		if len(c.NameSuffix) > 0 {
-			m.AddSuffix(c.NameSuffix)
+
+		for _, cs := range c.Copies {
+			if !cs.matches(original) {
+				continue
+			}
+			m := original.Copy()
+			c.modify(m)
+			modify(m, cs.NameOverride, cs.NamePrefix, cs.NameSuffix, cs.Tags)
+			out = append(out, m)
 		}
-		for key, value := range c.Tags {
-			m.AddTag(key, value)
+	}
+
+	if c.DropOriginal {
+		for _, original := range in {
+			original.Drop()
 		}
-		out = append(out, m)
+		return out
 	}
 
 	return append(out, in...)
 }
 
+func (c *Clone) modify(m telegraf.Metric) {
+	modify(m, c.NameOverride, c.NamePrefix, c.NameSuffix, c.Tags)
+}
+
+// matches checks if any of the tagpass filters of the copy matches the
+// metric, similar to the tagpass metric filtering option. A copy without any
+// tagpass filter matches all metrics.
+func (cs *copySpec) matches(m telegraf.Metric) bool {
+	if len(cs.tagFilters) == 0 {
+		return true
+	}
+	for key, f := range cs.tagFilters {
+		if value, found := m.GetTag(key); found && f.Match(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func modify(m telegraf.Metric, nameOverride, namePrefix, nameSuffix string, tags map[string]string) {
+	if len(nameOverride) > 0 {
+		m.SetName(nameOverride)
+	}
+	if len(namePrefix) > 0 {
+		m.AddPrefix(namePrefix)
+	}
+	if len(nameSuffix) > 0 {
+		m.AddSuffix(nameSuffix)
+	}
+	for key, value := range tags {
+		m.AddTag(key, value)
+	}
+}
+
 func init() {
 	processors.Add("clone", func() telegraf.Processor {
 		return &Clone{}
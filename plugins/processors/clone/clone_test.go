@@ -191,6 +191,174 @@ func TestNameSuffix(t *testing.T) {
 	testutil.RequireMetricsEqual(t, expected, actual)
 }
 
+func TestMultipleCopies(t *testing.T) {
+	input := []telegraf.Metric{
+		metric.New(
+			"cpu",
+			map[string]string{"region": "eu-west"},
+			map[string]interface{}{"value": int64(1)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"cpu",
+			map[string]string{"region": "us-east"},
+			map[string]interface{}{"value": int64(2)},
+			time.Unix(0, 0),
+		),
+	}
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"cpu",
+			map[string]string{"region": "eu-west", "tenant": "a", "source": "clone"},
+			map[string]interface{}{"value": int64(1)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"tenant_b",
+			map[string]string{"region": "eu-west", "tenant": "b", "source": "clone"},
+			map[string]interface{}{"value": int64(1)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"cpu",
+			map[string]string{"region": "us-east", "tenant": "a", "source": "clone"},
+			map[string]interface{}{"value": int64(2)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"cpu",
+			map[string]string{"region": "us-east", "tenant": "c", "source": "clone"},
+			map[string]interface{}{"value": int64(2)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"cpu",
+			map[string]string{"region": "eu-west"},
+			map[string]interface{}{"value": int64(1)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"cpu",
+			map[string]string{"region": "us-east"},
+			map[string]interface{}{"value": int64(2)},
+			time.Unix(0, 0),
+		),
+	}
+
+	plugin := &Clone{
+		Tags: map[string]string{"source": "clone"},
+		Copies: []copySpec{
+			{
+				Tags: map[string]string{"tenant": "a"},
+			},
+			{
+				NameOverride: "tenant_b",
+				Tags:         map[string]string{"tenant": "b"},
+				TagPass:      map[string][]string{"region": {"eu-*"}},
+			},
+			{
+				Tags:    map[string]string{"tenant": "c"},
+				TagPass: map[string][]string{"region": {"us-*", "ap-*"}},
+			},
+		},
+	}
+	require.NoError(t, plugin.Init())
+
+	actual := plugin.Apply(input...)
+	testutil.RequireMetricsEqual(t, expected, actual)
+}
+
+func TestInvalidTagPass(t *testing.T) {
+	plugin := &Clone{
+		Copies: []copySpec{
+			{TagPass: map[string][]string{"region": {"eu-["}}},
+		},
+	}
+	require.ErrorContains(t, plugin.Init(), "creating tagpass filter")
+}
+
+func TestDropOriginal(t *testing.T) {
+	inputRaw := []telegraf.Metric{
+		metric.New(
+			"m1",
+			map[string]string{"metric_tag": "from_metric"},
+			map[string]interface{}{"value": int64(1)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"m2",
+			map[string]string{"metric_tag": "foo_metric"},
+			map[string]interface{}{"value": int64(2)},
+			time.Unix(0, 0),
+		),
+	}
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"m1",
+			map[string]string{"metric_tag": "from_metric", "tenant": "a"},
+			map[string]interface{}{"value": int64(1)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"m1",
+			map[string]string{"metric_tag": "from_metric", "tenant": "b"},
+			map[string]interface{}{"value": int64(1)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"m2",
+			map[string]string{"metric_tag": "foo_metric", "tenant": "a"},
+			map[string]interface{}{"value": int64(2)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"m2",
+			map[string]string{"metric_tag": "foo_metric", "tenant": "b"},
+			map[string]interface{}{"value": int64(2)},
+			time.Unix(0, 0),
+		),
+	}
+
+	var mu sync.Mutex
+	delivered := make([]telegraf.DeliveryInfo, 0, len(inputRaw))
+	notify := func(di telegraf.DeliveryInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		delivered = append(delivered, di)
+	}
+	input := make([]telegraf.Metric, 0, len(inputRaw))
+	for _, m := range inputRaw {
+		tm, _ := metric.WithTracking(m, notify)
+		input = append(input, tm)
+	}
+
+	plugin := &Clone{
+		DropOriginal: true,
+		Copies: []copySpec{
+			{Tags: map[string]string{"tenant": "a"}},
+			{Tags: map[string]string{"tenant": "b"}},
+		},
+	}
+	require.NoError(t, plugin.Init())
+
+	actual := plugin.Apply(input...)
+	testutil.RequireMetricsEqual(t, expected, actual)
+
+	// Simulate output acknowledging delivery
+	for _, m := range actual {
+		m.Accept()
+	}
+
+	// Check delivery
+	require.Eventuallyf(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(input) == len(delivered)
+	}, time.Second, 100*time.Millisecond, "%d delivered but %d expected", len(delivered), len(input))
+}
+
 func TestTracking(t *testing.T) {
 	inputRaw := []telegraf.Metric{
 		metric.New(
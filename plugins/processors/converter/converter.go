@@ -4,9 +4,14 @@ package converter
 import (
 	_ "embed"
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/alecthomas/units"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/filter"
@@ -27,6 +32,11 @@ type Conversion struct {
 	Float           []string `toml:"float"`
 	Timestamp       []string `toml:"timestamp"`
 	TimestampFormat string   `toml:"timestamp_format"`
+
+	Duration           []string `toml:"duration"`
+	DurationUnit       string   `toml:"duration_unit"`
+	DurationOutputUnit string   `toml:"duration_output_unit"`
+	Size               []string `toml:"size"`
 }
 
 type Converter struct {
@@ -36,6 +46,11 @@ type Converter struct {
 
 	tagConversions   *ConversionFilter
 	fieldConversions *ConversionFilter
+
+	durationUnit       time.Duration
+	durationOutputUnit time.Duration
+	lastWarning        time.Time
+	suppressedWarnings int
 }
 
 type ConversionFilter struct {
@@ -47,6 +62,20 @@ type ConversionFilter struct {
 	Boolean     filter.Filter
 	Float       filter.Filter
 	Timestamp   filter.Filter
+	Duration    filter.Filter
+	Size        filter.Filter
+}
+
+// Minimum time between two warnings about unparseable duration or size values
+const warningInterval = time.Minute
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
 }
 
 func (*Converter) SampleConfig() string {
@@ -54,6 +83,25 @@ func (*Converter) SampleConfig() string {
 }
 
 func (p *Converter) Init() error {
+	p.durationUnit = time.Second
+	p.durationOutputUnit = time.Second
+	if p.Fields != nil {
+		if p.Fields.DurationUnit != "" {
+			unit, found := durationUnits[p.Fields.DurationUnit]
+			if !found {
+				return fmt.Errorf("invalid duration_unit %q", p.Fields.DurationUnit)
+			}
+			p.durationUnit = unit
+		}
+		if p.Fields.DurationOutputUnit != "" {
+			unit, found := durationUnits[p.Fields.DurationOutputUnit]
+			if !found {
+				return fmt.Errorf("invalid duration_output_unit %q", p.Fields.DurationOutputUnit)
+			}
+			p.durationOutputUnit = unit
+		}
+	}
+
 	return p.compile()
 }
 
@@ -132,6 +180,16 @@ func compileFilter(conv *Conversion) (*ConversionFilter, error) {
 		return nil, err
 	}
 
+	cf.Duration, err = filter.Compile(conv.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	cf.Size, err = filter.Compile(conv.Size)
+	if err != nil {
+		return nil, err
+	}
+
 	return cf, nil
 }
 
@@ -249,8 +307,72 @@ func (p *Converter) convertFields(metric telegraf.Metric) {
 				metric.SetTime(time)
 				metric.RemoveField(key)
 			}
+		case p.fieldConversions.Duration != nil && p.fieldConversions.Duration.Match(key):
+			if v, err := toDuration(value, p.durationUnit); err != nil {
+				p.warnf("Converting field %q to duration [%T] failed: %v", key, value, err)
+			} else {
+				metric.AddField(key, float64(v)/float64(p.durationOutputUnit))
+			}
+		case p.fieldConversions.Size != nil && p.fieldConversions.Size.Match(key):
+			if v, err := toSize(value); err != nil {
+				p.warnf("Converting field %q to size [%T] failed: %v", key, value, err)
+			} else {
+				metric.AddField(key, v)
+			}
+		}
+	}
+}
+
+// warnf logs a warning at most once per warning interval to avoid flooding
+// the log with messages about unparseable values.
+func (p *Converter) warnf(format string, args ...interface{}) {
+	if time.Since(p.lastWarning) < warningInterval {
+		p.suppressedWarnings++
+		return
+	}
+	if p.suppressedWarnings > 0 {
+		format += fmt.Sprintf(" (%d similar warnings suppressed)", p.suppressedWarnings)
+	}
+	p.Log.Warnf(format, args...)
+	p.lastWarning = time.Now()
+	p.suppressedWarnings = 0
+}
+
+// toDuration converts Go duration strings like "1.5s" or bare numbers given
+// in the specified unit to a duration.
+func toDuration(v interface{}, unit time.Duration) (time.Duration, error) {
+	if s, ok := v.(string); ok {
+		s = strings.TrimSpace(s)
+		if d, err := time.ParseDuration(s); err == nil {
+			return d, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
 		}
+		return time.Duration(f * float64(unit)), nil
+	}
+
+	f, err := internal.ToFloat64(v)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(f * float64(unit)), nil
+}
+
+// toSize converts size strings with SI (e.g. "kB") or IEC (e.g. "KiB")
+// suffixes and bare numbers to a number of bytes.
+func toSize(v interface{}) (int64, error) {
+	s, ok := v.(string)
+	if !ok {
+		return toInteger(v)
+	}
+
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
 	}
+	return units.ParseStrictBytes(s)
 }
 
 func toInteger(v interface{}) (int64, error) {
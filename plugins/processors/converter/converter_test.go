@@ -668,6 +668,104 @@ func TestConverter(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "from duration field",
+			converter: &Converter{
+				Fields: &Conversion{
+					Duration:     []string{"d*"},
+					DurationUnit: "ms",
+				},
+			},
+			input: testutil.MustMetric(
+				"cpu",
+				map[string]string{},
+				map[string]interface{}{
+					"d_go":      "1.5s",
+					"d_ms":      "250ms",
+					"d_bare":    "500",
+					"d_numeric": int64(2000),
+					"d_invalid": "forever",
+				},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"cpu",
+					map[string]string{},
+					map[string]interface{}{
+						"d_go":      1.5,
+						"d_ms":      0.25,
+						"d_bare":    0.5,
+						"d_numeric": 2.0,
+						"d_invalid": "forever",
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "from duration field with output unit",
+			converter: &Converter{
+				Fields: &Conversion{
+					Duration:           []string{"latency"},
+					DurationOutputUnit: "ms",
+				},
+			},
+			input: testutil.MustMetric(
+				"cpu",
+				map[string]string{},
+				map[string]interface{}{
+					"latency": "1m2.5s",
+				},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"cpu",
+					map[string]string{},
+					map[string]interface{}{
+						"latency": 62500.0,
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "from size field",
+			converter: &Converter{
+				Fields: &Conversion{
+					Size: []string{"s*"},
+				},
+			},
+			input: testutil.MustMetric(
+				"cpu",
+				map[string]string{},
+				map[string]interface{}{
+					"s_iec":     "3.5GiB",
+					"s_si":      "10kB",
+					"s_space":   "2 MiB",
+					"s_bare":    "1024",
+					"s_numeric": 42.0,
+					"s_invalid": "large",
+				},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"cpu",
+					map[string]string{},
+					map[string]interface{}{
+						"s_iec":     int64(3758096384),
+						"s_si":      int64(10000),
+						"s_space":   int64(2097152),
+						"s_bare":    int64(1024),
+						"s_numeric": int64(42),
+						"s_invalid": "large",
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -839,3 +937,14 @@ func TestTracking(t *testing.T) {
 		return len(input) == len(delivered)
 	}, time.Second, 100*time.Millisecond, "%d delivered but %d expected", len(delivered), len(expected))
 }
+
+func TestInvalidDurationUnit(t *testing.T) {
+	plugin := &Converter{
+		Fields: &Conversion{
+			Duration:     []string{"latency"},
+			DurationUnit: "fortnight",
+		},
+		Log: testutil.Logger{},
+	}
+	require.ErrorContains(t, plugin.Init(), "invalid duration_unit")
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/plugins/processors"
+	"github.com/influxdata/telegraf/selfstat"
 )
 
 //go:embed sample.conf
@@ -23,7 +25,14 @@ type Date struct {
 	DateOffset config.Duration `toml:"date_offset"`
 	Timezone   string          `toml:"timezone"`
 
-	location *time.Location
+	Field        string          `toml:"field"`
+	FieldFormats []string        `toml:"field_formats"`
+	RemoveField  bool            `toml:"remove_field"`
+	Alias        string          `toml:"alias"`
+	Log          telegraf.Logger `toml:"-"`
+
+	location    *time.Location
+	parseErrors selfstat.Stat
 }
 
 func (*Date) SampleConfig() string {
@@ -34,8 +43,20 @@ func (d *Date) Init() error {
 	// Check either TagKey or FieldKey specified
 	if len(d.FieldKey) > 0 && len(d.TagKey) > 0 {
 		return errors.New("field_key and tag_key cannot be specified at the same time")
-	} else if len(d.FieldKey) == 0 && len(d.TagKey) == 0 {
-		return errors.New("at least one of field_key or tag_key must be specified")
+	} else if len(d.FieldKey) == 0 && len(d.TagKey) == 0 && len(d.Field) == 0 {
+		return errors.New("at least one of field, field_key or tag_key must be specified")
+	}
+
+	if len(d.Field) > 0 {
+		if len(d.FieldFormats) == 0 {
+			return errors.New("field_formats required when parsing the time from a field")
+		}
+		// Tag the statistics by alias to distinguish the plugin instances
+		tags := make(map[string]string)
+		if d.Alias != "" {
+			tags["alias"] = d.Alias
+		}
+		d.parseErrors = selfstat.Register("date", "parse_errors", tags)
 	}
 
 	// LoadLocation returns UTC if timezone is the empty string.
@@ -46,6 +67,10 @@ func (d *Date) Init() error {
 
 func (d *Date) Apply(in ...telegraf.Metric) []telegraf.Metric {
 	for _, point := range in {
+		if len(d.Field) > 0 {
+			d.parseTime(point)
+		}
+
 		tm := point.Time().In(d.location).Add(time.Duration(d.DateOffset))
 		if len(d.TagKey) > 0 {
 			point.AddTag(d.TagKey, tm.Format(d.DateFormat))
@@ -68,6 +93,30 @@ func (d *Date) Apply(in ...telegraf.Metric) []telegraf.Metric {
 	return in
 }
 
+// parseTime sets the metric time from the configured field trying the given
+// formats in order. If no format matches, the metric time is left untouched.
+func (d *Date) parseTime(point telegraf.Metric) {
+	value, found := point.GetField(d.Field)
+	if !found {
+		return
+	}
+
+	for _, format := range d.FieldFormats {
+		tm, err := internal.ParseTimestamp(format, value, d.location)
+		if err != nil {
+			continue
+		}
+		point.SetTime(tm)
+		if d.RemoveField {
+			point.RemoveField(d.Field)
+		}
+		return
+	}
+
+	d.parseErrors.Incr(1)
+	d.Log.Debugf("Value %v of field %q does not match any of the given formats", value, d.Field)
+}
+
 func init() {
 	processors.Add("date", func() telegraf.Processor {
 		return &Date{
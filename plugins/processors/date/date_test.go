@@ -292,3 +292,69 @@ func TestTracking(t *testing.T) {
 		return len(input) == len(delivered)
 	}, time.Second, 100*time.Millisecond, "%d delivered but %d expected", len(delivered), len(expected))
 }
+
+func TestParseFieldNoFormats(t *testing.T) {
+	plugin := &Date{
+		Field: "created_at",
+	}
+	require.ErrorContains(t, plugin.Init(), "field_formats required")
+}
+
+func TestParseField(t *testing.T) {
+	now := time.Now()
+	input := []telegraf.Metric{
+		metric.New("foo", map[string]string{}, map[string]interface{}{"created_at": "2023-06-01T12:00:00+02:00", "value": 42}, now),
+		metric.New("bar", map[string]string{}, map[string]interface{}{"created_at": int64(1685620800123), "value": 42}, now),
+		metric.New("baz", map[string]string{}, map[string]interface{}{"created_at": "yesterday", "value": 42}, now),
+		metric.New("qux", map[string]string{}, map[string]interface{}{"value": 42}, now),
+	}
+
+	expected := []telegraf.Metric{
+		metric.New("foo", map[string]string{"year": "2023"}, map[string]interface{}{"value": 42}, time.Unix(1685613600, 0)),
+		metric.New("bar", map[string]string{"year": "2023"}, map[string]interface{}{"value": 42}, time.Unix(1685620800, 123000000)),
+		metric.New("baz", map[string]string{"year": now.UTC().Format("2006")}, map[string]interface{}{"created_at": "yesterday", "value": 42}, now),
+		metric.New("qux", map[string]string{"year": now.UTC().Format("2006")}, map[string]interface{}{"value": 42}, now),
+	}
+
+	plugin := &Date{
+		TagKey:       "year",
+		DateFormat:   "2006",
+		Field:        "created_at",
+		FieldFormats: []string{"2006-01-02T15:04:05Z07:00", "unix_ms"},
+		RemoveField:  true,
+		Log:          testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	errorsBefore := plugin.parseErrors.Get()
+	actual := plugin.Apply(input...)
+	testutil.RequireMetricsEqual(t, expected, actual)
+	require.Equal(t, errorsBefore+1, plugin.parseErrors.Get())
+}
+
+func TestParseFieldStatisticsPerInstance(t *testing.T) {
+	first := &Date{
+		TagKey:       "year",
+		DateFormat:   "2006",
+		Field:        "created_at",
+		FieldFormats: []string{"unix"},
+		Alias:        "first",
+		Log:          testutil.Logger{},
+	}
+	require.NoError(t, first.Init())
+	second := &Date{
+		TagKey:       "year",
+		DateFormat:   "2006",
+		Field:        "created_at",
+		FieldFormats: []string{"unix"},
+		Alias:        "second",
+		Log:          testutil.Logger{},
+	}
+	require.NoError(t, second.Init())
+
+	// Parse errors must only be counted for the instance failing to parse
+	firstBefore, secondBefore := first.parseErrors.Get(), second.parseErrors.Get()
+	first.Apply(metric.New("foo", map[string]string{}, map[string]interface{}{"created_at": "yesterday"}, time.Now()))
+	require.Equal(t, firstBefore+1, first.parseErrors.Get())
+	require.Equal(t, secondBefore, second.parseErrors.Get())
+}
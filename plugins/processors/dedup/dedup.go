@@ -4,10 +4,12 @@ package dedup
 import (
 	_ "embed"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/plugins/parsers/influx"
 	"github.com/influxdata/telegraf/plugins/processors"
 	serializers_influx "github.com/influxdata/telegraf/plugins/serializers/influx"
@@ -17,10 +19,29 @@ import (
 var sampleConfig string
 
 type Dedup struct {
-	DedupInterval config.Duration `toml:"dedup_interval"`
-	FlushTime     time.Time
-	Cache         map[uint64]telegraf.Metric
-	Log           telegraf.Logger `toml:"-"`
+	DedupInterval    config.Duration `toml:"dedup_interval"`
+	FieldsInclude    []string        `toml:"fields_include"`
+	FieldsExclude    []string        `toml:"fields_exclude"`
+	NumericTolerance float64         `toml:"numeric_tolerance"`
+	FlushTime        time.Time
+	Cache            map[uint64]telegraf.Metric
+	Log              telegraf.Logger `toml:"-"`
+
+	fieldFilter filter.Filter
+}
+
+func (d *Dedup) Init() error {
+	if d.NumericTolerance < 0 {
+		return fmt.Errorf("numeric_tolerance must not be negative but is %v", d.NumericTolerance)
+	}
+
+	f, err := filter.NewIncludeExcludeFilter(d.FieldsInclude, d.FieldsExclude)
+	if err != nil {
+		return fmt.Errorf("creating field filter failed: %w", err)
+	}
+	d.fieldFilter = f
+
+	return nil
 }
 
 // Remove expired items from cache
@@ -77,8 +98,12 @@ func (d *Dedup) Apply(metrics ...telegraf.Metric) []telegraf.Metric {
 		added := false
 		sametime := metric.Time() == m.Time()
 		for _, f := range metric.FieldList() {
+			// Only compare the selected fields
+			if d.fieldFilter != nil && !d.fieldFilter.Match(f.Key) {
+				continue
+			}
 			if value, ok := m.GetField(f.Key); ok {
-				if value != f.Value {
+				if !d.equal(value, f.Value) {
 					changed = true
 					break
 				}
@@ -121,6 +146,19 @@ func (d *Dedup) Apply(metrics ...telegraf.Metric) []telegraf.Metric {
 	return metrics
 }
 
+// equal checks if the two field values are equal. Float values are
+// considered equal if their difference is within the numeric tolerance.
+func (d *Dedup) equal(cached, current interface{}) bool {
+	if d.NumericTolerance > 0 {
+		a, aok := cached.(float64)
+		b, bok := current.(float64)
+		if aok && bok {
+			return math.Abs(a-b) <= d.NumericTolerance
+		}
+	}
+	return cached == current
+}
+
 func (d *Dedup) GetState() interface{} {
 	s := &serializers_influx.Serializer{}
 	v := make([]telegraf.Metric, 0, len(d.Cache))
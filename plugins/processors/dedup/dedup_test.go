@@ -530,3 +530,97 @@ func TestStatePersistence(t *testing.T) {
 	}
 	require.Len(t, actualState, expectedLen)
 }
+
+func TestFieldSelection(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		input    []telegraf.Metric
+		expected []telegraf.Metric
+	}{
+		{
+			name:    "include",
+			include: []string{"status"},
+			input: []telegraf.Metric{
+				metric.New("app", map[string]string{}, map[string]interface{}{"status": "ok", "uptime": 10}, now.Add(-3*time.Second)),
+				metric.New("app", map[string]string{}, map[string]interface{}{"status": "ok", "uptime": 20}, now.Add(-2*time.Second)),
+				metric.New("app", map[string]string{}, map[string]interface{}{"status": "failed", "uptime": 30}, now.Add(-1*time.Second)),
+			},
+			expected: []telegraf.Metric{
+				metric.New("app", map[string]string{}, map[string]interface{}{"status": "ok", "uptime": 10}, now.Add(-3*time.Second)),
+				metric.New("app", map[string]string{}, map[string]interface{}{"status": "failed", "uptime": 30}, now.Add(-1*time.Second)),
+			},
+		},
+		{
+			name:    "exclude",
+			exclude: []string{"up*"},
+			input: []telegraf.Metric{
+				metric.New("app", map[string]string{}, map[string]interface{}{"status": "ok", "uptime": 10}, now.Add(-3*time.Second)),
+				metric.New("app", map[string]string{}, map[string]interface{}{"status": "ok", "uptime": 20}, now.Add(-2*time.Second)),
+				metric.New("app", map[string]string{}, map[string]interface{}{"status": "ok", "uptime": 30}, now.Add(-1*time.Second)),
+			},
+			expected: []telegraf.Metric{
+				metric.New("app", map[string]string{}, map[string]interface{}{"status": "ok", "uptime": 10}, now.Add(-3*time.Second)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &Dedup{
+				DedupInterval: config.Duration(10 * time.Minute),
+				FieldsInclude: tt.include,
+				FieldsExclude: tt.exclude,
+				FlushTime:     now.Add(-1 * time.Second),
+				Cache:         make(map[uint64]telegraf.Metric),
+			}
+			require.NoError(t, plugin.Init())
+
+			var actual []telegraf.Metric
+			for _, m := range tt.input {
+				actual = append(actual, plugin.Apply(m)...)
+			}
+			testutil.RequireMetricsEqual(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestNumericTolerance(t *testing.T) {
+	now := time.Now()
+
+	plugin := &Dedup{
+		DedupInterval:    config.Duration(10 * time.Minute),
+		NumericTolerance: 0.5,
+		FlushTime:        now.Add(-1 * time.Second),
+		Cache:            make(map[uint64]telegraf.Metric),
+	}
+	require.NoError(t, plugin.Init())
+
+	// The comparison is done against the last emitted value so slowly
+	// drifting values are emitted once exceeding the tolerance.
+	input := []telegraf.Metric{
+		metric.New("temp", map[string]string{}, map[string]interface{}{"value": 20.0}, now.Add(-4*time.Second)),
+		metric.New("temp", map[string]string{}, map[string]interface{}{"value": 20.3}, now.Add(-3*time.Second)),
+		metric.New("temp", map[string]string{}, map[string]interface{}{"value": 20.5}, now.Add(-2*time.Second)),
+		metric.New("temp", map[string]string{}, map[string]interface{}{"value": 20.6}, now.Add(-1*time.Second)),
+		metric.New("temp", map[string]string{}, map[string]interface{}{"value": 20.9}, now),
+	}
+	expected := []telegraf.Metric{
+		metric.New("temp", map[string]string{}, map[string]interface{}{"value": 20.0}, now.Add(-4*time.Second)),
+		metric.New("temp", map[string]string{}, map[string]interface{}{"value": 20.6}, now.Add(-1*time.Second)),
+	}
+
+	var actual []telegraf.Metric
+	for _, m := range input {
+		actual = append(actual, plugin.Apply(m)...)
+	}
+	testutil.RequireMetricsEqual(t, expected, actual)
+}
+
+func TestNegativeToleranceInitError(t *testing.T) {
+	plugin := &Dedup{NumericTolerance: -1}
+	require.ErrorContains(t, plugin.Init(), "must not be negative")
+}
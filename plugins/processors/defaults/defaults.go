@@ -3,6 +3,7 @@ package defaults
 
 import (
 	_ "embed"
+	"errors"
 	"strings"
 
 	"github.com/influxdata/telegraf"
@@ -12,23 +13,43 @@ import (
 //go:embed sample.conf
 var sampleConfig string
 
-// Defaults is a processor for ensuring certain fields always exist
+// Defaults is a processor for ensuring certain fields and tags always exist
 // on your Metrics with at least a default value.
 type Defaults struct {
 	DefaultFieldsSets map[string]interface{} `toml:"fields"`
+	DefaultTagsSets   map[string]string      `toml:"tags"`
+	OnlyWhenTag       string                 `toml:"only_when_tag"`
+	OnlyWhenValue     string                 `toml:"only_when_value"`
 }
 
 func (*Defaults) SampleConfig() string {
 	return sampleConfig
 }
 
+func (def *Defaults) Init() error {
+	if def.OnlyWhenValue != "" && def.OnlyWhenTag == "" {
+		return errors.New("'only_when_value' requires 'only_when_tag' to be set")
+	}
+	return nil
+}
+
 // Apply contains the main implementation of this processor.
 // For each metric in 'inputMetrics', it goes over each default pair.
-// If the field in the pair does not exist on the metric, the associated default is added.
-// If the field was found, then, if its value is the empty string or one or more spaces, it is replaced
+// If the field or tag in the pair does not exist on the metric, the associated default is added.
+// If the field or tag was found, then, if its value is the empty string or one or more spaces, it is replaced
 // by the associated default.
 func (def *Defaults) Apply(inputMetrics ...telegraf.Metric) []telegraf.Metric {
 	for _, metric := range inputMetrics {
+		if !def.matches(metric) {
+			continue
+		}
+
+		for defTag, defValue := range def.DefaultTagsSets {
+			if current, isSet := metric.GetTag(defTag); !isSet || strings.TrimSpace(current) == "" {
+				metric.AddTag(defTag, defValue)
+			}
+		}
+
 		for defField, defValue := range def.DefaultFieldsSets {
 			if maybeCurrent, isSet := metric.GetField(defField); !isSet {
 				metric.AddField(defField, defValue)
@@ -41,6 +62,18 @@ func (def *Defaults) Apply(inputMetrics ...telegraf.Metric) []telegraf.Metric {
 	return inputMetrics
 }
 
+// matches checks if the defaults should be applied to the given metric
+func (def *Defaults) matches(metric telegraf.Metric) bool {
+	if def.OnlyWhenTag == "" {
+		return true
+	}
+	value, found := metric.GetTag(def.OnlyWhenTag)
+	if !found {
+		return false
+	}
+	return def.OnlyWhenValue == "" || value == def.OnlyWhenValue
+}
+
 func maybeTrimmedString(v interface{}) (string, bool) {
 	if value, ok := v.(string); ok {
 		return strings.TrimSpace(value), true
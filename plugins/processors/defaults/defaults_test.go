@@ -120,11 +120,110 @@ func TestDefaults(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "Tests that missing and blank tags are set on the metric",
+			defaults: &Defaults{
+				DefaultTagsSets: map[string]string{
+					"env":    "unknown",
+					"region": "none",
+					"host":   "localhost",
+				},
+			},
+			input: testutil.MustMetric(
+				"CPU metrics",
+				map[string]string{
+					"region": "  ",
+					"host":   "server01",
+				},
+				map[string]interface{}{"usage": 45},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"CPU metrics",
+					map[string]string{
+						"env":    "unknown",
+						"region": "none",
+						"host":   "server01",
+					},
+					map[string]interface{}{"usage": 45},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "Tests that defaults are applied if the condition matches",
+			defaults: &Defaults{
+				DefaultFieldsSets: map[string]interface{}{"status": "N/A"},
+				DefaultTagsSets:   map[string]string{"env": "unknown"},
+				OnlyWhenTag:       "cluster",
+				OnlyWhenValue:     "prod",
+			},
+			input: testutil.MustMetric(
+				"CPU metrics",
+				map[string]string{"cluster": "prod"},
+				map[string]interface{}{"usage": 45},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"CPU metrics",
+					map[string]string{"cluster": "prod", "env": "unknown"},
+					map[string]interface{}{"usage": 45, "status": "N/A"},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "Tests that defaults are skipped if the condition value does not match",
+			defaults: &Defaults{
+				DefaultFieldsSets: map[string]interface{}{"status": "N/A"},
+				DefaultTagsSets:   map[string]string{"env": "unknown"},
+				OnlyWhenTag:       "cluster",
+				OnlyWhenValue:     "prod",
+			},
+			input: testutil.MustMetric(
+				"CPU metrics",
+				map[string]string{"cluster": "dev"},
+				map[string]interface{}{"usage": 45},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"CPU metrics",
+					map[string]string{"cluster": "dev"},
+					map[string]interface{}{"usage": 45},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "Tests that defaults are skipped if the condition tag is missing",
+			defaults: &Defaults{
+				DefaultTagsSets: map[string]string{"env": "unknown"},
+				OnlyWhenTag:     "cluster",
+			},
+			input: testutil.MustMetric(
+				"CPU metrics",
+				map[string]string{},
+				map[string]interface{}{"usage": 45},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"CPU metrics",
+					map[string]string{},
+					map[string]interface{}{"usage": 45},
+					time.Unix(0, 0),
+				),
+			},
+		},
 	}
 
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
 			defaults := scenario.defaults
+			require.NoError(t, defaults.Init())
 
 			resultMetrics := defaults.Apply(scenario.input)
 			require.Len(t, resultMetrics, 1)
@@ -133,6 +232,11 @@ func TestDefaults(t *testing.T) {
 	}
 }
 
+func TestInitOnlyWhenValueWithoutTag(t *testing.T) {
+	plugin := &Defaults{OnlyWhenValue: "prod"}
+	require.ErrorContains(t, plugin.Init(), "requires 'only_when_tag'")
+}
+
 func TestTracking(t *testing.T) {
 	inputRaw := []telegraf.Metric{
 		metric.New("foo", map[string]string{}, map[string]interface{}{"value": 42, "topic": "telegraf"}, time.Unix(0, 0)),
@@ -4,6 +4,7 @@ package enum
 import (
 	_ "embed"
 	"fmt"
+	"regexp"
 	"strconv"
 
 	"github.com/influxdata/telegraf"
@@ -22,11 +23,24 @@ type EnumMapper struct {
 }
 
 type Mapping struct {
-	Tag           string
-	Field         string
-	Dest          string
-	Default       interface{}
-	ValueMappings map[string]interface{}
+	Tag                string
+	Field              string
+	Dest               string
+	Default            interface{}
+	ValueMappings      map[string]interface{}
+	ValueMappingsRegex []RegexMapping
+
+	regexMappings []compiledRegexMapping
+}
+
+type RegexMapping struct {
+	Pattern string
+	Value   interface{}
+}
+
+type compiledRegexMapping struct {
+	re    *regexp.Regexp
+	value interface{}
 }
 
 func (*EnumMapper) SampleConfig() string {
@@ -36,7 +50,17 @@ func (*EnumMapper) SampleConfig() string {
 func (mapper *EnumMapper) Init() error {
 	mapper.FieldFilters = make(map[string]filter.Filter)
 	mapper.TagFilters = make(map[string]filter.Filter)
-	for _, mapping := range mapper.Mappings {
+	for i := range mapper.Mappings {
+		mapping := &mapper.Mappings[i]
+		mapping.regexMappings = make([]compiledRegexMapping, 0, len(mapping.ValueMappingsRegex))
+		for _, rm := range mapping.ValueMappingsRegex {
+			re, err := regexp.Compile(rm.Pattern)
+			if err != nil {
+				return fmt.Errorf("failed to compile value mapping pattern %q: %w", rm.Pattern, err)
+			}
+			mapping.regexMappings = append(mapping.regexMappings, compiledRegexMapping{re: re, value: rm.Value})
+		}
+
 		if mapping.Field != "" {
 			fieldFilter, err := filter.NewIncludeExcludeFilter([]string{mapping.Field}, nil)
 			if err != nil {
@@ -135,6 +159,11 @@ func (mapping *Mapping) mapValue(original string) (interface{}, bool) {
 	if mapped, found := mapping.ValueMappings[original]; found {
 		return mapped, true
 	}
+	for _, rm := range mapping.regexMappings {
+		if rm.re.MatchString(original) {
+			return rm.value, true
+		}
+	}
 	if mapping.Default != nil {
 		return mapping.Default, true
 	}
@@ -217,3 +217,52 @@ func TestTracking(t *testing.T) {
 		return delivered
 	}, time.Second, 100*time.Millisecond, "no metrics delivered")
 }
+
+func TestRegexMappings(t *testing.T) {
+	mapper := EnumMapper{
+		Mappings: []Mapping{
+			{
+				Field:         "status",
+				Dest:          "severity",
+				Default:       0,
+				ValueMappings: map[string]interface{}{"ERROR: exact": 10},
+				ValueMappingsRegex: []RegexMapping{
+					{Pattern: `^ERROR`, Value: 3},
+					{Pattern: `refused`, Value: 2},
+					{Pattern: `^WARN`, Value: 1},
+				},
+			},
+		},
+	}
+	require.NoError(t, mapper.Init())
+
+	tests := []struct {
+		value    string
+		expected interface{}
+	}{
+		{value: "ERROR: connection refused (attempt 3)", expected: 3},
+		{value: "connection refused", expected: 2},
+		{value: "WARN: retrying", expected: 1},
+		{value: "ERROR: exact", expected: 10},
+		{value: "ok", expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			m := metric.New("m1", map[string]string{}, map[string]interface{}{"status": tt.value}, time.Now())
+			fields := calculateProcessedValues(mapper, m)
+			assertFieldValue(t, tt.expected, "severity", fields)
+		})
+	}
+}
+
+func TestRegexMappingsInvalidPattern(t *testing.T) {
+	mapper := EnumMapper{
+		Mappings: []Mapping{
+			{
+				Field:              "status",
+				ValueMappingsRegex: []RegexMapping{{Pattern: `^ERROR(`, Value: 3}},
+			},
+		},
+	}
+	require.ErrorContains(t, mapper.Init(), `"^ERROR("`)
+}
@@ -0,0 +1,81 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+)
+
+type fieldCondition struct {
+	Field    string          `toml:"field"`
+	Greater  *config.Number  `toml:"gt"`
+	GreaterE *config.Number  `toml:"gte"`
+	Less     *config.Number  `toml:"lt"`
+	LessE    *config.Number  `toml:"lte"`
+	NotEqual *config.Number  `toml:"ne"`
+	Between  []config.Number `toml:"between"`
+}
+
+func (c *fieldCondition) init() error {
+	if c.Field == "" {
+		return errors.New("field required")
+	}
+
+	if c.Greater == nil && c.GreaterE == nil && c.Less == nil && c.LessE == nil && c.NotEqual == nil && c.Between == nil {
+		return fmt.Errorf("no comparison given for field %q", c.Field)
+	}
+
+	if c.Between != nil {
+		if len(c.Between) != 2 {
+			return fmt.Errorf("'between' requires exactly two values but got %d", len(c.Between))
+		}
+		if c.Between[0] > c.Between[1] {
+			return fmt.Errorf("invalid range [%v, %v]", c.Between[0], c.Between[1])
+		}
+	}
+
+	return nil
+}
+
+// matches checks if the field exists, is numeric and satisfies all given
+// comparisons. Missing or non-numeric fields never match.
+func (c *fieldCondition) matches(m telegraf.Metric) bool {
+	raw, found := m.GetField(c.Field)
+	if !found {
+		return false
+	}
+
+	var v config.Number
+	switch value := raw.(type) {
+	case int64:
+		v = config.Number(value)
+	case uint64:
+		v = config.Number(value)
+	case float64:
+		v = config.Number(value)
+	default:
+		return false
+	}
+
+	if c.Greater != nil && v <= *c.Greater {
+		return false
+	}
+	if c.GreaterE != nil && v < *c.GreaterE {
+		return false
+	}
+	if c.Less != nil && v >= *c.Less {
+		return false
+	}
+	if c.LessE != nil && v > *c.LessE {
+		return false
+	}
+	if c.NotEqual != nil && v == *c.NotEqual {
+		return false
+	}
+	if c.Between != nil && (v < c.Between[0] || v > c.Between[1]) {
+		return false
+	}
+	return true
+}
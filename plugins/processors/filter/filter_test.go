@@ -5,7 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/influxdata/toml"
+
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/testutil"
 	"github.com/stretchr/testify/require"
@@ -661,6 +664,151 @@ func TestRuleMultiple(t *testing.T) {
 	testutil.RequireMetricsEqual(t, expected, actual)
 }
 
+func TestFieldConditions(t *testing.T) {
+	zero, limit := config.Number(0), config.Number(1000)
+	tests := []struct {
+		name       string
+		conditions []fieldCondition
+		expected   []string
+	}{
+		{
+			name:       "greater",
+			conditions: []fieldCondition{{Field: "operating_hours", Greater: &limit}},
+			expected:   []string{"machine B", "machine C"},
+		},
+		{
+			name:       "greater or equal",
+			conditions: []fieldCondition{{Field: "operating_hours", GreaterE: &[]config.Number{1009}[0]}},
+			expected:   []string{"machine B", "machine C"},
+		},
+		{
+			name:       "less",
+			conditions: []fieldCondition{{Field: "operating_hours", Less: &[]config.Number{1009}[0]}},
+			expected:   []string{"machine A", "machine D"},
+		},
+		{
+			name:       "less or equal",
+			conditions: []fieldCondition{{Field: "temperature", LessE: &[]config.Number{23.1}[0]}},
+			expected:   []string{"machine A", "machine B"},
+		},
+		{
+			name:       "not equal",
+			conditions: []fieldCondition{{Field: "operating_hours", NotEqual: &[]config.Number{37}[0]}},
+			expected:   []string{"machine B", "machine C", "machine D"},
+		},
+		{
+			name:       "between",
+			conditions: []fieldCondition{{Field: "temperature", Between: []config.Number{20, 40}}},
+			expected:   []string{"machine A", "machine D"},
+		},
+		{
+			name: "multiple conditions",
+			conditions: []fieldCondition{
+				{Field: "temperature", Between: []config.Number{20, 70}},
+				{Field: "operating_hours", Greater: &limit},
+			},
+			expected: []string{"machine C"},
+		},
+		{
+			name:       "missing field",
+			conditions: []fieldCondition{{Field: "pieces", Greater: &zero}},
+			expected:   []string{"machine B"},
+		},
+		{
+			name:       "non-numeric field",
+			conditions: []fieldCondition{{Field: "message", Greater: &zero}},
+			expected:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &Filter{
+				Rules: []rule{
+					{
+						Name:            []string{"*"},
+						FieldConditions: tt.conditions,
+						Action:          "pass",
+					},
+				},
+				DefaultAction: "drop",
+			}
+			require.NoError(t, plugin.Init())
+
+			input := make([]telegraf.Metric, 0, len(testmetrics))
+			for _, m := range testmetrics {
+				input = append(input, m.Copy())
+			}
+			actual := plugin.Apply(input...)
+
+			sources := make([]string, 0, len(actual))
+			for _, m := range actual {
+				source, _ := m.GetTag("source")
+				sources = append(sources, source)
+			}
+			require.ElementsMatch(t, tt.expected, sources)
+		})
+	}
+}
+
+func TestFieldConditionsInvalid(t *testing.T) {
+	zero := config.Number(0)
+	tests := []struct {
+		name      string
+		condition fieldCondition
+		expected  string
+	}{
+		{
+			name:      "missing field",
+			condition: fieldCondition{Greater: &zero},
+			expected:  "field required",
+		},
+		{
+			name:      "missing comparison",
+			condition: fieldCondition{Field: "value"},
+			expected:  "no comparison given",
+		},
+		{
+			name:      "invalid range length",
+			condition: fieldCondition{Field: "value", Between: []config.Number{1}},
+			expected:  "requires exactly two values",
+		},
+		{
+			name:      "invalid range order",
+			condition: fieldCondition{Field: "value", Between: []config.Number{2, 1}},
+			expected:  "invalid range",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &Filter{
+				Rules: []rule{{FieldConditions: []fieldCondition{tt.condition}}},
+			}
+			require.ErrorContains(t, plugin.Init(), tt.expected)
+		})
+	}
+}
+
+func TestFieldConditionsConfig(t *testing.T) {
+	cfg := `
+[[rule]]
+  action = "pass"
+  [[rule.field_condition]]
+    field = "response_time_ms"
+    gt = 0
+    between = [1.0, 2.5]
+`
+	var plugin Filter
+	require.NoError(t, toml.Unmarshal([]byte(cfg), &plugin))
+	require.Len(t, plugin.Rules, 1)
+	require.Len(t, plugin.Rules[0].FieldConditions, 1)
+	condition := plugin.Rules[0].FieldConditions[0]
+	require.NotNil(t, condition.Greater)
+	require.Zero(t, *condition.Greater)
+	require.Equal(t, []config.Number{1.0, 2.5}, condition.Between)
+}
+
 func TestTracking(t *testing.T) {
 	inputRaw := testmetrics
 
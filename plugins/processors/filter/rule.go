@@ -8,10 +8,11 @@ import (
 )
 
 type rule struct {
-	Name   []string            `toml:"name"`
-	Tags   map[string][]string `toml:"tags"`
-	Fields []string            `toml:"fields"`
-	Action string              `toml:"action"`
+	Name            []string            `toml:"name"`
+	Tags            map[string][]string `toml:"tags"`
+	Fields          []string            `toml:"fields"`
+	FieldConditions []fieldCondition    `toml:"field_condition"`
+	Action          string              `toml:"action"`
 
 	nameFilter  filter.Filter
 	fieldFilter filter.Filter
@@ -50,6 +51,12 @@ func (r *rule) init() error {
 		}
 	}
 
+	for i := range r.FieldConditions {
+		if err := r.FieldConditions[i].init(); err != nil {
+			return fmt.Errorf("field condition %d invalid: %w", i+1, err)
+		}
+	}
+
 	return nil
 }
 
@@ -83,5 +90,12 @@ func (r *rule) apply(m telegraf.Metric) (pass, applies bool) {
 		}
 	}
 
+	// Check the field conditions
+	for _, c := range r.FieldConditions {
+		if !c.matches(m) {
+			return true, false
+		}
+	}
+
 	return r.pass, true
 }
@@ -81,3 +81,12 @@ func (c *LRUCache) Delete(key keyType) {
 		delete(c.m, key)
 	}
 }
+
+// Pairs returns all entries starting with the least recently used one
+func (c *LRUCache) Pairs() []Pair {
+	pairs := make([]Pair, 0, c.l.Len())
+	for node := c.l.Back(); node != nil; node = node.Prev() {
+		pairs = append(pairs, node.Value.(*list.Element).Value.(Pair))
+	}
+	return pairs
+}
@@ -39,6 +39,9 @@ type IfName struct {
 	Ordered            bool            `toml:"ordered"`
 	CacheTTL           config.Duration `toml:"cache_ttl"`
 
+	CacheFile               string          `toml:"cache_file"`
+	CacheCheckpointInterval config.Duration `toml:"cache_checkpoint_interval"`
+
 	Log telegraf.Logger `toml:"-"`
 
 	ifTable  *snmp.Table
@@ -49,6 +52,13 @@ type IfName struct {
 	parallel parallel.Parallel
 	sigs     sigMap
 
+	// outdated entries restored from a persisted cache
+	stale      map[string]TTLValType
+	refreshing map[string]bool
+
+	done chan struct{}
+	wg   sync.WaitGroup
+
 	getMapRemote mapFunc
 }
 
@@ -65,6 +75,12 @@ func (d *IfName) Init() error {
 	d.cache = &c
 
 	d.sigs = make(sigMap)
+	d.stale = make(map[string]TTLValType)
+	d.refreshing = make(map[string]bool)
+
+	if d.CacheFile != "" && d.CacheCheckpointInterval <= 0 {
+		return errors.New("cache_checkpoint_interval must be positive")
+	}
 
 	if _, err := snmp.NewWrapper(d.ClientConfig); err != nil {
 		return fmt.Errorf("parsing SNMP client config: %w", err)
@@ -149,6 +165,13 @@ func (d *IfName) Start(acc telegraf.Accumulator) error {
 		return []telegraf.Metric{m}
 	}
 
+	d.done = make(chan struct{})
+	if d.CacheFile != "" {
+		d.loadCacheFile()
+		d.wg.Add(1)
+		go d.checkpoint()
+	}
+
 	if d.Ordered {
 		d.parallel = parallel.NewOrdered(acc, fn, 10000, d.MaxParallelLookups)
 	} else {
@@ -164,6 +187,16 @@ func (d *IfName) Add(metric telegraf.Metric, _ telegraf.Accumulator) error {
 
 func (d *IfName) Stop() {
 	d.parallel.Stop()
+
+	// Stop the checkpointing and wait for background refreshes to finish
+	close(d.done)
+	d.wg.Wait()
+
+	if d.CacheFile != "" {
+		if err := d.writeCacheFile(); err != nil {
+			d.Log.Errorf("Writing cache failed: %v", err)
+		}
+	}
 }
 
 // getMap gets the interface names map either from cache or from the SNMP
@@ -180,6 +213,17 @@ func (d *IfName) getMap(agent string) (entry nameMap, age time.Duration, err err
 		return m, age, nil
 	}
 
+	// Use outdated restored entries while refreshing them in the background
+	if entry, found := d.stale[agent]; found {
+		if !d.refreshing[agent] {
+			d.refreshing[agent] = true
+			d.wg.Add(1)
+			go d.refresh(agent)
+		}
+		d.lock.Unlock()
+		return entry.val, time.Since(entry.time), nil
+	}
+
 	// cache miss.  Is this the first request for this agent?
 	sig, found := d.sigs[agent]
 	if !found {
@@ -267,6 +311,8 @@ func init() {
 			MaxParallelLookups: 100,
 			ClientConfig:       *snmp.DefaultClientConfig(),
 			CacheTTL:           config.Duration(8 * time.Hour),
+
+			CacheCheckpointInterval: config.Duration(5 * time.Minute),
 		}
 	})
 }
@@ -0,0 +1,152 @@
+package ifname
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// cacheEntry is the serializable form of the interface names of an agent
+type cacheEntry struct {
+	Names     nameMap   `json:"names"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// GetState returns the cached interface names to be persisted
+func (d *IfName) GetState() interface{} {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	state := make(map[string]cacheEntry)
+	for agent, entry := range d.stale {
+		state[agent] = cacheEntry{Names: entry.val, Timestamp: entry.time}
+	}
+	for _, pair := range d.cache.lru.Pairs() {
+		state[pair.key] = cacheEntry{Names: pair.value.val, Timestamp: pair.value.time}
+	}
+	return state
+}
+
+// SetState restores the cached interface names. Outdated entries are kept
+// for use until they are refreshed in the background.
+func (d *IfName) SetState(state interface{}) error {
+	entries, ok := state.(map[string]cacheEntry)
+	if !ok {
+		return fmt.Errorf("invalid state type %T", state)
+	}
+	d.restore(entries)
+	return nil
+}
+
+func (d *IfName) restore(entries map[string]cacheEntry) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for agent, entry := range entries {
+		if entry.Names == nil {
+			continue
+		}
+		if d.cache.Expired(entry.Timestamp) {
+			d.stale[agent] = TTLValType{val: entry.Names, time: entry.Timestamp}
+			continue
+		}
+		d.cache.PutAt(agent, entry.Names, entry.Timestamp)
+	}
+}
+
+// loadCacheFile restores the cache from the configured file. A missing file
+// is not an error, a corrupted file results in an empty cache.
+func (d *IfName) loadCacheFile() {
+	buf, err := os.ReadFile(d.CacheFile)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			d.Log.Warnf("Reading cache file %q failed, starting with empty cache: %v", d.CacheFile, err)
+		}
+		return
+	}
+
+	var entries map[string]cacheEntry
+	if err := json.Unmarshal(buf, &entries); err != nil {
+		d.Log.Warnf("Cache file %q is corrupted, starting with empty cache: %v", d.CacheFile, err)
+		return
+	}
+	d.restore(entries)
+}
+
+// writeCacheFile stores the cache to the configured file. The file is
+// replaced atomically to not leave a partial file on errors.
+func (d *IfName) writeCacheFile() error {
+	buf, err := json.Marshal(d.GetState())
+	if err != nil {
+		return fmt.Errorf("serializing cache failed: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(d.CacheFile), filepath.Base(d.CacheFile)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary cache file failed: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return fmt.Errorf("writing cache file failed: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing cache file failed: %w", err)
+	}
+	return os.Rename(f.Name(), d.CacheFile)
+}
+
+// checkpoint periodically writes the cache file until stopped
+func (d *IfName) checkpoint() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(time.Duration(d.CacheCheckpointInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-ticker.C:
+			if err := d.writeCacheFile(); err != nil {
+				d.Log.Errorf("Checkpointing cache failed: %v", err)
+			}
+		}
+	}
+}
+
+// refresh retrieves the interface names of an outdated entry in the
+// background. The result is dropped if the processor is stopping to not
+// modify the cache after writing the cache file.
+func (d *IfName) refresh(agent string) {
+	defer d.wg.Done()
+
+	select {
+	case <-d.done:
+		return
+	default:
+	}
+
+	m, err := d.getMapRemote(agent)
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	select {
+	case <-d.done:
+		return
+	default:
+	}
+
+	delete(d.refreshing, agent)
+	delete(d.stale, agent)
+	if err != nil {
+		d.Log.Debugf("Refreshing interface names for %s failed: %v", agent, err)
+		return
+	}
+	d.cache.Put(agent, m)
+}
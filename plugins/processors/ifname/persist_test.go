@@ -0,0 +1,180 @@
+package ifname
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestCacheFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "ifname.json")
+
+	// Fill the cache and persist it on shutdown
+	plugin := &IfName{
+		CacheSize:               1000,
+		CacheTTL:                config.Duration(time.Hour),
+		CacheFile:               filename,
+		CacheCheckpointInterval: config.Duration(time.Hour),
+		Log:                     testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+	plugin.getMapRemote = func(string) (nameMap, error) {
+		return nameMap{1: "eth0", 2: "eth1"}, nil
+	}
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Start(&acc))
+	_, _, err := plugin.getMap("agent1")
+	require.NoError(t, err)
+	plugin.Stop()
+	require.FileExists(t, filename)
+
+	// Restarting should use the persisted entries without remote calls
+	restarted := &IfName{
+		CacheSize:               1000,
+		CacheTTL:                config.Duration(time.Hour),
+		CacheFile:               filename,
+		CacheCheckpointInterval: config.Duration(time.Hour),
+		Log:                     testutil.Logger{},
+	}
+	require.NoError(t, restarted.Init())
+	restarted.getMapRemote = func(string) (nameMap, error) {
+		require.FailNow(t, "unexpected remote call")
+		return nil, nil
+	}
+	require.NoError(t, restarted.Start(&acc))
+	defer restarted.Stop()
+
+	m, age, err := restarted.getMap("agent1")
+	require.NoError(t, err)
+	require.NotZero(t, age)
+	require.Equal(t, nameMap{1: "eth0", 2: "eth1"}, m)
+}
+
+func TestCacheFileCorrupted(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "ifname.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"agent1": {"names": `), 0640))
+
+	logger := &testutil.CaptureLogger{}
+	plugin := &IfName{
+		CacheSize:               1000,
+		CacheTTL:                config.Duration(time.Hour),
+		CacheFile:               filename,
+		CacheCheckpointInterval: config.Duration(time.Hour),
+		Log:                     logger,
+	}
+	require.NoError(t, plugin.Init())
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Start(&acc))
+	defer plugin.Stop()
+
+	require.Len(t, logger.Warnings(), 1)
+	require.Contains(t, logger.Warnings()[0], "corrupted")
+	require.Empty(t, plugin.GetState())
+}
+
+func TestStaleEntriesRefresh(t *testing.T) {
+	plugin := &IfName{
+		CacheSize: 1000,
+		CacheTTL:  config.Duration(time.Hour),
+		Log:       testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var remoteCalls int32
+	plugin.getMapRemote = func(string) (nameMap, error) {
+		atomic.AddInt32(&remoteCalls, 1)
+		return nameMap{1: "new"}, nil
+	}
+
+	state := map[string]cacheEntry{
+		"agent1": {Names: nameMap{1: "old"}, Timestamp: time.Now().Add(-2 * time.Hour)},
+		"agent2": {Names: nameMap{1: "fresh"}, Timestamp: time.Now().Add(-time.Minute)},
+	}
+	require.NoError(t, plugin.SetState(state))
+
+	// Fresh entries are used as is
+	m, _, err := plugin.getMap("agent2")
+	require.NoError(t, err)
+	require.Equal(t, nameMap{1: "fresh"}, m)
+
+	// Outdated entries are used while being refreshed in the background
+	m, _, err = plugin.getMap("agent1")
+	require.NoError(t, err)
+	require.Equal(t, nameMap{1: "old"}, m)
+	require.Eventually(t, func() bool {
+		m, _, err := plugin.getMap("agent1")
+		return err == nil && m[1] == "new"
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(1), atomic.LoadInt32(&remoteCalls))
+}
+
+func TestStopWaitsForRefresh(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "ifname.json")
+	plugin := &IfName{
+		CacheSize:               1000,
+		CacheTTL:                config.Duration(time.Hour),
+		CacheFile:               filename,
+		CacheCheckpointInterval: config.Duration(time.Hour),
+		Log:                     testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	plugin.getMapRemote = func(string) (nameMap, error) {
+		close(started)
+		<-release
+		return nameMap{1: "new"}, nil
+	}
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Start(&acc))
+	state := map[string]cacheEntry{
+		"agent1": {Names: nameMap{1: "old"}, Timestamp: time.Now().Add(-2 * time.Hour)},
+	}
+	require.NoError(t, plugin.SetState(state))
+
+	// Trigger the background refresh of the outdated entry
+	m, _, err := plugin.getMap("agent1")
+	require.NoError(t, err)
+	require.Equal(t, nameMap{1: "old"}, m)
+	<-started
+
+	// Stopping must wait for the refresh to finish
+	var stopped atomic.Bool
+	go func() {
+		plugin.Stop()
+		stopped.Store(true)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	require.False(t, stopped.Load())
+	close(release)
+	require.Eventually(t, stopped.Load, time.Second, 10*time.Millisecond)
+
+	// The refreshed entry must not be applied after stopping
+	buf, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Contains(t, string(buf), "old")
+	require.NotContains(t, string(buf), "new")
+}
+
+func TestStateRoundtrip(t *testing.T) {
+	plugin := &IfName{
+		CacheSize: 1000,
+		CacheTTL:  config.Duration(time.Hour),
+	}
+	require.NoError(t, plugin.Init())
+	ts := time.Now().Add(-time.Minute)
+	plugin.cache.PutAt("agent1", nameMap{1: "eth0"}, ts)
+
+	state, ok := plugin.GetState().(map[string]cacheEntry)
+	require.True(t, ok)
+	require.Equal(t, map[string]cacheEntry{"agent1": {Names: nameMap{1: "eth0"}, Timestamp: ts}}, state)
+}
@@ -50,13 +50,24 @@ func (c *TTLCache) Get(key keyType) (valType, bool, time.Duration) {
 }
 
 func (c *TTLCache) Put(key keyType, value valType) {
+	c.PutAt(key, value, c.now())
+}
+
+// PutAt adds an entry with the given time of creation, e.g. for restoring
+// previously cached entries
+func (c *TTLCache) PutAt(key keyType, value valType, t time.Time) {
 	v := TTLValType{
 		val:  value,
-		time: c.now(),
+		time: t,
 	}
 	c.lru.Put(key, v)
 }
 
+// Expired checks if an entry created at the given time is outdated
+func (c *TTLCache) Expired(t time.Time) bool {
+	return c.now().Sub(t) >= c.validDuration
+}
+
 func (c *TTLCache) Delete(key keyType) {
 	c.lru.Delete(key)
 }
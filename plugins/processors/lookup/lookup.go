@@ -12,8 +12,10 @@ import (
 	"os"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/plugins/processors"
 )
 
@@ -21,13 +23,18 @@ import (
 var sampleConfig string
 
 type Processor struct {
-	Filenames   []string        `toml:"files"`
-	Fileformat  string          `toml:"format"`
-	KeyTemplate string          `toml:"key"`
-	Log         telegraf.Logger `toml:"-"`
+	Filenames       []string          `toml:"files"`
+	Fileformat      string            `toml:"format"`
+	KeyTemplate     string            `toml:"key"`
+	RefreshInterval config.Duration   `toml:"refresh_interval"`
+	DefaultTags     map[string]string `toml:"default_tags"`
+	Log             telegraf.Logger   `toml:"-"`
 
-	tmpl     *template.Template
-	mappings map[string][]telegraf.Tag
+	tmpl        *template.Template
+	load        func(mappings map[string][]telegraf.Tag) error
+	mappings    map[string][]telegraf.Tag
+	modified    map[string]time.Time
+	lastRefresh time.Time
 }
 
 func (*Processor) SampleConfig() string {
@@ -49,20 +56,38 @@ func (p *Processor) Init() error {
 	}
 	p.tmpl = tmpl
 
-	p.mappings = make(map[string][]telegraf.Tag)
 	switch strings.ToLower(p.Fileformat) {
 	case "", "json":
-		return p.loadJSONFiles()
+		p.load = p.loadJSONFiles
 	case "csv_key_name_value":
-		return p.loadCSVKeyNameValueFiles()
+		p.load = p.loadCSVKeyNameValueFiles
 	case "csv_key_values":
-		return p.loadCSVKeyValuesFiles()
+		p.load = p.loadCSVKeyValuesFiles
+	default:
+		return fmt.Errorf("invalid format %q", p.Fileformat)
 	}
 
-	return fmt.Errorf("invalid format %q", p.Fileformat)
+	modified, err := p.modificationTimes()
+	if err != nil {
+		return err
+	}
+
+	mappings := make(map[string][]telegraf.Tag)
+	if err := p.load(mappings); err != nil {
+		return err
+	}
+	p.mappings = mappings
+	p.modified = modified
+	p.lastRefresh = time.Now()
+
+	return nil
 }
 
 func (p *Processor) Apply(in ...telegraf.Metric) []telegraf.Metric {
+	if p.RefreshInterval > 0 && time.Since(p.lastRefresh) >= time.Duration(p.RefreshInterval) {
+		p.refresh()
+	}
+
 	out := make([]telegraf.Metric, 0, len(in))
 	for _, raw := range in {
 		m := raw
@@ -78,13 +103,73 @@ func (p *Processor) Apply(in ...telegraf.Metric) []telegraf.Metric {
 			for _, tag := range tags {
 				m.AddTag(tag.Key, tag.Value)
 			}
+		} else {
+			for k, v := range p.DefaultTags {
+				m.AddTag(k, v)
+			}
 		}
 		out = append(out, raw)
 	}
 	return out
 }
 
-func (p *Processor) loadJSONFiles() error {
+// refresh reloads the lookup files if any of them changed since the last
+// load. In case of errors the current mappings are kept.
+func (p *Processor) refresh() {
+	p.lastRefresh = time.Now()
+
+	modified, err := p.modificationTimes()
+	if err != nil {
+		p.Log.Errorf("Checking files failed, keeping current mappings: %v", err)
+		return
+	}
+	changed := len(modified) != len(p.modified)
+	for fn, mtime := range modified {
+		if !p.modified[fn].Equal(mtime) {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return
+	}
+
+	mappings := make(map[string][]telegraf.Tag)
+	if err := p.load(mappings); err != nil {
+		p.Log.Errorf("Reloading files failed, keeping current mappings: %v", err)
+		return
+	}
+
+	var added, removed int
+	for key := range mappings {
+		if _, found := p.mappings[key]; !found {
+			added++
+		}
+	}
+	for key := range p.mappings {
+		if _, found := mappings[key]; !found {
+			removed++
+		}
+	}
+	p.Log.Infof("Reloaded %d keys, %d added and %d removed", len(mappings), added, removed)
+
+	p.mappings = mappings
+	p.modified = modified
+}
+
+func (p *Processor) modificationTimes() (map[string]time.Time, error) {
+	modified := make(map[string]time.Time, len(p.Filenames))
+	for _, fn := range p.Filenames {
+		info, err := os.Stat(fn)
+		if err != nil {
+			return nil, fmt.Errorf("loading %q failed: %w", fn, err)
+		}
+		modified[fn] = info.ModTime()
+	}
+	return modified, nil
+}
+
+func (p *Processor) loadJSONFiles(mappings map[string][]telegraf.Tag) error {
 	for _, fn := range p.Filenames {
 		buf, err := os.ReadFile(fn)
 		if err != nil {
@@ -98,23 +183,23 @@ func (p *Processor) loadJSONFiles() error {
 
 		for key, tags := range data {
 			for k, v := range tags {
-				p.mappings[key] = append(p.mappings[key], telegraf.Tag{Key: k, Value: v})
+				mappings[key] = append(mappings[key], telegraf.Tag{Key: k, Value: v})
 			}
 		}
 	}
 	return nil
 }
 
-func (p *Processor) loadCSVKeyNameValueFiles() error {
+func (p *Processor) loadCSVKeyNameValueFiles(mappings map[string][]telegraf.Tag) error {
 	for _, fn := range p.Filenames {
-		if err := p.loadCSVKeyNameValueFile(fn); err != nil {
+		if err := loadCSVKeyNameValueFile(fn, mappings); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (p *Processor) loadCSVKeyNameValueFile(fn string) error {
+func loadCSVKeyNameValueFile(fn string, mappings map[string][]telegraf.Tag) error {
 	f, err := os.Open(fn)
 	if err != nil {
 		return fmt.Errorf("loading %q failed: %w", fn, err)
@@ -146,23 +231,23 @@ func (p *Processor) loadCSVKeyNameValueFile(fn string) error {
 		key := data[0]
 		for i := 1; i < len(data)-1; i += 2 {
 			k, v := data[i], data[i+1]
-			p.mappings[key] = append(p.mappings[key], telegraf.Tag{Key: k, Value: v})
+			mappings[key] = append(mappings[key], telegraf.Tag{Key: k, Value: v})
 		}
 	}
 
 	return nil
 }
 
-func (p *Processor) loadCSVKeyValuesFiles() error {
+func (p *Processor) loadCSVKeyValuesFiles(mappings map[string][]telegraf.Tag) error {
 	for _, fn := range p.Filenames {
-		if err := p.loadCSVKeyValuesFile(fn); err != nil {
+		if err := loadCSVKeyValuesFile(fn, mappings); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (p *Processor) loadCSVKeyValuesFile(fn string) error {
+func loadCSVKeyValuesFile(fn string, mappings map[string][]telegraf.Tag) error {
 	f, err := os.Open(fn)
 	if err != nil {
 		return fmt.Errorf("loading %q failed: %w", fn, err)
@@ -201,7 +286,7 @@ func (p *Processor) loadCSVKeyValuesFile(fn string) error {
 		for i, v := range data[1:] {
 			v = strings.TrimSpace(v)
 			if v != "" {
-				p.mappings[key] = append(p.mappings[key], telegraf.Tag{Key: header[i], Value: v})
+				mappings[key] = append(mappings[key], telegraf.Tag{Key: header[i], Value: v})
 			}
 		}
 	}
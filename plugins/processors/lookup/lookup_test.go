@@ -175,3 +175,41 @@ func TestCasesTracking(t *testing.T) {
 		})
 	}
 }
+
+func TestRefresh(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "lut.json")
+	require.NoError(t, os.WriteFile(fn, []byte(`{"dev1": {"site": "berlin"}}`), 0600))
+
+	plugin := &Processor{
+		Filenames:       []string{fn},
+		KeyTemplate:     `{{.Tag "device"}}`,
+		RefreshInterval: config.Duration(time.Nanosecond),
+		Log:             testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	input := metric.New("test", map[string]string{"device": "dev1"}, map[string]interface{}{"value": 42}, time.Unix(0, 0))
+	expected := []telegraf.Metric{
+		metric.New("test", map[string]string{"device": "dev1", "site": "berlin"}, map[string]interface{}{"value": 42}, time.Unix(0, 0)),
+	}
+	testutil.RequireMetricsEqual(t, expected, plugin.Apply(input.Copy()))
+
+	// Update the file and make sure the modification time changes
+	require.NoError(t, os.WriteFile(fn, []byte(`{"dev1": {"site": "paris"}, "dev2": {"site": "rome"}}`), 0600))
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(fn, future, future))
+
+	expected = []telegraf.Metric{
+		metric.New("test", map[string]string{"device": "dev1", "site": "paris"}, map[string]interface{}{"value": 42}, time.Unix(0, 0)),
+	}
+	testutil.RequireMetricsEqual(t, expected, plugin.Apply(input.Copy()))
+	require.Len(t, plugin.mappings, 2)
+
+	// Break the file and make sure the previous mappings are kept
+	require.NoError(t, os.WriteFile(fn, []byte(`{"dev1": `), 0600))
+	future = future.Add(time.Minute)
+	require.NoError(t, os.Chtimes(fn, future, future))
+
+	testutil.RequireMetricsEqual(t, expected, plugin.Apply(input.Copy()))
+	require.Len(t, plugin.mappings, 2)
+}
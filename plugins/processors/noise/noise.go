@@ -3,6 +3,7 @@ package noise
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
@@ -27,9 +28,12 @@ const (
 
 type Noise struct {
 	Scale         float64         `toml:"scale"`
+	RelativeScale float64         `toml:"relative_scale"`
+	MinScale      float64         `toml:"min_scale"`
 	Min           float64         `toml:"min"`
 	Max           float64         `toml:"max"`
 	Mu            float64         `toml:"mu"`
+	ClampZero     bool            `toml:"clamp_zero"`
 	IncludeFields []string        `toml:"include_fields"`
 	ExcludeFields []string        `toml:"exclude_fields"`
 	NoiseType     string          `toml:"type"`
@@ -38,17 +42,30 @@ type Noise struct {
 	fieldFilter   filter.Filter
 }
 
+// generates a random noise value for the given value. For relative scaling
+// the generator follows the standard distribution and is scaled by the given
+// percentage of the value, but at least by the minimum scale.
+func (p *Noise) noise(value float64) float64 {
+	if p.RelativeScale == 0 {
+		return p.generator.Rand()
+	}
+	scale := math.Max(math.Abs(value)*p.RelativeScale/100, p.MinScale)
+	return p.Mu + scale*p.generator.Rand()
+}
+
 // generates a random noise value depending on the defined probability density
-// function and adds that to the original value. If any integer overflows
-// happen during the calculation, the result is set to MaxInt or 0 (for uint)
+// function and adds that to the original value. Integer results are rounded.
+// If any integer overflows happen during the calculation, the result is set to
+// MaxInt or 0 (for uint). With clamp_zero enabled, values changing their sign
+// are set to zero.
 func (p *Noise) addNoise(value interface{}) interface{} {
-	n := p.generator.Rand()
 	switch v := value.(type) {
 	case int:
 	case int8:
 	case int16:
 	case int32:
 	case int64:
+		n := math.Round(float64(v)+p.noise(float64(v))) - float64(v)
 		if v > 0 && (n > math.Nextafter(float64(math.MaxInt64), 0) || int64(n) > math.MaxInt64-v) {
 			p.Log.Debug("Int64 overflow, setting value to MaxInt64")
 			return int64(math.MaxInt64)
@@ -57,12 +74,17 @@ func (p *Noise) addNoise(value interface{}) interface{} {
 			p.Log.Debug("Int64 (negative) overflow, setting value to MinInt64")
 			return int64(math.MinInt64)
 		}
-		return v + int64(n)
+		result := v + int64(n)
+		if p.ClampZero && (v > 0 && result < 0 || v < 0 && result > 0) {
+			return int64(0)
+		}
+		return result
 	case uint:
 	case uint8:
 	case uint16:
 	case uint32:
 	case uint64:
+		n := math.Round(float64(v)+p.noise(float64(v))) - float64(v)
 		if n < 0 {
 			if uint64(-n) > v {
 				p.Log.Debug("Uint64 (negative) overflow, setting value to 0")
@@ -76,9 +98,17 @@ func (p *Noise) addNoise(value interface{}) interface{} {
 		}
 		return v + uint64(n)
 	case float32:
-		return v + float32(n)
+		result := v + float32(p.noise(float64(v)))
+		if p.ClampZero && (v > 0 && result < 0 || v < 0 && result > 0) {
+			return float32(0)
+		}
+		return result
 	case float64:
-		return v + n
+		result := v + p.noise(v)
+		if p.ClampZero && (v > 0 && result < 0 || v < 0 && result > 0) {
+			return float64(0)
+		}
+		return result
 	default:
 		p.Log.Debugf("Value (%v) type invalid: [%v] is not an int, uint or float", v, reflect.TypeOf(value))
 	}
@@ -98,13 +128,30 @@ func (p *Noise) Init() error {
 	}
 	p.fieldFilter = fieldFilter
 
+	if p.RelativeScale < 0 {
+		return fmt.Errorf("invalid relative scale %f", p.RelativeScale)
+	}
+	if p.MinScale < 0 {
+		return fmt.Errorf("invalid minimum scale %f", p.MinScale)
+	}
+
+	// For relative scaling use the standard distribution and scale the
+	// generated noise depending on the field value
+	mu, scale := p.Mu, p.Scale
+	if p.RelativeScale > 0 {
+		mu, scale = 0, 1
+	}
+
 	switch p.NoiseType {
 	case "", "laplacian":
-		p.generator = &distuv.Laplace{Mu: p.Mu, Scale: p.Scale}
+		p.generator = &distuv.Laplace{Mu: mu, Scale: scale}
 	case "uniform":
+		if p.RelativeScale > 0 {
+			return errors.New("relative scale is not supported for uniform distribution")
+		}
 		p.generator = &distuv.Uniform{Min: p.Min, Max: p.Max}
 	case "gaussian":
-		p.generator = &distuv.Normal{Mu: p.Mu, Sigma: p.Scale}
+		p.generator = &distuv.Normal{Mu: mu, Sigma: scale}
 	default:
 		return fmt.Errorf("unknown distribution type %q", p.NoiseType)
 	}
@@ -72,7 +72,7 @@ func TestAddNoise(t *testing.T) {
 				),
 				testutil.MustMetric("cpu",
 					map[string]string{},
-					map[string]interface{}{"value": int64(-11)},
+					map[string]interface{}{"value": int64(-12)},
 					time.Unix(0, 0),
 				),
 			},
@@ -95,12 +95,12 @@ func TestAddNoise(t *testing.T) {
 			expected: []telegraf.Metric{
 				testutil.MustMetric("cpu",
 					map[string]string{},
-					map[string]interface{}{"value": uint64(26)},
+					map[string]interface{}{"value": uint64(27)},
 					time.Unix(0, 0),
 				),
 				testutil.MustMetric("cpu",
 					map[string]string{},
-					map[string]interface{}{"value": uint64(1)},
+					map[string]interface{}{"value": uint64(2)},
 					time.Unix(0, 0),
 				),
 			},
@@ -368,6 +368,114 @@ func TestAddNoiseWithZeroValue(t *testing.T) {
 	}
 }
 
+// Verifies that relative noise is centered around the original value and its
+// spread is proportional to the value
+func TestRelativeScale(t *testing.T) {
+	for _, generator := range []string{"laplacian", "gaussian"} {
+		t.Run(generator, func(t *testing.T) {
+			plugin := Noise{
+				NoiseType:     generator,
+				RelativeScale: 1.0,
+				MinScale:      0.5,
+				Log:           testutil.Logger{},
+			}
+			require.NoError(t, plugin.Init())
+
+			const samples = 10000
+			for _, value := range []float64{1000.0, 0.0} {
+				var sum, sumSquared float64
+				for i := 0; i < samples; i++ {
+					m := metric.New("test", map[string]string{}, map[string]interface{}{"value": value}, time.Unix(0, 0))
+					plugin.Apply(m)
+					v, ok := m.GetField("value")
+					require.True(t, ok)
+					diff := v.(float64) - value
+					sum += diff
+					sumSquared += diff * diff
+				}
+				mean := sum / samples
+				stddev := math.Sqrt(sumSquared/samples - mean*mean)
+
+				// Expect a zero mean and a spread depending on the scale,
+				// i.e. 1% of the value or the minimum scale of 0.5
+				scale := math.Max(value*0.01, 0.5)
+				require.InDelta(t, 0, mean, 0.1*scale)
+				require.Greater(t, stddev, 0.5*scale)
+				require.Less(t, stddev, 2*scale)
+			}
+		})
+	}
+}
+
+// Verifies that integer results are rounded and do not change their sign
+// with clamp_zero enabled while excluded fields are kept
+func TestClampZeroAndExclusion(t *testing.T) {
+	plugin := Noise{
+		NoiseType:     "laplacian",
+		ClampZero:     true,
+		ExcludeFields: []string{"billing_*"},
+		Log:           testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+	plugin.generator = &testDistribution{value: -7.6}
+
+	input := metric.New("test",
+		map[string]string{},
+		map[string]interface{}{
+			"int_small":     int64(5),
+			"int_large":     int64(100),
+			"int_negative":  int64(-3),
+			"float_small":   2.5,
+			"billing_count": int64(42),
+		},
+		time.Unix(0, 0),
+	)
+	expected := metric.New("test",
+		map[string]string{},
+		map[string]interface{}{
+			"int_small":     int64(0),
+			"int_large":     int64(92),
+			"int_negative":  int64(-11),
+			"float_small":   0.0,
+			"billing_count": int64(42),
+		},
+		time.Unix(0, 0),
+	)
+
+	actual := plugin.Apply(input)
+	testutil.RequireMetricsEqual(t, []telegraf.Metric{expected}, actual)
+}
+
+func TestInvalidRelativeScale(t *testing.T) {
+	tests := []struct {
+		name     string
+		plugin   Noise
+		expected string
+	}{
+		{
+			name:     "negative relative scale",
+			plugin:   Noise{RelativeScale: -1},
+			expected: "invalid relative scale",
+		},
+		{
+			name:     "negative minimum scale",
+			plugin:   Noise{RelativeScale: 1, MinScale: -1},
+			expected: "invalid minimum scale",
+		},
+		{
+			name:     "uniform distribution",
+			plugin:   Noise{RelativeScale: 1, NoiseType: "uniform"},
+			expected: "not supported for uniform distribution",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorContains(t, tt.plugin.Init(), tt.expected)
+		})
+	}
+}
+
 // Verifies that any invalid generator setting (not "laplacian", "gaussian" or
 // "uniform") raises an error
 func TestInvalidDistributionFunction(t *testing.T) {
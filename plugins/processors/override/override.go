@@ -3,8 +3,10 @@ package override
 
 import (
 	_ "embed"
+	"fmt"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/plugins/processors"
 )
 
@@ -16,30 +18,83 @@ type Override struct {
 	NamePrefix   string            `toml:"name_prefix"`
 	NameSuffix   string            `toml:"name_suffix"`
 	Tags         map[string]string `toml:"tags"`
+	Rules        []rule            `toml:"rule"`
+	ApplyAll     bool              `toml:"apply_all"`
+}
+
+// rule contains overrides only applied to metrics with matching tags
+type rule struct {
+	TagMatch     map[string]string `toml:"tag_match"`
+	NameOverride string            `toml:"name_override"`
+	NamePrefix   string            `toml:"name_prefix"`
+	NameSuffix   string            `toml:"name_suffix"`
+	Tags         map[string]string `toml:"tags"`
+
+	tagFilters map[string]filter.Filter
 }
 
 func (*Override) SampleConfig() string {
 	return sampleConfig
 }
 
+func (p *Override) Init() error {
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		r.tagFilters = make(map[string]filter.Filter, len(r.TagMatch))
+		for key, pattern := range r.TagMatch {
+			f, err := filter.Compile([]string{pattern})
+			if err != nil {
+				return fmt.Errorf("creating tag filter for %q in rule %d failed: %w", key, i+1, err)
+			}
+			r.tagFilters[key] = f
+		}
+	}
+	return nil
+}
+
 func (p *Override) Apply(in ...telegraf.Metric) []telegraf.Metric {
 	for _, metric := range in {
-		if len(p.NameOverride) > 0 {
-			metric.SetName(p.NameOverride)
-		}
-		if len(p.NamePrefix) > 0 {
-			metric.AddPrefix(p.NamePrefix)
-		}
-		if len(p.NameSuffix) > 0 {
-			metric.AddSuffix(p.NameSuffix)
-		}
-		for key, value := range p.Tags {
-			metric.AddTag(key, value)
+		modify(metric, p.NameOverride, p.NamePrefix, p.NameSuffix, p.Tags)
+
+		for _, r := range p.Rules {
+			if !r.matches(metric) {
+				continue
+			}
+			modify(metric, r.NameOverride, r.NamePrefix, r.NameSuffix, r.Tags)
+			if !p.ApplyAll {
+				break
+			}
 		}
 	}
 	return in
 }
 
+// matches checks if all given tags of the rule exist and match the pattern
+func (r *rule) matches(metric telegraf.Metric) bool {
+	for key, f := range r.tagFilters {
+		value, found := metric.GetTag(key)
+		if !found || !f.Match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func modify(metric telegraf.Metric, nameOverride, namePrefix, nameSuffix string, tags map[string]string) {
+	if len(nameOverride) > 0 {
+		metric.SetName(nameOverride)
+	}
+	if len(namePrefix) > 0 {
+		metric.AddPrefix(namePrefix)
+	}
+	if len(nameSuffix) > 0 {
+		metric.AddSuffix(nameSuffix)
+	}
+	for key, value := range tags {
+		metric.AddTag(key, value)
+	}
+}
+
 func init() {
 	processors.Add("override", func() telegraf.Processor {
 		return &Override{}
@@ -81,6 +81,89 @@ func TestNameSuffix(t *testing.T) {
 
 	require.Equal(t, "m1-suff", processed[0].Name(), "Suffix was not applied")
 }
+
+func TestRules(t *testing.T) {
+	rules := []rule{
+		{
+			TagMatch:   map[string]string{"region": "eu-*"},
+			NamePrefix: "europe_",
+		},
+		{
+			TagMatch: map[string]string{"region": "*-west", "env": "prod"},
+			Tags:     map[string]string{"priority": "high"},
+		},
+		{
+			NameSuffix: "_other",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		applyAll bool
+		input    telegraf.Metric
+		expected telegraf.Metric
+	}{
+		{
+			name:     "first match",
+			input:    metric.New("cpu", map[string]string{"region": "eu-west", "env": "prod"}, map[string]interface{}{"value": 1}, time.Unix(0, 0)),
+			expected: metric.New("europe_cpu", map[string]string{"region": "eu-west", "env": "prod"}, map[string]interface{}{"value": 1}, time.Unix(0, 0)),
+		},
+		{
+			name:     "multiple tags",
+			input:    metric.New("cpu", map[string]string{"region": "us-west", "env": "prod"}, map[string]interface{}{"value": 1}, time.Unix(0, 0)),
+			expected: metric.New("cpu", map[string]string{"region": "us-west", "env": "prod", "priority": "high"}, map[string]interface{}{"value": 1}, time.Unix(0, 0)),
+		},
+		{
+			name:     "catch-all",
+			input:    metric.New("cpu", map[string]string{"region": "us-west"}, map[string]interface{}{"value": 1}, time.Unix(0, 0)),
+			expected: metric.New("cpu_other", map[string]string{"region": "us-west"}, map[string]interface{}{"value": 1}, time.Unix(0, 0)),
+		},
+		{
+			name:     "apply all",
+			applyAll: true,
+			input:    metric.New("cpu", map[string]string{"region": "eu-west", "env": "prod"}, map[string]interface{}{"value": 1}, time.Unix(0, 0)),
+			expected: metric.New(
+				"europe_cpu_other",
+				map[string]string{"region": "eu-west", "env": "prod", "priority": "high"},
+				map[string]interface{}{"value": 1},
+				time.Unix(0, 0),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &Override{
+				Rules:    rules,
+				ApplyAll: tt.applyAll,
+			}
+			require.NoError(t, plugin.Init())
+
+			actual := plugin.Apply(tt.input)
+			testutil.RequireMetricsEqual(t, []telegraf.Metric{tt.expected}, actual)
+		})
+	}
+}
+
+func TestRulesAfterGlobalOverrides(t *testing.T) {
+	plugin := &Override{
+		Tags: map[string]string{"region": "eu-west"},
+		Rules: []rule{
+			{
+				TagMatch:     map[string]string{"region": "eu-*"},
+				NameOverride: "europe",
+			},
+		},
+	}
+	require.NoError(t, plugin.Init())
+
+	input := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 1}, time.Unix(0, 0))
+	expected := metric.New("europe", map[string]string{"region": "eu-west"}, map[string]interface{}{"value": 1}, time.Unix(0, 0))
+
+	actual := plugin.Apply(input)
+	testutil.RequireMetricsEqual(t, []telegraf.Metric{expected}, actual)
+}
+
 func TestTracking(t *testing.T) {
 	// Setup raw input and expected output
 	inputRaw := []telegraf.Metric{
@@ -12,8 +12,29 @@ import (
 var sampleConfig string
 
 type Pivot struct {
-	TagKey   string `toml:"tag_key"`
-	ValueKey string `toml:"value_key"`
+	TagKey   string          `toml:"tag_key"`
+	ValueKey valueKeys       `toml:"value_key"`
+	Log      telegraf.Logger `toml:"-"`
+
+	conflicts map[string]bool
+}
+
+// valueKeys accepts either a single field name or a list of field names
+type valueKeys []string
+
+func (k *valueKeys) UnmarshalTOML(unmarshal func(interface{}) error) error {
+	var single string
+	if err := unmarshal(&single); err == nil {
+		*k = valueKeys{single}
+		return nil
+	}
+
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return err
+	}
+	*k = list
+	return nil
 }
 
 func (*Pivot) SampleConfig() string {
@@ -27,18 +48,54 @@ func (p *Pivot) Apply(metrics ...telegraf.Metric) []telegraf.Metric {
 			continue
 		}
 
-		value, ok := m.GetField(p.ValueKey)
-		if !ok {
+		// Keep the original naming for a single value field
+		if len(p.ValueKey) == 1 {
+			value, ok := m.GetField(p.ValueKey[0])
+			if !ok {
+				continue
+			}
+
+			m.RemoveTag(p.TagKey)
+			m.RemoveField(p.ValueKey[0])
+			m.AddField(key, value)
 			continue
 		}
 
-		m.RemoveTag(p.TagKey)
-		m.RemoveField(p.ValueKey)
-		m.AddField(key, value)
+		var pivoted bool
+		for _, field := range p.ValueKey {
+			value, ok := m.GetField(field)
+			if !ok {
+				continue
+			}
+
+			name := key + "_" + field
+			if m.HasField(name) {
+				p.logConflict(name)
+				continue
+			}
+			m.RemoveField(field)
+			m.AddField(name, value)
+			pivoted = true
+		}
+		if pivoted {
+			m.RemoveTag(p.TagKey)
+		}
 	}
 	return metrics
 }
 
+// logConflict reports a conflicting field name once per name
+func (p *Pivot) logConflict(name string) {
+	if p.conflicts == nil {
+		p.conflicts = make(map[string]bool)
+	}
+	if p.conflicts[name] {
+		return
+	}
+	p.conflicts[name] = true
+	p.Log.Warnf("Field %q already exists, skipping pivot", name)
+}
+
 func init() {
 	processors.Add("pivot", func() telegraf.Processor {
 		return &Pivot{}
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/influxdata/toml"
+
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/testutil"
@@ -23,7 +25,7 @@ func TestPivot(t *testing.T) {
 			name: "simple",
 			pivot: &Pivot{
 				TagKey:   "name",
-				ValueKey: "value",
+				ValueKey: valueKeys{"value"},
 			},
 			metrics: []telegraf.Metric{
 				testutil.MustMetric("cpu",
@@ -50,7 +52,7 @@ func TestPivot(t *testing.T) {
 			name: "missing tag",
 			pivot: &Pivot{
 				TagKey:   "name",
-				ValueKey: "value",
+				ValueKey: valueKeys{"value"},
 			},
 			metrics: []telegraf.Metric{
 				testutil.MustMetric("cpu",
@@ -79,7 +81,7 @@ func TestPivot(t *testing.T) {
 			name: "missing field",
 			pivot: &Pivot{
 				TagKey:   "name",
-				ValueKey: "value",
+				ValueKey: valueKeys{"value"},
 			},
 			metrics: []telegraf.Metric{
 				testutil.MustMetric("cpu",
@@ -104,6 +106,64 @@ func TestPivot(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "multiple value fields",
+			pivot: &Pivot{
+				TagKey:   "name",
+				ValueKey: valueKeys{"min", "max"},
+			},
+			metrics: []telegraf.Metric{
+				testutil.MustMetric("cpu",
+					map[string]string{
+						"name": "idle_time",
+					},
+					map[string]interface{}{
+						"min":   int64(40),
+						"max":   int64(44),
+						"count": int64(3),
+					},
+					now,
+				),
+			},
+			expected: []telegraf.Metric{
+				testutil.MustMetric("cpu",
+					map[string]string{},
+					map[string]interface{}{
+						"idle_time_min": int64(40),
+						"idle_time_max": int64(44),
+						"count":         int64(3),
+					},
+					now,
+				),
+			},
+		},
+		{
+			name: "multiple value fields partially present",
+			pivot: &Pivot{
+				TagKey:   "name",
+				ValueKey: valueKeys{"min", "max"},
+			},
+			metrics: []telegraf.Metric{
+				testutil.MustMetric("cpu",
+					map[string]string{
+						"name": "idle_time",
+					},
+					map[string]interface{}{
+						"max": int64(44),
+					},
+					now,
+				),
+			},
+			expected: []telegraf.Metric{
+				testutil.MustMetric("cpu",
+					map[string]string{},
+					map[string]interface{}{
+						"idle_time_max": int64(44),
+					},
+					now,
+				),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -113,6 +173,72 @@ func TestPivot(t *testing.T) {
 	}
 }
 
+func TestPivotConflict(t *testing.T) {
+	logger := &testutil.CaptureLogger{}
+	plugin := &Pivot{
+		TagKey:   "name",
+		ValueKey: valueKeys{"min", "max"},
+		Log:      logger,
+	}
+
+	input := []telegraf.Metric{
+		metric.New("cpu",
+			map[string]string{"name": "idle"},
+			map[string]interface{}{"min": int64(1), "max": int64(2), "idle_max": int64(3)},
+			time.Unix(0, 0),
+		),
+		metric.New("cpu",
+			map[string]string{"name": "idle"},
+			map[string]interface{}{"min": int64(4), "max": int64(5), "idle_max": int64(6)},
+			time.Unix(0, 0),
+		),
+	}
+	expected := []telegraf.Metric{
+		metric.New("cpu",
+			map[string]string{},
+			map[string]interface{}{"idle_min": int64(1), "max": int64(2), "idle_max": int64(3)},
+			time.Unix(0, 0),
+		),
+		metric.New("cpu",
+			map[string]string{},
+			map[string]interface{}{"idle_min": int64(4), "max": int64(5), "idle_max": int64(6)},
+			time.Unix(0, 0),
+		),
+	}
+
+	actual := plugin.Apply(input...)
+	testutil.RequireMetricsEqual(t, expected, actual)
+	require.Len(t, logger.Warnings(), 1)
+	require.Contains(t, logger.Warnings()[0], `"idle_max" already exists`)
+}
+
+func TestValueKeyConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected valueKeys
+	}{
+		{
+			name:     "single",
+			config:   `value_key = "value"`,
+			expected: valueKeys{"value"},
+		},
+		{
+			name:     "list",
+			config:   `value_key = ["min", "max"]`,
+			expected: valueKeys{"min", "max"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var plugin Pivot
+			require.NoError(t, toml.Unmarshal([]byte(tt.config), &plugin))
+			require.Equal(t, tt.expected, plugin.ValueKey)
+		})
+	}
+}
+
 func TestTracking(t *testing.T) {
 	// Setup raw input and expected output
 	inputRaw := []telegraf.Metric{
@@ -176,7 +302,7 @@ func TestTracking(t *testing.T) {
 	// Prepare and start the plugin
 	plugin := &Pivot{
 		TagKey:   "name",
-		ValueKey: "value",
+		ValueKey: valueKeys{"value"},
 	}
 
 	// Process expected metrics and compare with resulting metrics
@@ -4,6 +4,7 @@ package port_name
 import (
 	"bufio"
 	_ "embed"
+	"errors"
 	"io"
 	"os"
 	"strconv"
@@ -17,8 +18,13 @@ import (
 var sampleConfig string
 
 type sMap map[string]map[int]string // "https" == services["tcp"][443]
+type pMap map[int]string            // "tcp" == protocols[6]
 
 var services sMap
+var protocols pMap
+
+// Protocols used if the system protocols file doesn't exist
+var defaultProtocols = pMap{1: "icmp", 6: "tcp", 17: "udp"}
 
 type PortName struct {
 	SourceTag       string `toml:"tag"`
@@ -28,6 +34,12 @@ type PortName struct {
 	ProtocolTag     string `toml:"protocol_tag"`
 	ProtocolField   string `toml:"protocol_field"`
 
+	DstTag   string `toml:"dst_tag"`
+	DstField string `toml:"dst_field"`
+	DstDest  string `toml:"dst_dest"`
+
+	KeepUnknownPorts bool `toml:"keep_unknown_ports"`
+
 	Log telegraf.Logger `toml:"-"`
 }
 
@@ -83,120 +95,194 @@ func readServices(r io.Reader) sMap {
 	return services
 }
 
+func readProtocolsFile() {
+	file, err := os.Open(protocolsPath())
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	protocols = readProtocols(file)
+}
+
+// Read the protocols file into a map of protocol number to name.
+func readProtocols(r io.Reader) pMap {
+	protocols := make(pMap)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// "tcp	6	TCP		# transmission control protocol"
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		f := strings.Fields(line)
+		if len(f) < 2 {
+			continue
+		}
+		number, err := strconv.Atoi(f[1])
+		if err != nil || number < 0 {
+			continue
+		}
+		if _, found := protocols[number]; !found {
+			protocols[number] = strings.ToLower(f[0])
+		}
+	}
+	return protocols
+}
+
 func (*PortName) SampleConfig() string {
 	return sampleConfig
 }
 
 func (pn *PortName) Apply(metrics ...telegraf.Metric) []telegraf.Metric {
 	for _, m := range metrics {
-		var portProto string
-		var fromField bool
+		pn.translate(m, pn.SourceTag, pn.SourceField, pn.Dest)
+		if len(pn.DstTag) > 0 || len(pn.DstField) > 0 {
+			pn.translate(m, pn.DstTag, pn.DstField, pn.DstDest)
+		}
+	}
 
-		if len(pn.SourceTag) > 0 {
-			if tag, ok := m.GetTag(pn.SourceTag); ok {
-				portProto = tag
-			}
+	return metrics
+}
+
+// translate looks up the service name of the port in the given tag or field
+// and stores the name in the destination tag or field depending on the
+// source of the port.
+func (pn *PortName) translate(m telegraf.Metric, sourceTag, sourceField, dest string) {
+	var portProto string
+	var fromField bool
+
+	if len(sourceTag) > 0 {
+		if tag, ok := m.GetTag(sourceTag); ok {
+			portProto = tag
 		}
-		if len(pn.SourceField) > 0 {
-			if field, ok := m.GetField(pn.SourceField); ok {
-				switch v := field.(type) {
-				default:
-					pn.Log.Errorf("Unexpected type %t in source field; must be string or int", v)
-					continue
-				case int64:
-					portProto = strconv.FormatInt(v, 10)
-				case uint64:
-					portProto = strconv.FormatUint(v, 10)
-				case string:
-					portProto = v
-				}
-				fromField = true
+	}
+	if len(sourceField) > 0 {
+		if field, ok := m.GetField(sourceField); ok {
+			switch v := field.(type) {
+			default:
+				pn.Log.Errorf("Unexpected type %t in source field; must be string or int", v)
+				return
+			case int64:
+				portProto = strconv.FormatInt(v, 10)
+			case uint64:
+				portProto = strconv.FormatUint(v, 10)
+			case string:
+				portProto = v
 			}
+			fromField = true
 		}
+	}
 
-		if len(portProto) == 0 {
-			continue
-		}
+	if len(portProto) == 0 {
+		return
+	}
 
-		portProtoSlice := strings.SplitN(portProto, "/", 2)
-		l := len(portProtoSlice)
+	portProtoSlice := strings.SplitN(portProto, "/", 2)
+	l := len(portProtoSlice)
 
-		if l == 0 {
-			// Empty tag
-			pn.Log.Errorf("empty port tag: %v", pn.SourceTag)
-			continue
-		}
+	if l == 0 {
+		// Empty tag
+		pn.Log.Errorf("empty port tag: %v", sourceTag)
+		return
+	}
 
-		var port int
-		if l > 0 {
-			var err error
-			val := portProtoSlice[0]
-			port, err = strconv.Atoi(val)
-			if err != nil {
-				// Can't convert port to string
-				pn.Log.Errorf("error converting port to integer: %v", val)
-				continue
-			}
+	var port int
+	if l > 0 {
+		var err error
+		val := portProtoSlice[0]
+		port, err = strconv.Atoi(val)
+		if err != nil {
+			// Can't convert port to string
+			pn.Log.Errorf("error converting port to integer: %v", val)
+			return
 		}
+	}
 
-		proto := pn.DefaultProtocol
-		if l > 1 && len(portProtoSlice[1]) > 0 {
-			proto = portProtoSlice[1]
-		}
-		if len(pn.ProtocolTag) > 0 {
-			if tag, ok := m.GetTag(pn.ProtocolTag); ok {
-				proto = tag
-			}
+	proto := pn.DefaultProtocol
+	if l > 1 && len(portProtoSlice[1]) > 0 {
+		proto = portProtoSlice[1]
+	}
+	if len(pn.ProtocolTag) > 0 {
+		if tag, ok := m.GetTag(pn.ProtocolTag); ok {
+			proto = tag
 		}
-		if len(pn.ProtocolField) > 0 {
-			if field, ok := m.GetField(pn.ProtocolField); ok {
-				switch v := field.(type) {
-				default:
-					pn.Log.Errorf("Unexpected type %t in protocol field; must be string", v)
-					continue
-				case string:
-					proto = v
-				}
+	}
+	if len(pn.ProtocolField) > 0 {
+		if field, ok := m.GetField(pn.ProtocolField); ok {
+			switch v := field.(type) {
+			default:
+				pn.Log.Errorf("Unexpected type %t in protocol field; must be string or int", v)
+				return
+			case int64:
+				proto = strconv.FormatInt(v, 10)
+			case uint64:
+				proto = strconv.FormatUint(v, 10)
+			case string:
+				proto = v
 			}
 		}
+	}
 
-		proto = strings.ToLower(proto)
+	proto = strings.ToLower(proto)
 
-		protoMap, ok := services[proto]
+	// Translate numeric IP protocol values, e.g. 6 for tcp
+	if number, err := strconv.Atoi(proto); err == nil {
+		name, ok := protocols[number]
 		if !ok {
-			// Unknown protocol
-			//
-			// Protocol is normally tcp or udp.  The services file
-			// normally has entries for both, so our map does too.  If
-			// not, it's very likely the source tag or the services
-			// file doesn't make sense.
-			pn.Log.Errorf("protocol not found in services map: %v", proto)
-			continue
+			pn.Log.Errorf("protocol number not found in protocols map: %v", number)
+			return
 		}
+		proto = name
+	}
 
-		service, ok := protoMap[port]
-		if !ok {
-			// Unknown port
-			//
-			// Not all ports are named so this isn't an error, but
-			// it's helpful to know when debugging.
-			pn.Log.Debugf("port not found in services map: %v", port)
-			continue
-		}
+	protoMap, ok := services[proto]
+	if !ok {
+		// Unknown protocol
+		//
+		// Protocol is normally tcp or udp.  The services file
+		// normally has entries for both, so our map does too.  If
+		// not, it's very likely the source tag or the services
+		// file doesn't make sense.
+		pn.Log.Errorf("protocol not found in services map: %v", proto)
+		return
+	}
 
-		if fromField {
-			m.AddField(pn.Dest, service)
-		} else {
-			m.AddTag(pn.Dest, service)
+	service, ok := protoMap[port]
+	if !ok {
+		// Unknown port
+		//
+		// Not all ports are named so this isn't an error, but
+		// it's helpful to know when debugging.
+		pn.Log.Debugf("port not found in services map: %v", port)
+		if !pn.KeepUnknownPorts {
+			return
 		}
+		service = strconv.Itoa(port)
 	}
 
-	return metrics
+	if fromField {
+		m.AddField(dest, service)
+	} else {
+		m.AddTag(dest, service)
+	}
 }
 
 func (pn *PortName) Init() error {
 	services = make(sMap)
 	readServicesFile()
+
+	protocols = make(pMap, len(defaultProtocols))
+	readProtocolsFile()
+	for number, name := range defaultProtocols {
+		if _, found := protocols[number]; !found {
+			protocols[number] = name
+		}
+	}
+
+	if (len(pn.DstTag) > 0 || len(pn.DstField) > 0) && len(pn.DstDest) == 0 {
+		return errors.New("'dst_dest' required for translating the destination port")
+	}
 	return nil
 }
 
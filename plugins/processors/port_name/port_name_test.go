@@ -28,6 +28,24 @@ func TestFakeServices(t *testing.T) {
 	require.Equal(t, sMap{"tcp": {80: "http", 443: "https"}, "udp": {69: "tftp"}}, m)
 }
 
+var fakeProtocols = `
+# Internet (IP) protocols
+ip	0	IP		# internet protocol, pseudo protocol number
+icmp	1	ICMP		# internet control message protocol
+tcp	6	TCP		# transmission control protocol
+udp	17	UDP		# user datagram protocol`
+
+func TestReadProtocolsFile(t *testing.T) {
+	readProtocolsFile()
+	require.NotEmpty(t, protocols)
+}
+
+func TestFakeProtocols(t *testing.T) {
+	r := strings.NewReader(fakeProtocols)
+	m := readProtocols(r)
+	require.Equal(t, pMap{0: "ip", 1: "icmp", 6: "tcp", 17: "udp"}, m)
+}
+
 func TestTable(t *testing.T) {
 	var tests = []struct {
 		name      string
@@ -355,6 +373,172 @@ func TestTable(t *testing.T) {
 	}
 }
 
+func TestNumericProtocols(t *testing.T) {
+	services = readServices(strings.NewReader(fakeServices))
+	protocols = readProtocols(strings.NewReader(fakeProtocols))
+
+	input := []telegraf.Metric{
+		metric.New(
+			"flow",
+			map[string]string{"proto": "17"},
+			map[string]interface{}{"port": int64(69)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"flow",
+			map[string]string{"proto": "6"},
+			map[string]interface{}{"port": int64(80)},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"flow",
+			map[string]string{"proto": "99"},
+			map[string]interface{}{"port": int64(80)},
+			time.Unix(0, 0),
+		),
+	}
+
+	expected := []telegraf.Metric{
+		metric.New(
+			"flow",
+			map[string]string{"proto": "17"},
+			map[string]interface{}{"port": int64(69), "service": "tftp"},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"flow",
+			map[string]string{"proto": "6"},
+			map[string]interface{}{"port": int64(80), "service": "http"},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"flow",
+			map[string]string{"proto": "99"},
+			map[string]interface{}{"port": int64(80)},
+			time.Unix(0, 0),
+		),
+	}
+
+	plugin := &PortName{
+		SourceField:     "port",
+		Dest:            "service",
+		DefaultProtocol: "tcp",
+		ProtocolTag:     "proto",
+		Log:             testutil.Logger{},
+	}
+
+	actual := plugin.Apply(input...)
+	testutil.RequireMetricsEqual(t, expected, actual)
+}
+
+func TestSourceAndDestinationPorts(t *testing.T) {
+	services = readServices(strings.NewReader(fakeServices))
+
+	tests := []struct {
+		name        string
+		keepUnknown bool
+		expected    []telegraf.Metric
+	}{
+		{
+			name: "drop unknown",
+			expected: []telegraf.Metric{
+				metric.New(
+					"flow",
+					map[string]string{
+						"src_port":    "80",
+						"dst_port":    "443",
+						"src_service": "http",
+						"dst_service": "https",
+					},
+					map[string]interface{}{"bytes": int64(42)},
+					time.Unix(0, 0),
+				),
+				metric.New(
+					"flow",
+					map[string]string{
+						"src_port":    "54321",
+						"dst_port":    "80",
+						"dst_service": "http",
+					},
+					map[string]interface{}{"bytes": int64(23)},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name:        "keep unknown",
+			keepUnknown: true,
+			expected: []telegraf.Metric{
+				metric.New(
+					"flow",
+					map[string]string{
+						"src_port":    "80",
+						"dst_port":    "443",
+						"src_service": "http",
+						"dst_service": "https",
+					},
+					map[string]interface{}{"bytes": int64(42)},
+					time.Unix(0, 0),
+				),
+				metric.New(
+					"flow",
+					map[string]string{
+						"src_port":    "54321",
+						"dst_port":    "80",
+						"src_service": "54321",
+						"dst_service": "http",
+					},
+					map[string]interface{}{"bytes": int64(23)},
+					time.Unix(0, 0),
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := []telegraf.Metric{
+				metric.New(
+					"flow",
+					map[string]string{"src_port": "80", "dst_port": "443"},
+					map[string]interface{}{"bytes": int64(42)},
+					time.Unix(0, 0),
+				),
+				metric.New(
+					"flow",
+					map[string]string{"src_port": "54321", "dst_port": "80"},
+					map[string]interface{}{"bytes": int64(23)},
+					time.Unix(0, 0),
+				),
+			}
+
+			plugin := &PortName{
+				SourceTag:        "src_port",
+				Dest:             "src_service",
+				DstTag:           "dst_port",
+				DstDest:          "dst_service",
+				DefaultProtocol:  "tcp",
+				KeepUnknownPorts: tt.keepUnknown,
+				Log:              testutil.Logger{},
+			}
+
+			actual := plugin.Apply(input...)
+			testutil.RequireMetricsEqual(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestInitMissingDestinationDest(t *testing.T) {
+	plugin := &PortName{
+		SourceTag:       "src_port",
+		Dest:            "src_service",
+		DstTag:          "dst_port",
+		DefaultProtocol: "tcp",
+		Log:             testutil.Logger{},
+	}
+	require.ErrorContains(t, plugin.Init(), "dst_dest")
+}
+
 func TestTracking(t *testing.T) {
 	// Setup raw input and expected output
 	inputRaw := []telegraf.Metric{
@@ -10,3 +10,7 @@ import (
 func servicesPath() string {
 	return filepath.Join(os.Getenv("WINDIR"), "system32", "drivers", "etc", "services")
 }
+
+func protocolsPath() string {
+	return filepath.Join(os.Getenv("WINDIR"), "system32", "drivers", "etc", "protocol")
+}
@@ -22,3 +22,19 @@ func servicesPath() string {
 	}
 	return files[0]
 }
+
+// protocolsPath returns the path of the `protocols` file with the same
+// approach as servicesPath
+func protocolsPath() string {
+	var files = []string{
+		"/etc/protocols",
+		"/usr/etc/protocols", // fallback on OpenSuSE
+	}
+
+	for i := range files {
+		if _, err := os.Stat(files[i]); err == nil {
+			return files[i]
+		}
+	}
+	return files[0]
+}
@@ -41,11 +41,15 @@ type converter struct {
 	Replacement string `toml:"replacement"`
 	ResultKey   string `toml:"result_key"`
 	Append      bool   `toml:"append"`
+	NamedGroups bool   `toml:"named_groups"`
+	KeepSource  *bool  `toml:"keep_source"`
+	Conflict    string `toml:"conflict"`
 
-	filter filter.Filter
-	re     *regexp.Regexp
-	groups []string
-	apply  func(m telegraf.Metric)
+	filter     filter.Filter
+	re         *regexp.Regexp
+	groups     []string
+	keepSource bool
+	apply      func(m telegraf.Metric)
 }
 
 func (c *converter) setup(ct converterType, log telegraf.Logger) error {
@@ -67,8 +71,35 @@ func (c *converter) setup(ct converterType, log telegraf.Logger) error {
 		}
 		c.filter = f
 
+		switch c.Conflict {
+		case "":
+			c.Conflict = "overwrite"
+		case "overwrite", "keep":
+			// Do nothing as those are valid choices
+		default:
+			return fmt.Errorf("invalid conflict policy %q", c.Conflict)
+		}
+		c.keepSource = c.KeepSource == nil || *c.KeepSource
+
 		// Check for named groups
-		if c.ResultKey == "" && c.Replacement == "" {
+		if c.NamedGroups {
+			if c.ResultKey != "" || c.Replacement != "" {
+				return errors.New("'named_groups' cannot be used together with 'result_key' or 'replacement'")
+			}
+			groups := c.re.SubexpNames()[1:]
+			var found bool
+			for _, g := range groups {
+				if g != "" {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return errors.New("'named_groups' requires at least one named group in the pattern")
+			}
+			log.Debugf("%s: Using explicit named-group mode...", ct)
+			c.groups = groups
+		} else if c.ResultKey == "" && c.Replacement == "" {
 			groups := c.re.SubexpNames()
 			allNamed := len(groups) > 1
 			for _, g := range groups[1:] {
@@ -117,6 +148,10 @@ func (c *converter) setup(ct converterType, log telegraf.Logger) error {
 }
 
 func (c *converter) applyTags(m telegraf.Metric) {
+	// Named groups are handled after iterating the tags as the source tag
+	// might be removed which is not possible while iterating the tag-list.
+	var sources []string
+
 	for _, tag := range m.TagList() {
 		if !c.filter.Match(tag.Key) || !c.re.MatchString(tag.Value) {
 			continue
@@ -124,19 +159,7 @@ func (c *converter) applyTags(m telegraf.Metric) {
 
 		// Handle named groups
 		if len(c.groups) > 0 {
-			matches := c.re.FindStringSubmatch(tag.Value)
-			for i, match := range matches[1:] {
-				if match == "" {
-					continue
-				}
-				name := c.groups[i]
-				if c.Append {
-					if v, ok := m.GetTag(name); ok {
-						match = v + match
-					}
-				}
-				m.AddTag(name, match)
-			}
+			sources = append(sources, tag.Key)
 			continue
 		}
 
@@ -154,9 +177,17 @@ func (c *converter) applyTags(m telegraf.Metric) {
 		}
 		m.AddTag(newKey, newValue)
 	}
+
+	for _, key := range sources {
+		c.applyTagGroups(m, key)
+	}
 }
 
 func (c *converter) applyFields(m telegraf.Metric) {
+	// Named groups are handled after iterating the fields as the source field
+	// might be removed which is not possible while iterating the field-list.
+	var sources []string
+
 	for _, field := range m.FieldList() {
 		if !c.filter.Match(field.Key) {
 			continue
@@ -169,19 +200,7 @@ func (c *converter) applyFields(m telegraf.Metric) {
 
 		// Handle named groups
 		if len(c.groups) > 0 {
-			matches := c.re.FindStringSubmatch(value)
-			for i, match := range matches[1:] {
-				if match == "" {
-					continue
-				}
-				name := c.groups[i]
-				if c.Append {
-					if v, ok := m.GetTag(name); ok {
-						match = v + match
-					}
-				}
-				m.AddField(name, match)
-			}
+			sources = append(sources, field.Key)
 			continue
 		}
 
@@ -194,6 +213,80 @@ func (c *converter) applyFields(m telegraf.Metric) {
 		newValue := c.re.ReplaceAllString(value, c.Replacement)
 		m.AddField(newKey, newValue)
 	}
+
+	for _, key := range sources {
+		c.applyFieldGroups(m, key)
+	}
+}
+
+func (c *converter) applyTagGroups(m telegraf.Metric, key string) {
+	value, ok := m.GetTag(key)
+	if !ok {
+		return
+	}
+	// The value might have been overwritten by an earlier source so it does
+	// not match anymore
+	matches := c.re.FindStringSubmatch(value)
+	if matches == nil {
+		return
+	}
+
+	if !c.keepSource {
+		m.RemoveTag(key)
+	}
+
+	for i, match := range matches[1:] {
+		name := c.groups[i]
+		if name == "" || match == "" {
+			continue
+		}
+		if m.HasTag(name) && c.Conflict == "keep" {
+			continue
+		}
+		if c.Append {
+			if v, ok := m.GetTag(name); ok {
+				match = v + match
+			}
+		}
+		m.AddTag(name, match)
+	}
+}
+
+func (c *converter) applyFieldGroups(m telegraf.Metric, key string) {
+	raw, ok := m.GetField(key)
+	if !ok {
+		return
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return
+	}
+	// The value might have been overwritten by an earlier source so it does
+	// not match anymore
+	matches := c.re.FindStringSubmatch(value)
+	if matches == nil {
+		return
+	}
+
+	if !c.keepSource {
+		m.RemoveField(key)
+	}
+
+	for i, match := range matches[1:] {
+		name := c.groups[i]
+		if name == "" || match == "" {
+			continue
+		}
+		if m.HasField(name) && c.Conflict == "keep" {
+			continue
+		}
+		if c.Append {
+			if v, ok := m.GetTag(name); ok {
+				match = v + match
+			}
+		}
+		m.AddField(name, match)
+	}
 }
 
 func (c *converter) applyTagRename(m telegraf.Metric) {
@@ -1092,3 +1092,278 @@ func TestTrackedMetricNotLost(t *testing.T) {
 		return len(delivered) == 1
 	}, time.Second, 100*time.Millisecond, "%d delivered but %d expected", len(delivered), len(expected))
 }
+
+func TestExplicitNamedGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []converter
+		fields   []converter
+		input    telegraf.Metric
+		expected []telegraf.Metric
+	}{
+		{
+			name: "tags with mixed groups",
+			tags: []converter{
+				{
+					Key:         "request_path",
+					Pattern:     `^/api/(?P<api_version>v\d+)/(\w+)/(?P<resource>\d+)`,
+					NamedGroups: true,
+				},
+			},
+			input: testutil.MustMetric(
+				"access_log",
+				map[string]string{"request_path": "/api/v2/users/123/orders"},
+				map[string]interface{}{"value": 42},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"access_log",
+					map[string]string{
+						"request_path": "/api/v2/users/123/orders",
+						"api_version":  "v2",
+						"resource":     "123",
+					},
+					map[string]interface{}{"value": 42},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "tags removing source",
+			tags: []converter{
+				{
+					Key:         "request_path",
+					Pattern:     `^/api/(?P<api_version>v\d+)/(?P<resource>\w+)`,
+					NamedGroups: true,
+					KeepSource:  &[]bool{false}[0],
+				},
+			},
+			input: testutil.MustMetric(
+				"access_log",
+				map[string]string{"request_path": "/api/v2/users/123/orders"},
+				map[string]interface{}{"value": 42},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"access_log",
+					map[string]string{
+						"api_version": "v2",
+						"resource":    "users",
+					},
+					map[string]interface{}{"value": 42},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "tags keep existing on conflict",
+			tags: []converter{
+				{
+					Key:         "request_path",
+					Pattern:     `^/api/(?P<api_version>v\d+)/(?P<resource>\w+)`,
+					NamedGroups: true,
+					Conflict:    "keep",
+				},
+			},
+			input: testutil.MustMetric(
+				"access_log",
+				map[string]string{
+					"request_path": "/api/v2/users/123/orders",
+					"resource":     "static",
+				},
+				map[string]interface{}{"value": 42},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"access_log",
+					map[string]string{
+						"request_path": "/api/v2/users/123/orders",
+						"api_version":  "v2",
+						"resource":     "static",
+					},
+					map[string]interface{}{"value": 42},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "tags not matching",
+			tags: []converter{
+				{
+					Key:         "request_path",
+					Pattern:     `^/api/(?P<api_version>v\d+)/(?P<resource>\w+)`,
+					NamedGroups: true,
+					KeepSource:  &[]bool{false}[0],
+				},
+			},
+			input: testutil.MustMetric(
+				"access_log",
+				map[string]string{"request_path": "/index.html"},
+				map[string]interface{}{"value": 42},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"access_log",
+					map[string]string{"request_path": "/index.html"},
+					map[string]interface{}{"value": 42},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "tags overwritten by earlier source",
+			tags: []converter{
+				{
+					Key:         "*",
+					Pattern:     `^x(?P<b>\d)$`,
+					NamedGroups: true,
+					KeepSource:  &[]bool{false}[0],
+				},
+			},
+			input: testutil.MustMetric(
+				"test",
+				map[string]string{
+					"a": "x1",
+					"b": "x2",
+				},
+				map[string]interface{}{"value": 42},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"test",
+					map[string]string{"b": "1"},
+					map[string]interface{}{"value": 42},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "fields overwritten by earlier source",
+			fields: []converter{
+				{
+					Key:         "*",
+					Pattern:     `^x(?P<b>\d)$`,
+					NamedGroups: true,
+				},
+			},
+			input: testutil.MustMetric(
+				"test",
+				map[string]string{},
+				map[string]interface{}{
+					"a": "x1",
+					"b": "x2",
+				},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"test",
+					map[string]string{},
+					map[string]interface{}{
+						"a": "x1",
+						"b": "1",
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "fields removing source",
+			fields: []converter{
+				{
+					Key:         "request",
+					Pattern:     `^(?P<method>[A-Z]+) (?P<path>\S+)`,
+					NamedGroups: true,
+					KeepSource:  &[]bool{false}[0],
+				},
+			},
+			input: testutil.MustMetric(
+				"access_log",
+				map[string]string{},
+				map[string]interface{}{
+					"request": "GET /index.html HTTP/1.1",
+					"method":  "unknown",
+				},
+				time.Unix(0, 0),
+			),
+			expected: []telegraf.Metric{
+				testutil.MustMetric(
+					"access_log",
+					map[string]string{},
+					map[string]interface{}{
+						"method": "GET",
+						"path":   "/index.html",
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &Regex{
+				Tags:   tt.tags,
+				Fields: tt.fields,
+				Log:    testutil.Logger{},
+			}
+			require.NoError(t, plugin.Init())
+
+			actual := plugin.Apply(tt.input)
+			testutil.RequireMetricsEqual(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestExplicitNamedGroupsInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		conv     converter
+		expected string
+	}{
+		{
+			name: "no named groups",
+			conv: converter{
+				Key:         "path",
+				Pattern:     `^/(\w+)`,
+				NamedGroups: true,
+			},
+			expected: "requires at least one named group",
+		},
+		{
+			name: "with replacement",
+			conv: converter{
+				Key:         "path",
+				Pattern:     `^/(?P<first>\w+)`,
+				Replacement: "${first}",
+				NamedGroups: true,
+			},
+			expected: "cannot be used together",
+		},
+		{
+			name: "invalid conflict policy",
+			conv: converter{
+				Key:         "path",
+				Pattern:     `^/(?P<first>\w+)`,
+				NamedGroups: true,
+				Conflict:    "merge",
+			},
+			expected: "invalid conflict policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &Regex{
+				Tags: []converter{tt.conv},
+				Log:  testutil.Logger{},
+			}
+			require.ErrorContains(t, plugin.Init(), tt.expected)
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"golang.org/x/sync/semaphore"
+
+	"github.com/influxdata/telegraf/selfstat"
 )
 
 const defaultMaxWorkers = 10
@@ -26,11 +28,13 @@ type AnyResolver interface {
 // if multiple goroutines request the same IP at the same time, one of the
 // requests will trigger the lookup and the rest will wait for its response.
 type ReverseDNSCache struct {
-	Resolver AnyResolver
-	stats    RDNSCacheStats
+	Resolver  AnyResolver
+	stats     RDNSCacheStats
+	selfStats *cacheSelfStats
 
 	// settings
 	ttl           time.Duration
+	negativeTTL   time.Duration
 	lookupTimeout time.Duration
 	maxWorkers    int
 
@@ -48,18 +52,37 @@ type ReverseDNSCache struct {
 	// As a bonus, we only have to read the first item to know if anything in the
 	// map has expired.
 	// must lock to get access to this.
-	expireList     []*dnslookup
-	expireListLock sync.Mutex
+	// Negative results use a different TTL and are kept in a separate list to
+	// preserve the ordering by expiry time.
+	expireList         []*dnslookup
+	negativeExpireList []*dnslookup
+	expireListLock     sync.Mutex
 }
 
 type RDNSCacheStats struct {
 	CacheHit          uint64
 	CacheMiss         uint64
 	CacheExpire       uint64
+	NegativeCacheHit  uint64
 	RequestsAbandoned uint64
 	RequestsFilled    uint64
 }
 
+// cacheSelfStats are the internal statistics reported via selfstat
+type cacheSelfStats struct {
+	hits         selfstat.Stat
+	misses       selfstat.Stat
+	negativeHits selfstat.Stat
+}
+
+func newCacheSelfStats(tags map[string]string) *cacheSelfStats {
+	return &cacheSelfStats{
+		hits:         selfstat.Register("reverse_dns", "cache_hits", tags),
+		misses:       selfstat.Register("reverse_dns", "cache_misses", tags),
+		negativeHits: selfstat.Register("reverse_dns", "negative_cache_hits", tags),
+	}
+}
+
 func NewReverseDNSCache(ttl, lookupTimeout time.Duration, workerPoolSize int) *ReverseDNSCache {
 	if workerPoolSize <= 0 {
 		workerPoolSize = defaultMaxWorkers
@@ -78,6 +101,20 @@ func NewReverseDNSCache(ttl, lookupTimeout time.Duration, workerPoolSize int) *R
 	return d
 }
 
+// newResolver creates a resolver querying the given DNS servers in a
+// round-robin fashion
+func newResolver(servers []string, timeout time.Duration) *net.Resolver {
+	var next uint64
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			server := servers[atomic.AddUint64(&next, 1)%uint64(len(servers))]
+			dialer := net.Dialer{Timeout: timeout}
+			return dialer.DialContext(ctx, network, server)
+		},
+	}
+}
+
 // dnslookup represents a lookup request/response. It may or may not be answered yet.
 // interested parties register themselves with existing requests or create new ones
 // to get their dns query answered. Answers will be pushed out to callbacks.
@@ -86,6 +123,7 @@ type dnslookup struct {
 	domains   []string
 	expiresAt time.Time
 	completed bool
+	negative  bool
 	callbacks []callbackChannelType
 }
 
@@ -109,7 +147,7 @@ func (d *ReverseDNSCache) Lookup(ip string) ([]string, error) {
 	result, found := d.lockedGetFromCache(ip)
 	if found && result.completed && !result.expiresAt.Before(time.Now()) {
 		defer d.rwLock.RUnlock()
-		atomic.AddUint64(&d.stats.CacheHit, 1)
+		d.countHit(result)
 		// cache is valid
 		return result.domains, nil
 	}
@@ -139,7 +177,7 @@ func (d *ReverseDNSCache) subscribeTo(ip string) callbackChannelType {
 	// confirm it's still not in the cache. This needs to be done under an active lock.
 	result, found := d.lockedGetFromCache(ip)
 	if found {
-		atomic.AddUint64(&d.stats.CacheHit, 1)
+		d.countHit(result)
 		// has the request been answered since we last checked?
 		if result.completed {
 			// we can return the answer with the channel.
@@ -154,6 +192,9 @@ func (d *ReverseDNSCache) subscribeTo(ip string) callbackChannelType {
 	}
 
 	atomic.AddUint64(&d.stats.CacheMiss, 1)
+	if d.selfStats != nil {
+		d.selfStats.misses.Incr(1)
+	}
 
 	// otherwise we need to register the request
 	l := &dnslookup{
@@ -213,9 +254,24 @@ func (d *ReverseDNSCache) doLookup(ip string) {
 
 	names, err := d.Resolver.LookupAddr(ctx, ip)
 	if err != nil {
+		var dnsErr *net.DNSError
+		if d.negativeTTL > 0 && errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			d.completeLookup(ip, nil, true)
+			return
+		}
 		d.abandonLookup(ip, err)
 		return
 	}
+	d.completeLookup(ip, names, false)
+}
+
+// completeLookup stores the lookup result in the cache and notifies all
+// subscribers. Negative results are stored without any names.
+func (d *ReverseDNSCache) completeLookup(ip string, names []string, negative bool) {
+	ttl := d.ttl
+	if negative {
+		ttl = d.negativeTTL
+	}
 
 	d.rwLock.Lock()
 	lookup, found := d.lockedGetFromCache(ip)
@@ -226,7 +282,8 @@ func (d *ReverseDNSCache) doLookup(ip string) {
 
 	lookup.domains = names
 	lookup.completed = true
-	lookup.expiresAt = time.Now().Add(d.ttl) // extend the ttl now that we have a reply.
+	lookup.negative = negative
+	lookup.expiresAt = time.Now().Add(ttl) // extend the ttl now that we have a reply.
 	callbacks := lookup.callbacks
 	lookup.callbacks = nil
 
@@ -235,7 +292,11 @@ func (d *ReverseDNSCache) doLookup(ip string) {
 
 	d.expireListLock.Lock()
 	// add it to the expireList.
-	d.expireList = append(d.expireList, lookup)
+	if negative {
+		d.negativeExpireList = append(d.negativeExpireList, lookup)
+	} else {
+		d.expireList = append(d.expireList, lookup)
+	}
 	d.expireListLock.Unlock()
 
 	atomic.AddUint64(&d.stats.RequestsFilled, uint64(len(callbacks)))
@@ -264,26 +325,29 @@ func (d *ReverseDNSCache) abandonLookup(ip string, err error) {
 	}
 }
 
-func (d *ReverseDNSCache) cleanup() {
-	now := time.Now()
-	d.expireListLock.Lock()
-	if len(d.expireList) == 0 {
-		d.expireListLock.Unlock()
-		return
+func (d *ReverseDNSCache) countHit(lookup *dnslookup) {
+	atomic.AddUint64(&d.stats.CacheHit, 1)
+	if lookup.negative {
+		atomic.AddUint64(&d.stats.NegativeCacheHit, 1)
 	}
-	ipsToDelete := make([]string, 0, len(d.expireList))
-	for i := 0; i < len(d.expireList); i++ {
-		if !d.expireList[i].expiresAt.Before(now) {
-			break // done. Nothing after this point is expired.
+	if d.selfStats != nil {
+		d.selfStats.hits.Incr(1)
+		if lookup.negative {
+			d.selfStats.negativeHits.Incr(1)
 		}
-		ipsToDelete = append(ipsToDelete, d.expireList[i].ip)
 	}
+}
+
+func (d *ReverseDNSCache) cleanup() {
+	now := time.Now()
+	d.expireListLock.Lock()
+	var ipsToDelete []string
+	d.expireList, ipsToDelete = expired(d.expireList, now, ipsToDelete)
+	d.negativeExpireList, ipsToDelete = expired(d.negativeExpireList, now, ipsToDelete)
+	d.expireListLock.Unlock()
 	if len(ipsToDelete) == 0 {
-		d.expireListLock.Unlock()
 		return
 	}
-	d.expireList = d.expireList[len(ipsToDelete):]
-	d.expireListLock.Unlock()
 
 	atomic.AddUint64(&d.stats.CacheExpire, uint64(len(ipsToDelete)))
 
@@ -294,11 +358,25 @@ func (d *ReverseDNSCache) cleanup() {
 	}
 }
 
+// expired removes the expired lookups from the given list and appends their
+// IPs to the given slice. The list must be ordered by expiry time.
+func expired(list []*dnslookup, now time.Time, ips []string) (remaining []*dnslookup, expiredIPs []string) {
+	var i int
+	for ; i < len(list); i++ {
+		if !list[i].expiresAt.Before(now) {
+			break // done. Nothing after this point is expired.
+		}
+		ips = append(ips, list[i].ip)
+	}
+	return list[i:], ips
+}
+
 func (d *ReverseDNSCache) Stats() RDNSCacheStats {
 	stats := RDNSCacheStats{}
 	stats.CacheHit = atomic.LoadUint64(&d.stats.CacheHit)
 	stats.CacheMiss = atomic.LoadUint64(&d.stats.CacheMiss)
 	stats.CacheExpire = atomic.LoadUint64(&d.stats.CacheExpire)
+	stats.NegativeCacheHit = atomic.LoadUint64(&d.stats.NegativeCacheHit)
 	stats.RequestsAbandoned = atomic.LoadUint64(&d.stats.RequestsAbandoned)
 	stats.RequestsFilled = atomic.LoadUint64(&d.stats.RequestsFilled)
 	return stats
@@ -3,6 +3,7 @@ package reverse_dns
 import (
 	"context"
 	"errors"
+	"net"
 	"sync"
 	"testing"
 	"time"
@@ -123,6 +124,62 @@ func TestLookupTimeout(t *testing.T) {
 	require.EqualValues(t, 1, d.Stats().RequestsAbandoned)
 }
 
+func TestNegativeCaching(t *testing.T) {
+	ttl := 100 * time.Millisecond
+	d := NewReverseDNSCache(10*time.Second, 1*time.Second, -1)
+	d.negativeTTL = ttl
+	defer d.Stop()
+
+	resolver := &notFoundResolver{}
+	d.Resolver = resolver
+	for i := 0; i < 3; i++ {
+		answer, err := d.Lookup("192.0.2.1")
+		require.NoError(t, err)
+		require.Empty(t, answer)
+	}
+	require.EqualValues(t, 1, resolver.calls)
+	require.Len(t, d.negativeExpireList, 1)
+	require.Empty(t, d.expireList)
+
+	stats := d.Stats()
+	require.EqualValues(t, 1, stats.CacheMiss)
+	require.EqualValues(t, 2, stats.CacheHit)
+	require.EqualValues(t, 2, stats.NegativeCacheHit)
+
+	// negative entries expire with their own ttl
+	time.Sleep(ttl)
+	d.cleanup()
+	require.Empty(t, d.negativeExpireList)
+	require.Empty(t, d.cache)
+
+	_, err := d.Lookup("192.0.2.1")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, resolver.calls)
+}
+
+func TestNegativeCachingDisabled(t *testing.T) {
+	d := NewReverseDNSCache(10*time.Second, 1*time.Second, -1)
+	defer d.Stop()
+
+	resolver := &notFoundResolver{}
+	d.Resolver = resolver
+	for i := 0; i < 3; i++ {
+		_, err := d.Lookup("192.0.2.1")
+		require.Error(t, err)
+	}
+	require.EqualValues(t, 3, resolver.calls)
+	require.EqualValues(t, 3, d.Stats().RequestsAbandoned)
+}
+
+type notFoundResolver struct {
+	calls int
+}
+
+func (r *notFoundResolver) LookupAddr(_ context.Context, addr string) (names []string, err error) {
+	r.calls++
+	return nil, &net.DNSError{Err: "no such host", Name: addr, IsNotFound: true}
+}
+
 type timeoutResolver struct{}
 
 func (r *timeoutResolver) LookupAddr(_ context.Context, _ string) (names []string, err error) {
@@ -27,7 +27,9 @@ type ReverseDNS struct {
 
 	Lookups            []lookupEntry   `toml:"lookup"`
 	CacheTTL           config.Duration `toml:"cache_ttl"`
+	NegativeCacheTTL   config.Duration `toml:"negative_cache_ttl"`
 	LookupTimeout      config.Duration `toml:"lookup_timeout"`
+	DNSServers         []string        `toml:"dns_servers"`
 	MaxParallelLookups int             `toml:"max_parallel_lookups"`
 	Ordered            bool            `toml:"ordered"`
 	Log                telegraf.Logger `toml:"-"`
@@ -44,6 +46,11 @@ func (r *ReverseDNS) Start(acc telegraf.Accumulator) error {
 		time.Duration(r.LookupTimeout),
 		r.MaxParallelLookups, // max parallel reverse-dns lookups
 	)
+	r.reverseDNSCache.negativeTTL = time.Duration(r.NegativeCacheTTL)
+	r.reverseDNSCache.selfStats = newCacheSelfStats(map[string]string{})
+	if len(r.DNSServers) > 0 {
+		r.reverseDNSCache.Resolver = newResolver(r.DNSServers, time.Duration(r.LookupTimeout))
+	}
 	if r.Ordered {
 		r.parallel = parallel.NewOrdered(acc, r.asyncAdd, 10000, r.MaxParallelLookups)
 	} else {
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/influxdata/telegraf"
@@ -21,18 +22,22 @@ func (*Scale) SampleConfig() string {
 }
 
 type Scaling struct {
-	InMin  *float64 `toml:"input_minimum"`
-	InMax  *float64 `toml:"input_maximum"`
-	OutMin *float64 `toml:"output_minimum"`
-	OutMax *float64 `toml:"output_maximum"`
-	Factor *float64 `toml:"factor"`
-	Offset *float64 `toml:"offset"`
-	Fields []string `toml:"fields"`
+	Mode    string   `toml:"scaling"`
+	InMin   *float64 `toml:"input_minimum"`
+	InMax   *float64 `toml:"input_maximum"`
+	OutMin  *float64 `toml:"output_minimum"`
+	OutMax  *float64 `toml:"output_maximum"`
+	Factor  *float64 `toml:"factor"`
+	Offset  *float64 `toml:"offset"`
+	Epsilon *float64 `toml:"epsilon"`
+	Clamp   bool     `toml:"clamp"`
+	Fields  []string `toml:"fields"`
 
 	fieldFilter filter.Filter
 	scale       float64
 	shiftIn     float64
 	shiftOut    float64
+	logarithm   func(float64) float64
 }
 
 type Scale struct {
@@ -42,6 +47,40 @@ type Scale struct {
 
 func (s *Scaling) Init() error {
 	s.scale, s.shiftOut, s.shiftIn = float64(1.0), float64(0.0), float64(0.0)
+
+	var err error
+	switch s.Mode {
+	case "", "linear":
+		err = s.initLinear()
+	case "log10":
+		s.logarithm = math.Log10
+		err = s.initLogarithmic()
+	case "ln":
+		s.logarithm = math.Log
+		err = s.initLogarithmic()
+	case "percent":
+		err = s.initPercent()
+	default:
+		err = fmt.Errorf("unknown scaling %q for fields %s", s.Mode, strings.Join(s.Fields, ","))
+	}
+	if err != nil {
+		return err
+	}
+
+	scalingFilter, err := filter.Compile(s.Fields)
+	if err != nil {
+		return fmt.Errorf("could not compile fields filter: %w", err)
+	}
+	s.fieldFilter = scalingFilter
+
+	return nil
+}
+
+func (s *Scaling) initLinear() error {
+	if s.Epsilon != nil || s.Clamp {
+		return fmt.Errorf("epsilon and clamp are not supported for linear scaling of fields %s", strings.Join(s.Fields, ","))
+	}
+
 	allMinMaxSet := s.OutMax != nil && s.OutMin != nil && s.InMax != nil && s.InMin != nil
 	anyMinMaxSet := s.OutMax != nil || s.OutMin != nil || s.InMax != nil || s.InMin != nil
 	factorSet := s.Factor != nil || s.Offset != nil
@@ -73,18 +112,63 @@ func (s *Scaling) Init() error {
 		}
 	}
 
-	scalingFilter, err := filter.Compile(s.Fields)
-	if err != nil {
-		return fmt.Errorf("could not compile fields filter: %w", err)
+	return nil
+}
+
+func (s *Scaling) initLogarithmic() error {
+	if s.OutMax != nil || s.OutMin != nil || s.InMax != nil || s.InMin != nil || s.Clamp {
+		return fmt.Errorf("minimum/maximum and clamp are not supported for %s scaling of fields %s",
+			s.Mode, strings.Join(s.Fields, ","))
+	}
+	if s.Epsilon != nil && *s.Epsilon <= 0 {
+		return fmt.Errorf("epsilon has to be positive for fields %s", strings.Join(s.Fields, ","))
+	}
+
+	// Factor and offset are applied to the logarithm of the value
+	if s.Factor != nil {
+		s.scale = *s.Factor
+	}
+	if s.Offset != nil {
+		s.shiftOut = *s.Offset
 	}
-	s.fieldFilter = scalingFilter
 
 	return nil
 }
 
-// scale a float according to the input and output range
-func (s *Scaling) process(value float64) float64 {
-	return s.scale*(value-s.shiftIn) + s.shiftOut
+func (s *Scaling) initPercent() error {
+	if s.InMax == nil || s.InMin == nil {
+		return fmt.Errorf("input minimum and maximum need to be set for fields %s", strings.Join(s.Fields, ","))
+	}
+	if s.OutMax != nil || s.OutMin != nil || s.Factor != nil || s.Offset != nil || s.Epsilon != nil {
+		return fmt.Errorf("output minimum/maximum, factor/offset and epsilon are not supported for percent scaling of fields %s",
+			strings.Join(s.Fields, ","))
+	}
+	if *s.InMax == *s.InMin {
+		return fmt.Errorf("input minimum and maximum are equal for fields %s", strings.Join(s.Fields, ","))
+	}
+
+	s.scale = 100.0 / (*s.InMax - *s.InMin)
+	s.shiftIn = *s.InMin
+
+	return nil
+}
+
+// scale a float according to the configured scaling mode
+func (s *Scaling) process(value float64) (float64, error) {
+	if s.logarithm != nil {
+		if s.Epsilon != nil {
+			value = math.Max(value, *s.Epsilon)
+		} else if value <= 0 {
+			return 0, fmt.Errorf("logarithm of non-positive value %v", value)
+		}
+		return s.scale*s.logarithm(value) + s.shiftOut, nil
+	}
+
+	result := s.scale*(value-s.shiftIn) + s.shiftOut
+	if s.Clamp {
+		result = math.Max(0, math.Min(100, result))
+	}
+	return result, nil
 }
 
 func (s *Scale) Init() error {
@@ -128,7 +212,12 @@ func (s *Scale) scaleValues(metric telegraf.Metric) {
 			}
 
 			// scale the field values using the defined scaler
-			field.Value = scaling.process(v)
+			result, err := scaling.process(v)
+			if err != nil {
+				s.Log.Errorf("Error scaling %q: %v", field.Key, err)
+				continue
+			}
+			field.Value = result
 		}
 	}
 }
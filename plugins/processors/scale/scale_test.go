@@ -1,6 +1,7 @@
 package scale
 
 import (
+	"math"
 	"sync"
 	"testing"
 	"time"
@@ -549,3 +550,157 @@ func TestTracking(t *testing.T) {
 		return len(input) == len(delivered)
 	}, time.Second, 100*time.Millisecond, "%d delivered but %d expected", len(delivered), len(expected))
 }
+
+func TestNonLinearScaling(t *testing.T) {
+	a0, a10, a100, a4095, eps := float64(0.0), float64(10.0), float64(100.0), float64(4095.0), float64(1e-3)
+	tests := []struct {
+		name     string
+		scaling  Scaling
+		input    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "log10 with factor",
+			scaling: Scaling{
+				Mode:   "log10",
+				Factor: &a10,
+				Fields: []string{"ratio*"},
+			},
+			input: map[string]interface{}{
+				"ratio1": 100.0,
+				"ratio2": int64(1000),
+				"ratio3": 0.0,
+				"other":  10.0,
+			},
+			expected: map[string]interface{}{
+				"ratio1": 20.0,
+				"ratio2": 30.0,
+				"ratio3": 0.0,
+				"other":  10.0,
+			},
+		},
+		{
+			name: "ln with epsilon and offset",
+			scaling: Scaling{
+				Mode:    "ln",
+				Offset:  &a100,
+				Epsilon: &eps,
+				Fields:  []string{"value*"},
+			},
+			input: map[string]interface{}{
+				"value1": math.E,
+				"value2": -5.0,
+			},
+			expected: map[string]interface{}{
+				"value1": 101.0,
+				"value2": 100 + math.Log(eps),
+			},
+		},
+		{
+			name: "percent without clamping",
+			scaling: Scaling{
+				Mode:   "percent",
+				InMin:  &a0,
+				InMax:  &a4095,
+				Fields: []string{"adc*"},
+			},
+			input: map[string]interface{}{
+				"adc1": int64(4095),
+				"adc2": uint64(819),
+				"adc3": 5000.0,
+			},
+			expected: map[string]interface{}{
+				"adc1": 100.0,
+				"adc2": 20.0,
+				"adc3": 5000.0 / 40.95,
+			},
+		},
+		{
+			name: "percent with clamping",
+			scaling: Scaling{
+				Mode:   "percent",
+				InMin:  &a10,
+				InMax:  &a100,
+				Clamp:  true,
+				Fields: []string{"adc*"},
+			},
+			input: map[string]interface{}{
+				"adc1": 55.0,
+				"adc2": 0.0,
+				"adc3": 500.0,
+			},
+			expected: map[string]interface{}{
+				"adc1": 50.0,
+				"adc2": 0.0,
+				"adc3": 100.0,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &Scale{
+				Scalings: []Scaling{tt.scaling},
+				Log:      testutil.Logger{},
+			}
+			require.NoError(t, plugin.Init())
+
+			input := metric.New("test", map[string]string{}, tt.input, time.Unix(0, 0))
+			expected := []telegraf.Metric{
+				metric.New("test", map[string]string{}, tt.expected, time.Unix(0, 0)),
+			}
+			actual := plugin.Apply(input)
+			testutil.RequireMetricsEqual(t, expected, actual, cmpopts.EquateApprox(0, 1e-9))
+		})
+	}
+}
+
+func TestErrorCasesNonLinear(t *testing.T) {
+	a0, a1, a100 := float64(0.0), float64(1.0), float64(100.0)
+	tests := []struct {
+		name             string
+		scaling          Scaling
+		expectedErrorMsg string
+	}{
+		{
+			name:             "unknown mode",
+			scaling:          Scaling{Mode: "sqrt", Fields: []string{"test"}},
+			expectedErrorMsg: `unknown scaling "sqrt"`,
+		},
+		{
+			name:             "log with range",
+			scaling:          Scaling{Mode: "log10", InMin: &a0, InMax: &a1, Fields: []string{"test"}},
+			expectedErrorMsg: "minimum/maximum and clamp are not supported",
+		},
+		{
+			name:             "log with non-positive epsilon",
+			scaling:          Scaling{Mode: "ln", Epsilon: &a0, Fields: []string{"test"}},
+			expectedErrorMsg: "epsilon has to be positive",
+		},
+		{
+			name:             "percent without range",
+			scaling:          Scaling{Mode: "percent", Fields: []string{"test"}},
+			expectedErrorMsg: "input minimum and maximum need to be set",
+		},
+		{
+			name:             "percent with output range",
+			scaling:          Scaling{Mode: "percent", InMin: &a0, InMax: &a1, OutMin: &a0, OutMax: &a100, Fields: []string{"test"}},
+			expectedErrorMsg: "not supported for percent scaling",
+		},
+		{
+			name:             "linear with clamp",
+			scaling:          Scaling{Factor: &a1, Clamp: true, Fields: []string{"test"}},
+			expectedErrorMsg: "not supported for linear scaling",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &Scale{
+				Scalings: []Scaling{tt.scaling},
+				Log:      testutil.Logger{},
+			}
+			require.ErrorContains(t, plugin.Init(), tt.expectedErrorMsg)
+		})
+	}
+}
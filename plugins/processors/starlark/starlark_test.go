@@ -2485,6 +2485,58 @@ def process(metric):
 				),
 			},
 		},
+		{
+			name: "json decode depth limit",
+			source: `
+def apply(metric):
+    doc = "[" * 65 + "]" * 65
+    metric.fields["error"] = catch(lambda: json.decode(doc))
+    metric.fields["ok"] = len(json.decode("[" * 64 + "]" * 64))
+    return metric
+`,
+			input: []telegraf.Metric{
+				testutil.MustMetric("cpu",
+					map[string]string{},
+					map[string]interface{}{"value": 42},
+					time.Unix(0, 0),
+				),
+			},
+			expected: []telegraf.Metric{
+				testutil.MustMetric("cpu",
+					map[string]string{},
+					map[string]interface{}{
+						"value": 42,
+						"error": "decode: document exceeds maximum nesting depth of 64",
+						"ok":    1,
+					},
+					time.Unix(0, 0),
+				),
+			},
+		},
+		{
+			name: "predeclared time parsing",
+			source: `
+def apply(metric):
+    t = time.parse_time("02/01/2006 15:04", metric.fields.pop("value"), location="UTC")
+    metric.fields["year"] = t.year
+    metric.time = t.unix_nano
+    return metric
+`,
+			input: []telegraf.Metric{
+				testutil.MustMetric("cpu",
+					map[string]string{},
+					map[string]interface{}{"value": "24/12/2022 18:00"},
+					time.Unix(0, 0),
+				),
+			},
+			expected: []telegraf.Metric{
+				testutil.MustMetric("cpu",
+					map[string]string{},
+					map[string]interface{}{"year": 2022},
+					time.Date(2022, 12, 24, 18, 0, 0, 0, time.UTC),
+				),
+			},
+		},
 		{
 			name: "support constants",
 			source: `
@@ -2,13 +2,21 @@
 package strings
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
 	_ "embed"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/plugins/processors"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -30,6 +38,7 @@ type Strings struct {
 	Left         []converter `toml:"left"`
 	Base64Decode []converter `toml:"base64decode"`
 	ValidUTF8    []converter `toml:"valid_utf8"`
+	Hash         []converter `toml:"hash"`
 
 	converters []converter
 	init       bool
@@ -51,6 +60,9 @@ type converter struct {
 	New         string
 	Width       int
 	Replacement string
+	Algorithm   string
+	Key         config.Secret
+	Length      int
 
 	fn ConvertFunc
 }
@@ -262,9 +274,75 @@ func (s *Strings) initOnce() {
 		s.converters = append(s.converters, c)
 	}
 
+	s.converters = append(s.converters, s.Hash...)
+
 	s.init = true
 }
 
+// hashFunc creates the conversion function hashing values with the
+// configured algorithm and key and producing (truncated) hex output
+func (c *converter) hashFunc() (ConvertFunc, error) {
+	if c.Length < 0 {
+		return nil, fmt.Errorf("invalid length %d", c.Length)
+	}
+
+	var key []byte
+	if !c.Key.Empty() {
+		secret, err := c.Key.Get()
+		if err != nil {
+			return nil, fmt.Errorf("getting key failed: %w", err)
+		}
+		key = append(key, secret.Bytes()...)
+		secret.Destroy()
+	}
+
+	var newHash func() hash.Hash
+	switch c.Algorithm {
+	case "", "sha256":
+		newHash = sha256.New
+	case "sha512":
+		newHash = sha512.New
+	case "hmac-sha256":
+		if len(key) == 0 {
+			return nil, errors.New("algorithm \"hmac-sha256\" requires a key")
+		}
+		newHash = func() hash.Hash { return hmac.New(sha256.New, key) }
+	default:
+		return nil, fmt.Errorf("unknown algorithm %q", c.Algorithm)
+	}
+
+	// For the plain hash algorithms the key is used as salt
+	salt := key
+	if strings.HasPrefix(c.Algorithm, "hmac-") {
+		salt = nil
+	}
+
+	return func(s string) string {
+		if s == "" {
+			return s
+		}
+		h := newHash()
+		h.Write(salt)
+		h.Write([]byte(s))
+		digest := hex.EncodeToString(h.Sum(nil))
+		if c.Length > 0 && c.Length < len(digest) {
+			return digest[:c.Length]
+		}
+		return digest
+	}, nil
+}
+
+func (s *Strings) Init() error {
+	for i := range s.Hash {
+		fn, err := s.Hash[i].hashFunc()
+		if err != nil {
+			return fmt.Errorf("hash: %w", err)
+		}
+		s.Hash[i].fn = fn
+	}
+	return nil
+}
+
 func (*Strings) SampleConfig() string {
 	return sampleConfig
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/testutil"
 )
@@ -1161,6 +1162,130 @@ func TestValidUTF8(t *testing.T) {
 	}
 }
 
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		plugin   *Strings
+		expected map[string]string
+	}{
+		{
+			name: "sha256 default",
+			plugin: &Strings{
+				Hash: []converter{{Tag: "user"}},
+			},
+			expected: map[string]string{
+				"user":  "2bd806c97f0e00af1a1fc3328fa763a9269723c8db8fac4f93af71db186d6e90",
+				"empty": "",
+			},
+		},
+		{
+			name: "sha512 with salt",
+			plugin: &Strings{
+				Hash: []converter{{Tag: "user", Algorithm: "sha512", Key: config.NewSecret([]byte("pepper"))}},
+			},
+			expected: map[string]string{
+				"user": "da400ef32ce2dc73854c8340bdf472e30dfd8f96bfe12008133cc7a8f660b282" +
+					"77041492e7aeb77078526631eea4eb155948373dc48562556d547837029798cf",
+				"empty": "",
+			},
+		},
+		{
+			name: "hmac-sha256 truncated into dest",
+			plugin: &Strings{
+				Hash: []converter{{
+					Tag:       "user",
+					Dest:      "user_hash",
+					Algorithm: "hmac-sha256",
+					Key:       config.NewSecret([]byte("secret")),
+					Length:    16,
+				}},
+			},
+			expected: map[string]string{
+				"user":      "alice",
+				"user_hash": "4360c67bc8102511",
+				"empty":     "",
+			},
+		},
+		{
+			name: "empty value stays empty",
+			plugin: &Strings{
+				Hash: []converter{{Tag: "*"}},
+			},
+			expected: map[string]string{
+				"user":  "2bd806c97f0e00af1a1fc3328fa763a9269723c8db8fac4f93af71db186d6e90",
+				"empty": "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.plugin.Init())
+
+			input := metric.New(
+				"login",
+				map[string]string{"user": "alice", "empty": ""},
+				map[string]interface{}{"value": 1},
+				time.Unix(0, 0),
+			)
+			expected := metric.New("login", tt.expected, map[string]interface{}{"value": 1}, time.Unix(0, 0))
+
+			actual := tt.plugin.Apply(input)
+			testutil.RequireMetricsEqual(t, []telegraf.Metric{expected}, actual)
+		})
+	}
+}
+
+func TestHashInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		hash     converter
+		expected string
+	}{
+		{
+			name:     "unknown algorithm",
+			hash:     converter{Tag: "user", Algorithm: "md5"},
+			expected: `unknown algorithm "md5"`,
+		},
+		{
+			name:     "hmac without key",
+			hash:     converter{Tag: "user", Algorithm: "hmac-sha256"},
+			expected: "requires a key",
+		},
+		{
+			name:     "negative length",
+			hash:     converter{Tag: "user", Length: -1},
+			expected: "invalid length -1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &Strings{Hash: []converter{tt.hash}}
+			require.ErrorContains(t, plugin.Init(), tt.expected)
+		})
+	}
+}
+
+func BenchmarkHash(b *testing.B) {
+	plugin := &Strings{
+		Hash: []converter{{
+			Tag:       "user",
+			Algorithm: "hmac-sha256",
+			Key:       config.NewSecret([]byte("secret")),
+			Length:    16,
+		}},
+	}
+	require.NoError(b, plugin.Init())
+
+	m := metric.New("login", map[string]string{}, map[string]interface{}{"value": 1}, time.Unix(0, 0))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		m.AddTag("user", "alice")
+		plugin.Apply(m)
+	}
+}
+
 func TestTrackedMetricNotLost(t *testing.T) {
 	var mu sync.Mutex
 	delivered := make([]telegraf.DeliveryInfo, 0, 3)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/processors"
@@ -64,18 +65,50 @@ func (r *TemplateProcessor) Apply(in ...telegraf.Metric) []telegraf.Metric {
 func (r *TemplateProcessor) Init() error {
 	var err error
 
-	r.tmplTag, err = template.New("tag template").Parse(r.Tag)
+	r.tmplTag, err = template.New("tag template").Funcs(funcs).Parse(r.Tag)
 	if err != nil {
 		return fmt.Errorf("creating tag name template failed: %w", err)
 	}
 
-	r.tmplValue, err = template.New("value template").Parse(r.Template)
+	r.tmplValue, err = template.New("value template").Funcs(funcs).Parse(r.Template)
 	if err != nil {
 		return fmt.Errorf("creating value template failed: %w", err)
 	}
 	return nil
 }
 
+// funcs are the additional functions available in templates
+var funcs = template.FuncMap{
+	"printf":     fmt.Sprintf,
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"replace":    replace,
+	"default":    defaultValue,
+	"formatTime": formatTime,
+}
+
+// replace substitutes all occurrences of old by new in s, the argument order
+// allows to use the function in pipelines
+func replace(old, replacement, s string) string {
+	return strings.ReplaceAll(s, old, replacement)
+}
+
+// defaultValue returns the given default if the value is nil or empty
+func defaultValue(def, value interface{}) interface{} {
+	if value == nil {
+		return def
+	}
+	if s, ok := value.(string); ok && s == "" {
+		return def
+	}
+	return value
+}
+
+// formatTime formats the time using the given Go reference layout
+func formatTime(layout string, t time.Time) string {
+	return t.Format(layout)
+}
+
 func init() {
 	processors.Add("template", func() telegraf.Processor {
 		return &TemplateProcessor{}
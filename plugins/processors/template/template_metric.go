@@ -25,8 +25,13 @@ func (m *TemplateMetric) Tag(key string) string {
 	return m.metric.Tag(key)
 }
 
+// Field returns the value of the given field in its original type or an
+// empty string if the field does not exist.
 func (m *TemplateMetric) Field(key string) interface{} {
-	return m.metric.Field(key)
+	if v := m.metric.Field(key); v != nil {
+		return v
+	}
+	return ""
 }
 
 func (m *TemplateMetric) Time() time.Time {
@@ -266,6 +266,68 @@ func TestDot(t *testing.T) {
 	testutil.RequireMetricsEqual(t, []telegraf.Metric{expected}, actual)
 }
 
+func TestTemplateFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "typed field",
+			template: `{{ if gt (.Field "usage") 80.0 }}high{{ else }}normal{{ end }}`,
+			expected: "high",
+		},
+		{
+			name:     "missing field",
+			template: `value:{{ .Field "missing" }}`,
+			expected: "value:",
+		},
+		{
+			name:     "default for missing field",
+			template: `{{ .Field "missing" | default "none" }}`,
+			expected: "none",
+		},
+		{
+			name:     "default for existing field",
+			template: `{{ .Field "usage" | default 0.0 }}`,
+			expected: "92.5",
+		},
+		{
+			name:     "time bucket",
+			template: `{{ .Time.UTC | formatTime "2006-01" | printf "bucket_%s" }}`,
+			expected: "bucket_2024-05",
+		},
+		{
+			name:     "string functions",
+			template: `{{ .Tag "host" | upper | replace "-" "_" }}.{{ .Name | lower }}`,
+			expected: "WEB_01.cpu",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := TemplateProcessor{
+				Tag:      "result",
+				Template: tt.template,
+				Log:      testutil.Logger{},
+			}
+			require.NoError(t, plugin.Init())
+
+			input := metric.New(
+				"CPU",
+				map[string]string{"host": "web-01"},
+				map[string]interface{}{"usage": 92.5},
+				time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC),
+			)
+			expected := input.Copy()
+			expected.AddTag("result", tt.expected)
+
+			actual := plugin.Apply(input)
+			testutil.RequireMetricsEqual(t, []telegraf.Metric{expected}, actual)
+		})
+	}
+}
+
 func TestTracking(t *testing.T) {
 	// Create a tracking metric and tap the delivery information
 	var mu sync.Mutex
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/plugins/processors"
 )
@@ -16,11 +17,13 @@ import (
 var sampleConfig string
 
 type Timestamp struct {
-	Field               string `toml:"field"`
-	SourceFormat        string `toml:"source_timestamp_format"`
-	SourceTimezone      string `toml:"source_timestamp_timezone"`
-	DestinationFormat   string `toml:"destination_timestamp_format"`
-	DestinationTimezone string `toml:"destination_timestamp_timezone"`
+	Field               string          `toml:"field"`
+	SourceFormat        string          `toml:"source_timestamp_format"`
+	SourceTimezone      string          `toml:"source_timestamp_timezone"`
+	DestinationFormat   string          `toml:"destination_timestamp_format"`
+	DestinationTimezone string          `toml:"destination_timestamp_timezone"`
+	Rounding            string          `toml:"rounding"`
+	RoundingInterval    config.Duration `toml:"rounding_interval"`
 
 	sourceLocation      *time.Location
 	destinationLocation *time.Location
@@ -31,6 +34,24 @@ func (*Timestamp) SampleConfig() string {
 }
 
 func (t *Timestamp) Init() error {
+	switch t.Rounding {
+	case "":
+		if t.RoundingInterval != 0 {
+			return errors.New("rounding_interval requires rounding to be set")
+		}
+	case "truncate", "nearest", "ceiling":
+		if t.RoundingInterval <= 0 {
+			return fmt.Errorf("invalid rounding_interval %s", time.Duration(t.RoundingInterval))
+		}
+	default:
+		return fmt.Errorf("invalid rounding %q", t.Rounding)
+	}
+
+	// Allow to only round the metric time without converting a field
+	if t.Field == "" && t.Rounding != "" {
+		return nil
+	}
+
 	switch t.SourceFormat {
 	case "":
 		return errors.New("source_timestamp_format is required")
@@ -75,31 +96,66 @@ func (t *Timestamp) Init() error {
 
 func (t *Timestamp) Apply(in ...telegraf.Metric) []telegraf.Metric {
 	for _, point := range in {
-		if field, ok := point.GetField(t.Field); ok {
-			timestamp, err := internal.ParseTimestamp(t.SourceFormat, field, t.sourceLocation)
-			if err != nil {
-				continue
-			}
-
-			switch t.DestinationFormat {
-			case "unix":
-				point.AddField(t.Field, timestamp.Unix())
-			case "unix_ms":
-				point.AddField(t.Field, timestamp.UnixNano()/1000000)
-			case "unix_us":
-				point.AddField(t.Field, timestamp.UnixNano()/1000)
-			case "unix_ns":
-				point.AddField(t.Field, timestamp.UnixNano())
-			default:
-				inLocation := timestamp.In(t.destinationLocation)
-				point.AddField(t.Field, inLocation.Format(t.DestinationFormat))
-			}
+		t.convertField(point)
+		if t.Rounding != "" {
+			point.SetTime(t.round(point.Time()))
 		}
 	}
 
 	return in
 }
 
+func (t *Timestamp) convertField(point telegraf.Metric) {
+	if t.Field == "" {
+		return
+	}
+	if field, ok := point.GetField(t.Field); ok {
+		timestamp, err := internal.ParseTimestamp(t.SourceFormat, field, t.sourceLocation)
+		if err != nil {
+			return
+		}
+
+		switch t.DestinationFormat {
+		case "unix":
+			point.AddField(t.Field, timestamp.Unix())
+		case "unix_ms":
+			point.AddField(t.Field, timestamp.UnixNano()/1000000)
+		case "unix_us":
+			point.AddField(t.Field, timestamp.UnixNano()/1000)
+		case "unix_ns":
+			point.AddField(t.Field, timestamp.UnixNano())
+		default:
+			inLocation := timestamp.In(t.destinationLocation)
+			point.AddField(t.Field, inLocation.Format(t.DestinationFormat))
+		}
+	}
+}
+
+// round aligns the given time to the rounding interval. In contrast to
+// time.Truncate and time.Round the computation is based on the unix epoch to
+// also align intervals not dividing a minute evenly.
+func (t *Timestamp) round(ts time.Time) time.Time {
+	interval := int64(t.RoundingInterval)
+	ns := ts.UnixNano()
+	remainder := ns % interval
+	if remainder < 0 {
+		remainder += interval
+	}
+	base := ns - remainder
+
+	switch t.Rounding {
+	case "nearest":
+		if 2*remainder >= interval {
+			base += interval
+		}
+	case "ceiling":
+		if remainder != 0 {
+			base += interval
+		}
+	}
+	return time.Unix(0, base).In(ts.Location())
+}
+
 func init() {
 	processors.Add("timestamp", func() telegraf.Processor {
 		return &Timestamp{}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/testutil"
 	"github.com/stretchr/testify/require"
@@ -149,6 +150,100 @@ func TestCases(t *testing.T) {
 				time.Unix(0, 0),
 			),
 		},
+		{
+			name: "truncate metric time",
+			timestamp: Timestamp{
+				Rounding:         "truncate",
+				RoundingInterval: config.Duration(10 * time.Second),
+			},
+			input:    metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1560540097, 500)),
+			expected: metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1560540090, 0)),
+		},
+		{
+			name: "round metric time to nearest",
+			timestamp: Timestamp{
+				Rounding:         "nearest",
+				RoundingInterval: config.Duration(10 * time.Second),
+			},
+			input:    metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1560540095, 0)),
+			expected: metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1560540100, 0)),
+		},
+		{
+			name: "round metric time to nearest down",
+			timestamp: Timestamp{
+				Rounding:         "nearest",
+				RoundingInterval: config.Duration(10 * time.Second),
+			},
+			input:    metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1560540094, 999999999)),
+			expected: metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1560540090, 0)),
+		},
+		{
+			name: "ceiling metric time",
+			timestamp: Timestamp{
+				Rounding:         "ceiling",
+				RoundingInterval: config.Duration(10 * time.Second),
+			},
+			input:    metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1560540090, 1)),
+			expected: metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1560540100, 0)),
+		},
+		{
+			name: "ceiling aligned metric time",
+			timestamp: Timestamp{
+				Rounding:         "ceiling",
+				RoundingInterval: config.Duration(10 * time.Second),
+			},
+			input:    metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1560540090, 0)),
+			expected: metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1560540090, 0)),
+		},
+		{
+			name: "truncate to interval not dividing a minute",
+			timestamp: Timestamp{
+				Rounding:         "truncate",
+				RoundingInterval: config.Duration(7 * time.Second),
+			},
+			input:    metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(100, 0)),
+			expected: metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(98, 0)),
+		},
+		{
+			name: "round to sub-second interval",
+			timestamp: Timestamp{
+				Rounding:         "nearest",
+				RoundingInterval: config.Duration(250 * time.Millisecond),
+			},
+			input:    metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1, 300000000)),
+			expected: metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(1, 250000000)),
+		},
+		{
+			name: "truncate time before epoch",
+			timestamp: Timestamp{
+				Rounding:         "truncate",
+				RoundingInterval: config.Duration(10 * time.Second),
+			},
+			input:    metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(-3, 0)),
+			expected: metric.New("test", map[string]string{}, map[string]any{"value": 42}, time.Unix(-10, 0)),
+		},
+		{
+			name: "field conversion with rounding",
+			timestamp: Timestamp{
+				Field:             "timestamp",
+				SourceFormat:      "2006-01-02T15:04:05Z",
+				DestinationFormat: "unix",
+				Rounding:          "truncate",
+				RoundingInterval:  config.Duration(time.Minute),
+			},
+			input: metric.New(
+				"test",
+				map[string]string{},
+				map[string]any{"timestamp": "2024-03-04T10:10:32.123456789Z"},
+				time.Unix(1560540094, 0),
+			),
+			expected: metric.New(
+				"test",
+				map[string]string{},
+				map[string]any{"timestamp": int64(1709547032)},
+				time.Unix(1560540060, 0),
+			),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -162,3 +257,33 @@ func TestCases(t *testing.T) {
 		})
 	}
 }
+
+func TestInvalidRounding(t *testing.T) {
+	testcases := []struct {
+		name      string
+		timestamp Timestamp
+		expected  string
+	}{
+		{
+			name:      "unknown rounding",
+			timestamp: Timestamp{Rounding: "up", RoundingInterval: config.Duration(time.Second)},
+			expected:  `invalid rounding "up"`,
+		},
+		{
+			name:      "missing interval",
+			timestamp: Timestamp{Rounding: "truncate"},
+			expected:  "invalid rounding_interval",
+		},
+		{
+			name:      "interval without rounding",
+			timestamp: Timestamp{RoundingInterval: config.Duration(time.Second)},
+			expected:  "rounding_interval requires rounding",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorContains(t, tc.timestamp.Init(), tc.expected)
+		})
+	}
+}
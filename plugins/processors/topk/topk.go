@@ -3,6 +3,7 @@ package topk
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
 	"math"
 	"sort"
@@ -11,6 +12,7 @@ import (
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/filter"
+	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/plugins/processors"
 )
@@ -22,11 +24,13 @@ type TopK struct {
 	Period             config.Duration `toml:"period"`
 	K                  int             `toml:"k"`
 	GroupBy            []string        `toml:"group_by"`
+	GroupByFields      []string        `toml:"group_by_fields"`
 	Fields             []string        `toml:"fields"`
 	Aggregation        string          `toml:"aggregation"`
 	Bottomk            bool            `toml:"bottomk"`
 	AddGroupByTag      string          `toml:"add_groupby_tag"`
 	AddRankFields      []string        `toml:"add_rank_fields"`
+	RankFieldSuffix    string          `toml:"rank_field_suffix"`
 	AddAggregateFields []string        `toml:"add_aggregate_fields"`
 	Log                telegraf.Logger `toml:"-"`
 
@@ -48,6 +52,7 @@ func New() *TopK {
 	topk.Aggregation = "mean"
 	topk.GroupBy = []string{"*"}
 	topk.AddGroupByTag = ""
+	topk.RankFieldSuffix = "_topk_rank"
 
 	// Initialize cache
 	topk.Reset()
@@ -60,24 +65,35 @@ type MetricAggregation struct {
 	values     map[string]float64
 }
 
+// sortMetrics sorts the aggregations by the value of the given field in
+// descending order or ascending order if reverse is set. Ties are resolved
+// by the group key to produce deterministic results.
 func sortMetrics(metrics []MetricAggregation, field string, reverse bool) {
-	less := func(i, j int) bool {
+	sort.Slice(metrics, func(i, j int) bool {
 		iv := metrics[i].values[field]
 		jv := metrics[j].values[field]
-		return iv < jv
-	}
-
-	if reverse {
-		sort.SliceStable(metrics, less)
-	} else {
-		sort.SliceStable(metrics, func(i, j int) bool { return !less(i, j) })
-	}
+		if iv == jv {
+			return metrics[i].groupbykey < metrics[j].groupbykey
+		}
+		if reverse {
+			return iv < jv
+		}
+		return iv > jv
+	})
 }
 
 func (*TopK) SampleConfig() string {
 	return sampleConfig
 }
 
+func (t *TopK) Init() error {
+	// An empty suffix would overwrite the ranked field with the rank
+	if t.RankFieldSuffix == "" {
+		return errors.New("'rank_field_suffix' must not be empty")
+	}
+	return nil
+}
+
 func (t *TopK) Reset() {
 	t.cache = make(map[string][]telegraf.Metric)
 	t.lastAggregation = time.Now()
@@ -111,6 +127,19 @@ func (t *TopK) generateGroupByKey(m telegraf.Metric) (string, error) {
 		}
 	}
 
+	// Add the values of the selected fields in the order given by the user
+	for _, field := range t.GroupByFields {
+		raw, ok := m.GetField(field)
+		if !ok {
+			continue
+		}
+		value, err := internal.ToString(raw)
+		if err != nil {
+			return "", fmt.Errorf("could not convert value of field %q: %w", field, err)
+		}
+		groupkey += field + "=" + value + "&"
+	}
+
 	return groupkey, nil
 }
 
@@ -237,7 +266,7 @@ func (t *TopK) push() []telegraf.Metric {
 					// Add the rank relative to the current field if requested
 					_, addRankField := t.rankFieldSet[field]
 					if addRankField && m.HasField(field) {
-						m.AddField(field+"_topk_rank", i+1)
+						m.AddField(field+t.RankFieldSuffix, i+1)
 					}
 				}
 			}
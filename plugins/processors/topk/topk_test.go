@@ -579,3 +579,78 @@ func TestTracking(t *testing.T) {
 		return len(input) == len(delivered)
 	}, time.Second, 100*time.Millisecond, "%d delivered but %d expected", len(delivered), len(expected))
 }
+
+// Ties are resolved by the group key
+func TestTopkTies(t *testing.T) {
+	now := time.Now()
+	input := []telegraf.Metric{
+		metric.New("m", map[string]string{"host": "c"}, map[string]interface{}{"value": 10.0}, now),
+		metric.New("m", map[string]string{"host": "a"}, map[string]interface{}{"value": 10.0}, now),
+		metric.New("m", map[string]string{"host": "b"}, map[string]interface{}{"value": 10.0}, now),
+		metric.New("m", map[string]string{"host": "d"}, map[string]interface{}{"value": 5.0}, now),
+	}
+
+	for _, bottomk := range []bool{false, true} {
+		topk := *New()
+		topk.Period = tenMillisecondsDuration
+		topk.K = 2
+		topk.Aggregation = "sum"
+		topk.GroupBy = []string{"host"}
+		topk.Bottomk = bottomk
+		topk.AddRankFields = []string{"value"}
+		topk.RankFieldSuffix = "_rank"
+
+		var changeSet map[int]metricChange
+		if bottomk {
+			changeSet = map[int]metricChange{
+				1: {newFields: fieldList(field{"value_rank", 2})},
+				3: {newFields: fieldList(field{"value_rank", 1})},
+			}
+		} else {
+			changeSet = map[int]metricChange{
+				1: {newFields: fieldList(field{"value_rank", 1})},
+				2: {newFields: fieldList(field{"value_rank", 2})},
+			}
+		}
+		answer := generateAns(input, changeSet)
+
+		// Repeat the run to detect non-deterministic selections
+		for i := 0; i < 10; i++ {
+			runAndCompare(&topk, deepCopy(input), answer, "Ties test", t)
+		}
+	}
+}
+
+// GroupBy fields
+func TestTopkGroupByFieldValues(t *testing.T) {
+	now := time.Now()
+	input := []telegraf.Metric{
+		metric.New("m", map[string]string{"host": "a"}, map[string]interface{}{"value": 10.0, "status": "ok"}, now),
+		metric.New("m", map[string]string{"host": "a"}, map[string]interface{}{"value": 20.0, "status": "ok"}, now),
+		metric.New("m", map[string]string{"host": "a"}, map[string]interface{}{"value": 25.0, "status": "failed"}, now),
+		metric.New("m", map[string]string{"host": "b"}, map[string]interface{}{"value": 1.0, "status": "ok"}, now),
+	}
+
+	topk := *New()
+	topk.Period = tenMillisecondsDuration
+	topk.K = 1
+	topk.Aggregation = "sum"
+	topk.GroupBy = []string{"host"}
+	topk.GroupByFields = []string{"status"}
+	topk.AddGroupByTag = "gbt"
+
+	changeSet := map[int]metricChange{
+		0: {newTags: tagList(tag{"gbt", "m&host=a&status=ok&"})},
+		1: {newTags: tagList(tag{"gbt", "m&host=a&status=ok&"})},
+	}
+	answer := generateAns(input, changeSet)
+
+	runAndCompare(&topk, deepCopy(input), answer, "GroupBy field values test", t)
+}
+
+func TestTopkEmptyRankFieldSuffix(t *testing.T) {
+	topk := New()
+	topk.AddRankFields = []string{"value"}
+	topk.RankFieldSuffix = ""
+	require.ErrorContains(t, topk.Init(), "'rank_field_suffix' must not be empty")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/plugins/processors"
 )
 
@@ -13,9 +14,14 @@ import (
 var sampleConfig string
 
 type Unpivot struct {
-	FieldNameAs string `toml:"use_fieldname_as"`
-	TagKey      string `toml:"tag_key"`
-	ValueKey    string `toml:"value_key"`
+	FieldNameAs   string          `toml:"use_fieldname_as"`
+	TagKey        string          `toml:"tag_key"`
+	ValueKey      string          `toml:"value_key"`
+	ExcludeFields []string        `toml:"exclude_fields"`
+	Log           telegraf.Logger `toml:"-"`
+
+	excludeFilter filter.Filter
+	conflicts     map[string]bool
 }
 
 func copyWithoutFields(metric telegraf.Metric) telegraf.Metric {
@@ -53,6 +59,12 @@ func (p *Unpivot) Init() error {
 		p.ValueKey = "value"
 	}
 
+	f, err := filter.Compile(p.ExcludeFields)
+	if err != nil {
+		return fmt.Errorf("creating exclude filter failed: %w", err)
+	}
+	p.excludeFilter = f
+
 	return nil
 }
 
@@ -70,7 +82,20 @@ func (p *Unpivot) Apply(metrics ...telegraf.Metric) []telegraf.Metric {
 		}
 		base = copyWithoutFields(base)
 
+		// Keep the excluded fields on every resulting metric
+		exploded := make([]*telegraf.Field, 0, len(m.FieldList()))
 		for _, field := range m.FieldList() {
+			if p.excludeFilter == nil || !p.excludeFilter.Match(field.Key) {
+				exploded = append(exploded, field)
+				continue
+			}
+			if field.Key == p.ValueKey {
+				p.logConflict(field.Key)
+			}
+			base.AddField(field.Key, field.Value)
+		}
+
+		for _, field := range exploded {
 			newMetric := base.Copy()
 			newMetric.AddField(p.ValueKey, field.Value)
 
@@ -88,6 +113,18 @@ func (p *Unpivot) Apply(metrics ...telegraf.Metric) []telegraf.Metric {
 	return results
 }
 
+// logConflict reports a conflicting field name once per name
+func (p *Unpivot) logConflict(name string) {
+	if p.conflicts == nil {
+		p.conflicts = make(map[string]bool)
+	}
+	if p.conflicts[name] {
+		return
+	}
+	p.conflicts[name] = true
+	p.Log.Warnf("Excluded field %q conflicts with value key and will be overwritten", name)
+}
+
 func init() {
 	processors.Add("unpivot", func() telegraf.Processor {
 		return &Unpivot{}
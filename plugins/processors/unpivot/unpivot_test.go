@@ -223,6 +223,71 @@ func TestUnpivot_fieldMode(t *testing.T) {
 	}
 }
 
+func TestUnpivot_excludeFields(t *testing.T) {
+	logger := &testutil.CaptureLogger{}
+	plugin := &Unpivot{
+		ExcludeFields: []string{"serial*", "value"},
+		Log:           logger,
+	}
+	require.NoError(t, plugin.Init())
+
+	now := time.Now()
+	input := []telegraf.Metric{
+		testutil.MustMetric("cpu",
+			map[string]string{},
+			map[string]interface{}{
+				"idle_time":     int64(42),
+				"idle_user":     int64(43),
+				"serial_number": "abc",
+			},
+			now,
+		),
+		testutil.MustMetric("cpu",
+			map[string]string{},
+			map[string]interface{}{
+				"idle_time": int64(44),
+				"value":     int64(1),
+			},
+			now,
+		),
+		testutil.MustMetric("cpu",
+			map[string]string{},
+			map[string]interface{}{
+				"idle_time": int64(45),
+				"value":     int64(2),
+			},
+			now,
+		),
+	}
+	expected := []telegraf.Metric{
+		testutil.MustMetric("cpu",
+			map[string]string{"name": "idle_time"},
+			map[string]interface{}{"value": int64(42), "serial_number": "abc"},
+			now,
+		),
+		testutil.MustMetric("cpu",
+			map[string]string{"name": "idle_user"},
+			map[string]interface{}{"value": int64(43), "serial_number": "abc"},
+			now,
+		),
+		testutil.MustMetric("cpu",
+			map[string]string{"name": "idle_time"},
+			map[string]interface{}{"value": int64(44)},
+			now,
+		),
+		testutil.MustMetric("cpu",
+			map[string]string{"name": "idle_time"},
+			map[string]interface{}{"value": int64(45)},
+			now,
+		),
+	}
+
+	actual := plugin.Apply(input...)
+	testutil.RequireMetricsEqual(t, expected, actual, testutil.SortMetrics())
+	require.Len(t, logger.Warnings(), 1)
+	require.Contains(t, logger.Warnings()[0], `"value" conflicts`)
+}
+
 func TestTrackedMetricNotLost(t *testing.T) {
 	var mu sync.Mutex
 	delivered := make([]telegraf.DeliveryInfo, 0, 3)
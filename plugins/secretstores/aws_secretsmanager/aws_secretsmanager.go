@@ -0,0 +1,190 @@
+//go:generate ../../../tools/readme_config_includer/generator
+package aws_secretsmanager
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	common_aws "github.com/influxdata/telegraf/plugins/common/aws"
+	"github.com/influxdata/telegraf/plugins/secretstores"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+type secretsManagerClient interface {
+	GetSecretValue(
+		ctx context.Context,
+		params *secretsmanager.GetSecretValueInput,
+		optFns ...func(*secretsmanager.Options),
+	) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+type cacheEntry struct {
+	value  []byte
+	expiry time.Time
+}
+
+type AWSSecretsManager struct {
+	TTL        config.Duration `toml:"ttl"`
+	MaxRetries int             `toml:"max_retries"`
+	Timeout    config.Duration `toml:"timeout"`
+	Log        telegraf.Logger `toml:"-"`
+	common_aws.CredentialConfig
+
+	client secretsManagerClient
+
+	sync.Mutex
+	cache map[string]cacheEntry
+}
+
+func (*AWSSecretsManager) SampleConfig() string {
+	return sampleConfig
+}
+
+// Init initializes all internals of the secret-store
+func (s *AWSSecretsManager) Init() error {
+	if s.MaxRetries < 0 {
+		return fmt.Errorf("invalid 'max_retries' %d", s.MaxRetries)
+	}
+	s.cache = make(map[string]cacheEntry)
+
+	cfg, err := s.CredentialConfig.Credentials()
+	if err != nil {
+		return fmt.Errorf("getting credentials failed: %w", err)
+	}
+
+	// Throttled requests are retried by the client using exponential backoff
+	s.client = secretsmanager.NewFromConfig(cfg, func(o *secretsmanager.Options) {
+		o.RetryMaxAttempts = s.MaxRetries + 1
+		if s.CredentialConfig.EndpointURL != "" {
+			o.BaseEndpoint = &s.CredentialConfig.EndpointURL
+		}
+	})
+
+	return nil
+}
+
+// Get searches for the given key and return the secret. The key is either the
+// name of the secret or the name followed by '#' and a key within the
+// JSON-encoded secret value.
+func (s *AWSSecretsManager) Get(key string) ([]byte, error) {
+	name, field, hasField := strings.Cut(key, "#")
+	if name == "" {
+		return nil, errors.New("empty secret name")
+	}
+
+	value, err := s.fetch(name)
+	if err != nil {
+		return nil, err
+	}
+	if !hasField {
+		return bytes.Clone(value), nil
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(value, &data); err != nil {
+		return nil, fmt.Errorf("decoding secret %q as JSON failed: %w", name, err)
+	}
+	raw, found := data[field]
+	if !found {
+		return nil, fmt.Errorf("key %q not found in secret %q", field, name)
+	}
+	if v, ok := raw.(string); ok {
+		return []byte(v), nil
+	}
+	return json.Marshal(raw)
+}
+
+// Set sets the given secret for the given key
+func (*AWSSecretsManager) Set(_, _ string) error {
+	return errors.New("setting secrets not supported")
+}
+
+// List lists the names of all cached secrets
+func (s *AWSSecretsManager) List() ([]string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := make([]string, 0, len(s.cache))
+	for k := range s.cache {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// GetResolver returns a function to resolve the given key.
+func (s *AWSSecretsManager) GetResolver(key string) (telegraf.ResolveFunc, error) {
+	// Secrets are fetched again after the TTL expired so report the resolver
+	// as dynamic to make plugins pick up rotated secrets.
+	dynamic := s.TTL > 0
+	resolver := func() ([]byte, bool, error) {
+		v, err := s.Get(key)
+		return v, dynamic, err
+	}
+	return resolver, nil
+}
+
+// fetch returns the value of the secret with the given name either from the
+// cache or from the service if not cached or expired
+func (s *AWSSecretsManager) fetch(name string) ([]byte, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if entry, found := s.cache[name]; found && (s.TTL <= 0 || time.Now().Before(entry.expiry)) {
+		return entry.value, nil
+	}
+
+	ctx := context.Background()
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(s.Timeout))
+		defer cancel()
+	}
+
+	out, err := s.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(name)})
+	if err != nil {
+		return nil, fmt.Errorf("getting secret %q failed: %w", name, err)
+	}
+
+	var value []byte
+	switch {
+	case out.SecretString != nil:
+		value = []byte(*out.SecretString)
+	case out.SecretBinary != nil:
+		value = out.SecretBinary
+	default:
+		return nil, fmt.Errorf("secret %q has no value", name)
+	}
+	s.cache[name] = cacheEntry{
+		value:  value,
+		expiry: time.Now().Add(time.Duration(s.TTL)),
+	}
+
+	return value, nil
+}
+
+// Register the secret-store on load.
+func init() {
+	secretstores.Add("aws_secretsmanager", func(string) telegraf.SecretStore {
+		return &AWSSecretsManager{
+			TTL:        config.Duration(5 * time.Minute),
+			MaxRetries: 5,
+			Timeout:    config.Duration(10 * time.Second),
+		}
+	})
+}
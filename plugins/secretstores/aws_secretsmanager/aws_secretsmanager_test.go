@@ -0,0 +1,223 @@
+package aws_secretsmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf/config"
+	common_aws "github.com/influxdata/telegraf/plugins/common/aws"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+type mockClient struct {
+	secrets map[string]*secretsmanager.GetSecretValueOutput
+	calls   int
+}
+
+func (m *mockClient) GetSecretValue(
+	_ context.Context,
+	params *secretsmanager.GetSecretValueInput,
+	_ ...func(*secretsmanager.Options),
+) (*secretsmanager.GetSecretValueOutput, error) {
+	m.calls++
+	out, found := m.secrets[*params.SecretId]
+	if !found {
+		return nil, errors.New("ResourceNotFoundException")
+	}
+	return out, nil
+}
+
+func newPlugin(t *testing.T, client *mockClient) *AWSSecretsManager {
+	t.Helper()
+
+	plugin := &AWSSecretsManager{
+		TTL:        config.Duration(5 * time.Minute),
+		MaxRetries: 5,
+		Log:        testutil.Logger{},
+		CredentialConfig: common_aws.CredentialConfig{
+			Region:    "us-east-1",
+			AccessKey: "dummy",
+			SecretKey: "dummy",
+		},
+	}
+	require.NoError(t, plugin.Init())
+	plugin.client = client
+	return plugin
+}
+
+func TestSampleConfig(t *testing.T) {
+	plugin := &AWSSecretsManager{}
+	require.NotEmpty(t, plugin.SampleConfig())
+}
+
+func TestInitInvalidRetries(t *testing.T) {
+	plugin := &AWSSecretsManager{MaxRetries: -1}
+	require.ErrorContains(t, plugin.Init(), "invalid 'max_retries' -1")
+}
+
+func TestGet(t *testing.T) {
+	client := &mockClient{
+		secrets: map[string]*secretsmanager.GetSecretValueOutput{
+			"telegraf/influx_token": {SecretString: aws.String("Ood Bnar")},
+			"telegraf/database":     {SecretString: aws.String(`{"username": "telegraf", "password": "s3cr3t", "port": 5432}`)},
+			"telegraf/binary":       {SecretBinary: []byte{0x01, 0x02}},
+			"telegraf/empty":        {},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		expected string
+		err      string
+	}{
+		{
+			name:     "plain secret",
+			key:      "telegraf/influx_token",
+			expected: "Ood Bnar",
+		},
+		{
+			name:     "json key",
+			key:      "telegraf/database#password",
+			expected: "s3cr3t",
+		},
+		{
+			name:     "json key non-string",
+			key:      "telegraf/database#port",
+			expected: "5432",
+		},
+		{
+			name:     "whole json secret",
+			key:      "telegraf/database",
+			expected: `{"username": "telegraf", "password": "s3cr3t", "port": 5432}`,
+		},
+		{
+			name:     "binary secret",
+			key:      "telegraf/binary",
+			expected: "\x01\x02",
+		},
+		{
+			name: "missing json key",
+			key:  "telegraf/database#hostname",
+			err:  `key "hostname" not found in secret "telegraf/database"`,
+		},
+		{
+			name: "json key in non-json secret",
+			key:  "telegraf/influx_token#password",
+			err:  `decoding secret "telegraf/influx_token" as JSON failed`,
+		},
+		{
+			name: "unknown secret",
+			key:  "telegraf/unknown",
+			err:  `getting secret "telegraf/unknown" failed: ResourceNotFoundException`,
+		},
+		{
+			name: "secret without value",
+			key:  "telegraf/empty",
+			err:  `secret "telegraf/empty" has no value`,
+		},
+		{
+			name: "empty name",
+			key:  "#password",
+			err:  "empty secret name",
+		},
+	}
+
+	plugin := newPlugin(t, client)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := plugin.Get(tt.key)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, string(actual))
+		})
+	}
+}
+
+func TestCache(t *testing.T) {
+	client := &mockClient{
+		secrets: map[string]*secretsmanager.GetSecretValueOutput{
+			"telegraf/database": {SecretString: aws.String(`{"username": "telegraf", "password": "s3cr3t"}`)},
+			"telegraf/token":    {SecretString: aws.String("Thon")},
+		},
+	}
+	plugin := newPlugin(t, client)
+
+	// Keys of the same secret are served from the cache
+	_, err := plugin.Get("telegraf/database#username")
+	require.NoError(t, err)
+	_, err = plugin.Get("telegraf/database#password")
+	require.NoError(t, err)
+	require.Equal(t, 1, client.calls)
+
+	// Only cached secrets are listed
+	keys, err := plugin.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"telegraf/database"}, keys)
+
+	_, err = plugin.Get("telegraf/token")
+	require.NoError(t, err)
+	keys, err = plugin.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"telegraf/database", "telegraf/token"}, keys)
+}
+
+func TestResolverRefetchesAfterTTL(t *testing.T) {
+	client := &mockClient{
+		secrets: map[string]*secretsmanager.GetSecretValueOutput{
+			"telegraf/token": {SecretString: aws.String("Ood Bnar")},
+		},
+	}
+	plugin := newPlugin(t, client)
+	plugin.TTL = config.Duration(100 * time.Millisecond)
+
+	resolver, err := plugin.GetResolver("telegraf/token")
+	require.NoError(t, err)
+	secret, dynamic, err := resolver()
+	require.NoError(t, err)
+	require.True(t, dynamic)
+	require.Equal(t, "Ood Bnar", string(secret))
+
+	// Rotate the secret, the cached value is used until the TTL expired
+	client.secrets["telegraf/token"] = &secretsmanager.GetSecretValueOutput{SecretString: aws.String("Thon")}
+	secret, _, err = resolver()
+	require.NoError(t, err)
+	require.Equal(t, "Ood Bnar", string(secret))
+
+	time.Sleep(150 * time.Millisecond)
+	secret, _, err = resolver()
+	require.NoError(t, err)
+	require.Equal(t, "Thon", string(secret))
+	require.Equal(t, 2, client.calls)
+}
+
+func TestResolverStaticWithoutTTL(t *testing.T) {
+	client := &mockClient{
+		secrets: map[string]*secretsmanager.GetSecretValueOutput{
+			"telegraf/token": {SecretString: aws.String("Ood Bnar")},
+		},
+	}
+	plugin := newPlugin(t, client)
+	plugin.TTL = 0
+
+	resolver, err := plugin.GetResolver("telegraf/token")
+	require.NoError(t, err)
+	secret, dynamic, err := resolver()
+	require.NoError(t, err)
+	require.False(t, dynamic)
+	require.Equal(t, "Ood Bnar", string(secret))
+}
+
+func TestSetUnsupported(t *testing.T) {
+	plugin := newPlugin(t, &mockClient{})
+	require.ErrorContains(t, plugin.Set("foo", "bar"), "not supported")
+}
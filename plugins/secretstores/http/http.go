@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/blues/jsonata-go"
@@ -26,6 +28,9 @@ var sampleConfig string
 
 const defaultIdleConnTimeoutMinutes = 5
 
+// Maximum time to wait between retries of failed requests
+const maxRetryBackoff = 30 * time.Second
+
 type HTTP struct {
 	URL                string            `toml:"url"`
 	Headers            map[string]string `toml:"headers"`
@@ -34,14 +39,28 @@ type HTTP struct {
 	Token              config.Secret     `toml:"token"`
 	SuccessStatusCodes []int             `toml:"success_status_codes"`
 	Transformation     string            `toml:"transformation"`
+	CacheTTL           config.Duration   `toml:"cache_ttl"`
+	MaxRetries         int               `toml:"max_retries"`
+	RetryBackoff       config.Duration   `toml:"retry_backoff"`
 	Log                telegraf.Logger   `toml:"-"`
 	common_http.HTTPClientConfig
 	DecryptionConfig
 
 	client      *http.Client
 	transformer *jsonata.Expr
-	cache       map[string]string
 	decrypter   Decrypter
+
+	// fetch serializes downloads, the cache is not locked while downloading
+	// to not block invalidation during retries
+	fetch sync.Mutex
+
+	// invalidated counts the invalidations and fetched the invalidations
+	// already observed when starting the last successful download
+	sync.Mutex
+	cache       map[string]string
+	expiry      time.Time
+	invalidated uint64
+	fetched     uint64
 }
 
 func (h *HTTP) SampleConfig() string {
@@ -49,18 +68,18 @@ func (h *HTTP) SampleConfig() string {
 }
 
 func (h *HTTP) Init() error {
-	ctx := context.Background()
+	if h.MaxRetries < 0 {
+		return fmt.Errorf("invalid 'max_retries' %d", h.MaxRetries)
+	}
 
 	// Prevent idle connections from hanging around forever on telegraf reload
 	if h.HTTPClientConfig.IdleConnTimeout == 0 {
 		h.HTTPClientConfig.IdleConnTimeout = config.Duration(defaultIdleConnTimeoutMinutes * time.Minute)
 	}
 
-	client, err := h.HTTPClientConfig.CreateClient(ctx, h.Log)
-	if err != nil {
+	if err := h.createClient(); err != nil {
 		return err
 	}
-	h.client = client
 
 	// Set default as [200]
 	if len(h.SuccessStatusCodes) == 0 {
@@ -77,17 +96,38 @@ func (h *HTTP) Init() error {
 	}
 
 	// Setup the decryption infrastructure
-	h.decrypter, err = h.DecryptionConfig.CreateDecrypter()
+	decrypter, err := h.DecryptionConfig.CreateDecrypter()
 	if err != nil {
 		return fmt.Errorf("creating decryptor failed: %w", err)
 	}
+	h.decrypter = decrypter
 
 	return nil
 }
 
+// createClient sets up the HTTP client. When using OAuth2 the client obtains
+// a new token before the current one expires.
+func (h *HTTP) createClient() error {
+	client, err := h.HTTPClientConfig.CreateClient(context.Background(), h.Log)
+	if err != nil {
+		return err
+	}
+	if h.client != nil {
+		h.client.CloseIdleConnections()
+	}
+	h.client = client
+	return nil
+}
+
 // Get searches for the given key and return the secret
 func (h *HTTP) Get(key string) ([]byte, error) {
+	if err := h.update(); err != nil {
+		return nil, err
+	}
+
+	h.Lock()
 	v, found := h.cache[key]
+	h.Unlock()
 	if !found {
 		return nil, errors.New("not found")
 	}
@@ -112,28 +152,75 @@ func (h *HTTP) Set(_, _ string) error {
 
 // List lists all known secret keys
 func (h *HTTP) List() ([]string, error) {
+	if err := h.update(); err != nil {
+		return nil, err
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
 	keys := make([]string, 0, len(h.cache))
 	for k := range h.cache {
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 	return keys, nil
 }
 
 // GetResolver returns a function to resolve the given key.
 func (h *HTTP) GetResolver(key string) (telegraf.ResolveFunc, error) {
-	// Download and parse the credentials
-	if err := h.download(); err != nil {
-		return nil, err
+	// Download and parse the credentials if not done yet
+	h.fetch.Lock()
+	h.Lock()
+	loaded := h.cache != nil
+	h.Unlock()
+	if !loaded {
+		if err := h.download(); err != nil {
+			h.fetch.Unlock()
+			return nil, err
+		}
 	}
+	h.fetch.Unlock()
 
+	// Secrets are downloaded again after the cache TTL expired so report
+	// the resolver as dynamic to make plugins pick up rotated secrets.
+	dynamic := h.CacheTTL > 0
 	resolver := func() ([]byte, bool, error) {
 		s, err := h.Get(key)
-		return s, false, err
+		return s, dynamic, err
 	}
 	return resolver, nil
 }
 
+// Invalidate forces downloading the secrets again on the next resolution as
+// the secret of the given key was rejected. As all secrets are contained in
+// the same document, the whole cache is invalidated.
+func (h *HTTP) Invalidate(string) {
+	h.Lock()
+	defer h.Unlock()
+	h.invalidated++
+}
+
+// update downloads the secrets again if the cache expired or was invalidated
+func (h *HTTP) update() error {
+	h.fetch.Lock()
+	defer h.fetch.Unlock()
+
+	h.Lock()
+	current := h.invalidated == h.fetched && (h.CacheTTL <= 0 || time.Now().Before(h.expiry))
+	h.Unlock()
+	if current {
+		return nil
+	}
+	return h.download()
+}
+
 func (h *HTTP) download() error {
+	// Invalidations arriving during the download must cause another one
+	h.Lock()
+	invalidated := h.invalidated
+	h.Unlock()
+
 	// Get the raw data form the URL
 	data, err := h.query()
 	if err != nil {
@@ -150,21 +237,63 @@ func (h *HTTP) download() error {
 	}
 
 	// Extract the data from the resulting data
-	if err := json.Unmarshal(data, &h.cache); err != nil {
+	var cache map[string]string
+	if err := json.Unmarshal(data, &cache); err != nil {
 		var terr *json.UnmarshalTypeError
 		if errors.As(err, &terr) {
 			return fmt.Errorf("%w; maybe missing or wrong data transformation", err)
 		}
 		return err
 	}
+	if cache == nil {
+		cache = make(map[string]string)
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	h.cache = cache
+	h.expiry = time.Now().Add(time.Duration(h.CacheTTL))
+	h.fetched = invalidated
 
 	return nil
 }
 
+// query requests the secrets from the URL. Requests failing with a server
+// error are retried with an exponential backoff. If the request is rejected
+// as unauthorized when using OAuth2, a new token is requested once as the
+// current token might have been revoked.
 func (h *HTTP) query() ([]byte, error) {
+	backoff := time.Duration(h.RetryBackoff)
+	var renewed bool
+	for retry := 0; ; retry++ {
+		data, status, err := h.request()
+		if err == nil {
+			return data, nil
+		}
+
+		switch {
+		case status == http.StatusUnauthorized && h.OAuth2Config.ClientID != "" && !renewed:
+			h.Log.Debug("Request unauthorized, renewing OAuth2 token")
+			if cerr := h.createClient(); cerr != nil {
+				return nil, fmt.Errorf("%w; recreating client failed: %w", err, cerr)
+			}
+			renewed = true
+		case status >= 500 && retry < h.MaxRetries:
+			h.Log.Debugf("Request failed, retrying in %s: %v", backoff, err)
+			time.Sleep(backoff)
+			backoff = min(2*backoff, maxRetryBackoff)
+		default:
+			return nil, err
+		}
+	}
+}
+
+// request executes a single request and returns the response body or the
+// status code of a failed request
+func (h *HTTP) request() ([]byte, int, error) {
 	request, err := http.NewRequest(http.MethodGet, h.URL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("creating request failed: %w", err)
+		return nil, 0, fmt.Errorf("creating request failed: %w", err)
 	}
 
 	for k, v := range h.Headers {
@@ -176,12 +305,12 @@ func (h *HTTP) query() ([]byte, error) {
 	}
 
 	if err := h.setRequestAuth(request); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	resp, err := h.client.Do(request)
 	if err != nil {
-		return nil, fmt.Errorf("executing request failed: %w", err)
+		return nil, 0, fmt.Errorf("executing request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -199,10 +328,11 @@ func (h *HTTP) query() ([]byte, error) {
 
 	if !responseHasSuccessCode {
 		msg := "received status code %d (%s), expected any value out of %v"
-		return nil, fmt.Errorf(msg, resp.StatusCode, http.StatusText(resp.StatusCode), h.SuccessStatusCodes)
+		return nil, resp.StatusCode, fmt.Errorf(msg, resp.StatusCode, http.StatusText(resp.StatusCode), h.SuccessStatusCodes)
 	}
 
-	return io.ReadAll(resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	return data, resp.StatusCode, err
 }
 
 func (h *HTTP) setRequestAuth(request *http.Request) error {
@@ -236,6 +366,9 @@ func (h *HTTP) setRequestAuth(request *http.Request) error {
 // Register the secret-store on load.
 func init() {
 	secretstores.Add("http", func(string) telegraf.SecretStore {
-		return &HTTP{}
+		return &HTTP{
+			MaxRetries:   3,
+			RetryBackoff: config.Duration(time.Second),
+		}
 	})
 }
@@ -2,12 +2,14 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -431,3 +433,275 @@ func TestAuthenticationToken(t *testing.T) {
 	require.NotEmpty(t, auth)
 	require.Equal(t, "Bearer "+token, auth)
 }
+
+func TestCacheTTL(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := requests.Add(1)
+		if _, err := fmt.Fprintf(w, `{"test": "password-%d"}`, n); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			t.Error(err)
+			return
+		}
+	}))
+	defer server.Close()
+
+	plugin := &HTTP{
+		URL:      server.URL,
+		CacheTTL: config.Duration(100 * time.Millisecond),
+		Log:      testutil.Logger{},
+	}
+	plugin.Timeout = config.Duration(200 * time.Millisecond)
+	require.NoError(t, plugin.Init())
+
+	resolver, err := plugin.GetResolver("test")
+	require.NoError(t, err)
+	_, err = plugin.GetResolver("test")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), requests.Load())
+
+	// The secrets are served from the cache until the TTL expired
+	s, dynamic, err := resolver()
+	require.NoError(t, err)
+	require.True(t, dynamic)
+	require.Equal(t, "password-1", string(s))
+
+	require.Eventually(t, func() bool {
+		s, _, err := resolver()
+		return err == nil && string(s) == "password-2"
+	}, 3*time.Second, 50*time.Millisecond)
+	require.Equal(t, int64(2), requests.Load())
+}
+
+func TestInvalidate(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := requests.Add(1)
+		if _, err := fmt.Fprintf(w, `{"test": "password-%d"}`, n); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			t.Error(err)
+			return
+		}
+	}))
+	defer server.Close()
+
+	plugin := &HTTP{
+		URL:      server.URL,
+		CacheTTL: config.Duration(time.Hour),
+		Log:      testutil.Logger{},
+	}
+	plugin.Timeout = config.Duration(200 * time.Millisecond)
+	require.NoError(t, plugin.Init())
+
+	resolver, err := plugin.GetResolver("test")
+	require.NoError(t, err)
+	s, _, err := resolver()
+	require.NoError(t, err)
+	require.Equal(t, "password-1", string(s))
+
+	// Rejected secrets must be fetched again on the next resolution
+	plugin.Invalidate("test")
+	s, _, err = resolver()
+	require.NoError(t, err)
+	require.Equal(t, "password-2", string(s))
+	s, _, err = resolver()
+	require.NoError(t, err)
+	require.Equal(t, "password-2", string(s))
+}
+
+func TestInvalidateWithoutCacheTTL(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := requests.Add(1)
+		if _, err := fmt.Fprintf(w, `{"test": "password-%d"}`, n); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			t.Error(err)
+			return
+		}
+	}))
+	defer server.Close()
+
+	plugin := &HTTP{
+		URL: server.URL,
+		Log: testutil.Logger{},
+	}
+	plugin.Timeout = config.Duration(200 * time.Millisecond)
+	require.NoError(t, plugin.Init())
+
+	// Secrets are static without cache TTL but invalidation still applies
+	resolver, err := plugin.GetResolver("test")
+	require.NoError(t, err)
+	s, dynamic, err := resolver()
+	require.NoError(t, err)
+	require.False(t, dynamic)
+	require.Equal(t, "password-1", string(s))
+
+	plugin.Invalidate("test")
+	s, _, err = resolver()
+	require.NoError(t, err)
+	require.Equal(t, "password-2", string(s))
+}
+
+func TestInvalidateDuringRetry(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if n := requests.Add(1); n == 2 || n == 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if _, err := w.Write([]byte(`{"test": "password"}`)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			t.Error(err)
+			return
+		}
+	}))
+	defer server.Close()
+
+	plugin := &HTTP{
+		URL:          server.URL,
+		MaxRetries:   3,
+		RetryBackoff: config.Duration(200 * time.Millisecond),
+		Log:          testutil.Logger{},
+	}
+	plugin.Timeout = config.Duration(200 * time.Millisecond)
+	require.NoError(t, plugin.Init())
+
+	resolver, err := plugin.GetResolver("test")
+	require.NoError(t, err)
+	plugin.Invalidate("test")
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := resolver()
+		done <- err
+	}()
+	require.Eventually(t, func() bool {
+		return requests.Load() >= 2
+	}, 3*time.Second, 10*time.Millisecond)
+
+	// Invalidating must not wait for the download backing off
+	start := time.Now()
+	plugin.Invalidate("test")
+	require.Less(t, time.Since(start), 100*time.Millisecond)
+
+	require.NoError(t, <-done)
+
+	// The invalidation during the download must not get lost
+	n := requests.Load()
+	_, _, err = resolver()
+	require.NoError(t, err)
+	require.Equal(t, n+1, requests.Load())
+}
+
+func TestRetryServerErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int64
+		status   int
+		expected string
+	}{
+		{
+			name:     "recovers",
+			failures: 2,
+			status:   http.StatusServiceUnavailable,
+		},
+		{
+			name:     "retries exhausted",
+			failures: 4,
+			status:   http.StatusBadGateway,
+			expected: "received status code 502",
+		},
+		{
+			name:     "client error not retried",
+			failures: 1,
+			status:   http.StatusNotFound,
+			expected: "received status code 404",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if requests.Add(1) <= tt.failures {
+					w.WriteHeader(tt.status)
+					return
+				}
+				if _, err := w.Write([]byte(`{"test": "password"}`)); err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					t.Error(err)
+					return
+				}
+			}))
+			defer server.Close()
+
+			plugin := &HTTP{
+				URL:          server.URL,
+				MaxRetries:   3,
+				RetryBackoff: config.Duration(time.Millisecond),
+				Log:          testutil.Logger{},
+			}
+			plugin.Timeout = config.Duration(200 * time.Millisecond)
+			require.NoError(t, plugin.Init())
+
+			_, err := plugin.GetResolver("test")
+			if tt.expected != "" {
+				require.ErrorContains(t, err, tt.expected)
+				require.Equal(t, min(tt.failures, 4), requests.Load())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.failures+1, requests.Load())
+		})
+	}
+}
+
+func TestOAuth2TokenRenewal(t *testing.T) {
+	var tokens atomic.Int64
+	var revoked atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			// Issue tokens expiring right away to force a refresh per request
+			n := tokens.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			if _, err := fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "bearer", "expires_in": 1}`, n); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				t.Error(err)
+			}
+		case "/secrets":
+			if revoked.Load() && r.Header.Get("Authorization") == fmt.Sprintf("Bearer token-%d", tokens.Load()) {
+				revoked.Store(false)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if _, err := w.Write([]byte(`{"test": "password"}`)); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				t.Error(err)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	plugin := &HTTP{
+		URL: server.URL + "/secrets",
+		Log: testutil.Logger{},
+	}
+	plugin.ClientID = "client"
+	plugin.ClientSecret = "secret"
+	plugin.TokenURL = server.URL + "/token"
+	plugin.Timeout = config.Duration(time.Second)
+	require.NoError(t, plugin.Init())
+
+	require.NoError(t, plugin.download())
+	require.NoError(t, plugin.download())
+	require.Equal(t, int64(2), tokens.Load())
+
+	// Rejected tokens are renewed
+	revoked.Store(true)
+	require.NoError(t, plugin.download())
+	require.False(t, revoked.Load())
+	require.Equal(t, int64(4), tokens.Load())
+}
@@ -0,0 +1,463 @@
+//go:generate ../../../tools/readme_config_includer/generator
+package vault
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/common/tls"
+	"github.com/influxdata/telegraf/plugins/secretstores"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+// Minimal time to wait before retrying a failed token renewal
+var retryInterval = 10 * time.Second
+
+type Vault struct {
+	Address             string          `toml:"address"`
+	Namespace           string          `toml:"namespace"`
+	Mount               string          `toml:"mount"`
+	Path                string          `toml:"path"`
+	AuthMethod          string          `toml:"auth_method"`
+	Token               config.Secret   `toml:"token"`
+	AppRoleMount        string          `toml:"approle_mount"`
+	RoleID              config.Secret   `toml:"role_id"`
+	SecretID            config.Secret   `toml:"secret_id"`
+	KubernetesMount     string          `toml:"kubernetes_mount"`
+	KubernetesRole      string          `toml:"kubernetes_role"`
+	KubernetesTokenFile string          `toml:"kubernetes_token_file"`
+	TTL                 config.Duration `toml:"ttl"`
+	Timeout             config.Duration `toml:"timeout"`
+	Log                 telegraf.Logger `toml:"-"`
+	tls.ClientConfig
+
+	client *http.Client
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	sync.Mutex
+	cache  map[string]string
+	expiry time.Time
+
+	auth      sync.Mutex
+	token     config.Secret
+	lease     time.Duration
+	expires   time.Time
+	renewable bool
+}
+
+// authResponse is the response of login and token renewal requests
+type authResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+	} `json:"auth"`
+}
+
+func (*Vault) SampleConfig() string {
+	return sampleConfig
+}
+
+// Init initializes all internals of the secret-store
+func (v *Vault) Init() error {
+	if v.Address == "" {
+		return errors.New("'address' required")
+	}
+	v.Address = strings.TrimSuffix(v.Address, "/")
+	v.Mount = strings.Trim(v.Mount, "/")
+	v.Path = strings.Trim(v.Path, "/")
+	if v.Path == "" {
+		return errors.New("'path' required")
+	}
+
+	switch v.AuthMethod {
+	case "", "token":
+		v.AuthMethod = "token"
+		if v.Token.Empty() {
+			return errors.New("'token' required for token authentication")
+		}
+	case "approle":
+		if v.RoleID.Empty() {
+			return errors.New("'role_id' required for AppRole authentication")
+		}
+	case "kubernetes":
+		if v.KubernetesRole == "" {
+			return errors.New("'kubernetes_role' required for Kubernetes authentication")
+		}
+	default:
+		return fmt.Errorf("unknown 'auth_method' %q", v.AuthMethod)
+	}
+
+	tlsCfg, err := v.ClientConfig.TLSConfig()
+	if err != nil {
+		return fmt.Errorf("creating TLS configuration failed: %w", err)
+	}
+	v.client = &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsCfg,
+			Proxy:           http.ProxyFromEnvironment,
+		},
+		Timeout: time.Duration(v.Timeout),
+	}
+
+	// Authenticate and keep the token alive in the background
+	if err := v.authenticate(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	v.wg.Add(1)
+	go func() {
+		defer v.wg.Done()
+		v.maintainToken(ctx)
+	}()
+
+	return nil
+}
+
+// Stop terminates the background renewal of the token
+func (v *Vault) Stop() {
+	if v.cancel != nil {
+		v.cancel()
+	}
+	v.wg.Wait()
+}
+
+// Get searches for the given key and return the secret
+func (v *Vault) Get(key string) ([]byte, error) {
+	v.Lock()
+	defer v.Unlock()
+
+	if err := v.update(); err != nil {
+		return nil, err
+	}
+
+	value, found := v.cache[key]
+	if !found {
+		return nil, fmt.Errorf("field %q not found in secret %q", key, v.Path)
+	}
+	return []byte(value), nil
+}
+
+// Set sets the given secret for the given key
+func (*Vault) Set(_, _ string) error {
+	return errors.New("setting secrets not supported")
+}
+
+// List lists all known secret keys
+func (v *Vault) List() ([]string, error) {
+	v.Lock()
+	defer v.Unlock()
+
+	if err := v.update(); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(v.cache))
+	for k := range v.cache {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// GetResolver returns a function to resolve the given key.
+func (v *Vault) GetResolver(key string) (telegraf.ResolveFunc, error) {
+	// Secrets are re-read after the TTL expired so report the resolver as
+	// dynamic to make plugins pick up rotated secrets.
+	dynamic := v.TTL > 0
+	resolver := func() ([]byte, bool, error) {
+		s, err := v.Get(key)
+		return s, dynamic, err
+	}
+	return resolver, nil
+}
+
+// update reads the secret from the KV v2 engine if not cached or expired
+func (v *Vault) update() error {
+	if v.cache != nil && (v.TTL <= 0 || time.Now().Before(v.expiry)) {
+		return nil
+	}
+
+	var response struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	endpoint := v.Mount + "/data/" + v.Path
+	if err := v.request(http.MethodGet, endpoint, true, nil, &response); err != nil {
+		return fmt.Errorf("reading secret %q failed: %w", v.Path, err)
+	}
+
+	cache := make(map[string]string, len(response.Data.Data))
+	for k, raw := range response.Data.Data {
+		switch value := raw.(type) {
+		case string:
+			cache[k] = value
+		default:
+			buf, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("encoding field %q failed: %w", k, err)
+			}
+			cache[k] = string(buf)
+		}
+	}
+	v.cache = cache
+	v.expiry = time.Now().Add(time.Duration(v.TTL))
+
+	return nil
+}
+
+// authenticate obtains a token using the configured authentication method
+func (v *Vault) authenticate() error {
+	if v.AuthMethod == "token" {
+		return v.lookupToken()
+	}
+
+	var endpoint string
+	var body map[string]string
+	switch v.AuthMethod {
+	case "approle":
+		roleID, err := v.RoleID.Get()
+		if err != nil {
+			return fmt.Errorf("getting role ID failed: %w", err)
+		}
+		defer roleID.Destroy()
+		body = map[string]string{"role_id": roleID.String()}
+
+		if !v.SecretID.Empty() {
+			secretID, err := v.SecretID.Get()
+			if err != nil {
+				return fmt.Errorf("getting secret ID failed: %w", err)
+			}
+			defer secretID.Destroy()
+			body["secret_id"] = secretID.String()
+		}
+		endpoint = "auth/" + v.AppRoleMount + "/login"
+	case "kubernetes":
+		jwt, err := os.ReadFile(v.KubernetesTokenFile)
+		if err != nil {
+			return fmt.Errorf("reading service account token failed: %w", err)
+		}
+		body = map[string]string{
+			"role": v.KubernetesRole,
+			"jwt":  strings.TrimSpace(string(jwt)),
+		}
+		endpoint = "auth/" + v.KubernetesMount + "/login"
+	}
+
+	// Login endpoints do not require a token
+	var response authResponse
+	if err := v.request(http.MethodPost, endpoint, false, body, &response); err != nil {
+		return fmt.Errorf("%s login failed: %w", v.AuthMethod, err)
+	}
+	v.setToken(&response)
+
+	return nil
+}
+
+// lookupToken determines the lease of the configured token
+func (v *Vault) lookupToken() error {
+	token, err := v.Token.Get()
+	if err != nil {
+		return fmt.Errorf("getting token failed: %w", err)
+	}
+	v.auth.Lock()
+	v.token.Destroy()
+	v.token = config.NewSecret([]byte(token.String()))
+	v.auth.Unlock()
+	token.Destroy()
+
+	var response struct {
+		Data struct {
+			TTL       int64 `json:"ttl"`
+			Renewable bool  `json:"renewable"`
+		} `json:"data"`
+	}
+	if err := v.request(http.MethodGet, "auth/token/lookup-self", true, nil, &response); err != nil {
+		return fmt.Errorf("looking up token failed: %w", err)
+	}
+
+	v.auth.Lock()
+	v.lease = time.Duration(response.Data.TTL) * time.Second
+	v.expires = time.Now().Add(v.lease)
+	v.renewable = response.Data.Renewable
+	v.auth.Unlock()
+
+	return nil
+}
+
+// renewToken extends the lease of the current token
+func (v *Vault) renewToken() error {
+	var response authResponse
+	if err := v.request(http.MethodPost, "auth/token/renew-self", true, map[string]string{}, &response); err != nil {
+		return fmt.Errorf("renewing token failed: %w", err)
+	}
+	v.setToken(&response)
+
+	return nil
+}
+
+func (v *Vault) setToken(response *authResponse) {
+	v.auth.Lock()
+	defer v.auth.Unlock()
+
+	// Freeing the previous token is safe as the token is only accessed while
+	// holding the lock
+	if response.Auth.ClientToken != "" {
+		v.token.Destroy()
+		v.token = config.NewSecret([]byte(response.Auth.ClientToken))
+	}
+	v.lease = time.Duration(response.Auth.LeaseDuration) * time.Second
+	v.expires = time.Now().Add(v.lease)
+	v.renewable = response.Auth.Renewable
+}
+
+// maintainToken renews the token in the background before its lease expires
+func (v *Vault) maintainToken(ctx context.Context) {
+	wait, ok := v.refreshToken(false)
+	for ok {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		wait, ok = v.refreshToken(true)
+	}
+}
+
+// refreshToken renews the token if requested, falling back to a new login,
+// and returns the time to wait until the next renewal. Failed renewals are
+// retried after a third of the remaining lease to retry multiple times before
+// the token expires. The flag is false if the token does not expire or cannot
+// be renewed.
+func (v *Vault) refreshToken(renew bool) (time.Duration, bool) {
+	if renew {
+		v.auth.Lock()
+		renewable := v.renewable
+		v.auth.Unlock()
+
+		var err error
+		if renewable {
+			err = v.renewToken()
+		} else {
+			err = errors.New("token not renewable")
+		}
+		if err != nil && v.AuthMethod != "token" {
+			v.Log.Debugf("Renewing token failed, logging in again: %v", err)
+			err = v.authenticate()
+		}
+		if err != nil {
+			v.Log.Errorf("Refreshing token failed: %v", err)
+			v.auth.Lock()
+			remaining := time.Until(v.expires)
+			v.auth.Unlock()
+			return max(remaining/3, retryInterval), true
+		}
+	}
+
+	v.auth.Lock()
+	defer v.auth.Unlock()
+
+	// Tokens without lease do not expire
+	if v.lease <= 0 {
+		return 0, false
+	}
+	if v.AuthMethod == "token" && !v.renewable {
+		v.Log.Warnf("Token expires in %s and cannot be renewed", v.lease)
+		return 0, false
+	}
+
+	// Renew after two thirds of the lease to have time for retries
+	return max(v.lease*2/3, retryInterval), true
+}
+
+// request sends a request to the given API endpoint and decodes the response,
+// the current token is added to the request if authenticated is set
+func (v *Vault) request(method, endpoint string, authenticated bool, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request failed: %w", err)
+		}
+		reader = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequest(method, v.Address+"/v1/"+endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("creating request failed: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+
+	if authenticated {
+		v.auth.Lock()
+		token, err := v.token.Get()
+		v.auth.Unlock()
+		if err != nil {
+			return fmt.Errorf("getting token failed: %w", err)
+		}
+		req.Header.Set("X-Vault-Token", strings.TrimSpace(token.String()))
+		token.Destroy()
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Try to wipe the token
+	req.Header.Set("X-Vault-Token", "---")
+
+	if resp.StatusCode != http.StatusOK {
+		var response struct {
+			Errors []string `json:"errors"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&response); err == nil && len(response.Errors) > 0 {
+			return fmt.Errorf("received status %q: %s", resp.Status, strings.Join(response.Errors, "; "))
+		}
+		return fmt.Errorf("received status %q", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("decoding response failed: %w", err)
+	}
+
+	return nil
+}
+
+// Register the secret-store on load.
+func init() {
+	secretstores.Add("vault", func(string) telegraf.SecretStore {
+		return &Vault{
+			Mount:               "secret",
+			AppRoleMount:        "approle",
+			KubernetesMount:     "kubernetes",
+			KubernetesTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+			TTL:                 config.Duration(5 * time.Minute),
+			Timeout:             config.Duration(5 * time.Second),
+		}
+	})
+}
@@ -0,0 +1,437 @@
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+// server mocks the Vault API endpoints used by the plugin
+type server struct {
+	sync.Mutex
+	token    string
+	data     map[string]interface{}
+	lease    int
+	logins   int
+	renewal  bool
+	failures int
+	renewed  int
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	if r.Header.Get("X-Vault-Namespace") != "ns1" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var body map[string]string
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	var response interface{}
+	switch r.URL.Path {
+	case "/v1/auth/approle/login":
+		if body["role_id"] != "role" || body["secret_id"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["invalid role or secret ID"]}`))
+			return
+		}
+		s.logins++
+		response = authFor(s.token, s.lease)
+	case "/v1/auth/kubernetes/login":
+		if body["role"] != "telegraf" || body["jwt"] != "eyJhbGciOi" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.logins++
+		response = authFor(s.token, s.lease)
+	case "/v1/auth/token/lookup-self":
+		if r.Header.Get("X-Vault-Token") != s.token {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		response = map[string]interface{}{
+			"data": map[string]interface{}{"ttl": s.lease, "renewable": true},
+		}
+	case "/v1/auth/token/renew-self":
+		if r.Header.Get("X-Vault-Token") != s.token || !s.renewal {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if s.failures > 0 {
+			s.failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.renewed++
+		response = authFor(s.token, s.lease)
+	case "/v1/secret/data/telegraf":
+		if r.Header.Get("X-Vault-Token") != s.token {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		response = map[string]interface{}{
+			"data": map[string]interface{}{"data": s.data},
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func authFor(token string, lease int) map[string]interface{} {
+	return map[string]interface{}{
+		"auth": map[string]interface{}{
+			"client_token":   token,
+			"lease_duration": lease,
+			"renewable":      true,
+		},
+	}
+}
+
+func newServer() *server {
+	return &server{
+		token: "s.token",
+		data: map[string]interface{}{
+			"username": "telegraf",
+			"password": "s3cr3t",
+			"port":     8086,
+		},
+		lease:   3600,
+		renewal: true,
+	}
+}
+
+func newPlugin(address string) *Vault {
+	return &Vault{
+		Address:             address,
+		Namespace:           "ns1",
+		Mount:               "secret",
+		Path:                "telegraf",
+		AppRoleMount:        "approle",
+		KubernetesMount:     "kubernetes",
+		KubernetesTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+		TTL:                 config.Duration(5 * time.Minute),
+		Timeout:             config.Duration(5 * time.Second),
+		Log:                 testutil.Logger{},
+	}
+}
+
+func TestSampleConfig(t *testing.T) {
+	plugin := &Vault{}
+	require.NotEmpty(t, plugin.SampleConfig())
+}
+
+func TestInitFail(t *testing.T) {
+	tests := []struct {
+		name     string
+		plugin   *Vault
+		expected string
+	}{
+		{
+			name:     "missing address",
+			plugin:   &Vault{Path: "telegraf"},
+			expected: "'address' required",
+		},
+		{
+			name:     "missing path",
+			plugin:   &Vault{Address: "http://localhost:8200"},
+			expected: "'path' required",
+		},
+		{
+			name:     "missing token",
+			plugin:   &Vault{Address: "http://localhost:8200", Path: "telegraf"},
+			expected: "'token' required for token authentication",
+		},
+		{
+			name:     "missing role ID",
+			plugin:   &Vault{Address: "http://localhost:8200", Path: "telegraf", AuthMethod: "approle"},
+			expected: "'role_id' required for AppRole authentication",
+		},
+		{
+			name:     "missing kubernetes role",
+			plugin:   &Vault{Address: "http://localhost:8200", Path: "telegraf", AuthMethod: "kubernetes"},
+			expected: "'kubernetes_role' required for Kubernetes authentication",
+		},
+		{
+			name:     "unknown auth method",
+			plugin:   &Vault{Address: "http://localhost:8200", Path: "telegraf", AuthMethod: "ldap"},
+			expected: `unknown 'auth_method' "ldap"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorContains(t, tt.plugin.Init(), tt.expected)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	// Write the service account token for the kubernetes authentication
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("eyJhbGciOi\n"), 0600))
+
+	tests := []struct {
+		name   string
+		modify func(*Vault)
+	}{
+		{
+			name: "token",
+			modify: func(v *Vault) {
+				v.Token = config.NewSecret([]byte("s.token"))
+			},
+		},
+		{
+			name: "approle",
+			modify: func(v *Vault) {
+				v.AuthMethod = "approle"
+				v.RoleID = config.NewSecret([]byte("role"))
+				v.SecretID = config.NewSecret([]byte("secret"))
+			},
+		},
+		{
+			name: "kubernetes",
+			modify: func(v *Vault) {
+				v.AuthMethod = "kubernetes"
+				v.KubernetesRole = "telegraf"
+				v.KubernetesTokenFile = tokenFile
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(newServer())
+			defer ts.Close()
+
+			plugin := newPlugin(ts.URL)
+			tt.modify(plugin)
+			require.NoError(t, plugin.Init())
+			defer plugin.Stop()
+
+			secret, err := plugin.Get("password")
+			require.NoError(t, err)
+			require.Equal(t, "s3cr3t", string(secret))
+
+			// Non-string values are returned JSON encoded
+			secret, err = plugin.Get("port")
+			require.NoError(t, err)
+			require.Equal(t, "8086", string(secret))
+
+			_, err = plugin.Get("foo")
+			require.EqualError(t, err, `field "foo" not found in secret "telegraf"`)
+
+			keys, err := plugin.List()
+			require.NoError(t, err)
+			require.Equal(t, []string{"password", "port", "username"}, keys)
+		})
+	}
+}
+
+func TestLoginFailed(t *testing.T) {
+	ts := httptest.NewServer(newServer())
+	defer ts.Close()
+
+	plugin := newPlugin(ts.URL)
+	plugin.AuthMethod = "approle"
+	plugin.RoleID = config.NewSecret([]byte("role"))
+	plugin.SecretID = config.NewSecret([]byte("wrong"))
+	require.ErrorContains(t, plugin.Init(), "approle login failed: received status \"400 Bad Request\": invalid role or secret ID")
+}
+
+func TestSetUnsupported(t *testing.T) {
+	ts := httptest.NewServer(newServer())
+	defer ts.Close()
+
+	plugin := newPlugin(ts.URL)
+	plugin.Token = config.NewSecret([]byte("s.token"))
+	require.NoError(t, plugin.Init())
+	defer plugin.Stop()
+
+	require.ErrorContains(t, plugin.Set("foo", "bar"), "not supported")
+}
+
+func TestResolverRereadsAfterTTL(t *testing.T) {
+	srv := newServer()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	plugin := newPlugin(ts.URL)
+	plugin.Token = config.NewSecret([]byte("s.token"))
+	plugin.TTL = config.Duration(100 * time.Millisecond)
+	require.NoError(t, plugin.Init())
+	defer plugin.Stop()
+
+	resolver, err := plugin.GetResolver("password")
+	require.NoError(t, err)
+	secret, dynamic, err := resolver()
+	require.NoError(t, err)
+	require.True(t, dynamic)
+	require.Equal(t, "s3cr3t", string(secret))
+
+	// Rotate the secret, the cached value is used until the TTL expired
+	srv.Lock()
+	srv.data["password"] = "n3w"
+	srv.Unlock()
+
+	secret, _, err = resolver()
+	require.NoError(t, err)
+	require.Equal(t, "s3cr3t", string(secret))
+
+	require.Eventually(t, func() bool {
+		secret, _, err := resolver()
+		return err == nil && string(secret) == "n3w"
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func TestResolverStaticWithoutTTL(t *testing.T) {
+	ts := httptest.NewServer(newServer())
+	defer ts.Close()
+
+	plugin := newPlugin(ts.URL)
+	plugin.Token = config.NewSecret([]byte("s.token"))
+	plugin.TTL = 0
+	require.NoError(t, plugin.Init())
+	defer plugin.Stop()
+
+	resolver, err := plugin.GetResolver("username")
+	require.NoError(t, err)
+	secret, dynamic, err := resolver()
+	require.NoError(t, err)
+	require.False(t, dynamic)
+	require.Equal(t, "telegraf", string(secret))
+}
+
+func TestRefreshToken(t *testing.T) {
+	srv := newServer()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	plugin := newPlugin(ts.URL)
+	plugin.AuthMethod = "approle"
+	plugin.RoleID = config.NewSecret([]byte("role"))
+	plugin.SecretID = config.NewSecret([]byte("secret"))
+	require.NoError(t, plugin.Init())
+	plugin.Stop()
+
+	// The token is renewed after two thirds of the lease
+	wait, ok := plugin.refreshToken(true)
+	require.True(t, ok)
+	require.Equal(t, 40*time.Minute, wait)
+	srv.Lock()
+	require.Equal(t, 1, srv.logins)
+	srv.Unlock()
+
+	// Login again if the renewal fails
+	srv.Lock()
+	srv.renewal = false
+	srv.token = "s.other"
+	srv.Unlock()
+
+	_, ok = plugin.refreshToken(true)
+	require.True(t, ok)
+	srv.Lock()
+	require.Equal(t, 2, srv.logins)
+	srv.Unlock()
+
+	secret, err := plugin.Get("username")
+	require.NoError(t, err)
+	require.Equal(t, "telegraf", string(secret))
+}
+
+func TestStop(t *testing.T) {
+	ts := httptest.NewServer(newServer())
+	defer ts.Close()
+
+	plugin := newPlugin(ts.URL)
+	plugin.Token = config.NewSecret([]byte("s.token"))
+	require.NoError(t, plugin.Init())
+
+	var store telegraf.SecretStore = plugin
+	stopper, ok := store.(telegraf.SecretStoreStopper)
+	require.True(t, ok)
+
+	done := make(chan struct{})
+	go func() {
+		stopper.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		require.FailNow(t, "token renewal not stopped")
+	}
+}
+
+func TestRefreshTokenFailed(t *testing.T) {
+	srv := newServer()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	plugin := newPlugin(ts.URL)
+	plugin.Token = config.NewSecret([]byte("s.token"))
+	require.NoError(t, plugin.Init())
+	plugin.Stop()
+
+	// Retry after a third of the remaining lease if renewing a token fails
+	srv.Lock()
+	srv.renewal = false
+	srv.Unlock()
+
+	wait, ok := plugin.refreshToken(true)
+	require.True(t, ok)
+	require.InDelta(t, (20 * time.Minute).Seconds(), wait.Seconds(), 1)
+}
+
+func TestMaintainTokenRetriesBeforeExpiry(t *testing.T) {
+	interval := retryInterval
+	retryInterval = 10 * time.Millisecond
+	defer func() { retryInterval = interval }()
+
+	// The first renewal fails
+	srv := newServer()
+	srv.lease = 2
+	srv.failures = 1
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	plugin := newPlugin(ts.URL)
+	plugin.Token = config.NewSecret([]byte("s.token"))
+	require.NoError(t, plugin.Init())
+	defer plugin.Stop()
+
+	// The renewal is attempted after two thirds of the lease and must be
+	// retried before the token expires
+	require.Eventually(t, func() bool {
+		srv.Lock()
+		defer srv.Unlock()
+		return srv.renewed > 0
+	}, 1900*time.Millisecond, 10*time.Millisecond)
+
+	srv.Lock()
+	require.Zero(t, srv.failures)
+	srv.Unlock()
+}
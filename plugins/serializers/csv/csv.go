@@ -23,8 +23,9 @@ type Serializer struct {
 	Prefix          bool     `toml:"csv_column_prefix"`
 	Columns         []string `toml:"csv_columns"`
 
-	buffer bytes.Buffer
-	writer *csv.Writer
+	buffer        bytes.Buffer
+	writer        *csv.Writer
+	headerWritten bool
 }
 
 func (s *Serializer) Init() error {
@@ -48,7 +49,12 @@ func (s *Serializer) Init() error {
 	}
 
 	// Check columns if any
-	for _, name := range s.Columns {
+	for i, name := range s.Columns {
+		// Accept colon-separated references as alias
+		if strings.HasPrefix(name, "tag:") || strings.HasPrefix(name, "field:") {
+			name = strings.Replace(name, ":", ".", 1)
+			s.Columns[i] = name
+		}
 		switch {
 		case name == "timestamp", name == "name",
 			strings.HasPrefix(name, "tag."),
@@ -79,7 +85,7 @@ func (s *Serializer) SerializeBatch(metrics []telegraf.Metric) ([]byte, error) {
 	s.buffer.Truncate(0)
 
 	// Write the header if the user wants us to
-	if s.Header {
+	if s.Header && !s.headerWritten {
 		if len(s.Columns) > 0 {
 			if err := s.writeHeaderOrdered(); err != nil {
 				return nil, fmt.Errorf("writing header failed: %w", err)
@@ -89,7 +95,7 @@ func (s *Serializer) SerializeBatch(metrics []telegraf.Metric) ([]byte, error) {
 				return nil, fmt.Errorf("writing header failed: %w", err)
 			}
 		}
-		s.Header = false
+		s.headerWritten = true
 	}
 
 	for _, m := range metrics {
@@ -109,6 +115,11 @@ func (s *Serializer) SerializeBatch(metrics []telegraf.Metric) ([]byte, error) {
 	return s.buffer.Bytes(), nil
 }
 
+// ResetHeader causes the header to be written with the next batch
+func (s *Serializer) ResetHeader() {
+	s.headerWritten = false
+}
+
 func (s *Serializer) writeHeader(metric telegraf.Metric) error {
 	columns := []string{
 		"timestamp",
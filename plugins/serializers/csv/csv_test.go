@@ -10,6 +10,7 @@ import (
 	"github.com/influxdata/toml"
 	"github.com/stretchr/testify/require"
 
+	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/parsers/influx"
 	"github.com/influxdata/telegraf/plugins/serializers"
 	"github.com/influxdata/telegraf/testutil"
@@ -37,6 +38,45 @@ func TestInvalidSeparator(t *testing.T) {
 	require.EqualError(t, err, "writing data failed: csv: invalid field or comment delimiter")
 }
 
+func TestColumnAliases(t *testing.T) {
+	s := Serializer{
+		Header:  true,
+		Columns: []string{"timestamp", "tag:tag1", "field:value"},
+	}
+	require.NoError(t, s.Init())
+	require.Equal(t, []string{"timestamp", "tag.tag1", "field.value"}, s.Columns)
+	s.writer.UseCRLF = false
+
+	actual, err := s.Serialize(testutil.TestMetric(42.3, "test"))
+	require.NoError(t, err)
+	require.Equal(t, "timestamp,tag1,value\n1257894000,value1,42.3\n", string(actual))
+}
+
+func TestHeaderOncePerInstance(t *testing.T) {
+	s := Serializer{
+		Header:  true,
+		Columns: []string{"tag.tag1", "field.value"},
+	}
+	require.NoError(t, s.Init())
+	s.writer.UseCRLF = false
+
+	m := testutil.TestMetric("a,b", "test")
+	actual, err := s.Serialize(m)
+	require.NoError(t, err)
+	require.Equal(t, "tag1,value\nvalue1,\"a,b\"\n", string(actual))
+
+	// No header for subsequent batches
+	actual, err = s.SerializeBatch([]telegraf.Metric{m})
+	require.NoError(t, err)
+	require.Equal(t, "value1,\"a,b\"\n", string(actual))
+
+	// Emit the header again after reset
+	s.ResetHeader()
+	actual, err = s.SerializeBatch([]telegraf.Metric{m})
+	require.NoError(t, err)
+	require.Equal(t, "tag1,value\nvalue1,\"a,b\"\n", string(actual))
+}
+
 func TestSerializeTransformationNonBatch(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -36,6 +36,10 @@ var (
 type GraphiteTemplate struct {
 	Filter filter.Filter
 	Value  string
+
+	// TagSupport overrides the serializer's tag support setting for
+	// matching metrics if set
+	TagSupport *bool
 }
 
 type GraphiteSerializer struct {
@@ -89,10 +93,33 @@ func (s *GraphiteSerializer) Serialize(metric telegraf.Metric) ([]byte, error) {
 	// Convert UnixNano to Unix timestamps
 	timestamp := metric.Time().UnixNano() / 1000000000
 
-	switch s.TagSupport {
+	// Select the template and mode for the metric
+	template := s.Template
+	tagSupport := s.TagSupport
+	for _, graphiteTemplate := range s.tmplts {
+		if graphiteTemplate.Filter.Match(metric.Name()) {
+			if graphiteTemplate.Value != "" {
+				template = graphiteTemplate.Value
+			}
+			if graphiteTemplate.TagSupport != nil {
+				tagSupport = *graphiteTemplate.TagSupport
+			}
+			break
+		}
+	}
+
+	// Iterate the fields in a stable order
+	fields := metric.Fields()
+	fieldNames := make([]string, 0, len(fields))
+	for k := range fields {
+		fieldNames = append(fieldNames, k)
+	}
+	sort.Strings(fieldNames)
+
+	switch tagSupport {
 	case true:
-		for fieldName, value := range metric.Fields() {
-			fieldValue := formatValue(value)
+		for _, fieldName := range fieldNames {
+			fieldValue := formatValue(fields[fieldName])
 			if fieldValue == "" {
 				continue
 			}
@@ -107,21 +134,13 @@ func (s *GraphiteSerializer) Serialize(metric telegraf.Metric) ([]byte, error) {
 			out = append(out, point...)
 		}
 	default:
-		template := s.Template
-		for _, graphiteTemplate := range s.tmplts {
-			if graphiteTemplate.Filter.Match(metric.Name()) {
-				template = graphiteTemplate.Value
-				break
-			}
-		}
-
 		bucket := SerializeBucketName(metric.Name(), metric.Tags(), template, s.Prefix)
 		if bucket == "" {
 			return out, nil
 		}
 
-		for fieldName, value := range metric.Fields() {
-			fieldValue := formatValue(value)
+		for _, fieldName := range fieldNames {
+			fieldValue := formatValue(fields[fieldName])
 			if fieldValue == "" {
 				continue
 			}
@@ -241,6 +260,35 @@ func InitGraphiteTemplates(templates []string) ([]*GraphiteTemplate, string, err
 		if len(parts) == 0 {
 			return nil, "", fmt.Errorf("missing template at position: %d", i)
 		}
+
+		// An optional trailing setting overrides the tag support mode for
+		// the matching metrics. In this case the template is optional.
+		if setting, found := strings.CutPrefix(parts[len(parts)-1], "tag_support="); found {
+			tagSupport, err := strconv.ParseBool(setting)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid tag support setting in template %q: %w", t, err)
+			}
+			parts = parts[:len(parts)-1]
+			if len(parts) == 0 || len(parts) > 2 {
+				return nil, "", fmt.Errorf("invalid template format: %q", t)
+			}
+
+			tFilter, err := filter.Compile([]string{parts[0]})
+			if err != nil {
+				return nil, "", err
+			}
+
+			graphiteTemplate := &GraphiteTemplate{
+				Filter:     tFilter,
+				TagSupport: &tagSupport,
+			}
+			if len(parts) == 2 {
+				graphiteTemplate.Value = parts[1]
+			}
+			graphiteTemplates = append(graphiteTemplates, graphiteTemplate)
+			continue
+		}
+
 		if len(parts) == 1 {
 			if parts[0] == "" {
 				return nil, "", fmt.Errorf("missing template at position: %d", i)
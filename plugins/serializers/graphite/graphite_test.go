@@ -1222,6 +1222,102 @@ func TestSerializeBatchWithTagsSupport(t *testing.T) {
 	}
 }
 
+func TestSerializeTemplateTagSupportOverride(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	tags := map[string]string{
+		"host": "localhost",
+		"cpu":  "cpu/0",
+	}
+	fields := map[string]interface{}{
+		"usage_idle": float64(91.5),
+		"usage_busy": float64(8.5),
+	}
+	metrics := []telegraf.Metric{
+		metric.New("cpu", tags, fields, now),
+		metric.New("disk", tags, fields, now),
+		metric.New("mem", tags, fields, now),
+	}
+
+	tests := []struct {
+		name       string
+		tagSupport bool
+		templates  []string
+		expected   string
+	}{
+		{
+			name: "tag support for selected measurements",
+			templates: []string{
+				"disk* tag_support=true",
+				"host.measurement.tags.field",
+			},
+			expected: "localhost.cpu.cpu-0.usage_busy 8.5 1234567890\n" +
+				"localhost.cpu.cpu-0.usage_idle 91.5 1234567890\n" +
+				"disk.usage_busy;cpu=cpu-0;host=localhost 8.5 1234567890\n" +
+				"disk.usage_idle;cpu=cpu-0;host=localhost 91.5 1234567890\n" +
+				"localhost.mem.cpu-0.usage_busy 8.5 1234567890\n" +
+				"localhost.mem.cpu-0.usage_idle 91.5 1234567890\n",
+		},
+		{
+			name:       "template for selected measurements",
+			tagSupport: true,
+			templates: []string{
+				"cpu measurement.host.field tag_support=false",
+				"mem tag_support=false",
+			},
+			expected: "cpu.localhost.usage_busy 8.5 1234567890\n" +
+				"cpu.localhost.usage_idle 91.5 1234567890\n" +
+				"disk.usage_busy;cpu=cpu-0;host=localhost 8.5 1234567890\n" +
+				"disk.usage_idle;cpu=cpu-0;host=localhost 91.5 1234567890\n" +
+				"localhost.cpu-0.mem.usage_busy 8.5 1234567890\n" +
+				"localhost.cpu-0.mem.usage_idle 91.5 1234567890\n",
+		},
+		{
+			name:       "templates without override",
+			tagSupport: true,
+			templates: []string{
+				"cpu measurement.host.field",
+			},
+			expected: "cpu.usage_busy;cpu=cpu-0;host=localhost 8.5 1234567890\n" +
+				"cpu.usage_idle;cpu=cpu-0;host=localhost 91.5 1234567890\n" +
+				"disk.usage_busy;cpu=cpu-0;host=localhost 8.5 1234567890\n" +
+				"disk.usage_idle;cpu=cpu-0;host=localhost 91.5 1234567890\n" +
+				"mem.usage_busy;cpu=cpu-0;host=localhost 8.5 1234567890\n" +
+				"mem.usage_idle;cpu=cpu-0;host=localhost 91.5 1234567890\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := GraphiteSerializer{
+				TagSupport: tt.tagSupport,
+				Templates:  tt.templates,
+			}
+			require.NoError(t, s.Init())
+
+			actual, err := s.SerializeBatch(metrics)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, string(actual))
+		})
+	}
+}
+
+func TestInitTemplateTagSupportInvalid(t *testing.T) {
+	s := GraphiteSerializer{
+		Templates: []string{"cpu measurement.field tag_support=maybe"},
+	}
+	require.ErrorContains(t, s.Init(), `invalid tag support setting in template "cpu measurement.field tag_support=maybe"`)
+
+	s = GraphiteSerializer{
+		Templates: []string{"tag_support=true"},
+	}
+	require.ErrorContains(t, s.Init(), `invalid template format: "tag_support=true"`)
+
+	s = GraphiteSerializer{
+		Templates: []string{"cpu measurement.field foo tag_support=true"},
+	}
+	require.ErrorContains(t, s.Init(), `invalid template format`)
+}
+
 func BenchmarkSerialize(b *testing.B) {
 	s := &GraphiteSerializer{}
 	require.NoError(b, s.Init())
@@ -1,6 +1,7 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,13 +17,18 @@ import (
 )
 
 type Serializer struct {
-	TimestampUnits      config.Duration `toml:"json_timestamp_units"`
-	TimestampFormat     string          `toml:"json_timestamp_format"`
-	Transformation      string          `toml:"json_transformation"`
-	NestedFieldsInclude []string        `toml:"json_nested_fields_include"`
-	NestedFieldsExclude []string        `toml:"json_nested_fields_exclude"`
+	TimestampUnits      config.Duration   `toml:"json_timestamp_units"`
+	TimestampFormat     string            `toml:"json_timestamp_format"`
+	Transformation      string            `toml:"json_transformation"`
+	NestedFieldsInclude []string          `toml:"json_nested_fields_include"`
+	NestedFieldsExclude []string          `toml:"json_nested_fields_exclude"`
+	NestedTemplate      map[string]string `toml:"json_nested_template"`
+	OmitInvalidNumbers  *bool             `toml:"json_omit_invalid_numbers"`
 
 	nestedfields filter.Filter
+	omitInvalid  bool
+	omitNull     bool
+	template     []templateEntry
 }
 
 func (s *Serializer) Init() error {
@@ -43,6 +49,11 @@ func (s *Serializer) Init() error {
 	}
 	s.TimestampUnits = config.Duration(t)
 
+	// Keep the previous behavior of dropping invalid numbers but outputting
+	// nested null values if the option is not set
+	s.omitInvalid = s.OmitInvalidNumbers == nil || *s.OmitInvalidNumbers
+	s.omitNull = s.OmitInvalidNumbers != nil && *s.OmitInvalidNumbers
+
 	if len(s.NestedFieldsInclude) > 0 || len(s.NestedFieldsExclude) > 0 {
 		f, err := filter.NewIncludeExcludeFilter(s.NestedFieldsInclude, s.NestedFieldsExclude)
 		if err != nil {
@@ -51,6 +62,17 @@ func (s *Serializer) Init() error {
 		s.nestedfields = f
 	}
 
+	if len(s.NestedTemplate) > 0 {
+		if s.Transformation != "" {
+			return errors.New("cannot use 'json_nested_template' together with 'json_transformation'")
+		}
+		template, err := compileTemplate(s.NestedTemplate)
+		if err != nil {
+			return fmt.Errorf("invalid nested template: %w", err)
+		}
+		s.template = template
+	}
+
 	return nil
 }
 
@@ -58,6 +80,13 @@ func (s *Serializer) Serialize(metric telegraf.Metric) ([]byte, error) {
 	var obj interface{}
 	obj = s.createObject(metric)
 
+	if s.template != nil {
+		var err error
+		if obj, err = s.applyTemplate(obj.(map[string]interface{})); err != nil {
+			return nil, err
+		}
+	}
+
 	if s.Transformation != "" {
 		var err error
 		if obj, err = s.transform(obj); err != nil {
@@ -81,12 +110,25 @@ func (s *Serializer) SerializeBatch(metrics []telegraf.Metric) ([]byte, error) {
 	objects := make([]interface{}, 0, len(metrics))
 	for _, metric := range metrics {
 		m := s.createObject(metric)
-		objects = append(objects, m)
+		if s.template == nil {
+			objects = append(objects, m)
+			continue
+		}
+		obj, err := s.applyTemplate(m)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
 	}
 
+	// Templated objects are output as plain array
 	var obj interface{}
-	obj = map[string]interface{}{
-		"metrics": objects,
+	if s.template != nil {
+		obj = objects
+	} else {
+		obj = map[string]interface{}{
+			"metrics": objects,
+		}
 	}
 
 	if s.Transformation != "" {
@@ -124,15 +166,28 @@ func (s *Serializer) createObject(metric telegraf.Metric) map[string]interface{}
 		case float64:
 			// JSON does not support these special values
 			if math.IsNaN(fv) || math.IsInf(fv, 0) {
-				continue
+				if s.omitInvalid {
+					continue
+				}
+				val = nil
 			}
 		case string:
 			// Check for nested fields if any
 			if s.nestedfields != nil && s.nestedfields.Match(field.Key) {
 				bv := []byte(fv)
 				if json.Valid(bv) {
+					// Keep the number representation to avoid precision
+					// loss for large integers. JSONata requires native
+					// numbers so we cannot do this for transformations.
+					decoder := json.NewDecoder(bytes.NewReader(bv))
+					if s.Transformation == "" {
+						decoder.UseNumber()
+					}
 					var nested interface{}
-					if err := json.Unmarshal(bv, &nested); err == nil {
+					if err := decoder.Decode(&nested); err == nil {
+						if nested == nil && s.omitNull {
+							continue
+						}
 						val = nested
 					}
 				}
@@ -143,9 +198,18 @@ func (s *Serializer) createObject(metric telegraf.Metric) map[string]interface{}
 	m["fields"] = fields
 
 	m["name"] = metric.Name()
-	if s.TimestampFormat == "" {
+	switch s.TimestampFormat {
+	case "":
 		m["timestamp"] = metric.Time().UnixNano() / int64(s.TimestampUnits)
-	} else {
+	case "unix":
+		m["timestamp"] = metric.Time().Unix()
+	case "unix_ms":
+		m["timestamp"] = metric.Time().UnixMilli()
+	case "unix_us":
+		m["timestamp"] = metric.Time().UnixMicro()
+	case "unix_ns":
+		m["timestamp"] = metric.Time().UnixNano()
+	default:
 		m["timestamp"] = metric.Time().UTC().Format(s.TimestampFormat)
 	}
 	return m
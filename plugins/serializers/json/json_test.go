@@ -81,6 +81,32 @@ func TestSerialize_TimestampUnits(t *testing.T) {
 			timestampFormat: "2006-01-02T15:04:05Z07:00",
 			expected:        `{"fields":{"value":42},"name":"cpu","tags":{},"timestamp":"2018-05-05T00:06:35Z"}`,
 		},
+		{
+			name:            "timestamp format RFC3339 with nanoseconds",
+			timestampFormat: time.RFC3339Nano,
+			expected:        `{"fields":{"value":42},"name":"cpu","tags":{},"timestamp":"2018-05-05T00:06:35.123456789Z"}`,
+		},
+		{
+			name:            "timestamp format unix",
+			timestampFormat: "unix",
+			timestampUnits:  1 * time.Millisecond,
+			expected:        `{"fields":{"value":42},"name":"cpu","tags":{},"timestamp":1525478795}`,
+		},
+		{
+			name:            "timestamp format unix_ms",
+			timestampFormat: "unix_ms",
+			expected:        `{"fields":{"value":42},"name":"cpu","tags":{},"timestamp":1525478795123}`,
+		},
+		{
+			name:            "timestamp format unix_us",
+			timestampFormat: "unix_us",
+			expected:        `{"fields":{"value":42},"name":"cpu","tags":{},"timestamp":1525478795123456}`,
+		},
+		{
+			name:            "timestamp format unix_ns",
+			timestampFormat: "unix_ns",
+			expected:        `{"fields":{"value":42},"name":"cpu","tags":{},"timestamp":1525478795123456789}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -242,6 +268,83 @@ func TestSerializeBatchSkipInfAllFields(t *testing.T) {
 	require.Equal(t, []byte(`{"metrics":[{"fields":{},"name":"cpu","tags":{},"timestamp":0}]}`+"\n"), buf)
 }
 
+func TestSerializeInvalidNumbers(t *testing.T) {
+	metrics := []telegraf.Metric{
+		testutil.MustMetric(
+			"cpu",
+			map[string]string{},
+			map[string]interface{}{
+				"nan":       math.NaN(),
+				"inf":       math.Inf(-1),
+				"nested":    "null",
+				"time_idle": 42,
+			},
+			time.Unix(0, 0),
+		),
+	}
+
+	tests := []struct {
+		name     string
+		omit     *bool
+		expected string
+	}{
+		{
+			name:     "default",
+			expected: `{"fields":{"nested":null,"time_idle":42},"name":"cpu","tags":{},"timestamp":0}`,
+		},
+		{
+			name:     "omit",
+			omit:     &[]bool{true}[0],
+			expected: `{"fields":{"time_idle":42},"name":"cpu","tags":{},"timestamp":0}`,
+		},
+		{
+			name:     "keep as null",
+			omit:     &[]bool{false}[0],
+			expected: `{"fields":{"inf":null,"nan":null,"nested":null,"time_idle":42},"name":"cpu","tags":{},"timestamp":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Serializer{
+				NestedFieldsInclude: []string{"nested"},
+				OmitInvalidNumbers:  tt.omit,
+			}
+			require.NoError(t, s.Init())
+
+			buf, err := s.Serialize(metrics[0])
+			require.NoError(t, err)
+			require.Equal(t, tt.expected+"\n", string(buf))
+
+			buf, err = s.SerializeBatch(metrics)
+			require.NoError(t, err)
+			require.Equal(t, `{"metrics":[`+tt.expected+`]}`+"\n", string(buf))
+		})
+	}
+}
+
+func TestSerializeInvalidNumbersTransformation(t *testing.T) {
+	m := testutil.MustMetric(
+		"cpu",
+		map[string]string{},
+		map[string]interface{}{
+			"nan":       math.NaN(),
+			"time_idle": 42,
+		},
+		time.Unix(1525478795, 123000000),
+	)
+
+	s := Serializer{
+		TimestampFormat: "unix_ms",
+		Transformation:  `{"time": timestamp, "values": fields}`,
+	}
+	require.NoError(t, s.Init())
+
+	buf, err := s.Serialize(m)
+	require.NoError(t, err)
+	require.Equal(t, `{"time":1525478795123,"values":{"time_idle":42}}`+"\n", string(buf))
+}
+
 func TestSerializeTransformationNonBatch(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -453,12 +556,138 @@ func TestSerializeNesting(t *testing.T) {
 	}
 }
 
+func TestSerializeNestedTemplate(t *testing.T) {
+	filename := filepath.FromSlash("testcases/nested_template.conf")
+	cfg, header, err := loadTestConfiguration(filename)
+	require.NoError(t, err)
+
+	// Get the input metrics
+	parser := &influx.Parser{}
+	require.NoError(t, parser.Init())
+	metrics, err := testutil.ParseMetricsFrom(header, "Input:", parser)
+	require.NoError(t, err)
+
+	// Get the expectations
+	expectedArray, err := loadJSON(strings.TrimSuffix(filename, ".conf") + "_out.json")
+	require.NoError(t, err)
+	expected := expectedArray.([]interface{})
+
+	serializer := Serializer{
+		TimestampUnits: config.Duration(cfg.TimestampUnits),
+		NestedTemplate: cfg.NestedTemplate,
+	}
+	require.NoError(t, serializer.Init())
+
+	t.Run("non-batch", func(t *testing.T) {
+		for i, m := range metrics {
+			buf, err := serializer.Serialize(m)
+			require.NoError(t, err)
+
+			var actual interface{}
+			require.NoError(t, json.Unmarshal(buf, &actual))
+			require.EqualValuesf(t, expected[i], actual, "mismatch in %d", i)
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		buf, err := serializer.SerializeBatch(metrics)
+		require.NoError(t, err)
+
+		var actual interface{}
+		require.NoError(t, json.Unmarshal(buf, &actual))
+		require.EqualValues(t, expected, actual)
+	})
+}
+
+func TestSerializeNestedTemplatePrecision(t *testing.T) {
+	m := metric.New(
+		"data",
+		map[string]string{"host": "server01"},
+		map[string]interface{}{
+			"counter": int64(9007199254740993),
+			"nested":  `{"id": 12345678901234567890, "ratio": 0.1}`,
+		},
+		time.Unix(1666006350, 0),
+	)
+
+	serializer := Serializer{
+		NestedFieldsInclude: []string{"nested"},
+		NestedTemplate: map[string]string{
+			"source.host":  "tag:host",
+			"values.count": "field:counter",
+			"values.data":  "field:nested",
+			"values.none":  "field:non-existing",
+		},
+	}
+	require.NoError(t, serializer.Init())
+
+	buf, err := serializer.Serialize(m)
+	require.NoError(t, err)
+	expected := `{"source":{"host":"server01"},"values":{"count":9007199254740993,"data":{"id":12345678901234567890,"ratio":0.1}}}` + "\n"
+	require.Equal(t, expected, string(buf))
+}
+
+func TestNestedTemplateInvalid(t *testing.T) {
+	tests := []struct {
+		name           string
+		template       map[string]string
+		transformation string
+		expected       string
+	}{
+		{
+			name:     "invalid source",
+			template: map[string]string{"host": "host"},
+			expected: `invalid nested template: path "host": invalid source "host"`,
+		},
+		{
+			name:     "missing key",
+			template: map[string]string{"host": "tag:"},
+			expected: `invalid nested template: path "host": invalid source "tag:"`,
+		},
+		{
+			name:     "empty path element",
+			template: map[string]string{"host..name": "tag:host"},
+			expected: `invalid nested template: path "host..name": empty path element`,
+		},
+		{
+			name:     "unsupported placeholder",
+			template: map[string]string{"metrics.{field:foo}": "fields"},
+			expected: `invalid nested template: path "metrics.{field:foo}": placeholder "{field:foo}" not supported`,
+		},
+		{
+			name: "conflicting paths",
+			template: map[string]string{
+				"host":      "tag:host",
+				"host.name": "tag:host",
+			},
+			expected: `invalid nested template: path "host" conflicts with "host.name"`,
+		},
+		{
+			name:           "transformation",
+			template:       map[string]string{"host": "tag:host"},
+			transformation: "name",
+			expected:       "cannot use 'json_nested_template' together with 'json_transformation'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serializer := Serializer{
+				NestedTemplate: tt.template,
+				Transformation: tt.transformation,
+			}
+			require.EqualError(t, serializer.Init(), tt.expected)
+		})
+	}
+}
+
 type Config struct {
-	TimestampUnits          time.Duration `toml:"json_timestamp_units"`
-	TimestampFormat         string        `toml:"json_timestamp_format"`
-	Transformation          string        `toml:"json_transformation"`
-	JSONNestedFieldsInclude []string      `toml:"json_nested_fields_include"`
-	JSONNestedFieldsExclude []string      `toml:"json_nested_fields_exclude"`
+	TimestampUnits          time.Duration     `toml:"json_timestamp_units"`
+	TimestampFormat         string            `toml:"json_timestamp_format"`
+	Transformation          string            `toml:"json_transformation"`
+	JSONNestedFieldsInclude []string          `toml:"json_nested_fields_include"`
+	JSONNestedFieldsExclude []string          `toml:"json_nested_fields_exclude"`
+	NestedTemplate          map[string]string `toml:"json_nested_template"`
 }
 
 func loadTestConfiguration(filename string) (*Config, []string, error) {
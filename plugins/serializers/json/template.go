@@ -0,0 +1,187 @@
+package json
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type templateSource struct {
+	kind string
+	key  string
+}
+
+type templateEntry struct {
+	path   []templateSource
+	source templateSource
+}
+
+// compileTemplate converts the given mapping of dotted output paths to
+// metric sources into a list of entries sorted by path
+func compileTemplate(mapping map[string]string) ([]templateEntry, error) {
+	paths := make([]string, 0, len(mapping))
+	for path := range mapping {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	entries := make([]templateEntry, 0, len(mapping))
+	for _, path := range paths {
+		source, err := parseSource(mapping[path])
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %w", path, err)
+		}
+
+		var elements []templateSource
+		for _, element := range strings.Split(path, ".") {
+			if element == "" {
+				return nil, fmt.Errorf("path %q: empty path element", path)
+			}
+			if !strings.HasPrefix(element, "{") || !strings.HasSuffix(element, "}") {
+				elements = append(elements, templateSource{kind: "literal", key: element})
+				continue
+			}
+
+			// Path elements can be dynamic using the metric name or tags
+			placeholder, err := parseSource(element[1 : len(element)-1])
+			if err != nil {
+				return nil, fmt.Errorf("path %q: %w", path, err)
+			}
+			if placeholder.kind != "name" && placeholder.kind != "tag" {
+				return nil, fmt.Errorf("path %q: placeholder %q not supported", path, element)
+			}
+			elements = append(elements, placeholder)
+		}
+		entries = append(entries, templateEntry{path: elements, source: source})
+	}
+
+	// Check for static paths shadowing each other as this cannot work
+	for i, a := range entries {
+		for _, b := range entries[i+1:] {
+			if isPrefix(a.path, b.path) {
+				return nil, fmt.Errorf("path %q conflicts with %q", a.String(), b.String())
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func parseSource(source string) (templateSource, error) {
+	switch source {
+	case "name", "timestamp", "tags", "fields":
+		return templateSource{kind: source}, nil
+	}
+
+	kind, key, found := strings.Cut(source, ":")
+	if !found || key == "" || (kind != "tag" && kind != "field") {
+		return templateSource{}, fmt.Errorf("invalid source %q", source)
+	}
+	return templateSource{kind: kind, key: key}, nil
+}
+
+func isPrefix(a, b []templateSource) bool {
+	if len(a) > len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].kind != "literal" || b[i].kind != "literal" || a[i].key != b[i].key {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *templateEntry) String() string {
+	elements := make([]string, 0, len(e.path))
+	for _, element := range e.path {
+		switch element.kind {
+		case "literal":
+			elements = append(elements, element.key)
+		case "name":
+			elements = append(elements, "{name}")
+		default:
+			elements = append(elements, "{"+element.kind+":"+element.key+"}")
+		}
+	}
+	return strings.Join(elements, ".")
+}
+
+// applyTemplate arranges the data of the standard-form object according to
+// the template. Entries referencing non-existing tags or fields are skipped.
+func (s *Serializer) applyTemplate(obj map[string]interface{}) (map[string]interface{}, error) {
+	tags := obj["tags"].(map[string]string)
+	fields := obj["fields"].(map[string]interface{})
+
+	lookup := func(source templateSource) (interface{}, bool) {
+		switch source.kind {
+		case "literal":
+			return source.key, true
+		case "tag":
+			v, found := tags[source.key]
+			return v, found
+		case "field":
+			v, found := fields[source.key]
+			return v, found
+		case "tags":
+			return tags, true
+		case "fields":
+			return fields, true
+		}
+		return obj[source.kind], true
+	}
+
+	out := make(map[string]interface{})
+	for _, entry := range s.template {
+		value, found := lookup(entry.source)
+		if !found {
+			continue
+		}
+
+		// Resolve the path elements
+		path := make([]string, 0, len(entry.path))
+		for _, element := range entry.path {
+			v, found := lookup(element)
+			if !found {
+				break
+			}
+			path = append(path, v.(string))
+		}
+		if len(path) != len(entry.path) {
+			continue
+		}
+
+		if err := insert(out, path, value); err != nil {
+			return nil, fmt.Errorf("applying template path %q failed: %w", entry.String(), err)
+		}
+	}
+
+	return out, nil
+}
+
+func insert(obj map[string]interface{}, path []string, value interface{}) error {
+	current := obj
+	for _, element := range path[:len(path)-1] {
+		v, found := current[element]
+		if !found {
+			next := make(map[string]interface{})
+			current[element] = next
+			current = next
+			continue
+		}
+		next, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("element %q already holds a value", element)
+		}
+		current = next
+	}
+
+	key := path[len(path)-1]
+	if _, found := current[key]; found {
+		return errors.New("value already set")
+	}
+	current[key] = value
+
+	return nil
+}
@@ -1,7 +1,6 @@
 package prometheusremotewrite
 
 import (
-	"bytes"
 	"fmt"
 	"hash/fnv"
 	"sort"
@@ -13,23 +12,105 @@ import (
 	"github.com/prometheus/prometheus/prompb"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/plugins/serializers"
 	"github.com/influxdata/telegraf/plugins/serializers/prometheus"
 )
 
 type MetricKey uint64
 
+const metadataHelp = "Telegraf collected metric"
+
 type Serializer struct {
-	SortMetrics   bool            `toml:"prometheus_sort_metrics"`
-	StringAsLabel bool            `toml:"prometheus_string_as_label"`
-	Log           telegraf.Logger `toml:"-"`
+	SortMetrics           bool            `toml:"prometheus_sort_metrics"`
+	StringAsLabel         bool            `toml:"prometheus_string_as_label"`
+	MaxBytesPerWrite      config.Size     `toml:"prometheus_max_bytes_per_write"`
+	MaxTimeseriesPerWrite int             `toml:"prometheus_max_timeseries_per_write"`
+	SendMetadata          bool            `toml:"prometheus_metadata"`
+	Log                   telegraf.Logger `toml:"-"`
+}
+
+func (s *Serializer) Init() error {
+	if s.MaxBytesPerWrite < 0 {
+		return fmt.Errorf("invalid 'prometheus_max_bytes_per_write' %d", s.MaxBytesPerWrite)
+	}
+	if s.MaxTimeseriesPerWrite < 0 {
+		return fmt.Errorf("invalid 'prometheus_max_timeseries_per_write' %d", s.MaxTimeseriesPerWrite)
+	}
+	return nil
 }
 
 func (s *Serializer) Serialize(metric telegraf.Metric) ([]byte, error) {
 	return s.SerializeBatch([]telegraf.Metric{metric})
 }
 
+// SerializeBatch converts the metrics into a single write request ignoring
+// any size limits.
 func (s *Serializer) SerializeBatch(metrics []telegraf.Metric) ([]byte, error) {
+	promTS, metadata, err := s.convert(metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return encode(promTS, s.metadataFor(promTS, metadata))
+}
+
+// SerializeBatchSplit converts the metrics into one or more write requests,
+// each respecting the configured maximum number of time series and maximum
+// size of the encoded request. Every request contains the metadata for the
+// series it carries. The series are always sorted to produce the same
+// requests for the same metrics, e.g. when retrying a failed write.
+func (s *Serializer) SerializeBatchSplit(metrics []telegraf.Metric) ([][]byte, error) {
+	promTS, metadata, err := s.convert(metrics)
+	if err != nil {
+		return nil, err
+	}
+	if !s.SortMetrics {
+		sortTimeSeries(promTS)
+	}
+
+	var payloads [][]byte
+	for start := 0; start < len(promTS); {
+		end := len(promTS)
+		if s.MaxTimeseriesPerWrite > 0 {
+			end = min(start+s.MaxTimeseriesPerWrite, end)
+		}
+		chunk, err := s.encodeLimited(promTS[start:end], metadata)
+		if err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, chunk...)
+		start = end
+	}
+
+	return payloads, nil
+}
+
+// encodeLimited encodes the series into a single request and splits the
+// series in halves until each encoded request respects the byte limit. A
+// single series exceeding the limit on its own is sent in a separate request.
+func (s *Serializer) encodeLimited(promTS []prompb.TimeSeries, metadata map[string]prompb.MetricMetadata) ([][]byte, error) {
+	payload, err := encode(promTS, s.metadataFor(promTS, metadata))
+	if err != nil {
+		return nil, err
+	}
+	if s.MaxBytesPerWrite == 0 || int64(len(payload)) <= int64(s.MaxBytesPerWrite) || len(promTS) == 1 {
+		return [][]byte{payload}, nil
+	}
+
+	half := len(promTS) / 2
+	first, err := s.encodeLimited(promTS[:half], metadata)
+	if err != nil {
+		return nil, err
+	}
+	second, err := s.encodeLimited(promTS[half:], metadata)
+	if err != nil {
+		return nil, err
+	}
+	return append(first, second...), nil
+}
+
+func (s *Serializer) convert(metrics []telegraf.Metric) ([]prompb.TimeSeries, map[string]prompb.MetricMetadata, error) {
 	var lastErr error
 	// traceAndKeepErr logs on Trace level every passed error.
 	// with each call it updates lastErr, so it can be logged later with higher level.
@@ -38,8 +119,8 @@ func (s *Serializer) SerializeBatch(metrics []telegraf.Metric) ([]byte, error) {
 		s.Log.Trace(lastErr)
 	}
 
-	var buf bytes.Buffer
 	var entries = make(map[MetricKey]prompb.TimeSeries)
+	var metadata = make(map[string]prompb.MetricMetadata)
 	var labels = make([]prompb.Label, 0)
 	for _, metric := range metrics {
 		labels = s.appendCommonLabels(labels[:0], metric)
@@ -179,7 +260,7 @@ func (s *Serializer) SerializeBatch(metrics []telegraf.Metric) ([]byte, error) {
 					metrickey, promts = getPromTS(metricName, labels, value, metric.Time(), extraLabel)
 				}
 			default:
-				return nil, fmt.Errorf("unknown type %v", metric.Type())
+				return nil, nil, fmt.Errorf("unknown type %v", metric.Type())
 			}
 
 			// A batch of metrics can contain multiple values for a single
@@ -193,6 +274,16 @@ func (s *Serializer) SerializeBatch(metrics []telegraf.Metric) ([]byte, error) {
 				}
 			}
 			entries[metrickey] = promts
+
+			if s.SendMetadata {
+				if _, found := metadata[metricName]; !found {
+					metadata[metricName] = prompb.MetricMetadata{
+						Type:             metadataType(metric.Type()),
+						MetricFamilyName: metricName,
+						Help:             metadataHelp,
+					}
+				}
+			}
 		}
 	}
 
@@ -210,37 +301,97 @@ func (s *Serializer) SerializeBatch(metrics []telegraf.Metric) ([]byte, error) {
 	}
 
 	if s.SortMetrics {
-		sort.Slice(promTS, func(i, j int) bool {
-			lhs := promTS[i].Labels
-			rhs := promTS[j].Labels
-			if len(lhs) != len(rhs) {
-				return len(lhs) < len(rhs)
+		sortTimeSeries(promTS)
+	}
+
+	return promTS, metadata, nil
+}
+
+func sortTimeSeries(promTS []prompb.TimeSeries) {
+	sort.Slice(promTS, func(i, j int) bool {
+		lhs := promTS[i].Labels
+		rhs := promTS[j].Labels
+		if len(lhs) != len(rhs) {
+			return len(lhs) < len(rhs)
+		}
+
+		for index := range lhs {
+			l := lhs[index]
+			r := rhs[index]
+
+			if l.Name != r.Name {
+				return l.Name < r.Name
 			}
 
-			for index := range lhs {
-				l := lhs[index]
-				r := rhs[index]
+			if l.Value != r.Value {
+				return l.Value < r.Value
+			}
+		}
 
-				if l.Name != r.Name {
-					return l.Name < r.Name
-				}
+		return false
+	})
+}
+
+// metadataFor returns the sorted metadata of all metric families contained
+// in the given series
+func (s *Serializer) metadataFor(promTS []prompb.TimeSeries, metadata map[string]prompb.MetricMetadata) []prompb.MetricMetadata {
+	if !s.SendMetadata {
+		return nil
+	}
 
-				if l.Value != r.Value {
-					return l.Value < r.Value
+	families := make(map[string]bool)
+	for _, ts := range promTS {
+		for _, label := range ts.Labels {
+			if label.Name != "__name__" {
+				continue
+			}
+			if _, found := metadata[label.Value]; found {
+				families[label.Value] = true
+				break
+			}
+			// Histogram and summary series are suffixed with the sample type
+			for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+				name := strings.TrimSuffix(label.Value, suffix)
+				if _, found := metadata[name]; found {
+					families[name] = true
+					break
 				}
 			}
+			break
+		}
+	}
 
-			return false
-		})
+	result := make([]prompb.MetricMetadata, 0, len(families))
+	for name := range families {
+		result = append(result, metadata[name])
 	}
-	pb := &prompb.WriteRequest{Timeseries: promTS}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].MetricFamilyName < result[j].MetricFamilyName
+	})
+	return result
+}
+
+func encode(promTS []prompb.TimeSeries, metadata []prompb.MetricMetadata) ([]byte, error) {
+	pb := &prompb.WriteRequest{Timeseries: promTS, Metadata: metadata}
 	data, err := pb.Marshal()
 	if err != nil {
 		return nil, fmt.Errorf("unable to marshal protobuf: %w", err)
 	}
-	encoded := snappy.Encode(nil, data)
-	buf.Write(encoded)
-	return buf.Bytes(), nil
+	return snappy.Encode(nil, data), nil
+}
+
+func metadataType(vt telegraf.ValueType) prompb.MetricMetadata_MetricType {
+	switch vt {
+	case telegraf.Counter:
+		return prompb.MetricMetadata_COUNTER
+	case telegraf.Gauge:
+		return prompb.MetricMetadata_GAUGE
+	case telegraf.Histogram:
+		return prompb.MetricMetadata_HISTOGRAM
+	case telegraf.Summary:
+		return prompb.MetricMetadata_SUMMARY
+	}
+	return prompb.MetricMetadata_UNKNOWN
 }
 
 func hasLabel(name string, labels []prompb.Label) bool {
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/plugins/serializers"
 	"github.com/influxdata/telegraf/testutil"
 )
@@ -773,6 +774,207 @@ rpc_duration_seconds_sum 17560473
 	}
 }
 
+func TestRemoteWriteSerializeBatchSplit(t *testing.T) {
+	metrics := make([]telegraf.Metric, 0, 10)
+	for i := 0; i < 10; i++ {
+		metrics = append(metrics, testutil.MustMetric(
+			"cpu",
+			map[string]string{
+				"host": "example.org",
+				"cpu":  fmt.Sprintf("cpu%d", i),
+			},
+			map[string]interface{}{
+				"time_idle": 42.0,
+			},
+			time.Unix(0, 0),
+		))
+	}
+
+	tests := []struct {
+		name          string
+		maxBytes      config.Size
+		maxTimeseries int
+		expected      int
+	}{
+		{
+			name:     "no limits",
+			expected: 1,
+		},
+		{
+			name:          "timeseries limit",
+			maxTimeseries: 3,
+			expected:      4,
+		},
+		{
+			name:     "bytes limit on compressed request",
+			maxBytes: 200,
+			expected: 1,
+		},
+		{
+			name:     "bytes limit",
+			maxBytes: 100,
+			expected: 4,
+		},
+		{
+			name:     "series exceeding bytes limit",
+			maxBytes: 10,
+			expected: 10,
+		},
+		{
+			name:          "both limits",
+			maxBytes:      300,
+			maxTimeseries: 3,
+			expected:      4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Serializer{
+				Log:                   &testutil.CaptureLogger{},
+				SortMetrics:           true,
+				MaxBytesPerWrite:      tt.maxBytes,
+				MaxTimeseriesPerWrite: tt.maxTimeseries,
+			}
+			require.NoError(t, s.Init())
+
+			payloads, err := s.SerializeBatchSplit(metrics)
+			require.NoError(t, err)
+			require.Len(t, payloads, tt.expected)
+
+			var series int
+			for _, payload := range payloads {
+				data, err := snappy.Decode(nil, payload)
+				require.NoError(t, err)
+				var req prompb.WriteRequest
+				require.NoError(t, req.Unmarshal(data))
+
+				// A single series exceeding the limit is sent on its own
+				if tt.maxBytes > 0 && len(req.Timeseries) > 1 {
+					require.LessOrEqual(t, len(payload), int(tt.maxBytes))
+				}
+				if tt.maxTimeseries > 0 {
+					require.LessOrEqual(t, len(req.Timeseries), tt.maxTimeseries)
+				}
+				for _, ts := range req.Timeseries {
+					require.IsIncreasing(t, labelNames(ts.Labels))
+				}
+				series += len(req.Timeseries)
+			}
+			require.Equal(t, len(metrics), series)
+		})
+	}
+}
+
+func TestRemoteWriteSerializeInvalidLimits(t *testing.T) {
+	s := &Serializer{MaxBytesPerWrite: -1}
+	require.ErrorContains(t, s.Init(), "invalid 'prometheus_max_bytes_per_write'")
+
+	s = &Serializer{MaxTimeseriesPerWrite: -1}
+	require.ErrorContains(t, s.Init(), "invalid 'prometheus_max_timeseries_per_write'")
+}
+
+func TestRemoteWriteSerializeMetadata(t *testing.T) {
+	metrics := []telegraf.Metric{
+		testutil.MustMetric(
+			"cpu",
+			map[string]string{},
+			map[string]interface{}{
+				"time_idle": 42.0,
+			},
+			time.Unix(0, 0),
+			telegraf.Gauge,
+		),
+		testutil.MustMetric(
+			"http_requests",
+			map[string]string{},
+			map[string]interface{}{
+				"total": 1024,
+			},
+			time.Unix(0, 0),
+			telegraf.Counter,
+		),
+		testutil.MustMetric(
+			"prometheus",
+			map[string]string{},
+			map[string]interface{}{
+				"http_request_duration_seconds_sum":   53423,
+				"http_request_duration_seconds_count": 144320,
+			},
+			time.Unix(0, 0),
+			telegraf.Histogram,
+		),
+		testutil.MustMetric(
+			"disk",
+			map[string]string{},
+			map[string]interface{}{
+				"free": 12345,
+			},
+			time.Unix(0, 0),
+		),
+	}
+
+	expected := []prompb.MetricMetadata{
+		{
+			Type:             prompb.MetricMetadata_GAUGE,
+			MetricFamilyName: "cpu_time_idle",
+			Help:             "Telegraf collected metric",
+		},
+		{
+			Type:             prompb.MetricMetadata_UNKNOWN,
+			MetricFamilyName: "disk_free",
+			Help:             "Telegraf collected metric",
+		},
+		{
+			Type:             prompb.MetricMetadata_HISTOGRAM,
+			MetricFamilyName: "http_request_duration_seconds",
+			Help:             "Telegraf collected metric",
+		},
+		{
+			Type:             prompb.MetricMetadata_COUNTER,
+			MetricFamilyName: "http_requests_total",
+			Help:             "Telegraf collected metric",
+		},
+	}
+
+	s := &Serializer{
+		Log:          &testutil.CaptureLogger{},
+		SortMetrics:  true,
+		SendMetadata: true,
+	}
+	require.NoError(t, s.Init())
+
+	payload, err := s.SerializeBatch(metrics)
+	require.NoError(t, err)
+	data, err := snappy.Decode(nil, payload)
+	require.NoError(t, err)
+	var req prompb.WriteRequest
+	require.NoError(t, req.Unmarshal(data))
+	require.Equal(t, expected, req.Metadata)
+
+	// Each split request must only contain the metadata of its series
+	s.MaxTimeseriesPerWrite = 1
+	payloads, err := s.SerializeBatchSplit(metrics)
+	require.NoError(t, err)
+	require.Len(t, payloads, 6)
+	for _, payload := range payloads {
+		data, err := snappy.Decode(nil, payload)
+		require.NoError(t, err)
+		var req prompb.WriteRequest
+		require.NoError(t, req.Unmarshal(data))
+		require.Len(t, req.Timeseries, 1)
+		require.Len(t, req.Metadata, 1)
+		require.True(t, strings.HasPrefix(req.Timeseries[0].Labels[0].Value, req.Metadata[0].MetricFamilyName))
+	}
+}
+
+func labelNames(labels []prompb.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, l := range labels {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
 func prompbToText(data []byte) ([]byte, error) {
 	var buf = bytes.Buffer{}
 	protobuff, err := snappy.Decode(nil, data)
@@ -3,6 +3,7 @@ package splunkmetric
 import (
 	"encoding/json"
 	"log"
+	"math"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/serializers"
@@ -71,6 +72,7 @@ func (s *Serializer) createMulti(metric telegraf.Metric, dataGroup HECTimeSeries
 	dataGroup.Fields = commonTags.Fields
 
 	// Stuff the metric data into the structure.
+	var count int
 	for _, field := range metric.FieldList() {
 		value, valid := verifyValue(field.Value)
 
@@ -80,6 +82,12 @@ func (s *Serializer) createMulti(metric telegraf.Metric, dataGroup HECTimeSeries
 		}
 
 		dataGroup.Fields["metric_name:"+metric.Name()+"."+field.Key] = value
+		count++
+	}
+
+	// Splunk rejects events without any metric value
+	if count == 0 {
+		return nil, nil
 	}
 
 	// Manage the rest of the event details based upon HEC routing rules
@@ -187,10 +195,14 @@ func (s *Serializer) createObject(metric telegraf.Metric) ([]byte, error) {
 }
 
 func verifyValue(v interface{}) (value interface{}, valid bool) {
-	switch v.(type) {
+	switch fv := v.(type) {
 	case string:
 		valid = false
 		value = v
+	case float64:
+		// JSON does not support these special values
+		valid = !math.IsNaN(fv) && !math.IsInf(fv, 0)
+		value = v
 	case bool:
 		if v == bool(true) {
 			// Store 1 for a "true" value
@@ -1,6 +1,7 @@
 package splunkmetric
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -194,6 +195,86 @@ func TestSerializeMulti(t *testing.T) {
 	require.Equal(t, expS, string(buf))
 }
 
+func TestSerializeMultiBatch(t *testing.T) {
+	metrics := []telegraf.Metric{
+		metric.New(
+			"cpu",
+			map[string]string{"cpu": "cpu0", "host": "server01"},
+			map[string]interface{}{
+				"user":   42.0,
+				"system": 8.0,
+			},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"cpu",
+			map[string]string{"cpu": "cpu1", "host": "server01"},
+			map[string]interface{}{
+				"user":   12.0,
+				"system": math.NaN(),
+			},
+			time.Unix(1, 0),
+		),
+		metric.New(
+			"log",
+			map[string]string{"host": "server01"},
+			map[string]interface{}{
+				"message": "not a metric value",
+			},
+			time.Unix(2, 0),
+		),
+	}
+
+	tests := []struct {
+		name       string
+		hecRouting bool
+		expected   string
+	}{
+		{
+			name: "raw",
+			expected: `{"cpu":"cpu0","metric_name:cpu.system":8,"metric_name:cpu.user":42,"time":0}` +
+				`{"cpu":"cpu1","metric_name:cpu.user":12,"time":1}`,
+		},
+		{
+			name:       "hec routing",
+			hecRouting: true,
+			expected: `{"time":0,"event":"metric","host":"server01","fields":{"cpu":"cpu0","metric_name:cpu.system":8,"metric_name:cpu.user":42}}` +
+				`{"time":1,"event":"metric","host":"server01","fields":{"cpu":"cpu1","metric_name:cpu.user":12}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Serializer{
+				HecRouting:  tt.hecRouting,
+				MultiMetric: true,
+			}
+			buf, err := s.SerializeBatch(metrics)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, string(buf))
+		})
+	}
+}
+
+func TestSerializeInvalidNumbers(t *testing.T) {
+	metrics := []telegraf.Metric{
+		metric.New(
+			"cpu",
+			map[string]string{},
+			map[string]interface{}{
+				"inf":   math.Inf(1),
+				"value": 42.0,
+			},
+			time.Unix(0, 0),
+		),
+	}
+
+	s := &Serializer{}
+	buf, err := s.SerializeBatch(metrics)
+	require.NoError(t, err)
+	require.Equal(t, `{"_value":42,"metric_name":"cpu.value","time":0}`, string(buf))
+}
+
 func TestSerializeBatchHec(t *testing.T) {
 	m := metric.New(
 		"cpu",
@@ -3,49 +3,61 @@ package template
 import (
 	"bytes"
 	"fmt"
+	"strconv"
+	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/serializers"
+	"github.com/influxdata/telegraf/selfstat"
 )
 
 type Serializer struct {
-	Template      string          `toml:"template"`
-	BatchTemplate string          `toml:"batch_template"`
-	Log           telegraf.Logger `toml:"-"`
+	Template       string          `toml:"template"`
+	BatchTemplate  string          `toml:"batch_template"`
+	BatchSeparator string          `toml:"batch_separator"`
+	Alias          string          `toml:"alias"`
+	Log            telegraf.Logger `toml:"-"`
 
 	tmplMetric *template.Template
 	tmplBatch  *template.Template
+	skipped    selfstat.Stat
 }
 
 func (s *Serializer) Init() error {
-	// Setting defaults
-	var err error
+	funcs := sprig.TxtFuncMap()
+	funcs["replaceAll"] = replaceAll
+	funcs["formatTime"] = formatTime
 
-	s.tmplMetric, err = template.New("template").Funcs(sprig.TxtFuncMap()).Parse(s.Template)
+	var err error
+	s.tmplMetric, err = template.New("template").Funcs(funcs).Parse(s.Template)
 	if err != nil {
 		return fmt.Errorf("creating template failed: %w", err)
 	}
-	if s.BatchTemplate == "" {
-		s.BatchTemplate = fmt.Sprintf("{{range .}}%s{{end}}", s.Template)
+	if s.BatchTemplate != "" {
+		s.tmplBatch, err = template.New("batch template").Funcs(funcs).Parse(s.BatchTemplate)
+		if err != nil {
+			return fmt.Errorf("creating batch template failed: %w", err)
+		}
 	}
-	s.tmplBatch, err = template.New("batch template").Funcs(sprig.TxtFuncMap()).Parse(s.BatchTemplate)
-	if err != nil {
-		return fmt.Errorf("creating batch template failed: %w", err)
+
+	// Tag the statistics by alias to distinguish the plugin instances
+	tags := make(map[string]string)
+	if s.Alias != "" {
+		tags["alias"] = s.Alias
 	}
+	s.skipped = selfstat.Register("serializers_template", "skipped", tags)
 	return nil
 }
 
 func (s *Serializer) Serialize(metric telegraf.Metric) ([]byte, error) {
-	metricPlain := metric
-	if wm, ok := metric.(telegraf.UnwrappableMetric); ok {
-		metricPlain = wm.Unwrap()
-	}
-	m, ok := metricPlain.(telegraf.TemplateMetric)
+	m, ok := toTemplateMetric(metric)
 	if !ok {
-		s.Log.Errorf("metric of type %T is not a template metric", metricPlain)
+		s.Log.Errorf("metric of type %T is not a template metric", metric)
+		s.skipped.Incr(1)
 		return nil, nil
 	}
 	var b bytes.Buffer
@@ -53,16 +65,18 @@ func (s *Serializer) Serialize(metric telegraf.Metric) ([]byte, error) {
 	if s.Template != "" {
 		if err := s.tmplMetric.Execute(&b, &m); err != nil {
 			s.Log.Errorf("failed to execute template: %v", err)
+			s.skipped.Incr(1)
 			return nil, nil
 		}
 		return b.Bytes(), nil
 	}
 
 	// The template was defined for a batch of metrics, so wrap the metric into a slice
-	if s.BatchTemplate != "" {
+	if s.tmplBatch != nil {
 		metrics := []telegraf.TemplateMetric{m}
 		if err := s.tmplBatch.Execute(&b, &metrics); err != nil {
 			s.Log.Errorf("failed to execute batch template: %v", err)
+			s.skipped.Incr(1)
 			return nil, nil
 		}
 		return b.Bytes(), nil
@@ -74,25 +88,78 @@ func (s *Serializer) Serialize(metric telegraf.Metric) ([]byte, error) {
 
 func (s *Serializer) SerializeBatch(metrics []telegraf.Metric) ([]byte, error) {
 	newMetrics := make([]telegraf.TemplateMetric, 0, len(metrics))
-
 	for _, metric := range metrics {
-		m, ok := metric.(telegraf.TemplateMetric)
+		m, ok := toTemplateMetric(metric)
 		if !ok {
 			s.Log.Errorf("metric of type %T is not a template metric", metric)
-			return nil, nil
+			s.skipped.Incr(1)
+			continue
 		}
 		newMetrics = append(newMetrics, m)
 	}
 
 	var b bytes.Buffer
-	if err := s.tmplBatch.Execute(&b, &newMetrics); err != nil {
-		s.Log.Errorf("failed to execute batch template: %v", err)
-		return nil, nil
+	if s.tmplBatch != nil {
+		if err := s.tmplBatch.Execute(&b, &newMetrics); err != nil {
+			s.Log.Errorf("failed to execute batch template: %v", err)
+			s.skipped.Incr(int64(len(newMetrics)))
+			return nil, nil
+		}
+		return b.Bytes(), nil
+	}
+
+	// Repeat the metric template for each metric, skipping metrics where
+	// the execution fails
+	var buf bytes.Buffer
+	var written bool
+	for _, m := range newMetrics {
+		buf.Reset()
+		if err := s.tmplMetric.Execute(&buf, &m); err != nil {
+			s.Log.Errorf("failed to execute template for metric %q: %v", m.Name(), err)
+			s.skipped.Incr(1)
+			continue
+		}
+		if written {
+			b.WriteString(s.BatchSeparator)
+		}
+		b.Write(buf.Bytes())
+		written = true
 	}
 
 	return b.Bytes(), nil
 }
 
+func toTemplateMetric(metric telegraf.Metric) (telegraf.TemplateMetric, bool) {
+	metricPlain := metric
+	if wm, ok := metric.(telegraf.UnwrappableMetric); ok {
+		metricPlain = wm.Unwrap()
+	}
+	m, ok := metricPlain.(telegraf.TemplateMetric)
+	return m, ok
+}
+
+// replaceAll replaces all occurrences of old by replacement in s. The
+// argument order allows to use the function in pipelines.
+func replaceAll(old, replacement, s string) string {
+	return strings.ReplaceAll(s, old, replacement)
+}
+
+// formatTime formats the time using the given Go time layout or one of the
+// "unix", "unix_ms", "unix_us" or "unix_ns" keywords
+func formatTime(format string, t time.Time) string {
+	switch format {
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "unix_ms":
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	case "unix_us":
+		return strconv.FormatInt(t.UnixMicro(), 10)
+	case "unix_ns":
+		return strconv.FormatInt(t.UnixNano(), 10)
+	}
+	return t.Format(format)
+}
+
 func init() {
 	serializers.Add("template",
 		func() serializers.Serializer {
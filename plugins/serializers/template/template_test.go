@@ -9,6 +9,7 @@ import (
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
 	"github.com/influxdata/telegraf/plugins/serializers"
+	"github.com/influxdata/telegraf/testutil"
 )
 
 func TestSerializer(t *testing.T) {
@@ -181,6 +182,153 @@ func TestSerializeBatch(t *testing.T) {
 	require.Equal(t, "0: cpu 42\n", string(singleBuf))
 }
 
+func TestSerializeBatchSeparator(t *testing.T) {
+	metrics := []telegraf.Metric{
+		metric.New(
+			"cpu",
+			map[string]string{"host": "server01"},
+			map[string]interface{}{"value": 42.0},
+			time.Unix(100, 0),
+		),
+		metric.New(
+			"mem",
+			map[string]string{"host": "server01"},
+			map[string]interface{}{"value": 23.0},
+			time.Unix(100, 0),
+		),
+	}
+
+	s := &Serializer{
+		Template:       `PUTVAL {{ .Tag "host" }}/{{ .Name }}/gauge interval:10 N:{{ .Field "value" }}`,
+		BatchSeparator: "\n",
+	}
+	require.NoError(t, s.Init())
+	buf, err := s.SerializeBatch(metrics)
+	require.NoError(t, err)
+	require.Equal(t, "PUTVAL server01/cpu/gauge interval:10 N:42\nPUTVAL server01/mem/gauge interval:10 N:23", string(buf))
+}
+
+func TestSerializeBatchSkipFailing(t *testing.T) {
+	metrics := []telegraf.Metric{
+		metric.New(
+			"cpu",
+			map[string]string{},
+			map[string]interface{}{"value": 42.0},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"mem",
+			map[string]string{},
+			map[string]interface{}{"free": 1024},
+			time.Unix(0, 0),
+		),
+		metric.New(
+			"disk",
+			map[string]string{},
+			map[string]interface{}{"value": 23.0},
+			time.Unix(0, 0),
+		),
+	}
+
+	logger := &testutil.CaptureLogger{}
+	s := &Serializer{
+		Template:       `{{ if not (hasKey .Fields "value") }}{{ fail "value missing" }}{{ end }}{{ .Name }} {{ .Field "value" }}`,
+		BatchSeparator: ";",
+		Log:            logger,
+	}
+	require.NoError(t, s.Init())
+	skipped := s.skipped.Get()
+	buf, err := s.SerializeBatch(metrics)
+	require.NoError(t, err)
+	require.Equal(t, "cpu 42;disk 23", string(buf))
+	require.Equal(t, skipped+1, s.skipped.Get())
+	require.Len(t, logger.Errors(), 1)
+	require.Contains(t, logger.Errors()[0], `failed to execute template for metric "mem"`)
+}
+
+func TestSkippedStatisticsPerInstance(t *testing.T) {
+	m := metric.New("cpu", map[string]string{}, map[string]interface{}{"value": 42.0}, time.Unix(0, 0))
+
+	first := &Serializer{Template: `{{ fail "broken" }}`, Alias: "first", Log: testutil.Logger{}}
+	require.NoError(t, first.Init())
+	second := &Serializer{Template: `{{ .Name }}`, Alias: "second", Log: testutil.Logger{}}
+	require.NoError(t, second.Init())
+
+	skippedFirst := first.skipped.Get()
+	skippedSecond := second.skipped.Get()
+	_, err := first.SerializeBatch([]telegraf.Metric{m})
+	require.NoError(t, err)
+	_, err = second.SerializeBatch([]telegraf.Metric{m})
+	require.NoError(t, err)
+
+	require.Equal(t, skippedFirst+1, first.skipped.Get())
+	require.Equal(t, skippedSecond, second.skipped.Get())
+	require.Equal(t, map[string]string{"alias": "first"}, first.skipped.Tags())
+}
+
+func TestInvalidTemplate(t *testing.T) {
+	s := &Serializer{Template: "{{ .Name "}
+	require.ErrorContains(t, s.Init(), "creating template failed")
+
+	s = &Serializer{BatchTemplate: "{{ range . }}"}
+	require.ErrorContains(t, s.Init(), "creating batch template failed")
+}
+
+func TestFunctions(t *testing.T) {
+	m := metric.New(
+		"cpu",
+		map[string]string{"host": "server01.example.org"},
+		map[string]interface{}{"value": 42.0},
+		time.Unix(1704067200, 123456789),
+	)
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "printf",
+			template: `{{ printf "%s=%.2f" .Name (.Field "value") }}`,
+			expected: "cpu=42.00",
+		},
+		{
+			name:     "join",
+			template: `{{ join "/" (list (.Tag "host") .Name) }}`,
+			expected: "server01.example.org/cpu",
+		},
+		{
+			name:     "replaceAll",
+			template: `{{ .Tag "host" | replaceAll "." "_" }}`,
+			expected: "server01_example_org",
+		},
+		{
+			name:     "formatTime unix",
+			template: `{{ formatTime "unix" .Time }}`,
+			expected: "1704067200",
+		},
+		{
+			name:     "formatTime unix_ms",
+			template: `{{ .Time | formatTime "unix_ms" }}`,
+			expected: "1704067200123",
+		},
+		{
+			name:     "formatTime layout",
+			template: `{{ .Time.UTC | formatTime "2006-01-02T15:04:05Z07:00" }}`,
+			expected: "2024-01-01T00:00:00Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Serializer{Template: tt.template}
+			require.NoError(t, s.Init())
+			buf, err := s.Serialize(m)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, string(buf))
+		})
+	}
+}
+
 func BenchmarkSerialize(b *testing.B) {
 	s := &Serializer{}
 	require.NoError(b, s.Init())
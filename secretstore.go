@@ -18,6 +18,21 @@ type SecretStore interface {
 	GetResolver(key string) (ResolveFunc, error)
 }
 
+// SecretInvalidator is an optional interface for secret-stores caching
+// secrets. Invalidate marks the cached value of the given key as outdated,
+// forcing the secret-store to fetch the secret again on the next resolution,
+// e.g. after a plugin reported an authentication failure using the secret.
+type SecretInvalidator interface {
+	Invalidate(key string)
+}
+
+// SecretStoreStopper is an optional interface for secret-stores running
+// background tasks, e.g. for renewing credentials. Stop is called once the
+// secret-store is not used anymore.
+type SecretStoreStopper interface {
+	Stop()
+}
+
 // ResolveFunc is a function to resolve the secret.
 // The returned flag indicates if the resolver is static (false), i.e.
 // the secret will not change over time, or dynamic (true) to handle
@@ -0,0 +1,38 @@
+package selfstat
+
+// funcStat is a stat determining its value by calling the given function when
+// collected, e.g. for values depending on the time of collection
+type funcStat struct {
+	measurement string
+	field       string
+	tags        map[string]string
+	fn          func() int64
+}
+
+// Incr is a no-op as the value is determined by the function
+func (*funcStat) Incr(int64) {}
+
+// Set is a no-op as the value is determined by the function
+func (*funcStat) Set(int64) {}
+
+func (s *funcStat) Get() int64 {
+	return s.fn()
+}
+
+func (s *funcStat) Name() string {
+	return s.measurement
+}
+
+func (s *funcStat) FieldName() string {
+	return s.field
+}
+
+// Tags returns a copy of the funcStat's tags.
+// NOTE this allocates a new map every time it is called.
+func (s *funcStat) Tags() map[string]string {
+	m := make(map[string]string, len(s.tags))
+	for k, v := range s.tags {
+		m[k] = v
+	}
+	return m
+}
@@ -108,6 +108,30 @@ func TestRegisterTimingAndIncrAndSet(t *testing.T) {
 	require.Equal(t, "internal_test", foo.Name())
 }
 
+func TestRegisterFunc(t *testing.T) {
+	testLock.Lock()
+	defer testCleanup()
+	var value int64
+	s1 := RegisterFunc("test", "test_func", map[string]string{"test": "foo"}, func() int64 { return value })
+	require.Equal(t, int64(0), s1.Get())
+
+	value = 42
+	require.Equal(t, int64(42), s1.Get())
+
+	// the value cannot be modified directly
+	s1.Incr(10)
+	s1.Set(12)
+	require.Equal(t, int64(42), s1.Get())
+
+	// make sure that the same field returns the same metric
+	foo := RegisterFunc("test", "test_func", map[string]string{"test": "foo"}, func() int64 { return 0 })
+	require.Equal(t, int64(42), foo.Get())
+
+	// check that tags are consistent
+	require.Equal(t, map[string]string{"test": "foo"}, foo.Tags())
+	require.Equal(t, "internal_test", foo.Name())
+}
+
 func TestStatKeyConsistency(t *testing.T) {
 	lhs := key("internal_stats", map[string]string{
 		"foo":   "bar",
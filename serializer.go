@@ -20,6 +20,24 @@ type Serializer interface {
 	SerializeBatch(metrics []Metric) ([]byte, error)
 }
 
+// HeaderSerializer is an optional interface for serializers emitting a header
+// with the first serialized batch only, e.g. column names.
+type HeaderSerializer interface {
+	// ResetHeader requests the header to be emitted again with the next
+	// serialized batch. Outputs should call this function when starting to
+	// write to a new destination, e.g. after rotating a file.
+	ResetHeader()
+}
+
+// SplitSerializer is an optional interface for serializers able to split a
+// batch of metrics into multiple self-contained payloads, e.g. to respect the
+// request size limits of the receiving endpoint.
+type SplitSerializer interface {
+	// SerializeBatchSplit takes an array of telegraf metric and serializes
+	// them into one or more payloads, each to be sent separately.
+	SerializeBatchSplit(metrics []Metric) ([][]byte, error)
+}
+
 // SerializerFunc is a function to create a new instance of a serializer
 type SerializerFunc func() (Serializer, error)
 